@@ -0,0 +1,38 @@
+# These tests need a mock API server serving a generated package, a Docker daemon to load it into and a registry to
+# push it to, so they are not meant to be run directly. Run them with the integration test instead:
+#
+#   go test -tags integration ./tools/k8sintegration
+#
+# The test sets the SINGULARITY_API_ENDPOINT and SINGULARITY_API_TOKEN environment variables along with the
+# TF_VAR_* variables used below.
+run "downloads_package" {
+  command = apply
+
+  assert {
+    condition     = singularity_package_download.k8s_agent.version == "23.3.1.1"
+    error_message = "The package version was not read from the mock API server."
+  }
+
+  assert {
+    condition     = singularity_package_download.k8s_agent.file_size > 0
+    error_message = "The package file was not downloaded."
+  }
+}
+
+run "loads_agent_and_helper_images" {
+  command = apply
+
+  assert {
+    condition     = output.image_purposes == ["agent", "helper"]
+    error_message = "The agent and helper images were not both loaded from the package."
+  }
+}
+
+run "pushes_agent_and_helper_images" {
+  command = apply
+
+  assert {
+    condition     = output.pushed_purposes == ["agent", "helper"]
+    error_message = "The agent and helper images were not both pushed to the registry."
+  }
+}
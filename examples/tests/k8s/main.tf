@@ -0,0 +1,71 @@
+# Example module which downloads a Singularity Agent for Kubernetes package, loads its images into Docker and pushes
+# them to a registry. The test in this directory is driven by tools/k8sintegration, which serves a generated package
+# from the mock API server and provides a throwaway Docker daemon and registry.
+terraform {
+  required_providers {
+    singularity = {
+      source = "joshhogle-at-s1/sentinelone-singularity"
+    }
+  }
+}
+
+variable "docker_host" {
+  type        = string
+  description = "URL of the Docker host into which the package images are loaded."
+}
+
+variable "local_folder" {
+  type        = string
+  description = "Folder into which the package is downloaded."
+}
+
+variable "package_id" {
+  type        = string
+  description = "ID of the package to download."
+}
+
+variable "registry" {
+  type        = string
+  description = "Address of the plain HTTP registry to which the package images are pushed."
+}
+
+variable "repo_path" {
+  type        = string
+  description = "Repository path within the registry in which to store the package images."
+}
+
+variable "site_id" {
+  type        = string
+  description = "ID of the site from which to download the package."
+}
+
+resource "singularity_package_download" "k8s_agent" {
+  local_filename = "k8s-agent.tar"
+  local_folder   = var.local_folder
+  package_id     = var.package_id
+  site_id        = var.site_id
+}
+
+resource "singularity_k8s_agent_package_loader" "k8s_agent" {
+  docker_host  = var.docker_host
+  package_file = singularity_package_download.k8s_agent.output_file
+}
+
+resource "singularity_k8s_agent_registry_copy" "k8s_agent" {
+  insecure     = true
+  package_file = singularity_package_download.k8s_agent.output_file
+  registry     = var.registry
+  repo_path    = var.repo_path
+}
+
+output "package_file" {
+  value = singularity_package_download.k8s_agent.output_file
+}
+
+output "image_purposes" {
+  value = sort([for image in singularity_k8s_agent_package_loader.k8s_agent.images : image.purpose])
+}
+
+output "pushed_purposes" {
+  value = sort([for image in singularity_k8s_agent_registry_copy.k8s_agent.pushed_images : image.purpose])
+}
@@ -0,0 +1,33 @@
+# Start the mock API server before running these tests:
+#
+#   go run ./tools/mockapi -fixtures examples/tests/fixtures -listen 127.0.0.1:8080
+#
+# Then run "terraform test" from the examples/tests directory. Make sure the SINGULARITY_API_ENDPOINT and
+# SINGULARITY_API_TOKEN environment variables are not set as they take precedence over the provider block below.
+provider "singularity" {
+  api_endpoint = "http://127.0.0.1:8080"
+  api_token    = "mock-api-token"
+}
+
+run "finds_default_site" {
+  command = plan
+
+  assert {
+    condition     = output.default_site_id == "1000000000000000010"
+    error_message = "The default site was not returned by the mock API server."
+  }
+
+  assert {
+    condition     = output.default_site_free_licenses == 88
+    error_message = "The number of free licenses was not calculated from the site's license counts."
+  }
+}
+
+run "finds_default_group" {
+  command = plan
+
+  assert {
+    condition     = output.default_group_id == "1000000000000000100"
+    error_message = "The default group of the default site was not returned by the mock API server."
+  }
+}
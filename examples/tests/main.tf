@@ -0,0 +1,35 @@
+# Example module which wraps the provider. The tests in this directory show how to exercise a module like this
+# against the mock API server in tools/mockapi instead of a real console.
+terraform {
+  required_providers {
+    singularity = {
+      source = "joshhogle-at-s1/sentinelone-singularity"
+    }
+  }
+}
+
+data "singularity_sites" "default" {
+  filter {
+    is_default = true
+    states     = ["active"]
+  }
+}
+
+data "singularity_groups" "default" {
+  filter {
+    is_default = true
+    site_ids   = [data.singularity_sites.default.sites[0].id]
+  }
+}
+
+output "default_site_id" {
+  value = data.singularity_sites.default.sites[0].id
+}
+
+output "default_site_free_licenses" {
+  value = data.singularity_sites.default.sites[0].total_licenses - data.singularity_sites.default.sites[0].active_licenses
+}
+
+output "default_group_id" {
+  value = data.singularity_groups.default.groups[0].id
+}
@@ -0,0 +1,262 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ACCOUNT_LICENSE_SURFACE is the name of the license surface used to hold the number of licensed agents.
+const ACCOUNT_LICENSE_SURFACE = "Total Agents"
+
+// Account defines the API model for an account.
+type Account struct {
+	AccountType         string      `json:"accountType"`
+	ActiveAgents        int         `json:"activeAgents"`
+	BillingMode         string      `json:"billingMode"`
+	CreatedAt           string      `json:"createdAt"`
+	Creator             string      `json:"creator"`
+	CreatorId           string      `json:"creatorId"`
+	Expiration          string      `json:"expiration"`
+	ExternalId          string      `json:"externalId"`
+	Id                  string      `json:"id"`
+	IsDefault           bool        `json:"isDefault"`
+	Licenses            SiteLicense `json:"licenses"`
+	Name                string      `json:"name"`
+	NumberOfSites       int         `json:"numberOfSites"`
+	RegistrationToken   string      `json:"registrationToken"`
+	SalesforceId        string      `json:"salesforceId"`
+	State               string      `json:"state"`
+	TotalLicenses       int         `json:"totalLicenses"`
+	UnlimitedExpiration bool        `json:"unlimitedExpiration"`
+	UnlimitedLicenses   bool        `json:"unlimitedLicenses"`
+	UpdatedAt           string      `json:"updatedAt"`
+	UsageType           string      `json:"usageType"`
+}
+
+// AccountBody is used to hold the attributes used for creating or updating an account.
+type AccountBody struct {
+	AccountType         *string  `json:"accountType"`
+	Expiration          *string  `json:"expiration"`
+	ExternalId          *string  `json:"externalId"`
+	Modules             []string `json:"modules"`
+	Name                *string  `json:"name"`
+	SalesforceId        *string  `json:"salesforceId"`
+	SKU                 *string  `json:"sku"`
+	TotalLicenses       *int64   `json:"totalLicenses"`
+	UnlimitedExpiration *bool    `json:"unlimitedExpiration"`
+	UnlimitedLicenses   *bool    `json:"unlimitedLicenses"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *AccountBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.AccountType != nil {
+		body["accountType"] = *b.AccountType
+	}
+	if b.Expiration != nil {
+		body["expiration"] = *b.Expiration
+	}
+	if b.ExternalId != nil {
+		body["externalId"] = *b.ExternalId
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.SalesforceId != nil {
+		body["salesforceId"] = *b.SalesforceId
+	}
+	if b.UnlimitedExpiration != nil {
+		body["unlimitedExpiration"] = *b.UnlimitedExpiration
+	}
+	if b.UnlimitedLicenses != nil {
+		body["unlimitedLicenses"] = *b.UnlimitedLicenses
+	}
+
+	// licenses are made up of a single bundle (the SKU) and any number of add-on modules
+	if b.SKU != nil || b.Modules != nil {
+		licenses := map[string]interface{}{}
+		if b.SKU != nil {
+			bundle := map[string]interface{}{
+				"name": *b.SKU,
+			}
+			if b.TotalLicenses != nil {
+				bundle["surfaces"] = []map[string]interface{}{
+					{
+						"name":  ACCOUNT_LICENSE_SURFACE,
+						"count": *b.TotalLicenses,
+					},
+				}
+			}
+			licenses["bundles"] = []map[string]interface{}{bundle}
+		}
+		if b.Modules != nil {
+			modules := []map[string]interface{}{}
+			for _, m := range b.Modules {
+				modules = append(modules, map[string]interface{}{"name": m})
+			}
+			licenses["modules"] = modules
+		}
+		body["licenses"] = licenses
+	}
+	return map[string]interface{}{
+		"data": body,
+	}
+}
+
+// CreateAccount creates a new account with the given attributes and returns the new account.
+func (c *client) CreateAccount(ctx context.Context, body AccountBody) (*Account, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/accounts", body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var account Account
+	if err := c.unmarshal(result.Data, &account); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"Account object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_ACCOUNT_CREATE_ACCOUNT,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &account, diags
+}
+
+// ExpireAccount immediately expires the account with the matching ID.
+//
+// Accounts cannot be removed using the API. Once expired, the agents in the account are decommissioned and the
+// account can no longer be used.
+func (c *client) ExpireAccount(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Post(ctx, fmt.Sprintf("/accounts/%s/expire-now", id), map[string]interface{}{})
+	return diags
+}
+
+// FindAccounts returns a list of accounts found based on the given query parameters.
+func (c *client) FindAccounts(ctx context.Context, queryParams AccountQueryParams) ([]Account, diag.Diagnostics) {
+	var accounts []Account
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/accounts", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Account
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Account objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_ACCOUNT_FIND_ACCOUNTS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		accounts = append(accounts, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return accounts, diags
+}
+
+// UpdateAccount updates the account with the matching ID using the given attributes and returns the updated
+// account.
+func (c *client) UpdateAccount(ctx context.Context, id string, body AccountBody) (*Account, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/accounts/%s", id), body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var account Account
+	if err := c.unmarshal(result.Data, &account); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"Account object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_ACCOUNT_UPDATE_ACCOUNT,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &account, diags
+}
+
+// AccountQueryParams is used to hold query parameters for finding accounts.
+type AccountQueryParams struct {
+	AccountIds    []string `json:"ids"`
+	AccountType   *string  `json:"accountType"`
+	ExpiresAfter  *string  `json:"expiration__gt"`
+	ExpiresBefore *string  `json:"expiration__lt"`
+	ExternalId    *string  `json:"externalId"`
+	Features      []string `json:"features"`
+	IsDefault     *bool    `json:"isDefault"`
+	Name          *string  `json:"name"`
+	NameContains  []string `json:"name__contains"`
+	Query         *string  `json:"query"`
+	SortBy        *string  `json:"sortBy"`
+	SortOrder     *string  `json:"sortOrder"`
+	States        []string `json:"states"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *AccountQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["ids"] = strings.Join(p.AccountIds, ",")
+	}
+	if p.AccountType != nil {
+		queryString["accountType"] = *p.AccountType
+	}
+	if p.ExpiresAfter != nil {
+		queryString["expiration__gt"] = *p.ExpiresAfter
+	}
+	if p.ExpiresBefore != nil {
+		queryString["expiration__lt"] = *p.ExpiresBefore
+	}
+	if p.ExternalId != nil {
+		queryString["externalId"] = *p.ExternalId
+	}
+	if len(p.Features) > 0 {
+		queryString["features"] = strings.Join(p.Features, ",")
+	}
+	if p.IsDefault != nil {
+		queryString["isDefault"] = fmt.Sprintf("%t", *p.IsDefault)
+	}
+	if p.Name != nil {
+		queryString["name"] = *p.Name
+	}
+	if len(p.NameContains) > 0 {
+		queryString["name__contains"] = strings.Join(p.NameContains, ",")
+	}
+	if p.Query != nil {
+		queryString["query"] = *p.Query
+	}
+	if p.SortBy != nil {
+		queryString["sortBy"] = *p.SortBy
+	}
+	if p.SortOrder != nil {
+		queryString["sortOrder"] = *p.SortOrder
+	}
+	if len(p.States) > 0 {
+		queryString["states"] = strings.Join(p.States, ",")
+	}
+	return queryString
+}
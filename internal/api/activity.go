@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ACTIVITY_TYPE_AGENT_LOGS_UPLOADED is the activity type logged once an agent has uploaded the log bundle requested
+// by a fetch logs action.
+const ACTIVITY_TYPE_AGENT_LOGS_UPLOADED = 90
+
+// Activity defines the API model for an activity (ie: an entry in the console's activity log).
+type Activity struct {
+	AccountId          string       `json:"accountId"`
+	ActivityType       int          `json:"activityType"`
+	AgentId            string       `json:"agentId"`
+	CreatedAt          string       `json:"createdAt"`
+	Data               activityData `json:"data"`
+	Id                 string       `json:"id"`
+	PrimaryDescription string       `json:"primaryDescription"`
+	SiteId             string       `json:"siteId"`
+	UpdatedAt          string       `json:"updatedAt"`
+}
+
+// activityData holds the details of an activity which are relevant to the provider.
+type activityData struct {
+	ComputerName     string `json:"computerName"`
+	DownloadUrl      string `json:"downloadUrl"`
+	FilePath         string `json:"filePath"`
+	UploadedFilename string `json:"uploadedFilename"`
+}
+
+// DownloadAgentUpload streams a file uploaded by an agent (eg: a log bundle) for the activity with the given ID to a
+// local file and returns the absolute path and size of the file.
+//
+// The file is staged in a temporary folder alongside the destination and only moved into place once it is complete.
+func (c *client) DownloadAgentUpload(ctx context.Context, agentId, activityId, path, folderMode, fileMode string,
+	overwrite bool) (string, int64, diag.Diagnostics) {
+
+	// convert the path to an absolute path
+	absPath, diags := plugin.ToAbsolutePath(ctx, path)
+	if diags.HasError() {
+		return "", 0, diags
+	}
+	ctx = tflog.SetField(ctx, "file", absPath)
+
+	// create the temporary file for writing
+	tempDir, diags := plugin.NewTempDir(ctx, "agent-upload", filepath.Dir(absPath), folderMode)
+	if diags.HasError() {
+		return "", 0, diags
+	}
+	defer tempDir.Cleanup(ctx)
+	filename := filepath.Base(absPath)
+	outfile, diags := tempDir.CreateFile(ctx, filename)
+	if diags.HasError() {
+		return "", 0, diags
+	}
+
+	// stream the upload into the temporary file
+	diags = c.GetStream(ctx, AgentUploadURI(agentId, activityId), map[string]string{}, outfile)
+	outfile.Close()
+	if diags.HasError() {
+		return "", 0, diags
+	}
+
+	// get the size of the file and move it into place
+	fileInfo, err := os.Stat(tempDir.Path(filename))
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while retrieving information about the uploaded file.\n\n"+
+			"Error: %s\nFile: %s", err.Error(), tempDir.Path(filename))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_ACTIVITY_DOWNLOAD_AGENT_UPLOAD,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return "", 0, diags
+	}
+	diags = tempDir.Commit(ctx, filename, absPath, folderMode, fileMode, overwrite)
+	if diags.HasError() {
+		return "", 0, diags
+	}
+	return absPath, fileInfo.Size(), diags
+}
+
+// FindActivities returns a list of activities found based on the given query parameters.
+func (c *client) FindActivities(ctx context.Context, queryParams ActivityQueryParams) ([]Activity,
+	diag.Diagnostics) {
+
+	var activities []Activity
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/activities", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Activity
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Activity objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_ACTIVITY_FIND_ACTIVITIES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		activities = append(activities, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return activities, diags
+}
+
+// AgentUploadURI returns the URI, relative to the API base URI, from which a file uploaded by an agent for the
+// activity with the given ID can be downloaded.
+func AgentUploadURI(agentId, activityId string) string {
+	return fmt.Sprintf("/agents/%s/uploads/%s", agentId, activityId)
+}
+
+// ActivityQueryParams is used to hold query parameters for finding activities.
+type ActivityQueryParams struct {
+	ActivityTypes []int    `json:"activityTypes"`
+	AgentIds      []string `json:"agentIds"`
+	CreatedAfter  *string  `json:"createdAt__gt"`
+	SortBy        *string  `json:"sortBy"`
+	SortOrder     *string  `json:"sortOrder"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *ActivityQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.ActivityTypes) > 0 {
+		activityTypes := []string{}
+		for _, t := range p.ActivityTypes {
+			activityTypes = append(activityTypes, fmt.Sprintf("%d", t))
+		}
+		queryString["activityTypes"] = strings.Join(activityTypes, ",")
+	}
+	if len(p.AgentIds) > 0 {
+		queryString["agentIds"] = strings.Join(p.AgentIds, ",")
+	}
+	if p.CreatedAfter != nil {
+		queryString["createdAt__gt"] = *p.CreatedAfter
+	}
+	if p.SortBy != nil {
+		queryString["sortBy"] = *p.SortBy
+	}
+	if p.SortOrder != nil {
+		queryString["sortOrder"] = *p.SortOrder
+	}
+	return queryString
+}
@@ -0,0 +1,672 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Agent defines the API model for an agent.
+type Agent struct {
+	AccountId                string                  `json:"accountId"`
+	AccountName              string                  `json:"accountName"`
+	ActiveThreats            int                     `json:"activeThreats"`
+	AgentVersion             string                  `json:"agentVersion"`
+	ComputerName             string                  `json:"computerName"`
+	CoreCount                int                     `json:"coreCount"`
+	CpuCount                 int                     `json:"cpuCount"`
+	CreatedAt                string                  `json:"createdAt"`
+	Domain                   string                  `json:"domain"`
+	ExternalId               string                  `json:"externalId"`
+	ExternalIp               string                  `json:"externalIp"`
+	GroupId                  string                  `json:"groupId"`
+	GroupName                string                  `json:"groupName"`
+	Id                       string                  `json:"id"`
+	Infected                 bool                    `json:"infected"`
+	IsActive                 bool                    `json:"isActive"`
+	IsDecommissioned         bool                    `json:"isDecommissioned"`
+	IsUninstalled            bool                    `json:"isUninstalled"`
+	IsUpToDate               bool                    `json:"isUpToDate"`
+	LastActiveDate           string                  `json:"lastActiveDate"`
+	LastIpToMgmt             string                  `json:"lastIpToMgmt"`
+	LastLoggedInUserName     string                  `json:"lastLoggedInUserName"`
+	MachineType              string                  `json:"machineType"`
+	MitigationMode           string                  `json:"mitigationMode"`
+	MitigationModeSuspicious string                  `json:"mitigationModeSuspicious"`
+	ModelName                string                  `json:"modelName"`
+	NetworkInterfaces        []AgentNetworkInterface `json:"networkInterfaces"`
+	NetworkStatus            string                  `json:"networkStatus"`
+	OSArch                   string                  `json:"osArch"`
+	OSName                   string                  `json:"osName"`
+	OSRevision               string                  `json:"osRevision"`
+	OSStartTime              string                  `json:"osStartTime"`
+	OSType                   string                  `json:"osType"`
+	OSUsername               string                  `json:"osUsername"`
+	RegisteredAt             string                  `json:"registeredAt"`
+	SiteId                   string                  `json:"siteId"`
+	SiteName                 string                  `json:"siteName"`
+	Tags                     agentTags               `json:"tags"`
+	TotalMemory              int                     `json:"totalMemory"`
+	UpdatedAt                string                  `json:"updatedAt"`
+	UUID                     string                  `json:"uuid"`
+}
+
+// AgentNetworkInterface defines the API model for a network interface on an agent's endpoint.
+type AgentNetworkInterface struct {
+	Id       string   `json:"id"`
+	Inet     []string `json:"inet"`
+	Inet6    []string `json:"inet6"`
+	Name     string   `json:"name"`
+	Physical string   `json:"physical"`
+}
+
+// AgentPassphrase defines the API model for the passphrase used to uninstall an agent locally.
+type AgentPassphrase struct {
+	ComputerName string `json:"computerName"`
+	Id           string `json:"id"`
+	Passphrase   string `json:"passphrase"`
+	UUID         string `json:"uuid"`
+}
+
+// agentTags holds the tags assigned to an agent.
+type agentTags struct {
+	SentinelOne []AgentTag `json:"sentinelone"`
+}
+
+// AgentTag defines the API model for a tag assigned to an agent.
+type AgentTag struct {
+	Id    string `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ConnectAgents reconnects all agents matching the given filter to the network and returns the number of agents
+// affected.
+func (c *client) ConnectAgents(ctx context.Context, filter AgentQueryParams) (int, diag.Diagnostics) {
+	return c.agentAction(ctx, "connect", filter, plugin.ERR_API_AGENT_CONNECT_AGENTS)
+}
+
+// DisconnectAgents disconnects all agents matching the given filter from the network (network quarantine) and
+// returns the number of agents affected.
+func (c *client) DisconnectAgents(ctx context.Context, filter AgentQueryParams) (int, diag.Diagnostics) {
+	return c.agentAction(ctx, "disconnect", filter, plugin.ERR_API_AGENT_DISCONNECT_AGENTS)
+}
+
+// ExportAgents streams an export of all agents matching the given query parameters in the given format (csv or json)
+// to a local file and returns the absolute path and size of the file.
+//
+// The export is staged in a temporary folder alongside the destination and only moved into place once it is complete.
+func (c *client) ExportAgents(ctx context.Context, queryParams AgentQueryParams, format, path, folderMode,
+	fileMode string, overwrite bool) (string, int64, diag.Diagnostics) {
+
+	// convert the path to an absolute path
+	absPath, diags := plugin.ToAbsolutePath(ctx, path)
+	if diags.HasError() {
+		return "", 0, diags
+	}
+	ctx = tflog.SetField(ctx, "file", absPath)
+
+	// create the temporary file for writing
+	tempDir, diags := plugin.NewTempDir(ctx, "agents-export", filepath.Dir(absPath), folderMode)
+	if diags.HasError() {
+		return "", 0, diags
+	}
+	defer tempDir.Cleanup(ctx)
+	filename := filepath.Base(absPath)
+	outfile, diags := tempDir.CreateFile(ctx, filename)
+	if diags.HasError() {
+		return "", 0, diags
+	}
+
+	// stream the export into the temporary file
+	diags = c.GetStream(ctx, fmt.Sprintf("/agents/export/%s", format), queryParams.toStringMap(), outfile)
+	outfile.Close()
+	if diags.HasError() {
+		return "", 0, diags
+	}
+
+	// get the size of the export and move it into place
+	fileInfo, err := os.Stat(tempDir.Path(filename))
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while retrieving information about the export file.\n\n"+
+			"Error: %s\nFile: %s", err.Error(), tempDir.Path(filename))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_AGENT_EXPORT_AGENTS,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return "", 0, diags
+	}
+	diags = tempDir.Commit(ctx, filename, absPath, folderMode, fileMode, overwrite)
+	if diags.HasError() {
+		return "", 0, diags
+	}
+	return absPath, fileInfo.Size(), diags
+}
+
+// FindAgents returns a list of agents found based on the given query parameters.
+func (c *client) FindAgents(ctx context.Context, queryParams AgentQueryParams) ([]Agent, diag.Diagnostics) {
+	var agents []Agent
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/agents", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Agent
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Agent objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_AGENT_FIND_AGENTS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		agents = append(agents, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return agents, diags
+}
+
+// FindAgentPassphrases returns the passphrases of the agents found based on the given query parameters.
+func (c *client) FindAgentPassphrases(ctx context.Context, queryParams AgentQueryParams) ([]AgentPassphrase,
+	diag.Diagnostics) {
+
+	var passphrases []AgentPassphrase
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/agents/passphrases", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []AgentPassphrase
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of AgentPassphrase objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_AGENT_FIND_PASSPHRASES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		passphrases = append(passphrases, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return passphrases, diags
+}
+
+// FetchAgentLogs requests that all agents matching the given filter upload a log bundle to the console and returns
+// the number of agents affected. Platform logs are included in the bundle if platformLogs is true.
+func (c *client) FetchAgentLogs(ctx context.Context, filter AgentQueryParams, platformLogs bool) (int,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/agents/actions/fetch-logs", map[string]interface{}{
+		"filter": filter.toFilter(),
+		"data": map[string]interface{}{
+			"agentLogs":    true,
+			"platformLogs": platformLogs,
+		},
+	})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// parse the data returned
+	var action actionResult
+	if err := c.unmarshal(result.Data, &action); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_AGENT_FETCH_LOGS,
+		})
+		diags.AddError("API Response Error", msg)
+		return 0, diags
+	}
+	return action.Affected, diags
+}
+
+// InitiateScan starts a full disk scan on all agents matching the given filter and returns the number of agents
+// affected.
+func (c *client) InitiateScan(ctx context.Context, filter AgentQueryParams) (int, diag.Diagnostics) {
+	return c.agentAction(ctx, "initiate-scan", filter, plugin.ERR_API_AGENT_INITIATE_SCAN)
+}
+
+// ManageAgentTags adds and removes the tags with the given IDs on all agents matching the given filter and returns
+// the number of agents affected.
+func (c *client) ManageAgentTags(ctx context.Context, filter AgentQueryParams, addTagIds, removeTagIds []string) (
+	int, diag.Diagnostics) {
+
+	// build the list of operations
+	operations := []map[string]interface{}{}
+	for _, id := range addTagIds {
+		operations = append(operations, map[string]interface{}{
+			"operation": "add",
+			"tagId":     id,
+		})
+	}
+	for _, id := range removeTagIds {
+		operations = append(operations, map[string]interface{}{
+			"operation": "remove",
+			"tagId":     id,
+		})
+	}
+
+	// query the API
+	result, diags := c.Post(ctx, "/agents/actions/manage-tags", map[string]interface{}{
+		"filter": filter.toFilter(),
+		"data":   operations,
+	})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// parse the data returned
+	var action actionResult
+	if err := c.unmarshal(result.Data, &action); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_AGENT_MANAGE_TAGS,
+		})
+		diags.AddError("API Response Error", msg)
+		return 0, diags
+	}
+	return action.Affected, diags
+}
+
+// MoveAgentsToGroup moves all agents matching the given filter to the group with the matching ID and returns the
+// number of agents affected.
+//
+// The agents must already belong to the group's site.
+func (c *client) MoveAgentsToGroup(ctx context.Context, filter AgentQueryParams, groupId string) (int,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/groups/%s/move-agents", groupId), map[string]interface{}{
+		"filter": filter.toFilter(),
+	})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// parse the data returned
+	var action actionResult
+	if err := c.unmarshal(result.Data, &action); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_AGENT_MOVE_TO_GROUP,
+		})
+		diags.AddError("API Response Error", msg)
+		return 0, diags
+	}
+	return action.Affected, diags
+}
+
+// MoveAgentsToSite moves all agents matching the given filter to the default group of the site with the matching ID
+// and returns the number of agents affected.
+func (c *client) MoveAgentsToSite(ctx context.Context, filter AgentQueryParams, siteId string) (int,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/agents/actions/move-to-site", map[string]interface{}{
+		"filter": filter.toFilter(),
+		"data": map[string]interface{}{
+			"targetSiteId": siteId,
+		},
+	})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// parse the data returned
+	var action actionResult
+	if err := c.unmarshal(result.Data, &action); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_AGENT_MOVE_TO_SITE,
+		})
+		diags.AddError("API Response Error", msg)
+		return 0, diags
+	}
+	return action.Affected, diags
+}
+
+// SetAgentExternalId sets the external ID (a free-text annotation) on all agents matching the given filter and
+// returns the number of agents affected.
+func (c *client) SetAgentExternalId(ctx context.Context, filter AgentQueryParams, externalId string) (int,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/agents/actions/set-external-id", map[string]interface{}{
+		"filter": filter.toFilter(),
+		"data": map[string]interface{}{
+			"externalId": externalId,
+		},
+	})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// parse the data returned
+	var action actionResult
+	if err := c.unmarshal(result.Data, &action); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_AGENT_SET_EXTERNAL_ID,
+		})
+		diags.AddError("API Response Error", msg)
+		return 0, diags
+	}
+	return action.Affected, diags
+}
+
+// UninstallAgents sends an uninstall command to all agents matching the given filter and returns the number of agents
+// affected.
+func (c *client) UninstallAgents(ctx context.Context, filter AgentQueryParams) (int, diag.Diagnostics) {
+	return c.agentAction(ctx, "uninstall", filter, plugin.ERR_API_AGENT_UNINSTALL_AGENTS)
+}
+
+// UpgradeAgents sends a command to upgrade all agents matching the given filter to the package with the given ID and
+// returns the number of agents affected.
+//
+// If isScheduled is true, the upgrade is applied according to the upgrade schedule configured for each agent's scope
+// rather than immediately.
+func (c *client) UpgradeAgents(ctx context.Context, filter AgentQueryParams, packageId string, isScheduled bool) (int,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/agents/actions/update-software", map[string]interface{}{
+		"filter": filter.toFilter(),
+		"data": map[string]interface{}{
+			"packageId":   packageId,
+			"isScheduled": isScheduled,
+		},
+	})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// parse the data returned
+	var action actionResult
+	if err := c.unmarshal(result.Data, &action); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_AGENT_UPGRADE_AGENTS,
+		})
+		diags.AddError("API Response Error", msg)
+		return 0, diags
+	}
+	return action.Affected, diags
+}
+
+// agentAction executes the given action on all agents matching the given filter and returns the number of agents
+// affected.
+func (c *client) agentAction(ctx context.Context, action string, filter AgentQueryParams, errorCode int) (int,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, fmt.Sprintf("/agents/actions/%s", action), map[string]interface{}{
+		"filter": filter.toFilter(),
+	})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// parse the data returned
+	var actionResult actionResult
+	if err := c.unmarshal(result.Data, &actionResult); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": errorCode,
+		})
+		diags.AddError("API Response Error", msg)
+		return 0, diags
+	}
+	return actionResult.Affected, diags
+}
+
+// AgentQueryParams is used to hold query parameters for finding agents.
+type AgentQueryParams struct {
+	AccountIds           []string `json:"accountIds"`
+	AgentIds             []string `json:"ids"`
+	AgentVersions        []string `json:"agentVersions"`
+	AgentVersionsNin     []string `json:"agentVersionsNin"`
+	ComputerName         *string  `json:"computerName"`
+	ComputerNameContains []string `json:"computerName__contains"`
+	ExternalId           *string  `json:"externalId"`
+	GroupIds             []string `json:"groupIds"`
+	Infected             *bool    `json:"infected"`
+	IsActive             *bool    `json:"isActive"`
+	IsDecommissioned     *bool    `json:"isDecommissioned"`
+	IsUninstalled        *bool    `json:"isUninstalled"`
+	IsUpToDate           *bool    `json:"isUpToDate"`
+	LastActiveAfter      *string  `json:"lastActiveDate__gt"`
+	LastActiveBefore     *string  `json:"lastActiveDate__lt"`
+	MachineTypes         []string `json:"machineTypes"`
+	MaxAgentVersion      *string  `json:"agentVersion__lte"`
+	MinAgentVersion      *string  `json:"agentVersion__gte"`
+	NetworkStatuses      []string `json:"networkStatuses"`
+	OSTypes              []string `json:"osTypes"`
+	Query                *string  `json:"query"`
+	SiteIds              []string `json:"siteIds"`
+	SortBy               *string  `json:"sortBy"`
+	SortOrder            *string  `json:"sortOrder"`
+	TagIds               []string `json:"tagIds"`
+	UUIDs                []string `json:"uuids"`
+
+	// RawFilter holds additional filters, keyed by API parameter name, which are passed through as-is. Any typed
+	// parameter set above takes precedence over a raw filter with the same name.
+	RawFilter map[string]interface{} `json:"-"`
+}
+
+// IsEmpty returns whether or not no filters have been set.
+func (p *AgentQueryParams) IsEmpty() bool {
+	return len(p.toFilter()) == 0
+}
+
+// toFilter converts the object into a filter for use in the body of an agent action.
+//
+// Note that sorting parameters are not included as they are not valid for actions.
+func (p *AgentQueryParams) toFilter() map[string]interface{} {
+	filter := map[string]interface{}{}
+	for k, v := range p.RawFilter {
+		filter[k] = v
+	}
+	if len(p.AccountIds) > 0 {
+		filter["accountIds"] = p.AccountIds
+	}
+	if len(p.AgentIds) > 0 {
+		filter["ids"] = p.AgentIds
+	}
+	if len(p.AgentVersions) > 0 {
+		filter["agentVersions"] = p.AgentVersions
+	}
+	if len(p.AgentVersionsNin) > 0 {
+		filter["agentVersionsNin"] = p.AgentVersionsNin
+	}
+	if p.ComputerName != nil {
+		filter["computerName"] = *p.ComputerName
+	}
+	if len(p.ComputerNameContains) > 0 {
+		filter["computerName__contains"] = p.ComputerNameContains
+	}
+	if p.ExternalId != nil {
+		filter["externalId"] = *p.ExternalId
+	}
+	if len(p.GroupIds) > 0 {
+		filter["groupIds"] = p.GroupIds
+	}
+	if p.Infected != nil {
+		filter["infected"] = *p.Infected
+	}
+	if p.IsActive != nil {
+		filter["isActive"] = *p.IsActive
+	}
+	if p.IsDecommissioned != nil {
+		filter["isDecommissioned"] = *p.IsDecommissioned
+	}
+	if p.IsUninstalled != nil {
+		filter["isUninstalled"] = *p.IsUninstalled
+	}
+	if p.IsUpToDate != nil {
+		filter["isUpToDate"] = *p.IsUpToDate
+	}
+	if p.LastActiveAfter != nil {
+		filter["lastActiveDate__gt"] = *p.LastActiveAfter
+	}
+	if p.LastActiveBefore != nil {
+		filter["lastActiveDate__lt"] = *p.LastActiveBefore
+	}
+	if len(p.MachineTypes) > 0 {
+		filter["machineTypes"] = p.MachineTypes
+	}
+	if p.MaxAgentVersion != nil {
+		filter["agentVersion__lte"] = *p.MaxAgentVersion
+	}
+	if p.MinAgentVersion != nil {
+		filter["agentVersion__gte"] = *p.MinAgentVersion
+	}
+	if len(p.NetworkStatuses) > 0 {
+		filter["networkStatuses"] = p.NetworkStatuses
+	}
+	if len(p.OSTypes) > 0 {
+		filter["osTypes"] = p.OSTypes
+	}
+	if p.Query != nil {
+		filter["query"] = *p.Query
+	}
+	if len(p.SiteIds) > 0 {
+		filter["siteIds"] = p.SiteIds
+	}
+	if len(p.TagIds) > 0 {
+		filter["tagIds"] = p.TagIds
+	}
+	if len(p.UUIDs) > 0 {
+		filter["uuids"] = p.UUIDs
+	}
+	return filter
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *AgentQueryParams) toStringMap() map[string]string {
+	queryString := rawFilterToStringMap(p.RawFilter)
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.AgentIds) > 0 {
+		queryString["ids"] = strings.Join(p.AgentIds, ",")
+	}
+	if len(p.AgentVersions) > 0 {
+		queryString["agentVersions"] = strings.Join(p.AgentVersions, ",")
+	}
+	if len(p.AgentVersionsNin) > 0 {
+		queryString["agentVersionsNin"] = strings.Join(p.AgentVersionsNin, ",")
+	}
+	if p.ComputerName != nil {
+		queryString["computerName"] = *p.ComputerName
+	}
+	if len(p.ComputerNameContains) > 0 {
+		queryString["computerName__contains"] = strings.Join(p.ComputerNameContains, ",")
+	}
+	if p.ExternalId != nil {
+		queryString["externalId"] = *p.ExternalId
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if p.Infected != nil {
+		queryString["infected"] = fmt.Sprintf("%t", *p.Infected)
+	}
+	if p.IsActive != nil {
+		queryString["isActive"] = fmt.Sprintf("%t", *p.IsActive)
+	}
+	if p.IsDecommissioned != nil {
+		queryString["isDecommissioned"] = fmt.Sprintf("%t", *p.IsDecommissioned)
+	}
+	if p.IsUninstalled != nil {
+		queryString["isUninstalled"] = fmt.Sprintf("%t", *p.IsUninstalled)
+	}
+	if p.IsUpToDate != nil {
+		queryString["isUpToDate"] = fmt.Sprintf("%t", *p.IsUpToDate)
+	}
+	if p.LastActiveAfter != nil {
+		queryString["lastActiveDate__gt"] = *p.LastActiveAfter
+	}
+	if p.LastActiveBefore != nil {
+		queryString["lastActiveDate__lt"] = *p.LastActiveBefore
+	}
+	if len(p.MachineTypes) > 0 {
+		queryString["machineTypes"] = strings.Join(p.MachineTypes, ",")
+	}
+	if p.MaxAgentVersion != nil {
+		queryString["agentVersion__lte"] = *p.MaxAgentVersion
+	}
+	if p.MinAgentVersion != nil {
+		queryString["agentVersion__gte"] = *p.MinAgentVersion
+	}
+	if len(p.NetworkStatuses) > 0 {
+		queryString["networkStatuses"] = strings.Join(p.NetworkStatuses, ",")
+	}
+	if len(p.OSTypes) > 0 {
+		queryString["osTypes"] = strings.Join(p.OSTypes, ",")
+	}
+	if p.Query != nil {
+		queryString["query"] = *p.Query
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if p.SortBy != nil {
+		queryString["sortBy"] = *p.SortBy
+	}
+	if p.SortOrder != nil {
+		queryString["sortOrder"] = *p.SortOrder
+	}
+	if len(p.TagIds) > 0 {
+		queryString["tagIds"] = strings.Join(p.TagIds, ",")
+	}
+	if len(p.UUIDs) > 0 {
+		queryString["uuids"] = strings.Join(p.UUIDs, ",")
+	}
+	return queryString
+}
@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// AlertRule defines the API model for a custom alert rule.
+type AlertRule struct {
+	CreatedAt             string   `json:"createdAt"`
+	Description           string   `json:"description"`
+	Enabled               bool     `json:"enabled"`
+	Id                    string   `json:"id"`
+	Name                  string   `json:"name"`
+	NotificationTargets   []string `json:"notificationTargets"`
+	Query                 string   `json:"query"`
+	Severity              string   `json:"severity"`
+	ThrottleWindowSeconds int      `json:"throttleWindowSeconds"`
+	UpdatedAt             string   `json:"updatedAt"`
+}
+
+// AlertRuleBody is used to hold the attributes used for creating or updating a custom alert rule.
+type AlertRuleBody struct {
+	Description           *string
+	Enabled               *bool
+	Name                  *string
+	NotificationTargets   []string
+	Query                 *string
+	Severity              *string
+	ThrottleWindowSeconds *int
+}
+
+// toBody converts the object into the request body for the API.
+func (b *AlertRuleBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.NotificationTargets != nil {
+		body["notificationTargets"] = b.NotificationTargets
+	}
+	if b.Query != nil {
+		body["query"] = *b.Query
+	}
+	if b.Severity != nil {
+		body["severity"] = *b.Severity
+	}
+	if b.ThrottleWindowSeconds != nil {
+		body["throttleWindowSeconds"] = *b.ThrottleWindowSeconds
+	}
+	return body
+}
+
+// CreateAlertRule creates a new custom alert rule within the given scope and returns the new rule.
+func (c *client) CreateAlertRule(ctx context.Context, scope Scope, body AlertRuleBody) (*AlertRule, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/custom-alert-rules", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var rule AlertRule
+	if err := c.unmarshal(result.Data, &rule); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"AlertRule object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_ALERT_RULE_CREATE_RULE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &rule, diags
+}
+
+// DeleteAlertRule deletes the custom alert rule with the matching ID.
+func (c *client) DeleteAlertRule(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/custom-alert-rules", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindAlertRules returns a list of custom alert rules found based on the given query parameters.
+func (c *client) FindAlertRules(ctx context.Context, queryParams AlertRuleQueryParams) ([]AlertRule, diag.Diagnostics) {
+	var rules []AlertRule
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/custom-alert-rules", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []AlertRule
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of AlertRule objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_ALERT_RULE_FIND_RULES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		rules = append(rules, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return rules, diags
+}
+
+// UpdateAlertRule updates the custom alert rule with the matching ID using the given attributes and returns the
+// updated rule.
+func (c *client) UpdateAlertRule(ctx context.Context, id string, body AlertRuleBody) (*AlertRule, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/custom-alert-rules/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var rule AlertRule
+	if err := c.unmarshal(result.Data, &rule); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"AlertRule object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_ALERT_RULE_UPDATE_RULE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &rule, diags
+}
+
+// AlertRuleQueryParams is used to hold query parameters for finding custom alert rules.
+type AlertRuleQueryParams struct {
+	AccountIds   []string `json:"accountIds"`
+	AlertRuleIds []string `json:"ids"`
+	SiteIds      []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *AlertRuleQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.AlertRuleIds) > 0 {
+		queryString["ids"] = strings.Join(p.AlertRuleIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
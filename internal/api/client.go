@@ -7,18 +7,35 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
 )
 
+// secretFieldsRegex matches JSON fields in request and response bodies whose values must never be logged.
+var secretFieldsRegex = regexp.MustCompile(
+	`"(registrationToken|password|apiToken|token|apiKey|clientSecret|serviceAccountKey)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+
 // client is the HTTP client used for interacting with the S1 REST API.
 type client struct {
-	apiToken string
-	baseURL  string
-	conn     *http.Client
+	accountTokens map[string]string
+	apiToken      string
+	baseURL       string
+	conn          *http.Client
+	debugHeaders  []string
+	normalizeIds  bool
+
+	// strictDecoding determines whether or not unknown fields in API responses cause parsing to fail.
+	strictDecoding bool
+
+	// maintenanceRetryTimeout is the maximum time to keep retrying a request while the console is under
+	// maintenance. Requests are not retried if it is zero.
+	maintenanceRetryTimeout time.Duration
 }
 
 // Client returns the one and only global REST API client object.
@@ -27,7 +44,8 @@ type client struct {
 func Client() *client {
 	_once.Do(func() {
 		_client = &client{
-			conn: http.DefaultClient,
+			conn:         http.DefaultClient,
+			normalizeIds: true,
 		}
 	})
 	return _client
@@ -52,9 +70,62 @@ func (c *client) GetStream(ctx context.Context, uri string, queryParams map[stri
 }
 
 // Init sets the base URL and API token to use in any API queries.
+//
+// The endpoint is normally just the hostname of the console, in which case HTTPS is always used. An explicit
+// "http://" prefix is honored so that the client can be pointed at a local mock API server for testing.
+//
+// Every other setting of the client is reset to its default so that nothing set up for a previously configured
+// provider (eg: a test HTTP client or account tokens) carries over. Settings must therefore be applied after calling
+// Init.
 func (c *client) Init(endpoint, apiToken string) {
-	c.baseURL = fmt.Sprintf("https://%s%s", strings.TrimPrefix(endpoint, "https://"), API_BASE_URI)
+	if strings.HasPrefix(endpoint, "http://") {
+		c.baseURL = fmt.Sprintf("%s%s", strings.TrimSuffix(endpoint, "/"), API_BASE_URI)
+	} else {
+		c.baseURL = fmt.Sprintf("https://%s%s", strings.TrimSuffix(strings.TrimPrefix(endpoint, "https://"), "/"),
+			API_BASE_URI)
+	}
 	c.apiToken = apiToken
+	c.accountTokens = map[string]string{}
+	c.conn = http.DefaultClient
+	c.debugHeaders = []string{}
+	c.maintenanceRetryTimeout = 0
+	c.normalizeIds = true
+	c.strictDecoding = false
+}
+
+// IsInitialized determines whether or not Init() has been called to set the endpoint and API token.
+func (c *client) IsInitialized() bool {
+	return c.baseURL != ""
+}
+
+// URL returns the full URL of the given URI, which is relative to the API base URI.
+func (c *client) URL(uri string) string {
+	return fmt.Sprintf("%s/%s", c.baseURL, strings.TrimPrefix(uri, "/"))
+}
+
+// SetHTTPClient replaces the underlying HTTP client used for all API queries.
+//
+// This is primarily intended for tests which need to inject a client with a custom transport.
+func (c *client) SetHTTPClient(conn *http.Client) {
+	c.conn = conn
+}
+
+// SetMaintenanceRetryTimeout sets the maximum time to keep retrying a request while the console is under
+// maintenance. A zero duration disables retries.
+func (c *client) SetMaintenanceRetryTimeout(timeout time.Duration) {
+	c.maintenanceRetryTimeout = timeout
+}
+
+// SetNormalizeIds sets whether or not IDs returned as numbers by the API are converted to strings before the
+// response data is returned to the caller.
+func (c *client) SetNormalizeIds(normalize bool) {
+	c.normalizeIds = normalize
+}
+
+// SetStrictDecoding sets whether or not fields returned by the API which do not exist in the API models cause the
+// response to fail to parse.
+func (c *client) SetStrictDecoding(strict bool) {
+	c.strictDecoding = strict
 }
 
 // Post executes an HTTP POST query.
@@ -104,11 +175,14 @@ func (c *client) do(ctx context.Context, method, url string, queryParams map[str
 	ctx = tflog.SetField(ctx, "url", url)
 	ctx = tflog.SetField(ctx, "api_token", c.apiToken)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_token")
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, secretFieldsRegex)
+	ctx = tflog.MaskMessageRegexes(ctx, secretFieldsRegex)
 
 	// prepare body for the request, if there is any
-	var payload *bytes.Buffer
+	var jsonBody []byte
 	if len(body) > 0 {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			msg := fmt.Sprintf("An unexpected error occurred while attempting to create a request to the API Server.\n\n"+
 				"Error: %s\nURL: %s\nMethod: %s", err.Error(), url, method)
@@ -119,9 +193,81 @@ func (c *client) do(ctx context.Context, method, url string, queryParams map[str
 			diags.AddError("API Request Error", msg)
 			return nil, diags
 		}
-		payload = bytes.NewBuffer(jsonBody)
 		ctx = tflog.SetField(ctx, "body", string(jsonBody))
 	}
+	return c.doPayload(ctx, method, url, queryParams, jsonBody, "application/json")
+}
+
+// doPayload is responsible for executing a request with an already encoded body of the given content type and
+// checking the HTTP response code from the API server.
+//
+// Callers can check for errors using the HasErrors function on the Diagnostics object returned.
+//
+// If this function does not return errors in the Diagnostics object, it is the caller's responsibility
+// to close the response body.
+func (c *client) doPayload(ctx context.Context, method, url string, queryParams map[string]string, payload []byte,
+	contentType string) (*http.Response, diag.Diagnostics) {
+
+	// use the token of the child account on whose behalf the query is being made, if there is one
+	token, diags := c.tokenFor(ctx)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// execute the request, retrying for as long as allowed while the console is under maintenance
+	deadline := time.Now().Add(c.maintenanceRetryTimeout)
+	for {
+		resp, diags := c.send(ctx, token, method, url, queryParams, payload, contentType)
+		if diags.HasError() {
+			return nil, diags
+		}
+		if !isMaintenanceResponse(resp) {
+			return c.checkResponse(ctx, resp, method, url)
+		}
+		resp.Body.Close()
+
+		// give up if retries are disabled or the console will not be back before the deadline
+		delay := maintenanceRetryDelay(resp)
+		if c.maintenanceRetryTimeout <= 0 || time.Now().Add(delay).After(deadline) {
+			msg := fmt.Sprintf("The management console is currently under maintenance. Retry later.\n\n"+
+				"URL: %s\nMethod: %s\nHTTP Status Code: %d", url, method, resp.StatusCode)
+			if c.maintenanceRetryTimeout > 0 {
+				msg = fmt.Sprintf("The management console was still under maintenance after retrying for %s. "+
+					"Retry later.\n\nURL: %s\nMethod: %s\nHTTP Status Code: %d", c.maintenanceRetryTimeout, url,
+					method, resp.StatusCode)
+			}
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"internal_error_code": plugin.ERR_API_CLIENT_MAINTENANCE,
+			})
+			diags.AddError("Console Under Maintenance", msg)
+			return nil, diags
+		}
+		tflog.Warn(ctx, "console is under maintenance; retrying request", map[string]interface{}{
+			"retry_in": delay.String(),
+		})
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			msg := fmt.Sprintf("Stopped waiting for the management console to finish its maintenance.\n\n"+
+				"Error: %s\nURL: %s\nMethod: %s", ctx.Err().Error(), url, method)
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               ctx.Err().Error(),
+				"internal_error_code": plugin.ERR_API_CLIENT_MAINTENANCE,
+			})
+			diags.AddError("Console Under Maintenance", msg)
+			return nil, diags
+		}
+	}
+}
+
+// send creates and executes a single request to the API server.
+//
+// If this function does not return errors in the Diagnostics object, it is the caller's responsibility
+// to close the response body.
+func (c *client) send(ctx context.Context, token, method, url string, queryParams map[string]string,
+	payload []byte, contentType string) (*http.Response, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
 
 	// create the request
 	var req *http.Request
@@ -129,7 +275,7 @@ func (c *client) do(ctx context.Context, method, url string, queryParams map[str
 	if payload == nil { // sending a typed nil to NewRequest will cause a panic
 		req, err = http.NewRequest(method, url, nil)
 	} else {
-		req, err = http.NewRequest(method, url, payload)
+		req, err = http.NewRequest(method, url, bytes.NewBuffer(payload))
 	}
 	if err != nil {
 		msg := fmt.Sprintf("An unexpected error occurred while attempting to create a request to the API Server.\n\n"+
@@ -143,8 +289,8 @@ func (c *client) do(ctx context.Context, method, url string, queryParams map[str
 	}
 
 	// add headers to the request
-	req.Header.Set("Authorization", fmt.Sprintf("ApiToken %s", c.apiToken))
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("ApiToken %s", token))
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json, application/octet-stream")
 	req.Header.Set("User-Agent", USER_AGENT)
 
@@ -158,9 +304,12 @@ func (c *client) do(ctx context.Context, method, url string, queryParams map[str
 		ctx = tflog.SetField(ctx, "query_params", req.URL.RawQuery)
 	}
 
-	// execute the request
+	// execute the request, tracing how long each phase takes for debugging slow queries
+	timings, trace := newRequestTimings()
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
 	tflog.Debug(ctx, "executing REST API query")
 	resp, err := c.conn.Do(req)
+	tflog.Debug(ctx, "REST API query timings", timings.fields())
 	if err != nil {
 		msg := fmt.Sprintf("An unexpected error occurred while executing a request to the API Server.\n\n"+
 			"Error: %s\nURL: %s\nMethod: %s", err.Error(), url, method)
@@ -172,6 +321,23 @@ func (c *client) do(ctx context.Context, method, url string, queryParams map[str
 		return nil, diags
 	}
 	tflog.SetField(ctx, "status_code", resp.StatusCode)
+	if len(c.debugHeaders) > 0 {
+		tflog.Debug(ctx, "debug response headers received from API server", map[string]interface{}{
+			"headers": c.captureDebugResponseHeaders(ctx, resp),
+		})
+	}
+	return resp, diags
+}
+
+// checkResponse checks the HTTP response code from the API server, converting any errors returned into
+// diagnostics.
+//
+// If this function does not return errors in the Diagnostics object, it is the caller's responsibility
+// to close the response body.
+func (c *client) checkResponse(ctx context.Context, resp *http.Response, method, url string) (*http.Response,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
 
 	// status code >= 400 means there was an error
 	if resp.StatusCode >= 400 {
@@ -240,12 +406,25 @@ func (c *client) doAndParse(ctx context.Context, method, uri string, queryParams
 	ctx = tflog.SetField(ctx, "url", url)
 	ctx = tflog.SetField(ctx, "api_token", c.apiToken)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_token")
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, secretFieldsRegex)
+	ctx = tflog.MaskMessageRegexes(ctx, secretFieldsRegex)
 
 	// execute the actual request
 	resp, diags := c.do(ctx, method, url, queryParams, body)
 	if diags.HasError() {
 		return nil, diags
 	}
+	return c.parseResponse(ctx, resp, method, url)
+}
+
+// parseResponse reads and parses the body of a successful response from the API server, closing the body once
+// it has been read.
+//
+// Callers can check for errors using the HasErrors function on the Diagnostics object returned.
+func (c *client) parseResponse(ctx context.Context, resp *http.Response, method, url string) (*apiResponse,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
 	defer resp.Body.Close()
 	ctx = tflog.SetField(ctx, "status_code", resp.StatusCode)
 
@@ -276,6 +455,23 @@ func (c *client) doAndParse(ctx context.Context, method, uri string, queryParams
 		diags.AddError("API Response Error", msg)
 		return nil, diags
 	}
+
+	// some endpoints return IDs as numbers rather than strings so convert them before the data is parsed into models
+	if c.normalizeIds {
+		data, err := normalizeIds(result.Data)
+		if err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while normalizing the IDs in the response from the API "+
+				"Server.\n\nError: %s\nURL: %s\nMethod: %s\nHTTP Status Code: %d", err.Error(), url, method,
+				resp.StatusCode)
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_CLIENT_NORMALIZE_IDS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		result.Data = data
+	}
 	tflog.Debug(ctx, fmt.Sprintf("returning API response to caller: %+v", result))
 	return &result, diags
 }
@@ -296,6 +492,8 @@ func (c *client) doAndStream(ctx context.Context, method, uri string, queryParam
 	ctx = tflog.SetField(ctx, "url", url)
 	ctx = tflog.SetField(ctx, "api_token", c.apiToken)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_token")
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, secretFieldsRegex)
+	ctx = tflog.MaskMessageRegexes(ctx, secretFieldsRegex)
 
 	// execute the request
 	resp, diags := c.do(ctx, http.MethodGet, url, queryParams, map[string]interface{}{})
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInitResetsSettings(t *testing.T) {
+	c := &client{}
+	c.Init("first.example.com", "first-token")
+	c.SetHTTPClient(&http.Client{})
+	c.SetAccountTokens(map[string]string{"1000000000000000001": "account-token"})
+	c.SetDebugResponseHeaders([]string{"x-ratelimit-remaining"})
+	c.SetMaintenanceRetryTimeout(time.Minute)
+	c.SetNormalizeIds(false)
+	c.SetStrictDecoding(true)
+
+	c.Init("http://127.0.0.1:8080/", "second-token")
+	if c.baseURL != "http://127.0.0.1:8080"+API_BASE_URI {
+		t.Errorf("unexpected base URL: %s", c.baseURL)
+	}
+	if c.apiToken != "second-token" {
+		t.Errorf("unexpected API token: %s", c.apiToken)
+	}
+	if c.conn != http.DefaultClient {
+		t.Error("HTTP client was not reset")
+	}
+	if len(c.accountTokens) != 0 {
+		t.Errorf("account tokens were not reset: %v", c.accountTokens)
+	}
+	if len(c.debugHeaders) != 0 {
+		t.Errorf("debug response headers were not reset: %v", c.debugHeaders)
+	}
+	if c.maintenanceRetryTimeout != 0 {
+		t.Errorf("maintenance retry timeout was not reset: %s", c.maintenanceRetryTimeout)
+	}
+	if !c.normalizeIds {
+		t.Error("ID normalization was not reset")
+	}
+	if c.strictDecoding {
+		t.Error("strict decoding was not reset")
+	}
+}
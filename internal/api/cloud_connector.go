@@ -0,0 +1,234 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Cloud connector constants
+const (
+	CLOUD_CONNECTOR_PROVIDER_AWS   = "aws"
+	CLOUD_CONNECTOR_PROVIDER_AZURE = "azure"
+	CLOUD_CONNECTOR_PROVIDER_GCP   = "gcp"
+
+	CLOUD_CONNECTOR_STATUS_CONNECTED = "connected"
+	CLOUD_CONNECTOR_STATUS_ERROR     = "error"
+	CLOUD_CONNECTOR_STATUS_PENDING   = "pending"
+)
+
+// CloudConnector defines the API model for a connection between a cloud provider and Singularity Cloud.
+type CloudConnector struct {
+	Aws           *AwsCloudConnectorConfig   `json:"aws"`
+	Azure         *AzureCloudConnectorConfig `json:"azure"`
+	Gcp           *GcpCloudConnectorConfig   `json:"gcp"`
+	CreatedAt     string                     `json:"createdAt"`
+	Id            string                     `json:"id"`
+	Name          string                     `json:"name"`
+	Provider      string                     `json:"provider"`
+	Services      []string                   `json:"services"`
+	Status        string                     `json:"status"`
+	StatusMessage string                     `json:"statusMessage"`
+	UpdatedAt     string                     `json:"updatedAt"`
+}
+
+// AwsCloudConnectorConfig defines the API model for the configuration of a connection to an AWS account or
+// organization.
+type AwsCloudConnectorConfig struct {
+	ExternalId     string   `json:"externalId"`
+	OrganizationId string   `json:"organizationId"`
+	Regions        []string `json:"regions"`
+	RoleArn        string   `json:"roleArn"`
+}
+
+// AzureCloudConnectorConfig defines the API model for the configuration of a connection to Azure subscriptions
+// within a tenant.
+//
+// The API never returns the client secret.
+type AzureCloudConnectorConfig struct {
+	ClientId        string   `json:"clientId"`
+	ClientSecret    string   `json:"clientSecret,omitempty"`
+	SubscriptionIds []string `json:"subscriptionIds"`
+	TenantId        string   `json:"tenantId"`
+}
+
+// GcpCloudConnectorConfig defines the API model for the configuration of a connection to GCP projects or an
+// organization.
+//
+// The console authenticates either with a service account key or by impersonating the service account through
+// workload identity federation. The API never returns the service account key.
+type GcpCloudConnectorConfig struct {
+	OrganizationId           string   `json:"organizationId"`
+	ProjectIds               []string `json:"projectIds"`
+	ServiceAccountEmail      string   `json:"serviceAccountEmail"`
+	ServiceAccountKey        string   `json:"serviceAccountKey,omitempty"`
+	WorkloadIdentityProvider string   `json:"workloadIdentityProvider"`
+}
+
+// CloudConnectorBody is used to hold the attributes used for creating or updating a cloud connector.
+//
+// Only the configuration of the connector's provider should be set.
+type CloudConnectorBody struct {
+	Aws      *AwsCloudConnectorConfig
+	Azure    *AzureCloudConnectorConfig
+	Gcp      *GcpCloudConnectorConfig
+	Name     *string
+	Services []string
+}
+
+// toBody converts the object into the request body for the API.
+func (b *CloudConnectorBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Aws != nil {
+		body["provider"] = CLOUD_CONNECTOR_PROVIDER_AWS
+		body["aws"] = b.Aws
+	}
+	if b.Azure != nil {
+		body["provider"] = CLOUD_CONNECTOR_PROVIDER_AZURE
+		body["azure"] = b.Azure
+	}
+	if b.Gcp != nil {
+		body["provider"] = CLOUD_CONNECTOR_PROVIDER_GCP
+		body["gcp"] = b.Gcp
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.Services != nil {
+		body["services"] = b.Services
+	}
+	return body
+}
+
+// CreateCloudConnector creates a new cloud connector within the given scope and returns the new connector.
+func (c *client) CreateCloudConnector(ctx context.Context, scope Scope, body CloudConnectorBody) (*CloudConnector,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/cloud-connectors", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var connector CloudConnector
+	if err := c.unmarshal(result.Data, &connector); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"CloudConnector object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_CLOUD_CONNECTOR_CREATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &connector, diags
+}
+
+// DeleteCloudConnector deletes the cloud connector with the matching ID.
+func (c *client) DeleteCloudConnector(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/cloud-connectors", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindCloudConnectors returns a list of cloud connectors found based on the given query parameters.
+func (c *client) FindCloudConnectors(ctx context.Context, queryParams CloudConnectorQueryParams) ([]CloudConnector,
+	diag.Diagnostics) {
+
+	var connectors []CloudConnector
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/cloud-connectors", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []CloudConnector
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of CloudConnector objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_CLOUD_CONNECTOR_FIND,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		connectors = append(connectors, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return connectors, diags
+}
+
+// UpdateCloudConnector updates the cloud connector with the matching ID using the given attributes and returns the
+// updated connector.
+func (c *client) UpdateCloudConnector(ctx context.Context, id string, body CloudConnectorBody) (*CloudConnector,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/cloud-connectors/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var connector CloudConnector
+	if err := c.unmarshal(result.Data, &connector); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"CloudConnector object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_CLOUD_CONNECTOR_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &connector, diags
+}
+
+// CloudConnectorQueryParams is used to hold query parameters for finding cloud connectors.
+type CloudConnectorQueryParams struct {
+	AccountIds        []string `json:"accountIds"`
+	CloudConnectorIds []string `json:"ids"`
+	Providers         []string `json:"providers"`
+	SiteIds           []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *CloudConnectorQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.CloudConnectorIds) > 0 {
+		queryString["ids"] = strings.Join(p.CloudConnectorIds, ",")
+	}
+	if len(p.Providers) > 0 {
+		queryString["providers"] = strings.Join(p.Providers, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
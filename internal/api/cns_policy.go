@@ -0,0 +1,199 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Cloud Native Security policy severity constants
+const (
+	CNS_SEVERITY_CRITICAL = "critical"
+	CNS_SEVERITY_HIGH     = "high"
+	CNS_SEVERITY_LOW      = "low"
+	CNS_SEVERITY_MEDIUM   = "medium"
+)
+
+// CnsPolicy defines the API model for a Cloud Native Security posture policy.
+type CnsPolicy struct {
+	CreatedAt   string               `json:"createdAt"`
+	Description string               `json:"description"`
+	Enabled     bool                 `json:"enabled"`
+	Exemptions  []CnsPolicyExemption `json:"exemptions"`
+	Id          string               `json:"id"`
+	Name        string               `json:"name"`
+	RuleSets    []string             `json:"ruleSets"`
+	Severities  []string             `json:"severities"`
+	UpdatedAt   string               `json:"updatedAt"`
+}
+
+// CnsPolicyExemption defines the API model for a cloud resource which is exempted from a rule of a policy.
+type CnsPolicyExemption struct {
+	ExpiresAt  string `json:"expiresAt,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+	ResourceId string `json:"resourceId"`
+	RuleId     string `json:"ruleId"`
+}
+
+// CnsPolicyBody is used to hold the attributes used for creating or updating a policy.
+type CnsPolicyBody struct {
+	Description *string
+	Enabled     *bool
+	Exemptions  []CnsPolicyExemption
+	Name        *string
+	RuleSets    []string
+	Severities  []string
+}
+
+// toBody converts the object into the request body for the API.
+func (b *CnsPolicyBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.Exemptions != nil {
+		body["exemptions"] = b.Exemptions
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.RuleSets != nil {
+		body["ruleSets"] = b.RuleSets
+	}
+	if b.Severities != nil {
+		body["severities"] = b.Severities
+	}
+	return body
+}
+
+// CreateCnsPolicy creates a new Cloud Native Security policy within the given scope and returns the new policy.
+func (c *client) CreateCnsPolicy(ctx context.Context, scope Scope, body CnsPolicyBody) (*CnsPolicy,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/cloud-native-security/policies", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy CnsPolicy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"CnsPolicy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_CNS_POLICY_CREATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// DeleteCnsPolicy deletes the Cloud Native Security policy with the matching ID.
+func (c *client) DeleteCnsPolicy(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/cloud-native-security/policies", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindCnsPolicies returns a list of Cloud Native Security policies found based on the given query parameters.
+func (c *client) FindCnsPolicies(ctx context.Context, queryParams CnsPolicyQueryParams) ([]CnsPolicy,
+	diag.Diagnostics) {
+
+	var policies []CnsPolicy
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/cloud-native-security/policies", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []CnsPolicy
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of CnsPolicy objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_CNS_POLICY_FIND,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		policies = append(policies, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return policies, diags
+}
+
+// UpdateCnsPolicy updates the Cloud Native Security policy with the matching ID using the given attributes and returns
+// the updated policy.
+func (c *client) UpdateCnsPolicy(ctx context.Context, id string, body CnsPolicyBody) (*CnsPolicy,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/cloud-native-security/policies/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy CnsPolicy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"CnsPolicy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_CNS_POLICY_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// CnsPolicyQueryParams is used to hold query parameters for finding Cloud Native Security policies.
+type CnsPolicyQueryParams struct {
+	AccountIds   []string `json:"accountIds"`
+	CnsPolicyIds []string `json:"ids"`
+	SiteIds      []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *CnsPolicyQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.CnsPolicyIds) > 0 {
+		queryString["ids"] = strings.Join(p.CnsPolicyIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
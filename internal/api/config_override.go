@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// CONFIG_OVERRIDE_VERSION_ALL and CONFIG_OVERRIDE_VERSION_SPECIFIC determine whether a configuration override
+// applies to every agent version or only to a specific version.
+const (
+	CONFIG_OVERRIDE_VERSION_ALL      = "ALL"
+	CONFIG_OVERRIDE_VERSION_SPECIFIC = "SPECIFIC"
+)
+
+// ConfigOverride defines the API model for an agent configuration override.
+type ConfigOverride struct {
+	AgentVersion  string          `json:"agentVersion"`
+	Config        json.RawMessage `json:"config"`
+	CreatedAt     string          `json:"createdAt"`
+	Description   string          `json:"description"`
+	Id            string          `json:"id"`
+	Name          string          `json:"name"`
+	OsType        string          `json:"osType"`
+	UpdatedAt     string          `json:"updatedAt"`
+	VersionOption string          `json:"versionOption"`
+}
+
+// ConfigOverrideBody is used to hold the attributes used for creating or updating a configuration override.
+type ConfigOverrideBody struct {
+	AgentVersion *string         `json:"agentVersion"`
+	Config       json.RawMessage `json:"config"`
+	Description  *string         `json:"description"`
+	Name         *string         `json:"name"`
+	OsType       *string         `json:"osType"`
+}
+
+// toBody converts the object into the request body for the API.
+//
+// The override applies to every agent version unless a specific version is given.
+func (b *ConfigOverrideBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{
+		"versionOption": CONFIG_OVERRIDE_VERSION_ALL,
+	}
+	if b.AgentVersion != nil {
+		body["agentVersion"] = *b.AgentVersion
+		body["versionOption"] = CONFIG_OVERRIDE_VERSION_SPECIFIC
+	}
+	if b.Config != nil {
+		body["config"] = b.Config
+	}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.OsType != nil {
+		body["osType"] = *b.OsType
+	}
+	return body
+}
+
+// CreateConfigOverride creates a new configuration override within the given scope and returns the new override.
+func (c *client) CreateConfigOverride(ctx context.Context, scope Scope, body ConfigOverrideBody) (*ConfigOverride,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/config-override", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var override ConfigOverride
+	if err := c.unmarshal(result.Data, &override); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ConfigOverride object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_CONFIG_OVERRIDE_CREATE_OVERRIDE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &override, diags
+}
+
+// DeleteConfigOverride deletes the configuration override with the matching ID.
+func (c *client) DeleteConfigOverride(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/config-override", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindConfigOverrides returns a list of configuration overrides found based on the given query parameters.
+func (c *client) FindConfigOverrides(ctx context.Context, queryParams ConfigOverrideQueryParams) ([]ConfigOverride,
+	diag.Diagnostics) {
+
+	var overrides []ConfigOverride
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/config-override", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []ConfigOverride
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of ConfigOverride objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_CONFIG_OVERRIDE_FIND_OVERRIDES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		overrides = append(overrides, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return overrides, diags
+}
+
+// UpdateConfigOverride updates the configuration override with the matching ID using the given attributes and
+// returns the updated override.
+func (c *client) UpdateConfigOverride(ctx context.Context, id string, body ConfigOverrideBody) (*ConfigOverride,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/config-override/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var override ConfigOverride
+	if err := c.unmarshal(result.Data, &override); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ConfigOverride object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_CONFIG_OVERRIDE_UPDATE_OVERRIDE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &override, diags
+}
+
+// ConfigOverrideQueryParams is used to hold query parameters for finding configuration overrides.
+type ConfigOverrideQueryParams struct {
+	AccountIds        []string `json:"accountIds"`
+	ConfigOverrideIds []string `json:"ids"`
+	GroupIds          []string `json:"groupIds"`
+	SiteIds           []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *ConfigOverrideQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.ConfigOverrideIds) > 0 {
+		queryString["ids"] = strings.Join(p.ConfigOverrideIds, ",")
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// debugRecorderKey is the context key under which a ResponseHeaderRecorder is stored.
+type debugRecorderKey struct{}
+
+// ResponseHeaderRecorder holds the debug response headers captured from every API query made with a context
+// returned by RecordResponseHeaders.
+type ResponseHeaderRecorder struct {
+	headers map[string]string
+	mutex   sync.Mutex
+}
+
+// RecordResponseHeaders returns a new context which, when passed to any API query, causes the debug response
+// headers configured with SetDebugResponseHeaders to be captured by the returned recorder.
+func RecordResponseHeaders(ctx context.Context) (context.Context, *ResponseHeaderRecorder) {
+	recorder := &ResponseHeaderRecorder{
+		headers: map[string]string{},
+	}
+	return context.WithValue(ctx, debugRecorderKey{}, recorder), recorder
+}
+
+// Headers returns the most recent value seen for each captured header.
+func (r *ResponseHeaderRecorder) Headers() map[string]string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	headers := map[string]string{}
+	for k, v := range r.headers {
+		headers[k] = v
+	}
+	return headers
+}
+
+// record saves the given header value.
+func (r *ResponseHeaderRecorder) record(name, value string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.headers[name] = value
+}
+
+// SetDebugResponseHeaders sets the names of the response headers which are logged and captured for debugging
+// purposes (eg: rate limit or server version headers).
+func (c *client) SetDebugResponseHeaders(headers []string) {
+	c.debugHeaders = []string{}
+	for _, h := range headers {
+		c.debugHeaders = append(c.debugHeaders, http.CanonicalHeaderKey(h))
+	}
+}
+
+// captureDebugResponseHeaders returns any configured debug headers found in the given response after saving them to
+// the recorder in the context, if there is one.
+func (c *client) captureDebugResponseHeaders(ctx context.Context, resp *http.Response) map[string]interface{} {
+	captured := map[string]interface{}{}
+	recorder, _ := ctx.Value(debugRecorderKey{}).(*ResponseHeaderRecorder)
+	for _, name := range c.debugHeaders {
+		value := resp.Header.Get(name)
+		if value == "" {
+			continue
+		}
+		captured[name] = value
+		if recorder != nil {
+			recorder.record(name, value)
+		}
+	}
+	return captured
+}
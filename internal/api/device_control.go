@@ -0,0 +1,222 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// DeviceControlRule defines the API model for a device control rule.
+type DeviceControlRule struct {
+	AccessPermission string `json:"accessPermission"`
+	Action           string `json:"action"`
+	CreatedAt        string `json:"createdAt"`
+	DeviceClass      string `json:"deviceClass"`
+	DeviceId         string `json:"deviceId"`
+	Id               string `json:"id"`
+	Interface        string `json:"interface"`
+	OSType           string `json:"osType"`
+	ProductId        string `json:"productId"`
+	RuleName         string `json:"ruleName"`
+	RuleType         string `json:"ruleType"`
+	Scope            string `json:"scope"`
+	ScopeId          string `json:"scopeId"`
+	Status           string `json:"status"`
+	UpdatedAt        string `json:"updatedAt"`
+	VendorId         string `json:"vendorId"`
+}
+
+// DeviceControlRuleBody is used to hold the attributes used for creating or updating a device control rule.
+type DeviceControlRuleBody struct {
+	AccessPermission *string `json:"accessPermission"`
+	Action           *string `json:"action"`
+	DeviceClass      *string `json:"deviceClass"`
+	DeviceId         *string `json:"deviceId"`
+	Interface        *string `json:"interface"`
+	OSType           *string `json:"osType"`
+	ProductId        *string `json:"productId"`
+	RuleName         *string `json:"ruleName"`
+	RuleType         *string `json:"ruleType"`
+	Status           *string `json:"status"`
+	VendorId         *string `json:"vendorId"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *DeviceControlRuleBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.AccessPermission != nil {
+		body["accessPermission"] = *b.AccessPermission
+	}
+	if b.Action != nil {
+		body["action"] = *b.Action
+	}
+	if b.DeviceClass != nil {
+		body["deviceClass"] = *b.DeviceClass
+	}
+	if b.DeviceId != nil {
+		body["deviceId"] = *b.DeviceId
+	}
+	if b.Interface != nil {
+		body["interface"] = *b.Interface
+	}
+	if b.OSType != nil {
+		body["osType"] = *b.OSType
+	}
+	if b.ProductId != nil {
+		body["productId"] = *b.ProductId
+	}
+	if b.RuleName != nil {
+		body["ruleName"] = *b.RuleName
+	}
+	if b.RuleType != nil {
+		body["ruleType"] = *b.RuleType
+	}
+	if b.Status != nil {
+		body["status"] = *b.Status
+	}
+	if b.VendorId != nil {
+		body["vendorId"] = *b.VendorId
+	}
+	return body
+}
+
+// CreateDeviceControlRule creates a new device control rule within the given scope and returns the new rule.
+func (c *client) CreateDeviceControlRule(ctx context.Context, scope Scope, body DeviceControlRuleBody) (
+	*DeviceControlRule, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/device-control", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var rule DeviceControlRule
+	if err := c.unmarshal(result.Data, &rule); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"DeviceControlRule object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_DEVICE_CONTROL_CREATE_RULE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &rule, diags
+}
+
+// DeleteDeviceControlRule deletes the device control rule with the matching ID.
+func (c *client) DeleteDeviceControlRule(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/device-control", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindDeviceControlRules returns a list of device control rules found based on the given query parameters.
+func (c *client) FindDeviceControlRules(ctx context.Context, queryParams DeviceControlRuleQueryParams) (
+	[]DeviceControlRule, diag.Diagnostics) {
+
+	var rules []DeviceControlRule
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/device-control", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []DeviceControlRule
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of DeviceControlRule objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_DEVICE_CONTROL_FIND_RULES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		rules = append(rules, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return rules, diags
+}
+
+// UpdateDeviceControlRule updates the device control rule with the matching ID using the given attributes and
+// returns the updated rule.
+func (c *client) UpdateDeviceControlRule(ctx context.Context, id string, body DeviceControlRuleBody) (
+	*DeviceControlRule, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/device-control/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var rule DeviceControlRule
+	if err := c.unmarshal(result.Data, &rule); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"DeviceControlRule object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_DEVICE_CONTROL_UPDATE_RULE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &rule, diags
+}
+
+// DeviceControlRuleQueryParams is used to hold query parameters for finding device control rules.
+type DeviceControlRuleQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	GroupIds   []string `json:"groupIds"`
+	Interfaces []string `json:"interfaces"`
+	RuleIds    []string `json:"ids"`
+	SiteIds    []string `json:"siteIds"`
+	Tenant     *bool    `json:"tenant"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *DeviceControlRuleQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.Interfaces) > 0 {
+		queryString["interfaces"] = strings.Join(p.Interfaces, ",")
+	}
+	if len(p.RuleIds) > 0 {
+		queryString["ids"] = strings.Join(p.RuleIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if p.Tenant != nil {
+		queryString["tenant"] = fmt.Sprintf("%t", *p.Tenant)
+	}
+	return queryString
+}
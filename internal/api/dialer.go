@@ -0,0 +1,37 @@
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// SetEndpointIP pins the hostname of the console to the given IP address instead of resolving it using DNS.
+//
+// Only the address connected to is changed: requests are still made to the hostname so TLS server name indication
+// and certificate verification continue to use it. Connections to any other host (eg: a proxy) are not affected.
+//
+// Init must be called before this function so the hostname of the console is known.
+func (c *client) SetEndpointIP(ip string) {
+	host := ""
+	if u, err := url.Parse(c.baseURL); err == nil {
+		host = u.Hostname()
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		addrHost, port, err := net.SplitHostPort(addr)
+		if err == nil && addrHost == host {
+			addr = net.JoinHostPort(ip, port)
+		}
+		return dialer.DialContext(ctx, network, addr)
+	}
+	c.conn = &http.Client{
+		Transport: transport,
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// EventForwarding defines the API model for the event forwarding (Cloud Funnel) configuration of a scope.
+type EventForwarding struct {
+	CredentialsRef  string   `json:"credentialsRef"`
+	Destination     string   `json:"destination"`
+	DestinationType string   `json:"destinationType"`
+	Enabled         bool     `json:"enabled"`
+	EventTypes      []string `json:"eventTypes"`
+	Region          string   `json:"region"`
+	Topic           string   `json:"topic"`
+	UpdatedAt       string   `json:"updatedAt"`
+}
+
+// EventForwardingBody is used to hold the attributes used for updating an event forwarding configuration.
+type EventForwardingBody struct {
+	CredentialsRef  *string  `json:"credentialsRef"`
+	Destination     *string  `json:"destination"`
+	DestinationType *string  `json:"destinationType"`
+	Enabled         *bool    `json:"enabled"`
+	EventTypes      []string `json:"eventTypes"`
+	Region          *string  `json:"region"`
+	Topic           *string  `json:"topic"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *EventForwardingBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.CredentialsRef != nil {
+		body["credentialsRef"] = *b.CredentialsRef
+	}
+	if b.Destination != nil {
+		body["destination"] = *b.Destination
+	}
+	if b.DestinationType != nil {
+		body["destinationType"] = *b.DestinationType
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.EventTypes != nil {
+		body["eventTypes"] = b.EventTypes
+	}
+	if b.Region != nil {
+		body["region"] = *b.Region
+	}
+	if b.Topic != nil {
+		body["topic"] = *b.Topic
+	}
+	return map[string]interface{}{
+		"data": body,
+	}
+}
+
+// GetEventForwarding returns the event forwarding configuration of the given scope.
+func (c *client) GetEventForwarding(ctx context.Context, scope Scope) (*EventForwarding, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, fmt.Sprintf("%s/cloud-funnel", scope.uriPrefix()), map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var forwarding EventForwarding
+	if err := c.unmarshal(result.Data, &forwarding); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"EventForwarding object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_EVENT_FORWARDING_GET,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &forwarding, diags
+}
+
+// UpdateEventForwarding updates the event forwarding configuration of the given scope using the given attributes and
+// returns the updated configuration.
+func (c *client) UpdateEventForwarding(ctx context.Context, scope Scope, body EventForwardingBody) (
+	*EventForwarding, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("%s/cloud-funnel", scope.uriPrefix()), body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var forwarding EventForwarding
+	if err := c.unmarshal(result.Data, &forwarding); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"EventForwarding object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_EVENT_FORWARDING_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &forwarding, diags
+}
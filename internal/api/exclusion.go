@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// EXCLUSION_TYPE_ALLOWLIST_HASH is the exclusion type used for allow-listed SHA1 hashes.
+const EXCLUSION_TYPE_ALLOWLIST_HASH = "white_hash"
+
+// Exclusion defines the API model for an exclusion.
+type Exclusion struct {
+	CreatedAt         string `json:"createdAt"`
+	Description       string `json:"description"`
+	Id                string `json:"id"`
+	Mode              string `json:"mode"`
+	OSType            string `json:"osType"`
+	PathExclusionType string `json:"pathExclusionType"`
+	ScopeName         string `json:"scopeName"`
+	ScopePath         string `json:"scopePath"`
+	Source            string `json:"source"`
+	Type              string `json:"type"`
+	UpdatedAt         string `json:"updatedAt"`
+	UserId            string `json:"userId"`
+	UserName          string `json:"userName"`
+	Value             string `json:"value"`
+}
+
+// ExclusionBody is used to hold the attributes used for creating or updating an exclusion.
+type ExclusionBody struct {
+	Description       *string `json:"description"`
+	Mode              *string `json:"mode"`
+	OSType            *string `json:"osType"`
+	PathExclusionType *string `json:"pathExclusionType"`
+	Type              *string `json:"type"`
+	Value             *string `json:"value"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *ExclusionBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Mode != nil {
+		body["mode"] = *b.Mode
+	}
+	if b.OSType != nil {
+		body["osType"] = *b.OSType
+	}
+	if b.PathExclusionType != nil {
+		body["pathExclusionType"] = *b.PathExclusionType
+	}
+	if b.Type != nil {
+		body["type"] = *b.Type
+	}
+	if b.Value != nil {
+		body["value"] = *b.Value
+	}
+	return body
+}
+
+// CreateExclusion creates a new exclusion within the given scope and returns the new exclusion.
+func (c *client) CreateExclusion(ctx context.Context, scope Scope, body ExclusionBody) (*Exclusion,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/exclusions", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned - the API always returns a list of the exclusions created
+	var exclusions []Exclusion
+	if err := c.unmarshal(result.Data, &exclusions); err != nil || len(exclusions) == 0 {
+		errMsg := "no exclusions were returned"
+		if err != nil {
+			errMsg = err.Error()
+		}
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"Exclusion object.\n\nError: %s", errMsg)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               errMsg,
+			"internal_error_code": plugin.ERR_API_EXCLUSION_CREATE_EXCLUSION,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &exclusions[0], diags
+}
+
+// DeleteExclusion deletes the exclusion with the matching ID and type.
+func (c *client) DeleteExclusion(ctx context.Context, id, exclusionType string) diag.Diagnostics {
+	return c.DeleteExclusions(ctx, []string{id}, exclusionType)
+}
+
+// DeleteExclusions deletes all of the exclusions with the matching IDs in a single call. The exclusions must all be
+// of the given type.
+func (c *client) DeleteExclusions(ctx context.Context, ids []string, exclusionType string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/exclusions", map[string]interface{}{
+		"data": map[string]interface{}{
+			"ids":  ids,
+			"type": exclusionType,
+		},
+	})
+	return diags
+}
+
+// FindExclusions returns a list of exclusions found based on the given query parameters.
+func (c *client) FindExclusions(ctx context.Context, queryParams ExclusionQueryParams) ([]Exclusion,
+	diag.Diagnostics) {
+
+	var exclusions []Exclusion
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/exclusions", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Exclusion
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Exclusion objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_EXCLUSION_FIND_EXCLUSIONS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		exclusions = append(exclusions, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return exclusions, diags
+}
+
+// UpdateExclusion updates the exclusion with the matching ID using the given attributes and returns the updated
+// exclusion.
+func (c *client) UpdateExclusion(ctx context.Context, id string, body ExclusionBody) (*Exclusion,
+	diag.Diagnostics) {
+
+	// query the API
+	data := body.toBody()
+	data["id"] = id
+	result, diags := c.Put(ctx, "/exclusions", map[string]interface{}{
+		"data": data,
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var exclusion Exclusion
+	if err := c.unmarshal(result.Data, &exclusion); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"Exclusion object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_EXCLUSION_UPDATE_EXCLUSION,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &exclusion, diags
+}
+
+// ExclusionQueryParams is used to hold query parameters for finding exclusions.
+type ExclusionQueryParams struct {
+	AccountIds   []string `json:"accountIds"`
+	ExclusionIds []string `json:"ids"`
+	GroupIds     []string `json:"groupIds"`
+	OSTypes      []string `json:"osTypes"`
+	SiteIds      []string `json:"siteIds"`
+	Tenant       *bool    `json:"tenant"`
+	Type         *string  `json:"type"`
+	Value        *string  `json:"value"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *ExclusionQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.ExclusionIds) > 0 {
+		queryString["ids"] = strings.Join(p.ExclusionIds, ",")
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.OSTypes) > 0 {
+		queryString["osTypes"] = strings.Join(p.OSTypes, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if p.Tenant != nil {
+		queryString["tenant"] = fmt.Sprintf("%t", *p.Tenant)
+	}
+	if p.Type != nil {
+		queryString["type"] = *p.Type
+	}
+	if p.Value != nil {
+		queryString["value"] = *p.Value
+	}
+	return queryString
+}
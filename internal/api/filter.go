@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Filter defines the API model for a saved filter.
+type Filter struct {
+	CreatedAt    string       `json:"createdAt"`
+	FilterFields FilterFields `json:"filterFields"`
+	Id           string       `json:"id"`
+	Name         string       `json:"name"`
+	Scope        string       `json:"scopeLevel"`
+	ScopeId      string       `json:"scopeId"`
+	UpdatedAt    string       `json:"updatedAt"`
+}
+
+// FilterFields defines the API model for the query fields of a saved filter.
+type FilterFields struct {
+	AgentVersions []string `json:"agentVersions"`
+	OsTypes       []string `json:"osTypes"`
+	TagIds        []string `json:"tagIds"`
+}
+
+// FilterBody is used to hold the attributes used for creating or updating a filter.
+type FilterBody struct {
+	AgentVersions []string `json:"agentVersions"`
+	Name          *string  `json:"name"`
+	OsTypes       []string `json:"osTypes"`
+	TagIds        []string `json:"tagIds"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *FilterBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+
+	// fields which are not set are removed from the filter so always send them
+	filterFields := map[string]interface{}{}
+	if len(b.AgentVersions) > 0 {
+		filterFields["agentVersions"] = b.AgentVersions
+	}
+	if len(b.OsTypes) > 0 {
+		filterFields["osTypes"] = b.OsTypes
+	}
+	if len(b.TagIds) > 0 {
+		filterFields["tagIds"] = b.TagIds
+	}
+	body["filterFields"] = filterFields
+	return body
+}
+
+// CreateFilter creates a new saved filter within the given scope and returns the new filter.
+func (c *client) CreateFilter(ctx context.Context, scope Scope, body FilterBody) (*Filter, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/filters", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var filter Filter
+	if err := c.unmarshal(result.Data, &filter); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Filter object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_FILTER_CREATE_FILTER,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &filter, diags
+}
+
+// DeleteFilter deletes the saved filter with the matching ID.
+func (c *client) DeleteFilter(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, fmt.Sprintf("/filters/%s", id), nil)
+	return diags
+}
+
+// FindFilters returns a list of saved filters found based on the given query parameters.
+func (c *client) FindFilters(ctx context.Context, queryParams FilterQueryParams) ([]Filter, diag.Diagnostics) {
+	var filters []Filter
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/filters", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Filter
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Filter objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_FILTER_FIND_FILTERS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		filters = append(filters, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return filters, diags
+}
+
+// UpdateFilter updates the saved filter with the matching ID using the given attributes and returns the updated
+// filter.
+func (c *client) UpdateFilter(ctx context.Context, id string, body FilterBody) (*Filter, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/filters/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var filter Filter
+	if err := c.unmarshal(result.Data, &filter); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Filter object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_FILTER_UPDATE_FILTER,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &filter, diags
+}
+
+// FilterQueryParams is used to hold query parameters for finding saved filters.
+type FilterQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	FilterIds  []string `json:"ids"`
+	SiteIds    []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *FilterQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.FilterIds) > 0 {
+		queryString["ids"] = strings.Join(p.FilterIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
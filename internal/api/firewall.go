@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// FirewallRule defines the API model for a firewall control rule.
+type FirewallRule struct {
+	Action      string   `json:"action"`
+	CreatedAt   string   `json:"createdAt"`
+	Description string   `json:"description"`
+	Direction   string   `json:"direction"`
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Order       int      `json:"order"`
+	OSTypes     []string `json:"osTypes"`
+	ScopeId     string   `json:"scopeId"`
+	Scope       string   `json:"scope"`
+	Status      string   `json:"status"`
+	UpdatedAt   string   `json:"updatedAt"`
+}
+
+// FindFirewallRules returns a list of firewall control rules found based on the given query parameters.
+func (c *client) FindFirewallRules(ctx context.Context, queryParams FirewallRuleQueryParams) ([]FirewallRule,
+	diag.Diagnostics) {
+
+	var rules []FirewallRule
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/firewall-control", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []FirewallRule
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of FirewallRule objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_FIREWALL_FIND_FIREWALL_RULES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		rules = append(rules, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return rules, diags
+}
+
+// ReorderFirewallRules sets the order in which the firewall rules within the given scope are evaluated.
+//
+// The order maps the ID of each rule to its new position. Rules which are not included keep their current position.
+func (c *client) ReorderFirewallRules(ctx context.Context, scope Scope, order map[string]int) diag.Diagnostics {
+	ids := []string{}
+	for id := range order {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	data := []map[string]interface{}{}
+	for _, id := range ids {
+		data = append(data, map[string]interface{}{
+			"id":    id,
+			"order": order[id],
+		})
+	}
+	_, diags := c.Put(ctx, "/firewall-control/reorder", map[string]interface{}{
+		"data":   data,
+		"filter": scope.toFilter(),
+	})
+	return diags
+}
+
+// FirewallRuleQueryParams is used to hold query parameters for finding firewall control rules.
+type FirewallRuleQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	GroupIds   []string `json:"groupIds"`
+	OSTypes    []string `json:"osTypes"`
+	RuleIds    []string `json:"ids"`
+	SiteIds    []string `json:"siteIds"`
+	Tenant     *bool    `json:"tenant"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *FirewallRuleQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.OSTypes) > 0 {
+		queryString["osTypes"] = strings.Join(p.OSTypes, ",")
+	}
+	if len(p.RuleIds) > 0 {
+		queryString["ids"] = strings.Join(p.RuleIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if p.Tenant != nil {
+		queryString["tenant"] = fmt.Sprintf("%t", *p.Tenant)
+	}
+	return queryString
+}
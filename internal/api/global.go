@@ -6,6 +6,13 @@ const (
 	// API_BASE_URI is the base URI for the REST API which indicates the version of the API to use.
 	API_BASE_URI = "/web/api/v2.1"
 
+	// DEFAULT_SORT_BY is the field on which list results are sorted when no sort field is given so that the order of
+	// results is deterministic regardless of the API server's own default ordering.
+	DEFAULT_SORT_BY = "id"
+
+	// DEFAULT_SORT_ORDER is the order in which list results are sorted when no sort order is given.
+	DEFAULT_SORT_ORDER = "asc"
+
 	// USER_AGENT is the User-Agent string sent in HTTP requests to the API server.
 	USER_AGENT = "SentinelOne-Singularity-Terraform-Provider"
 )
@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -31,6 +30,75 @@ type Group struct {
 	UpdatedAt         string `json:"updatedAt"`
 }
 
+// GroupBody is used to hold the attributes used for creating or updating a group.
+type GroupBody struct {
+	Description *string `json:"description"`
+	FilterId    *string `json:"filterId"`
+	Inherits    *bool   `json:"inherits"`
+	Name        *string `json:"name"`
+	Rank        *int64  `json:"rank"`
+	SiteId      *string `json:"siteId"`
+	Type        *string `json:"type"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *GroupBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.FilterId != nil {
+		body["filterId"] = *b.FilterId
+	}
+	if b.Inherits != nil {
+		body["inherits"] = *b.Inherits
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.Rank != nil {
+		body["rank"] = *b.Rank
+	}
+	if b.SiteId != nil {
+		body["siteId"] = *b.SiteId
+	}
+	if b.Type != nil {
+		body["type"] = *b.Type
+	}
+	return map[string]interface{}{
+		"data": body,
+	}
+}
+
+// CreateGroup creates a new group with the given attributes and returns the new group.
+func (c *client) CreateGroup(ctx context.Context, body GroupBody) (*Group, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/groups", body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var group Group
+	if err := c.unmarshal(result.Data, &group); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Group object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_GROUP_CREATE_GROUP,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &group, diags
+}
+
+// DeleteGroup deletes the group with the matching ID.
+func (c *client) DeleteGroup(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, fmt.Sprintf("/groups/%s", id), map[string]interface{}{})
+	return diags
+}
+
 // FindGroups returns a list of groups found based on the given query parameters.
 func (c *client) FindGroups(ctx context.Context, queryParams GroupQueryParams) ([]Group, diag.Diagnostics) {
 	var groups []Group
@@ -45,7 +113,7 @@ func (c *client) FindGroups(ctx context.Context, queryParams GroupQueryParams) (
 
 		// parse the response
 		var page []Group
-		if err := json.Unmarshal(result.Data, &page); err != nil {
+		if err := c.unmarshal(result.Data, &page); err != nil {
 			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
 				"list of Group objects.\n\nError: %s", err.Error())
 			tflog.Error(ctx, msg, map[string]interface{}{
@@ -100,7 +168,7 @@ func (c *client) GetGroup(ctx context.Context, id string) (*Group, diag.Diagnost
 
 	// parse the data returned
 	var groups []Group
-	if err := json.Unmarshal(result.Data, &groups); err != nil {
+	if err := c.unmarshal(result.Data, &groups); err != nil {
 		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
 			"Group object.\n\nError: %s", err.Error())
 		tflog.Error(ctx, msg, map[string]interface{}{
@@ -113,6 +181,29 @@ func (c *client) GetGroup(ctx context.Context, id string) (*Group, diag.Diagnost
 	return &groups[0], diags
 }
 
+// UpdateGroup updates the group with the matching ID using the given attributes and returns the updated group.
+func (c *client) UpdateGroup(ctx context.Context, id string, body GroupBody) (*Group, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/groups/%s", id), body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var group Group
+	if err := c.unmarshal(result.Data, &group); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Group object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_GROUP_UPDATE_GROUP,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &group, diags
+}
+
 // GroupQueryParams is used to hold query parameters for finding groups.
 type GroupQueryParams struct {
 	AccountIds        []string `json:"accountIds"`
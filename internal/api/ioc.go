@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// IOC defines the API model for a threat intelligence indicator of compromise.
+type IOC struct {
+	CreationTime string `json:"creationTime"`
+	Description  string `json:"description"`
+	Source       string `json:"source"`
+	Type         string `json:"type"`
+	UUID         string `json:"uuid"`
+	ValidUntil   string `json:"validUntil"`
+	Value        string `json:"value"`
+}
+
+// IOCBody is used to hold the attributes used for creating an indicator of compromise.
+type IOCBody struct {
+	Description *string
+	Source      string
+	Type        string
+	ValidUntil  *string
+	Value       string
+}
+
+// toBody converts the object into the request body for the API.
+func (b *IOCBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{
+		"method": "EQUALS",
+		"source": b.Source,
+		"type":   b.Type,
+		"value":  b.Value,
+	}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.ValidUntil != nil {
+		body["validUntil"] = *b.ValidUntil
+	}
+	return body
+}
+
+// CreateIOC creates a new indicator of compromise within the given scope and returns the new indicator.
+//
+// The API accepts a list of indicators but only a single one is created at a time so that each can be tracked on
+// its own.
+func (c *client) CreateIOC(ctx context.Context, scope Scope, body IOCBody) (*IOC, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/threat-intelligence/iocs", map[string]interface{}{
+		"data":   []map[string]interface{}{body.toBody()},
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var iocs []IOC
+	if err := c.unmarshal(result.Data, &iocs); err != nil || len(iocs) != 1 {
+		errMsg := fmt.Sprintf("expected 1 IOC but %d were returned", len(iocs))
+		if err != nil {
+			errMsg = err.Error()
+		}
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"IOC object.\n\nError: %s", errMsg)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               errMsg,
+			"internal_error_code": plugin.ERR_API_IOC_CREATE_IOC,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &iocs[0], diags
+}
+
+// CreateIOCs creates a list of indicators of compromise within the given scope with a single API call and returns
+// the new indicators.
+//
+// The caller is responsible for keeping the list small enough to fit within the request size limit of the API.
+func (c *client) CreateIOCs(ctx context.Context, scope Scope, bodies []IOCBody) ([]IOC, diag.Diagnostics) {
+	data := []map[string]interface{}{}
+	for _, body := range bodies {
+		data = append(data, body.toBody())
+	}
+
+	// query the API
+	result, diags := c.Post(ctx, "/threat-intelligence/iocs", map[string]interface{}{
+		"data":   data,
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var iocs []IOC
+	if err := c.unmarshal(result.Data, &iocs); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"list of IOC objects.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_IOC_CREATE_IOCS,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return iocs, diags
+}
+
+// DeleteIOC deletes the indicator of compromise with the matching UUID from the given scope.
+func (c *client) DeleteIOC(ctx context.Context, scope Scope, uuid string) diag.Diagnostics {
+	return c.DeleteIOCs(ctx, scope, []string{uuid})
+}
+
+// DeleteIOCs deletes the indicators of compromise with the matching UUIDs from the given scope with a single API
+// call.
+func (c *client) DeleteIOCs(ctx context.Context, scope Scope, uuids []string) diag.Diagnostics {
+	filter := scope.toFilter()
+	filter["uuids"] = uuids
+	_, diags := c.Delete(ctx, "/threat-intelligence/iocs", map[string]interface{}{
+		"filter": filter,
+	})
+	return diags
+}
+
+// FindIOCs returns a list of indicators of compromise found based on the given query parameters.
+func (c *client) FindIOCs(ctx context.Context, queryParams IOCQueryParams) ([]IOC, diag.Diagnostics) {
+	var iocs []IOC
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/threat-intelligence/iocs", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []IOC
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of IOC objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_IOC_FIND_IOCS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		iocs = append(iocs, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return iocs, diags
+}
+
+// IOCQueryParams is used to hold query parameters for finding indicators of compromise.
+type IOCQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	SiteIds    []string `json:"siteIds"`
+	Types      []string `json:"type"`
+	UUIDs      []string `json:"uuids"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *IOCQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if len(p.Types) > 0 {
+		queryString["type"] = strings.Join(p.Types, ",")
+	}
+	if len(p.UUIDs) > 0 {
+		queryString["uuids"] = strings.Join(p.UUIDs, ",")
+	}
+	return queryString
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// unmarshal parses the given JSON data into the given API model.
+//
+// When strict decoding is enabled, any field in the data which does not exist in the model is treated as an error
+// so that changes to the console's schema are noticed. Otherwise unknown fields are silently ignored.
+func (c *client) unmarshal(data []byte, v interface{}) error {
+	if !c.strictDecoding {
+		return json.Unmarshal(data, v)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// normalizeIds rewrites the given JSON data so that any ID which the API returned as a number is returned as a
+// string instead.
+//
+// Most endpoints return IDs as strings because they are too large to be represented exactly by a float64, but a
+// few return them as numbers, which fails to parse into the string fields of the API models. A field is treated as
+// an ID if it is named "id", ends with "Id" or ends with "Ids" (a list of IDs). Numbers are decoded as json.Number
+// so their exact digits are preserved.
+func normalizeIds(data json.RawMessage) (json.RawMessage, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if !normalizeIdsIn(value) {
+		return data, nil
+	}
+	return json.Marshal(value)
+}
+
+// normalizeIdsIn converts any numeric IDs found within the given decoded JSON value into strings in place and
+// returns whether or not anything was changed.
+func normalizeIdsIn(value interface{}) bool {
+	changed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			switch {
+			case key == "id" || strings.HasSuffix(key, "Id"):
+				if n, ok := field.(json.Number); ok {
+					v[key] = n.String()
+					changed = true
+					continue
+				}
+			case strings.HasSuffix(key, "Ids"):
+				if list, ok := field.([]interface{}); ok {
+					for i, e := range list {
+						if n, ok := e.(json.Number); ok {
+							list[i] = n.String()
+							changed = true
+						}
+					}
+					continue
+				}
+			}
+			if normalizeIdsIn(field) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, e := range v {
+			if normalizeIdsIn(e) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// rawFilterToStringMap converts the given raw filter, as decoded from JSON, into a string map for query parameters.
+//
+// Lists are joined with commas in the same way as the typed query parameters, null values are dropped and any
+// nested object is passed as its JSON encoding.
+func rawFilterToStringMap(raw map[string]interface{}) map[string]string {
+	queryString := map[string]string{}
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case []interface{}:
+			values := []string{}
+			for _, item := range v {
+				values = append(values, rawFilterValueToString(item))
+			}
+			queryString[key] = strings.Join(values, ",")
+		default:
+			queryString[key] = rawFilterValueToString(v)
+		}
+	}
+	return queryString
+}
+
+// rawFilterValueToString converts a single raw filter value into its query parameter representation.
+func rawFilterValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool, float64:
+		return fmt.Sprintf("%v", v)
+	}
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Kubernetes admission policy constants
+const (
+	K8S_ADMISSION_ACTION_ALERT = "alert"
+	K8S_ADMISSION_ACTION_BLOCK = "block"
+)
+
+// K8sAdmissionPolicy defines the API model for a Kubernetes admission and runtime policy enforced by the CWPP agent.
+type K8sAdmissionPolicy struct {
+	Action             string   `json:"action"`
+	AllowedImages      []string `json:"allowedImages"`
+	BlockedVerbs       []string `json:"blockedVerbs"`
+	ClusterNames       []string `json:"clusterNames"`
+	CreatedAt          string   `json:"createdAt"`
+	Description        string   `json:"description"`
+	Enabled            bool     `json:"enabled"`
+	ExcludedNamespaces []string `json:"excludedNamespaces"`
+	Id                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Namespaces         []string `json:"namespaces"`
+	UpdatedAt          string   `json:"updatedAt"`
+}
+
+// K8sAdmissionPolicyBody is used to hold the attributes used for creating or updating a policy.
+type K8sAdmissionPolicyBody struct {
+	Action             *string
+	AllowedImages      []string
+	BlockedVerbs       []string
+	ClusterNames       []string
+	Description        *string
+	Enabled            *bool
+	ExcludedNamespaces []string
+	Name               *string
+	Namespaces         []string
+}
+
+// toBody converts the object into the request body for the API.
+func (b *K8sAdmissionPolicyBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Action != nil {
+		body["action"] = *b.Action
+	}
+	if b.AllowedImages != nil {
+		body["allowedImages"] = b.AllowedImages
+	}
+	if b.BlockedVerbs != nil {
+		body["blockedVerbs"] = b.BlockedVerbs
+	}
+	if b.ClusterNames != nil {
+		body["clusterNames"] = b.ClusterNames
+	}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.ExcludedNamespaces != nil {
+		body["excludedNamespaces"] = b.ExcludedNamespaces
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.Namespaces != nil {
+		body["namespaces"] = b.Namespaces
+	}
+	return body
+}
+
+// CreateK8sAdmissionPolicy creates a new Kubernetes admission policy within the given scope and returns the new policy.
+func (c *client) CreateK8sAdmissionPolicy(ctx context.Context, scope Scope, body K8sAdmissionPolicyBody) (
+	*K8sAdmissionPolicy, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/cloud-native-security/k8s-admission-policies", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy K8sAdmissionPolicy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"K8sAdmissionPolicy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_K8S_ADMISSION_POLICY_CREATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// DeleteK8sAdmissionPolicy deletes the Kubernetes admission policy with the matching ID.
+func (c *client) DeleteK8sAdmissionPolicy(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/cloud-native-security/k8s-admission-policies", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindK8sAdmissionPolicies returns a list of Kubernetes admission policies found based on the given query parameters.
+func (c *client) FindK8sAdmissionPolicies(ctx context.Context, queryParams K8sAdmissionPolicyQueryParams) (
+	[]K8sAdmissionPolicy, diag.Diagnostics) {
+
+	var policies []K8sAdmissionPolicy
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/cloud-native-security/k8s-admission-policies", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []K8sAdmissionPolicy
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of K8sAdmissionPolicy objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_K8S_ADMISSION_POLICY_FIND,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		policies = append(policies, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return policies, diags
+}
+
+// UpdateK8sAdmissionPolicy updates the Kubernetes admission policy with the matching ID using the given attributes
+// and returns the updated policy.
+func (c *client) UpdateK8sAdmissionPolicy(ctx context.Context, id string, body K8sAdmissionPolicyBody) (
+	*K8sAdmissionPolicy, diag.Diagnostics) {
+
+	// query the API
+	path := fmt.Sprintf("/cloud-native-security/k8s-admission-policies/%s", id)
+	result, diags := c.Put(ctx, path, map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy K8sAdmissionPolicy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"K8sAdmissionPolicy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_K8S_ADMISSION_POLICY_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// K8sAdmissionPolicyQueryParams is used to hold query parameters for finding Kubernetes admission policies.
+type K8sAdmissionPolicyQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	PolicyIds  []string `json:"ids"`
+	SiteIds    []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *K8sAdmissionPolicyQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.PolicyIds) > 0 {
+		queryString["ids"] = strings.Join(p.PolicyIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
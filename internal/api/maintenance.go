@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MAINTENANCE_RETRY_INTERVAL is how long to wait before retrying a request while the console is under
+	// maintenance when the console does not say how long to wait.
+	MAINTENANCE_RETRY_INTERVAL = 30 * time.Second
+)
+
+// isMaintenanceResponse returns whether or not the response is the console's maintenance page.
+//
+// The API only ever returns JSON or file downloads, so an HTML page is always served by the front end while
+// the console itself is unavailable.
+func isMaintenanceResponse(resp *http.Response) bool {
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		return true
+	}
+	return strings.HasPrefix(strings.ToLower(resp.Header.Get("Content-Type")), "text/html")
+}
+
+// maintenanceRetryDelay returns how long to wait before retrying a request, honoring the Retry-After header
+// when the console sends one.
+func maintenanceRetryDelay(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(resp.Header.Get("Retry-After")); err == nil && time.Until(at) > 0 {
+		return time.Until(at)
+	}
+	return MAINTENANCE_RETRY_INTERVAL
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// MarketplaceApp defines the API model for an application installed from the Singularity Marketplace.
+type MarketplaceApp struct {
+	ApplicationId string                 `json:"applicationCatalogId"`
+	Config        map[string]interface{} `json:"config"`
+	CreatedAt     string                 `json:"createdAt"`
+	Enabled       bool                   `json:"enabled"`
+	Id            string                 `json:"id"`
+	Name          string                 `json:"name"`
+	UpdatedAt     string                 `json:"updatedAt"`
+}
+
+// MarketplaceAppBody is used to hold the attributes used for installing or updating a marketplace application.
+type MarketplaceAppBody struct {
+	ApplicationId *string
+	Config        map[string]string
+	Enabled       *bool
+}
+
+// toBody converts the object into the request body for the API.
+func (b *MarketplaceAppBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.ApplicationId != nil {
+		body["applicationCatalogId"] = *b.ApplicationId
+	}
+	if b.Config != nil {
+		body["config"] = b.Config
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	return body
+}
+
+// InstallMarketplaceApp installs a marketplace application within the given scope and returns the installed
+// application.
+func (c *client) InstallMarketplaceApp(ctx context.Context, scope Scope, body MarketplaceAppBody) (*MarketplaceApp,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/singularity-marketplace/applications", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var app MarketplaceApp
+	if err := c.unmarshal(result.Data, &app); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"MarketplaceApp object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_MARKETPLACE_APP_CREATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &app, diags
+}
+
+// UninstallMarketplaceApp uninstalls the marketplace application with the matching ID.
+func (c *client) UninstallMarketplaceApp(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/singularity-marketplace/applications", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindMarketplaceApps returns a list of installed marketplace applications found based on the given query parameters.
+func (c *client) FindMarketplaceApps(ctx context.Context, queryParams MarketplaceAppQueryParams) ([]MarketplaceApp,
+	diag.Diagnostics) {
+
+	var apps []MarketplaceApp
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/singularity-marketplace/applications", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []MarketplaceApp
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of MarketplaceApp objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_MARKETPLACE_APP_FIND,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		apps = append(apps, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return apps, diags
+}
+
+// UpdateMarketplaceApp updates the installed marketplace application with the matching ID using the given attributes
+// and returns the updated application.
+func (c *client) UpdateMarketplaceApp(ctx context.Context, id string, body MarketplaceAppBody) (*MarketplaceApp,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/singularity-marketplace/applications/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var app MarketplaceApp
+	if err := c.unmarshal(result.Data, &app); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"MarketplaceApp object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_MARKETPLACE_APP_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &app, diags
+}
+
+// MarketplaceAppQueryParams is used to hold query parameters for finding installed marketplace applications.
+type MarketplaceAppQueryParams struct {
+	AccountIds        []string `json:"accountIds"`
+	MarketplaceAppIds []string `json:"ids"`
+	SiteIds           []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *MarketplaceAppQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.MarketplaceAppIds) > 0 {
+		queryString["ids"] = strings.Join(p.MarketplaceAppIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Singularity Mobile policy constants
+const (
+	MOBILE_THREAT_ACTION_ALERT  = "alert"
+	MOBILE_THREAT_ACTION_BLOCK  = "block"
+	MOBILE_THREAT_ACTION_IGNORE = "ignore"
+
+	MOBILE_MDM_PROVIDER_INTUNE        = "intune"
+	MOBILE_MDM_PROVIDER_JAMF          = "jamf"
+	MOBILE_MDM_PROVIDER_MOBILEIRON    = "mobileiron"
+	MOBILE_MDM_PROVIDER_WORKSPACE_ONE = "workspace_one"
+)
+
+// MobilePolicy defines the API model for the Singularity Mobile policy of a scope.
+type MobilePolicy struct {
+	Android       *MobileOsPolicy `json:"android"`
+	InheritedFrom string          `json:"inheritedFrom"`
+	Ios           *MobileOsPolicy `json:"ios"`
+	Mdm           *MobileMdm      `json:"mdm"`
+}
+
+// MobileOsPolicy defines the API model for the threat policy settings applied to devices running an OS.
+type MobileOsPolicy struct {
+	CompromisedDeviceAction string `json:"compromisedDeviceAction"`
+	Enabled                 bool   `json:"enabled"`
+	MalwareAction           string `json:"malwareAction"`
+	MinimumOsVersion        string `json:"minimumOsVersion"`
+	NetworkThreatAction     string `json:"networkThreatAction"`
+	PhishingProtection      bool   `json:"phishingProtection"`
+}
+
+// MobileMdm defines the API model for the mobile device management integration used by Singularity Mobile.
+type MobileMdm struct {
+	ApiKey              string `json:"apiKey,omitempty"`
+	ApiUrl              string `json:"apiUrl"`
+	Enabled             bool   `json:"enabled"`
+	Provider            string `json:"provider"`
+	SyncIntervalMinutes int64  `json:"syncIntervalMinutes"`
+	TenantId            string `json:"tenantId"`
+}
+
+// MobilePolicyBody is used to hold the attributes used for updating a Singularity Mobile policy.
+//
+// Any section which is nil is left unchanged.
+type MobilePolicyBody struct {
+	Android *MobileOsPolicy
+	Ios     *MobileOsPolicy
+	Mdm     *MobileMdm
+}
+
+// toBody converts the object into the request body for the API.
+func (b *MobilePolicyBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Android != nil {
+		body["android"] = b.Android
+	}
+	if b.Ios != nil {
+		body["ios"] = b.Ios
+	}
+	if b.Mdm != nil {
+		body["mdm"] = b.Mdm
+	}
+	return body
+}
+
+// GetMobilePolicy returns the effective Singularity Mobile policy of the given scope.
+func (c *client) GetMobilePolicy(ctx context.Context, scope Scope) (*MobilePolicy, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, fmt.Sprintf("%s/mobile-policy", scope.uriPrefix()), map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy MobilePolicy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"MobilePolicy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_MOBILE_POLICY_GET,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// RevertMobilePolicy reverts the Singularity Mobile policy of the given scope so that it is inherited from its
+// parent.
+func (c *client) RevertMobilePolicy(ctx context.Context, scope Scope) diag.Diagnostics {
+	_, diags := c.Put(ctx, fmt.Sprintf("%s/revert-mobile-policy", scope.uriPrefix()), map[string]interface{}{})
+	return diags
+}
+
+// UpdateMobilePolicy updates the Singularity Mobile policy of the given scope using the given attributes and returns
+// the updated policy.
+func (c *client) UpdateMobilePolicy(ctx context.Context, scope Scope, body MobilePolicyBody) (*MobilePolicy,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("%s/mobile-policy", scope.uriPrefix()), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy MobilePolicy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"MobilePolicy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_MOBILE_POLICY_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
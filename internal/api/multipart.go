@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// MultipartFile holds a file to upload as part of a multipart form request.
+type MultipartFile struct {
+	// Content contains the contents of the file.
+	Content []byte
+
+	// Field is the name of the form field holding the file.
+	Field string
+
+	// Name is the file name sent to the API server.
+	Name string
+}
+
+// PostMultipart executes an HTTP POST query with the given fields and files encoded as a multipart form.
+//
+// Callers can check for errors using the HasErrors function on the Diagnostics object returned.
+func (c *client) PostMultipart(ctx context.Context, uri string, fields map[string]string, files []MultipartFile) (
+	*apiResponse, diag.Diagnostics) {
+	return c.doMultipartAndParse(ctx, http.MethodPost, uri, fields, files)
+}
+
+// PutMultipart executes an HTTP PUT query with the given fields and files encoded as a multipart form.
+//
+// Callers can check for errors using the HasErrors function on the Diagnostics object returned.
+func (c *client) PutMultipart(ctx context.Context, uri string, fields map[string]string, files []MultipartFile) (
+	*apiResponse, diag.Diagnostics) {
+	return c.doMultipartAndParse(ctx, http.MethodPut, uri, fields, files)
+}
+
+// doMultipartAndParse handles encoding a multipart form, executing the REST API query, verifying if any errors
+// occurred and then parsing the API response body.
+//
+// Callers can check for errors using the HasErrors function on the Diagnostics object returned.
+func (c *client) doMultipartAndParse(ctx context.Context, method, uri string, fields map[string]string,
+	files []MultipartFile) (*apiResponse, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	// build the request URL
+	uri = strings.TrimPrefix(uri, "/")
+	url := fmt.Sprintf("%s/%s", c.baseURL, uri)
+
+	// configure log context - file contents are never logged
+	ctx = tflog.SetField(ctx, "method", method)
+	ctx = tflog.SetField(ctx, "url", url)
+	ctx = tflog.SetField(ctx, "api_token", c.apiToken)
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "api_token")
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, secretFieldsRegex)
+	ctx = tflog.MaskMessageRegexes(ctx, secretFieldsRegex)
+	ctx = tflog.SetField(ctx, "fields", fields)
+
+	// encode the form
+	payload, contentType, err := encodeMultipart(fields, files)
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while attempting to create a request to the API Server.\n\n"+
+			"Error: %s\nURL: %s\nMethod: %s", err.Error(), url, method)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_CLIENT_MULTIPART,
+		})
+		diags.AddError("API Request Error", msg)
+		return nil, diags
+	}
+
+	// execute the actual request
+	resp, diags := c.doPayload(ctx, method, url, map[string]string{}, payload, contentType)
+	if diags.HasError() {
+		return nil, diags
+	}
+	return c.parseResponse(ctx, resp, method, url)
+}
+
+// encodeMultipart encodes the given fields and files as a multipart form and returns the encoded form along with
+// its content type.
+//
+// Fields are written in sorted order so the encoded form is deterministic.
+func encodeMultipart(fields map[string]string, files []MultipartFile) ([]byte, string, error) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writer.WriteField(name, fields[name]); err != nil {
+			return nil, "", err
+		}
+	}
+	for _, file := range files {
+		part, err := writer.CreateFormFile(file.Field, file.Name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := part.Write(file.Content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buffer.Bytes(), writer.FormDataContentType(), nil
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// NetworkLocation defines the API model for a network location used by location-aware firewall policies.
+type NetworkLocation struct {
+	CreatedAt         string                         `json:"createdAt"`
+	Description       string                         `json:"description"`
+	DNSLookup         NetworkLocationDNSLookupValues `json:"dnsLookup"`
+	DNSServers        NetworkLocationValues          `json:"dnsServers"`
+	GatewayIPs        NetworkLocationValues          `json:"gatewayIps"`
+	Id                string                         `json:"id"`
+	IPAddresses       NetworkLocationValues          `json:"ipAddresses"`
+	Name              string                         `json:"name"`
+	NetworkInterfaces NetworkLocationValues          `json:"networkInterfaces"`
+	Operator          string                         `json:"operator"`
+	UpdatedAt         string                         `json:"updatedAt"`
+}
+
+// NetworkLocationValues defines the API model for a criterion of a network location which matches a list of values.
+type NetworkLocationValues struct {
+	Enabled bool     `json:"enabled"`
+	Values  []string `json:"values"`
+}
+
+// NetworkLocationDNSLookupValues defines the API model for the DNS lookup criterion of a network location.
+type NetworkLocationDNSLookupValues struct {
+	Enabled bool                       `json:"enabled"`
+	Values  []NetworkLocationDNSLookup `json:"values"`
+}
+
+// NetworkLocationDNSLookup defines the API model for a host which must resolve to the given IP address.
+type NetworkLocationDNSLookup struct {
+	Host string `json:"host"`
+	IP   string `json:"ip"`
+}
+
+// NetworkLocationBody is used to hold the attributes used for creating or updating a network location.
+//
+// A criterion is enabled when it has at least one value and disabled otherwise.
+type NetworkLocationBody struct {
+	Description       *string                    `json:"description"`
+	DNSLookup         []NetworkLocationDNSLookup `json:"dnsLookup"`
+	DNSServers        []string                   `json:"dnsServers"`
+	GatewayIPs        []string                   `json:"gatewayIps"`
+	IPAddresses       []string                   `json:"ipAddresses"`
+	Name              *string                    `json:"name"`
+	NetworkInterfaces []string                   `json:"networkInterfaces"`
+	Operator          *string                    `json:"operator"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *NetworkLocationBody) toBody() map[string]interface{} {
+	criterion := func(values []string) map[string]interface{} {
+		if values == nil {
+			values = []string{}
+		}
+		return map[string]interface{}{
+			"enabled": len(values) > 0,
+			"values":  values,
+		}
+	}
+	dnsLookup := b.DNSLookup
+	if dnsLookup == nil {
+		dnsLookup = []NetworkLocationDNSLookup{}
+	}
+	body := map[string]interface{}{
+		"dnsLookup": map[string]interface{}{
+			"enabled": len(dnsLookup) > 0,
+			"values":  dnsLookup,
+		},
+		"dnsServers":        criterion(b.DNSServers),
+		"gatewayIps":        criterion(b.GatewayIPs),
+		"ipAddresses":       criterion(b.IPAddresses),
+		"networkInterfaces": criterion(b.NetworkInterfaces),
+	}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.Operator != nil {
+		body["operator"] = *b.Operator
+	}
+	return body
+}
+
+// CreateNetworkLocation creates a new network location within the given scope and returns the new location.
+func (c *client) CreateNetworkLocation(ctx context.Context, scope Scope, body NetworkLocationBody) (
+	*NetworkLocation, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/locations", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var location NetworkLocation
+	if err := c.unmarshal(result.Data, &location); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"NetworkLocation object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_NETWORK_LOCATION_CREATE_LOCATION,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &location, diags
+}
+
+// DeleteNetworkLocation deletes the network location with the matching ID.
+func (c *client) DeleteNetworkLocation(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/locations", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindNetworkLocations returns a list of network locations found based on the given query parameters.
+func (c *client) FindNetworkLocations(ctx context.Context, queryParams NetworkLocationQueryParams) (
+	[]NetworkLocation, diag.Diagnostics) {
+
+	var locations []NetworkLocation
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/locations", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []NetworkLocation
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of NetworkLocation objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_NETWORK_LOCATION_FIND_LOCATIONS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		locations = append(locations, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return locations, diags
+}
+
+// UpdateNetworkLocation updates the network location with the matching ID using the given attributes and returns
+// the updated location.
+func (c *client) UpdateNetworkLocation(ctx context.Context, id string, body NetworkLocationBody) (*NetworkLocation,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/locations/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var location NetworkLocation
+	if err := c.unmarshal(result.Data, &location); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"NetworkLocation object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_NETWORK_LOCATION_UPDATE_LOCATION,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &location, diags
+}
+
+// NetworkLocationQueryParams is used to hold query parameters for finding network locations.
+type NetworkLocationQueryParams struct {
+	AccountIds  []string `json:"accountIds"`
+	GroupIds    []string `json:"groupIds"`
+	LocationIds []string `json:"ids"`
+	SiteIds     []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *NetworkLocationQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.LocationIds) > 0 {
+		queryString["ids"] = strings.Join(p.LocationIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
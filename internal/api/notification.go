@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// NotificationRule defines the API model for the channels through which notifications for a single type of event
+// are sent.
+type NotificationRule struct {
+	Email   bool `json:"email"`
+	Syslog  bool `json:"syslog"`
+	Webhook bool `json:"webhook"`
+}
+
+// NotificationRuleBody is used to hold the attributes used for updating a notification rule.
+type NotificationRuleBody struct {
+	Email   *bool `json:"email"`
+	Syslog  *bool `json:"syslog"`
+	Webhook *bool `json:"webhook"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *NotificationRuleBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Email != nil {
+		body["email"] = *b.Email
+	}
+	if b.Syslog != nil {
+		body["syslog"] = *b.Syslog
+	}
+	if b.Webhook != nil {
+		body["webhook"] = *b.Webhook
+	}
+	return body
+}
+
+// GetNotificationRule returns the notification rule for the given type of event within the given scope.
+//
+// Event types which have never been configured have every channel disabled.
+func (c *client) GetNotificationRule(ctx context.Context, scope Scope, eventType string) (*NotificationRule,
+	diag.Diagnostics) {
+
+	// query the API
+	settings, diags := c.GetNotificationSettings(ctx, scope)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var rules map[string]NotificationRule
+	if err := c.unmarshal(settings, &rules); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"map of NotificationRule objects.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_NOTIFICATION_GET_RULE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	rule := rules[eventType]
+	return &rule, diags
+}
+
+// GetNotificationSettings returns the raw notification settings of the given scope exactly as they are returned by
+// the API.
+func (c *client) GetNotificationSettings(ctx context.Context, scope Scope) (json.RawMessage, diag.Diagnostics) {
+	result, diags := c.Get(ctx, "/settings/notifications", scope.toStringMap())
+	if diags.HasError() {
+		return nil, diags
+	}
+	return result.Data, diags
+}
+
+// UpdateNotificationRule updates the notification rule for the given type of event within the given scope and
+// returns the updated rule.
+func (c *client) UpdateNotificationRule(ctx context.Context, scope Scope, eventType string,
+	body NotificationRuleBody) (*NotificationRule, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, "/settings/notifications", map[string]interface{}{
+		"data": map[string]interface{}{
+			eventType: body.toBody(),
+		},
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var rules map[string]NotificationRule
+	if err := c.unmarshal(result.Data, &rules); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"map of NotificationRule objects.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_NOTIFICATION_UPDATE_RULE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	rule := rules[eventType]
+	return &rule, diags
+}
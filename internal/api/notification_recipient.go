@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// NotificationRecipient defines the API model for a recipient of email notifications and scheduled reports.
+type NotificationRecipient struct {
+	CreatedAt string `json:"createdAt"`
+	Email     string `json:"email"`
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// NotificationRecipientBody is used to hold the attributes used for creating or updating a notification recipient.
+type NotificationRecipientBody struct {
+	Email *string
+	Name  *string
+}
+
+// toBody converts the object into the request body for the API.
+func (b *NotificationRecipientBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Email != nil {
+		body["email"] = *b.Email
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	return body
+}
+
+// CreateNotificationRecipient creates a new notification recipient within the given scope and returns the new
+// recipient.
+func (c *client) CreateNotificationRecipient(ctx context.Context, scope Scope, body NotificationRecipientBody) (
+	*NotificationRecipient, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/settings/recipients", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var recipient NotificationRecipient
+	if err := c.unmarshal(result.Data, &recipient); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"NotificationRecipient object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_NOTIFICATION_RECIPIENT_CREATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &recipient, diags
+}
+
+// DeleteNotificationRecipient deletes the notification recipient with the matching ID.
+func (c *client) DeleteNotificationRecipient(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/settings/recipients", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindNotificationRecipients returns a list of notification recipients found based on the given query parameters.
+func (c *client) FindNotificationRecipients(ctx context.Context, queryParams NotificationRecipientQueryParams) (
+	[]NotificationRecipient, diag.Diagnostics) {
+
+	var recipients []NotificationRecipient
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/settings/recipients", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []NotificationRecipient
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of NotificationRecipient objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_NOTIFICATION_RECIPIENT_FIND,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		recipients = append(recipients, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return recipients, diags
+}
+
+// UpdateNotificationRecipient updates the notification recipient with the matching ID using the given attributes and
+// returns the updated recipient.
+func (c *client) UpdateNotificationRecipient(ctx context.Context, id string, body NotificationRecipientBody) (
+	*NotificationRecipient, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/settings/recipients/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var recipient NotificationRecipient
+	if err := c.unmarshal(result.Data, &recipient); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"NotificationRecipient object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_NOTIFICATION_RECIPIENT_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &recipient, diags
+}
+
+// NotificationRecipientQueryParams is used to hold query parameters for finding notification recipients.
+type NotificationRecipientQueryParams struct {
+	AccountIds               []string `json:"accountIds"`
+	NotificationRecipientIds []string `json:"ids"`
+	SiteIds                  []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *NotificationRecipientQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.NotificationRecipientIds) > 0 {
+		queryString["ids"] = strings.Join(p.NotificationRecipientIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
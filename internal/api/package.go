@@ -2,9 +2,9 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -49,6 +49,10 @@ type packageSite struct {
 }
 
 // DownloadPackage is responsible for downloading the package with the given ID to a local path.
+//
+// The package is downloaded to a temporary folder alongside the destination and only moved into place once it has
+// been downloaded completely, so a failed download never leaves a partial file at the destination or removes an
+// existing one.
 func (c *client) DownloadPackage(ctx context.Context, id, siteId, path, folderMode, fileMode string,
 	overwrite bool) (string, int64, string, string, diag.Diagnostics) {
 
@@ -59,43 +63,59 @@ func (c *client) DownloadPackage(ctx context.Context, id, siteId, path, folderMo
 	}
 	ctx = tflog.SetField(ctx, "file", absPath)
 
-	// create the file for writing
-	outfile, diags := plugin.CreateFile(ctx, absPath, folderMode, fileMode, overwrite)
+	// create the temporary file for writing
+	tempDir, diags := plugin.NewTempDir(ctx, "package-download", filepath.Dir(absPath), folderMode)
+	if diags.HasError() {
+		return "", 0, "", "", diags
+	}
+	defer tempDir.Cleanup(ctx)
+	filename := filepath.Base(absPath)
+	outfile, diags := tempDir.CreateFile(ctx, filename)
 	if diags.HasError() {
 		return "", 0, "", "", diags
 	}
 
-	// stream the download package into the output file
+	// stream the download package into the temporary file
 	diags = c.GetStream(ctx, fmt.Sprintf("/update/agent/download/%s/%s", siteId, id), map[string]string{},
 		outfile)
+	outfile.Close()
 	if diags.HasError() {
 		return "", 0, "", "", diags
 	}
-	outfile.Close()
 
-	// get the SHA1 and size of the destination file
-	fileInfo, err := os.Stat(absPath)
+	// get the SHA1 and size of the downloaded file
+	tempPath := tempDir.Path(filename)
+	fileInfo, err := os.Stat(tempPath)
 	if err != nil {
 		msg := fmt.Sprintf("An unexpected error occurred while retrieving information about the package file.\n\n"+
-			"Error: %s\nFile: %s", err.Error(), absPath)
+			"Error: %s\nFile: %s", err.Error(), tempPath)
+		if os.IsNotExist(err) {
+			msg += plugin.QuarantineHint(tempPath)
+		}
 		tflog.Error(ctx, msg, map[string]interface{}{
 			"error":               err.Error(),
 			"internal_error_code": plugin.ERR_API_PACKAGE_DOWNLOAD_PACKAGE,
 		})
 		diags.AddError("Unexpected Internal Error", msg)
-		os.Remove(absPath)
 		return "", 0, "", "", diags
 	}
-	sha1, diags := plugin.GetFileSHA1(ctx, absPath)
+	sha1, diags := plugin.GetFileSHA1(ctx, tempPath)
 	if diags.HasError() {
-		os.Remove(absPath)
+		if hint := plugin.QuarantineHint(tempPath); hint != "" {
+			diags.AddWarning("Possible Quarantine", strings.TrimSpace(hint))
+		}
 		return "", 0, "", "", diags
 	}
 
-	// finally get the version of the downloaded package
+	// get the version of the downloaded package
 	pkg, diags := c.GetPackage(ctx, id)
 	if diags.HasError() {
-		os.Remove(absPath)
+		return "", 0, "", "", diags
+	}
+
+	// finally move the package into place
+	diags = tempDir.Commit(ctx, filename, absPath, folderMode, fileMode, overwrite)
+	if diags.HasError() {
 		return "", 0, "", "", diags
 	}
 	return absPath, fileInfo.Size(), sha1, pkg.Version, diags
@@ -115,7 +135,7 @@ func (c *client) FindPackages(ctx context.Context, queryParams PackageQueryParam
 
 		// parse the response
 		var page []Package
-		if err := json.Unmarshal(result.Data, &page); err != nil {
+		if err := c.unmarshal(result.Data, &page); err != nil {
 			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
 				"list of Package objects.\n\nError: %s", err.Error())
 			tflog.Error(ctx, msg, map[string]interface{}{
@@ -170,7 +190,7 @@ func (c *client) GetPackage(ctx context.Context, id string) (*Package, diag.Diag
 
 	// parse the data returned
 	var pkgs []Package
-	if err := json.Unmarshal(result.Data, &pkgs); err != nil {
+	if err := c.unmarshal(result.Data, &pkgs); err != nil {
 		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
 			"Package object.\n\nError: %s", err.Error())
 		tflog.Error(ctx, msg, map[string]interface{}{
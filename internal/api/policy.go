@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Policy defines the API model for the protection policy of a scope (eg: a site).
+type Policy struct {
+	AgentNotification        bool          `json:"agentNotification"`
+	AgentUi                  policyAgentUi `json:"agentUi"`
+	AutoMitigationAction     string        `json:"autoMitigationAction"`
+	InheritedFrom            string        `json:"inheritedFrom"`
+	Ioc                      bool          `json:"ioc"`
+	MitigationMode           string        `json:"mitigationMode"`
+	MitigationModeSuspicious string        `json:"mitigationModeSuspicious"`
+	ScanNewAgents            bool          `json:"scanNewAgents"`
+	SnapshotsOn              bool          `json:"snapshotsOn"`
+}
+
+// policyAgentUi defines the API model for the agent UI settings of a policy.
+type policyAgentUi struct {
+	AgentUiOn bool `json:"agentUiOn"`
+}
+
+// PolicyBody is used to hold the attributes used for updating a policy.
+type PolicyBody struct {
+	AgentNotification        *bool   `json:"agentNotification"`
+	AgentUiOn                *bool   `json:"agentUiOn"`
+	AutoMitigationAction     *string `json:"autoMitigationAction"`
+	Ioc                      *bool   `json:"ioc"`
+	MitigationMode           *string `json:"mitigationMode"`
+	MitigationModeSuspicious *string `json:"mitigationModeSuspicious"`
+	ScanNewAgents            *bool   `json:"scanNewAgents"`
+	SnapshotsOn              *bool   `json:"snapshotsOn"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *PolicyBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.AgentNotification != nil {
+		body["agentNotification"] = *b.AgentNotification
+	}
+	if b.AgentUiOn != nil {
+		body["agentUi"] = map[string]interface{}{
+			"agentUiOn": *b.AgentUiOn,
+		}
+	}
+	if b.AutoMitigationAction != nil {
+		body["autoMitigationAction"] = *b.AutoMitigationAction
+	}
+	if b.Ioc != nil {
+		body["ioc"] = *b.Ioc
+	}
+	if b.MitigationMode != nil {
+		body["mitigationMode"] = *b.MitigationMode
+	}
+	if b.MitigationModeSuspicious != nil {
+		body["mitigationModeSuspicious"] = *b.MitigationModeSuspicious
+	}
+	if b.ScanNewAgents != nil {
+		body["scanNewAgents"] = *b.ScanNewAgents
+	}
+	if b.SnapshotsOn != nil {
+		body["snapshotsOn"] = *b.SnapshotsOn
+	}
+	return map[string]interface{}{
+		"data": body,
+	}
+}
+
+// BreakPolicyInheritance stops the given scope from inheriting the policy of its parent.
+//
+// The scope keeps its current effective policy, which is saved as its own so that it no longer follows changes to the
+// parent's policy.
+func (c *client) BreakPolicyInheritance(ctx context.Context, scope Scope) diag.Diagnostics {
+	raw, diags := c.GetScopePolicy(ctx, scope)
+	if diags.HasError() {
+		return diags
+	}
+
+	// save the effective policy back to the scope without the inheritance marker
+	var policy map[string]interface{}
+	if err := c.unmarshal(raw, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Policy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_POLICY_BREAK_INHERITANCE,
+		})
+		diags.AddError("API Response Error", msg)
+		return diags
+	}
+	delete(policy, "inheritedFrom")
+	_, diags = c.Put(ctx, fmt.Sprintf("%s/policy", scope.uriPrefix()), map[string]interface{}{
+		"data": policy,
+	})
+	return diags
+}
+
+// GetPolicy returns the effective policy of the given scope.
+func (c *client) GetPolicy(ctx context.Context, scope Scope) (*Policy, diag.Diagnostics) {
+	raw, diags := c.GetScopePolicy(ctx, scope)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy Policy
+	if err := c.unmarshal(raw, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Policy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_POLICY_GET_POLICY,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// GetScopePolicy returns the raw effective policy of the given scope exactly as it is returned by the API.
+//
+// Unlike GetSitePolicy, every setting in the policy is returned, which is useful for reporting purposes.
+func (c *client) GetScopePolicy(ctx context.Context, scope Scope) (json.RawMessage, diag.Diagnostics) {
+	result, diags := c.Get(ctx, fmt.Sprintf("%s/policy", scope.uriPrefix()), map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+	return result.Data, diags
+}
+
+// GetSitePolicy returns the effective policy of the site with the matching ID.
+func (c *client) GetSitePolicy(ctx context.Context, siteId string) (*Policy, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, fmt.Sprintf("/sites/%s/policy", siteId), map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy Policy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Policy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_POLICY_GET_SITE_POLICY,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// RevertPolicy reverts the policy of the given scope so that it is inherited from its parent.
+func (c *client) RevertPolicy(ctx context.Context, scope Scope) diag.Diagnostics {
+	_, diags := c.Put(ctx, fmt.Sprintf("%s/revert-policy", scope.uriPrefix()), map[string]interface{}{})
+	return diags
+}
+
+// RevertSitePolicy reverts the policy of the site with the matching ID so that it is inherited from its parent.
+func (c *client) RevertSitePolicy(ctx context.Context, siteId string) diag.Diagnostics {
+	_, diags := c.Put(ctx, fmt.Sprintf("/sites/%s/revert-policy", siteId), map[string]interface{}{})
+	return diags
+}
+
+// UpdatePolicy updates the policy of the given scope using the given attributes and returns the updated policy.
+func (c *client) UpdatePolicy(ctx context.Context, scope Scope, body PolicyBody) (*Policy, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("%s/policy", scope.uriPrefix()), body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy Policy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Policy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_POLICY_UPDATE_POLICY,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// UpdateSitePolicy updates the policy of the site with the matching ID using the given attributes and returns the
+// updated policy.
+func (c *client) UpdateSitePolicy(ctx context.Context, siteId string, body PolicyBody) (*Policy,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/sites/%s/policy", siteId), body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy Policy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Policy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_POLICY_UPDATE_SITE_POLICY,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
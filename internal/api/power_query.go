@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// SavedPowerQuery defines the API model for a saved Deep Visibility or Power Query query.
+type SavedPowerQuery struct {
+	CreatedAt     string `json:"createdAt"`
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Query         string `json:"query"`
+	QueryLanguage string `json:"queryType"`
+	Shared        bool   `json:"isShared"`
+	UpdatedAt     string `json:"updatedAt"`
+}
+
+// SavedPowerQueryBody is used to hold the attributes used for creating or updating a saved query.
+type SavedPowerQueryBody struct {
+	Name          *string
+	Query         *string
+	QueryLanguage *string
+	Shared        *bool
+}
+
+// toBody converts the object into the request body for the API.
+func (b *SavedPowerQueryBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.Query != nil {
+		body["query"] = *b.Query
+	}
+	if b.QueryLanguage != nil {
+		body["queryType"] = *b.QueryLanguage
+	}
+	if b.Shared != nil {
+		body["isShared"] = *b.Shared
+	}
+	return body
+}
+
+// CreateSavedPowerQuery creates a new saved query within the given scope and returns the new query.
+func (c *client) CreateSavedPowerQuery(ctx context.Context, scope Scope, body SavedPowerQueryBody) (*SavedPowerQuery,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/dv/saved-queries", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var query SavedPowerQuery
+	if err := c.unmarshal(result.Data, &query); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"SavedPowerQuery object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_POWER_QUERY_CREATE_SAVED_QUERY,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &query, diags
+}
+
+// DeleteSavedPowerQuery deletes the saved query with the matching ID.
+func (c *client) DeleteSavedPowerQuery(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/dv/saved-queries", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindSavedPowerQueries returns a list of saved queries found based on the given query parameters.
+func (c *client) FindSavedPowerQueries(ctx context.Context, queryParams SavedPowerQueryQueryParams) ([]SavedPowerQuery,
+	diag.Diagnostics) {
+
+	var queries []SavedPowerQuery
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/dv/saved-queries", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []SavedPowerQuery
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of SavedPowerQuery objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_POWER_QUERY_FIND_SAVED_QUERIES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		queries = append(queries, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return queries, diags
+}
+
+// UpdateSavedPowerQuery updates the saved query with the matching ID using the given attributes and returns the
+// updated query.
+func (c *client) UpdateSavedPowerQuery(ctx context.Context, id string, body SavedPowerQueryBody) (*SavedPowerQuery,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/dv/saved-queries/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var query SavedPowerQuery
+	if err := c.unmarshal(result.Data, &query); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"SavedPowerQuery object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_POWER_QUERY_UPDATE_SAVED_QUERY,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &query, diags
+}
+
+// SavedPowerQueryQueryParams is used to hold query parameters for finding saved queries.
+type SavedPowerQueryQueryParams struct {
+	AccountIds         []string `json:"accountIds"`
+	SavedPowerQueryIds []string `json:"ids"`
+	SiteIds            []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *SavedPowerQueryQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.SavedPowerQueryIds) > 0 {
+		queryString["ids"] = strings.Join(p.SavedPowerQueryIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
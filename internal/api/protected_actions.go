@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ProtectedActionsSettings defines the API model for the protected actions settings of a scope, which control the
+// sensitive operations that require two-factor authentication before they are performed.
+type ProtectedActionsSettings struct {
+	Actions []string `json:"actions"`
+	Enabled bool     `json:"enabled"`
+}
+
+// ProtectedActionsSettingsBody is used to hold the attributes used for updating the protected actions settings.
+type ProtectedActionsSettingsBody struct {
+	Actions []string `json:"actions"`
+	Enabled *bool    `json:"enabled"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *ProtectedActionsSettingsBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Actions != nil {
+		body["actions"] = b.Actions
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	return body
+}
+
+// GetProtectedActionsSettings returns the protected actions settings of the given scope.
+func (c *client) GetProtectedActionsSettings(ctx context.Context, scope Scope) (*ProtectedActionsSettings,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Get(ctx, "/settings/protected-actions", scope.toStringMap())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var settings ProtectedActionsSettings
+	if err := c.unmarshal(result.Data, &settings); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ProtectedActionsSettings object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_PROTECTED_ACTIONS_GET,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &settings, diags
+}
+
+// UpdateProtectedActionsSettings updates the protected actions settings of the given scope using the given attributes
+// and returns the updated settings.
+func (c *client) UpdateProtectedActionsSettings(ctx context.Context, scope Scope, body ProtectedActionsSettingsBody) (
+	*ProtectedActionsSettings, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, "/settings/protected-actions", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var settings ProtectedActionsSettings
+	if err := c.unmarshal(result.Data, &settings); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ProtectedActionsSettings object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_PROTECTED_ACTIONS_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &settings, diags
+}
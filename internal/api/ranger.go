@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// RangerSettings defines the API model for the Ranger network discovery settings of a site.
+type RangerSettings struct {
+	DeviceDecommissionDays int      `json:"deviceDecommissionDays"`
+	DeviceReviewEnabled    bool     `json:"deviceReviewEnabled"`
+	Enabled                bool     `json:"enabled"`
+	ExcludedSubnets        []string `json:"excludedSubnets"`
+	IncludedSubnets        []string `json:"includedSubnets"`
+	ScanIntervalMinutes    int      `json:"scanIntervalMinutes"`
+}
+
+// RangerSettingsBody is used to hold the attributes used for updating the Ranger settings.
+type RangerSettingsBody struct {
+	DeviceDecommissionDays *int
+	DeviceReviewEnabled    *bool
+	Enabled                *bool
+	ExcludedSubnets        []string
+	IncludedSubnets        []string
+	ScanIntervalMinutes    *int
+}
+
+// toBody converts the object into the request body for the API.
+func (b *RangerSettingsBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.DeviceDecommissionDays != nil {
+		body["deviceDecommissionDays"] = *b.DeviceDecommissionDays
+	}
+	if b.DeviceReviewEnabled != nil {
+		body["deviceReviewEnabled"] = *b.DeviceReviewEnabled
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.ExcludedSubnets != nil {
+		body["excludedSubnets"] = b.ExcludedSubnets
+	}
+	if b.IncludedSubnets != nil {
+		body["includedSubnets"] = b.IncludedSubnets
+	}
+	if b.ScanIntervalMinutes != nil {
+		body["scanIntervalMinutes"] = *b.ScanIntervalMinutes
+	}
+	return body
+}
+
+// GetRangerSettings returns the Ranger settings of the site with the matching ID.
+func (c *client) GetRangerSettings(ctx context.Context, siteId string) (*RangerSettings, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, "/ranger/settings", map[string]string{"siteIds": siteId})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var settings RangerSettings
+	if err := c.unmarshal(result.Data, &settings); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"RangerSettings object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_RANGER_SETTINGS_GET,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &settings, diags
+}
+
+// UpdateRangerSettings updates the Ranger settings of the site with the matching ID using the given attributes and
+// returns the updated settings.
+func (c *client) UpdateRangerSettings(ctx context.Context, siteId string, body RangerSettingsBody) (
+	*RangerSettings, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, "/ranger/settings", map[string]interface{}{
+		"data": body.toBody(),
+		"filter": map[string]interface{}{
+			"siteIds": []string{siteId},
+		},
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var settings RangerSettings
+	if err := c.unmarshal(result.Data, &settings); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"RangerSettings object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_RANGER_SETTINGS_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &settings, diags
+}
+
+// RangerDeployJob defines the API model for a job deploying agents to unmanaged devices discovered by Ranger.
+type RangerDeployJob struct {
+	Affected int    `json:"affected"`
+	Id       string `json:"jobId"`
+}
+
+// RangerDeployTarget defines the API model for the progress of a Ranger deployment job on a single device.
+type RangerDeployTarget struct {
+	DeviceId          string `json:"deviceId"`
+	Hostname          string `json:"hostname"`
+	IPAddress         string `json:"ipAddress"`
+	Status            string `json:"status"`
+	StatusDescription string `json:"statusDescription"`
+}
+
+// RangerDeployBody is used to hold the attributes used for creating a Ranger deployment job.
+type RangerDeployBody struct {
+	CredentialId string
+	DeviceIds    []string
+	OSTypes      []string
+	PackageId    *string
+	SiteIds      []string
+}
+
+// toBody converts the object into the request body for the API.
+func (b *RangerDeployBody) toBody() map[string]interface{} {
+	data := map[string]interface{}{
+		"credentialId": b.CredentialId,
+	}
+	if b.PackageId != nil {
+		data["packageId"] = *b.PackageId
+	}
+
+	filter := map[string]interface{}{}
+	if len(b.DeviceIds) > 0 {
+		filter["ids"] = b.DeviceIds
+	}
+	if len(b.OSTypes) > 0 {
+		filter["osTypes"] = b.OSTypes
+	}
+	if len(b.SiteIds) > 0 {
+		filter["siteIds"] = b.SiteIds
+	}
+	return map[string]interface{}{
+		"data":   data,
+		"filter": filter,
+	}
+}
+
+// CreateRangerDeployJob starts a job deploying agents to the unmanaged devices matching the given body.
+func (c *client) CreateRangerDeployJob(ctx context.Context, body RangerDeployBody) (*RangerDeployJob,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/ranger/deploy", body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var job RangerDeployJob
+	if err := c.unmarshal(result.Data, &job); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"RangerDeployJob object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_RANGER_CREATE_DEPLOY_JOB,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &job, diags
+}
+
+// FindRangerDeployTargets returns the progress of the Ranger deployment job with the matching ID on each of its
+// target devices.
+func (c *client) FindRangerDeployTargets(ctx context.Context, jobId string) ([]RangerDeployTarget,
+	diag.Diagnostics) {
+
+	var targets []RangerDeployTarget
+	var diags diag.Diagnostics
+	getQueryParams := map[string]string{}
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, fmt.Sprintf("/ranger/deploy/%s/targets", jobId), getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []RangerDeployTarget
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of RangerDeployTarget objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_RANGER_FIND_DEPLOY_TARGETS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		targets = append(targets, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return targets, diags
+}
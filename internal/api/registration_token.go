@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// RegenerateRegistrationToken generates a new registration token for the site or group matching the given scope and
+// returns it.
+//
+// The previous token stops working as soon as the new one is generated so any installers using it must be updated.
+func (c *client) RegenerateRegistrationToken(ctx context.Context, scope Scope) (string, diag.Diagnostics) {
+	// query the API - sites and groups use different methods for the same operation
+	var result *apiResponse
+	var diags diag.Diagnostics
+	if scope.Type == SCOPE_GROUP {
+		result, diags = c.Post(ctx, fmt.Sprintf("%s/regenerate-key", scope.uriPrefix()), nil)
+	} else {
+		result, diags = c.Put(ctx, fmt.Sprintf("%s/regenerate-key", scope.uriPrefix()), nil)
+	}
+	if diags.HasError() {
+		return "", diags
+	}
+
+	// parse the data returned
+	var token struct {
+		RegistrationToken string `json:"registrationToken"`
+	}
+	if err := c.unmarshal(result.Data, &token); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"registration token.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_REGISTRATION_TOKEN_REGENERATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return "", diags
+	}
+	return token.RegistrationToken, diags
+}
@@ -0,0 +1,353 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// RemoteScript defines the API model for a Remote Script Orchestration (RSO) script.
+type RemoteScript struct {
+	CreatedAt         string   `json:"createdAt"`
+	FileName          string   `json:"fileName"`
+	Id                string   `json:"id"`
+	InputExample      string   `json:"inputExample"`
+	InputInstructions string   `json:"inputInstructions"`
+	InputRequired     bool     `json:"inputRequired"`
+	OSTypes           []string `json:"osTypes"`
+	ScriptDescription string   `json:"scriptDescription"`
+	ScriptName        string   `json:"scriptName"`
+	ScriptType        string   `json:"scriptType"`
+	TimeoutSeconds    int64    `json:"scriptRuntimeTimeoutSeconds"`
+	UpdatedAt         string   `json:"updatedAt"`
+	Version           string   `json:"version"`
+}
+
+// RemoteScriptExecution defines the API model for the result of executing a script on a set of agents.
+type RemoteScriptExecution struct {
+	ParentTaskId string `json:"parentTaskId"`
+	Pending      bool   `json:"pending"`
+}
+
+// RemoteScriptExecutionBody is used to hold the attributes used for executing a script.
+type RemoteScriptExecutionBody struct {
+	InputParams     *string
+	ScriptId        string
+	TaskDescription *string
+	TimeoutSeconds  *int64
+}
+
+// toBody converts the object into the request body for the API.
+func (b *RemoteScriptExecutionBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{
+		"outputDestination": "SentinelCloud",
+		"scriptId":          b.ScriptId,
+	}
+	if b.InputParams != nil {
+		body["inputParams"] = *b.InputParams
+	}
+	if b.TaskDescription != nil {
+		body["taskDescription"] = *b.TaskDescription
+	}
+	if b.TimeoutSeconds != nil {
+		body["scriptRuntimeTimeoutSeconds"] = *b.TimeoutSeconds
+	}
+	return body
+}
+
+// RemoteScriptTask defines the API model for the execution of a script on a single agent.
+type RemoteScriptTask struct {
+	AgentComputerName string `json:"agentComputerName"`
+	AgentId           string `json:"agentId"`
+	DetailedStatus    string `json:"detailedStatus"`
+	Id                string `json:"id"`
+	Status            string `json:"status"`
+	UpdatedAt         string `json:"updatedAt"`
+}
+
+// RemoteScriptTaskFile defines the API model for the download link of the output of a script execution.
+type RemoteScriptTaskFile struct {
+	DownloadURL string `json:"downloadUrl"`
+	FileName    string `json:"fileName"`
+	TaskId      string `json:"taskId"`
+}
+
+// RemoteScriptBody is used to hold the attributes used for uploading a new script or a new version of a script.
+type RemoteScriptBody struct {
+	FileContent       []byte
+	FileName          string
+	InputExample      *string
+	InputInstructions *string
+	InputRequired     *bool
+	OSTypes           []string
+	ScriptDescription *string
+	ScriptName        *string
+	ScriptType        *string
+	TimeoutSeconds    *int64
+}
+
+// toFields converts the object into the multipart form fields for the API.
+func (b *RemoteScriptBody) toFields() map[string]string {
+	fields := map[string]string{}
+	if b.InputExample != nil {
+		fields["inputExample"] = *b.InputExample
+	}
+	if b.InputInstructions != nil {
+		fields["inputInstructions"] = *b.InputInstructions
+	}
+	if b.InputRequired != nil {
+		fields["inputRequired"] = strconv.FormatBool(*b.InputRequired)
+	}
+	if b.OSTypes != nil {
+		fields["osTypes"] = strings.Join(b.OSTypes, ",")
+	}
+	if b.ScriptDescription != nil {
+		fields["scriptDescription"] = *b.ScriptDescription
+	}
+	if b.ScriptName != nil {
+		fields["scriptName"] = *b.ScriptName
+	}
+	if b.ScriptType != nil {
+		fields["scriptType"] = *b.ScriptType
+	}
+	if b.TimeoutSeconds != nil {
+		fields["scriptRuntimeTimeoutSeconds"] = strconv.FormatInt(*b.TimeoutSeconds, 10)
+	}
+	return fields
+}
+
+// toFiles converts the object into the multipart form files for the API.
+func (b *RemoteScriptBody) toFiles() []MultipartFile {
+	return []MultipartFile{
+		{
+			Content: b.FileContent,
+			Field:   "file",
+			Name:    b.FileName,
+		},
+	}
+}
+
+// CreateRemoteScript uploads a new script within the given scope and returns the new script.
+func (c *client) CreateRemoteScript(ctx context.Context, scope Scope, body RemoteScriptBody) (*RemoteScript,
+	diag.Diagnostics) {
+
+	// query the API
+	fields := body.toFields()
+	for k, v := range scope.toStringMap() {
+		fields[k] = v
+	}
+	result, diags := c.PostMultipart(ctx, "/remote-scripts", fields, body.toFiles())
+	if diags.HasError() {
+		return nil, diags
+	}
+	return c.parseRemoteScript(ctx, result, plugin.ERR_API_REMOTE_SCRIPT_CREATE_SCRIPT)
+}
+
+// DeleteRemoteScript deletes the script with the matching ID.
+func (c *client) DeleteRemoteScript(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/remote-scripts", map[string]interface{}{
+		"data": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// ExecuteRemoteScript executes a script on all agents matching the given filter and returns the execution.
+func (c *client) ExecuteRemoteScript(ctx context.Context, filter AgentQueryParams, body RemoteScriptExecutionBody) (
+	*RemoteScriptExecution, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/remote-scripts/execute", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": filter.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var execution RemoteScriptExecution
+	if err := c.unmarshal(result.Data, &execution); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"RemoteScriptExecution object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_REMOTE_SCRIPT_EXECUTE_SCRIPT,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &execution, diags
+}
+
+// FetchRemoteScriptFiles returns the download links of the output of the tasks with the matching IDs.
+func (c *client) FetchRemoteScriptFiles(ctx context.Context, taskIds []string) ([]RemoteScriptTaskFile,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/remote-scripts/fetch-files", map[string]interface{}{
+		"data": map[string]interface{}{
+			"taskIds": taskIds,
+		},
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var files []RemoteScriptTaskFile
+	if err := c.unmarshal(result.Data, &files); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"list of RemoteScriptTaskFile objects.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_REMOTE_SCRIPT_FETCH_FILES,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return files, diags
+}
+
+// FindRemoteScripts returns a list of scripts found based on the given query parameters.
+func (c *client) FindRemoteScripts(ctx context.Context, queryParams RemoteScriptQueryParams) ([]RemoteScript,
+	diag.Diagnostics) {
+
+	var scripts []RemoteScript
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/remote-scripts", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []RemoteScript
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of RemoteScript objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_REMOTE_SCRIPT_FIND_SCRIPTS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		scripts = append(scripts, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return scripts, diags
+}
+
+// GetRemoteScriptTasks returns the tasks created on each agent when a script was executed.
+func (c *client) GetRemoteScriptTasks(ctx context.Context, parentTaskId string) ([]RemoteScriptTask,
+	diag.Diagnostics) {
+
+	var tasks []RemoteScriptTask
+	var diags diag.Diagnostics
+	getQueryParams := map[string]string{
+		"parent_task_id": parentTaskId,
+	}
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/remote-scripts/status", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []RemoteScriptTask
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of RemoteScriptTask objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_REMOTE_SCRIPT_GET_TASKS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		tasks = append(tasks, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return tasks, diags
+}
+
+// UpdateRemoteScript uploads a new version of the script with the matching ID and returns the updated script.
+func (c *client) UpdateRemoteScript(ctx context.Context, id string, body RemoteScriptBody) (*RemoteScript,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.PutMultipart(ctx, fmt.Sprintf("/remote-scripts/%s", id), body.toFields(), body.toFiles())
+	if diags.HasError() {
+		return nil, diags
+	}
+	return c.parseRemoteScript(ctx, result, plugin.ERR_API_REMOTE_SCRIPT_UPDATE_SCRIPT)
+}
+
+// parseRemoteScript parses the script returned in the given API response.
+func (c *client) parseRemoteScript(ctx context.Context, result *apiResponse, errorCode int) (*RemoteScript,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	var script RemoteScript
+	if err := c.unmarshal(result.Data, &script); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"RemoteScript object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": errorCode,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &script, diags
+}
+
+// RemoteScriptQueryParams is used to hold query parameters for finding scripts.
+type RemoteScriptQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	GroupIds   []string `json:"groupIds"`
+	OSTypes    []string `json:"osTypes"`
+	ScriptIds  []string `json:"ids"`
+	SiteIds    []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *RemoteScriptQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.OSTypes) > 0 {
+		queryString["osTypes"] = strings.Join(p.OSTypes, ",")
+	}
+	if len(p.ScriptIds) > 0 {
+		queryString["ids"] = strings.Join(p.ScriptIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
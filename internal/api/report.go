@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ReportSchedule defines the API model for a scheduled report task.
+type ReportSchedule struct {
+	CreatedAt  string   `json:"createdAt"`
+	Day        *int     `json:"day"`
+	Format     string   `json:"format"`
+	Frequency  string   `json:"frequency"`
+	Id         string   `json:"id"`
+	Name       string   `json:"name"`
+	Recipients []string `json:"recipients"`
+	ReportType string   `json:"reportType"`
+	UpdatedAt  string   `json:"updatedAt"`
+}
+
+// ReportScheduleBody is used to hold the attributes used for creating or updating a scheduled report task.
+type ReportScheduleBody struct {
+	Day        *int
+	Format     *string
+	Frequency  *string
+	Name       *string
+	Recipients []string
+	ReportType *string
+}
+
+// toBody converts the object into the request body for the API.
+func (b *ReportScheduleBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Day != nil {
+		body["day"] = *b.Day
+	}
+	if b.Format != nil {
+		body["format"] = *b.Format
+	}
+	if b.Frequency != nil {
+		body["frequency"] = *b.Frequency
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.Recipients != nil {
+		body["recipients"] = b.Recipients
+	}
+	if b.ReportType != nil {
+		body["reportType"] = *b.ReportType
+	}
+	return body
+}
+
+// CreateReportSchedule creates a new scheduled report task within the given scope and returns the new task.
+func (c *client) CreateReportSchedule(ctx context.Context, scope Scope, body ReportScheduleBody) (*ReportSchedule,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/report-tasks", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var schedule ReportSchedule
+	if err := c.unmarshal(result.Data, &schedule); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ReportSchedule object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_REPORT_CREATE_SCHEDULE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &schedule, diags
+}
+
+// DeleteReportSchedule deletes the scheduled report task with the matching ID.
+func (c *client) DeleteReportSchedule(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/report-tasks", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindReportSchedules returns a list of scheduled report tasks found based on the given query parameters.
+func (c *client) FindReportSchedules(ctx context.Context, queryParams ReportScheduleQueryParams) ([]ReportSchedule,
+	diag.Diagnostics) {
+
+	var schedules []ReportSchedule
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/report-tasks", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []ReportSchedule
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of ReportSchedule objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_REPORT_FIND_SCHEDULES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		schedules = append(schedules, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return schedules, diags
+}
+
+// UpdateReportSchedule updates the scheduled report task with the matching ID using the given attributes and returns
+// the updated task.
+func (c *client) UpdateReportSchedule(ctx context.Context, id string, body ReportScheduleBody) (*ReportSchedule,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/report-tasks/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var schedule ReportSchedule
+	if err := c.unmarshal(result.Data, &schedule); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ReportSchedule object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_REPORT_UPDATE_SCHEDULE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &schedule, diags
+}
+
+// ReportScheduleQueryParams is used to hold query parameters for finding scheduled report tasks.
+type ReportScheduleQueryParams struct {
+	AccountIds        []string `json:"accountIds"`
+	ReportScheduleIds []string `json:"ids"`
+	SiteIds           []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *ReportScheduleQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.ReportScheduleIds) > 0 {
+		queryString["ids"] = strings.Join(p.ReportScheduleIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
@@ -34,3 +34,10 @@ type apiResponse struct {
 	// Errors holds any errors that occurred during the query.
 	Errors []apiError `json:"errors"`
 }
+
+// actionResult defines the generic response to an API action (eg: one that is applied to all objects matching a
+// filter).
+type actionResult struct {
+	// Affected holds the number of objects affected by the action.
+	Affected int `json:"affected"`
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// RESTRICTION_TYPE_BLOCKLIST_HASH is the restriction type used for blocklisted SHA1 hashes.
+const RESTRICTION_TYPE_BLOCKLIST_HASH = "black_hash"
+
+// Restriction defines the API model for a restriction (eg: a blocklisted hash).
+type Restriction struct {
+	CreatedAt   string `json:"createdAt"`
+	Description string `json:"description"`
+	Id          string `json:"id"`
+	OSType      string `json:"osType"`
+	ScopeName   string `json:"scopeName"`
+	ScopePath   string `json:"scopePath"`
+	Source      string `json:"source"`
+	Type        string `json:"type"`
+	UpdatedAt   string `json:"updatedAt"`
+	UserId      string `json:"userId"`
+	UserName    string `json:"userName"`
+	Value       string `json:"value"`
+}
+
+// RestrictionBody is used to hold the attributes used for creating or updating a restriction.
+type RestrictionBody struct {
+	Description *string `json:"description"`
+	OSType      *string `json:"osType"`
+	Type        *string `json:"type"`
+	Value       *string `json:"value"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *RestrictionBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.OSType != nil {
+		body["osType"] = *b.OSType
+	}
+	if b.Type != nil {
+		body["type"] = *b.Type
+	}
+	if b.Value != nil {
+		body["value"] = *b.Value
+	}
+	return body
+}
+
+// CreateRestriction creates a new restriction within the given scope and returns the new restriction.
+func (c *client) CreateRestriction(ctx context.Context, scope Scope, body RestrictionBody) (*Restriction,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/restrictions", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned - the API always returns a list of the restrictions created
+	var restrictions []Restriction
+	if err := c.unmarshal(result.Data, &restrictions); err != nil || len(restrictions) == 0 {
+		errMsg := "no restrictions were returned"
+		if err != nil {
+			errMsg = err.Error()
+		}
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Restriction object.\n\nError: %s", errMsg)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               errMsg,
+			"internal_error_code": plugin.ERR_API_RESTRICTION_CREATE_RESTRICTION,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &restrictions[0], diags
+}
+
+// DeleteRestriction deletes the restriction with the matching ID and type.
+func (c *client) DeleteRestriction(ctx context.Context, id, restrictionType string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/restrictions", map[string]interface{}{
+		"data": map[string]interface{}{
+			"ids":  []string{id},
+			"type": restrictionType,
+		},
+	})
+	return diags
+}
+
+// FindRestrictions returns a list of restrictions found based on the given query parameters.
+func (c *client) FindRestrictions(ctx context.Context, queryParams RestrictionQueryParams) ([]Restriction,
+	diag.Diagnostics) {
+
+	var restrictions []Restriction
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/restrictions", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Restriction
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Restriction objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_RESTRICTION_FIND_RESTRICTIONS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		restrictions = append(restrictions, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return restrictions, diags
+}
+
+// UpdateRestriction updates the restriction with the matching ID using the given attributes and returns the updated
+// restriction.
+func (c *client) UpdateRestriction(ctx context.Context, id string, body RestrictionBody) (*Restriction,
+	diag.Diagnostics) {
+
+	// query the API
+	data := body.toBody()
+	data["id"] = id
+	result, diags := c.Put(ctx, "/restrictions", map[string]interface{}{
+		"data": data,
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var restriction Restriction
+	if err := c.unmarshal(result.Data, &restriction); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Restriction object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_RESTRICTION_UPDATE_RESTRICTION,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &restriction, diags
+}
+
+// RestrictionQueryParams is used to hold query parameters for finding restrictions.
+type RestrictionQueryParams struct {
+	AccountIds     []string `json:"accountIds"`
+	GroupIds       []string `json:"groupIds"`
+	OSTypes        []string `json:"osTypes"`
+	RestrictionIds []string `json:"ids"`
+	SiteIds        []string `json:"siteIds"`
+	Tenant         *bool    `json:"tenant"`
+	Type           *string  `json:"type"`
+	Value          *string  `json:"value"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *RestrictionQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.OSTypes) > 0 {
+		queryString["osTypes"] = strings.Join(p.OSTypes, ",")
+	}
+	if len(p.RestrictionIds) > 0 {
+		queryString["ids"] = strings.Join(p.RestrictionIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if p.Tenant != nil {
+		queryString["tenant"] = fmt.Sprintf("%t", *p.Tenant)
+	}
+	if p.Type != nil {
+		queryString["type"] = *p.Type
+	}
+	if p.Value != nil {
+		queryString["value"] = *p.Value
+	}
+	return queryString
+}
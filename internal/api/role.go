@@ -0,0 +1,166 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Role defines the API model for an RBAC role.
+type Role struct {
+	CreatedAt      string   `json:"createdAt"`
+	Description    string   `json:"description"`
+	Id             string   `json:"id"`
+	Name           string   `json:"name"`
+	PermissionIds  []string `json:"permissionIds"`
+	PredefinedRole bool     `json:"predefinedRole"`
+	Scope          string   `json:"scope"`
+	ScopeId        string   `json:"scopeId"`
+	UpdatedAt      string   `json:"updatedAt"`
+	UsersInRoles   int      `json:"usersInRoles"`
+}
+
+// RoleBody is used to hold the attributes used for creating or updating a role.
+type RoleBody struct {
+	Description   *string  `json:"description"`
+	Name          *string  `json:"name"`
+	PermissionIds []string `json:"permissionIds"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *RoleBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.PermissionIds != nil {
+		body["permissionIds"] = b.PermissionIds
+	}
+	return body
+}
+
+// CreateRole creates a new custom role within the given scope and returns the new role.
+func (c *client) CreateRole(ctx context.Context, scope Scope, body RoleBody) (*Role, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/rbac/role", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var role Role
+	if err := c.unmarshal(result.Data, &role); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Role object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_ROLE_CREATE_ROLE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &role, diags
+}
+
+// DeleteRole deletes the custom role with the matching ID.
+func (c *client) DeleteRole(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, fmt.Sprintf("/rbac/role/%s", id), nil)
+	return diags
+}
+
+// FindRoles returns a list of roles found based on the given query parameters.
+func (c *client) FindRoles(ctx context.Context, queryParams RoleQueryParams) ([]Role, diag.Diagnostics) {
+	var roles []Role
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/rbac/roles", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Role
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Role objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_ROLE_FIND_ROLES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		roles = append(roles, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return roles, diags
+}
+
+// UpdateRole updates the custom role with the matching ID using the given attributes and returns the updated role.
+func (c *client) UpdateRole(ctx context.Context, id string, scope Scope, body RoleBody) (*Role, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/rbac/role/%s", id), map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var role Role
+	if err := c.unmarshal(result.Data, &role); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Role object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_ROLE_UPDATE_ROLE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &role, diags
+}
+
+// RoleQueryParams is used to hold query parameters for finding roles.
+type RoleQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	RoleIds    []string `json:"ids"`
+	SiteIds    []string `json:"siteIds"`
+	Tenant     *bool    `json:"tenant"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *RoleQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.RoleIds) > 0 {
+		queryString["ids"] = strings.Join(p.RoleIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if p.Tenant != nil {
+		queryString["tenant"] = fmt.Sprintf("%t", *p.Tenant)
+	}
+	return queryString
+}
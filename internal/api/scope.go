@@ -0,0 +1,62 @@
+package api
+
+import "fmt"
+
+// SCOPE_ACCOUNT, SCOPE_SITE and SCOPE_GROUP are the levels at which scoped objects (eg: exclusions) can be created.
+const (
+	SCOPE_ACCOUNT = "account"
+	SCOPE_SITE    = "site"
+	SCOPE_GROUP   = "group"
+)
+
+// SCOPE_TENANT is the level used for objects which apply to the whole console (eg: custom roles). It only applies to
+// the few objects which can be created globally and is what any scope with an unrecognized type is treated as.
+const SCOPE_TENANT = "tenant"
+
+// Scope identifies the account, site or group to which an object applies.
+type Scope struct {
+	// Id is the ID of the account, site or group.
+	Id string
+
+	// Type is the level of the scope (one of SCOPE_ACCOUNT, SCOPE_SITE or SCOPE_GROUP).
+	Type string
+}
+
+// toFilter converts the scope into the filter used in the body of a request to create a scoped object.
+func (s *Scope) toFilter() map[string]interface{} {
+	switch s.Type {
+	case SCOPE_ACCOUNT:
+		return map[string]interface{}{"accountIds": []string{s.Id}}
+	case SCOPE_SITE:
+		return map[string]interface{}{"siteIds": []string{s.Id}}
+	case SCOPE_GROUP:
+		return map[string]interface{}{"groupIds": []string{s.Id}}
+	}
+	return map[string]interface{}{"tenant": true}
+}
+
+// toStringMap converts the scope into the query parameters used to find or get objects within the scope.
+func (s *Scope) toStringMap() map[string]string {
+	switch s.Type {
+	case SCOPE_ACCOUNT:
+		return map[string]string{"accountIds": s.Id}
+	case SCOPE_SITE:
+		return map[string]string{"siteIds": s.Id}
+	case SCOPE_GROUP:
+		return map[string]string{"groupIds": s.Id}
+	}
+	return map[string]string{"tenant": "true"}
+}
+
+// uriPrefix returns the URI prefix for objects which belong directly to the scope (eg: its policy).
+func (s *Scope) uriPrefix() string {
+	switch s.Type {
+	case SCOPE_ACCOUNT:
+		return fmt.Sprintf("/accounts/%s", s.Id)
+	case SCOPE_SITE:
+		return fmt.Sprintf("/sites/%s", s.Id)
+	case SCOPE_GROUP:
+		return fmt.Sprintf("/groups/%s", s.Id)
+	}
+	return "/tenant"
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// scopeAccountKey is the context key under which the ID of the child account on whose behalf queries are made is
+// stored.
+type scopeAccountKey struct{}
+
+// WithScopeAccount returns a new context which, when passed to any API query, causes the query to be made with the
+// API token configured for the given child account instead of the provider's own token.
+//
+// An empty account ID returns the context unchanged so the provider's own token is used.
+func WithScopeAccount(ctx context.Context, accountId string) context.Context {
+	if accountId == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, scopeAccountKey{}, accountId)
+}
+
+// SetAccountTokens sets the API tokens used for queries made on behalf of child accounts, keyed by account ID.
+func (c *client) SetAccountTokens(tokens map[string]string) {
+	c.accountTokens = map[string]string{}
+	for id, token := range tokens {
+		c.accountTokens[id] = token
+	}
+}
+
+// tokenFor returns the API token to use for a query made with the given context.
+func (c *client) tokenFor(ctx context.Context) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	accountId, ok := ctx.Value(scopeAccountKey{}).(string)
+	if !ok {
+		return c.apiToken, diags
+	}
+	token, ok := c.accountTokens[accountId]
+	if !ok {
+		msg := fmt.Sprintf("No API token has been configured for the child account on whose behalf the query is "+
+			"being made. Add the account to the provider's account_api_tokens attribute.\n\nAccount ID: %s", accountId)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"account_id":          accountId,
+			"internal_error_code": plugin.ERR_API_CLIENT_SCOPE_TOKEN,
+		})
+		diags.AddError("Missing Account API Token", msg)
+		return "", diags
+	}
+	return token, diags
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Singularity Data Lake ingestion constants
+const (
+	SDL_RETENTION_TIER_ARCHIVE  = "archive"
+	SDL_RETENTION_TIER_EXTENDED = "extended"
+	SDL_RETENTION_TIER_STANDARD = "standard"
+
+	SDL_SOURCE_TYPE_HEC    = "hec"
+	SDL_SOURCE_TYPE_S3     = "s3"
+	SDL_SOURCE_TYPE_SYSLOG = "syslog"
+)
+
+// SdlIngestionConfig defines the API model for the ingestion configuration of a custom Singularity Data Lake log
+// source.
+type SdlIngestionConfig struct {
+	CreatedAt        string `json:"createdAt"`
+	Description      string `json:"description"`
+	Enabled          bool   `json:"enabled"`
+	Id               string `json:"id"`
+	IngestUrl        string `json:"ingestUrl"`
+	Name             string `json:"name"`
+	ParserDefinition string `json:"parserDefinition"`
+	ParserName       string `json:"parserName"`
+	RetentionTier    string `json:"retentionTier"`
+	SourceType       string `json:"sourceType"`
+	UpdatedAt        string `json:"updatedAt"`
+}
+
+// SdlIngestionConfigBody is used to hold the attributes used for creating or updating an ingestion configuration.
+type SdlIngestionConfigBody struct {
+	Description      *string
+	Enabled          *bool
+	Name             *string
+	ParserDefinition *string
+	ParserName       *string
+	RetentionTier    *string
+	SourceType       *string
+}
+
+// toBody converts the object into the request body for the API.
+func (b *SdlIngestionConfigBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.ParserDefinition != nil {
+		body["parserDefinition"] = *b.ParserDefinition
+	}
+	if b.ParserName != nil {
+		body["parserName"] = *b.ParserName
+	}
+	if b.RetentionTier != nil {
+		body["retentionTier"] = *b.RetentionTier
+	}
+	if b.SourceType != nil {
+		body["sourceType"] = *b.SourceType
+	}
+	return body
+}
+
+// CreateSdlIngestionConfig creates a new Data Lake ingestion configuration within the given scope and returns the new
+// configuration.
+func (c *client) CreateSdlIngestionConfig(ctx context.Context, scope Scope, body SdlIngestionConfigBody) (
+	*SdlIngestionConfig, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/sdl/ingestion-configs", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var config SdlIngestionConfig
+	if err := c.unmarshal(result.Data, &config); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"SdlIngestionConfig object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SDL_INGESTION_CONFIG_CREATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &config, diags
+}
+
+// DeleteSdlIngestionConfig deletes the Data Lake ingestion configuration with the matching ID.
+func (c *client) DeleteSdlIngestionConfig(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/sdl/ingestion-configs", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindSdlIngestionConfigs returns a list of Data Lake ingestion configurations found based on the given query
+// parameters.
+func (c *client) FindSdlIngestionConfigs(ctx context.Context, queryParams SdlIngestionConfigQueryParams) (
+	[]SdlIngestionConfig, diag.Diagnostics) {
+
+	var configs []SdlIngestionConfig
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/sdl/ingestion-configs", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []SdlIngestionConfig
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of SdlIngestionConfig objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_SDL_INGESTION_CONFIG_FIND,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		configs = append(configs, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return configs, diags
+}
+
+// UpdateSdlIngestionConfig updates the Data Lake ingestion configuration with the matching ID using the given
+// attributes and returns the updated configuration.
+func (c *client) UpdateSdlIngestionConfig(ctx context.Context, id string, body SdlIngestionConfigBody) (
+	*SdlIngestionConfig, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/sdl/ingestion-configs/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var config SdlIngestionConfig
+	if err := c.unmarshal(result.Data, &config); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"SdlIngestionConfig object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SDL_INGESTION_CONFIG_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &config, diags
+}
+
+// SdlIngestionConfigQueryParams is used to hold query parameters for finding Data Lake ingestion configurations.
+type SdlIngestionConfigQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	ConfigIds  []string `json:"ids"`
+	SiteIds    []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *SdlIngestionConfigQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.ConfigIds) > 0 {
+		queryString["ids"] = strings.Join(p.ConfigIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ServiceUser defines the API model for a service user.
+type ServiceUser struct {
+	ApiToken       ServiceUserApiToken    `json:"apiToken"`
+	CreatedAt      string                 `json:"createdAt"`
+	Description    string                 `json:"description"`
+	Id             string                 `json:"id"`
+	LastActivation string                 `json:"lastActivation"`
+	Name           string                 `json:"name"`
+	Scope          string                 `json:"scope"`
+	ScopeRoles     []ServiceUserScopeRole `json:"scopeRoles"`
+	UpdatedAt      string                 `json:"updatedAt"`
+}
+
+// ServiceUserApiToken defines the API model for the details of a service user's API token.
+type ServiceUserApiToken struct {
+	CreatedAt string `json:"createdAt"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// ServiceUserScopeRole defines the API model for the role a service user has within an account or site.
+type ServiceUserScopeRole struct {
+	Id       string `json:"id"`
+	RoleId   string `json:"roleId"`
+	RoleName string `json:"roleName"`
+}
+
+// ServiceUserBody is used to hold the attributes used for creating or updating a service user.
+type ServiceUserBody struct {
+	Description    *string                `json:"description"`
+	ExpirationDate *string                `json:"expirationDate"`
+	Name           *string                `json:"name"`
+	Scope          *string                `json:"scope"`
+	ScopeRoles     []ServiceUserScopeRole `json:"scopeRoles"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *ServiceUserBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.ExpirationDate != nil {
+		body["expirationDate"] = *b.ExpirationDate
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.Scope != nil {
+		body["scope"] = *b.Scope
+	}
+	if b.ScopeRoles != nil {
+		scopeRoles := []map[string]interface{}{}
+		for _, r := range b.ScopeRoles {
+			scopeRoles = append(scopeRoles, map[string]interface{}{
+				"id":     r.Id,
+				"roleId": r.RoleId,
+			})
+		}
+		body["scopeRoles"] = scopeRoles
+	}
+	return body
+}
+
+// CreateServiceUser creates a new service user and returns the new service user.
+func (c *client) CreateServiceUser(ctx context.Context, body ServiceUserBody) (*ServiceUser, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/service-users", map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var user ServiceUser
+	if err := c.unmarshal(result.Data, &user); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ServiceUser object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SERVICE_USER_CREATE_SERVICE_USER,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &user, diags
+}
+
+// DeleteServiceUser deletes the service user with the matching ID.
+func (c *client) DeleteServiceUser(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, fmt.Sprintf("/service-users/%s", id), nil)
+	return diags
+}
+
+// FindServiceUsers returns a list of service users found based on the given query parameters.
+func (c *client) FindServiceUsers(ctx context.Context, queryParams ServiceUserQueryParams) ([]ServiceUser,
+	diag.Diagnostics) {
+
+	var users []ServiceUser
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/service-users", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []ServiceUser
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of ServiceUser objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_SERVICE_USER_FIND_SERVICE_USERS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		users = append(users, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return users, diags
+}
+
+// GenerateServiceUserApiToken generates a new API token for the service user with the matching ID which expires at
+// the given time and returns the token. Any previous token for the service user is revoked.
+func (c *client) GenerateServiceUserApiToken(ctx context.Context, id, expirationDate string) (string,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, fmt.Sprintf("/service-users/%s/generate-api-token", id), map[string]interface{}{
+		"data": map[string]interface{}{
+			"expirationDate": expirationDate,
+		},
+	})
+	if diags.HasError() {
+		return "", diags
+	}
+
+	// parse the data returned
+	var token struct {
+		Token string `json:"token"`
+	}
+	if err := c.unmarshal(result.Data, &token); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"API token.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SERVICE_USER_GENERATE_API_TOKEN,
+		})
+		diags.AddError("API Response Error", msg)
+		return "", diags
+	}
+	return token.Token, diags
+}
+
+// RevokeServiceUserApiToken revokes the API token of the service user with the matching ID.
+func (c *client) RevokeServiceUserApiToken(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Post(ctx, fmt.Sprintf("/service-users/%s/revoke-api-token", id), nil)
+	return diags
+}
+
+// UpdateServiceUser updates the service user with the matching ID using the given attributes and returns the updated
+// service user.
+func (c *client) UpdateServiceUser(ctx context.Context, id string, body ServiceUserBody) (*ServiceUser,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/service-users/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var user ServiceUser
+	if err := c.unmarshal(result.Data, &user); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ServiceUser object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SERVICE_USER_UPDATE_SERVICE_USER,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &user, diags
+}
+
+// ServiceUserQueryParams is used to hold query parameters for finding service users.
+type ServiceUserQueryParams struct {
+	AccountIds     []string `json:"accountIds"`
+	ServiceUserIds []string `json:"ids"`
+	SiteIds        []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *ServiceUserQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.ServiceUserIds) > 0 {
+		queryString["ids"] = strings.Join(p.ServiceUserIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
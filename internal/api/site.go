@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -24,7 +23,7 @@ type Site struct {
 	ExternalId          string      `json:"externalId"`
 	Id                  string      `json:"id"`
 	IsDefault           bool        `json:"isDefault"`
-	Licenses            siteLicense `json:"licenses"`
+	Licenses            SiteLicense `json:"licenses"`
 	Name                string      `json:"name"`
 	RegistrationToken   string      `json:"registrationToken"`
 	SiteType            string      `json:"siteType"`
@@ -35,8 +34,8 @@ type Site struct {
 	UpdatedAt           string      `json:"updatedAt"`
 }
 
-// siteLicense defines the API model for a site's license.
-type siteLicense struct {
+// SiteLicense defines the API model for a site's license.
+type SiteLicense struct {
 	Bundles  []siteLicenseBundle  `json:"bundles"`
 	Modules  []siteLicenseModule  `json:"modules"`
 	Settings []siteLicenseSetting `json:"settings"`
@@ -72,6 +71,25 @@ type siteLicenseSetting struct {
 	SettingGroupDisplayName string `json:"settingGroupDisplayName"`
 }
 
+// Includes determines whether or not the license includes any of the given bundles or modules.
+//
+// Names are compared case-insensitively against both the name and display name of each bundle and module.
+func (l *SiteLicense) Includes(names ...string) bool {
+	for _, name := range names {
+		for _, b := range l.Bundles {
+			if strings.EqualFold(b.Name, name) || strings.EqualFold(b.DisplayName, name) {
+				return true
+			}
+		}
+		for _, m := range l.Modules {
+			if strings.EqualFold(m.Name, name) || strings.EqualFold(m.DisplayName, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Sites defines the API model for a list of sites.
 type Sites struct {
 	AllSites allSites `json:"all_sites"`
@@ -84,6 +102,83 @@ type allSites struct {
 	TotalLicenses  int `json:"total_licenses"`
 }
 
+// SiteBody is used to hold the attributes used for creating or updating a site.
+type SiteBody struct {
+	AccountId           *string `json:"accountId"`
+	Description         *string `json:"description"`
+	Expiration          *string `json:"expiration"`
+	ExternalId          *string `json:"externalId"`
+	Name                *string `json:"name"`
+	SiteType            *string `json:"siteType"`
+	TotalLicenses       *int64  `json:"totalLicenses"`
+	UnlimitedExpiration *bool   `json:"unlimitedExpiration"`
+	UnlimitedLicenses   *bool   `json:"unlimitedLicenses"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *SiteBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.AccountId != nil {
+		body["accountId"] = *b.AccountId
+	}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Expiration != nil {
+		body["expiration"] = *b.Expiration
+	}
+	if b.ExternalId != nil {
+		body["externalId"] = *b.ExternalId
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.SiteType != nil {
+		body["siteType"] = *b.SiteType
+	}
+	if b.TotalLicenses != nil {
+		body["totalLicenses"] = *b.TotalLicenses
+	}
+	if b.UnlimitedExpiration != nil {
+		body["unlimitedExpiration"] = *b.UnlimitedExpiration
+	}
+	if b.UnlimitedLicenses != nil {
+		body["unlimitedLicenses"] = *b.UnlimitedLicenses
+	}
+	return map[string]interface{}{
+		"data": body,
+	}
+}
+
+// CreateSite creates a new site with the given attributes and returns the new site.
+func (c *client) CreateSite(ctx context.Context, body SiteBody) (*Site, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/sites", body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var site Site
+	if err := c.unmarshal(result.Data, &site); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Site object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SITE_CREATE_SITE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &site, diags
+}
+
+// DeleteSite deletes the site with the matching ID.
+func (c *client) DeleteSite(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, fmt.Sprintf("/sites/%s", id), map[string]interface{}{})
+	return diags
+}
+
 // FindSites returns a list of sites found based on the given query parameters.
 func (c *client) FindSites(ctx context.Context, queryParams SiteQueryParams) ([]Site, diag.Diagnostics) {
 	var sites []Site
@@ -98,7 +193,7 @@ func (c *client) FindSites(ctx context.Context, queryParams SiteQueryParams) ([]
 
 		// parse the response
 		var page Sites
-		if err := json.Unmarshal(result.Data, &page); err != nil {
+		if err := c.unmarshal(result.Data, &page); err != nil {
 			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
 				"list of Site objects.\n\nError: %s", err.Error())
 			tflog.Error(ctx, msg, map[string]interface{}{
@@ -153,7 +248,7 @@ func (c *client) GetSite(ctx context.Context, id string) (*Site, diag.Diagnostic
 
 	// parse the data returned
 	var sites []Site
-	if err := json.Unmarshal(result.Data, &sites); err != nil {
+	if err := c.unmarshal(result.Data, &sites); err != nil {
 		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
 			"Site object.\n\nError: %s", err.Error())
 		tflog.Error(ctx, msg, map[string]interface{}{
@@ -166,6 +261,29 @@ func (c *client) GetSite(ctx context.Context, id string) (*Site, diag.Diagnostic
 	return &sites[0], diags
 }
 
+// UpdateSite updates the site with the matching ID using the given attributes and returns the updated site.
+func (c *client) UpdateSite(ctx context.Context, id string, body SiteBody) (*Site, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/sites/%s", id), body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var site Site
+	if err := c.unmarshal(result.Data, &site); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Site object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SITE_UPDATE_SITE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &site, diags
+}
+
 // SiteQueryParams is used to hold query parameters for finding sites.
 type SiteQueryParams struct {
 	AccountIds          []string `json:"accountIds"`
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// SSOSettings defines the API model for the SAML single sign-on settings of a scope.
+type SSOSettings struct {
+	AttributeMappings map[string]string `json:"attributeMappings"`
+	DefaultRoleId     string            `json:"defaultRoleId"`
+	Enabled           bool              `json:"enabled"`
+	IdpMetadataUrl    string            `json:"idpMetadataUrl"`
+	JitProvisioning   bool              `json:"jitProvisioning"`
+	SpAcsUrl          string            `json:"spAcsUrl"`
+	SpEntityId        string            `json:"spEntityId"`
+}
+
+// SSOSettingsBody is used to hold the attributes used for updating the SAML single sign-on settings.
+type SSOSettingsBody struct {
+	AttributeMappings map[string]string `json:"attributeMappings"`
+	DefaultRoleId     *string           `json:"defaultRoleId"`
+	Enabled           *bool             `json:"enabled"`
+	IdpMetadataUrl    *string           `json:"idpMetadataUrl"`
+	IdpMetadataXml    *string           `json:"idpMetadataXml"`
+	JitProvisioning   *bool             `json:"jitProvisioning"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *SSOSettingsBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.AttributeMappings != nil {
+		body["attributeMappings"] = b.AttributeMappings
+	}
+	if b.DefaultRoleId != nil {
+		body["defaultRoleId"] = *b.DefaultRoleId
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.IdpMetadataUrl != nil {
+		body["idpMetadataUrl"] = *b.IdpMetadataUrl
+	}
+	if b.IdpMetadataXml != nil {
+		body["idpMetadataXml"] = *b.IdpMetadataXml
+	}
+	if b.JitProvisioning != nil {
+		body["jitProvisioning"] = *b.JitProvisioning
+	}
+	return body
+}
+
+// GetSSOSettings returns the SAML single sign-on settings of the given scope.
+func (c *client) GetSSOSettings(ctx context.Context, scope Scope) (*SSOSettings, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, "/settings/sso", scope.toStringMap())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var settings SSOSettings
+	if err := c.unmarshal(result.Data, &settings); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"SSOSettings object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SSO_SETTINGS_GET,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &settings, diags
+}
+
+// UpdateSSOSettings updates the SAML single sign-on settings of the given scope using the given attributes and
+// returns the updated settings.
+func (c *client) UpdateSSOSettings(ctx context.Context, scope Scope, body SSOSettingsBody) (*SSOSettings,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, "/settings/sso", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var settings SSOSettings
+	if err := c.unmarshal(result.Data, &settings); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"SSOSettings object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SSO_SETTINGS_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &settings, diags
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// SyslogConnector defines the API model for the syslog integration settings of a scope.
+type SyslogConnector struct {
+	Enabled  bool   `json:"enabled"`
+	Facility string `json:"facility"`
+	Format   string `json:"formattingType"`
+	Host     string `json:"host"`
+	Port     int64  `json:"port"`
+	Protocol string `json:"protocol"`
+	TLS      bool   `json:"ssl"`
+}
+
+// SyslogConnectorBody is used to hold the attributes used for updating the syslog integration settings.
+type SyslogConnectorBody struct {
+	Enabled  *bool   `json:"enabled"`
+	Facility *string `json:"facility"`
+	Format   *string `json:"formattingType"`
+	Host     *string `json:"host"`
+	Port     *int64  `json:"port"`
+	Protocol *string `json:"protocol"`
+	TLS      *bool   `json:"ssl"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *SyslogConnectorBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.Facility != nil {
+		body["facility"] = *b.Facility
+	}
+	if b.Format != nil {
+		body["formattingType"] = *b.Format
+	}
+	if b.Host != nil {
+		body["host"] = *b.Host
+	}
+	if b.Port != nil {
+		body["port"] = *b.Port
+	}
+	if b.Protocol != nil {
+		body["protocol"] = *b.Protocol
+	}
+	if b.TLS != nil {
+		body["ssl"] = *b.TLS
+	}
+	return body
+}
+
+// GetSyslogConnector returns the syslog integration settings of the given scope.
+func (c *client) GetSyslogConnector(ctx context.Context, scope Scope) (*SyslogConnector, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, "/settings/syslog", scope.toStringMap())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var connector SyslogConnector
+	if err := c.unmarshal(result.Data, &connector); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"SyslogConnector object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SYSLOG_CONNECTOR_GET,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &connector, diags
+}
+
+// UpdateSyslogConnector updates the syslog integration settings of the given scope using the given attributes and
+// returns the updated settings.
+func (c *client) UpdateSyslogConnector(ctx context.Context, scope Scope, body SyslogConnectorBody) (
+	*SyslogConnector, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, "/settings/syslog", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var connector SyslogConnector
+	if err := c.unmarshal(result.Data, &connector); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"SyslogConnector object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SYSLOG_CONNECTOR_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &connector, diags
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// SystemInfo defines the API model for the build information of the management console.
+type SystemInfo struct {
+	Build   string `json:"build"`
+	Patch   string `json:"patch"`
+	Release string `json:"release"`
+}
+
+// GetSystemInfo returns the build information of the management console.
+func (c *client) GetSystemInfo(ctx context.Context) (*SystemInfo, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, "/system/info", map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var info SystemInfo
+	if err := c.unmarshal(result.Data, &info); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"SystemInfo object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_SYSTEM_GET_INFO,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &info, diags
+}
+
+// GetSystemStatus checks whether or not the management console is reachable and healthy.
+func (c *client) GetSystemStatus(ctx context.Context) diag.Diagnostics {
+	_, diags := c.Get(ctx, "/system/status", map[string]string{})
+	return diags
+}
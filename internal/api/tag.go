@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// TAG_TYPE_AGENTS is the type of tag which can be assigned to agents.
+const TAG_TYPE_AGENTS = "agents"
+
+// Tag defines the API model for a tag managed by the tag manager.
+type Tag struct {
+	CreatedAt   string `json:"createdAt"`
+	Description string `json:"description"`
+	Id          string `json:"id"`
+	Key         string `json:"key"`
+	ScopeId     string `json:"scopeId"`
+	ScopeLevel  string `json:"scopeLevel"`
+	Type        string `json:"type"`
+	UpdatedAt   string `json:"updatedAt"`
+	Value       string `json:"value"`
+}
+
+// TagBody is used to hold the attributes used for creating or updating a tag.
+type TagBody struct {
+	Description *string `json:"description"`
+	Key         *string `json:"key"`
+	Value       *string `json:"value"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *TagBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{
+		"type": TAG_TYPE_AGENTS,
+	}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Key != nil {
+		body["key"] = *b.Key
+	}
+	if b.Value != nil {
+		body["value"] = *b.Value
+	}
+	return body
+}
+
+// CreateTag creates a new agent tag within the given scope and returns the new tag.
+func (c *client) CreateTag(ctx context.Context, scope Scope, body TagBody) (*Tag, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/tag-manager", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var tag Tag
+	if err := c.unmarshal(result.Data, &tag); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Tag object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_TAG_CREATE_TAG,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &tag, diags
+}
+
+// DeleteTag deletes the tag with the matching ID, removing it from any agents to which it is assigned.
+func (c *client) DeleteTag(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/tag-manager", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindTags returns a list of tags found based on the given query parameters.
+func (c *client) FindTags(ctx context.Context, queryParams TagQueryParams) ([]Tag, diag.Diagnostics) {
+	var tags []Tag
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/tag-manager", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Tag
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Tag objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_TAG_FIND_TAGS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		tags = append(tags, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return tags, diags
+}
+
+// UpdateTag updates the tag with the matching ID using the given attributes and returns the updated tag.
+func (c *client) UpdateTag(ctx context.Context, id string, body TagBody) (*Tag, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/tag-manager/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var tag Tag
+	if err := c.unmarshal(result.Data, &tag); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Tag object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_TAG_UPDATE_TAG,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &tag, diags
+}
+
+// TagQueryParams is used to hold query parameters for finding tags.
+type TagQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	Keys       []string `json:"keys"`
+	SiteIds    []string `json:"siteIds"`
+	TagIds     []string `json:"ids"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *TagQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{
+		"type": TAG_TYPE_AGENTS,
+	}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.Keys) > 0 {
+		queryString["keys"] = strings.Join(p.Keys, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if len(p.TagIds) > 0 {
+		queryString["ids"] = strings.Join(p.TagIds, ",")
+	}
+	return queryString
+}
@@ -0,0 +1,330 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Threat defines the API model for a threat.
+type Threat struct {
+	AgentRealtimeInfo threatAgentRealtimeInfo `json:"agentRealtimeInfo"`
+	Id                string                  `json:"id"`
+	ThreatInfo        threatInfo              `json:"threatInfo"`
+}
+
+// threatAgentRealtimeInfo defines the API model for the agent on which a threat was detected.
+type threatAgentRealtimeInfo struct {
+	AccountId         string `json:"accountId"`
+	AgentComputerName string `json:"agentComputerName"`
+	AgentId           string `json:"agentId"`
+	GroupId           string `json:"groupId"`
+	SiteId            string `json:"siteId"`
+	SiteName          string `json:"siteName"`
+}
+
+// threatInfo defines the API model for the details of a threat.
+type threatInfo struct {
+	AnalystVerdict   string `json:"analystVerdict"`
+	Classification   string `json:"classification"`
+	ConfidenceLevel  string `json:"confidenceLevel"`
+	CreatedAt        string `json:"createdAt"`
+	IncidentStatus   string `json:"incidentStatus"`
+	MitigationStatus string `json:"mitigationStatus"`
+	SHA1             string `json:"sha1"`
+	SHA256           string `json:"sha256"`
+	ThreatName       string `json:"threatName"`
+	UpdatedAt        string `json:"updatedAt"`
+}
+
+// ThreatMitigationAction defines the API model for the status of a single mitigation action taken against a threat
+// across all of the agents on which the threat was detected.
+type ThreatMitigationAction struct {
+	Action       string `json:"action"`
+	FailedCount  int64  `json:"failedCount"`
+	PendingCount int64  `json:"pendingCount"`
+	Status       string `json:"status"`
+	SuccessCount int64  `json:"successCount"`
+}
+
+// ThreatNote defines the API model for an analyst note attached to a threat.
+type ThreatNote struct {
+	CreatedAt string `json:"createdAt"`
+	Creator   string `json:"creator"`
+	CreatorId string `json:"creatorId"`
+	Edited    bool   `json:"edited"`
+	Id        string `json:"id"`
+	Text      string `json:"text"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// AddThreatNote adds a note with the given text to the threat with the matching ID and returns the new note.
+//
+// The API only returns the number of threats affected when adding a note so the new note is found by comparing the
+// notes on the threat before and after it is added.
+func (c *client) AddThreatNote(ctx context.Context, threatId, text string) (*ThreatNote, diag.Diagnostics) {
+	// get the existing notes
+	existing, diags := c.FindThreatNotes(ctx, threatId)
+	if diags.HasError() {
+		return nil, diags
+	}
+	existingIds := map[string]bool{}
+	for _, note := range existing {
+		existingIds[note.Id] = true
+	}
+
+	// add the note
+	result, diags := c.Post(ctx, "/threats/notes", map[string]interface{}{
+		"data": map[string]interface{}{
+			"text": text,
+		},
+		"filter": map[string]interface{}{
+			"ids": []string{threatId},
+		},
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+	var action actionResult
+	if err := c.unmarshal(result.Data, &action); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_THREAT_ADD_NOTE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	if action.Affected == 0 {
+		msg := fmt.Sprintf("The note could not be added because the threat does not exist or is not visible to the "+
+			"API token.\n\nThreat ID: %s", threatId)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"threat_id":           threatId,
+			"internal_error_code": plugin.ERR_API_THREAT_ADD_NOTE,
+		})
+		diags.AddError("Threat Not Found", msg)
+		return nil, diags
+	}
+
+	// find the new note
+	notes, diags := c.FindThreatNotes(ctx, threatId)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for i := range notes {
+		if !existingIds[notes[i].Id] && notes[i].Text == text {
+			return &notes[i], diags
+		}
+	}
+	msg := fmt.Sprintf("The note was added to the threat but could not be found afterwards.\n\nThreat ID: %s",
+		threatId)
+	tflog.Error(ctx, msg, map[string]interface{}{
+		"threat_id":           threatId,
+		"internal_error_code": plugin.ERR_API_THREAT_ADD_NOTE,
+	})
+	diags.AddError("API Response Error", msg)
+	return nil, diags
+}
+
+// DeleteThreatNote deletes the note with the matching ID from the threat with the matching ID.
+func (c *client) DeleteThreatNote(ctx context.Context, threatId, noteId string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, fmt.Sprintf("/threats/%s/notes/%s", threatId, noteId), map[string]interface{}{})
+	return diags
+}
+
+// FindThreatNotes returns the list of notes attached to the threat with the matching ID.
+func (c *client) FindThreatNotes(ctx context.Context, threatId string) ([]ThreatNote, diag.Diagnostics) {
+	var notes []ThreatNote
+	var diags diag.Diagnostics
+	getQueryParams := map[string]string{}
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, fmt.Sprintf("/threats/%s/notes", threatId), getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []ThreatNote
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of ThreatNote objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_THREAT_FIND_NOTES,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		notes = append(notes, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return notes, diags
+}
+
+// FindThreats returns a list of threats found based on the given query parameters.
+func (c *client) FindThreats(ctx context.Context, queryParams ThreatQueryParams) ([]Threat, diag.Diagnostics) {
+	var threats []Threat
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/threats", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Threat
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Threat objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_THREAT_FIND_THREATS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		threats = append(threats, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return threats, diags
+}
+
+// GetThreatMitigationStatus returns the status of each mitigation action taken against the threat with the
+// matching ID.
+func (c *client) GetThreatMitigationStatus(ctx context.Context, id string) ([]ThreatMitigationAction,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Get(ctx, fmt.Sprintf("/threats/%s/mitigation-status", id), map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var actions []ThreatMitigationAction
+	if err := c.unmarshal(result.Data, &actions); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"list of ThreatMitigationAction objects.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_THREAT_GET_MITIGATION_STATUS,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return actions, diags
+}
+
+// MitigateThreats applies the given mitigation action (eg: kill, quarantine) to the threats with the matching IDs
+// and returns the number of threats affected.
+func (c *client) MitigateThreats(ctx context.Context, action string, threatIds []string) (int, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, fmt.Sprintf("/threats/mitigate/%s", action), map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": threatIds,
+		},
+	})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	// parse the data returned
+	var mitigation actionResult
+	if err := c.unmarshal(result.Data, &mitigation); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"action result.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_THREAT_MITIGATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return 0, diags
+	}
+	return mitigation.Affected, diags
+}
+
+// UpdateThreatNote replaces the text of the note with the matching ID on the threat with the matching ID.
+func (c *client) UpdateThreatNote(ctx context.Context, threatId, noteId, text string) diag.Diagnostics {
+	_, diags := c.Put(ctx, fmt.Sprintf("/threats/%s/notes/%s", threatId, noteId), map[string]interface{}{
+		"data": map[string]interface{}{
+			"text": text,
+		},
+	})
+	return diags
+}
+
+// ThreatQueryParams is used to hold query parameters for finding threats.
+type ThreatQueryParams struct {
+	AccountIds       []string `json:"accountIds"`
+	AgentIds         []string `json:"agentIds"`
+	Classifications  []string `json:"classifications"`
+	ConfidenceLevels []string `json:"confidenceLevels"`
+	CreatedAtGt      *string  `json:"createdAt__gt"`
+	GroupIds         []string `json:"groupIds"`
+	IncidentStatuses []string `json:"incidentStatuses"`
+	SiteIds          []string `json:"siteIds"`
+	SortBy           *string  `json:"sortBy"`
+	SortOrder        *string  `json:"sortOrder"`
+	ThreatIds        []string `json:"ids"`
+
+	// RawFilter holds additional filters, keyed by API parameter name, which are passed through as-is. Any typed
+	// parameter set above takes precedence over a raw filter with the same name.
+	RawFilter map[string]interface{} `json:"-"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *ThreatQueryParams) toStringMap() map[string]string {
+	queryString := rawFilterToStringMap(p.RawFilter)
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.AgentIds) > 0 {
+		queryString["agentIds"] = strings.Join(p.AgentIds, ",")
+	}
+	if len(p.Classifications) > 0 {
+		queryString["classifications"] = strings.Join(p.Classifications, ",")
+	}
+	if len(p.ConfidenceLevels) > 0 {
+		queryString["confidenceLevels"] = strings.Join(p.ConfidenceLevels, ",")
+	}
+	if p.CreatedAtGt != nil {
+		queryString["createdAt__gt"] = *p.CreatedAtGt
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.IncidentStatuses) > 0 {
+		queryString["incidentStatuses"] = strings.Join(p.IncidentStatuses, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if p.SortBy != nil {
+		queryString["sortBy"] = *p.SortBy
+	}
+	if p.SortOrder != nil {
+		queryString["sortOrder"] = *p.SortOrder
+	}
+	if len(p.ThreatIds) > 0 {
+		queryString["ids"] = strings.Join(p.ThreatIds, ",")
+	}
+	return queryString
+}
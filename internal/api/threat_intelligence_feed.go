@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// threat intelligence feed constants
+const (
+	THREAT_INTELLIGENCE_FEED_FORMAT_CSV   = "csv"
+	THREAT_INTELLIGENCE_FEED_FORMAT_JSON  = "json"
+	THREAT_INTELLIGENCE_FEED_FORMAT_STIX  = "stix"
+	THREAT_INTELLIGENCE_FEED_FORMAT_TAXII = "taxii"
+
+	THREAT_INTELLIGENCE_FEED_STATUS_FAILED  = "failed"
+	THREAT_INTELLIGENCE_FEED_STATUS_PENDING = "pending"
+	THREAT_INTELLIGENCE_FEED_STATUS_SUCCESS = "success"
+)
+
+// ThreatIntelligenceFeed defines the API model for a third-party threat intelligence feed whose indicators are
+// ingested on a recurring schedule.
+type ThreatIntelligenceFeed struct {
+	CreatedAt              string `json:"createdAt"`
+	Description            string `json:"description"`
+	Enabled                bool   `json:"enabled"`
+	FeedUrl                string `json:"feedUrl"`
+	Format                 string `json:"format"`
+	Id                     string `json:"id"`
+	IndicatorCount         int64  `json:"indicatorCount"`
+	LastRefreshedAt        string `json:"lastRefreshedAt"`
+	LastRefreshMessage     string `json:"lastRefreshMessage"`
+	LastRefreshStatus      string `json:"lastRefreshStatus"`
+	Name                   string `json:"name"`
+	RefreshIntervalMinutes int64  `json:"refreshIntervalMinutes"`
+	UpdatedAt              string `json:"updatedAt"`
+}
+
+// ThreatIntelligenceFeedBody is used to hold the attributes used for creating or updating a feed.
+type ThreatIntelligenceFeedBody struct {
+	ApiKey                 *string
+	Description            *string
+	Enabled                *bool
+	FeedUrl                *string
+	Format                 *string
+	Name                   *string
+	RefreshIntervalMinutes *int64
+}
+
+// toBody converts the object into the request body for the API.
+func (b *ThreatIntelligenceFeedBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.ApiKey != nil {
+		body["apiKey"] = *b.ApiKey
+	}
+	if b.Description != nil {
+		body["description"] = *b.Description
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.FeedUrl != nil {
+		body["feedUrl"] = *b.FeedUrl
+	}
+	if b.Format != nil {
+		body["format"] = *b.Format
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.RefreshIntervalMinutes != nil {
+		body["refreshIntervalMinutes"] = *b.RefreshIntervalMinutes
+	}
+	return body
+}
+
+// CreateThreatIntelligenceFeed creates a new threat intelligence feed within the given scope and returns the new
+// feed.
+func (c *client) CreateThreatIntelligenceFeed(ctx context.Context, scope Scope, body ThreatIntelligenceFeedBody) (
+	*ThreatIntelligenceFeed, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/threat-intelligence/feeds", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var feed ThreatIntelligenceFeed
+	if err := c.unmarshal(result.Data, &feed); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ThreatIntelligenceFeed object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_THREAT_INTELLIGENCE_FEED_CREATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &feed, diags
+}
+
+// DeleteThreatIntelligenceFeed deletes the threat intelligence feed with the matching ID.
+//
+// Indicators which were previously ingested from the feed are removed by the server along with the feed.
+func (c *client) DeleteThreatIntelligenceFeed(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/threat-intelligence/feeds", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindThreatIntelligenceFeeds returns a list of threat intelligence feeds found based on the given query parameters.
+func (c *client) FindThreatIntelligenceFeeds(ctx context.Context, queryParams ThreatIntelligenceFeedQueryParams) (
+	[]ThreatIntelligenceFeed, diag.Diagnostics) {
+
+	var feeds []ThreatIntelligenceFeed
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/threat-intelligence/feeds", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []ThreatIntelligenceFeed
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of ThreatIntelligenceFeed objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_THREAT_INTELLIGENCE_FEED_FIND,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		feeds = append(feeds, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return feeds, diags
+}
+
+// UpdateThreatIntelligenceFeed updates the threat intelligence feed with the matching ID using the given attributes
+// and returns the updated feed.
+func (c *client) UpdateThreatIntelligenceFeed(ctx context.Context, id string, body ThreatIntelligenceFeedBody) (
+	*ThreatIntelligenceFeed, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/threat-intelligence/feeds/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var feed ThreatIntelligenceFeed
+	if err := c.unmarshal(result.Data, &feed); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"ThreatIntelligenceFeed object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_THREAT_INTELLIGENCE_FEED_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &feed, diags
+}
+
+// ThreatIntelligenceFeedQueryParams is used to hold query parameters for finding threat intelligence feeds.
+type ThreatIntelligenceFeedQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	FeedIds    []string `json:"ids"`
+	SiteIds    []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *ThreatIntelligenceFeedQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.FeedIds) > 0 {
+		queryString["ids"] = strings.Join(p.FeedIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
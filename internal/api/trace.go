@@ -0,0 +1,88 @@
+package api
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// requestTimings records when each phase of a single request to the API server happened so that slowness in the
+// console can be told apart from slowness in the network.
+type requestTimings struct {
+	connectDone  time.Time
+	connectStart time.Time
+	dnsDone      time.Time
+	dnsStart     time.Time
+	firstByte    time.Time
+	mutex        sync.Mutex
+	reused       bool
+	start        time.Time
+	tlsDone      time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+}
+
+// newRequestTimings returns a new recorder along with the trace which updates it as the request progresses.
+func newRequestTimings() (*requestTimings, *httptrace.ClientTrace) {
+	t := &requestTimings{
+		start: time.Now(),
+	}
+	return t, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { t.set(&t.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { t.set(&t.dnsDone) },
+		// the first connection attempt to start and the first one to finish are the ones which matter when
+		// several addresses are tried in parallel
+		ConnectStart:      func(string, string) { t.setOnce(&t.connectStart) },
+		ConnectDone:       func(string, string, error) { t.setOnce(&t.connectDone) },
+		TLSHandshakeStart: func() { t.set(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { t.set(&t.tlsDone) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mutex.Lock()
+			defer t.mutex.Unlock()
+			t.reused = info.Reused
+		},
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.set(&t.wroteRequest) },
+		GotFirstResponseByte: func() { t.set(&t.firstByte) },
+	}
+}
+
+// fields returns the duration of each phase of the request in milliseconds, suitable for logging.
+//
+// Phases which did not happen (eg: DNS lookups and TLS handshakes when a connection is reused) are omitted.
+func (t *requestTimings) fields() map[string]interface{} {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	fields := map[string]interface{}{
+		"connection_reused": t.reused,
+		"total_ms":          time.Since(t.start).Milliseconds(),
+	}
+	addPhase := func(name string, start, end time.Time) {
+		if !start.IsZero() && !end.IsZero() {
+			fields[name] = end.Sub(start).Milliseconds()
+		}
+	}
+	addPhase("dns_ms", t.dnsStart, t.dnsDone)
+	addPhase("connect_ms", t.connectStart, t.connectDone)
+	addPhase("tls_ms", t.tlsStart, t.tlsDone)
+	addPhase("server_ms", t.wroteRequest, t.firstByte)
+	addPhase("first_byte_ms", t.start, t.firstByte)
+	return fields
+}
+
+// set records the current time in the given field.
+func (t *requestTimings) set(field *time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	*field = time.Now()
+}
+
+// setOnce records the current time in the given field unless it has already been set.
+func (t *requestTimings) setOnce(field *time.Time) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if field.IsZero() {
+		*field = time.Now()
+	}
+}
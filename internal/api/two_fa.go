@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// TwoFASettings defines the API model for the console two-factor authentication settings of a scope.
+type TwoFASettings struct {
+	AllowedMethods  []string `json:"allowedMethods"`
+	Enforced        bool     `json:"enforced"`
+	GracePeriodDays int      `json:"gracePeriodDays"`
+}
+
+// TwoFASettingsBody is used to hold the attributes used for updating the two-factor authentication settings.
+type TwoFASettingsBody struct {
+	AllowedMethods  []string `json:"allowedMethods"`
+	Enforced        *bool    `json:"enforced"`
+	GracePeriodDays *int     `json:"gracePeriodDays"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *TwoFASettingsBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.AllowedMethods != nil {
+		body["allowedMethods"] = b.AllowedMethods
+	}
+	if b.Enforced != nil {
+		body["enforced"] = *b.Enforced
+	}
+	if b.GracePeriodDays != nil {
+		body["gracePeriodDays"] = *b.GracePeriodDays
+	}
+	return body
+}
+
+// GetTwoFASettings returns the two-factor authentication settings of the given scope.
+func (c *client) GetTwoFASettings(ctx context.Context, scope Scope) (*TwoFASettings, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, "/settings/two-fa", scope.toStringMap())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var settings TwoFASettings
+	if err := c.unmarshal(result.Data, &settings); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"TwoFASettings object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_TWO_FA_SETTINGS_GET,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &settings, diags
+}
+
+// UpdateTwoFASettings updates the two-factor authentication settings of the given scope using the given attributes
+// and returns the updated settings.
+func (c *client) UpdateTwoFASettings(ctx context.Context, scope Scope, body TwoFASettingsBody) (*TwoFASettings,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, "/settings/two-fa", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var settings TwoFASettings
+	if err := c.unmarshal(result.Data, &settings); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"TwoFASettings object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_TWO_FA_SETTINGS_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &settings, diags
+}
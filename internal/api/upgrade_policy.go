@@ -0,0 +1,106 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// UpgradePolicy defines the API model for the agent upgrade policy of a scope, which controls whether agents may be
+// upgraded or downgraded locally and which agent versions are pinned for each OS.
+type UpgradePolicy struct {
+	InheritedFrom             string          `json:"inheritedFrom"`
+	LocalUpgradeAuthorization bool            `json:"localUpgradeAuthorization"`
+	PinnedVersions            []PinnedVersion `json:"pinnedVersions"`
+}
+
+// PinnedVersion defines the API model for the agent version to which agents of an OS are pinned.
+type PinnedVersion struct {
+	OsType  string `json:"osType"`
+	Version string `json:"version"`
+}
+
+// UpgradePolicyBody is used to hold the attributes used for updating an upgrade policy.
+type UpgradePolicyBody struct {
+	LocalUpgradeAuthorization *bool
+	PinnedVersions            []PinnedVersion
+}
+
+// toBody converts the object into the request body for the API.
+func (b *UpgradePolicyBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.LocalUpgradeAuthorization != nil {
+		body["localUpgradeAuthorization"] = *b.LocalUpgradeAuthorization
+	}
+
+	// an empty list removes any existing pins so it must always be sent
+	pins := []map[string]interface{}{}
+	for _, pin := range b.PinnedVersions {
+		pins = append(pins, map[string]interface{}{
+			"osType":  pin.OsType,
+			"version": pin.Version,
+		})
+	}
+	body["pinnedVersions"] = pins
+	return map[string]interface{}{
+		"data": body,
+	}
+}
+
+// GetUpgradePolicy returns the effective agent upgrade policy of the given scope.
+func (c *client) GetUpgradePolicy(ctx context.Context, scope Scope) (*UpgradePolicy, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, fmt.Sprintf("%s/upgrade-policy", scope.uriPrefix()), map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy UpgradePolicy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"UpgradePolicy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_UPGRADE_POLICY_GET_POLICY,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
+
+// RevertUpgradePolicy reverts the agent upgrade policy of the given scope so that it is inherited from its parent.
+func (c *client) RevertUpgradePolicy(ctx context.Context, scope Scope) diag.Diagnostics {
+	_, diags := c.Put(ctx, fmt.Sprintf("%s/revert-upgrade-policy", scope.uriPrefix()), map[string]interface{}{})
+	return diags
+}
+
+// UpdateUpgradePolicy updates the agent upgrade policy of the given scope using the given attributes and returns
+// the updated policy.
+func (c *client) UpdateUpgradePolicy(ctx context.Context, scope Scope, body UpgradePolicyBody) (*UpgradePolicy,
+	diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("%s/upgrade-policy", scope.uriPrefix()), body.toBody())
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var policy UpgradePolicy
+	if err := c.unmarshal(result.Data, &policy); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"UpgradePolicy object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_UPGRADE_POLICY_UPDATE_POLICY,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &policy, diags
+}
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// User defines the API model for a console user.
+type User struct {
+	CreatedAt          string `json:"createdAt"`
+	Email              string `json:"email"`
+	EmailVerified      bool   `json:"emailVerified"`
+	FullName           string `json:"fullName"`
+	Id                 string `json:"id"`
+	LastLogin          string `json:"lastLogin"`
+	LowestRole         string `json:"lowestRole"`
+	PrimaryTwoFaMethod string `json:"primaryTwoFaMethod"`
+	Scope              string `json:"scope"`
+	Source             string `json:"source"`
+	TwoFaEnabled       bool   `json:"twoFaEnabled"`
+	UpdatedAt          string `json:"updatedAt"`
+}
+
+// FindUsers returns a list of users found based on the given query parameters.
+func (c *client) FindUsers(ctx context.Context, queryParams UserQueryParams) ([]User, diag.Diagnostics) {
+	var users []User
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/users", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []User
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of User objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_USER_FIND_USERS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		users = append(users, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return users, diags
+}
+
+// GetCurrentUser returns the user which owns the API token used by the client.
+func (c *client) GetCurrentUser(ctx context.Context) (*User, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Get(ctx, "/user", map[string]string{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var user User
+	if err := c.unmarshal(result.Data, &user); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"User object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_USER_GET_CURRENT_USER,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &user, diags
+}
+
+// GenerateUserApiToken generates a new API token for the user with the matching ID which expires at the given time
+// and returns the token. Any previous token for the user is revoked.
+func (c *client) GenerateUserApiToken(ctx context.Context, id, expirationDate string) (string, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, fmt.Sprintf("/users/%s/generate-api-token", id), map[string]interface{}{
+		"data": map[string]interface{}{
+			"expirationDate": expirationDate,
+		},
+	})
+	if diags.HasError() {
+		return "", diags
+	}
+
+	// parse the data returned
+	var token struct {
+		Token string `json:"token"`
+	}
+	if err := c.unmarshal(result.Data, &token); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into an "+
+			"API token.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_USER_GENERATE_API_TOKEN,
+		})
+		diags.AddError("API Response Error", msg)
+		return "", diags
+	}
+	return token.Token, diags
+}
+
+// RevokeUserApiToken revokes the API token of the user with the matching ID.
+func (c *client) RevokeUserApiToken(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Post(ctx, fmt.Sprintf("/users/%s/revoke-api-token", id), nil)
+	return diags
+}
+
+// UserQueryParams is used to hold query parameters for finding users.
+type UserQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	GroupIds   []string `json:"groupIds"`
+	SiteIds    []string `json:"siteIds"`
+	Tenant     *bool    `json:"tenant"`
+	UserIds    []string `json:"ids"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *UserQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.GroupIds) > 0 {
+		queryString["groupIds"] = strings.Join(p.GroupIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if p.Tenant != nil {
+		queryString["tenant"] = fmt.Sprintf("%t", *p.Tenant)
+	}
+	if len(p.UserIds) > 0 {
+		queryString["ids"] = strings.Join(p.UserIds, ",")
+	}
+	return queryString
+}
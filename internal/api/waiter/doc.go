@@ -0,0 +1,2 @@
+// Package waiter contains functions for polling the SentinelOne REST API until an asynchronous task completes.
+package waiter
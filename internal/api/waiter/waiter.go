@@ -0,0 +1,125 @@
+package waiter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+const (
+	// DEFAULT_POLL_INTERVAL is the time to wait between polls when no interval is given.
+	DEFAULT_POLL_INTERVAL = 5 * time.Second
+
+	// DEFAULT_TIMEOUT is the maximum time to wait for a task to complete when no timeout is given.
+	DEFAULT_TIMEOUT = 10 * time.Minute
+)
+
+// RefreshFunc retrieves the current state of an asynchronous task along with the object representing it.
+type RefreshFunc[T any] func(ctx context.Context) (T, string, diag.Diagnostics)
+
+// Config defines how an asynchronous task is polled and which of its states are expected.
+type Config struct {
+	// Description is a short description of the task used in log messages and diagnostics (eg: agent upgrade).
+	Description string
+
+	// Interval is the time to wait between polls. [Default: DEFAULT_POLL_INTERVAL]
+	Interval time.Duration
+
+	// Pending holds the states in which the task is still running. An empty list allows any state which is not a
+	// target state.
+	Pending []string
+
+	// Target holds the terminal states in which the task has completed successfully.
+	Target []string
+
+	// Timeout is the maximum time to wait for the task to reach a target state. [Default: DEFAULT_TIMEOUT]
+	Timeout time.Duration
+}
+
+// Wait polls the task using the given function until it reaches one of the target states and returns the final
+// object representing the task.
+//
+// An error is returned if the task reaches a state which is neither pending nor a target, if polling the task fails,
+// if the timeout expires or if the context is cancelled.
+func Wait[T any](ctx context.Context, config Config, refresh RefreshFunc[T]) (T, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	interval := config.Interval
+	if interval <= 0 {
+		interval = DEFAULT_POLL_INTERVAL
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DEFAULT_TIMEOUT
+	}
+	ctx = tflog.SetField(ctx, "task", config.Description)
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		// check the current state of the task
+		obj, state, d := refresh(ctx)
+		if d.HasError() {
+			return obj, d
+		}
+		tflog.Debug(ctx, "polled asynchronous task", map[string]interface{}{
+			"state": state,
+		})
+		if contains(config.Target, state) {
+			return obj, diags
+		}
+		if len(config.Pending) > 0 && !contains(config.Pending, state) {
+			msg := fmt.Sprintf("The %s reached an unexpected state.\n\nState: %s\nExpected States: %s",
+				config.Description, state, strings.Join(append(append([]string{}, config.Pending...),
+					config.Target...), ", "))
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"state":               state,
+				"internal_error_code": plugin.ERR_API_WAITER_UNEXPECTED_STATE,
+			})
+			diags.AddError("Task Failed", msg)
+			return obj, diags
+		}
+
+		// wait for the next poll
+		poll := time.NewTimer(interval)
+		select {
+		case <-poll.C:
+		case <-deadline.C:
+			poll.Stop()
+			msg := fmt.Sprintf("Timed out after %s waiting for the %s to complete.\n\nLast State: %s",
+				timeout, config.Description, state)
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"state":               state,
+				"internal_error_code": plugin.ERR_API_WAITER_TIMEOUT,
+			})
+			diags.AddError("Task Timed Out", msg)
+			return obj, diags
+		case <-ctx.Done():
+			poll.Stop()
+			msg := fmt.Sprintf("Stopped waiting for the %s to complete.\n\nError: %s\nLast State: %s",
+				config.Description, ctx.Err().Error(), state)
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               ctx.Err().Error(),
+				"state":               state,
+				"internal_error_code": plugin.ERR_API_WAITER_CANCELLED,
+			})
+			diags.AddError("Task Cancelled", msg)
+			return obj, diags
+		}
+	}
+}
+
+// contains determines whether or not the given state is in the list of states.
+func contains(states []string, state string) bool {
+	for _, s := range states {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// Webhook defines the API model for an outbound webhook integration.
+//
+// The secret used to sign deliveries is never returned by the API.
+type Webhook struct {
+	CreatedAt          string   `json:"createdAt"`
+	Enabled            bool     `json:"enabled"`
+	EventTypes         []string `json:"eventTypes"`
+	Id                 string   `json:"id"`
+	LastDeliveryAt     string   `json:"lastDeliveryAt"`
+	LastDeliveryStatus string   `json:"lastDeliveryStatus"`
+	Name               string   `json:"name"`
+	UpdatedAt          string   `json:"updatedAt"`
+	URL                string   `json:"url"`
+}
+
+// WebhookBody is used to hold the attributes used for creating or updating a webhook.
+type WebhookBody struct {
+	Enabled    *bool    `json:"enabled"`
+	EventTypes []string `json:"eventTypes"`
+	Name       *string  `json:"name"`
+	Secret     *string  `json:"secret"`
+	URL        *string  `json:"url"`
+}
+
+// toBody converts the object into the request body for the API.
+func (b *WebhookBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.EventTypes != nil {
+		body["eventTypes"] = b.EventTypes
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.Secret != nil {
+		body["secret"] = *b.Secret
+	}
+	if b.URL != nil {
+		body["url"] = *b.URL
+	}
+	return body
+}
+
+// CreateWebhook creates a new webhook within the given scope and returns the new webhook.
+func (c *client) CreateWebhook(ctx context.Context, scope Scope, body WebhookBody) (*Webhook, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Post(ctx, "/webhooks", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var webhook Webhook
+	if err := c.unmarshal(result.Data, &webhook); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Webhook object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_WEBHOOK_CREATE_WEBHOOK,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &webhook, diags
+}
+
+// DeleteWebhook deletes the webhook with the matching ID.
+func (c *client) DeleteWebhook(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, fmt.Sprintf("/webhooks/%s", id), nil)
+	return diags
+}
+
+// FindWebhooks returns a list of webhooks found based on the given query parameters.
+func (c *client) FindWebhooks(ctx context.Context, queryParams WebhookQueryParams) ([]Webhook, diag.Diagnostics) {
+	var webhooks []Webhook
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/webhooks", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []Webhook
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of Webhook objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_WEBHOOK_FIND_WEBHOOKS,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		webhooks = append(webhooks, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return webhooks, diags
+}
+
+// UpdateWebhook updates the webhook with the matching ID using the given attributes and returns the updated webhook.
+func (c *client) UpdateWebhook(ctx context.Context, id string, body WebhookBody) (*Webhook, diag.Diagnostics) {
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/webhooks/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var webhook Webhook
+	if err := c.unmarshal(result.Data, &webhook); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"Webhook object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_WEBHOOK_UPDATE_WEBHOOK,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &webhook, diags
+}
+
+// WebhookQueryParams is used to hold query parameters for finding webhooks.
+type WebhookQueryParams struct {
+	AccountIds []string `json:"accountIds"`
+	SiteIds    []string `json:"siteIds"`
+	WebhookIds []string `json:"ids"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *WebhookQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	if len(p.WebhookIds) > 0 {
+		queryString["ids"] = strings.Join(p.WebhookIds, ",")
+	}
+	return queryString
+}
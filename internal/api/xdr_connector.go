@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// XDR connector constants
+const (
+	XDR_CONNECTOR_HEALTH_HEALTHY   = "healthy"
+	XDR_CONNECTOR_HEALTH_PENDING   = "pending"
+	XDR_CONNECTOR_HEALTH_UNHEALTHY = "unhealthy"
+)
+
+// XdrConnector defines the API model for a connector which ingests data from a third-party product into XDR.
+type XdrConnector struct {
+	ConnectorType          string `json:"connectorType"`
+	CreatedAt              string `json:"createdAt"`
+	CredentialId           string `json:"credentialId"`
+	Enabled                bool   `json:"enabled"`
+	Health                 string `json:"health"`
+	HealthMessage          string `json:"healthMessage"`
+	Id                     string `json:"id"`
+	LastPolledAt           string `json:"lastPolledAt"`
+	Name                   string `json:"name"`
+	PollingIntervalMinutes int64  `json:"pollingIntervalMinutes"`
+	UpdatedAt              string `json:"updatedAt"`
+}
+
+// XdrConnectorBody is used to hold the attributes used for creating or updating a connector.
+type XdrConnectorBody struct {
+	ConnectorType          *string
+	CredentialId           *string
+	Enabled                *bool
+	Name                   *string
+	PollingIntervalMinutes *int64
+}
+
+// toBody converts the object into the request body for the API.
+func (b *XdrConnectorBody) toBody() map[string]interface{} {
+	body := map[string]interface{}{}
+	if b.ConnectorType != nil {
+		body["connectorType"] = *b.ConnectorType
+	}
+	if b.CredentialId != nil {
+		body["credentialId"] = *b.CredentialId
+	}
+	if b.Enabled != nil {
+		body["enabled"] = *b.Enabled
+	}
+	if b.Name != nil {
+		body["name"] = *b.Name
+	}
+	if b.PollingIntervalMinutes != nil {
+		body["pollingIntervalMinutes"] = *b.PollingIntervalMinutes
+	}
+	return body
+}
+
+// CreateXdrConnector creates a new XDR connector within the given scope and returns the new connector.
+func (c *client) CreateXdrConnector(ctx context.Context, scope Scope, body XdrConnectorBody) (
+	*XdrConnector, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Post(ctx, "/xdr/connectors", map[string]interface{}{
+		"data":   body.toBody(),
+		"filter": scope.toFilter(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var connector XdrConnector
+	if err := c.unmarshal(result.Data, &connector); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"XdrConnector object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_XDR_CONNECTOR_CREATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &connector, diags
+}
+
+// DeleteXdrConnector deletes the XDR connector with the matching ID.
+func (c *client) DeleteXdrConnector(ctx context.Context, id string) diag.Diagnostics {
+	_, diags := c.Delete(ctx, "/xdr/connectors", map[string]interface{}{
+		"filter": map[string]interface{}{
+			"ids": []string{id},
+		},
+	})
+	return diags
+}
+
+// FindXdrConnectors returns a list of XDR connectors found based on the given query parameters.
+func (c *client) FindXdrConnectors(ctx context.Context, queryParams XdrConnectorQueryParams) (
+	[]XdrConnector, diag.Diagnostics) {
+
+	var connectors []XdrConnector
+	var diags diag.Diagnostics
+	getQueryParams := queryParams.toStringMap()
+	for {
+		// get a page of results
+		result, diags := c.Get(ctx, "/xdr/connectors", getQueryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		// parse the response
+		var page []XdrConnector
+		if err := c.unmarshal(result.Data, &page); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+				"list of XdrConnector objects.\n\nError: %s", err.Error())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_API_XDR_CONNECTOR_FIND,
+			})
+			diags.AddError("API Response Error", msg)
+			return nil, diags
+		}
+		connectors = append(connectors, page...)
+
+		// get the next page of results until there is no next cursor
+		if result.Pagination.NextCursor == "" {
+			break
+		}
+		getQueryParams["cursor"] = result.Pagination.NextCursor
+	}
+	return connectors, diags
+}
+
+// UpdateXdrConnector updates the XDR connector with the matching ID using the given attributes and returns the
+// updated connector.
+func (c *client) UpdateXdrConnector(ctx context.Context, id string, body XdrConnectorBody) (
+	*XdrConnector, diag.Diagnostics) {
+
+	// query the API
+	result, diags := c.Put(ctx, fmt.Sprintf("/xdr/connectors/%s", id), map[string]interface{}{
+		"data": body.toBody(),
+	})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// parse the data returned
+	var connector XdrConnector
+	if err := c.unmarshal(result.Data, &connector); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the response from the API Server into a "+
+			"XdrConnector object.\n\nError: %s", err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_API_XDR_CONNECTOR_UPDATE,
+		})
+		diags.AddError("API Response Error", msg)
+		return nil, diags
+	}
+	return &connector, diags
+}
+
+// XdrConnectorQueryParams is used to hold query parameters for finding XDR connectors.
+type XdrConnectorQueryParams struct {
+	AccountIds   []string `json:"accountIds"`
+	ConnectorIds []string `json:"ids"`
+	SiteIds      []string `json:"siteIds"`
+}
+
+// toStringMap converts the object into a string map for actual query parameters.
+func (p *XdrConnectorQueryParams) toStringMap() map[string]string {
+	queryString := map[string]string{}
+	if len(p.AccountIds) > 0 {
+		queryString["accountIds"] = strings.Join(p.AccountIds, ",")
+	}
+	if len(p.ConnectorIds) > 0 {
+		queryString["ids"] = strings.Join(p.ConnectorIds, ",")
+	}
+	if len(p.SiteIds) > 0 {
+		queryString["siteIds"] = strings.Join(p.SiteIds, ",")
+	}
+	return queryString
+}
@@ -0,0 +1,2 @@
+// Package importer generates Terraform configuration for bringing existing console objects under management.
+package importer
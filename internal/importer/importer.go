@@ -0,0 +1,181 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// invalidNameChars matches any characters which are not allowed in a Terraform resource name.
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// attribute is a single argument written into a resource skeleton.
+type attribute struct {
+	name  string
+	value string
+}
+
+// generator holds the configuration being generated.
+type generator struct {
+	// buf holds the generated configuration.
+	buf bytes.Buffer
+
+	// names holds the resource names already used for each resource type so that every name is unique.
+	names map[string]map[string]struct{}
+}
+
+// Generate writes an import block and a resource skeleton for each site, group and exclusion found within the given
+// account or site to the given writer.
+//
+// The import blocks require Terraform 1.5 or later. Each skeleton only contains the required arguments so the
+// remaining arguments should be reviewed with "terraform plan" before applying. Default groups are skipped since
+// they are created and deleted along with their site.
+func Generate(ctx context.Context, w io.Writer, scope api.Scope) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if scope.Type != api.SCOPE_ACCOUNT && scope.Type != api.SCOPE_SITE {
+		msg := fmt.Sprintf("Import blocks can only be generated for an %s or %s.\n\nScope Type: %s",
+			api.SCOPE_ACCOUNT, api.SCOPE_SITE, scope.Type)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"scope_type":          scope.Type,
+			"internal_error_code": plugin.ERR_IMPORTER_UNSUPPORTED_SCOPE,
+		})
+		diags.AddError("Unsupported Scope", msg)
+		return diags
+	}
+	var accountIds, siteIds []string
+	if scope.Type == api.SCOPE_ACCOUNT {
+		accountIds = []string{scope.Id}
+	} else {
+		siteIds = []string{scope.Id}
+	}
+
+	g := &generator{
+		names: map[string]map[string]struct{}{},
+	}
+	fmt.Fprintf(&g.buf, "# Generated by terraform-provider-sentinelone-singularity -generate-imports for %s %s.\n",
+		scope.Type, scope.Id)
+
+	// sites
+	sites, diags := api.Client().FindSites(ctx, api.SiteQueryParams{AccountIds: accountIds, SiteIds: siteIds})
+	if diags.HasError() {
+		return diags
+	}
+	for _, site := range sites {
+		if site.State == "deleted" {
+			continue
+		}
+		g.writeResource("singularity_site", site.Name, site.Id, []attribute{
+			{name: "account_id", value: site.AccountId},
+			{name: "name", value: site.Name},
+		})
+	}
+
+	// groups
+	groups, diags := api.Client().FindGroups(ctx, api.GroupQueryParams{AccountIds: accountIds, SiteIds: siteIds})
+	if diags.HasError() {
+		return diags
+	}
+	for _, group := range groups {
+		if group.IsDefault {
+			continue
+		}
+		g.writeResource("singularity_group", group.Name, group.Id, []attribute{
+			{name: "name", value: group.Name},
+			{name: "site_id", value: group.SiteId},
+		})
+	}
+
+	// exclusions directly within the scope
+	exclusions, diags := api.Client().FindExclusions(ctx, api.ExclusionQueryParams{
+		AccountIds: accountIds,
+		SiteIds:    siteIds,
+	})
+	if diags.HasError() {
+		return diags
+	}
+	for _, exclusion := range exclusions {
+		importId := fmt.Sprintf("%s/%s/%s/%s", scope.Type, scope.Id, exclusion.Type, exclusion.Id)
+		g.writeResource("singularity_exclusion", fmt.Sprintf("%s_%s", exclusion.Type, exclusion.Value), importId,
+			[]attribute{
+				{name: "os_type", value: exclusion.OSType},
+				{name: "scope_id", value: scope.Id},
+				{name: "scope_type", value: scope.Type},
+				{name: "type", value: exclusion.Type},
+				{name: "value", value: exclusion.Value},
+			})
+	}
+
+	// write out the configuration
+	if _, err := w.Write(g.buf.Bytes()); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while writing the generated configuration.\n\nError: %s",
+			err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_IMPORTER_WRITE_CONFIG,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return diags
+	}
+	tflog.Info(ctx, "Generated import blocks", map[string]interface{}{
+		"scope_type": scope.Type,
+		"scope_id":   scope.Id,
+		"sites":      len(sites),
+		"groups":     len(groups),
+		"exclusions": len(exclusions),
+	})
+	return diags
+}
+
+// writeResource writes an import block and resource skeleton for a single object.
+func (g *generator) writeResource(resourceType, objectName, importId string, attributes []attribute) {
+	name := g.resourceName(resourceType, objectName, importId)
+	fmt.Fprintf(&g.buf, "\nimport {\n  to = %s.%s\n  id = %s\n}\n\n", resourceType, name, hclString(importId))
+	fmt.Fprintf(&g.buf, "resource %q %q {\n", resourceType, name)
+	width := 0
+	for _, a := range attributes {
+		if len(a.name) > width {
+			width = len(a.name)
+		}
+	}
+	for _, a := range attributes {
+		fmt.Fprintf(&g.buf, "  %-*s = %s\n", width, a.name, hclString(a.value))
+	}
+	g.buf.WriteString("}\n")
+}
+
+// resourceName returns a unique Terraform resource name derived from the name of the object.
+//
+// If the object's name cannot be used or is already taken, the ID is appended.
+func (g *generator) resourceName(resourceType, objectName, id string) string {
+	if _, ok := g.names[resourceType]; !ok {
+		g.names[resourceType] = map[string]struct{}{}
+	}
+	used := g.names[resourceType]
+
+	name := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(objectName), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "r_" + name
+	}
+	if _, ok := used[name]; ok {
+		name = strings.TrimSuffix(name, "_") + "_" + invalidNameChars.ReplaceAllString(strings.ToLower(id), "_")
+	}
+	used[name] = struct{}{}
+	return name
+}
+
+// hclString returns the given value as a quoted HCL string.
+//
+// Go quoting is compatible with HCL apart from template sequences, which must be escaped.
+func hclString(value string) string {
+	quoted := fmt.Sprintf("%q", value)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
@@ -0,0 +1,2 @@
+// Package mockapi serves canned SentinelOne Singularity API responses from a set of JSON fixtures.
+package mockapi
@@ -0,0 +1,45 @@
+package mockapi
+
+import (
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+)
+
+// NewHandler returns an HTTP handler which serves fixtures from the given file system.
+//
+// Fixtures are looked up by HTTP method and URI relative to the API base URI. For example, a GET request for
+// /web/api/v2.1/sites is answered with the contents of GET/sites.json and a PUT request for
+// /web/api/v2.1/sites/123/policy is answered with PUT/sites/123/policy.json. If there is no JSON fixture but there is
+// a file named after the URI itself (eg: GET/update/agent/download/123/456 for a package download), its contents are
+// returned as a binary stream. Requests which have no matching fixture receive a 404 response in the same format as
+// the real API. If logger is not nil, every request is logged.
+func NewHandler(fixtures fs.FS, logger *log.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uri := strings.Trim(strings.TrimPrefix(r.URL.Path, api.API_BASE_URI), "/")
+		name := path.Join(r.Method, uri+".json")
+		if logger != nil {
+			logger.Printf("%s %s -> %s", r.Method, r.URL.String(), name)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		body, err := fs.ReadFile(fixtures, name)
+		if err != nil {
+			if stream, streamErr := fs.ReadFile(fixtures, path.Join(r.Method, uri)); streamErr == nil {
+				w.Header().Set("Content-Type", "application/octet-stream")
+				w.Write(stream)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"errors":[{"code":4040010,"title":"Not Found","detail":"no fixture for %s %s"}]}`,
+				r.Method, uri)
+			return
+		}
+		w.Write(body)
+	})
+}
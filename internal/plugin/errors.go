@@ -6,42 +6,339 @@ const (
 
 	ERR_VALIDATOR_ENUM_STRING     = 450
 	ERR_VALIDATOR_ENUM_STRINGLIST = 451
+	ERR_VALIDATOR_DURATION        = 452
+	ERR_VALIDATOR_SCOPE_LICENSE   = 453
+	ERR_VALIDATOR_TIMESTAMP       = 454
+	ERR_VALIDATOR_TIMESTAMP_ORDER = 455
+	ERR_VALIDATOR_JSON_OBJECT     = 456
 
-	ERR_UTIL_CREATE_FILE           = 500
-	ERR_UTIL_GET_FILE_SHA1         = 501
-	ERR_UTIL_PATH_EXISTS           = 502
-	ERR_UTIL_PARSE_FILESYSTEM_MODE = 503
-	ERR_UTIL_TO_ABSOLUTE_PATH      = 504
-	ERR_UTIL_CREATE_DIRECTORY      = 505
-
-	ERR_API_CLIENT_DO                = 1000
-	ERR_API_CLIENT_DO_AND_PARSE      = 1001
-	ERR_API_CLIENT_DO_AND_STREAM     = 1002
-	ERR_API_PACKAGE_FIND_PACKAGES    = 1003
-	ERR_API_PACKAGE_DOWNLOAD_PACKAGE = 1004
-	ERR_API_PACKAGE_GET_PACKAGE      = 1005
-	ERR_API_GROUP_FIND_GROUPS        = 1006
-	ERR_API_GROUP_GET_GROUP          = 1007
-	ERR_API_SITE_FIND_SITES          = 1008
-	ERR_API_SITE_GET_SITES           = 1009
-
-	ERR_DATASOURCE_GROUP_CONFIGURE    = 2000
-	ERR_DATASOURCE_PACKAGE_CONFIGURE  = 2001
-	ERR_DATASOURCE_SITE_CONFIGURE     = 2002
-	ERR_DATASOURCE_GROUPS_CONFIGURE   = 2003
-	ERR_DATASOURCE_PACKAGES_CONFIGURE = 2004
-	ERR_DATASOURCE_SITES_CONFIGURE    = 2005
-
-	ERR_RESOURCE_PACKAGE_DOWNLOAD_CONFIGURE           = 3000
-	ERR_RESOURCE_PACKAGE_DOWNLOAD_CREATE              = 3001
-	ERR_RESOURCE_PACKAGE_DOWNLOAD_READ                = 3002
-	ERR_RESOURCE_PACKAGE_DOWNLOAD_UPDATE              = 3003
-	ERR_RESOURCE_PACKAGE_DOWNLOAD_DELETE              = 3004
-	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_CONFIGURE   = 3005
-	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_CREATE      = 3006
-	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_READ        = 3007
-	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_UPDATE      = 3008
-	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_DELETE      = 3009
-	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_DOCKER_INIT = 3010
-	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_DOCKER_LOAD = 3011
+	ERR_MODIFIER_ALIAS_CONFLICT = 470
+
+	ERR_UTIL_CREATE_FILE             = 500
+	ERR_UTIL_GET_FILE_SHA1           = 501
+	ERR_UTIL_PATH_EXISTS             = 502
+	ERR_UTIL_PARSE_FILESYSTEM_MODE   = 503
+	ERR_UTIL_TO_ABSOLUTE_PATH        = 504
+	ERR_UTIL_CREATE_DIRECTORY        = 505
+	ERR_UTIL_PARSE_RELATIVE_DURATION = 506
+	ERR_UTIL_ADD_DEFENDER_EXCLUSION  = 507
+	ERR_UTIL_GET_FILE_SHA256         = 508
+	ERR_UTIL_RESOLVE_TIMESTAMP       = 509
+	ERR_UTIL_CREATE_TEMP_DIR         = 510
+	ERR_UTIL_COMMIT_TEMP_FILE        = 511
+
+	ERR_IMPORTER_WRITE_CONFIG      = 550
+	ERR_IMPORTER_UNSUPPORTED_SCOPE = 551
+
+	ERR_API_CLIENT_DO                        = 1000
+	ERR_API_CLIENT_DO_AND_PARSE              = 1001
+	ERR_API_CLIENT_DO_AND_STREAM             = 1002
+	ERR_API_PACKAGE_FIND_PACKAGES            = 1003
+	ERR_API_PACKAGE_DOWNLOAD_PACKAGE         = 1004
+	ERR_API_PACKAGE_GET_PACKAGE              = 1005
+	ERR_API_GROUP_FIND_GROUPS                = 1006
+	ERR_API_GROUP_GET_GROUP                  = 1007
+	ERR_API_SITE_FIND_SITES                  = 1008
+	ERR_API_SITE_GET_SITES                   = 1009
+	ERR_API_GROUP_CREATE_GROUP               = 1010
+	ERR_API_GROUP_UPDATE_GROUP               = 1011
+	ERR_API_SITE_CREATE_SITE                 = 1012
+	ERR_API_SITE_UPDATE_SITE                 = 1013
+	ERR_API_AGENT_FIND_AGENTS                = 1014
+	ERR_API_AGENT_SET_EXTERNAL_ID            = 1015
+	ERR_API_ACCOUNT_FIND_ACCOUNTS            = 1016
+	ERR_API_ACCOUNT_CREATE_ACCOUNT           = 1017
+	ERR_API_ACCOUNT_UPDATE_ACCOUNT           = 1018
+	ERR_API_EXCLUSION_FIND_EXCLUSIONS        = 1019
+	ERR_API_FIREWALL_FIND_FIREWALL_RULES     = 1020
+	ERR_API_EXCLUSION_CREATE_EXCLUSION       = 1021
+	ERR_API_EXCLUSION_UPDATE_EXCLUSION       = 1022
+	ERR_API_RESTRICTION_CREATE_RESTRICTION   = 1023
+	ERR_API_RESTRICTION_FIND_RESTRICTIONS    = 1024
+	ERR_API_RESTRICTION_UPDATE_RESTRICTION   = 1025
+	ERR_API_POLICY_GET_SITE_POLICY           = 1026
+	ERR_API_POLICY_UPDATE_SITE_POLICY        = 1027
+	ERR_API_DEVICE_CONTROL_CREATE_RULE       = 1028
+	ERR_API_DEVICE_CONTROL_FIND_RULES        = 1029
+	ERR_API_DEVICE_CONTROL_UPDATE_RULE       = 1030
+	ERR_API_AGENT_CONNECT_AGENTS             = 1031
+	ERR_API_AGENT_DISCONNECT_AGENTS          = 1032
+	ERR_API_USER_FIND_USERS                  = 1033
+	ERR_API_THREAT_FIND_THREATS              = 1034
+	ERR_API_SERVICE_USER_CREATE_SERVICE_USER = 1035
+	ERR_API_SERVICE_USER_FIND_SERVICE_USERS  = 1036
+	ERR_API_SERVICE_USER_GENERATE_API_TOKEN  = 1037
+	ERR_API_SERVICE_USER_UPDATE_SERVICE_USER = 1038
+	ERR_API_ROLE_CREATE_ROLE                 = 1039
+	ERR_API_ROLE_FIND_ROLES                  = 1040
+	ERR_API_ROLE_UPDATE_ROLE                 = 1041
+	ERR_API_USER_GENERATE_API_TOKEN          = 1042
+	ERR_API_EVENT_FORWARDING_GET             = 1043
+	ERR_API_EVENT_FORWARDING_UPDATE          = 1044
+	ERR_API_FILTER_CREATE_FILTER             = 1045
+	ERR_API_FILTER_FIND_FILTERS              = 1046
+	ERR_API_FILTER_UPDATE_FILTER             = 1047
+	ERR_API_SYSTEM_GET_INFO                  = 1048
+	ERR_API_USER_GET_CURRENT_USER            = 1049
+	ERR_API_TAG_CREATE_TAG                   = 1050
+	ERR_API_TAG_FIND_TAGS                    = 1051
+	ERR_API_TAG_UPDATE_TAG                   = 1052
+	ERR_API_AGENT_MANAGE_TAGS                = 1053
+	ERR_API_AGENT_EXPORT_AGENTS              = 1054
+	ERR_API_NOTIFICATION_GET_RULE            = 1055
+	ERR_API_NOTIFICATION_UPDATE_RULE         = 1056
+	ERR_API_SYSLOG_CONNECTOR_GET             = 1057
+	ERR_API_SYSLOG_CONNECTOR_UPDATE          = 1058
+	ERR_API_WEBHOOK_CREATE_WEBHOOK           = 1059
+	ERR_API_WEBHOOK_FIND_WEBHOOKS            = 1060
+	ERR_API_WEBHOOK_UPDATE_WEBHOOK           = 1061
+	ERR_API_CLIENT_NORMALIZE_IDS             = 1062
+	ERR_API_SSO_SETTINGS_GET                 = 1063
+	ERR_API_SSO_SETTINGS_UPDATE              = 1064
+	ERR_API_WAITER_UNEXPECTED_STATE          = 1065
+	ERR_API_WAITER_TIMEOUT                   = 1066
+	ERR_API_WAITER_CANCELLED                 = 1067
+	ERR_API_THREAT_GET_MITIGATION_STATUS     = 1068
+	ERR_API_CLIENT_MAINTENANCE               = 1069
+	ERR_API_CLIENT_SCOPE_TOKEN               = 1070
+	ERR_API_AGENT_INITIATE_SCAN              = 1071
+	ERR_API_CLIENT_MULTIPART                 = 1072
+	ERR_API_REMOTE_SCRIPT_CREATE_SCRIPT      = 1073
+	ERR_API_REMOTE_SCRIPT_FIND_SCRIPTS       = 1074
+	ERR_API_REMOTE_SCRIPT_UPDATE_SCRIPT      = 1075
+	ERR_API_REMOTE_SCRIPT_EXECUTE_SCRIPT     = 1076
+	ERR_API_REMOTE_SCRIPT_GET_TASKS          = 1077
+	ERR_API_REMOTE_SCRIPT_FETCH_FILES        = 1078
+	ERR_API_THREAT_ADD_NOTE                  = 1079
+	ERR_API_THREAT_FIND_NOTES                = 1080
+	ERR_API_THREAT_MITIGATE                  = 1081
+	ERR_API_CONFIG_OVERRIDE_CREATE_OVERRIDE  = 1082
+	ERR_API_CONFIG_OVERRIDE_FIND_OVERRIDES   = 1083
+	ERR_API_CONFIG_OVERRIDE_UPDATE_OVERRIDE  = 1084
+	ERR_API_NETWORK_LOCATION_CREATE_LOCATION = 1085
+	ERR_API_NETWORK_LOCATION_FIND_LOCATIONS  = 1086
+	ERR_API_NETWORK_LOCATION_UPDATE_LOCATION = 1087
+	ERR_API_UPGRADE_POLICY_GET_POLICY        = 1088
+	ERR_API_UPGRADE_POLICY_UPDATE_POLICY     = 1089
+	ERR_API_RANGER_SETTINGS_GET              = 1090
+	ERR_API_RANGER_SETTINGS_UPDATE           = 1091
+	ERR_API_RANGER_CREATE_DEPLOY_JOB         = 1092
+	ERR_API_RANGER_FIND_DEPLOY_TARGETS       = 1093
+	ERR_API_REGISTRATION_TOKEN_REGENERATE    = 1094
+	ERR_API_REPORT_CREATE_SCHEDULE           = 1095
+	ERR_API_REPORT_FIND_SCHEDULES            = 1096
+	ERR_API_REPORT_UPDATE_SCHEDULE           = 1097
+	ERR_API_IOC_CREATE_IOC                   = 1098
+	ERR_API_IOC_FIND_IOCS                    = 1099
+	ERR_API_POWER_QUERY_CREATE_SAVED_QUERY   = 1100
+	ERR_API_POWER_QUERY_FIND_SAVED_QUERIES   = 1101
+	ERR_API_POWER_QUERY_UPDATE_SAVED_QUERY   = 1102
+	ERR_API_ALERT_RULE_CREATE_RULE           = 1103
+	ERR_API_ALERT_RULE_FIND_RULES            = 1104
+	ERR_API_ALERT_RULE_UPDATE_RULE           = 1105
+	ERR_API_POLICY_GET_POLICY                = 1106
+	ERR_API_POLICY_BREAK_INHERITANCE         = 1107
+	ERR_API_AGENT_MOVE_TO_SITE               = 1108
+	ERR_API_AGENT_MOVE_TO_GROUP              = 1109
+	ERR_API_PROTECTED_ACTIONS_GET            = 1110
+	ERR_API_PROTECTED_ACTIONS_UPDATE         = 1111
+	ERR_API_TWO_FA_SETTINGS_GET              = 1112
+	ERR_API_TWO_FA_SETTINGS_UPDATE           = 1113
+	ERR_API_NOTIFICATION_RECIPIENT_CREATE    = 1114
+	ERR_API_NOTIFICATION_RECIPIENT_FIND      = 1115
+	ERR_API_NOTIFICATION_RECIPIENT_UPDATE    = 1116
+	ERR_API_MARKETPLACE_APP_CREATE           = 1117
+	ERR_API_MARKETPLACE_APP_FIND             = 1118
+	ERR_API_MARKETPLACE_APP_UPDATE           = 1119
+	ERR_API_CLOUD_CONNECTOR_CREATE           = 1120
+	ERR_API_CLOUD_CONNECTOR_FIND             = 1121
+	ERR_API_CLOUD_CONNECTOR_UPDATE           = 1122
+	ERR_API_CNS_POLICY_CREATE                = 1123
+	ERR_API_CNS_POLICY_FIND                  = 1124
+	ERR_API_CNS_POLICY_UPDATE                = 1125
+	ERR_API_K8S_ADMISSION_POLICY_CREATE      = 1126
+	ERR_API_K8S_ADMISSION_POLICY_FIND        = 1127
+	ERR_API_K8S_ADMISSION_POLICY_UPDATE      = 1128
+	ERR_API_SDL_INGESTION_CONFIG_CREATE      = 1129
+	ERR_API_SDL_INGESTION_CONFIG_FIND        = 1130
+	ERR_API_SDL_INGESTION_CONFIG_UPDATE      = 1131
+	ERR_API_XDR_CONNECTOR_CREATE             = 1132
+	ERR_API_XDR_CONNECTOR_FIND               = 1133
+	ERR_API_XDR_CONNECTOR_UPDATE             = 1134
+	ERR_API_MOBILE_POLICY_GET                = 1135
+	ERR_API_MOBILE_POLICY_UPDATE             = 1136
+	ERR_API_AGENT_FIND_PASSPHRASES           = 1137
+	ERR_API_AGENT_UNINSTALL_AGENTS           = 1138
+	ERR_API_AGENT_UPGRADE_AGENTS             = 1139
+	ERR_API_AGENT_FETCH_LOGS                 = 1140
+	ERR_API_ACTIVITY_FIND_ACTIVITIES         = 1141
+	ERR_API_ACTIVITY_DOWNLOAD_AGENT_UPLOAD   = 1142
+	ERR_API_THREAT_INTELLIGENCE_FEED_CREATE  = 1143
+	ERR_API_THREAT_INTELLIGENCE_FEED_FIND    = 1144
+	ERR_API_THREAT_INTELLIGENCE_FEED_UPDATE  = 1145
+	ERR_API_POLICY_UPDATE_POLICY             = 1146
+	ERR_API_IOC_CREATE_IOCS                  = 1147
+
+	ERR_DATASOURCE_GROUP_CONFIGURE                    = 2000
+	ERR_DATASOURCE_PACKAGE_CONFIGURE                  = 2001
+	ERR_DATASOURCE_SITE_CONFIGURE                     = 2002
+	ERR_DATASOURCE_GROUPS_CONFIGURE                   = 2003
+	ERR_DATASOURCE_PACKAGES_CONFIGURE                 = 2004
+	ERR_DATASOURCE_SITES_CONFIGURE                    = 2005
+	ERR_DATASOURCE_SITE_CHECK                         = 2006
+	ERR_DATASOURCE_UNMANAGED_OBJECTS_CONFIGURE        = 2007
+	ERR_DATASOURCE_UNMANAGED_OBJECTS_READ             = 2008
+	ERR_DATASOURCE_GROUPS_WITH_SITES_CONFIGURE        = 2009
+	ERR_DATASOURCE_THREATS_CONFIGURE                  = 2010
+	ERR_DATASOURCE_PREFLIGHT_CONFIGURE                = 2011
+	ERR_DATASOURCE_PREFLIGHT_READ                     = 2012
+	ERR_DATASOURCE_PROVIDER_INFO_CONFIGURE            = 2013
+	ERR_DATASOURCE_PROVIDER_INFO_READ                 = 2014
+	ERR_DATASOURCE_AGENTS_EXPORT_CONFIGURE            = 2015
+	ERR_DATASOURCE_THREAT_MITIGATION_STATUS_CONFIGURE = 2016
+	ERR_DATASOURCE_EXCLUSIONS_CONFIGURE               = 2017
+	ERR_DATASOURCE_FILTER_JSON                        = 2018
+	ERR_DATASOURCE_AGENTS_CONFIGURE                   = 2019
+	ERR_DATASOURCE_AGENT_CONFIGURE                    = 2020
+	ERR_DATASOURCE_AGENT_READ                         = 2021
+	ERR_DATASOURCE_ACCOUNTS_CONFIGURE                 = 2022
+	ERR_DATASOURCE_ACCOUNT_CONFIGURE                  = 2023
+	ERR_DATASOURCE_ACCOUNT_READ                       = 2024
+
+	ERR_RESOURCE_PACKAGE_DOWNLOAD_CONFIGURE                 = 3000
+	ERR_RESOURCE_PACKAGE_DOWNLOAD_CREATE                    = 3001
+	ERR_RESOURCE_PACKAGE_DOWNLOAD_READ                      = 3002
+	ERR_RESOURCE_PACKAGE_DOWNLOAD_UPDATE                    = 3003
+	ERR_RESOURCE_PACKAGE_DOWNLOAD_DELETE                    = 3004
+	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_CONFIGURE         = 3005
+	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_CREATE            = 3006
+	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_READ              = 3007
+	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_UPDATE            = 3008
+	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_DELETE            = 3009
+	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_DOCKER_INIT       = 3010
+	ERR_RESOURCE_K8S_AGENT_PACKAGE_LOADER_DOCKER_LOAD       = 3011
+	ERR_RESOURCE_GROUP_CONFIGURE                            = 3012
+	ERR_RESOURCE_SITE_CONFIGURE                             = 3013
+	ERR_RESOURCE_AGENT_ANNOTATION_CONFIGURE                 = 3014
+	ERR_RESOURCE_AGENT_ANNOTATION_CREATE                    = 3015
+	ERR_RESOURCE_ACCOUNT_CONFIGURE                          = 3016
+	ERR_RESOURCE_EXCLUSION_CONFIGURE                        = 3017
+	ERR_RESOURCE_BLOCKLIST_HASH_CONFIGURE                   = 3018
+	ERR_RESOURCE_POLICY_CONFIGURE                           = 3019
+	ERR_RESOURCE_SITE_SET_CONFIGURE                         = 3020
+	ERR_RESOURCE_FIREWALL_RULE_ORDER_CONFIGURE              = 3021
+	ERR_RESOURCE_FIREWALL_RULE_ORDER_APPLY                  = 3022
+	ERR_RESOURCE_DEVICE_CONTROL_RULE_CONFIGURE              = 3023
+	ERR_RESOURCE_DEVICE_CONTROL_RULE_IMPORT                 = 3024
+	ERR_RESOURCE_NETWORK_QUARANTINE_CONFIGURE               = 3025
+	ERR_RESOURCE_NETWORK_QUARANTINE_CREATE                  = 3026
+	ERR_RESOURCE_EVIDENCE_BUNDLE_CONFIGURE                  = 3027
+	ERR_RESOURCE_EVIDENCE_BUNDLE_CREATE                     = 3028
+	ERR_RESOURCE_EVIDENCE_BUNDLE_READ                       = 3029
+	ERR_RESOURCE_SERVICE_USER_CONFIGURE                     = 3030
+	ERR_RESOURCE_ROLE_CONFIGURE                             = 3031
+	ERR_RESOURCE_ROLE_CREATE                                = 3032
+	ERR_RESOURCE_ROLE_IMPORT                                = 3033
+	ERR_RESOURCE_EXCLUSION_IMPORT                           = 3034
+	ERR_RESOURCE_API_TOKEN_CONFIGURE                        = 3035
+	ERR_RESOURCE_API_TOKEN_MODIFY_PLAN                      = 3036
+	ERR_RESOURCE_EVENT_FORWARDING_CONFIGURE                 = 3037
+	ERR_RESOURCE_FILTER_CONFIGURE                           = 3038
+	ERR_RESOURCE_FILTER_IMPORT                              = 3039
+	ERR_RESOURCE_TAG_CONFIGURE                              = 3040
+	ERR_RESOURCE_TAG_IMPORT                                 = 3041
+	ERR_RESOURCE_AGENT_TAG_ASSIGNMENT_CONFIGURE             = 3042
+	ERR_RESOURCE_AGENT_TAG_ASSIGNMENT_CREATE                = 3043
+	ERR_RESOURCE_NOTIFICATION_RULE_CONFIGURE                = 3044
+	ERR_RESOURCE_NOTIFICATION_RULE_CREATE                   = 3045
+	ERR_RESOURCE_NOTIFICATION_RULE_IMPORT                   = 3046
+	ERR_RESOURCE_SYSLOG_CONNECTOR_CONFIGURE                 = 3047
+	ERR_RESOURCE_SYSLOG_CONNECTOR_IMPORT                    = 3048
+	ERR_RESOURCE_WEBHOOK_CONFIGURE                          = 3049
+	ERR_RESOURCE_WEBHOOK_IMPORT                             = 3050
+	ERR_RESOURCE_SSO_SAML_CONFIGURE                         = 3051
+	ERR_RESOURCE_SSO_SAML_IMPORT                            = 3052
+	ERR_RESOURCE_SSO_SAML_METADATA                          = 3053
+	ERR_RESOURCE_AGENT_SCAN_CONFIGURE                       = 3054
+	ERR_RESOURCE_AGENT_SCAN_CREATE                          = 3055
+	ERR_RESOURCE_RSO_SCRIPT_CONFIGURE                       = 3056
+	ERR_RESOURCE_RSO_SCRIPT_READ_FILE                       = 3057
+	ERR_RESOURCE_RSO_SCRIPT_IMPORT                          = 3058
+	ERR_RESOURCE_RSO_EXECUTION_CONFIGURE                    = 3059
+	ERR_RESOURCE_RSO_EXECUTION_CREATE                       = 3060
+	ERR_RESOURCE_THREAT_NOTE_CONFIGURE                      = 3061
+	ERR_RESOURCE_THREAT_NOTE_IMPORT                         = 3062
+	ERR_RESOURCE_THREAT_MITIGATION_CONFIGURE                = 3063
+	ERR_RESOURCE_CONFIG_OVERRIDE_CONFIGURE                  = 3064
+	ERR_RESOURCE_CONFIG_OVERRIDE_IMPORT                     = 3065
+	ERR_RESOURCE_NETWORK_LOCATION_CONFIGURE                 = 3066
+	ERR_RESOURCE_NETWORK_LOCATION_IMPORT                    = 3067
+	ERR_RESOURCE_AGENT_UPGRADE_POLICY_CONFIGURE             = 3068
+	ERR_RESOURCE_AGENT_UPGRADE_POLICY_IMPORT                = 3069
+	ERR_RESOURCE_RANGER_SETTINGS_CONFIGURE                  = 3070
+	ERR_RESOURCE_RANGER_DEPLOY_JOB_CONFIGURE                = 3071
+	ERR_RESOURCE_RANGER_DEPLOY_JOB_CREATE                   = 3072
+	ERR_RESOURCE_SITE_REGISTRATION_TOKEN_ROTATION_CONFIGURE = 3073
+	ERR_RESOURCE_REPORT_SCHEDULE_CONFIGURE                  = 3074
+	ERR_RESOURCE_REPORT_SCHEDULE_IMPORT                     = 3075
+	ERR_RESOURCE_IOC_CONFIGURE                              = 3076
+	ERR_RESOURCE_IOC_IMPORT                                 = 3077
+	ERR_RESOURCE_SAVED_POWER_QUERY_CONFIGURE                = 3078
+	ERR_RESOURCE_SAVED_POWER_QUERY_IMPORT                   = 3079
+	ERR_RESOURCE_ALERT_RULE_CONFIGURE                       = 3080
+	ERR_RESOURCE_ALERT_RULE_IMPORT                          = 3081
+	ERR_RESOURCE_POLICY_INHERITANCE_CONFIGURE               = 3082
+	ERR_RESOURCE_POLICY_INHERITANCE_IMPORT                  = 3083
+	ERR_RESOURCE_AGENT_MOVE_CONFIGURE                       = 3084
+	ERR_RESOURCE_AGENT_MOVE_CREATE                          = 3085
+	ERR_RESOURCE_PROTECTED_ACTIONS_CONFIGURE                = 3086
+	ERR_RESOURCE_PROTECTED_ACTIONS_IMPORT                   = 3087
+	ERR_RESOURCE_CONSOLE_2FA_SETTINGS_CONFIGURE             = 3088
+	ERR_RESOURCE_EMAIL_NOTIFICATION_RECIPIENT_CONFIGURE     = 3089
+	ERR_RESOURCE_EMAIL_NOTIFICATION_RECIPIENT_IMPORT        = 3090
+	ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_CONFIGURE           = 3091
+	ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_VALUES              = 3092
+	ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_HELM                = 3093
+	ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_PARSE               = 3094
+	ERR_RESOURCE_K8S_AGENT_MANIFEST_CONFIGURE               = 3095
+	ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_CONFIGURE          = 3096
+	ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_PACKAGE            = 3097
+	ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_PUSH               = 3098
+	ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_READ               = 3099
+	ERR_RESOURCE_MARKETPLACE_APP_CONFIGURE                  = 3100
+	ERR_RESOURCE_MARKETPLACE_APP_IMPORT                     = 3101
+	ERR_RESOURCE_AWS_CLOUD_CONNECTOR_CONFIGURE              = 3102
+	ERR_RESOURCE_AWS_CLOUD_CONNECTOR_IMPORT                 = 3103
+	ERR_RESOURCE_CLOUD_CONNECTOR_STATUS                     = 3104
+	ERR_RESOURCE_AZURE_CLOUD_CONNECTOR_CONFIGURE            = 3105
+	ERR_RESOURCE_AZURE_CLOUD_CONNECTOR_IMPORT               = 3106
+	ERR_RESOURCE_GCP_CLOUD_CONNECTOR_CONFIGURE              = 3107
+	ERR_RESOURCE_GCP_CLOUD_CONNECTOR_IMPORT                 = 3108
+	ERR_RESOURCE_GCP_CLOUD_CONNECTOR_CREDENTIALS            = 3109
+	ERR_RESOURCE_CNS_POLICY_CONFIGURE                       = 3110
+	ERR_RESOURCE_CNS_POLICY_IMPORT                          = 3111
+	ERR_RESOURCE_K8S_ADMISSION_POLICY_CONFIGURE             = 3112
+	ERR_RESOURCE_K8S_ADMISSION_POLICY_IMPORT                = 3113
+	ERR_RESOURCE_SDL_INGESTION_CONFIG_CONFIGURE             = 3114
+	ERR_RESOURCE_SDL_INGESTION_CONFIG_IMPORT                = 3115
+	ERR_RESOURCE_XDR_CONNECTOR_CONFIGURE                    = 3116
+	ERR_RESOURCE_XDR_CONNECTOR_IMPORT                       = 3117
+	ERR_RESOURCE_MOBILE_POLICY_CONFIGURE                    = 3118
+	ERR_RESOURCE_MOBILE_POLICY_IMPORT                       = 3119
+	ERR_RESOURCE_AGENT_UNINSTALL_CONFIGURE                  = 3120
+	ERR_RESOURCE_AGENT_UNINSTALL_CREATE                     = 3121
+	ERR_RESOURCE_AGENTS_UPGRADE_CONFIGURE                   = 3122
+	ERR_RESOURCE_AGENTS_UPGRADE_CREATE                      = 3123
+	ERR_RESOURCE_HASH_ALLOWLIST_CONFIGURE                   = 3124
+	ERR_RESOURCE_HASH_ALLOWLIST_IMPORT                      = 3125
+	ERR_RESOURCE_EXCLUSIONS_BULK_CONFIGURE                  = 3126
+	ERR_RESOURCE_EXCLUSIONS_BULK_PARSE_FILE                 = 3127
+	ERR_RESOURCE_AGENT_FETCH_LOGS_CONFIGURE                 = 3128
+	ERR_RESOURCE_AGENT_FETCH_LOGS_CREATE                    = 3129
+	ERR_RESOURCE_THREAT_INTELLIGENCE_FEED_CONFIGURE         = 3130
+	ERR_RESOURCE_THREAT_INTELLIGENCE_FEED_IMPORT            = 3131
+	ERR_RESOURCE_ACCOUNT_POLICY_CONFIGURE                   = 3132
+	ERR_RESOURCE_IOC_BULK_CONFIGURE                         = 3133
+	ERR_RESOURCE_IOC_BULK_PARSE_FILE                        = 3134
+	ERR_RESOURCE_EXCLUSIONS_BULK_CONVERGE                   = 3135
 )
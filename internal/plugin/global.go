@@ -6,6 +6,9 @@ const (
 
 	// PROVIDER_ADDRESS refers to the URL used for the provider in the Terraform registry.
 	PROVIDER_ADDRESS = "registry.terraform.io/joshhogle-at-s1/sentinelone-singularity"
+
+	// PROTOCOL_VERSION is the version of the Terraform plugin protocol served by the provider.
+	PROTOCOL_VERSION = 6
 )
 
 var (
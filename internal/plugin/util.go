@@ -3,18 +3,108 @@ package plugin
 import (
 	"context"
 	"crypto/sha1"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// AddDefenderExclusion registers the given folder as a Microsoft Defender exclusion path so that files saved within
+// it are not quarantined while they are being used.
+//
+// This function does nothing on systems other than Windows. Adding an exclusion requires administrative privileges.
+func AddDefenderExclusion(ctx context.Context, folder string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if runtime.GOOS != "windows" {
+		tflog.Debug(ctx, "Skipping Defender exclusion on a non-Windows system", map[string]interface{}{
+			"folder": folder,
+		})
+		return diags
+	}
+
+	// convert the path to an absolute path
+	absPath, diags := ToAbsolutePath(ctx, folder)
+	if diags.HasError() {
+		return diags
+	}
+	ctx = tflog.SetField(ctx, "folder", absPath)
+
+	// single quotes are escaped by doubling them within a PowerShell string literal
+	cmd := exec.CommandContext(ctx, "powershell.exe", "-NoProfile", "-NonInteractive", "-Command",
+		fmt.Sprintf("Add-MpPreference -ExclusionPath '%s'", strings.ReplaceAll(absPath, "'", "''")))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while adding the folder to the Microsoft Defender "+
+			"exclusions. Adding an exclusion requires administrative privileges.\n\nError: %s\nOutput: %s\n"+
+			"Folder: %s", err.Error(), strings.TrimSpace(string(output)), absPath)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"output":              string(output),
+			"internal_error_code": ERR_UTIL_ADD_DEFENDER_EXCLUSION,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return diags
+	}
+	tflog.Debug(ctx, "Added folder to Microsoft Defender exclusions")
+	return diags
+}
+
+// AggregateDiagnostics groups repeated identical diagnostics produced by a bulk operation and, if any of the items
+// failed, appends a final error summarizing how many of them failed.
+//
+// Diagnostics which are repeated have the number of times they occurred appended to their detail so that the output
+// remains readable when many items fail for the same reason.
+func AggregateDiagnostics(diags diag.Diagnostics, failed, total int, items string) diag.Diagnostics {
+	var aggregated diag.Diagnostics
+
+	// count each distinct diagnostic while preserving the order in which they first occurred
+	type key struct {
+		severity diag.Severity
+		summary  string
+		detail   string
+	}
+	counts := map[key]int{}
+	order := []diag.Diagnostic{}
+	for _, d := range diags {
+		k := key{severity: d.Severity(), summary: d.Summary(), detail: d.Detail()}
+		if counts[k] == 0 {
+			order = append(order, d)
+		}
+		counts[k]++
+	}
+	for _, d := range order {
+		count := counts[key{severity: d.Severity(), summary: d.Summary(), detail: d.Detail()}]
+		if count == 1 {
+			aggregated = append(aggregated, d)
+			continue
+		}
+		detail := fmt.Sprintf("%s\n\n(This occurred %d times.)", d.Detail(), count)
+		if d.Severity() == diag.SeverityError {
+			aggregated.AddError(d.Summary(), detail)
+		} else {
+			aggregated.AddWarning(d.Summary(), detail)
+		}
+	}
+
+	// summarize the failures
+	if failed > 0 && total > 1 {
+		aggregated.AddError("Bulk Operation Failed", fmt.Sprintf("%d of %d %s failed; see above for details.",
+			failed, total, items))
+	}
+	return aggregated
+}
+
 // CreateDirectory creates the given path along with any parent directories setting the permissions using the
 // given permissions mode.
 func CreateDirectory(ctx context.Context, path, mode string) diag.Diagnostics {
@@ -158,6 +248,48 @@ func GetFileSHA1(ctx context.Context, file string) (string, diag.Diagnostics) {
 	return fmt.Sprintf("%x", h.Sum(nil)), diags
 }
 
+// GetFileSHA256 calculates the SHA256 hash of a file.
+//
+// If an error occurs, the function returns an empty string with an error in the diag.Diagnostics object.
+func GetFileSHA256(ctx context.Context, file string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// convert the path to an absolute path
+	absPath, diags := ToAbsolutePath(ctx, file)
+	if diags.HasError() {
+		return "", diags
+	}
+	ctx = tflog.SetField(ctx, "file", absPath)
+
+	// open the file for reading
+	f, err := os.Open(absPath)
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while attempting to open the given file for computing "+
+			"the SHA256 checksum.\n\nError: %s\nFile: %s", err.Error(), absPath)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": ERR_UTIL_GET_FILE_SHA256,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return "", diags
+	}
+	defer f.Close()
+
+	// calculate the SHA256
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		msg := fmt.Sprintf("Failed to read file for computing SHA256.\n\n"+
+			"Error: %s\nFile: %s", err.Error(), absPath)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": ERR_UTIL_GET_FILE_SHA256,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return "", diags
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), diags
+}
+
 // GetWorkDir returns the path to the current working directory.
 //
 // This function will return "." in the case where os.Getwd() fails.
@@ -169,6 +301,34 @@ func GetWorkDir() string {
 	return cwd
 }
 
+// MaskSecrets returns a new logging context in which any occurrence of the given secrets is masked in both log
+// messages and field values.
+//
+// Empty secrets are ignored.
+func MaskSecrets(ctx context.Context, secrets ...string) context.Context {
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		ctx = tflog.MaskMessageStrings(ctx, secret)
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, secret)
+	}
+	return ctx
+}
+
+// MaskToken returns a partially masked representation of the given token which is safe to display in outputs and
+// reports.
+//
+// Only the first and last 4 characters of the token are kept. Tokens which are too short to safely reveal any
+// characters are completely masked.
+func MaskToken(token string) string {
+	const visible = 4
+	if len(token) <= visible*3 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:visible] + strings.Repeat("*", len(token)-visible*2) + token[len(token)-visible:]
+}
+
 // ParseFilesystemMode converts a filesystem mode string into the corresponding octal mode.
 func ParseFilesystemMode(ctx context.Context, mode string) (fs.FileMode, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -188,6 +348,71 @@ func ParseFilesystemMode(ctx context.Context, mode string) (fs.FileMode, diag.Di
 	return fs.FileMode(fsmode), diags
 }
 
+// ParseRelativeDuration converts a relative duration string (eg: 30d, 2w, 1d12h) into a time.Duration.
+//
+// In addition to the units supported by time.ParseDuration, the "d" (day) and "w" (week) units are supported.
+func ParseRelativeDuration(ctx context.Context, duration string) (time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// convert days and weeks into hours since they are not supported by time.ParseDuration
+	var convErr error
+	converted := regexp.MustCompile(`([0-9]*\.?[0-9]+)([dw])`).ReplaceAllStringFunc(duration, func(m string) string {
+		value, err := strconv.ParseFloat(m[:len(m)-1], 64)
+		if err != nil {
+			convErr = err
+			return m
+		}
+		if strings.HasSuffix(m, "w") {
+			return fmt.Sprintf("%gh", value*24*7)
+		}
+		return fmt.Sprintf("%gh", value*24)
+	})
+	d, err := time.ParseDuration(converted)
+	if convErr != nil {
+		err = convErr
+	}
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the given duration string.\n\n"+
+			"Error: %s\nDuration: %s", err.Error(), duration)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"duration":            duration,
+			"internal_error_code": ERR_UTIL_PARSE_RELATIVE_DURATION,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return 0, diags
+	}
+	return d, diags
+}
+
+// ResolveTimestamp converts a relative timestamp (eg: -24h, -7d) into an RFC3339 timestamp based on the given
+// reference time. Any other value is returned unchanged.
+//
+// Terraform plans and applies changes in separate provider processes, so callers which need the same value in both
+// must resolve the timestamp once and keep the result in the state.
+func ResolveTimestamp(ctx context.Context, timestamp string, reference time.Time) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if !strings.HasPrefix(timestamp, "-") && !strings.HasPrefix(timestamp, "+") {
+		return timestamp, diags
+	}
+	d, diags := ParseRelativeDuration(ctx, timestamp[1:])
+	if diags.HasError() {
+		msg := fmt.Sprintf("An unexpected error occurred while resolving the given relative timestamp.\n\n"+
+			"Error: %s\nTimestamp: %s", diags[0].Detail(), timestamp)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               diags[0].Detail(),
+			"timestamp":           timestamp,
+			"internal_error_code": ERR_UTIL_RESOLVE_TIMESTAMP,
+		})
+		return "", diags
+	}
+	if strings.HasPrefix(timestamp, "-") {
+		d = -d
+	}
+	return reference.UTC().Truncate(time.Second).Add(d).Format(time.RFC3339), diags
+}
+
 // PathExists determines whether or not the given path exists. The path may be a folder or a file.
 //
 // If an error occurs, the function returns false with an error in the diag.Diagnostics object.
@@ -216,6 +441,20 @@ func PathExists(ctx context.Context, path string) (bool, diag.Diagnostics) {
 	return true, diags
 }
 
+// QuarantineHint returns a hint which can be appended to an error message when a file that was just written has
+// disappeared or could not be read.
+//
+// On Windows this is usually caused by a local anti-virus product quarantining the file. On other systems an empty
+// string is returned.
+func QuarantineHint(path string) string {
+	if runtime.GOOS != "windows" {
+		return ""
+	}
+	return fmt.Sprintf("\n\nThe file may have been quarantined by a local anti-virus product such as Microsoft "+
+		"Defender. Check the protection history for the file and consider excluding its folder from scanning (eg: "+
+		"Add-MpPreference -ExclusionPath '%s') or setting defender_exclusion to true.", filepath.Dir(path))
+}
+
 // ToAbsolutePath converts the given path to an absolute path if it's not already an absolute path.
 //
 // If an error occurs the original path is returned and the diag.Diagnostics object will contain
@@ -0,0 +1,222 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// TEMP_DIR_PREFIX is the prefix of the name of every TempDir.
+const TEMP_DIR_PREFIX = ".singularity-"
+
+// TempDir is a private temporary folder in which a single resource operation stages the files it produces.
+//
+// Terraform runs resources concurrently, so files are never written directly to their destination. Instead each
+// operation writes to its own uniquely named TempDir and only moves a file into place with Commit once it is
+// complete. This keeps concurrent resources writing to the same folder from colliding and makes sure a failed
+// operation never leaves a partial file at the destination or removes a file which was already there.
+//
+// Callers should always defer Cleanup as soon as the TempDir has been created so that it is removed whether the
+// operation succeeds or fails.
+type TempDir struct {
+	path string
+}
+
+// NewTempDir creates a new, uniquely named temporary folder for staging files within the given parent folder. The
+// purpose (eg: package-download) is included in the folder name to make it easier to identify.
+//
+// The parent should normally be the folder to which the files are committed so that they are moved within the same
+// filesystem and are covered by any anti-virus exclusion for it. It is created with the given folder mode if it does
+// not exist. If the parent is empty, the system's temporary folder is used instead.
+func NewTempDir(ctx context.Context, purpose, parent, parentMode string) (*TempDir, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if parent == "" {
+		parent = os.TempDir()
+	} else {
+		parent, diags = ToAbsolutePath(ctx, parent)
+		if diags.HasError() {
+			return nil, diags
+		}
+		diags = CreateDirectory(ctx, parent, parentMode)
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+	path, err := os.MkdirTemp(parent, TEMP_DIR_PREFIX+purpose+"-*")
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while creating the temporary folder.\n\nError: %s\n"+
+			"Parent Folder: %s", err.Error(), parent)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": ERR_UTIL_CREATE_TEMP_DIR,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return nil, diags
+	}
+	tflog.Debug(ctx, "created temporary folder", map[string]interface{}{
+		"path": path,
+	})
+	return &TempDir{path: path}, diags
+}
+
+// Path returns the path to the file with the given name within the folder.
+func (d *TempDir) Path(name string) string {
+	return filepath.Join(d.path, name)
+}
+
+// CreateFile creates a new file with the given name within the folder and opens it for writing.
+func (d *TempDir) CreateFile(ctx context.Context, name string) (*os.File, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	file, err := os.Create(d.Path(name))
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while attempting to open the temporary file for writing.\n\n"+
+			"Error: %s\nFile: %s", err.Error(), d.Path(name))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": ERR_UTIL_CREATE_TEMP_DIR,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return nil, diags
+	}
+	return file, diags
+}
+
+// Commit moves the file with the given name from the folder to the given destination path.
+//
+// Any parent folders are automatically created with the given folder mode and the file's mode is set to the given
+// file mode except on Windows, where it is ignored. If overwrite is false and the destination already exists, an
+// error occurs and the destination is left untouched.
+func (d *TempDir) Commit(ctx context.Context, name, path, folderMode, fileMode string,
+	overwrite bool) diag.Diagnostics {
+
+	// convert the path to an absolute path
+	absPath, diags := ToAbsolutePath(ctx, path)
+	if diags.HasError() {
+		return diags
+	}
+
+	// create the destination folder if it does not exist
+	diags = CreateDirectory(ctx, filepath.Dir(absPath), folderMode)
+	if diags.HasError() {
+		return diags
+	}
+
+	// set file permissions before moving the file so that it never appears with the wrong mode
+	if runtime.GOOS != "windows" {
+		fsmode, diags := ParseFilesystemMode(ctx, fileMode)
+		if diags.HasError() {
+			return diags
+		}
+		if err := os.Chmod(d.Path(name), fsmode); err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while setting permissions on the file.\n\n"+
+				"Error: %s\nMode: %s\nFile: %s", err.Error(), fileMode, d.Path(name))
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"file_mode":           fileMode,
+				"internal_error_code": ERR_UTIL_COMMIT_TEMP_FILE,
+			})
+			diags.AddError("Unexpected Internal Error", msg)
+			return diags
+		}
+	}
+	return MoveFile(ctx, d.Path(name), absPath, overwrite)
+}
+
+// Cleanup removes the folder along with any files which were not committed.
+//
+// Failures are only logged since they do not affect the result of the operation.
+func (d *TempDir) Cleanup(ctx context.Context) {
+	if err := os.RemoveAll(d.path); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("failed to remove temporary folder: %s", err.Error()), map[string]interface{}{
+			"path": d.path,
+		})
+	}
+}
+
+// MoveFile moves the file at the given source path to the given destination path.
+//
+// The file is renamed when possible and copied otherwise (eg: when the paths are on different filesystems). If
+// overwrite is false and the destination already exists, an error occurs and the destination is left untouched, even
+// if another process creates it at the same time.
+func MoveFile(ctx context.Context, src, dest string, overwrite bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ctx = tflog.SetField(ctx, "src_path", src)
+	ctx = tflog.SetField(ctx, "dest_path", dest)
+
+	// a hard link fails if the destination exists, which a rename does not
+	var err error
+	if overwrite {
+		err = os.Rename(src, dest)
+	} else if err = os.Link(src, dest); err == nil {
+		os.Remove(src)
+	}
+	if err != nil && !os.IsExist(err) {
+		tflog.Debug(ctx, fmt.Sprintf("failed to rename file, copying it instead: %s", err.Error()))
+		err = copyFile(src, dest, overwrite)
+		if err == nil {
+			os.Remove(src)
+		}
+	}
+	if err != nil {
+		if os.IsExist(err) {
+			msg := fmt.Sprintf("The destination file already exists and should not be overwritten.\n\nFile: %s", dest)
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"internal_error_code": ERR_UTIL_COMMIT_TEMP_FILE,
+			})
+			diags.AddError("File Exists", msg)
+			return diags
+		}
+		msg := fmt.Sprintf("An unexpected error occurred while moving the file.\n\nError: %s\nSource: %s\n"+
+			"Destination: %s", err.Error(), src, dest)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": ERR_UTIL_COMMIT_TEMP_FILE,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return diags
+	}
+	return diags
+}
+
+// copyFile copies the file at the given source path to the given destination path, keeping its mode.
+//
+// If overwrite is false, an error satisfying os.IsExist is returned if the destination already exists. A partially
+// copied destination is removed.
+func copyFile(src, dest string, overwrite bool) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	out, err := os.OpenFile(dest, flags, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dest)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dest)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &Account{}
+	_ datasource.DataSourceWithConfigure = &Account{}
+)
+
+// NewAccount creates a new Account object.
+func NewAccount() datasource.DataSource {
+	return &Account{}
+}
+
+// Account is a data source used to store details about a single account.
+type Account struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *Account) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *Account) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	accountSchema := getAccountSchema(ctx)
+
+	// override the default schema
+	accountSchema.MarkdownDescription = `This data source can be used for getting details about a single account.
+
+	The account can be looked up using either its ` + "`id`" + ` or its exact ` + "`name`" + `, but exactly one of them
+	must be given. The ` + "`licenses`" + ` attribute holds the bundles and modules the account is entitled to, which can
+	be used to conditionally enable resources that depend on a particular module.
+	`
+	accountSchema.Attributes["id"] = schema.StringAttribute{
+		Description:         "ID of the account. Conflicts with name.",
+		MarkdownDescription: "ID of the account. Conflicts with `name`.",
+		Optional:            true,
+		Computed:            true,
+	}
+	accountSchema.Attributes["name"] = schema.StringAttribute{
+		Description:         "Name of the account. Conflicts with id.",
+		MarkdownDescription: "Name of the account. Conflicts with `id`.",
+		Optional:            true,
+		Computed:            true,
+	}
+	resp.Schema = accountSchema
+}
+
+// Configure initializes the configuration for the data source.
+func (d *Account) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_ACCOUNT_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *Account) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfAccount
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// exactly one of the ID or name must be given
+	hasId := !data.Id.IsNull() && !data.Id.IsUnknown()
+	hasName := !data.Name.IsNull() && !data.Name.IsUnknown()
+	if hasId == hasName {
+		msg := "Exactly one of 'id' or 'name' must be given in order to look up an account."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_ACCOUNT_READ,
+		})
+		resp.Diagnostics.AddError("Invalid Configuration", msg)
+		return
+	}
+	queryParams := api.AccountQueryParams{}
+	var lookup string
+	if hasId {
+		lookup = fmt.Sprintf("ID: %s", data.Id.ValueString())
+		queryParams.AccountIds = []string{data.Id.ValueString()}
+	} else {
+		name := data.Name.ValueString()
+		lookup = fmt.Sprintf("Name: %s", name)
+		queryParams.Name = &name
+	}
+
+	// find the matching account
+	accounts, diags := api.Client().FindAccounts(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(accounts) != 1 {
+		msg := fmt.Sprintf("Expected exactly 1 account to match but %d accounts were found.\n\n%s", len(accounts), lookup)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_ACCOUNT_READ,
+			"accounts_found":      len(accounts),
+		})
+		if len(accounts) == 0 {
+			resp.Diagnostics.AddError("Account Not Found", msg)
+		} else {
+			resp.Diagnostics.AddError("Multiple Accounts Found", msg)
+		}
+		return
+	}
+
+	// convert the API object to the Terraform object
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfAccountFromAPI(ctx, &accounts[0]))...)
+}
@@ -0,0 +1,527 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &Accounts{}
+	_ datasource.DataSourceWithConfigure = &Accounts{}
+)
+
+// tfAccounts defines the Terraform model for accounts.
+type tfAccounts struct {
+	AccountIds []types.String    `tfsdk:"account_ids"`
+	Accounts   []tfAccount       `tfsdk:"accounts"`
+	Debug      types.Map         `tfsdk:"debug"`
+	Filter     *tfAccountsFilter `tfsdk:"filter"`
+}
+
+// tfAccount defines the Terraform model for an account.
+type tfAccount struct {
+	AccountType         types.String   `tfsdk:"account_type"`
+	ActiveAgents        types.Int64    `tfsdk:"active_agents"`
+	BillingMode         types.String   `tfsdk:"billing_mode"`
+	CreatedAt           types.String   `tfsdk:"created_at"`
+	Creator             types.String   `tfsdk:"creator"`
+	CreatorId           types.String   `tfsdk:"creator_id"`
+	Expiration          types.String   `tfsdk:"expiration"`
+	ExternalId          types.String   `tfsdk:"external_id"`
+	Id                  types.String   `tfsdk:"id"`
+	IsDefault           types.Bool     `tfsdk:"is_default"`
+	Licenses            *tfSiteLicense `tfsdk:"licenses"`
+	Name                types.String   `tfsdk:"name"`
+	NumberOfSites       types.Int64    `tfsdk:"number_of_sites"`
+	RegistrationToken   types.String   `tfsdk:"registration_token"`
+	SalesforceId        types.String   `tfsdk:"salesforce_id"`
+	State               types.String   `tfsdk:"state"`
+	TotalLicenses       types.Int64    `tfsdk:"total_licenses"`
+	UnlimitedExpiration types.Bool     `tfsdk:"unlimited_expiration"`
+	UnlimitedLicenses   types.Bool     `tfsdk:"unlimited_licenses"`
+	UpdatedAt           types.String   `tfsdk:"updated_at"`
+	UsageType           types.String   `tfsdk:"usage_type"`
+}
+
+// tfAccountsFilter defines the Terraform model for account filtering.
+type tfAccountsFilter struct {
+	AccountIds    []types.String `tfsdk:"account_ids"`
+	AccountType   types.String   `tfsdk:"account_type"`
+	ExpiresAfter  types.String   `tfsdk:"expires_after"`
+	ExpiresBefore types.String   `tfsdk:"expires_before"`
+	ExternalId    types.String   `tfsdk:"external_id"`
+	Features      []types.String `tfsdk:"features"`
+	IsDefault     types.Bool     `tfsdk:"is_default"`
+	Name          types.String   `tfsdk:"name"`
+	NameContains  []types.String `tfsdk:"name_contains"`
+	Query         types.String   `tfsdk:"query"`
+	SortBy        types.String   `tfsdk:"sort_by"`
+	SortOrder     types.String   `tfsdk:"sort_order"`
+	States        []types.String `tfsdk:"states"`
+}
+
+// NewAccounts creates a new Accounts object.
+func NewAccounts() datasource.DataSource {
+	return &Accounts{}
+}
+
+// Accounts is a data source used to store details about accounts.
+type Accounts struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *Accounts) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_accounts"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *Accounts) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source can be used for getting a list of accounts based on filters.",
+		MarkdownDescription: `This data source can be used for getting a list of accounts based on filters.
+
+		This is typically used by MSSP configurations to discover the tenant accounts that are visible to the API
+		token. The ` + "`account_ids`" + ` attribute can be converted to a set with ` + "`toset()`" + ` and used with
+		` + "`for_each`" + ` to manage resources in every matching account.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"debug": getDebugSchema(),
+			"account_ids": schema.ListAttribute{
+				Description:         "List of the IDs of the matching accounts that were found.",
+				MarkdownDescription: "List of the IDs of the matching accounts that were found.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"accounts": schema.ListNestedAttribute{
+				Description:         "List of matching accounts that were found.",
+				MarkdownDescription: "List of matching accounts that were found.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: getAccountSchema(ctx).Attributes,
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Description:         "Defines the query filters to use when searching for accounts.",
+				MarkdownDescription: "Defines the query filters to use when searching for accounts.",
+				Attributes: map[string]schema.Attribute{
+					"account_ids": schema.ListAttribute{
+						Description:         "List of account IDs to filter by.",
+						MarkdownDescription: "List of account IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"account_type": schema.StringAttribute{
+						Description:         "Type of account (valid values: Paid, Trial).",
+						MarkdownDescription: "Type of account (valid values: `Paid`, `Trial`).",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(true, "Paid", "Trial"),
+						},
+					},
+					"expires_after": schema.StringAttribute{
+						Description: "Account expires after the given timestamp (eg: 2023-01-01T00:00:00Z) or relative " +
+							"timestamp (eg: +30d).",
+						MarkdownDescription: "Account expires after the given timestamp (eg: `2023-01-01T00:00:00Z`) or " +
+							"relative timestamp (eg: `+30d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+							validators.TimestampIsBefore(false, "expires_before"),
+						},
+					},
+					"expires_before": schema.StringAttribute{
+						Description: "Account expires before the given timestamp (eg: 2023-01-01T00:00:00Z) or relative " +
+							"timestamp (eg: +30d).",
+						MarkdownDescription: "Account expires before the given timestamp (eg: `2023-01-01T00:00:00Z`) or " +
+							"relative timestamp (eg: `+30d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+						},
+					},
+					"external_id": schema.StringAttribute{
+						Description:         "ID of the account in an external system.",
+						MarkdownDescription: "ID of the account in an external system.",
+						Optional:            true,
+					},
+					"features": schema.ListAttribute{
+						Description:         "List of features which must be enabled for the account (eg: firewall-control).",
+						MarkdownDescription: "List of features which must be enabled for the account (eg: `firewall-control`).",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"is_default": schema.BoolAttribute{
+						Description:         "Whether or not the account is the default account.",
+						MarkdownDescription: "Whether or not the account is the default account.",
+						Optional:            true,
+					},
+					"name": schema.StringAttribute{
+						Description:         "Exact name of the account.",
+						MarkdownDescription: "Exact name of the account.",
+						Optional:            true,
+					},
+					"name_contains": schema.ListAttribute{
+						Description:         "List of partial account names to filter by.",
+						MarkdownDescription: "List of partial account names to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"query": schema.StringAttribute{
+						Description:         "A free-text search term, will match applicable attributes.",
+						MarkdownDescription: "A free-text search term, will match applicable attributes.",
+						Optional:            true,
+					},
+					"sort_by": schema.StringAttribute{
+						Description: "Field on which to sort results (valid values: accountType, activeAgents, " +
+							"createdAt, expiration, id, name, state, updatedAt) [Default: id].",
+						MarkdownDescription: "Field on which to sort results (valid values: `accountType`, " +
+							"`activeAgents`, `createdAt`, `expiration`, `id`, `name`, `state`, `updatedAt`) " +
+							"[Default: `id`].",
+						Optional: true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(false,
+								"accountType", "activeAgents", "createdAt", "expiration", "id", "name", "state",
+								"updatedAt",
+							),
+						},
+					},
+					"sort_order": schema.StringAttribute{
+						Description:         "Order in which to sort results (valid values: asc, desc) [Default: asc].",
+						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`) [Default: `asc`].",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(false,
+								"asc", "desc",
+							),
+						},
+					},
+					"states": schema.ListAttribute{
+						Description:         "State of the account (valid values: active, deleted, expired).",
+						MarkdownDescription: "State of the account (valid values: `active`, `deleted`, `expired`).",
+						Optional:            true,
+						ElementType:         types.StringType,
+						Validators: []validator.List{
+							validators.EnumStringListValuesAre(false,
+								"active", "deleted", "expired",
+							),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *Accounts) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_ACCOUNTS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *Accounts) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfAccounts
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// capture any debug response headers returned by the queries below
+	ctx, recorder := api.RecordResponseHeaders(ctx)
+
+	// construct query parameters
+	queryParams := api.AccountQueryParams{}
+	if data.Filter != nil {
+		var diags diag.Diagnostics
+		queryParams, diags = d.queryParamsFromFilter(ctx, *data.Filter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// always sort results so their order is deterministic even if the API server changes its default ordering
+	queryParams.SortBy, queryParams.SortOrder = sortOrDefault(queryParams.SortBy, queryParams.SortOrder)
+
+	// find the matching accounts
+	accounts, diags := api.Client().FindAccounts(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert API objects into Terraform objects
+	tfaccounts := tfAccounts{
+		AccountIds: []types.String{},
+		Accounts:   []tfAccount{},
+		Filter:     data.Filter,
+	}
+	for _, account := range accounts {
+		tfaccounts.AccountIds = append(tfaccounts.AccountIds, types.StringValue(account.Id))
+		tfaccounts.Accounts = append(tfaccounts.Accounts, tfAccountFromAPI(ctx, &account))
+	}
+	debug, diags := tfDebugFromRecorder(ctx, recorder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tfaccounts.Debug = debug
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfaccounts)...)
+}
+
+// queryParamsFromFilter converts the TF filter block into API query parameters.
+func (d *Accounts) queryParamsFromFilter(ctx context.Context, filter tfAccountsFilter) (api.AccountQueryParams,
+	diag.Diagnostics) {
+	var diags diag.Diagnostics
+	queryParams := api.AccountQueryParams{}
+
+	// relative timestamps are resolved against the time at which the data source is read
+	now := time.Now()
+
+	if len(filter.AccountIds) > 0 {
+		queryParams.AccountIds = stringsFromList(filter.AccountIds)
+	}
+
+	if !filter.AccountType.IsNull() && !filter.AccountType.IsUnknown() {
+		value := filter.AccountType.ValueString()
+		queryParams.AccountType = &value
+	}
+
+	if !filter.ExpiresAfter.IsNull() && !filter.ExpiresAfter.IsUnknown() {
+		value, diags := plugin.ResolveTimestamp(ctx, filter.ExpiresAfter.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
+		queryParams.ExpiresAfter = &value
+	}
+
+	if !filter.ExpiresBefore.IsNull() && !filter.ExpiresBefore.IsUnknown() {
+		value, diags := plugin.ResolveTimestamp(ctx, filter.ExpiresBefore.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
+		queryParams.ExpiresBefore = &value
+	}
+
+	if !filter.ExternalId.IsNull() && !filter.ExternalId.IsUnknown() {
+		value := filter.ExternalId.ValueString()
+		queryParams.ExternalId = &value
+	}
+
+	if len(filter.Features) > 0 {
+		queryParams.Features = stringsFromList(filter.Features)
+	}
+
+	if !filter.IsDefault.IsNull() && !filter.IsDefault.IsUnknown() {
+		value := filter.IsDefault.ValueBool()
+		queryParams.IsDefault = &value
+	}
+
+	if !filter.Name.IsNull() && !filter.Name.IsUnknown() {
+		value := filter.Name.ValueString()
+		queryParams.Name = &value
+	}
+
+	if len(filter.NameContains) > 0 {
+		queryParams.NameContains = stringsFromList(filter.NameContains)
+	}
+
+	if !filter.Query.IsNull() && !filter.Query.IsUnknown() {
+		value := filter.Query.ValueString()
+		queryParams.Query = &value
+	}
+
+	if !filter.SortBy.IsNull() && !filter.SortBy.IsUnknown() {
+		value := filter.SortBy.ValueString()
+		queryParams.SortBy = &value
+	}
+
+	if !filter.SortOrder.IsNull() && !filter.SortOrder.IsUnknown() {
+		value := filter.SortOrder.ValueString()
+		queryParams.SortOrder = &value
+	}
+
+	if len(filter.States) > 0 {
+		queryParams.States = stringsFromList(filter.States)
+	}
+	return queryParams, diags
+}
+
+// getAccountSchema returns a default Terraform schema where all values are computed.
+func getAccountSchema(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Description:         "This data source is used for getting details on a specific account.",
+		MarkdownDescription: "This data source is used for getting details on a specific account.",
+		Attributes: map[string]schema.Attribute{
+			"account_type": schema.StringAttribute{
+				Description:         "Type of account (eg: Paid, Trial).",
+				MarkdownDescription: "Type of account (eg: `Paid`, `Trial`).",
+				Computed:            true,
+			},
+			"active_agents": schema.Int64Attribute{
+				Description:         "Number of active agents in the account.",
+				MarkdownDescription: "Number of active agents in the account.",
+				Computed:            true,
+			},
+			"billing_mode": schema.StringAttribute{
+				Description:         "Billing mode of the account.",
+				MarkdownDescription: "Billing mode of the account.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the account was created.",
+				MarkdownDescription: "Timestamp of when the account was created.",
+				Computed:            true,
+			},
+			"creator": schema.StringAttribute{
+				Description:         "Full name of the user who created the account.",
+				MarkdownDescription: "Full name of the user who created the account.",
+				Computed:            true,
+			},
+			"creator_id": schema.StringAttribute{
+				Description:         "ID of the user who created the account.",
+				MarkdownDescription: "ID of the user who created the account.",
+				Computed:            true,
+			},
+			"expiration": schema.StringAttribute{
+				Description:         "Timestamp of when the account expires.",
+				MarkdownDescription: "Timestamp of when the account expires.",
+				Computed:            true,
+			},
+			"external_id": schema.StringAttribute{
+				Description:         "ID of the account in an external system.",
+				MarkdownDescription: "ID of the account in an external system.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the account.",
+				MarkdownDescription: "ID of the account.",
+				Computed:            true,
+			},
+			"is_default": schema.BoolAttribute{
+				Description:         "Whether or not this is the default account.",
+				MarkdownDescription: "Whether or not this is the default account.",
+				Computed:            true,
+			},
+			"licenses": getSiteLicenseSchema("account"),
+			"name": schema.StringAttribute{
+				Description:         "Name of the account.",
+				MarkdownDescription: "Name of the account.",
+				Computed:            true,
+			},
+			"number_of_sites": schema.Int64Attribute{
+				Description:         "Number of sites in the account.",
+				MarkdownDescription: "Number of sites in the account.",
+				Computed:            true,
+			},
+			"registration_token": schema.StringAttribute{
+				Description:         "Registration token for the account.",
+				MarkdownDescription: "Registration token for the account.",
+				Computed:            true,
+			},
+			"salesforce_id": schema.StringAttribute{
+				Description:         "ID of the account in Salesforce.",
+				MarkdownDescription: "ID of the account in Salesforce.",
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				Description:         "State of the account (eg: active, deleted, expired).",
+				MarkdownDescription: "State of the account (eg: `active`, `deleted`, `expired`).",
+				Computed:            true,
+			},
+			"total_licenses": schema.Int64Attribute{
+				Description:         "Total number of agent licenses available to the account.",
+				MarkdownDescription: "Total number of agent licenses available to the account.",
+				Computed:            true,
+			},
+			"unlimited_expiration": schema.BoolAttribute{
+				Description:         "Whether or not the account never expires.",
+				MarkdownDescription: "Whether or not the account never expires.",
+				Computed:            true,
+			},
+			"unlimited_licenses": schema.BoolAttribute{
+				Description:         "Whether or not the account has an unlimited number of licenses.",
+				MarkdownDescription: "Whether or not the account has an unlimited number of licenses.",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the account was last updated.",
+				MarkdownDescription: "Timestamp of when the account was last updated.",
+				Computed:            true,
+			},
+			"usage_type": schema.StringAttribute{
+				Description:         "Usage type of the account.",
+				MarkdownDescription: "Usage type of the account.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// tfAccountFromAPI converts an API account into a Terraform account.
+func tfAccountFromAPI(ctx context.Context, account *api.Account) tfAccount {
+	tfaccount := tfAccount{
+		AccountType:         types.StringValue(account.AccountType),
+		ActiveAgents:        types.Int64Value(int64(account.ActiveAgents)),
+		BillingMode:         types.StringValue(account.BillingMode),
+		CreatedAt:           types.StringValue(account.CreatedAt),
+		Creator:             types.StringValue(account.Creator),
+		CreatorId:           types.StringValue(account.CreatorId),
+		Expiration:          types.StringValue(account.Expiration),
+		ExternalId:          types.StringValue(account.ExternalId),
+		Id:                  types.StringValue(account.Id),
+		IsDefault:           types.BoolValue(account.IsDefault),
+		Licenses:            tfSiteLicenseFromAPI(&account.Licenses),
+		Name:                types.StringValue(account.Name),
+		NumberOfSites:       types.Int64Value(int64(account.NumberOfSites)),
+		RegistrationToken:   types.StringValue(account.RegistrationToken),
+		SalesforceId:        types.StringValue(account.SalesforceId),
+		State:               types.StringValue(account.State),
+		TotalLicenses:       types.Int64Value(int64(account.TotalLicenses)),
+		UnlimitedExpiration: types.BoolValue(account.UnlimitedExpiration),
+		UnlimitedLicenses:   types.BoolValue(account.UnlimitedLicenses),
+		UpdatedAt:           types.StringValue(account.UpdatedAt),
+		UsageType:           types.StringValue(account.UsageType),
+	}
+	ctx = plugin.MaskSecrets(ctx, account.RegistrationToken)
+	tflog.Debug(ctx, fmt.Sprintf("converted API account to TF account: %+v", tfaccount), map[string]interface{}{
+		"api_account": account,
+	})
+	return tfaccount
+}
@@ -0,0 +1,138 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &Agent{}
+	_ datasource.DataSourceWithConfigure = &Agent{}
+)
+
+// NewAgent creates a new Agent object.
+func NewAgent() datasource.DataSource {
+	return &Agent{}
+}
+
+// Agent is a data source used to store details about a single agent.
+type Agent struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *Agent) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *Agent) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	agentSchema := getAgentSchema(ctx)
+
+	// override the default schema
+	agentSchema.MarkdownDescription = `This data source can be used for getting details about a single agent.
+
+	The agent can be looked up using either its ` + "`id`" + ` or its ` + "`uuid`" + `, but exactly one of them must be
+	given.
+	`
+	agentSchema.Attributes["id"] = schema.StringAttribute{
+		Description:         "ID of the agent. Conflicts with uuid.",
+		MarkdownDescription: "ID of the agent. Conflicts with `uuid`.",
+		Optional:            true,
+		Computed:            true,
+	}
+	agentSchema.Attributes["uuid"] = schema.StringAttribute{
+		Description:         "UUID of the agent. Conflicts with id.",
+		MarkdownDescription: "UUID of the agent. Conflicts with `id`.",
+		Optional:            true,
+		Computed:            true,
+	}
+	resp.Schema = agentSchema
+}
+
+// Configure initializes the configuration for the data source.
+func (d *Agent) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_AGENT_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *Agent) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfAgent
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// exactly one of the ID or UUID must be given
+	hasId := !data.Id.IsNull() && !data.Id.IsUnknown()
+	hasUUID := !data.UUID.IsNull() && !data.UUID.IsUnknown()
+	if hasId == hasUUID {
+		msg := "Exactly one of 'id' or 'uuid' must be given in order to look up an agent."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_AGENT_READ,
+		})
+		resp.Diagnostics.AddError("Invalid Configuration", msg)
+		return
+	}
+	queryParams := api.AgentQueryParams{}
+	var lookup string
+	if hasId {
+		lookup = fmt.Sprintf("ID: %s", data.Id.ValueString())
+		queryParams.AgentIds = []string{data.Id.ValueString()}
+	} else {
+		lookup = fmt.Sprintf("UUID: %s", data.UUID.ValueString())
+		queryParams.UUIDs = []string{data.UUID.ValueString()}
+	}
+
+	// find the matching agent
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(agents) != 1 {
+		msg := fmt.Sprintf("Expected exactly 1 agent to match but %d agents were found.\n\n%s", len(agents), lookup)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_AGENT_READ,
+			"agents_found":        len(agents),
+		})
+		if len(agents) == 0 {
+			resp.Diagnostics.AddError("Agent Not Found", msg)
+		} else {
+			resp.Diagnostics.AddError("Multiple Agents Found", msg)
+		}
+		return
+	}
+
+	// convert the API object to the Terraform object
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfAgentFromAPI(ctx, &agents[0]))...)
+}
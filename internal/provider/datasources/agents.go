@@ -0,0 +1,917 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &Agents{}
+	_ datasource.DataSourceWithConfigure = &Agents{}
+)
+
+// tfAgents defines the Terraform model for agents.
+type tfAgents struct {
+	AgentIds []types.String  `tfsdk:"agent_ids"`
+	Agents   []tfAgent       `tfsdk:"agents"`
+	Debug    types.Map       `tfsdk:"debug"`
+	Filter   *tfAgentsFilter `tfsdk:"filter"`
+}
+
+// tfAgent defines the Terraform model for an agent.
+type tfAgent struct {
+	AccountId                types.String              `tfsdk:"account_id"`
+	AccountName              types.String              `tfsdk:"account_name"`
+	ActiveThreats            types.Int64               `tfsdk:"active_threats"`
+	AgentVersion             types.String              `tfsdk:"agent_version"`
+	ComputerName             types.String              `tfsdk:"computer_name"`
+	CoreCount                types.Int64               `tfsdk:"core_count"`
+	CpuCount                 types.Int64               `tfsdk:"cpu_count"`
+	CreatedAt                types.String              `tfsdk:"created_at"`
+	Domain                   types.String              `tfsdk:"domain"`
+	ExternalId               types.String              `tfsdk:"external_id"`
+	ExternalIp               types.String              `tfsdk:"external_ip"`
+	GroupId                  types.String              `tfsdk:"group_id"`
+	GroupName                types.String              `tfsdk:"group_name"`
+	Id                       types.String              `tfsdk:"id"`
+	Infected                 types.Bool                `tfsdk:"infected"`
+	IsActive                 types.Bool                `tfsdk:"is_active"`
+	IsDecommissioned         types.Bool                `tfsdk:"is_decommissioned"`
+	IsUninstalled            types.Bool                `tfsdk:"is_uninstalled"`
+	IsUpToDate               types.Bool                `tfsdk:"is_up_to_date"`
+	LastActiveDate           types.String              `tfsdk:"last_active_date"`
+	LastIpToMgmt             types.String              `tfsdk:"last_ip_to_mgmt"`
+	LastLoggedInUserName     types.String              `tfsdk:"last_logged_in_user_name"`
+	MachineType              types.String              `tfsdk:"machine_type"`
+	MitigationMode           types.String              `tfsdk:"mitigation_mode"`
+	MitigationModeSuspicious types.String              `tfsdk:"mitigation_mode_suspicious"`
+	ModelName                types.String              `tfsdk:"model_name"`
+	NetworkInterfaces        []tfAgentNetworkInterface `tfsdk:"network_interfaces"`
+	NetworkStatus            types.String              `tfsdk:"network_status"`
+	OSArch                   types.String              `tfsdk:"os_arch"`
+	OSName                   types.String              `tfsdk:"os_name"`
+	OSRevision               types.String              `tfsdk:"os_revision"`
+	OSStartTime              types.String              `tfsdk:"os_start_time"`
+	OSType                   types.String              `tfsdk:"os_type"`
+	OSUsername               types.String              `tfsdk:"os_username"`
+	RegisteredAt             types.String              `tfsdk:"registered_at"`
+	SiteId                   types.String              `tfsdk:"site_id"`
+	SiteName                 types.String              `tfsdk:"site_name"`
+	Tags                     []tfAgentTag              `tfsdk:"tags"`
+	TotalMemory              types.Int64               `tfsdk:"total_memory"`
+	UpdatedAt                types.String              `tfsdk:"updated_at"`
+	UUID                     types.String              `tfsdk:"uuid"`
+}
+
+// tfAgentNetworkInterface defines the Terraform model for a network interface on an agent's endpoint.
+type tfAgentNetworkInterface struct {
+	Id       types.String   `tfsdk:"id"`
+	Inet     []types.String `tfsdk:"inet"`
+	Inet6    []types.String `tfsdk:"inet6"`
+	Name     types.String   `tfsdk:"name"`
+	Physical types.String   `tfsdk:"physical"`
+}
+
+// tfAgentTag defines the Terraform model for a tag assigned to an agent.
+type tfAgentTag struct {
+	Id    types.String `tfsdk:"id"`
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+}
+
+// tfAgentsFilter defines the Terraform model for agent filtering.
+type tfAgentsFilter struct {
+	AccountIds           []types.String `tfsdk:"account_ids"`
+	AgentIds             []types.String `tfsdk:"agent_ids"`
+	AgentVersions        []types.String `tfsdk:"agent_versions"`
+	ComputerName         types.String   `tfsdk:"computer_name"`
+	ComputerNameContains []types.String `tfsdk:"computer_name_contains"`
+	ExcludeAgentVersions []types.String `tfsdk:"exclude_agent_versions"`
+	ExternalId           types.String   `tfsdk:"external_id"`
+	FilterJSON           types.String   `tfsdk:"filter_json"`
+	GroupIds             []types.String `tfsdk:"group_ids"`
+	Infected             types.Bool     `tfsdk:"infected"`
+	IsActive             types.Bool     `tfsdk:"is_active"`
+	IsDecommissioned     types.Bool     `tfsdk:"is_decommissioned"`
+	IsUninstalled        types.Bool     `tfsdk:"is_uninstalled"`
+	IsUpToDate           types.Bool     `tfsdk:"is_up_to_date"`
+	LastActiveAfter      types.String   `tfsdk:"last_active_after"`
+	LastActiveBefore     types.String   `tfsdk:"last_active_before"`
+	MachineTypes         []types.String `tfsdk:"machine_types"`
+	MaxAgentVersion      types.String   `tfsdk:"max_agent_version"`
+	MinAgentVersion      types.String   `tfsdk:"min_agent_version"`
+	NetworkStatuses      []types.String `tfsdk:"network_statuses"`
+	OSTypes              []types.String `tfsdk:"os_types"`
+	Query                types.String   `tfsdk:"query"`
+	SiteIds              []types.String `tfsdk:"site_ids"`
+	SortBy               types.String   `tfsdk:"sort_by"`
+	SortOrder            types.String   `tfsdk:"sort_order"`
+	TagIds               []types.String `tfsdk:"tag_ids"`
+}
+
+// NewAgents creates a new Agents object.
+func NewAgents() datasource.DataSource {
+	return &Agents{}
+}
+
+// Agents is a data source used to store details about agents.
+type Agents struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *Agents) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agents"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *Agents) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source can be used for getting a list of agents based on filters.",
+		MarkdownDescription: `This data source can be used for getting a list of agents based on filters.
+
+		Every filter is applied by the API server so only the matching agents are returned, which keeps reads fast even
+		for large fleets. All of the filters given must match (AND). Within a list filter such as
+		` + "`os_types`" + `, any of the values may match (OR). The ` + "`agent_ids`" + ` attribute holds the IDs of
+		the matching agents so that they can be passed directly to the ` + "`agent_ids`" + ` filter of an action
+		resource such as ` + "`singularity_agent_scan`" + `, which ensures the action targets exactly the agents that
+		were planned.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"debug": getDebugSchema(),
+			"agent_ids": schema.ListAttribute{
+				Description:         "List of the IDs of the matching agents that were found.",
+				MarkdownDescription: "List of the IDs of the matching agents that were found.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"agents": schema.ListNestedAttribute{
+				Description:         "List of matching agents that were found.",
+				MarkdownDescription: "List of matching agents that were found.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: getAgentSchema(ctx).Attributes,
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Description:         "Defines the query filters to use when searching for agents.",
+				MarkdownDescription: "Defines the query filters to use when searching for agents.",
+				Attributes: map[string]schema.Attribute{
+					"account_ids": schema.ListAttribute{
+						Description:         "List of account IDs to filter by.",
+						MarkdownDescription: "List of account IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"agent_ids": schema.ListAttribute{
+						Description:         "List of agent IDs to filter by.",
+						MarkdownDescription: "List of agent IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"agent_versions": schema.ListAttribute{
+						Description:         "List of exact agent versions to filter by (eg: 23.1.2.400).",
+						MarkdownDescription: "List of exact agent versions to filter by (eg: `23.1.2.400`).",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"computer_name": schema.StringAttribute{
+						Description:         "Exact computer name of the agent.",
+						MarkdownDescription: "Exact computer name of the agent.",
+						Optional:            true,
+					},
+					"computer_name_contains": schema.ListAttribute{
+						Description:         "List of partial computer names to filter by.",
+						MarkdownDescription: "List of partial computer names to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"exclude_agent_versions": schema.ListAttribute{
+						Description:         "List of exact agent versions to exclude (eg: 23.1.2.400).",
+						MarkdownDescription: "List of exact agent versions to exclude (eg: `23.1.2.400`).",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"external_id": schema.StringAttribute{
+						Description:         "ID of the agent in an external system.",
+						MarkdownDescription: "ID of the agent in an external system.",
+						Optional:            true,
+					},
+					"filter_json": getFilterJSONSchema(),
+					"group_ids": schema.ListAttribute{
+						Description:         "List of group IDs to filter by.",
+						MarkdownDescription: "List of group IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"infected": schema.BoolAttribute{
+						Description:         "Whether or not the agent has active threats.",
+						MarkdownDescription: "Whether or not the agent has active threats.",
+						Optional:            true,
+					},
+					"is_active": schema.BoolAttribute{
+						Description:         "Whether or not the agent is active.",
+						MarkdownDescription: "Whether or not the agent is active.",
+						Optional:            true,
+					},
+					"is_decommissioned": schema.BoolAttribute{
+						Description:         "Whether or not the agent has been decommissioned.",
+						MarkdownDescription: "Whether or not the agent has been decommissioned.",
+						Optional:            true,
+					},
+					"is_uninstalled": schema.BoolAttribute{
+						Description:         "Whether or not the agent has been uninstalled.",
+						MarkdownDescription: "Whether or not the agent has been uninstalled.",
+						Optional:            true,
+					},
+					"is_up_to_date": schema.BoolAttribute{
+						Description:         "Whether or not the agent is running the latest version.",
+						MarkdownDescription: "Whether or not the agent is running the latest version.",
+						Optional:            true,
+					},
+					"last_active_after": schema.StringAttribute{
+						Description: "Agent was last active after the given timestamp (eg: 2023-01-01T00:00:00Z) or " +
+							"relative timestamp (eg: -24h, -7d).",
+						MarkdownDescription: "Agent was last active after the given timestamp (eg: " +
+							"`2023-01-01T00:00:00Z`) or relative timestamp (eg: `-24h`, `-7d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+							validators.TimestampIsBefore(false, "last_active_before"),
+						},
+					},
+					"last_active_before": schema.StringAttribute{
+						Description: "Agent was last active before the given timestamp (eg: 2023-01-01T00:00:00Z) or " +
+							"relative timestamp (eg: -24h, -7d).",
+						MarkdownDescription: "Agent was last active before the given timestamp (eg: " +
+							"`2023-01-01T00:00:00Z`) or relative timestamp (eg: `-24h`, `-7d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+						},
+					},
+					"machine_types": schema.ListAttribute{
+						Description: "List of machine types to filter by (valid values: desktop, kubernetes node, " +
+							"laptop, server, storage, unknown).",
+						MarkdownDescription: "List of machine types to filter by (valid values: `desktop`, " +
+							"`kubernetes node`, `laptop`, `server`, `storage`, `unknown`).",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							validators.EnumStringListValuesAre(false,
+								"desktop", "kubernetes node", "laptop", "server", "storage", "unknown",
+							),
+						},
+					},
+					"max_agent_version": schema.StringAttribute{
+						Description:         "Only return agents running the given version or older (eg: 23.1.2.400).",
+						MarkdownDescription: "Only return agents running the given version or older (eg: `23.1.2.400`).",
+						Optional:            true,
+					},
+					"min_agent_version": schema.StringAttribute{
+						Description:         "Only return agents running the given version or newer (eg: 22.3.1.100).",
+						MarkdownDescription: "Only return agents running the given version or newer (eg: `22.3.1.100`).",
+						Optional:            true,
+					},
+					"network_statuses": schema.ListAttribute{
+						Description: "List of network statuses to filter by (valid values: connected, connecting, " +
+							"disconnected, disconnecting).",
+						MarkdownDescription: "List of network statuses to filter by (valid values: `connected`, " +
+							"`connecting`, `disconnected`, `disconnecting`).",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							validators.EnumStringListValuesAre(false,
+								"connected", "connecting", "disconnected", "disconnecting",
+							),
+						},
+					},
+					"os_types": schema.ListAttribute{
+						Description: "List of OS types to filter by (valid values: linux, macos, windows, " +
+							"windows_legacy).",
+						MarkdownDescription: "List of OS types to filter by (valid values: `linux`, `macos`, `windows`, " +
+							"`windows_legacy`).",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							validators.EnumStringListValuesAre(false,
+								"linux", "macos", "windows", "windows_legacy",
+							),
+						},
+					},
+					"query": schema.StringAttribute{
+						Description:         "A free-text search term, will match applicable attributes.",
+						MarkdownDescription: "A free-text search term, will match applicable attributes.",
+						Optional:            true,
+					},
+					"site_ids": schema.ListAttribute{
+						Description:         "List of site IDs to filter by.",
+						MarkdownDescription: "List of site IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"sort_by": schema.StringAttribute{
+						Description: "Field on which to sort results (valid values: agentVersion, computerName, " +
+							"createdAt, id, lastActiveDate, networkStatus, osType, registeredAt, siteName, updatedAt) " +
+							"[Default: id].",
+						MarkdownDescription: "Field on which to sort results (valid values: `agentVersion`, " +
+							"`computerName`, `createdAt`, `id`, `lastActiveDate`, `networkStatus`, `osType`, " +
+							"`registeredAt`, `siteName`, `updatedAt`) [Default: `id`].",
+						Optional: true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(false,
+								"agentVersion", "computerName", "createdAt", "id", "lastActiveDate", "networkStatus",
+								"osType", "registeredAt", "siteName", "updatedAt",
+							),
+						},
+					},
+					"sort_order": schema.StringAttribute{
+						Description:         "Order in which to sort results (valid values: asc, desc) [Default: asc].",
+						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`) [Default: `asc`].",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(false,
+								"asc", "desc",
+							),
+						},
+					},
+					"tag_ids": schema.ListAttribute{
+						Description:         "List of IDs of tags assigned to the agent to filter by.",
+						MarkdownDescription: "List of IDs of tags assigned to the agent to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *Agents) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_AGENTS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *Agents) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfAgents
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// capture any debug response headers returned by the queries below
+	ctx, recorder := api.RecordResponseHeaders(ctx)
+
+	// construct query parameters
+	queryParams := api.AgentQueryParams{}
+	if data.Filter != nil {
+		var diags diag.Diagnostics
+		queryParams, diags = d.queryParamsFromFilter(ctx, *data.Filter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// always sort results so their order is deterministic even if the API server changes its default ordering
+	queryParams.SortBy, queryParams.SortOrder = sortOrDefault(queryParams.SortBy, queryParams.SortOrder)
+
+	// find the matching agents
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert API objects into Terraform objects
+	tfagents := tfAgents{
+		AgentIds: []types.String{},
+		Agents:   []tfAgent{},
+		Filter:   data.Filter,
+	}
+	for _, agent := range agents {
+		tfagents.AgentIds = append(tfagents.AgentIds, types.StringValue(agent.Id))
+		tfagents.Agents = append(tfagents.Agents, tfAgentFromAPI(ctx, &agent))
+	}
+	debug, diags := tfDebugFromRecorder(ctx, recorder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tfagents.Debug = debug
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfagents)...)
+}
+
+// queryParamsFromFilter converts the TF filter block into API query parameters.
+func (d *Agents) queryParamsFromFilter(ctx context.Context, filter tfAgentsFilter) (api.AgentQueryParams,
+	diag.Diagnostics) {
+	queryParams := api.AgentQueryParams{}
+
+	// relative timestamps are resolved against the time at which the data source is read
+	now := time.Now()
+
+	rawFilter, diags := rawFilterFromJSON(ctx, filter.FilterJSON)
+	if diags.HasError() {
+		return queryParams, diags
+	}
+	queryParams.RawFilter = rawFilter
+
+	if len(filter.AccountIds) > 0 {
+		queryParams.AccountIds = stringsFromList(filter.AccountIds)
+	}
+
+	if len(filter.AgentIds) > 0 {
+		queryParams.AgentIds = stringsFromList(filter.AgentIds)
+	}
+
+	if len(filter.AgentVersions) > 0 {
+		queryParams.AgentVersions = stringsFromList(filter.AgentVersions)
+	}
+
+	if !filter.ComputerName.IsNull() && !filter.ComputerName.IsUnknown() {
+		value := filter.ComputerName.ValueString()
+		queryParams.ComputerName = &value
+	}
+
+	if len(filter.ComputerNameContains) > 0 {
+		queryParams.ComputerNameContains = stringsFromList(filter.ComputerNameContains)
+	}
+
+	if len(filter.ExcludeAgentVersions) > 0 {
+		queryParams.AgentVersionsNin = stringsFromList(filter.ExcludeAgentVersions)
+	}
+
+	if !filter.ExternalId.IsNull() && !filter.ExternalId.IsUnknown() {
+		value := filter.ExternalId.ValueString()
+		queryParams.ExternalId = &value
+	}
+
+	if len(filter.GroupIds) > 0 {
+		queryParams.GroupIds = stringsFromList(filter.GroupIds)
+	}
+
+	if !filter.Infected.IsNull() && !filter.Infected.IsUnknown() {
+		value := filter.Infected.ValueBool()
+		queryParams.Infected = &value
+	}
+
+	if !filter.IsActive.IsNull() && !filter.IsActive.IsUnknown() {
+		value := filter.IsActive.ValueBool()
+		queryParams.IsActive = &value
+	}
+
+	if !filter.IsDecommissioned.IsNull() && !filter.IsDecommissioned.IsUnknown() {
+		value := filter.IsDecommissioned.ValueBool()
+		queryParams.IsDecommissioned = &value
+	}
+
+	if !filter.IsUninstalled.IsNull() && !filter.IsUninstalled.IsUnknown() {
+		value := filter.IsUninstalled.ValueBool()
+		queryParams.IsUninstalled = &value
+	}
+
+	if !filter.IsUpToDate.IsNull() && !filter.IsUpToDate.IsUnknown() {
+		value := filter.IsUpToDate.ValueBool()
+		queryParams.IsUpToDate = &value
+	}
+
+	if !filter.LastActiveAfter.IsNull() && !filter.LastActiveAfter.IsUnknown() {
+		value, diags := plugin.ResolveTimestamp(ctx, filter.LastActiveAfter.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
+		queryParams.LastActiveAfter = &value
+	}
+
+	if !filter.LastActiveBefore.IsNull() && !filter.LastActiveBefore.IsUnknown() {
+		value, diags := plugin.ResolveTimestamp(ctx, filter.LastActiveBefore.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
+		queryParams.LastActiveBefore = &value
+	}
+
+	if len(filter.MachineTypes) > 0 {
+		queryParams.MachineTypes = stringsFromList(filter.MachineTypes)
+	}
+
+	if !filter.MaxAgentVersion.IsNull() && !filter.MaxAgentVersion.IsUnknown() {
+		value := filter.MaxAgentVersion.ValueString()
+		queryParams.MaxAgentVersion = &value
+	}
+
+	if !filter.MinAgentVersion.IsNull() && !filter.MinAgentVersion.IsUnknown() {
+		value := filter.MinAgentVersion.ValueString()
+		queryParams.MinAgentVersion = &value
+	}
+
+	if len(filter.NetworkStatuses) > 0 {
+		queryParams.NetworkStatuses = stringsFromList(filter.NetworkStatuses)
+	}
+
+	if len(filter.OSTypes) > 0 {
+		queryParams.OSTypes = stringsFromList(filter.OSTypes)
+	}
+
+	if !filter.Query.IsNull() && !filter.Query.IsUnknown() {
+		value := filter.Query.ValueString()
+		queryParams.Query = &value
+	}
+
+	if len(filter.SiteIds) > 0 {
+		queryParams.SiteIds = stringsFromList(filter.SiteIds)
+	}
+
+	if !filter.SortBy.IsNull() && !filter.SortBy.IsUnknown() {
+		value := filter.SortBy.ValueString()
+		queryParams.SortBy = &value
+	}
+
+	if !filter.SortOrder.IsNull() && !filter.SortOrder.IsUnknown() {
+		value := filter.SortOrder.ValueString()
+		queryParams.SortOrder = &value
+	}
+
+	if len(filter.TagIds) > 0 {
+		queryParams.TagIds = stringsFromList(filter.TagIds)
+	}
+	return queryParams, diags
+}
+
+// getAgentSchema returns a default schema for an agent.
+//
+// This is used by other data sources to avoid duplicating the schema.
+func getAgentSchema(ctx context.Context) schema.Schema {
+	return schema.Schema{
+		Description:         "This data source can be used for getting details about a single agent.",
+		MarkdownDescription: "This data source can be used for getting details about a single agent.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description:         "ID of the account to which the agent belongs.",
+				MarkdownDescription: "ID of the account to which the agent belongs.",
+				Computed:            true,
+			},
+			"account_name": schema.StringAttribute{
+				Description:         "Name of the account to which the agent belongs.",
+				MarkdownDescription: "Name of the account to which the agent belongs.",
+				Computed:            true,
+			},
+			"active_threats": schema.Int64Attribute{
+				Description:         "Number of active threats on the agent.",
+				MarkdownDescription: "Number of active threats on the agent.",
+				Computed:            true,
+			},
+			"agent_version": schema.StringAttribute{
+				Description:         "Version of the agent.",
+				MarkdownDescription: "Version of the agent.",
+				Computed:            true,
+			},
+			"computer_name": schema.StringAttribute{
+				Description:         "Computer name of the endpoint on which the agent is installed.",
+				MarkdownDescription: "Computer name of the endpoint on which the agent is installed.",
+				Computed:            true,
+			},
+			"core_count": schema.Int64Attribute{
+				Description:         "Number of CPU cores on the endpoint.",
+				MarkdownDescription: "Number of CPU cores on the endpoint.",
+				Computed:            true,
+			},
+			"cpu_count": schema.Int64Attribute{
+				Description:         "Number of CPUs on the endpoint.",
+				MarkdownDescription: "Number of CPUs on the endpoint.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the agent was created.",
+				MarkdownDescription: "Timestamp of when the agent was created.",
+				Computed:            true,
+			},
+			"domain": schema.StringAttribute{
+				Description:         "Network domain of the endpoint.",
+				MarkdownDescription: "Network domain of the endpoint.",
+				Computed:            true,
+			},
+			"external_id": schema.StringAttribute{
+				Description:         "ID of the agent in an external system.",
+				MarkdownDescription: "ID of the agent in an external system.",
+				Computed:            true,
+			},
+			"external_ip": schema.StringAttribute{
+				Description:         "External IP address of the endpoint.",
+				MarkdownDescription: "External IP address of the endpoint.",
+				Computed:            true,
+			},
+			"group_id": schema.StringAttribute{
+				Description:         "ID of the group to which the agent belongs.",
+				MarkdownDescription: "ID of the group to which the agent belongs.",
+				Computed:            true,
+			},
+			"group_name": schema.StringAttribute{
+				Description:         "Name of the group to which the agent belongs.",
+				MarkdownDescription: "Name of the group to which the agent belongs.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the agent.",
+				MarkdownDescription: "ID of the agent.",
+				Computed:            true,
+			},
+			"infected": schema.BoolAttribute{
+				Description:         "Whether or not the agent has active threats.",
+				MarkdownDescription: "Whether or not the agent has active threats.",
+				Computed:            true,
+			},
+			"is_active": schema.BoolAttribute{
+				Description:         "Whether or not the agent is active.",
+				MarkdownDescription: "Whether or not the agent is active.",
+				Computed:            true,
+			},
+			"is_decommissioned": schema.BoolAttribute{
+				Description:         "Whether or not the agent has been decommissioned.",
+				MarkdownDescription: "Whether or not the agent has been decommissioned.",
+				Computed:            true,
+			},
+			"is_uninstalled": schema.BoolAttribute{
+				Description:         "Whether or not the agent has been uninstalled.",
+				MarkdownDescription: "Whether or not the agent has been uninstalled.",
+				Computed:            true,
+			},
+			"is_up_to_date": schema.BoolAttribute{
+				Description:         "Whether or not the agent is running the latest version.",
+				MarkdownDescription: "Whether or not the agent is running the latest version.",
+				Computed:            true,
+			},
+			"last_active_date": schema.StringAttribute{
+				Description:         "Timestamp of when the agent was last active.",
+				MarkdownDescription: "Timestamp of when the agent was last active.",
+				Computed:            true,
+			},
+			"last_ip_to_mgmt": schema.StringAttribute{
+				Description:         "Last IP address the agent used to connect to the management console.",
+				MarkdownDescription: "Last IP address the agent used to connect to the management console.",
+				Computed:            true,
+			},
+			"last_logged_in_user_name": schema.StringAttribute{
+				Description:         "Name of the user who last logged in to the endpoint.",
+				MarkdownDescription: "Name of the user who last logged in to the endpoint.",
+				Computed:            true,
+			},
+			"machine_type": schema.StringAttribute{
+				Description:         "Type of the endpoint (eg: desktop, laptop, server).",
+				MarkdownDescription: "Type of the endpoint (eg: `desktop`, `laptop`, `server`).",
+				Computed:            true,
+			},
+			"mitigation_mode": schema.StringAttribute{
+				Description:         "How the agent responds to malicious threats (eg: detect, protect).",
+				MarkdownDescription: "How the agent responds to malicious threats (eg: `detect`, `protect`).",
+				Computed:            true,
+			},
+			"mitigation_mode_suspicious": schema.StringAttribute{
+				Description:         "How the agent responds to suspicious threats (eg: detect, protect).",
+				MarkdownDescription: "How the agent responds to suspicious threats (eg: `detect`, `protect`).",
+				Computed:            true,
+			},
+			"model_name": schema.StringAttribute{
+				Description:         "Hardware model of the endpoint.",
+				MarkdownDescription: "Hardware model of the endpoint.",
+				Computed:            true,
+			},
+			"network_interfaces": schema.ListNestedAttribute{
+				Description:         "List of network interfaces on the endpoint.",
+				MarkdownDescription: "List of network interfaces on the endpoint.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "ID of the network interface.",
+							MarkdownDescription: "ID of the network interface.",
+							Computed:            true,
+						},
+						"inet": schema.ListAttribute{
+							Description:         "List of IPv4 addresses assigned to the interface.",
+							MarkdownDescription: "List of IPv4 addresses assigned to the interface.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"inet6": schema.ListAttribute{
+							Description:         "List of IPv6 addresses assigned to the interface.",
+							MarkdownDescription: "List of IPv6 addresses assigned to the interface.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"name": schema.StringAttribute{
+							Description:         "Name of the interface.",
+							MarkdownDescription: "Name of the interface.",
+							Computed:            true,
+						},
+						"physical": schema.StringAttribute{
+							Description:         "MAC address of the interface.",
+							MarkdownDescription: "MAC address of the interface.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"network_status": schema.StringAttribute{
+				Description:         "Network status of the agent (eg: connected, disconnected).",
+				MarkdownDescription: "Network status of the agent (eg: `connected`, `disconnected`).",
+				Computed:            true,
+			},
+			"os_arch": schema.StringAttribute{
+				Description:         "Architecture of the operating system (eg: 32 bit, 64 bit).",
+				MarkdownDescription: "Architecture of the operating system (eg: `32 bit`, `64 bit`).",
+				Computed:            true,
+			},
+			"os_name": schema.StringAttribute{
+				Description:         "Name of the operating system of the endpoint.",
+				MarkdownDescription: "Name of the operating system of the endpoint.",
+				Computed:            true,
+			},
+			"os_revision": schema.StringAttribute{
+				Description:         "Revision or build of the operating system.",
+				MarkdownDescription: "Revision or build of the operating system.",
+				Computed:            true,
+			},
+			"os_start_time": schema.StringAttribute{
+				Description:         "Timestamp of when the operating system was last started.",
+				MarkdownDescription: "Timestamp of when the operating system was last started.",
+				Computed:            true,
+			},
+			"os_type": schema.StringAttribute{
+				Description:         "Type of the operating system of the endpoint (eg: linux, macos, windows).",
+				MarkdownDescription: "Type of the operating system of the endpoint (eg: `linux`, `macos`, `windows`).",
+				Computed:            true,
+			},
+			"os_username": schema.StringAttribute{
+				Description:         "Name of the user the operating system is registered to.",
+				MarkdownDescription: "Name of the user the operating system is registered to.",
+				Computed:            true,
+			},
+			"registered_at": schema.StringAttribute{
+				Description:         "Timestamp of when the agent was registered.",
+				MarkdownDescription: "Timestamp of when the agent was registered.",
+				Computed:            true,
+			},
+			"site_id": schema.StringAttribute{
+				Description:         "ID of the site to which the agent belongs.",
+				MarkdownDescription: "ID of the site to which the agent belongs.",
+				Computed:            true,
+			},
+			"site_name": schema.StringAttribute{
+				Description:         "Name of the site to which the agent belongs.",
+				MarkdownDescription: "Name of the site to which the agent belongs.",
+				Computed:            true,
+			},
+			"tags": schema.ListNestedAttribute{
+				Description:         "List of tags assigned to the agent.",
+				MarkdownDescription: "List of tags assigned to the agent.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "ID of the tag.",
+							MarkdownDescription: "ID of the tag.",
+							Computed:            true,
+						},
+						"key": schema.StringAttribute{
+							Description:         "Key of the tag.",
+							MarkdownDescription: "Key of the tag.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							Description:         "Value of the tag.",
+							MarkdownDescription: "Value of the tag.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"total_memory": schema.Int64Attribute{
+				Description:         "Total memory of the endpoint in MB.",
+				MarkdownDescription: "Total memory of the endpoint in MB.",
+				Computed:            true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the agent was last updated.",
+				MarkdownDescription: "Timestamp of when the agent was last updated.",
+				Computed:            true,
+			},
+			"uuid": schema.StringAttribute{
+				Description:         "UUID of the agent.",
+				MarkdownDescription: "UUID of the agent.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// tfAgentFromAPI converts an API agent into a Terraform agent.
+func tfAgentFromAPI(ctx context.Context, agent *api.Agent) tfAgent {
+	tfagent := tfAgent{
+		AccountId:                types.StringValue(agent.AccountId),
+		AccountName:              types.StringValue(agent.AccountName),
+		ActiveThreats:            types.Int64Value(int64(agent.ActiveThreats)),
+		AgentVersion:             types.StringValue(agent.AgentVersion),
+		ComputerName:             types.StringValue(agent.ComputerName),
+		CoreCount:                types.Int64Value(int64(agent.CoreCount)),
+		CpuCount:                 types.Int64Value(int64(agent.CpuCount)),
+		CreatedAt:                types.StringValue(agent.CreatedAt),
+		Domain:                   types.StringValue(agent.Domain),
+		ExternalId:               types.StringValue(agent.ExternalId),
+		ExternalIp:               types.StringValue(agent.ExternalIp),
+		GroupId:                  types.StringValue(agent.GroupId),
+		GroupName:                types.StringValue(agent.GroupName),
+		Id:                       types.StringValue(agent.Id),
+		Infected:                 types.BoolValue(agent.Infected),
+		IsActive:                 types.BoolValue(agent.IsActive),
+		IsDecommissioned:         types.BoolValue(agent.IsDecommissioned),
+		IsUninstalled:            types.BoolValue(agent.IsUninstalled),
+		IsUpToDate:               types.BoolValue(agent.IsUpToDate),
+		LastActiveDate:           types.StringValue(agent.LastActiveDate),
+		LastIpToMgmt:             types.StringValue(agent.LastIpToMgmt),
+		LastLoggedInUserName:     types.StringValue(agent.LastLoggedInUserName),
+		MachineType:              types.StringValue(agent.MachineType),
+		MitigationMode:           types.StringValue(agent.MitigationMode),
+		MitigationModeSuspicious: types.StringValue(agent.MitigationModeSuspicious),
+		ModelName:                types.StringValue(agent.ModelName),
+		NetworkInterfaces:        []tfAgentNetworkInterface{},
+		NetworkStatus:            types.StringValue(agent.NetworkStatus),
+		OSArch:                   types.StringValue(agent.OSArch),
+		OSName:                   types.StringValue(agent.OSName),
+		OSRevision:               types.StringValue(agent.OSRevision),
+		OSStartTime:              types.StringValue(agent.OSStartTime),
+		OSType:                   types.StringValue(agent.OSType),
+		OSUsername:               types.StringValue(agent.OSUsername),
+		RegisteredAt:             types.StringValue(agent.RegisteredAt),
+		SiteId:                   types.StringValue(agent.SiteId),
+		SiteName:                 types.StringValue(agent.SiteName),
+		Tags:                     []tfAgentTag{},
+		TotalMemory:              types.Int64Value(int64(agent.TotalMemory)),
+		UpdatedAt:                types.StringValue(agent.UpdatedAt),
+		UUID:                     types.StringValue(agent.UUID),
+	}
+	for _, iface := range agent.NetworkInterfaces {
+		tfiface := tfAgentNetworkInterface{
+			Id:       types.StringValue(iface.Id),
+			Inet:     []types.String{},
+			Inet6:    []types.String{},
+			Name:     types.StringValue(iface.Name),
+			Physical: types.StringValue(iface.Physical),
+		}
+		for _, addr := range iface.Inet {
+			tfiface.Inet = append(tfiface.Inet, types.StringValue(addr))
+		}
+		for _, addr := range iface.Inet6 {
+			tfiface.Inet6 = append(tfiface.Inet6, types.StringValue(addr))
+		}
+		tfagent.NetworkInterfaces = append(tfagent.NetworkInterfaces, tfiface)
+	}
+	for _, tag := range agent.Tags.SentinelOne {
+		tfagent.Tags = append(tfagent.Tags, tfAgentTag{
+			Id:    types.StringValue(tag.Id),
+			Key:   types.StringValue(tag.Key),
+			Value: types.StringValue(tag.Value),
+		})
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API agent to TF agent: %+v", tfagent), map[string]interface{}{
+		"api_agent": agent,
+	})
+	return tfagent
+}
@@ -0,0 +1,302 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &AgentsExport{}
+	_ datasource.DataSourceWithConfigure = &AgentsExport{}
+)
+
+// tfAgentsExport defines the Terraform model for an agents export.
+type tfAgentsExport struct {
+	DirectoryMode types.String          `tfsdk:"directory_mode"`
+	FileMode      types.String          `tfsdk:"file_mode"`
+	FileSize      types.Int64           `tfsdk:"file_size"`
+	Filter        *tfAgentsExportFilter `tfsdk:"filter"`
+	Format        types.String          `tfsdk:"format"`
+	LocalFilename types.String          `tfsdk:"local_filename"`
+	LocalFolder   types.String          `tfsdk:"local_folder"`
+	OutputFile    types.String          `tfsdk:"output_file"`
+	SHA256        types.String          `tfsdk:"sha256"`
+}
+
+// tfAgentsExportFilter defines the Terraform model for selecting the agents to export.
+type tfAgentsExportFilter struct {
+	AccountIds           []types.String `tfsdk:"account_ids"`
+	ComputerNameContains []types.String `tfsdk:"computer_name_contains"`
+	FilterJSON           types.String   `tfsdk:"filter_json"`
+	GroupIds             []types.String `tfsdk:"group_ids"`
+	IsActive             types.Bool     `tfsdk:"is_active"`
+	OSTypes              []types.String `tfsdk:"os_types"`
+	SiteIds              []types.String `tfsdk:"site_ids"`
+}
+
+// NewAgentsExport creates a new AgentsExport object.
+func NewAgentsExport() datasource.DataSource {
+	return &AgentsExport{}
+}
+
+// AgentsExport is a data source used to export the inventory of agents to a local file.
+type AgentsExport struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *AgentsExport) Metadata(ctx context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_agents_export"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *AgentsExport) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source is used for exporting the inventory of all agents matching a filter to a " +
+			"local CSV or JSON file.",
+		MarkdownDescription: `This data source is used for exporting the inventory of all agents matching a filter to a
+			local CSV or JSON file.
+
+		The export is streamed directly from the API to the file, so the agents never pass through the Terraform
+		state; only the location, size and checksum of the file are stored. Like any data source, the export is
+		refreshed every time the configuration is planned or applied and the file is overwritten.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"directory_mode": schema.StringAttribute{
+				Description: "The permissions to set on any folders created when saving the file. Ignored on " +
+					"Windows. [Default: 0755]",
+				MarkdownDescription: "The permissions to set on any folders created when saving the file. Ignored on " +
+					"Windows. [Default: `0755`]",
+				Optional: true,
+				Validators: []validator.String{
+					validators.FileModeIsValid(),
+				},
+			},
+			"file_mode": schema.StringAttribute{
+				Description: "The permissions to set on the file once it has been saved. Ignored on Windows. " +
+					"[Default: 0644]",
+				MarkdownDescription: "The permissions to set on the file once it has been saved. Ignored on Windows. " +
+					"[Default: `0644`]",
+				Optional: true,
+				Validators: []validator.String{
+					validators.FileModeIsValid(),
+				},
+			},
+			"file_size": schema.Int64Attribute{
+				Description:         "The size of the export file.",
+				MarkdownDescription: "The size of the export file.",
+				Computed:            true,
+			},
+			"format": schema.StringAttribute{
+				Description:         "Format of the export file (valid values: csv, json). [Default: csv]",
+				MarkdownDescription: "Format of the export file (valid values: `csv`, `json`). [Default: `csv`]",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "csv", "json"),
+				},
+			},
+			"local_filename": schema.StringAttribute{
+				Description:         "The name of the file to save the export as.",
+				MarkdownDescription: "The name of the file to save the export as.",
+				Required:            true,
+			},
+			"local_folder": schema.StringAttribute{
+				Description: "The full path to the folder in which to store the export. Relative paths will be " +
+					"based on the working directory when the Terraform plan is applied. [Default: the current working " +
+					"directory]",
+				MarkdownDescription: "The full path to the folder in which to store the export. Relative paths will be " +
+					"based on the working directory when the Terraform plan is applied. [Default: the current working " +
+					"directory]",
+				Optional: true,
+			},
+			"output_file": schema.StringAttribute{
+				Description:         "The absolute path of the export file once it has been saved.",
+				MarkdownDescription: "The absolute path of the export file once it has been saved.",
+				Computed:            true,
+			},
+			"sha256": schema.StringAttribute{
+				Description:         "The SHA256 checksum of the export file.",
+				MarkdownDescription: "The SHA256 checksum of the export file.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Description:         "Defines the query filters used to select the agents to export.",
+				MarkdownDescription: "Defines the query filters used to select the agents to export.",
+				Attributes: map[string]schema.Attribute{
+					"account_ids": schema.ListAttribute{
+						Description:         "List of account IDs to filter by.",
+						MarkdownDescription: "List of account IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"computer_name_contains": schema.ListAttribute{
+						Description:         "List of partial computer names to filter by.",
+						MarkdownDescription: "List of partial computer names to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"filter_json": getFilterJSONSchema(),
+					"group_ids": schema.ListAttribute{
+						Description:         "List of group IDs to filter by.",
+						MarkdownDescription: "List of group IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"is_active": schema.BoolAttribute{
+						Description:         "Whether or not the agent is active.",
+						MarkdownDescription: "Whether or not the agent is active.",
+						Optional:            true,
+					},
+					"os_types": schema.ListAttribute{
+						Description:         "List of OS types to filter by (eg: linux, macos, windows).",
+						MarkdownDescription: "List of OS types to filter by (eg: `linux`, `macos`, `windows`).",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"site_ids": schema.ListAttribute{
+						Description:         "List of site IDs to filter by.",
+						MarkdownDescription: "List of site IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *AgentsExport) Configure(ctx context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_AGENTS_EXPORT_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *AgentsExport) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfAgentsExport
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// data sources do not support defaults so fill them in here
+	if data.DirectoryMode.IsNull() {
+		data.DirectoryMode = types.StringValue("0755")
+	}
+	if data.FileMode.IsNull() {
+		data.FileMode = types.StringValue("0644")
+	}
+	if data.Format.IsNull() {
+		data.Format = types.StringValue("csv")
+	}
+	if data.LocalFolder.IsNull() {
+		data.LocalFolder = types.StringValue(plugin.GetWorkDir())
+	}
+
+	// construct query parameters
+	queryParams := api.AgentQueryParams{}
+	if data.Filter != nil {
+		var diags diag.Diagnostics
+		queryParams, diags = d.queryParamsFromFilter(ctx, *data.Filter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// stream the export to the file
+	outputFile, fileSize, diags := api.Client().ExportAgents(ctx, queryParams,
+		strings.ToLower(data.Format.ValueString()),
+		path.Join(data.LocalFolder.ValueString(), data.LocalFilename.ValueString()),
+		data.DirectoryMode.ValueString(), data.FileMode.ValueString(), true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sha256, diags := plugin.GetFileSHA256(ctx, outputFile)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.FileSize = types.Int64Value(fileSize)
+	data.OutputFile = types.StringValue(outputFile)
+	data.SHA256 = types.StringValue(sha256)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// queryParamsFromFilter converts the TF filter block into API query parameters.
+func (d *AgentsExport) queryParamsFromFilter(ctx context.Context, filter tfAgentsExportFilter) (
+	api.AgentQueryParams, diag.Diagnostics) {
+	queryParams := api.AgentQueryParams{}
+
+	rawFilter, diags := rawFilterFromJSON(ctx, filter.FilterJSON)
+	if diags.HasError() {
+		return queryParams, diags
+	}
+	queryParams.RawFilter = rawFilter
+
+	if len(filter.AccountIds) > 0 {
+		queryParams.AccountIds = stringsFromList(filter.AccountIds)
+	}
+
+	if len(filter.ComputerNameContains) > 0 {
+		queryParams.ComputerNameContains = stringsFromList(filter.ComputerNameContains)
+	}
+
+	if len(filter.GroupIds) > 0 {
+		queryParams.GroupIds = stringsFromList(filter.GroupIds)
+	}
+
+	if !filter.IsActive.IsNull() && !filter.IsActive.IsUnknown() {
+		value := filter.IsActive.ValueBool()
+		queryParams.IsActive = &value
+	}
+
+	if len(filter.OSTypes) > 0 {
+		queryParams.OSTypes = stringsFromList(filter.OSTypes)
+	}
+
+	if len(filter.SiteIds) > 0 {
+		queryParams.SiteIds = stringsFromList(filter.SiteIds)
+	}
+	return queryParams, diags
+}
@@ -0,0 +1,39 @@
+package datasources
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tfChecksums defines the Terraform model for the hashes of a file.
+//
+// Hashes are grouped under a checksums attribute so that new hash types can be added without adding a top-level
+// attribute for each one. Any hash which the API does not return for the file is left empty.
+type tfChecksums struct {
+	SHA1   types.String `tfsdk:"sha1"`
+	SHA256 types.String `tfsdk:"sha256"`
+}
+
+// getChecksumsSchema returns the schema for the computed attribute holding the hashes of the given object's file
+// (eg: threat).
+func getChecksumsSchema(object string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description:         fmt.Sprintf("Hashes of the %s's file.", object),
+		MarkdownDescription: fmt.Sprintf("Hashes of the %s's file.", object),
+		Computed:            true,
+		Attributes: map[string]schema.Attribute{
+			"sha1": schema.StringAttribute{
+				Description:         fmt.Sprintf("SHA1 hash of the %s's file.", object),
+				MarkdownDescription: fmt.Sprintf("SHA1 hash of the %s's file.", object),
+				Computed:            true,
+			},
+			"sha256": schema.StringAttribute{
+				Description:         fmt.Sprintf("SHA256 hash of the %s's file.", object),
+				MarkdownDescription: fmt.Sprintf("SHA256 hash of the %s's file.", object),
+				Computed:            true,
+			},
+		},
+	}
+}
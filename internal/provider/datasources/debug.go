@@ -0,0 +1,35 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+)
+
+// getDebugSchema returns the schema for the computed attribute holding the debug response headers captured while
+// reading a data source.
+func getDebugSchema() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "The most recent value of each debug response header captured from the API server while " +
+			"reading the data source. Only populated when the provider's debug_response_headers attribute is set.",
+		MarkdownDescription: "The most recent value of each debug response header captured from the API server while " +
+			"reading the data source. Only populated when the provider's `debug_response_headers` attribute is set.",
+		Computed:    true,
+		ElementType: types.StringType,
+	}
+}
+
+// tfDebugFromRecorder converts the headers captured by the given recorder into a Terraform map.
+//
+// A null map is returned if no headers were captured.
+func tfDebugFromRecorder(ctx context.Context, recorder *api.ResponseHeaderRecorder) (types.Map, diag.Diagnostics) {
+	headers := recorder.Headers()
+	if len(headers) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+	return types.MapValueFrom(ctx, types.StringType, headers)
+}
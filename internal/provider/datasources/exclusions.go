@@ -0,0 +1,333 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// exclusionTypes holds every type of exclusion which can be managed by the singularity_exclusion resource.
+var exclusionTypes = []string{"browser", "certificate", "path", "white_hash"}
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &Exclusions{}
+	_ datasource.DataSourceWithConfigure = &Exclusions{}
+)
+
+// tfExclusions defines the Terraform model for the exclusions within a scope.
+type tfExclusions struct {
+	Debug      types.Map      `tfsdk:"debug"`
+	Exclusions []tfExclusion  `tfsdk:"exclusions"`
+	HCL        types.String   `tfsdk:"hcl"`
+	ScopeId    types.String   `tfsdk:"scope_id"`
+	ScopeType  types.String   `tfsdk:"scope_type"`
+	Types      []types.String `tfsdk:"types"`
+}
+
+// tfExclusion defines the Terraform model for an exclusion.
+//
+// The attribute names match those of the singularity_exclusion resource so that the objects can be used to
+// configure the resource directly.
+type tfExclusion struct {
+	Description       types.String `tfsdk:"description"`
+	Id                types.String `tfsdk:"id"`
+	ImportId          types.String `tfsdk:"import_id"`
+	Mode              types.String `tfsdk:"mode"`
+	OSType            types.String `tfsdk:"os_type"`
+	PathExclusionType types.String `tfsdk:"path_exclusion_type"`
+	ScopeId           types.String `tfsdk:"scope_id"`
+	ScopeType         types.String `tfsdk:"scope_type"`
+	Type              types.String `tfsdk:"type"`
+	Value             types.String `tfsdk:"value"`
+}
+
+// NewExclusions creates a new Exclusions object.
+func NewExclusions() datasource.DataSource {
+	return &Exclusions{}
+}
+
+// Exclusions is a data source used to get the exclusions defined directly within a scope.
+type Exclusions struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *Exclusions) Metadata(ctx context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_exclusions"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *Exclusions) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source is used for getting the exclusions defined within a scope in a form which " +
+			"can be used to configure singularity_exclusion resources.",
+		MarkdownDescription: `This data source is used for getting the exclusions defined within a scope in a form
+			which can be used to configure ` + "`singularity_exclusion`" + ` resources.
+
+		This is intended to help adopt exclusions-as-code: ` + "`hcl`" + ` contains a resource and import block
+		for every exclusion which can be copied into the configuration, while ` + "`exclusions`" + ` can be compared
+		with the configuration over time to detect exclusions added or changed in the console.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"debug": getDebugSchema(),
+			"exclusions": schema.ListNestedAttribute{
+				Description:         "List of exclusions defined within the scope, sorted by ID.",
+				MarkdownDescription: "List of exclusions defined within the scope, sorted by ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							Description:         "User-defined description of the exclusion.",
+							MarkdownDescription: "User-defined description of the exclusion.",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							Description:         "ID of the exclusion.",
+							MarkdownDescription: "ID of the exclusion.",
+							Computed:            true,
+						},
+						"import_id": schema.StringAttribute{
+							Description:         "ID used to import the exclusion into a singularity_exclusion resource.",
+							MarkdownDescription: "ID used to import the exclusion into a `singularity_exclusion` resource.",
+							Computed:            true,
+						},
+						"mode": schema.StringAttribute{
+							Description:         "Mode of the exclusion.",
+							MarkdownDescription: "Mode of the exclusion.",
+							Computed:            true,
+						},
+						"os_type": schema.StringAttribute{
+							Description:         "Operating system to which the exclusion applies.",
+							MarkdownDescription: "Operating system to which the exclusion applies.",
+							Computed:            true,
+						},
+						"path_exclusion_type": schema.StringAttribute{
+							Description:         "What a path exclusion applies to.",
+							MarkdownDescription: "What a path exclusion applies to.",
+							Computed:            true,
+						},
+						"scope_id": schema.StringAttribute{
+							Description:         "ID of the scope to which the exclusion is assigned.",
+							MarkdownDescription: "ID of the scope to which the exclusion is assigned.",
+							Computed:            true,
+						},
+						"scope_type": schema.StringAttribute{
+							Description:         "Type of scope to which the exclusion is assigned.",
+							MarkdownDescription: "Type of scope to which the exclusion is assigned.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							Description:         "Type of the exclusion.",
+							MarkdownDescription: "Type of the exclusion.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							Description:         "Value of the exclusion.",
+							MarkdownDescription: "Value of the exclusion.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"hcl": schema.StringAttribute{
+				Description: "HCL containing a singularity_exclusion resource block and matching import block for " +
+					"every exclusion.",
+				MarkdownDescription: "HCL containing a `singularity_exclusion` resource block and matching `import` " +
+					"block for every exclusion.",
+				Computed: true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the scope whose exclusions are returned.",
+				MarkdownDescription: "ID of the scope whose exclusions are returned.",
+				Required:            true,
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Type of scope whose exclusions are returned (eg: account, site, group).",
+				MarkdownDescription: "Type of scope whose exclusions are returned (eg: `account`, `site`, `group`).",
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"types": schema.ListAttribute{
+				Description: "Types of exclusions to return (eg: browser, certificate, path, white_hash). " +
+					"[Default: all types]",
+				MarkdownDescription: "Types of exclusions to return (eg: `browser`, `certificate`, `path`, " +
+					"`white_hash`). [Default: all types]",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, exclusionTypes...),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *Exclusions) Configure(ctx context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_EXCLUSIONS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *Exclusions) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfExclusions
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// capture any debug response headers returned by the queries below
+	ctx, recorder := api.RecordResponseHeaders(ctx)
+
+	// the API can only find exclusions of a single type at a time
+	selectedTypes := exclusionTypes
+	if data.Types != nil {
+		selectedTypes = []string{}
+		for _, t := range data.Types {
+			selectedTypes = append(selectedTypes, t.ValueString())
+		}
+	}
+	scopeType := data.ScopeType.ValueString()
+	scopeId := data.ScopeId.ValueString()
+	exclusions := []api.Exclusion{}
+	for _, exclusionType := range selectedTypes {
+		exclusionType := exclusionType
+		queryParams := api.ExclusionQueryParams{
+			Type: &exclusionType,
+		}
+		switch scopeType {
+		case api.SCOPE_ACCOUNT:
+			queryParams.AccountIds = []string{scopeId}
+		case api.SCOPE_SITE:
+			queryParams.SiteIds = []string{scopeId}
+		case api.SCOPE_GROUP:
+			queryParams.GroupIds = []string{scopeId}
+		}
+		found, diags := api.Client().FindExclusions(ctx, queryParams)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		exclusions = append(exclusions, found...)
+	}
+
+	// always sort results so their order is deterministic even if the API server changes its default ordering
+	sort.Slice(exclusions, func(i, j int) bool {
+		return exclusions[i].Id < exclusions[j].Id
+	})
+
+	// convert API objects into Terraform objects
+	data.Exclusions = []tfExclusion{}
+	var hcl strings.Builder
+	for _, exclusion := range exclusions {
+		data.Exclusions = append(data.Exclusions, tfExclusionFromAPI(ctx, &exclusion, scopeType, scopeId))
+		hcl.WriteString(exclusionHCL(&exclusion, scopeType, scopeId))
+	}
+	data.HCL = types.StringValue(hcl.String())
+	debug, diags := tfDebugFromRecorder(ctx, recorder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Debug = debug
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// exclusionHCL renders a singularity_exclusion resource block and matching import block for the given exclusion.
+//
+// Only attributes with values are rendered so the output matches what a user would typically write by hand.
+func exclusionHCL(exclusion *api.Exclusion, scopeType, scopeId string) string {
+	name := fmt.Sprintf("exclusion_%s", exclusion.Id)
+	var b strings.Builder
+	fmt.Fprintf(&b, "resource \"singularity_exclusion\" %q {\n", name)
+	attrs := []struct{ name, value string }{
+		{"scope_type", scopeType},
+		{"scope_id", scopeId},
+		{"type", exclusion.Type},
+		{"os_type", exclusion.OSType},
+		{"value", exclusion.Value},
+		{"mode", exclusion.Mode},
+		{"path_exclusion_type", exclusion.PathExclusionType},
+		{"description", exclusion.Description},
+	}
+	for _, a := range attrs {
+		if a.value != "" {
+			fmt.Fprintf(&b, "  %s = %s\n", a.name, hclString(a.value))
+		}
+	}
+	b.WriteString("}\n\n")
+	fmt.Fprintf(&b, "import {\n  to = singularity_exclusion.%s\n  id = %s\n}\n\n", name,
+		hclString(exclusionImportId(exclusion, scopeType, scopeId)))
+	return b.String()
+}
+
+// exclusionImportId returns the ID used to import the given exclusion into a singularity_exclusion resource.
+func exclusionImportId(exclusion *api.Exclusion, scopeType, scopeId string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", scopeType, scopeId, exclusion.Type, exclusion.Id)
+}
+
+// hclString returns the given value as a quoted HCL string, escaping template sequences so the value is used
+// literally.
+func hclString(value string) string {
+	quoted := strconv.Quote(value)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+// tfExclusionFromAPI converts an API exclusion into a Terraform exclusion.
+//
+// The API does not return the scope to which the exclusion was assigned so it is set from the given scope.
+func tfExclusionFromAPI(ctx context.Context, exclusion *api.Exclusion, scopeType, scopeId string) tfExclusion {
+	tfexclusion := tfExclusion{
+		Description:       types.StringValue(exclusion.Description),
+		Id:                types.StringValue(exclusion.Id),
+		ImportId:          types.StringValue(exclusionImportId(exclusion, scopeType, scopeId)),
+		Mode:              types.StringValue(exclusion.Mode),
+		OSType:            types.StringValue(exclusion.OSType),
+		PathExclusionType: types.StringValue(exclusion.PathExclusionType),
+		ScopeId:           types.StringValue(scopeId),
+		ScopeType:         types.StringValue(scopeType),
+		Type:              types.StringValue(exclusion.Type),
+		Value:             types.StringValue(exclusion.Value),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API exclusion to TF exclusion: %+v", tfexclusion))
+	return tfexclusion
+}
@@ -0,0 +1,60 @@
+package datasources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// getFilterJSONSchema returns the schema for the attribute holding additional API filters which the provider does
+// not have a typed attribute for.
+func getFilterJSONSchema() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "JSON encoded object of additional API filters, keyed by API parameter name (eg: " +
+			"jsonencode({ agentVersions = [\"23.1.2.400\"] })). Lists are joined with commas. Any filter with a " +
+			"typed attribute should be given using that attribute, which takes precedence over the same filter here.",
+		MarkdownDescription: "JSON encoded object of additional API filters, keyed by API parameter name (eg: " +
+			"`jsonencode({ agentVersions = [\"23.1.2.400\"] })`). Lists are joined with commas. Any filter with a " +
+			"typed attribute should be given using that attribute, which takes precedence over the same filter here.",
+		Optional: true,
+		Validators: []validator.String{
+			validators.JSONObjectIsValid(),
+		},
+	}
+}
+
+// rawFilterFromJSON decodes the given filter_json value into a raw filter for the API.
+//
+// Numbers are decoded as json.Number so that large IDs are passed through exactly. A nil filter is returned if the
+// value is null or unknown.
+func rawFilterFromJSON(ctx context.Context, value types.String) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return nil, diags
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(value.ValueString())))
+	decoder.UseNumber()
+	var filter map[string]interface{}
+	if err := decoder.Decode(&filter); err != nil {
+		msg := fmt.Sprintf("The filter_json attribute could not be decoded into a JSON object.\n\nError: %s",
+			err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_DATASOURCE_FILTER_JSON,
+		})
+		diags.AddError("Invalid Filter", msg)
+		return nil, diags
+	}
+	return filter, diags
+}
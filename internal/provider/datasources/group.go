@@ -222,6 +222,7 @@ func tfGroupFromAPI(ctx context.Context, group *api.Group) tfGroup {
 		Type:              types.StringValue(group.Type),
 		UpdatedAt:         types.StringValue(group.UpdatedAt),
 	}
+	ctx = plugin.MaskSecrets(ctx, group.RegistrationToken)
 	tflog.Debug(ctx, fmt.Sprintf("converted API group to TF group: %+v", tfgroup), map[string]interface{}{
 		"api_group": group,
 	})
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -24,6 +26,7 @@ var (
 
 // tfGroups defines the Terraform model for groups.
 type tfGroups struct {
+	Debug  types.Map       `tfsdk:"debug"`
 	Groups []tfGroup       `tfsdk:"groups"`
 	Filter *tfGroupsFilter `tfsdk:"filter"`
 }
@@ -72,6 +75,7 @@ func (d *Groups) Schema(ctx context.Context, req datasource.SchemaRequest, resp
 		TODO: add more of a description on how to use this data source...
 		`,
 		Attributes: map[string]schema.Attribute{
+			"debug": getDebugSchema(),
 			"groups": schema.ListNestedAttribute{
 				Description:         "List of matching groups that were found.",
 				MarkdownDescription: "List of matching groups that were found.",
@@ -136,9 +140,9 @@ func (d *Groups) Schema(ctx context.Context, req datasource.SchemaRequest, resp
 					},
 					"sort_by": schema.StringAttribute{
 						Description: "Field on which to sort results (valid values: createdAt, description, id, inherits, " +
-							"name, rank, type, updatedAt).",
+							"name, rank, type, updatedAt) [Default: id].",
 						MarkdownDescription: "Field on which to sort results (valid values: `createdAt`, `description`, " +
-							"`id`, `inherits`, `name`, `rank`, `type`, `updatedAt`).",
+							"`id`, `inherits`, `name`, `rank`, `type`, `updatedAt`) [Default: `id`].",
 						Optional: true,
 						Validators: []validator.String{
 							validators.EnumStringValueOneOf(false,
@@ -147,8 +151,8 @@ func (d *Groups) Schema(ctx context.Context, req datasource.SchemaRequest, resp
 						},
 					},
 					"sort_order": schema.StringAttribute{
-						Description:         "Order in which to sort results (valid values: asc, desc).",
-						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`).",
+						Description:         "Order in which to sort results (valid values: asc, desc) [Default: asc].",
+						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`) [Default: `asc`].",
 						Optional:            true,
 						Validators: []validator.String{
 							validators.EnumStringValueOneOf(false,
@@ -168,24 +172,47 @@ func (d *Groups) Schema(ctx context.Context, req datasource.SchemaRequest, resp
 						},
 					},
 					"updated_after": schema.StringAttribute{
-						Description:         "Group was updated after the given timestamp (eg: 2023-01-01T00:00:00Z).",
-						MarkdownDescription: "Group was updated after the given timestamp (eg: `2023-01-01T00:00:00Z`).",
-						Optional:            true,
+						Description: "Group was updated after the given timestamp (eg: 2023-01-01T00:00:00Z) or relative " +
+							"timestamp (eg: -24h, -7d).",
+						MarkdownDescription: "Group was updated after the given timestamp (eg: `2023-01-01T00:00:00Z`) or " +
+							"relative timestamp (eg: `-24h`, `-7d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+							validators.TimestampIsBefore(false, "updated_at_or_before", "updated_before"),
+						},
 					},
 					"updated_at_or_after": schema.StringAttribute{
-						Description:         "Group was updated at or after the given timestamp (eg: 2023-01-01T00:00:00Z).",
-						MarkdownDescription: "Group was updated at or after the given timestamp (eg: `2023-01-01T00:00:00Z`).",
-						Optional:            true,
+						Description: "Group was updated at or after the given timestamp (eg: 2023-01-01T00:00:00Z) or relative " +
+							"timestamp (eg: -24h, -7d).",
+						MarkdownDescription: "Group was updated at or after the given timestamp (eg: `2023-01-01T00:00:00Z`) or " +
+							"relative timestamp (eg: `-24h`, `-7d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+							validators.TimestampIsBefore(true, "updated_at_or_before"),
+							validators.TimestampIsBefore(false, "updated_before"),
+						},
 					},
 					"updated_at_or_before": schema.StringAttribute{
-						Description:         "Group was updated at or before the given timestamp (eg: 2023-01-01T00:00:00Z).",
-						MarkdownDescription: "Group was updated at or before the given timestamp (eg: `2023-01-01T00:00:00Z`).",
-						Optional:            true,
+						Description: "Group was updated at or before the given timestamp (eg: 2023-01-01T00:00:00Z) or relative " +
+							"timestamp (eg: -24h, -7d).",
+						MarkdownDescription: "Group was updated at or before the given timestamp (eg: `2023-01-01T00:00:00Z`) or " +
+							"relative timestamp (eg: `-24h`, `-7d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+						},
 					},
 					"updated_before": schema.StringAttribute{
-						Description:         "Group was updated before the given timestamp (eg: 2023-01-01T00:00:00Z).",
-						MarkdownDescription: "Group was updated before the given timestamp (eg: `2023-01-01T00:00:00Z`).",
-						Optional:            true,
+						Description: "Group was updated before the given timestamp (eg: 2023-01-01T00:00:00Z) or relative " +
+							"timestamp (eg: -24h, -7d).",
+						MarkdownDescription: "Group was updated before the given timestamp (eg: `2023-01-01T00:00:00Z`) or " +
+							"relative timestamp (eg: `-24h`, `-7d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+						},
 					},
 				},
 			},
@@ -226,12 +253,23 @@ func (d *Groups) Read(ctx context.Context, req datasource.ReadRequest, resp *dat
 		return
 	}
 
+	// capture any debug response headers returned by the queries below
+	ctx, recorder := api.RecordResponseHeaders(ctx)
+
 	// construct query parameters
 	queryParams := api.GroupQueryParams{}
 	if data.Filter != nil {
-		queryParams = d.queryParamsFromFilter(*data.Filter)
+		var diags diag.Diagnostics
+		queryParams, diags = d.queryParamsFromFilter(ctx, *data.Filter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
+	// always sort results so their order is deterministic even if the API server changes its default ordering
+	queryParams.SortBy, queryParams.SortOrder = sortOrDefault(queryParams.SortBy, queryParams.SortOrder)
+
 	// find the matching groups
 	groups, diags := api.Client().FindGroups(ctx, queryParams)
 	resp.Diagnostics.Append(diags...)
@@ -247,13 +285,24 @@ func (d *Groups) Read(ctx context.Context, req datasource.ReadRequest, resp *dat
 	for _, group := range groups {
 		tfgroups.Groups = append(tfgroups.Groups, tfGroupFromAPI(ctx, &group))
 	}
+	debug, diags := tfDebugFromRecorder(ctx, recorder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tfgroups.Debug = debug
 	resp.Diagnostics.Append(resp.State.Set(ctx, tfgroups)...)
 }
 
 // queryParamsFromFilter converts the TF filter block into API query parameters.
-func (d *Groups) queryParamsFromFilter(filter tfGroupsFilter) api.GroupQueryParams {
+func (d *Groups) queryParamsFromFilter(ctx context.Context, filter tfGroupsFilter) (api.GroupQueryParams,
+	diag.Diagnostics) {
+	var diags diag.Diagnostics
 	queryParams := api.GroupQueryParams{}
 
+	// relative timestamps are resolved against the time at which the data source is read
+	now := time.Now()
+
 	if len(filter.AccountIds) > 0 {
 		queryParams.AccountIds = []string{}
 		for _, e := range filter.AccountIds {
@@ -331,23 +380,35 @@ func (d *Groups) queryParamsFromFilter(filter tfGroupsFilter) api.GroupQueryPara
 	}
 
 	if !filter.UpdatedAfter.IsNull() && !filter.UpdatedAfter.IsUnknown() {
-		value := filter.UpdatedAfter.ValueString()
+		value, diags := plugin.ResolveTimestamp(ctx, filter.UpdatedAfter.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
 		queryParams.UpdatedAfter = &value
 	}
 
 	if !filter.UpdatedAtOrAfter.IsNull() && !filter.UpdatedAtOrAfter.IsUnknown() {
-		value := filter.UpdatedAtOrAfter.ValueString()
+		value, diags := plugin.ResolveTimestamp(ctx, filter.UpdatedAtOrAfter.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
 		queryParams.UpdatedAtOrAfter = &value
 	}
 
 	if !filter.UpdatedAtOrBefore.IsNull() && !filter.UpdatedAtOrBefore.IsUnknown() {
-		value := filter.UpdatedAtOrBefore.ValueString()
+		value, diags := plugin.ResolveTimestamp(ctx, filter.UpdatedAtOrBefore.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
 		queryParams.UpdatedAtOrBefore = &value
 	}
 
 	if !filter.UpdatedBefore.IsNull() && !filter.UpdatedBefore.IsUnknown() {
-		value := filter.UpdatedBefore.ValueString()
+		value, diags := plugin.ResolveTimestamp(ctx, filter.UpdatedBefore.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
 		queryParams.UpdatedBefore = &value
 	}
-	return queryParams
+	return queryParams, diags
 }
@@ -0,0 +1,213 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &GroupsWithSites{}
+	_ datasource.DataSourceWithConfigure = &GroupsWithSites{}
+)
+
+// tfGroupsWithSites defines the Terraform model for groups joined with their parent sites.
+type tfGroupsWithSites struct {
+	Debug  types.Map         `tfsdk:"debug"`
+	Groups []tfGroupWithSite `tfsdk:"groups"`
+	Filter *tfGroupsFilter   `tfsdk:"filter"`
+}
+
+// tfGroupWithSite defines the Terraform model for a group joined with its parent site.
+type tfGroupWithSite struct {
+	AccountId   types.String `tfsdk:"account_id"`
+	AccountName types.String `tfsdk:"account_name"`
+	Group       tfGroup      `tfsdk:"group"`
+	SiteName    types.String `tfsdk:"site_name"`
+}
+
+// NewGroupsWithSites creates a new GroupsWithSites object.
+func NewGroupsWithSites() datasource.DataSource {
+	return &GroupsWithSites{}
+}
+
+// GroupsWithSites is a data source used to store details about groups along with their parent sites.
+type GroupsWithSites struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *GroupsWithSites) Metadata(ctx context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups_with_sites"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *GroupsWithSites) Schema(ctx context.Context, req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+
+	// the filter is identical to the one used by the groups data source
+	groupsResp := datasource.SchemaResponse{}
+	(&Groups{}).Schema(ctx, req, &groupsResp)
+
+	resp.Schema = schema.Schema{
+		Description: "This data source can be used for getting a list of groups based on filters along with the " +
+			"name of the site and account to which each group belongs.",
+		MarkdownDescription: `This data source can be used for getting a list of groups based on filters along with
+		the name of the site and account to which each group belongs.
+
+		The filter is identical to the one used by the ` + "`singularity_groups`" + ` data source. Parent sites are
+		looked up with a single query rather than once per group, which makes this data source well-suited to
+		rendering reports of the group layout.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"debug": getDebugSchema(),
+			"groups": schema.ListNestedAttribute{
+				Description:         "List of matching groups that were found.",
+				MarkdownDescription: "List of matching groups that were found.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account_id": schema.StringAttribute{
+							Description:         "ID of the account to which the group's site belongs.",
+							MarkdownDescription: "ID of the account to which the group's site belongs.",
+							Computed:            true,
+						},
+						"account_name": schema.StringAttribute{
+							Description:         "Name of the account to which the group's site belongs.",
+							MarkdownDescription: "Name of the account to which the group's site belongs.",
+							Computed:            true,
+						},
+						"group": schema.SingleNestedAttribute{
+							Description:         "Details of the group.",
+							MarkdownDescription: "Details of the group.",
+							Computed:            true,
+							Attributes:          getGroupSchema(ctx).Attributes,
+						},
+						"site_name": schema.StringAttribute{
+							Description:         "Name of the site to which the group belongs.",
+							MarkdownDescription: "Name of the site to which the group belongs.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": groupsResp.Schema.Blocks["filter"],
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *GroupsWithSites) Configure(ctx context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_GROUPS_WITH_SITES_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *GroupsWithSites) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfGroupsWithSites
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// capture any debug response headers returned by the queries below
+	ctx, recorder := api.RecordResponseHeaders(ctx)
+
+	// construct query parameters
+	queryParams := api.GroupQueryParams{}
+	if data.Filter != nil {
+		var diags diag.Diagnostics
+		queryParams, diags = (&Groups{}).queryParamsFromFilter(ctx, *data.Filter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// always sort results so their order is deterministic even if the API server changes its default ordering
+	queryParams.SortBy, queryParams.SortOrder = sortOrDefault(queryParams.SortBy, queryParams.SortOrder)
+
+	// find the matching groups
+	groups, diags := api.Client().FindGroups(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// look up all of the parent sites in a single query
+	sites := map[string]api.Site{}
+	siteIds := []string{}
+	for _, group := range groups {
+		if _, ok := sites[group.SiteId]; !ok {
+			sites[group.SiteId] = api.Site{}
+			siteIds = append(siteIds, group.SiteId)
+		}
+	}
+	if len(siteIds) > 0 {
+		found, diags := api.Client().FindSites(ctx, api.SiteQueryParams{
+			SiteIds: siteIds,
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, site := range found {
+			sites[site.Id] = site
+		}
+	}
+
+	// convert API objects into Terraform objects
+	tfgroups := tfGroupsWithSites{
+		Filter: data.Filter,
+		Groups: []tfGroupWithSite{},
+	}
+	for _, group := range groups {
+		site := sites[group.SiteId]
+		tfgroups.Groups = append(tfgroups.Groups, tfGroupWithSite{
+			AccountId:   types.StringValue(site.AccountId),
+			AccountName: types.StringValue(site.AccountName),
+			Group:       tfGroupFromAPI(ctx, &group),
+			SiteName:    types.StringValue(site.Name),
+		})
+	}
+	debug, diags := tfDebugFromRecorder(ctx, recorder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tfgroups.Debug = debug
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfgroups)...)
+}
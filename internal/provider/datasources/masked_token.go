@@ -0,0 +1,77 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource = &MaskedToken{}
+)
+
+// tfMaskedToken defines the Terraform model for a masked token.
+type tfMaskedToken struct {
+	Masked types.String `tfsdk:"masked"`
+	Value  types.String `tfsdk:"value"`
+}
+
+// NewMaskedToken creates a new MaskedToken object.
+func NewMaskedToken() datasource.DataSource {
+	return &MaskedToken{}
+}
+
+// MaskedToken is a data source used to produce a partially masked representation of a token.
+type MaskedToken struct{}
+
+// Metadata returns metadata about the data source.
+func (d *MaskedToken) Metadata(ctx context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_masked_token"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *MaskedToken) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source is used for producing a partially masked representation of a token which " +
+			"is safe to display in outputs and reports.",
+		MarkdownDescription: `This data source is used for producing a partially masked representation of a token
+			which is safe to display in outputs and reports.
+
+		Only the first and last 4 characters of the token are kept in ` + "`masked`" + `. Tokens of 12 characters or
+		fewer are completely masked. The token itself remains sensitive.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"masked": schema.StringAttribute{
+				Description:         "Partially masked representation of the token.",
+				MarkdownDescription: "Partially masked representation of the token.",
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				Description:         "The token to mask.",
+				MarkdownDescription: "The token to mask.",
+				Required:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Read masks the configured token.
+func (d *MaskedToken) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfMaskedToken
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Masked = types.StringValue(plugin.MaskToken(data.Value.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
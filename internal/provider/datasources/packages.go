@@ -24,6 +24,7 @@ var (
 
 // tfPackages defines the Terraform model for packages.
 type tfPackages struct {
+	Debug    types.Map         `tfsdk:"debug"`
 	Packages []tfPackage       `tfsdk:"packages"`
 	Filter   *tfPackagesFilter `tfsdk:"filter"`
 }
@@ -72,6 +73,7 @@ func (d *Packages) Schema(ctx context.Context, req datasource.SchemaRequest, res
 		TODO: add more of a description on how to use this data source...
 		`,
 		Attributes: map[string]schema.Attribute{
+			"debug": getDebugSchema(),
 			"packages": schema.ListNestedAttribute{
 				Description:         "List of matching packages that were found.",
 				MarkdownDescription: "List of matching packages that were found.",
@@ -190,10 +192,11 @@ func (d *Packages) Schema(ctx context.Context, req datasource.SchemaRequest, res
 					"sort_by": schema.StringAttribute{
 						Description: "Field on which to sort results (valid values: createdAt, fileExtension, fileName, " +
 							"fileSize, id, majorVersion, minorVersion, osType, packageType, platformType, rangerVersion, " +
-							"scopeLevel, sha1, status, updatedAt, version).",
+							"scopeLevel, sha1, status, updatedAt, version) [Default: id].",
 						MarkdownDescription: "Field on which to sort results (valid values: `createdAt`, `fileExtension`, " +
 							"`fileName`, `fileSize`, `id`, `majorVersion`, `minorVersion`, `osType`, `packageType`, " +
-							"`platformType`, `rangerVersion`, `scopeLevel`, `sha1`, `status`, `updatedAt`, `version`).",
+							"`platformType`, `rangerVersion`, `scopeLevel`, `sha1`, `status`, `updatedAt`, `version`) " +
+							"[Default: `id`].",
 						Optional: true,
 						Validators: []validator.String{
 							validators.EnumStringValueOneOf(false,
@@ -204,8 +207,8 @@ func (d *Packages) Schema(ctx context.Context, req datasource.SchemaRequest, res
 						},
 					},
 					"sort_order": schema.StringAttribute{
-						Description:         "Order in which to sort results (valid values: asc, desc).",
-						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`).",
+						Description:         "Order in which to sort results (valid values: asc, desc) [Default: asc].",
+						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`) [Default: `asc`].",
 						Optional:            true,
 						Validators: []validator.String{
 							validators.EnumStringValueOneOf(false,
@@ -268,12 +271,18 @@ func (d *Packages) Read(ctx context.Context, req datasource.ReadRequest, resp *d
 		return
 	}
 
+	// capture any debug response headers returned by the queries below
+	ctx, recorder := api.RecordResponseHeaders(ctx)
+
 	// construct query parameters
 	queryParams := api.PackageQueryParams{}
 	if data.Filter != nil {
 		queryParams = d.queryParamsFromFilter(*data.Filter)
 	}
 
+	// always sort results so their order is deterministic even if the API server changes its default ordering
+	queryParams.SortBy, queryParams.SortOrder = sortOrDefault(queryParams.SortBy, queryParams.SortOrder)
+
 	// find the matching packages
 	pkgs, diags := api.Client().FindPackages(ctx, queryParams)
 	resp.Diagnostics.Append(diags...)
@@ -289,6 +298,12 @@ func (d *Packages) Read(ctx context.Context, req datasource.ReadRequest, resp *d
 	for _, pkg := range pkgs {
 		tfpkgs.Packages = append(tfpkgs.Packages, tfPackageFromAPI(ctx, &pkg))
 	}
+	debug, diags := tfDebugFromRecorder(ctx, recorder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tfpkgs.Debug = debug
 	resp.Diagnostics.Append(resp.State.Set(ctx, tfpkgs)...)
 }
 
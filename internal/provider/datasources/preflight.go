@@ -0,0 +1,418 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// preflight check names
+const (
+	PREFLIGHT_CHECK_API_VERSION = "api_version"
+	PREFLIGHT_CHECK_DOCKER_HOST = "docker_host"
+	PREFLIGHT_CHECK_ENDPOINT    = "endpoint"
+	PREFLIGHT_CHECK_TOKEN_SCOPE = "token_scope"
+)
+
+// preflightDefaultDockerHost is the Docker host checked when none is given.
+const preflightDefaultDockerHost = "unix:///var/run/docker.sock"
+
+// preflightScopeLevels ranks the scope levels of an API token from the most to the least privileged.
+var preflightScopeLevels = map[string]int{
+	api.SCOPE_TENANT:  0,
+	api.SCOPE_ACCOUNT: 1,
+	api.SCOPE_SITE:    2,
+}
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &Preflight{}
+	_ datasource.DataSourceWithConfigure = &Preflight{}
+)
+
+// tfPreflight defines the Terraform model for a preflight report.
+type tfPreflight struct {
+	CheckDocker       types.Bool         `tfsdk:"check_docker"`
+	Checks            []tfPreflightCheck `tfsdk:"checks"`
+	ConsoleBuild      types.String       `tfsdk:"console_build"`
+	ConsoleRelease    types.String       `tfsdk:"console_release"`
+	DockerCertPath    types.String       `tfsdk:"docker_cert_path"`
+	DockerHost        types.String       `tfsdk:"docker_host"`
+	FailOnError       types.Bool         `tfsdk:"fail_on_error"`
+	MinConsoleRelease types.String       `tfsdk:"min_console_release"`
+	Passed            types.Bool         `tfsdk:"passed"`
+	RequiredScope     types.String       `tfsdk:"required_scope"`
+	TokenScope        types.String       `tfsdk:"token_scope"`
+}
+
+// tfPreflightCheck defines the Terraform model for the result of a single preflight check.
+type tfPreflightCheck struct {
+	Message types.String `tfsdk:"message"`
+	Name    types.String `tfsdk:"name"`
+	Passed  types.Bool   `tfsdk:"passed"`
+}
+
+// NewPreflight creates a new Preflight object.
+func NewPreflight() datasource.DataSource {
+	return &Preflight{}
+}
+
+// Preflight is a data source used to validate the prerequisites of a configuration before any other objects are
+// managed.
+type Preflight struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *Preflight) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_preflight"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *Preflight) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source is used for validating that the prerequisites of a configuration are met " +
+			"before any other objects are managed.",
+		MarkdownDescription: `This data source is used for validating that the prerequisites of a configuration are
+			met before any other objects are managed.
+
+		The following checks are performed and reported in ` + "`checks`" + `:
+
+		* ` + "`endpoint`" + ` - the API endpoint is reachable and healthy.
+		* ` + "`token_scope`" + ` - the API token is valid and, if ` + "`required_scope`" + ` is set, is scoped
+		  at that level or higher.
+		* ` + "`api_version`" + ` - the console supports the version of the API used by the provider and, if
+		  ` + "`min_console_release`" + ` is set, is running at least that release.
+		* ` + "`docker_host`" + ` - if ` + "`check_docker`" + ` is set, the Docker host used by loader resources is
+		  reachable.
+
+		Unless ` + "`fail_on_error`" + ` is false, reading the data source fails when any check fails, so making
+		other resources depend on it stops a large configuration early when a prerequisite is missing.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"check_docker": schema.BoolAttribute{
+				Description:         "Whether or not to check that the Docker host is reachable. [Default: false]",
+				MarkdownDescription: "Whether or not to check that the Docker host is reachable. [Default: `false`]",
+				Optional:            true,
+			},
+			"checks": schema.ListNestedAttribute{
+				Description:         "Results of the checks which were performed.",
+				MarkdownDescription: "Results of the checks which were performed.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"message": schema.StringAttribute{
+							Description:         "Details on the result of the check.",
+							MarkdownDescription: "Details on the result of the check.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "Name of the check.",
+							MarkdownDescription: "Name of the check.",
+							Computed:            true,
+						},
+						"passed": schema.BoolAttribute{
+							Description:         "Whether or not the check passed.",
+							MarkdownDescription: "Whether or not the check passed.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"console_build": schema.StringAttribute{
+				Description:         "Build number of the management console.",
+				MarkdownDescription: "Build number of the management console.",
+				Computed:            true,
+			},
+			"console_release": schema.StringAttribute{
+				Description:         "Release of the management console.",
+				MarkdownDescription: "Release of the management console.",
+				Computed:            true,
+			},
+			"docker_cert_path": schema.StringAttribute{
+				Description: "If a TLS connection to the Docker host is enabled, the full path in which to find the " +
+					"CA certificate and client certificate and key used to connect to the host. [Default: none]",
+				MarkdownDescription: "If a TLS connection to the Docker host is enabled, the full path in which to find " +
+					"the CA certificate and client certificate and key used to connect to the host. [Default: none]",
+				Optional: true,
+			},
+			"docker_host": schema.StringAttribute{
+				Description:         "The URL of the Docker host to check. [Default: unix:///var/run/docker.sock]",
+				MarkdownDescription: "The URL of the Docker host to check. [Default: `unix:///var/run/docker.sock`]",
+				Optional:            true,
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Description:         "Whether or not reading the data source fails if any check fails. [Default: true]",
+				MarkdownDescription: "Whether or not reading the data source fails if any check fails. [Default: `true`]",
+				Optional:            true,
+			},
+			"min_console_release": schema.StringAttribute{
+				Description:         "Minimum release of the management console required (eg: 23.1). [Default: none]",
+				MarkdownDescription: "Minimum release of the management console required (eg: `23.1`). [Default: none]",
+				Optional:            true,
+			},
+			"passed": schema.BoolAttribute{
+				Description:         "Whether or not all of the checks passed.",
+				MarkdownDescription: "Whether or not all of the checks passed.",
+				Computed:            true,
+			},
+			"required_scope": schema.StringAttribute{
+				Description: "Lowest scope level the API token must have (valid values: tenant, account, site). " +
+					"[Default: none]",
+				MarkdownDescription: "Lowest scope level the API token must have (valid values: `tenant`, `account`, " +
+					"`site`). [Default: none]",
+				Optional: true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_TENANT, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"token_scope": schema.StringAttribute{
+				Description:         "Scope level of the API token.",
+				MarkdownDescription: "Scope level of the API token.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *Preflight) Configure(ctx context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_PREFLIGHT_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *Preflight) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfPreflight
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the remaining API checks are meaningless if the endpoint cannot be reached
+	data.Checks = []tfPreflightCheck{}
+	data.ConsoleBuild = types.StringNull()
+	data.ConsoleRelease = types.StringNull()
+	data.TokenScope = types.StringNull()
+	endpoint := d.checkEndpoint(ctx)
+	data.Checks = append(data.Checks, endpoint)
+	if endpoint.Passed.ValueBool() {
+		data.Checks = append(data.Checks, d.checkTokenScope(ctx, &data), d.checkApiVersion(ctx, &data))
+	} else {
+		skipped := types.StringValue(fmt.Sprintf("Skipped because the %s check failed.", PREFLIGHT_CHECK_ENDPOINT))
+		data.Checks = append(data.Checks,
+			tfPreflightCheck{Message: skipped, Name: types.StringValue(PREFLIGHT_CHECK_TOKEN_SCOPE),
+				Passed: types.BoolValue(false)},
+			tfPreflightCheck{Message: skipped, Name: types.StringValue(PREFLIGHT_CHECK_API_VERSION),
+				Passed: types.BoolValue(false)},
+		)
+	}
+	if !data.CheckDocker.IsNull() && data.CheckDocker.ValueBool() {
+		data.Checks = append(data.Checks, d.checkDockerHost(ctx, data))
+	}
+
+	// summarize the results
+	failed := []string{}
+	for _, c := range data.Checks {
+		if !c.Passed.ValueBool() {
+			failed = append(failed, fmt.Sprintf("%s: %s", c.Name.ValueString(), c.Message.ValueString()))
+		}
+	}
+	data.Passed = types.BoolValue(len(failed) == 0)
+	if len(failed) > 0 && (data.FailOnError.IsNull() || data.FailOnError.ValueBool()) {
+		msg := fmt.Sprintf("One or more preflight checks failed.\n\n%s", strings.Join(failed, "\n"))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_PREFLIGHT_READ,
+		})
+		resp.Diagnostics.AddError("Preflight Check Failed", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// checkApiVersion checks that the console supports the API version used by the provider and, if required, is
+// running at least the minimum release.
+func (d *Preflight) checkApiVersion(ctx context.Context, data *tfPreflight) tfPreflightCheck {
+	check := tfPreflightCheck{
+		Name:   types.StringValue(PREFLIGHT_CHECK_API_VERSION),
+		Passed: types.BoolValue(false),
+	}
+	info, diags := api.Client().GetSystemInfo(ctx)
+	if diags.HasError() {
+		check.Message = types.StringValue(fmt.Sprintf("The console does not support the API at %s.\n\n%s",
+			api.API_BASE_URI, preflightDiagsMessage(diags)))
+		return check
+	}
+	data.ConsoleBuild = types.StringValue(info.Build)
+	data.ConsoleRelease = types.StringValue(info.Release)
+
+	if !data.MinConsoleRelease.IsNull() && data.MinConsoleRelease.ValueString() != "" {
+		minRelease := data.MinConsoleRelease.ValueString()
+		cmp, ok := compareReleases(info.Release, minRelease)
+		if !ok {
+			check.Message = types.StringValue(fmt.Sprintf("Unable to compare console release %s with the minimum "+
+				"release %s.", info.Release, minRelease))
+			return check
+		}
+		if cmp < 0 {
+			check.Message = types.StringValue(fmt.Sprintf("Console release %s is older than the minimum release %s.",
+				info.Release, minRelease))
+			return check
+		}
+	}
+	check.Message = types.StringValue(fmt.Sprintf("Console release %s (build %s) supports the API at %s.",
+		info.Release, info.Build, api.API_BASE_URI))
+	check.Passed = types.BoolValue(true)
+	return check
+}
+
+// checkDockerHost checks that the Docker host used by loader resources is reachable.
+func (d *Preflight) checkDockerHost(ctx context.Context, data tfPreflight) tfPreflightCheck {
+	check := tfPreflightCheck{
+		Name:   types.StringValue(PREFLIGHT_CHECK_DOCKER_HOST),
+		Passed: types.BoolValue(false),
+	}
+	host := preflightDefaultDockerHost
+	if !data.DockerHost.IsNull() && data.DockerHost.ValueString() != "" {
+		host = data.DockerHost.ValueString()
+	}
+	opts := []client.Opt{client.WithHost(host), client.WithAPIVersionNegotiation()}
+	if !data.DockerCertPath.IsNull() && data.DockerCertPath.ValueString() != "" {
+		certPath := data.DockerCertPath.ValueString()
+		opts = append(opts, client.WithTLSClientConfig(filepath.Join(certPath, "ca.pem"),
+			filepath.Join(certPath, "cert.pem"), filepath.Join(certPath, "key.pem")))
+	}
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		check.Message = types.StringValue(fmt.Sprintf("Unable to connect to Docker host %s: %s", host, err.Error()))
+		return check
+	}
+	defer cli.Close()
+	ping, err := cli.Ping(ctx)
+	if err != nil {
+		check.Message = types.StringValue(fmt.Sprintf("Unable to connect to Docker host %s: %s", host, err.Error()))
+		return check
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Docker ping response: %+v", ping))
+	check.Message = types.StringValue(fmt.Sprintf("Docker host %s is reachable (API version %s).", host,
+		ping.APIVersion))
+	check.Passed = types.BoolValue(true)
+	return check
+}
+
+// checkEndpoint checks that the API endpoint is reachable and healthy.
+func (d *Preflight) checkEndpoint(ctx context.Context) tfPreflightCheck {
+	check := tfPreflightCheck{
+		Name:   types.StringValue(PREFLIGHT_CHECK_ENDPOINT),
+		Passed: types.BoolValue(false),
+	}
+	if diags := api.Client().GetSystemStatus(ctx); diags.HasError() {
+		check.Message = types.StringValue(fmt.Sprintf("The API endpoint is not reachable or not healthy.\n\n%s",
+			preflightDiagsMessage(diags)))
+		return check
+	}
+	check.Message = types.StringValue("The API endpoint is reachable and healthy.")
+	check.Passed = types.BoolValue(true)
+	return check
+}
+
+// checkTokenScope checks that the API token is valid and, if required, has at least the required scope.
+func (d *Preflight) checkTokenScope(ctx context.Context, data *tfPreflight) tfPreflightCheck {
+	check := tfPreflightCheck{
+		Name:   types.StringValue(PREFLIGHT_CHECK_TOKEN_SCOPE),
+		Passed: types.BoolValue(false),
+	}
+	user, diags := api.Client().GetCurrentUser(ctx)
+	if diags.HasError() {
+		check.Message = types.StringValue(fmt.Sprintf("The API token is not valid.\n\n%s",
+			preflightDiagsMessage(diags)))
+		return check
+	}
+	data.TokenScope = types.StringValue(user.Scope)
+
+	if !data.RequiredScope.IsNull() && data.RequiredScope.ValueString() != "" {
+		required := strings.ToLower(data.RequiredScope.ValueString())
+		level, ok := preflightScopeLevels[strings.ToLower(user.Scope)]
+		if !ok || level > preflightScopeLevels[required] {
+			check.Message = types.StringValue(fmt.Sprintf("The API token has %s scope but %s scope is required.",
+				user.Scope, required))
+			return check
+		}
+	}
+	check.Message = types.StringValue(fmt.Sprintf("The API token is valid and has %s scope.", user.Scope))
+	check.Passed = types.BoolValue(true)
+	return check
+}
+
+// compareReleases compares two dotted release numbers (eg: 23.1.2) and returns -1, 0 or 1 if the first release is
+// older than, the same as or newer than the second. The second value returned is false if either release could not
+// be parsed.
+func compareReleases(a, b string) (int, bool) {
+	partsA := strings.Split(strings.TrimSpace(a), ".")
+	partsB := strings.Split(strings.TrimSpace(b), ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		var err error
+		if i < len(partsA) {
+			if numA, err = strconv.Atoi(partsA[i]); err != nil {
+				return 0, false
+			}
+		}
+		if i < len(partsB) {
+			if numB, err = strconv.Atoi(partsB[i]); err != nil {
+				return 0, false
+			}
+		}
+		if numA < numB {
+			return -1, true
+		}
+		if numA > numB {
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// preflightDiagsMessage flattens the errors in the given diagnostics into a single message.
+func preflightDiagsMessage(diags diag.Diagnostics) string {
+	messages := []string{}
+	for _, d := range diags.Errors() {
+		messages = append(messages, fmt.Sprintf("%s: %s", d.Summary(), d.Detail()))
+	}
+	return strings.Join(messages, "\n")
+}
@@ -0,0 +1,179 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &ProviderInfo{}
+	_ datasource.DataSourceWithConfigure = &ProviderInfo{}
+)
+
+// tfProviderInfo defines the Terraform model for the provider build information.
+type tfProviderInfo struct {
+	ApiVersion      types.String `tfsdk:"api_version"`
+	Build           types.String `tfsdk:"build"`
+	ConsoleBuild    types.String `tfsdk:"console_build"`
+	ConsoleRelease  types.String `tfsdk:"console_release"`
+	MinVersion      types.String `tfsdk:"min_version"`
+	ProtocolVersion types.Int64  `tfsdk:"protocol_version"`
+	Version         types.String `tfsdk:"version"`
+}
+
+// NewProviderInfo creates a new ProviderInfo object.
+func NewProviderInfo() datasource.DataSource {
+	return &ProviderInfo{}
+}
+
+// ProviderInfo is a data source used to retrieve build information for the provider and the console it manages.
+type ProviderInfo struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *ProviderInfo) Metadata(ctx context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_provider_info"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *ProviderInfo) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source is used for retrieving build information for the provider and the version " +
+			"of the console it is connected to.",
+		MarkdownDescription: `This data source is used for retrieving build information for the provider and the
+			version of the console it is connected to.
+
+		If ` + "`min_version`" + ` is set, reading the data source fails with a diagnostic naming both versions when
+		the provider is older than the given version. Development builds of the provider have no version and only
+		produce a warning.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"api_version": schema.StringAttribute{
+				Description:         "Version of the management console API used by the provider.",
+				MarkdownDescription: "Version of the management console API used by the provider.",
+				Computed:            true,
+			},
+			"build": schema.StringAttribute{
+				Description:         "Git commit from which the provider was built.",
+				MarkdownDescription: "Git commit from which the provider was built.",
+				Computed:            true,
+			},
+			"console_build": schema.StringAttribute{
+				Description:         "Build number of the management console.",
+				MarkdownDescription: "Build number of the management console.",
+				Computed:            true,
+			},
+			"console_release": schema.StringAttribute{
+				Description:         "Release of the management console.",
+				MarkdownDescription: "Release of the management console.",
+				Computed:            true,
+			},
+			"min_version": schema.StringAttribute{
+				Description:         "Minimum version of the provider required (eg: 1.2.0). [Default: none]",
+				MarkdownDescription: "Minimum version of the provider required (eg: `1.2.0`). [Default: none]",
+				Optional:            true,
+			},
+			"protocol_version": schema.Int64Attribute{
+				Description:         "Version of the Terraform plugin protocol served by the provider.",
+				MarkdownDescription: "Version of the Terraform plugin protocol served by the provider.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				Description:         "Version of the provider.",
+				MarkdownDescription: "Version of the provider.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *ProviderInfo) Configure(ctx context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_PROVIDER_INFO_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *ProviderInfo) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfProviderInfo
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// make sure the provider is new enough
+	if !data.MinVersion.IsNull() && data.MinVersion.ValueString() != "" {
+		minVersion := strings.TrimPrefix(data.MinVersion.ValueString(), "v")
+		version := strings.TrimPrefix(plugin.Version, "v")
+		if version == "" {
+			resp.Diagnostics.AddWarning("Provider Version Unknown", fmt.Sprintf("The provider was built without "+
+				"version information so it could not be compared with the minimum version %s.", minVersion))
+		} else if cmp, ok := compareReleases(version, minVersion); !ok || cmp < 0 {
+			msg := fmt.Sprintf("Version %s of the provider is in use but at least version %s is required. Upgrade "+
+				"the provider by updating its required_providers constraint and running 'terraform init -upgrade'.",
+				version, minVersion)
+			if !ok {
+				msg = fmt.Sprintf("Unable to compare version %s of the provider with the minimum version %s.",
+					version, minVersion)
+			}
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"version":             version,
+				"min_version":         minVersion,
+				"internal_error_code": plugin.ERR_DATASOURCE_PROVIDER_INFO_READ,
+			})
+			resp.Diagnostics.AddAttributeError(tfpath.Root("min_version"), "Provider Version Too Old", msg)
+			return
+		}
+	}
+
+	// detect the console version
+	info, diags := api.Client().GetSystemInfo(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ApiVersion = types.StringValue(path.Base(api.API_BASE_URI))
+	data.Build = types.StringValue(plugin.Build)
+	data.ConsoleBuild = types.StringValue(info.Build)
+	data.ConsoleRelease = types.StringValue(info.Release)
+	data.ProtocolVersion = types.Int64Value(plugin.PROTOCOL_VERSION)
+	data.Version = types.StringValue(plugin.Version)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
 )
 
 // ensure implementation satisfied expected interfaces
@@ -44,6 +48,39 @@ type tfSite struct {
 	UpdatedAt           types.String   `tfsdk:"updated_at"`
 }
 
+// tfSiteWithChecks defines the Terraform model for a single site along with any health checks to run against it.
+type tfSiteWithChecks struct {
+	AccountId           types.String   `tfsdk:"account_id"`
+	AccountName         types.String   `tfsdk:"account_name"`
+	ActiveLicenses      types.Int64    `tfsdk:"active_licenses"`
+	Checks              *tfSiteChecks  `tfsdk:"checks"`
+	CreatedAt           types.String   `tfsdk:"created_at"`
+	Creator             types.String   `tfsdk:"creator"`
+	CreatorId           types.String   `tfsdk:"creator_id"`
+	Description         types.String   `tfsdk:"description"`
+	Expiration          types.String   `tfsdk:"expiration"`
+	ExternalId          types.String   `tfsdk:"external_id"`
+	Id                  types.String   `tfsdk:"id"`
+	IsDefault           types.Bool     `tfsdk:"is_default"`
+	Licenses            *tfSiteLicense `tfsdk:"licenses"`
+	Name                types.String   `tfsdk:"name"`
+	RegistrationToken   types.String   `tfsdk:"registration_token"`
+	SiteType            types.String   `tfsdk:"site_type"`
+	State               types.String   `tfsdk:"state"`
+	TotalLicenses       types.Int64    `tfsdk:"total_licenses"`
+	UnlimitedExpiration types.Bool     `tfsdk:"unlimited_expiration"`
+	UnlimitedLicenses   types.Bool     `tfsdk:"unlimited_licenses"`
+	UpdatedAt           types.String   `tfsdk:"updated_at"`
+}
+
+// tfSiteChecks defines the Terraform model for the health checks to run against a site.
+type tfSiteChecks struct {
+	FailIfExpiringWithin types.String `tfsdk:"fail_if_expiring_within"`
+	FailIfNotActive      types.Bool   `tfsdk:"fail_if_not_active"`
+	FailIfOverLicensed   types.Bool   `tfsdk:"fail_if_over_licensed"`
+	Severity             types.String `tfsdk:"severity"`
+}
+
 // tfSiteLicense defines the Terraform model for a site's license.
 type tfSiteLicense struct {
 	Bundles  []tfSiteLicenseBundle  `tfsdk:"bundles"`
@@ -106,6 +143,44 @@ func (d *Site) Schema(ctx context.Context, req datasource.SchemaRequest, resp *d
 		MarkdownDescription: "ID of the site.",
 		Required:            true,
 	}
+	siteSchema.Blocks = map[string]schema.Block{
+		"checks": schema.SingleNestedBlock{
+			Description: "Defines health checks to run against the site. Failed checks are reported as diagnostics " +
+				"when the data source is read, which allows a plan to be stopped when a site is unhealthy.",
+			MarkdownDescription: "Defines health checks to run against the site. Failed checks are reported as " +
+				"diagnostics when the data source is read, which allows a plan to be stopped when a site is unhealthy.",
+			Attributes: map[string]schema.Attribute{
+				"fail_if_expiring_within": schema.StringAttribute{
+					Description:         "Fail if the site expires within the given duration (eg: 30d, 2w, 12h).",
+					MarkdownDescription: "Fail if the site expires within the given duration (eg: `30d`, `2w`, `12h`).",
+					Optional:            true,
+					Validators: []validator.String{
+						validators.DurationIsValid(),
+					},
+				},
+				"fail_if_not_active": schema.BoolAttribute{
+					Description:         "Fail if the site is not in the active state.",
+					MarkdownDescription: "Fail if the site is not in the `active` state.",
+					Optional:            true,
+				},
+				"fail_if_over_licensed": schema.BoolAttribute{
+					Description:         "Fail if the site is using more licenses than it has available.",
+					MarkdownDescription: "Fail if the site is using more licenses than it has available.",
+					Optional:            true,
+				},
+				"severity": schema.StringAttribute{
+					Description: "Whether failed checks are reported as errors or warnings (valid values: error, " +
+						"warning). [Default: error]",
+					MarkdownDescription: "Whether failed checks are reported as errors or warnings (valid values: " +
+						"`error`, `warning`). [Default: `error`]",
+					Optional: true,
+					Validators: []validator.String{
+						validators.EnumStringValueOneOf(false, "error", "warning"),
+					},
+				},
+			},
+		},
+	}
 	resp.Schema = siteSchema
 }
 
@@ -134,7 +209,7 @@ func (d *Site) Configure(ctx context.Context, req datasource.ConfigureRequest, r
 
 // Read retrieves data from the API.
 func (d *Site) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var data tfSite
+	var data tfSiteWithChecks
 
 	// read configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
@@ -149,8 +224,102 @@ func (d *Site) Read(ctx context.Context, req datasource.ReadRequest, resp *datas
 		return
 	}
 
+	// run any health checks against the site
+	if data.Checks != nil {
+		resp.Diagnostics.Append(d.runChecks(ctx, site, *data.Checks)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// convert the API object to the Terraform object
-	resp.Diagnostics.Append(resp.State.Set(ctx, tfSiteFromAPI(ctx, site))...)
+	tfsite := tfSiteFromAPI(ctx, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSiteWithChecks{
+		AccountId:           tfsite.AccountId,
+		AccountName:         tfsite.AccountName,
+		ActiveLicenses:      tfsite.ActiveLicenses,
+		Checks:              data.Checks,
+		CreatedAt:           tfsite.CreatedAt,
+		Creator:             tfsite.Creator,
+		CreatorId:           tfsite.CreatorId,
+		Description:         tfsite.Description,
+		Expiration:          tfsite.Expiration,
+		ExternalId:          tfsite.ExternalId,
+		Id:                  tfsite.Id,
+		IsDefault:           tfsite.IsDefault,
+		Licenses:            tfsite.Licenses,
+		Name:                tfsite.Name,
+		RegistrationToken:   tfsite.RegistrationToken,
+		SiteType:            tfsite.SiteType,
+		State:               tfsite.State,
+		TotalLicenses:       tfsite.TotalLicenses,
+		UnlimitedExpiration: tfsite.UnlimitedExpiration,
+		UnlimitedLicenses:   tfsite.UnlimitedLicenses,
+		UpdatedAt:           tfsite.UpdatedAt,
+	})...)
+}
+
+// runChecks runs the configured health checks against the site and returns a warning or error diagnostic for each
+// check which failed.
+func (d *Site) runChecks(ctx context.Context, site *api.Site, checks tfSiteChecks) diag.Diagnostics {
+	var diags diag.Diagnostics
+	ctx = tflog.SetField(ctx, "site_id", site.Id)
+	ctx = tflog.SetField(ctx, "site_name", site.Name)
+
+	// report a failed check using the configured severity
+	warnOnly := !checks.Severity.IsNull() && !checks.Severity.IsUnknown() && checks.Severity.ValueString() == "warning"
+	fail := func(summary, msg string) {
+		if warnOnly {
+			tflog.Warn(ctx, msg)
+			diags.AddWarning(summary, msg)
+			return
+		}
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_SITE_CHECK,
+		})
+		diags.AddError(summary, msg)
+	}
+
+	if !checks.FailIfExpiringWithin.IsNull() && !checks.FailIfExpiringWithin.IsUnknown() &&
+		!site.UnlimitedExpiration && site.Expiration != "" {
+
+		within, parseDiags := plugin.ParseRelativeDuration(ctx, checks.FailIfExpiringWithin.ValueString())
+		diags.Append(parseDiags...)
+		if diags.HasError() {
+			return diags
+		}
+		expiration, err := time.Parse(time.RFC3339Nano, site.Expiration)
+		if err != nil {
+			msg := fmt.Sprintf("An unexpected error occurred while parsing the site's expiration timestamp.\n\n"+
+				"Error: %s\nExpiration: %s", err.Error(), site.Expiration)
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_DATASOURCE_SITE_CHECK,
+			})
+			diags.AddError("Unexpected Internal Error", msg)
+			return diags
+		}
+		if time.Until(expiration) <= within {
+			fail("Site Expiring", fmt.Sprintf("The site '%s' expires at %s, which is within %s.\n\nSite ID: %s",
+				site.Name, site.Expiration, checks.FailIfExpiringWithin.ValueString(), site.Id))
+		}
+	}
+
+	if !checks.FailIfNotActive.IsNull() && !checks.FailIfNotActive.IsUnknown() && checks.FailIfNotActive.ValueBool() &&
+		site.State != "active" {
+
+		fail("Site Not Active", fmt.Sprintf("The site '%s' is not active.\n\nSite ID: %s\nState: %s",
+			site.Name, site.Id, site.State))
+	}
+
+	if !checks.FailIfOverLicensed.IsNull() && !checks.FailIfOverLicensed.IsUnknown() &&
+		checks.FailIfOverLicensed.ValueBool() && !site.UnlimitedLicenses && site.ActiveLicenses > site.TotalLicenses {
+
+		fail("Site Over Licensed", fmt.Sprintf("The site '%s' is using more licenses than it has available."+
+			"\n\nSite ID: %s\nActive Licenses: %d\nTotal Licenses: %d",
+			site.Name, site.Id, site.ActiveLicenses, site.TotalLicenses))
+	}
+	return diags
 }
 
 // getSiteSchema returns a default Terraform schema where all values are computed.
@@ -214,114 +383,7 @@ func getSiteSchema(ctx context.Context) schema.Schema {
 				MarkdownDescription: "Whether or not the site is the default site.",
 				Computed:            true,
 			},
-			"licenses": schema.SingleNestedAttribute{
-				Description:         "List of licenses associated with the site.",
-				MarkdownDescription: "List of licenses associated with the site.",
-				Computed:            true,
-				Attributes: map[string]schema.Attribute{
-					"bundles": schema.ListNestedAttribute{
-						Description:         "License bundles.",
-						MarkdownDescription: "License bundles.",
-						Computed:            true,
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"display_name": schema.StringAttribute{
-									Description:         "Bundle display name.",
-									MarkdownDescription: "Bundle display name.",
-									Computed:            true,
-								},
-								"major_version": schema.Int64Attribute{
-									Description:         "Bundle major version.",
-									MarkdownDescription: "Bundle major version.",
-									Computed:            true,
-								},
-								"minor_version": schema.Int64Attribute{
-									Description:         "Bundle minor version.",
-									MarkdownDescription: "Bundle minor version.",
-									Computed:            true,
-								},
-								"name": schema.StringAttribute{
-									Description:         "Bundle API name.",
-									MarkdownDescription: "Bundle API name.",
-									Computed:            true,
-								},
-								"surfaces": schema.ListNestedAttribute{
-									Description:         "Surfaces in the bundle.",
-									MarkdownDescription: "Surfaces in the bundle.",
-									Computed:            true,
-									NestedObject: schema.NestedAttributeObject{
-										Attributes: map[string]schema.Attribute{
-											"count": schema.Int64Attribute{
-												Description:         "Surface count or -1 for unlimited.",
-												MarkdownDescription: "Surface count or -1 for unlimited.",
-												Computed:            true,
-											},
-											"name": schema.StringAttribute{
-												Description:         "Surface name.",
-												MarkdownDescription: "Surface name.",
-												Computed:            true,
-											},
-										},
-									},
-								},
-								"total_surfaces": schema.Int64Attribute{
-									Description:         "Total number of surfaces in the bundle or -1 for unlimited.",
-									MarkdownDescription: "Total number of surfaces in the bundle or -1 for unlimited.",
-									Computed:            true,
-								},
-							},
-						},
-					},
-					"modules": schema.ListNestedAttribute{
-						Description:         "License add-ons.",
-						MarkdownDescription: "License add-ons.",
-						Computed:            true,
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"display_name": schema.StringAttribute{
-									Description:         "Add-on display name.",
-									MarkdownDescription: "Add-on display name.",
-									Computed:            true,
-								},
-								"major_version": schema.Int64Attribute{
-									Description:         "Add-on major version.",
-									MarkdownDescription: "Add-on major version.",
-									Computed:            true,
-								},
-								"name": schema.StringAttribute{
-									Description:         "Add-on API name.",
-									MarkdownDescription: "Add-on API name.",
-									Computed:            true,
-								},
-							},
-						},
-					},
-					"settings": schema.ListNestedAttribute{
-						Description:         "License settings.",
-						MarkdownDescription: "License Settings.",
-						Computed:            true,
-						NestedObject: schema.NestedAttributeObject{
-							Attributes: map[string]schema.Attribute{
-								"group_name": schema.StringAttribute{
-									Description:         "Setting group name.",
-									MarkdownDescription: "Setting group name.",
-									Computed:            true,
-								},
-								"setting": schema.StringAttribute{
-									Description:         "Setting display name.",
-									MarkdownDescription: "Setting display name.",
-									Computed:            true,
-								},
-								"setting_group_display_name": schema.StringAttribute{
-									Description:         "Setting group display name.",
-									MarkdownDescription: "Setting group display name.",
-									Computed:            true,
-								},
-							},
-						},
-					},
-				},
-			},
+			"licenses": getSiteLicenseSchema("site"),
 			"name": schema.StringAttribute{
 				Description:         "Name of the site.",
 				MarkdownDescription: "Name of the site.",
@@ -366,6 +428,118 @@ func getSiteSchema(ctx context.Context) schema.Schema {
 	}
 }
 
+// getSiteLicenseSchema returns the Terraform schema for the licenses associated with a site or account.
+func getSiteLicenseSchema(scopeType string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description:         fmt.Sprintf("List of licenses associated with the %s.", scopeType),
+		MarkdownDescription: fmt.Sprintf("List of licenses associated with the %s.", scopeType),
+		Computed:            true,
+		Attributes: map[string]schema.Attribute{
+			"bundles": schema.ListNestedAttribute{
+				Description:         "License bundles.",
+				MarkdownDescription: "License bundles.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"display_name": schema.StringAttribute{
+							Description:         "Bundle display name.",
+							MarkdownDescription: "Bundle display name.",
+							Computed:            true,
+						},
+						"major_version": schema.Int64Attribute{
+							Description:         "Bundle major version.",
+							MarkdownDescription: "Bundle major version.",
+							Computed:            true,
+						},
+						"minor_version": schema.Int64Attribute{
+							Description:         "Bundle minor version.",
+							MarkdownDescription: "Bundle minor version.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "Bundle API name.",
+							MarkdownDescription: "Bundle API name.",
+							Computed:            true,
+						},
+						"surfaces": schema.ListNestedAttribute{
+							Description:         "Surfaces in the bundle.",
+							MarkdownDescription: "Surfaces in the bundle.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"count": schema.Int64Attribute{
+										Description:         "Surface count or -1 for unlimited.",
+										MarkdownDescription: "Surface count or -1 for unlimited.",
+										Computed:            true,
+									},
+									"name": schema.StringAttribute{
+										Description:         "Surface name.",
+										MarkdownDescription: "Surface name.",
+										Computed:            true,
+									},
+								},
+							},
+						},
+						"total_surfaces": schema.Int64Attribute{
+							Description:         "Total number of surfaces in the bundle or -1 for unlimited.",
+							MarkdownDescription: "Total number of surfaces in the bundle or -1 for unlimited.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"modules": schema.ListNestedAttribute{
+				Description:         "License add-ons.",
+				MarkdownDescription: "License add-ons.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"display_name": schema.StringAttribute{
+							Description:         "Add-on display name.",
+							MarkdownDescription: "Add-on display name.",
+							Computed:            true,
+						},
+						"major_version": schema.Int64Attribute{
+							Description:         "Add-on major version.",
+							MarkdownDescription: "Add-on major version.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "Add-on API name.",
+							MarkdownDescription: "Add-on API name.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"settings": schema.ListNestedAttribute{
+				Description:         "License settings.",
+				MarkdownDescription: "License Settings.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"group_name": schema.StringAttribute{
+							Description:         "Setting group name.",
+							MarkdownDescription: "Setting group name.",
+							Computed:            true,
+						},
+						"setting": schema.StringAttribute{
+							Description:         "Setting display name.",
+							MarkdownDescription: "Setting display name.",
+							Computed:            true,
+						},
+						"setting_group_display_name": schema.StringAttribute{
+							Description:         "Setting group display name.",
+							MarkdownDescription: "Setting group display name.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // tfSiteFromAPI converts an API site into a Terraform site.
 func tfSiteFromAPI(ctx context.Context, site *api.Site) tfSite {
 	tfsite := tfSite{
@@ -389,12 +563,22 @@ func tfSiteFromAPI(ctx context.Context, site *api.Site) tfSite {
 		UnlimitedLicenses:   types.BoolValue(site.UnlimitedLicenses),
 		UpdatedAt:           types.StringValue(site.UpdatedAt),
 	}
-	tfsite.Licenses = &tfSiteLicense{
+	tfsite.Licenses = tfSiteLicenseFromAPI(&site.Licenses)
+	ctx = plugin.MaskSecrets(ctx, site.RegistrationToken)
+	tflog.Debug(ctx, fmt.Sprintf("converted API site to TF site: %+v", tfsite), map[string]interface{}{
+		"api_site": site,
+	})
+	return tfsite
+}
+
+// tfSiteLicenseFromAPI converts the licenses associated with a site or account into Terraform licenses.
+func tfSiteLicenseFromAPI(license *api.SiteLicense) *tfSiteLicense {
+	tflicense := &tfSiteLicense{
 		Bundles:  []tfSiteLicenseBundle{},
 		Modules:  []tfSiteLicenseModule{},
 		Settings: []tfSiteLicenseSetting{},
 	}
-	for _, bundle := range site.Licenses.Bundles {
+	for _, bundle := range license.Bundles {
 		b := tfSiteLicenseBundle{
 			DisplayName:   types.StringValue(bundle.DisplayName),
 			MajorVersion:  types.Int64Value(int64(bundle.MajorVersion)),
@@ -409,24 +593,21 @@ func tfSiteFromAPI(ctx context.Context, site *api.Site) tfSite {
 				Name:  types.StringValue(surface.Name),
 			})
 		}
-		tfsite.Licenses.Bundles = append(tfsite.Licenses.Bundles, b)
+		tflicense.Bundles = append(tflicense.Bundles, b)
 	}
-	for _, module := range site.Licenses.Modules {
-		tfsite.Licenses.Modules = append(tfsite.Licenses.Modules, tfSiteLicenseModule{
+	for _, module := range license.Modules {
+		tflicense.Modules = append(tflicense.Modules, tfSiteLicenseModule{
 			DisplayName:  types.StringValue(module.DisplayName),
 			MajorVersion: types.Int64Value(int64(module.MajorVersion)),
 			Name:         types.StringValue(module.Name),
 		})
 	}
-	for _, setting := range site.Licenses.Settings {
-		tfsite.Licenses.Settings = append(tfsite.Licenses.Settings, tfSiteLicenseSetting{
+	for _, setting := range license.Settings {
+		tflicense.Settings = append(tflicense.Settings, tfSiteLicenseSetting{
 			GroupName:               types.StringValue(setting.GroupName),
 			Setting:                 types.StringValue(setting.Setting),
 			SettingGroupDisplayName: types.StringValue(setting.SettingGroupDisplayName),
 		})
 	}
-	tflog.Debug(ctx, fmt.Sprintf("converted API site to TF site: %+v", tfsite), map[string]interface{}{
-		"api_site": site,
-	})
-	return tfsite
+	return tflicense
 }
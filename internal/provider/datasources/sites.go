@@ -24,6 +24,7 @@ var (
 
 // tfSites defines the Terraform model for sites.
 type tfSites struct {
+	Debug  types.Map      `tfsdk:"debug"`
 	Sites  []tfSite       `tfsdk:"sites"`
 	Filter *tfSitesFilter `tfsdk:"filter"`
 }
@@ -80,6 +81,7 @@ func (d *Sites) Schema(ctx context.Context, req datasource.SchemaRequest, resp *
 		TODO: add more of a description on how to use this data source...
 		`,
 		Attributes: map[string]schema.Attribute{
+			"debug": getDebugSchema(),
 			"sites": schema.ListNestedAttribute{
 				Description:         "List of matching sites that were found.",
 				MarkdownDescription: "List of matching sites that were found.",
@@ -208,10 +210,11 @@ func (d *Sites) Schema(ctx context.Context, req datasource.SchemaRequest, resp *
 					},
 					"sort_by": schema.StringAttribute{
 						Description: "Field on which to sort results (valid values: accountName, activeLicenses, " +
-							"createdAt, description, expiration, id, name, siteType, state, totalLicenses, updatedAt).",
+							"createdAt, description, expiration, id, name, siteType, state, totalLicenses, updatedAt) " +
+							"[Default: id].",
 						MarkdownDescription: "Field on which to sort results (valid values: `accountName`, `activeLicenses`, " +
 							"`createdAt`, `description`, `expiration`, `id`, `name`, `siteType`, `state`, `totalLicenses`, " +
-							"`updatedAt`).",
+							"`updatedAt`) [Default: `id`].",
 						Optional: true,
 						Validators: []validator.String{
 							validators.EnumStringValueOneOf(false,
@@ -221,8 +224,8 @@ func (d *Sites) Schema(ctx context.Context, req datasource.SchemaRequest, resp *
 						},
 					},
 					"sort_order": schema.StringAttribute{
-						Description:         "Order in which to sort results (valid values: asc, desc).",
-						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`).",
+						Description:         "Order in which to sort results (valid values: asc, desc) [Default: asc].",
+						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`) [Default: `asc`].",
 						Optional:            true,
 						Validators: []validator.String{
 							validators.EnumStringValueOneOf(false,
@@ -290,12 +293,18 @@ func (d *Sites) Read(ctx context.Context, req datasource.ReadRequest, resp *data
 		return
 	}
 
+	// capture any debug response headers returned by the queries below
+	ctx, recorder := api.RecordResponseHeaders(ctx)
+
 	// construct query parameters
 	queryParams := api.SiteQueryParams{}
 	if data.Filter != nil {
 		queryParams = d.queryParamsFromFilter(*data.Filter)
 	}
 
+	// always sort results so their order is deterministic even if the API server changes its default ordering
+	queryParams.SortBy, queryParams.SortOrder = sortOrDefault(queryParams.SortBy, queryParams.SortOrder)
+
 	// find the matching sites
 	sites, diags := api.Client().FindSites(ctx, queryParams)
 	resp.Diagnostics.Append(diags...)
@@ -311,6 +320,12 @@ func (d *Sites) Read(ctx context.Context, req datasource.ReadRequest, resp *data
 	for _, site := range sites {
 		tfsites.Sites = append(tfsites.Sites, tfSiteFromAPI(ctx, &site))
 	}
+	debug, diags := tfDebugFromRecorder(ctx, recorder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tfsites.Debug = debug
 	resp.Diagnostics.Append(resp.State.Set(ctx, tfsites)...)
 }
 
@@ -0,0 +1,22 @@
+package datasources
+
+import (
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+)
+
+// sortOrDefault returns the given sort field and order, replacing any which were not set by the filter with the
+// defaults.
+//
+// List data sources always sort their results so that the order is deterministic even if the API server changes its
+// default ordering.
+func sortOrDefault(sortBy, sortOrder *string) (*string, *string) {
+	if sortBy == nil {
+		value := api.DEFAULT_SORT_BY
+		sortBy = &value
+	}
+	if sortOrder == nil {
+		value := api.DEFAULT_SORT_ORDER
+		sortOrder = &value
+	}
+	return sortBy, sortOrder
+}
@@ -0,0 +1,271 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api/waiter"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+const (
+	// mitigationStateCompleted is the waiter state used once no mitigation actions are pending.
+	mitigationStateCompleted = "completed"
+
+	// mitigationStatePending is the waiter state used while any mitigation action is pending on any agent.
+	mitigationStatePending = "pending"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &ThreatMitigationStatus{}
+	_ datasource.DataSourceWithConfigure = &ThreatMitigationStatus{}
+)
+
+// tfThreatMitigationStatus defines the Terraform model for the mitigation status of a threat.
+type tfThreatMitigationStatus struct {
+	Actions      []tfThreatMitigationAction `tfsdk:"actions"`
+	Completed    types.Bool                 `tfsdk:"completed"`
+	FailedCount  types.Int64                `tfsdk:"failed_count"`
+	PendingCount types.Int64                `tfsdk:"pending_count"`
+	PollInterval types.String               `tfsdk:"poll_interval"`
+	SuccessCount types.Int64                `tfsdk:"success_count"`
+	ThreatId     types.String               `tfsdk:"threat_id"`
+	WaitTimeout  types.String               `tfsdk:"wait_timeout"`
+}
+
+// tfThreatMitigationAction defines the Terraform model for the status of a single mitigation action.
+type tfThreatMitigationAction struct {
+	Action       types.String `tfsdk:"action"`
+	FailedCount  types.Int64  `tfsdk:"failed_count"`
+	PendingCount types.Int64  `tfsdk:"pending_count"`
+	Status       types.String `tfsdk:"status"`
+	SuccessCount types.Int64  `tfsdk:"success_count"`
+}
+
+// NewThreatMitigationStatus creates a new ThreatMitigationStatus object.
+func NewThreatMitigationStatus() datasource.DataSource {
+	return &ThreatMitigationStatus{}
+}
+
+// ThreatMitigationStatus is a data source used to report the status of the mitigation actions taken against a
+// threat.
+type ThreatMitigationStatus struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *ThreatMitigationStatus) Metadata(ctx context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_threat_mitigation_status"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *ThreatMitigationStatus) Schema(ctx context.Context, req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+
+	resp.Schema = schema.Schema{
+		Description: "This data source is used for getting the status of the mitigation actions taken against a " +
+			"threat across all of the agents on which it was detected.",
+		MarkdownDescription: `This data source is used for getting the status of the mitigation actions taken against a
+			threat across all of the agents on which it was detected.
+
+		By default, the current status is returned immediately. If ` + "`wait_timeout`" + ` is set, the data source
+		polls the status every ` + "`poll_interval`" + ` until no action is pending on any agent, failing if that
+		does not happen within the timeout. This allows subsequent steps to depend on mitigation having finished.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"actions": schema.ListNestedAttribute{
+				Description:         "Status of each mitigation action taken against the threat.",
+				MarkdownDescription: "Status of each mitigation action taken against the threat.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"action": schema.StringAttribute{
+							Description: "Mitigation action taken (eg: kill, quarantine, remediate, rollback).",
+							MarkdownDescription: "Mitigation action taken (eg: `kill`, `quarantine`, `remediate`, " +
+								"`rollback`).",
+							Computed: true,
+						},
+						"failed_count": schema.Int64Attribute{
+							Description:         "Number of agents on which the action failed.",
+							MarkdownDescription: "Number of agents on which the action failed.",
+							Computed:            true,
+						},
+						"pending_count": schema.Int64Attribute{
+							Description:         "Number of agents on which the action is still pending.",
+							MarkdownDescription: "Number of agents on which the action is still pending.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "Overall status of the action.",
+							MarkdownDescription: "Overall status of the action.",
+							Computed:            true,
+						},
+						"success_count": schema.Int64Attribute{
+							Description:         "Number of agents on which the action succeeded.",
+							MarkdownDescription: "Number of agents on which the action succeeded.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"completed": schema.BoolAttribute{
+				Description:         "Whether or not every action has finished on every agent.",
+				MarkdownDescription: "Whether or not every action has finished on every agent.",
+				Computed:            true,
+			},
+			"failed_count": schema.Int64Attribute{
+				Description:         "Total number of failed actions across all agents.",
+				MarkdownDescription: "Total number of failed actions across all agents.",
+				Computed:            true,
+			},
+			"pending_count": schema.Int64Attribute{
+				Description:         "Total number of pending actions across all agents.",
+				MarkdownDescription: "Total number of pending actions across all agents.",
+				Computed:            true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description:         "How often to poll the status while waiting (eg: 10s, 1m). [Default: 10s]",
+				MarkdownDescription: "How often to poll the status while waiting (eg: `10s`, `1m`). [Default: `10s`]",
+				Optional:            true,
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"success_count": schema.Int64Attribute{
+				Description:         "Total number of successful actions across all agents.",
+				MarkdownDescription: "Total number of successful actions across all agents.",
+				Computed:            true,
+			},
+			"threat_id": schema.StringAttribute{
+				Description:         "ID of the threat.",
+				MarkdownDescription: "ID of the threat.",
+				Required:            true,
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for every action to finish (eg: 15m). [Default: none - the " +
+					"current status is returned without waiting]",
+				MarkdownDescription: "Maximum time to wait for every action to finish (eg: `15m`). [Default: none - " +
+					"the current status is returned without waiting]",
+				Optional: true,
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *ThreatMitigationStatus) Configure(ctx context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_THREAT_MITIGATION_STATUS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *ThreatMitigationStatus) Read(ctx context.Context, req datasource.ReadRequest,
+	resp *datasource.ReadResponse) {
+
+	var data tfThreatMitigationStatus
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// data sources do not support defaults so fill them in here
+	if data.PollInterval.IsNull() {
+		data.PollInterval = types.StringValue("10s")
+	}
+
+	// get the current status, waiting for pending actions to finish if requested
+	threatId := data.ThreatId.ValueString()
+	refresh := func(ctx context.Context) ([]api.ThreatMitigationAction, string, diag.Diagnostics) {
+		actions, diags := api.Client().GetThreatMitigationStatus(ctx, threatId)
+		if diags.HasError() {
+			return nil, "", diags
+		}
+		for _, a := range actions {
+			if a.PendingCount > 0 {
+				return actions, mitigationStatePending, diags
+			}
+		}
+		return actions, mitigationStateCompleted, diags
+	}
+	var actions []api.ThreatMitigationAction
+	var diags diag.Diagnostics
+	if data.WaitTimeout.IsNull() {
+		actions, _, diags = refresh(ctx)
+	} else {
+		interval, parseDiags := plugin.ParseRelativeDuration(ctx, data.PollInterval.ValueString())
+		resp.Diagnostics.Append(parseDiags...)
+		timeout, parseDiags := plugin.ParseRelativeDuration(ctx, data.WaitTimeout.ValueString())
+		resp.Diagnostics.Append(parseDiags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		actions, diags = waiter.Wait(ctx, waiter.Config{
+			Description: "threat mitigation",
+			Interval:    interval,
+			Pending:     []string{mitigationStatePending},
+			Target:      []string{mitigationStateCompleted},
+			Timeout:     timeout,
+		}, refresh)
+	}
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// convert API objects into Terraform objects
+	data.Actions = []tfThreatMitigationAction{}
+	var failed, pending, success int64
+	for _, a := range actions {
+		data.Actions = append(data.Actions, tfThreatMitigationAction{
+			Action:       types.StringValue(a.Action),
+			FailedCount:  types.Int64Value(a.FailedCount),
+			PendingCount: types.Int64Value(a.PendingCount),
+			Status:       types.StringValue(a.Status),
+			SuccessCount: types.Int64Value(a.SuccessCount),
+		})
+		failed += a.FailedCount
+		pending += a.PendingCount
+		success += a.SuccessCount
+	}
+	data.Completed = types.BoolValue(pending == 0)
+	data.FailedCount = types.Int64Value(failed)
+	data.PendingCount = types.Int64Value(pending)
+	data.SuccessCount = types.Int64Value(success)
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
@@ -0,0 +1,559 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/modifiers"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// threatAgentLookupBatchSize is the maximum number of agents looked up in a single query when evaluating agent tag
+// filters.
+const threatAgentLookupBatchSize = 100
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &Threats{}
+	_ datasource.DataSourceWithConfigure = &Threats{}
+)
+
+// tfThreats defines the Terraform model for threats.
+type tfThreats struct {
+	Debug   types.Map        `tfsdk:"debug"`
+	Threats []tfThreat       `tfsdk:"threats"`
+	Filter  *tfThreatsFilter `tfsdk:"filter"`
+}
+
+// tfThreat defines the Terraform model for a threat.
+type tfThreat struct {
+	AccountId         types.String `tfsdk:"account_id"`
+	AgentComputerName types.String `tfsdk:"agent_computer_name"`
+	AgentId           types.String `tfsdk:"agent_id"`
+	AnalystVerdict    types.String `tfsdk:"analyst_verdict"`
+	Checksums         tfChecksums  `tfsdk:"checksums"`
+	Classification    types.String `tfsdk:"classification"`
+	ConfidenceLevel   types.String `tfsdk:"confidence_level"`
+	CreatedAt         types.String `tfsdk:"created_at"`
+	GroupId           types.String `tfsdk:"group_id"`
+	Id                types.String `tfsdk:"id"`
+	IncidentStatus    types.String `tfsdk:"incident_status"`
+	MitigationStatus  types.String `tfsdk:"mitigation_status"`
+	SHA1              types.String `tfsdk:"sha1"`
+	SiteId            types.String `tfsdk:"site_id"`
+	SiteName          types.String `tfsdk:"site_name"`
+	ThreatName        types.String `tfsdk:"threat_name"`
+	UpdatedAt         types.String `tfsdk:"updated_at"`
+}
+
+// tfThreatsFilter defines the Terraform model for threat filtering.
+type tfThreatsFilter struct {
+	AccountIds         []types.String            `tfsdk:"account_ids"`
+	AgentTags          *tfThreatsAgentTagsFilter `tfsdk:"agent_tags"`
+	Classifications    []types.String            `tfsdk:"classifications"`
+	CreatedAfter       types.String              `tfsdk:"created_after"`
+	FilterJSON         types.String              `tfsdk:"filter_json"`
+	GroupIds           []types.String            `tfsdk:"group_ids"`
+	IncidentStatuses   []types.String            `tfsdk:"incident_statuses"`
+	MinConfidenceLevel types.String              `tfsdk:"min_confidence_level"`
+	SiteIds            []types.String            `tfsdk:"site_ids"`
+	SortBy             types.String              `tfsdk:"sort_by"`
+	SortOrder          types.String              `tfsdk:"sort_order"`
+}
+
+// tfThreatsAgentTagsFilter defines the Terraform model for filtering threats by the tags of their agents.
+type tfThreatsAgentTagsFilter struct {
+	AllOf []types.String `tfsdk:"all_of"`
+	AnyOf []types.String `tfsdk:"any_of"`
+}
+
+// NewThreats creates a new Threats object.
+func NewThreats() datasource.DataSource {
+	return &Threats{}
+}
+
+// Threats is a data source used to store details about threats.
+type Threats struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *Threats) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_threats"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *Threats) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source can be used for getting a list of threats based on filters.",
+		MarkdownDescription: `This data source can be used for getting a list of threats based on filters.
+
+		All of the filters given must match (AND). Within a list filter such as ` + "`classifications`" + `, any of the
+		values may match (OR). The ` + "`agent_tags`" + ` block matches threats by the tags assigned to the agent on
+		which they were detected. Tags are given either as ` + "`key`" + ` or ` + "`key=value`" + `. Since the API
+		cannot filter threats by agent tags, the agents are looked up after the threats have been found.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"debug": getDebugSchema(),
+			"threats": schema.ListNestedAttribute{
+				Description:         "List of matching threats that were found.",
+				MarkdownDescription: "List of matching threats that were found.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account_id": schema.StringAttribute{
+							Description:         "ID of the account in which the threat was detected.",
+							MarkdownDescription: "ID of the account in which the threat was detected.",
+							Computed:            true,
+						},
+						"agent_computer_name": schema.StringAttribute{
+							Description:         "Computer name of the agent on which the threat was detected.",
+							MarkdownDescription: "Computer name of the agent on which the threat was detected.",
+							Computed:            true,
+						},
+						"agent_id": schema.StringAttribute{
+							Description:         "ID of the agent on which the threat was detected.",
+							MarkdownDescription: "ID of the agent on which the threat was detected.",
+							Computed:            true,
+						},
+						"analyst_verdict": schema.StringAttribute{
+							Description:         "Verdict given to the threat by an analyst.",
+							MarkdownDescription: "Verdict given to the threat by an analyst.",
+							Computed:            true,
+						},
+						"checksums": getChecksumsSchema("threat"),
+						"classification": schema.StringAttribute{
+							Description:         "Classification of the threat (eg: Malware, Ransomware, PUA).",
+							MarkdownDescription: "Classification of the threat (eg: `Malware`, `Ransomware`, `PUA`).",
+							Computed:            true,
+						},
+						"confidence_level": schema.StringAttribute{
+							Description:         "Confidence level of the detection (eg: suspicious, malicious).",
+							MarkdownDescription: "Confidence level of the detection (eg: `suspicious`, `malicious`).",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							Description:         "Timestamp of when the threat was detected.",
+							MarkdownDescription: "Timestamp of when the threat was detected.",
+							Computed:            true,
+						},
+						"group_id": schema.StringAttribute{
+							Description:         "ID of the group in which the threat was detected.",
+							MarkdownDescription: "ID of the group in which the threat was detected.",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							Description:         "ID of the threat.",
+							MarkdownDescription: "ID of the threat.",
+							Computed:            true,
+						},
+						"incident_status": schema.StringAttribute{
+							Description:         "Incident status of the threat.",
+							MarkdownDescription: "Incident status of the threat.",
+							Computed:            true,
+						},
+						"mitigation_status": schema.StringAttribute{
+							Description:         "Mitigation status of the threat.",
+							MarkdownDescription: "Mitigation status of the threat.",
+							Computed:            true,
+						},
+						"sha1": schema.StringAttribute{
+							Description:         "SHA1 hash of the threat's file.",
+							MarkdownDescription: "SHA1 hash of the threat's file.",
+							Computed:            true,
+							DeprecationMessage:  modifiers.RenamedMessage("checksums.sha1"),
+						},
+						"site_id": schema.StringAttribute{
+							Description:         "ID of the site in which the threat was detected.",
+							MarkdownDescription: "ID of the site in which the threat was detected.",
+							Computed:            true,
+						},
+						"site_name": schema.StringAttribute{
+							Description:         "Name of the site in which the threat was detected.",
+							MarkdownDescription: "Name of the site in which the threat was detected.",
+							Computed:            true,
+						},
+						"threat_name": schema.StringAttribute{
+							Description:         "Name of the threat.",
+							MarkdownDescription: "Name of the threat.",
+							Computed:            true,
+						},
+						"updated_at": schema.StringAttribute{
+							Description:         "Timestamp of when the threat was last updated.",
+							MarkdownDescription: "Timestamp of when the threat was last updated.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": schema.SingleNestedBlock{
+				Description:         "Defines the query filters to use when searching for threats.",
+				MarkdownDescription: "Defines the query filters to use when searching for threats.",
+				Attributes: map[string]schema.Attribute{
+					"account_ids": schema.ListAttribute{
+						Description:         "List of account IDs to filter by.",
+						MarkdownDescription: "List of account IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"classifications": schema.ListAttribute{
+						Description:         "List of threat classifications to filter by (eg: Malware, Ransomware).",
+						MarkdownDescription: "List of threat classifications to filter by (eg: `Malware`, `Ransomware`).",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"created_after": schema.StringAttribute{
+						Description: "Threat was detected after the given timestamp (eg: 2023-01-01T00:00:00Z) or " +
+							"relative timestamp (eg: -24h, -7d).",
+						MarkdownDescription: "Threat was detected after the given timestamp (eg: `2023-01-01T00:00:00Z`) " +
+							"or relative timestamp (eg: `-24h`, `-7d`).",
+						Optional: true,
+						Validators: []validator.String{
+							validators.TimestampIsValid(),
+						},
+					},
+					"filter_json": getFilterJSONSchema(),
+					"group_ids": schema.ListAttribute{
+						Description:         "List of group IDs to filter by.",
+						MarkdownDescription: "List of group IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"incident_statuses": schema.ListAttribute{
+						Description: "List of incident statuses to filter by (valid values: unresolved, in_progress, " +
+							"resolved).",
+						MarkdownDescription: "List of incident statuses to filter by (valid values: `unresolved`, " +
+							"`in_progress`, `resolved`).",
+						Optional:    true,
+						ElementType: types.StringType,
+						Validators: []validator.List{
+							validators.EnumStringListValuesAre(false, "unresolved", "in_progress", "resolved"),
+						},
+					},
+					"min_confidence_level": schema.StringAttribute{
+						Description: "Minimum confidence level of the detection (valid values: suspicious, malicious). " +
+							"A value of suspicious matches both suspicious and malicious threats.",
+						MarkdownDescription: "Minimum confidence level of the detection (valid values: `suspicious`, " +
+							"`malicious`). A value of `suspicious` matches both suspicious and malicious threats.",
+						Optional: true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(false, "suspicious", "malicious"),
+						},
+					},
+					"site_ids": schema.ListAttribute{
+						Description:         "List of site IDs to filter by.",
+						MarkdownDescription: "List of site IDs to filter by.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"sort_by": schema.StringAttribute{
+						Description: "Field on which to sort results (valid values: classification, createdAt, id, " +
+							"threatName, updatedAt) [Default: id].",
+						MarkdownDescription: "Field on which to sort results (valid values: `classification`, `createdAt`, " +
+							"`id`, `threatName`, `updatedAt`) [Default: `id`].",
+						Optional: true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(false,
+								"classification", "createdAt", "id", "threatName", "updatedAt",
+							),
+						},
+					},
+					"sort_order": schema.StringAttribute{
+						Description:         "Order in which to sort results (valid values: asc, desc) [Default: asc].",
+						MarkdownDescription: "Order in which to sort results (valid values: `asc`, `desc`) [Default: `asc`].",
+						Optional:            true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(false,
+								"asc", "desc",
+							),
+						},
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"agent_tags": schema.SingleNestedBlock{
+						Description:         "Defines the agent tags used to filter threats.",
+						MarkdownDescription: "Defines the agent tags used to filter threats.",
+						Attributes: map[string]schema.Attribute{
+							"all_of": schema.ListAttribute{
+								Description:         "The agent must have all of the given tags.",
+								MarkdownDescription: "The agent must have all of the given tags.",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+							"any_of": schema.ListAttribute{
+								Description:         "The agent must have at least one of the given tags.",
+								MarkdownDescription: "The agent must have at least one of the given tags.",
+								Optional:            true,
+								ElementType:         types.StringType,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *Threats) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_THREATS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *Threats) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfThreats
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// capture any debug response headers returned by the queries below
+	ctx, recorder := api.RecordResponseHeaders(ctx)
+
+	// construct query parameters
+	queryParams := api.ThreatQueryParams{}
+	if data.Filter != nil {
+		var diags diag.Diagnostics
+		queryParams, diags = d.queryParamsFromFilter(ctx, *data.Filter)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// always sort results so their order is deterministic even if the API server changes its default ordering
+	queryParams.SortBy, queryParams.SortOrder = sortOrDefault(queryParams.SortBy, queryParams.SortOrder)
+
+	// find the matching threats
+	threats, diags := api.Client().FindThreats(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// filter threats by the tags of their agents
+	if data.Filter != nil && data.Filter.AgentTags != nil {
+		threats, diags = d.filterByAgentTags(ctx, threats, *data.Filter.AgentTags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// convert API objects into Terraform objects
+	tfthreats := tfThreats{
+		Filter:  data.Filter,
+		Threats: []tfThreat{},
+	}
+	for _, threat := range threats {
+		tfthreats.Threats = append(tfthreats.Threats, tfThreatFromAPI(ctx, &threat))
+	}
+	debug, diags := tfDebugFromRecorder(ctx, recorder)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tfthreats.Debug = debug
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfthreats)...)
+}
+
+// filterByAgentTags returns only those threats detected on agents whose tags match the given filter.
+func (d *Threats) filterByAgentTags(ctx context.Context, threats []api.Threat, filter tfThreatsAgentTagsFilter) (
+	[]api.Threat, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	allOf := stringsFromList(filter.AllOf)
+	anyOf := stringsFromList(filter.AnyOf)
+	if len(allOf) == 0 && len(anyOf) == 0 {
+		return threats, diags
+	}
+
+	// look up the tags of every agent on which a threat was detected
+	agentIds := []string{}
+	agentTags := map[string][]api.AgentTag{}
+	for _, threat := range threats {
+		if _, ok := agentTags[threat.AgentRealtimeInfo.AgentId]; !ok {
+			agentTags[threat.AgentRealtimeInfo.AgentId] = []api.AgentTag{}
+			agentIds = append(agentIds, threat.AgentRealtimeInfo.AgentId)
+		}
+	}
+	for start := 0; start < len(agentIds); start += threatAgentLookupBatchSize {
+		end := start + threatAgentLookupBatchSize
+		if end > len(agentIds) {
+			end = len(agentIds)
+		}
+		agents, diags := api.Client().FindAgents(ctx, api.AgentQueryParams{AgentIds: agentIds[start:end]})
+		if diags.HasError() {
+			return nil, diags
+		}
+		for _, agent := range agents {
+			agentTags[agent.Id] = agent.Tags.SentinelOne
+		}
+	}
+
+	// keep the threats whose agents match all of the tags in allOf and at least one of the tags in anyOf
+	filtered := []api.Threat{}
+	for _, threat := range threats {
+		tags := agentTags[threat.AgentRealtimeInfo.AgentId]
+		matches := true
+		for _, tag := range allOf {
+			if !agentHasTag(tags, tag) {
+				matches = false
+				break
+			}
+		}
+		if matches && len(anyOf) > 0 {
+			matches = false
+			for _, tag := range anyOf {
+				if agentHasTag(tags, tag) {
+					matches = true
+					break
+				}
+			}
+		}
+		if matches {
+			filtered = append(filtered, threat)
+		}
+	}
+	return filtered, diags
+}
+
+// queryParamsFromFilter converts the TF filter block into API query parameters.
+func (d *Threats) queryParamsFromFilter(ctx context.Context, filter tfThreatsFilter) (api.ThreatQueryParams,
+	diag.Diagnostics) {
+	queryParams := api.ThreatQueryParams{}
+
+	// relative timestamps are resolved against the time at which the data source is read
+	now := time.Now()
+
+	rawFilter, diags := rawFilterFromJSON(ctx, filter.FilterJSON)
+	if diags.HasError() {
+		return queryParams, diags
+	}
+	queryParams.RawFilter = rawFilter
+
+	if len(filter.AccountIds) > 0 {
+		queryParams.AccountIds = stringsFromList(filter.AccountIds)
+	}
+
+	if len(filter.Classifications) > 0 {
+		queryParams.Classifications = stringsFromList(filter.Classifications)
+	}
+
+	if !filter.CreatedAfter.IsNull() && !filter.CreatedAfter.IsUnknown() {
+		value, diags := plugin.ResolveTimestamp(ctx, filter.CreatedAfter.ValueString(), now)
+		if diags.HasError() {
+			return queryParams, diags
+		}
+		queryParams.CreatedAtGt = &value
+	}
+
+	if len(filter.GroupIds) > 0 {
+		queryParams.GroupIds = stringsFromList(filter.GroupIds)
+	}
+
+	if len(filter.IncidentStatuses) > 0 {
+		queryParams.IncidentStatuses = stringsFromList(filter.IncidentStatuses)
+	}
+
+	if !filter.MinConfidenceLevel.IsNull() && !filter.MinConfidenceLevel.IsUnknown() {
+		switch filter.MinConfidenceLevel.ValueString() {
+		case "suspicious":
+			queryParams.ConfidenceLevels = []string{"suspicious", "malicious"}
+		case "malicious":
+			queryParams.ConfidenceLevels = []string{"malicious"}
+		}
+	}
+
+	if len(filter.SiteIds) > 0 {
+		queryParams.SiteIds = stringsFromList(filter.SiteIds)
+	}
+
+	if !filter.SortBy.IsNull() && !filter.SortBy.IsUnknown() {
+		value := filter.SortBy.ValueString()
+		queryParams.SortBy = &value
+	}
+
+	if !filter.SortOrder.IsNull() && !filter.SortOrder.IsUnknown() {
+		value := filter.SortOrder.ValueString()
+		queryParams.SortOrder = &value
+	}
+	return queryParams, diags
+}
+
+// agentHasTag determines whether or not the given agent tags include the given tag.
+//
+// The tag is either a key, in which case any value matches, or a key=value pair.
+func agentHasTag(tags []api.AgentTag, tag string) bool {
+	key, value, hasValue := strings.Cut(tag, "=")
+	for _, t := range tags {
+		if t.Key == key && (!hasValue || t.Value == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// tfThreatFromAPI converts an API threat into a Terraform threat.
+func tfThreatFromAPI(ctx context.Context, threat *api.Threat) tfThreat {
+	tfthreat := tfThreat{
+		AccountId:         types.StringValue(threat.AgentRealtimeInfo.AccountId),
+		AgentComputerName: types.StringValue(threat.AgentRealtimeInfo.AgentComputerName),
+		AgentId:           types.StringValue(threat.AgentRealtimeInfo.AgentId),
+		AnalystVerdict:    types.StringValue(threat.ThreatInfo.AnalystVerdict),
+		Checksums: tfChecksums{
+			SHA1:   types.StringValue(threat.ThreatInfo.SHA1),
+			SHA256: types.StringValue(threat.ThreatInfo.SHA256),
+		},
+		Classification:   types.StringValue(threat.ThreatInfo.Classification),
+		ConfidenceLevel:  types.StringValue(threat.ThreatInfo.ConfidenceLevel),
+		CreatedAt:        types.StringValue(threat.ThreatInfo.CreatedAt),
+		GroupId:          types.StringValue(threat.AgentRealtimeInfo.GroupId),
+		Id:               types.StringValue(threat.Id),
+		IncidentStatus:   types.StringValue(threat.ThreatInfo.IncidentStatus),
+		MitigationStatus: types.StringValue(threat.ThreatInfo.MitigationStatus),
+		SHA1:             types.StringValue(threat.ThreatInfo.SHA1),
+		SiteId:           types.StringValue(threat.AgentRealtimeInfo.SiteId),
+		SiteName:         types.StringValue(threat.AgentRealtimeInfo.SiteName),
+		ThreatName:       types.StringValue(threat.ThreatInfo.ThreatName),
+		UpdatedAt:        types.StringValue(threat.ThreatInfo.UpdatedAt),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API threat to TF threat: %+v", tfthreat), map[string]interface{}{
+		"api_threat": threat,
+	})
+	return tfthreat
+}
@@ -0,0 +1,409 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ datasource.DataSource              = &UnmanagedObjects{}
+	_ datasource.DataSourceWithConfigure = &UnmanagedObjects{}
+)
+
+// tfUnmanagedObjects defines the Terraform model for unmanaged objects.
+type tfUnmanagedObjects struct {
+	AccountIds             []types.String            `tfsdk:"account_ids"`
+	FailIfUnmanaged        types.Bool                `tfsdk:"fail_if_unmanaged"`
+	GroupIds               []types.String            `tfsdk:"group_ids"`
+	Id                     types.String              `tfsdk:"id"`
+	ManagedExclusionIds    []types.String            `tfsdk:"managed_exclusion_ids"`
+	ManagedFirewallRuleIds []types.String            `tfsdk:"managed_firewall_rule_ids"`
+	ManagedGroupIds        []types.String            `tfsdk:"managed_group_ids"`
+	SiteIds                []types.String            `tfsdk:"site_ids"`
+	UnmanagedCount         types.Int64               `tfsdk:"unmanaged_count"`
+	UnmanagedExclusions    []tfUnmanagedExclusion    `tfsdk:"unmanaged_exclusions"`
+	UnmanagedFirewallRules []tfUnmanagedFirewallRule `tfsdk:"unmanaged_firewall_rules"`
+	UnmanagedGroups        []tfUnmanagedGroup        `tfsdk:"unmanaged_groups"`
+}
+
+// tfUnmanagedExclusion defines the Terraform model for an exclusion not managed by Terraform.
+type tfUnmanagedExclusion struct {
+	Description types.String `tfsdk:"description"`
+	Id          types.String `tfsdk:"id"`
+	OSType      types.String `tfsdk:"os_type"`
+	ScopeName   types.String `tfsdk:"scope_name"`
+	Type        types.String `tfsdk:"type"`
+	UserName    types.String `tfsdk:"user_name"`
+	Value       types.String `tfsdk:"value"`
+}
+
+// tfUnmanagedFirewallRule defines the Terraform model for a firewall rule not managed by Terraform.
+type tfUnmanagedFirewallRule struct {
+	Id     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Scope  types.String `tfsdk:"scope"`
+	Status types.String `tfsdk:"status"`
+}
+
+// tfUnmanagedGroup defines the Terraform model for a group not managed by Terraform.
+type tfUnmanagedGroup struct {
+	Id     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	SiteId types.String `tfsdk:"site_id"`
+	Type   types.String `tfsdk:"type"`
+}
+
+// NewUnmanagedObjects creates a new UnmanagedObjects object.
+func NewUnmanagedObjects() datasource.DataSource {
+	return &UnmanagedObjects{}
+}
+
+// UnmanagedObjects is a data source used to report objects in the console which are not managed by Terraform.
+type UnmanagedObjects struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the data source.
+func (d *UnmanagedObjects) Metadata(ctx context.Context, req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_unmanaged_objects"
+}
+
+// Schema defines the parameters for the data sources's configuration.
+func (d *UnmanagedObjects) Schema(ctx context.Context, req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source can be used for finding exclusions, firewall rules and groups which exist in " +
+			"the console but are not managed by Terraform.",
+		MarkdownDescription: `This data source can be used for finding exclusions, firewall rules and groups which exist
+		in the console but are not managed by Terraform.
+
+		Pass the IDs of the objects managed in your configuration (eg: ` + "`[for g in singularity_group.all : g.id]`" + `)
+		and the data source will return every object within the given scope whose ID is not in the list. Set
+		` + "`fail_if_unmanaged`" + ` to ` + "`true`" + ` to fail the plan whenever drift is detected.
+
+		Default groups are created automatically with each site and are never reported as unmanaged.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"account_ids": schema.ListAttribute{
+				Description:         "List of account IDs used to limit the scope of the search.",
+				MarkdownDescription: "List of account IDs used to limit the scope of the search.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"fail_if_unmanaged": schema.BoolAttribute{
+				Description:         "Whether or not to return an error if any unmanaged objects are found.",
+				MarkdownDescription: "Whether or not to return an error if any unmanaged objects are found.",
+				Optional:            true,
+			},
+			"group_ids": schema.ListAttribute{
+				Description:         "List of group IDs used to limit the scope of the search.",
+				MarkdownDescription: "List of group IDs used to limit the scope of the search.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Description:         "Identifier for the report.",
+				MarkdownDescription: "Identifier for the report.",
+				Computed:            true,
+			},
+			"managed_exclusion_ids": schema.ListAttribute{
+				Description:         "List of exclusion IDs managed by Terraform.",
+				MarkdownDescription: "List of exclusion IDs managed by Terraform.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"managed_firewall_rule_ids": schema.ListAttribute{
+				Description:         "List of firewall rule IDs managed by Terraform.",
+				MarkdownDescription: "List of firewall rule IDs managed by Terraform.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"managed_group_ids": schema.ListAttribute{
+				Description:         "List of group IDs managed by Terraform.",
+				MarkdownDescription: "List of group IDs managed by Terraform.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"site_ids": schema.ListAttribute{
+				Description:         "List of site IDs used to limit the scope of the search.",
+				MarkdownDescription: "List of site IDs used to limit the scope of the search.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"unmanaged_count": schema.Int64Attribute{
+				Description:         "Total number of unmanaged objects that were found.",
+				MarkdownDescription: "Total number of unmanaged objects that were found.",
+				Computed:            true,
+			},
+			"unmanaged_exclusions": schema.ListNestedAttribute{
+				Description:         "List of exclusions which are not managed by Terraform.",
+				MarkdownDescription: "List of exclusions which are not managed by Terraform.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							Description:         "Description of the exclusion.",
+							MarkdownDescription: "Description of the exclusion.",
+							Computed:            true,
+						},
+						"id": schema.StringAttribute{
+							Description:         "ID of the exclusion.",
+							MarkdownDescription: "ID of the exclusion.",
+							Computed:            true,
+						},
+						"os_type": schema.StringAttribute{
+							Description:         "Operating system the exclusion applies to.",
+							MarkdownDescription: "Operating system the exclusion applies to.",
+							Computed:            true,
+						},
+						"scope_name": schema.StringAttribute{
+							Description:         "Name of the scope in which the exclusion was created.",
+							MarkdownDescription: "Name of the scope in which the exclusion was created.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							Description:         "Type of exclusion.",
+							MarkdownDescription: "Type of exclusion.",
+							Computed:            true,
+						},
+						"user_name": schema.StringAttribute{
+							Description:         "Name of the user who created the exclusion.",
+							MarkdownDescription: "Name of the user who created the exclusion.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							Description:         "Value of the exclusion.",
+							MarkdownDescription: "Value of the exclusion.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"unmanaged_firewall_rules": schema.ListNestedAttribute{
+				Description:         "List of firewall rules which are not managed by Terraform.",
+				MarkdownDescription: "List of firewall rules which are not managed by Terraform.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "ID of the firewall rule.",
+							MarkdownDescription: "ID of the firewall rule.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "Name of the firewall rule.",
+							MarkdownDescription: "Name of the firewall rule.",
+							Computed:            true,
+						},
+						"scope": schema.StringAttribute{
+							Description:         "Scope in which the firewall rule was created.",
+							MarkdownDescription: "Scope in which the firewall rule was created.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "Status of the firewall rule.",
+							MarkdownDescription: "Status of the firewall rule.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"unmanaged_groups": schema.ListNestedAttribute{
+				Description:         "List of groups which are not managed by Terraform.",
+				MarkdownDescription: "List of groups which are not managed by Terraform.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "ID of the group.",
+							MarkdownDescription: "ID of the group.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "Name of the group.",
+							MarkdownDescription: "Name of the group.",
+							Computed:            true,
+						},
+						"site_id": schema.StringAttribute{
+							Description:         "ID of the site to which the group belongs.",
+							MarkdownDescription: "ID of the site to which the group belongs.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							Description:         "Type of group.",
+							MarkdownDescription: "Type of group.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the data source.
+func (d *UnmanagedObjects) Configure(ctx context.Context, req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_UNMANAGED_OBJECTS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	d.data = providerData
+}
+
+// Read retrieves data from the API.
+func (d *UnmanagedObjects) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data tfUnmanagedObjects
+
+	// read configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	accountIds := stringsFromList(data.AccountIds)
+	siteIds := stringsFromList(data.SiteIds)
+	groupIds := stringsFromList(data.GroupIds)
+
+	// find exclusions which are not managed
+	exclusions, diags := api.Client().FindExclusions(ctx, api.ExclusionQueryParams{
+		AccountIds: accountIds,
+		GroupIds:   groupIds,
+		SiteIds:    siteIds,
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	managed := idSetFromList(data.ManagedExclusionIds)
+	data.UnmanagedExclusions = []tfUnmanagedExclusion{}
+	for _, e := range exclusions {
+		if _, ok := managed[e.Id]; ok {
+			continue
+		}
+		data.UnmanagedExclusions = append(data.UnmanagedExclusions, tfUnmanagedExclusion{
+			Description: types.StringValue(e.Description),
+			Id:          types.StringValue(e.Id),
+			OSType:      types.StringValue(e.OSType),
+			ScopeName:   types.StringValue(e.ScopeName),
+			Type:        types.StringValue(e.Type),
+			UserName:    types.StringValue(e.UserName),
+			Value:       types.StringValue(e.Value),
+		})
+	}
+
+	// find firewall rules which are not managed
+	rules, diags := api.Client().FindFirewallRules(ctx, api.FirewallRuleQueryParams{
+		AccountIds: accountIds,
+		GroupIds:   groupIds,
+		SiteIds:    siteIds,
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	managed = idSetFromList(data.ManagedFirewallRuleIds)
+	data.UnmanagedFirewallRules = []tfUnmanagedFirewallRule{}
+	for _, r := range rules {
+		if _, ok := managed[r.Id]; ok {
+			continue
+		}
+		data.UnmanagedFirewallRules = append(data.UnmanagedFirewallRules, tfUnmanagedFirewallRule{
+			Id:     types.StringValue(r.Id),
+			Name:   types.StringValue(r.Name),
+			Scope:  types.StringValue(r.Scope),
+			Status: types.StringValue(r.Status),
+		})
+	}
+
+	// find groups which are not managed - default groups are created with each site and cannot be removed
+	isDefault := false
+	groups, diags := api.Client().FindGroups(ctx, api.GroupQueryParams{
+		AccountIds: accountIds,
+		GroupIds:   groupIds,
+		IsDefault:  &isDefault,
+		SiteIds:    siteIds,
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	managed = idSetFromList(data.ManagedGroupIds)
+	data.UnmanagedGroups = []tfUnmanagedGroup{}
+	for _, g := range groups {
+		if _, ok := managed[g.Id]; ok {
+			continue
+		}
+		data.UnmanagedGroups = append(data.UnmanagedGroups, tfUnmanagedGroup{
+			Id:     types.StringValue(g.Id),
+			Name:   types.StringValue(g.Name),
+			SiteId: types.StringValue(g.SiteId),
+			Type:   types.StringValue(g.Type),
+		})
+	}
+
+	count := len(data.UnmanagedExclusions) + len(data.UnmanagedFirewallRules) + len(data.UnmanagedGroups)
+	data.UnmanagedCount = types.Int64Value(int64(count))
+	data.Id = types.StringValue(fmt.Sprintf("accounts=%s;sites=%s;groups=%s", strings.Join(accountIds, ","),
+		strings.Join(siteIds, ","), strings.Join(groupIds, ",")))
+
+	// fail if requested and drift was found
+	if count > 0 && !data.FailIfUnmanaged.IsNull() && !data.FailIfUnmanaged.IsUnknown() &&
+		data.FailIfUnmanaged.ValueBool() {
+		msg := fmt.Sprintf("Found %d object(s) in the console which are not managed by Terraform (%d exclusion(s), "+
+			"%d firewall rule(s), %d group(s)).", count, len(data.UnmanagedExclusions),
+			len(data.UnmanagedFirewallRules), len(data.UnmanagedGroups))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_DATASOURCE_UNMANAGED_OBJECTS_READ,
+			"unmanaged_count":     count,
+		})
+		resp.Diagnostics.AddError("Unmanaged Objects Found", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+}
+
+// stringsFromList converts a list of TF strings into a list of strings, ignoring null and unknown values.
+func stringsFromList(list []types.String) []string {
+	values := []string{}
+	for _, e := range list {
+		if !e.IsNull() && !e.IsUnknown() {
+			values = append(values, e.ValueString())
+		}
+	}
+	return values
+}
+
+// idSetFromList converts a list of TF strings into a set of IDs for quick lookup.
+func idSetFromList(list []types.String) map[string]struct{} {
+	ids := map[string]struct{}{}
+	for _, id := range stringsFromList(list) {
+		ids[id] = struct{}{}
+	}
+	return ids
+}
@@ -0,0 +1,100 @@
+package modifiers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// RenamedMessage returns the deprecation message used for an attribute which has been renamed to the given
+// attribute.
+//
+// When an attribute is renamed, the old attribute is kept with this message as its DeprecationMessage until the next
+// major release so that existing configurations keep working but users are warned to move to the new name. Both
+// attributes are always populated when reading so that references to either one return the same value. Where the
+// attribute can be configured, both attributes should also be made optional and computed with StringAliasOf applied
+// to each of them so that configurations using either name produce the same plan.
+func RenamedMessage(newName string) string {
+	return fmt.Sprintf("This attribute has been renamed to %s and will be removed in the next major release. Use %s "+
+		"instead.", newName, newName)
+}
+
+// ensure implementation satisfied expected interfaces
+var _ planmodifier.String = stringAlias{}
+
+// StringAliasOf returns a plan modifier which plans the value configured for the attribute at the given path when
+// this attribute is not configured.
+//
+// It is used for both the old and new names of a renamed attribute so that the value given under either name is
+// planned for both of them. An error is returned if both attributes are configured with different values.
+func StringAliasOf(other path.Expression) planmodifier.String {
+	return stringAlias{
+		other: other,
+	}
+}
+
+// stringAlias holds details about the string alias plan modifier.
+type stringAlias struct {
+	// other is the path of the attribute of which this attribute is an alias.
+	other path.Expression
+}
+
+// Description returns a plain text description of the modifier's behavior, suitable for a practitioner to
+// understand its impact.
+func (m stringAlias) Description(ctx context.Context) string {
+	return fmt.Sprintf("uses the value configured for %s if this attribute is not configured", m.other)
+}
+
+// MarkdownDescription returns a markdown formatted description of the modifier's behavior, suitable for a
+// practitioner to understand its impact.
+func (m stringAlias) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("uses the value configured for `%s` if this attribute is not configured", m.other)
+}
+
+// PlanModifyString runs the main logic of the modifier, reading configuration data out of `req` and updating
+// `resp` with the planned value and any diagnostics.
+func (m stringAlias) PlanModifyString(ctx context.Context, req planmodifier.StringRequest,
+	resp *planmodifier.StringResponse) {
+
+	paths, diags := req.Config.PathMatches(ctx, req.PathExpression.Merge(m.other))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, otherPath := range paths {
+		var other types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, otherPath, &other)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if other.IsNull() {
+			continue
+		}
+
+		// only one of the names may be used unless both are given the same value
+		if !req.ConfigValue.IsNull() {
+			if req.ConfigValue.IsUnknown() || other.IsUnknown() || req.ConfigValue.Equal(other) {
+				continue
+			}
+			msg := fmt.Sprintf("The attribute %s is an alias of %s, so they cannot be given different values. "+
+				"Remove one of them from the configuration.\n\n%s: %s\n%s: %s", req.Path.String(),
+				otherPath.String(), req.Path.String(), req.ConfigValue.ValueString(), otherPath.String(),
+				other.ValueString())
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"attribute":           req.Path.String(),
+				"alias":               otherPath.String(),
+				"internal_error_code": plugin.ERR_MODIFIER_ALIAS_CONFLICT,
+			})
+			resp.Diagnostics.AddAttributeError(req.Path, "Conflicting Attribute Values", msg)
+			return
+		}
+		resp.PlanValue = other
+		return
+	}
+}
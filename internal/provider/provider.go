@@ -2,12 +2,17 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
@@ -15,6 +20,7 @@ import (
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/datasources"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/resources"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
 )
 
 // ensure SingularityProvider satisfies various provider interfaces.
@@ -22,17 +28,44 @@ var _ provider.Provider = &SingularityProvider{}
 
 // SingularityProviderModel describes the provider data model.
 type SingularityProviderModel struct {
+	// AccountApiTokens contains the API tokens used for resources managed on behalf of child accounts.
+	AccountApiTokens map[string]types.String `tfsdk:"account_api_tokens"`
+
 	// ApiToken contains the API token used to interact with the REST API.
 	ApiToken types.String `tfsdk:"api_token"`
 
 	// ApiEndpoint contains the hostname used in the base URL for querying the REST API.
 	ApiEndpoint types.String `tfsdk:"api_endpoint"`
+
+	// ApiEndpointIP contains the IP address to connect to instead of resolving the API endpoint hostname.
+	ApiEndpointIP types.String `tfsdk:"api_endpoint_ip"`
+
+	// DebugResponseHeaders contains the names of the response headers to capture for debugging purposes.
+	DebugResponseHeaders []types.String `tfsdk:"debug_response_headers"`
+
+	// MaintenanceRetryTimeout is the maximum time to keep retrying requests while the console is under maintenance.
+	MaintenanceRetryTimeout types.String `tfsdk:"maintenance_retry_timeout"`
+
+	// NormalizeIds determines whether or not IDs returned as numbers by the API are converted to strings.
+	NormalizeIds types.Bool `tfsdk:"normalize_ids"`
+
+	// StrictDecoding determines whether or not unknown fields in API responses cause parsing to fail.
+	StrictDecoding types.Bool `tfsdk:"strict_decoding"`
 }
 
 // SingularityProvider defines the provider implementation.
 type SingularityProvider struct {
 	// NOTE: we do not have the REST API client here because in certain cases it is needed before it is available
 	//       to data sources / resources so a globally accessible singleton is used instead.
+
+	// testApiEndpoint overrides any configured API endpoint when the provider is created for testing.
+	testApiEndpoint string
+
+	// testApiToken overrides any configured API token when the provider is created for testing.
+	testApiToken string
+
+	// testHTTPClient overrides the HTTP client used by the REST API client when the provider is created for testing.
+	testHTTPClient *http.Client
 }
 
 // New creates a new instance of the provider.
@@ -54,14 +87,62 @@ func (p *SingularityProvider) Metadata(ctx context.Context, req provider.Metadat
 func (p *SingularityProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"account_api_tokens": schema.MapAttribute{
+				MarkdownDescription: "API tokens scoped to child accounts, keyed by account ID. Resources with " +
+					"`scope_account_id` set use the matching token instead of `api_token`, allowing one provider " +
+					"configuration to manage several child tenants. Only the `singularity_account_policy`, " +
+					"`singularity_exclusion`, `singularity_exclusions_bulk`, `singularity_group`, " +
+					"`singularity_hash_allowlist`, `singularity_policy` and `singularity_site` resources support " +
+					"`scope_account_id`; all other resources and data sources always use `api_token`.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
 			"api_token": schema.StringAttribute{
 				MarkdownDescription: "API key used to query the SentinelOne Singularity API",
 				Optional:            true,
+				Sensitive:           true,
 			},
 			"api_endpoint": schema.StringAttribute{
 				MarkdownDescription: "The FQDN to use for all API queries, excluding 'https://'",
 				Optional:            true,
 			},
+			"api_endpoint_ip": schema.StringAttribute{
+				MarkdownDescription: "IP address to connect to instead of resolving the hostname in `api_endpoint` " +
+					"using DNS (eg: for split-horizon DNS or testing before a cutover). The hostname is still used " +
+					"for TLS verification. Can also be set using the `SINGULARITY_API_ENDPOINT_IP` environment " +
+					"variable. [Default: none]",
+				Optional: true,
+			},
+			"debug_response_headers": schema.ListAttribute{
+				MarkdownDescription: "Names of the API response headers (eg: rate limit or server version headers) to " +
+					"log for every API query and expose in the `debug` attribute of list data sources. Useful for " +
+					"diagnosing throttling in large tenants.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"maintenance_retry_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to keep retrying API queries while the management console is " +
+					"under maintenance (eg: `30m`). Queries are retried every 30 seconds, or as directed by the " +
+					"console. [Default: none - queries fail immediately with a single maintenance error]",
+				Optional: true,
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"normalize_ids": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not IDs returned as JSON numbers by the few API endpoints which do so " +
+					"are converted to strings before they are parsed. IDs are too large to be represented exactly as " +
+					"numbers, so this should only be disabled when troubleshooting. [Default: `true`]",
+				Optional: true,
+			},
+			"strict_decoding": schema.BoolAttribute{
+				MarkdownDescription: "Whether or not fields returned by the API which the provider does not know " +
+					"about cause the response to fail to parse. This surfaces changes to the console's API early and " +
+					"is intended for lower environments only, since the provider only models the fields it uses. " +
+					"[Default: `false`]",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -73,6 +154,7 @@ func (p *SingularityProvider) Configure(ctx context.Context, req provider.Config
 	// environment variables take precedence over configuration variables
 	apiToken := os.Getenv("SINGULARITY_API_TOKEN")
 	apiEndpoint := os.Getenv("SINGULARITY_API_ENDPOINT")
+	apiEndpointIP := os.Getenv("SINGULARITY_API_ENDPOINT_IP")
 
 	// read configuration
 	var config SingularityProviderModel
@@ -86,6 +168,17 @@ func (p *SingularityProvider) Configure(ctx context.Context, req provider.Config
 	if apiEndpoint == "" {
 		apiEndpoint = config.ApiEndpoint.ValueString()
 	}
+	if apiEndpointIP == "" {
+		apiEndpointIP = config.ApiEndpointIP.ValueString()
+	}
+
+	// test providers never talk to anything other than the endpoint they were created with
+	if p.testApiEndpoint != "" {
+		apiEndpoint = p.testApiEndpoint
+	}
+	if p.testApiToken != "" {
+		apiToken = p.testApiToken
+	}
 
 	// check required configuration variables
 	if apiToken == "" {
@@ -106,6 +199,15 @@ func (p *SingularityProvider) Configure(ctx context.Context, req provider.Config
 		})
 		resp.Diagnostics.AddError("Missing API Endpoint Configuration", msg)
 	}
+	if apiEndpointIP != "" && net.ParseIP(apiEndpointIP) == nil {
+		msg := fmt.Sprintf("While configuring the provider, the API endpoint IP address was not a valid IPv4 or "+
+			"IPv6 address.\n\nAPI Endpoint IP: %s", apiEndpointIP)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_PROVIDER_CONFIGURE,
+		})
+		resp.Diagnostics.AddError("Invalid API Endpoint IP Configuration", msg)
+		return
+	}
 
 	// share the configuration with resources and data sources
 	d := &data.SingularityProvider{}
@@ -116,25 +218,133 @@ func (p *SingularityProvider) Configure(ctx context.Context, req provider.Config
 	// NOTE: we are not storing the API client in the provider because in some instances the client may be needed before
 	//       the provider data is available to the specific data source or resource
 	api.Client().Init(apiEndpoint, apiToken)
+	if p.testHTTPClient != nil {
+		api.Client().SetHTTPClient(p.testHTTPClient)
+	} else if apiEndpointIP != "" {
+		api.Client().SetEndpointIP(apiEndpointIP)
+	}
+	debugHeaders := []string{}
+	for _, h := range config.DebugResponseHeaders {
+		if !h.IsNull() && !h.IsUnknown() {
+			debugHeaders = append(debugHeaders, h.ValueString())
+		}
+	}
+	api.Client().SetDebugResponseHeaders(debugHeaders)
+	accountTokens := map[string]string{}
+	for id, token := range config.AccountApiTokens {
+		if !token.IsNull() && !token.IsUnknown() {
+			accountTokens[id] = token.ValueString()
+		}
+	}
+	api.Client().SetAccountTokens(accountTokens)
+	api.Client().SetNormalizeIds(config.NormalizeIds.IsNull() || config.NormalizeIds.ValueBool())
+	api.Client().SetStrictDecoding(config.StrictDecoding.ValueBool())
+	var maintenanceRetryTimeout time.Duration
+	if !config.MaintenanceRetryTimeout.IsNull() && !config.MaintenanceRetryTimeout.IsUnknown() {
+		timeout, diags := plugin.ParseRelativeDuration(ctx, config.MaintenanceRetryTimeout.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		maintenanceRetryTimeout = timeout
+	}
+	api.Client().SetMaintenanceRetryTimeout(maintenanceRetryTimeout)
 	tflog.Debug(ctx, "REST API client has been initialized.")
 }
 
 // DataSources defines the various data sources from which the provider can read data.
 func (p *SingularityProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		datasources.NewAccount,
+		datasources.NewAccounts,
+		datasources.NewAgent,
+		datasources.NewAgents,
+		datasources.NewAgentsExport,
+		datasources.NewExclusions,
 		datasources.NewGroup,
 		datasources.NewGroups,
+		datasources.NewGroupsWithSites,
+		datasources.NewMaskedToken,
 		datasources.NewPackage,
 		datasources.NewPackages,
+		datasources.NewPreflight,
+		datasources.NewProviderInfo,
 		datasources.NewSite,
 		datasources.NewSites,
+		datasources.NewThreatMitigationStatus,
+		datasources.NewThreats,
+		datasources.NewUnmanagedObjects,
 	}
 }
 
 // Resources defines the various resources that the provider can create.
 func (p *SingularityProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		resources.NewAccount,
+		resources.NewAccountPolicy,
+		resources.NewAgentAnnotation,
+		resources.NewAgentFetchLogs,
+		resources.NewAgentMove,
+		resources.NewAgentScan,
+		resources.NewAgentTagAssignment,
+		resources.NewAgentUninstall,
+		resources.NewAgentUpgradePolicy,
+		resources.NewAgentsUpgrade,
+		resources.NewAlertRule,
+		resources.NewApiToken,
+		resources.NewAwsCloudConnector,
+		resources.NewAzureCloudConnector,
+		resources.NewBlocklistHash,
+		resources.NewCloudFunnel,
+		resources.NewCnsPolicy,
+		resources.NewConfigOverride,
+		resources.NewConsole2FASettings,
+		resources.NewDeviceControlRule,
+		resources.NewEmailNotificationRecipient,
+		resources.NewEventForwarding,
+		resources.NewEvidenceBundle,
+		resources.NewExclusion,
+		resources.NewExclusionsBulk,
+		resources.NewFilter,
+		resources.NewFirewallRuleOrder,
+		resources.NewGcpCloudConnector,
+		resources.NewGroup,
+		resources.NewHashAllowlist,
+		resources.NewIOC,
+		resources.NewIOCBulk,
+		resources.NewK8sAdmissionPolicy,
+		resources.NewK8sAgentHelmRelease,
+		resources.NewK8sAgentManifest,
 		resources.NewK8sAgentPackageLoader,
+		resources.NewK8sAgentRegistryCopy,
+		resources.NewMarketplaceApp,
+		resources.NewMobilePolicy,
+		resources.NewNetworkLocation,
+		resources.NewNetworkQuarantine,
+		resources.NewNotificationRule,
 		resources.NewPackageDownload,
+		resources.NewPolicy,
+		resources.NewPolicyInheritance,
+		resources.NewProtectedActions,
+		resources.NewRangerDeployJob,
+		resources.NewRangerSettings,
+		resources.NewReportSchedule,
+		resources.NewRole,
+		resources.NewRSOExecution,
+		resources.NewRSOScript,
+		resources.NewSavedPowerQuery,
+		resources.NewSdlIngestionConfig,
+		resources.NewServiceUser,
+		resources.NewSite,
+		resources.NewSiteRegistrationTokenRotation,
+		resources.NewSiteSet,
+		resources.NewSSOSAML,
+		resources.NewSyslogConnector,
+		resources.NewTag,
+		resources.NewThreatIntelligenceFeed,
+		resources.NewThreatMitigation,
+		resources.NewThreatNote,
+		resources.NewWebhook,
+		resources.NewXdrConnector,
 	}
 }
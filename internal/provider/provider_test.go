@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/mockapi"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// TestSitesDataSourceWithMockAPI configures the provider against the mock API server using the fixtures of the
+// Terraform tests in examples/tests and reads the singularity_sites data source.
+func TestSitesDataSourceWithMockAPI(t *testing.T) {
+	for _, name := range []string{"SINGULARITY_API_TOKEN", "SINGULARITY_API_ENDPOINT", "SINGULARITY_API_ENDPOINT_IP"} {
+		t.Setenv(name, "")
+	}
+	server := httptest.NewServer(mockapi.NewHandler(os.DirFS("../../examples/tests/fixtures"), nil))
+	defer server.Close()
+
+	ctx := context.Background()
+	providerServer, err := NewTestProviderFactories(server.URL, "mock-api-token",
+		server.Client())[plugin.PROVIDER_NAME]()
+	if err != nil {
+		t.Fatalf("failed to create provider server: %s", err.Error())
+	}
+	schemaResp, err := providerServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("failed to get provider schema: %s", err.Error())
+	}
+	checkDiagnostics(t, schemaResp.Diagnostics)
+
+	// configure the provider with an empty configuration
+	configureResp, err := providerServer.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.5.0",
+		Config:           nullConfig(t, schemaResp.Provider),
+	})
+	if err != nil {
+		t.Fatalf("failed to configure provider: %s", err.Error())
+	}
+	checkDiagnostics(t, configureResp.Diagnostics)
+
+	// read the data source without any filters
+	typeName := plugin.PROVIDER_NAME + "_sites"
+	dataSourceSchema, ok := schemaResp.DataSourceSchemas[typeName]
+	if !ok {
+		t.Fatalf("data source %s is not registered", typeName)
+	}
+	readResp, err := providerServer.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   nullConfig(t, dataSourceSchema),
+	})
+	if err != nil {
+		t.Fatalf("failed to read data source: %s", err.Error())
+	}
+	checkDiagnostics(t, readResp.Diagnostics)
+
+	// the fixtures contain a single default site
+	state, err := readResp.State.Unmarshal(dataSourceSchema.ValueType())
+	if err != nil {
+		t.Fatalf("failed to unmarshal data source state: %s", err.Error())
+	}
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		t.Fatalf("failed to convert data source state: %s", err.Error())
+	}
+	var sites []tftypes.Value
+	if err := attrs["sites"].As(&sites); err != nil {
+		t.Fatalf("failed to convert sites: %s", err.Error())
+	}
+	if len(sites) != 1 {
+		t.Fatalf("expected 1 site but found %d", len(sites))
+	}
+	var site map[string]tftypes.Value
+	if err := sites[0].As(&site); err != nil {
+		t.Fatalf("failed to convert site: %s", err.Error())
+	}
+	var id string
+	if err := site["id"].As(&id); err != nil {
+		t.Fatalf("failed to convert site ID: %s", err.Error())
+	}
+	if id != "1000000000000000010" {
+		t.Errorf("unexpected site ID: %s", id)
+	}
+}
+
+// checkDiagnostics fails the test if any of the given diagnostics is an error.
+func checkDiagnostics(t *testing.T, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+// nullConfig returns a configuration for the given schema in which every attribute and block is null.
+func nullConfig(t *testing.T, schema *tfprotov6.Schema) *tfprotov6.DynamicValue {
+	t.Helper()
+	objectType := schema.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	config, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("failed to create configuration: %s", err.Error())
+	}
+	return &config
+}
@@ -0,0 +1,509 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Account{}
+	_ resource.ResourceWithConfigure   = &Account{}
+	_ resource.ResourceWithImportState = &Account{}
+)
+
+// tfAccount defines the Terraform model for an account.
+type tfAccount struct {
+	AccountType         types.String `tfsdk:"account_type"`
+	ActiveAgents        types.Int64  `tfsdk:"active_agents"`
+	BillingMode         types.String `tfsdk:"billing_mode"`
+	CreatedAt           types.String `tfsdk:"created_at"`
+	Creator             types.String `tfsdk:"creator"`
+	CreatorId           types.String `tfsdk:"creator_id"`
+	Expiration          types.String `tfsdk:"expiration"`
+	ExternalId          types.String `tfsdk:"external_id"`
+	Id                  types.String `tfsdk:"id"`
+	IsDefault           types.Bool   `tfsdk:"is_default"`
+	Modules             types.Set    `tfsdk:"modules"`
+	Name                types.String `tfsdk:"name"`
+	NumberOfSites       types.Int64  `tfsdk:"number_of_sites"`
+	RegistrationToken   types.String `tfsdk:"registration_token"`
+	SalesforceId        types.String `tfsdk:"salesforce_id"`
+	SKU                 types.String `tfsdk:"sku"`
+	State               types.String `tfsdk:"state"`
+	TotalLicenses       types.Int64  `tfsdk:"total_licenses"`
+	UnlimitedExpiration types.Bool   `tfsdk:"unlimited_expiration"`
+	UnlimitedLicenses   types.Bool   `tfsdk:"unlimited_licenses"`
+	UpdatedAt           types.String `tfsdk:"updated_at"`
+}
+
+// NewAccount creates a new Account object.
+func NewAccount() resource.Resource {
+	return &Account{}
+}
+
+// Account is a resource used to manage the lifecycle of an account.
+type Account struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Account) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_account"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Account) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for provisioning and managing a customer account. This resource " +
+			"requires an MSSP or global scope API token.",
+		MarkdownDescription: `This resource is used for provisioning and managing a customer account. This resource
+			requires an MSSP or global scope API token.
+
+		Accounts cannot be removed using the API. When this resource is destroyed, the account is **expired
+		immediately** instead, which decommissions all of its agents. Existing accounts can be imported using their ID.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"account_type": schema.StringAttribute{
+				Description:         "Type of account (valid values: Paid, Trial). [Default: Paid]",
+				MarkdownDescription: "Type of account (valid values: `Paid`, `Trial`). [Default: `Paid`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("Paid"),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "Paid", "Trial"),
+				},
+			},
+			"active_agents": schema.Int64Attribute{
+				Description:         "Number of active agents in the account.",
+				MarkdownDescription: "Number of active agents in the account.",
+				Computed:            true,
+			},
+			"billing_mode": schema.StringAttribute{
+				Description:         "Billing mode of the account.",
+				MarkdownDescription: "Billing mode of the account.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the account was created.",
+				MarkdownDescription: "Timestamp of when the account was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creator": schema.StringAttribute{
+				Description:         "Full name of the user who created the account.",
+				MarkdownDescription: "Full name of the user who created the account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creator_id": schema.StringAttribute{
+				Description:         "ID of the user who created the account.",
+				MarkdownDescription: "ID of the user who created the account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expiration": schema.StringAttribute{
+				Description: "Timestamp of when the account expires (eg: 2024-01-01T00:00:00Z). If not set, the " +
+					"account never expires.",
+				MarkdownDescription: "Timestamp of when the account expires (eg: `2024-01-01T00:00:00Z`). If not set, " +
+					"the account never expires.",
+				Optional: true,
+			},
+			"external_id": schema.StringAttribute{
+				Description:         "ID of the account in an external system such as a CRM. [Default: none]",
+				MarkdownDescription: "ID of the account in an external system such as a CRM. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the account.",
+				MarkdownDescription: "ID of the account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_default": schema.BoolAttribute{
+				Description:         "Whether or not the account is the default account.",
+				MarkdownDescription: "Whether or not the account is the default account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modules": schema.SetAttribute{
+				Description: "Add-on license modules enabled for the account (eg: rso, star, ranger). If not set, the " +
+					"server will assign the modules.",
+				MarkdownDescription: "Add-on license modules enabled for the account (eg: `rso`, `star`, `ranger`). If " +
+					"not set, the server will assign the modules.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the account.",
+				MarkdownDescription: "Name of the account.",
+				Required:            true,
+			},
+			"number_of_sites": schema.Int64Attribute{
+				Description:         "Number of sites in the account.",
+				MarkdownDescription: "Number of sites in the account.",
+				Computed:            true,
+			},
+			"registration_token": schema.StringAttribute{
+				Description:         "Registration token for the account.",
+				MarkdownDescription: "Registration token for the account.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"salesforce_id": schema.StringAttribute{
+				Description:         "ID of the account in Salesforce. [Default: none]",
+				MarkdownDescription: "ID of the account in Salesforce. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"sku": schema.StringAttribute{
+				Description: "The license bundle (SKU) for the account (eg: core, control, complete). If not set, the " +
+					"server will assign the SKU.",
+				MarkdownDescription: "The license bundle (SKU) for the account (eg: `core`, `control`, `complete`). If " +
+					"not set, the server will assign the SKU.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description:         "State of the account.",
+				MarkdownDescription: "State of the account.",
+				Computed:            true,
+			},
+			"total_licenses": schema.Int64Attribute{
+				Description: "Number of agent licenses available to the account. Ignored when unlimited_licenses is " +
+					"true. If not set, the server will assign the number of licenses.",
+				MarkdownDescription: "Number of agent licenses available to the account. Ignored when " +
+					"`unlimited_licenses` is `true`. If not set, the server will assign the number of licenses.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"unlimited_expiration": schema.BoolAttribute{
+				Description:         "Whether or not the account never expires.",
+				MarkdownDescription: "Whether or not the account never expires.",
+				Computed:            true,
+			},
+			"unlimited_licenses": schema.BoolAttribute{
+				Description:         "Whether or not the account has unlimited licenses. [Default: true]",
+				MarkdownDescription: "Whether or not the account has unlimited licenses. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the account was last updated.",
+				MarkdownDescription: "Timestamp of when the account was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Account) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_ACCOUNT_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Account) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAccount
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the account
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	account, diags := api.Client().CreateAccount(ctx, body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the account to the state
+	tfaccount, diags := tfAccountFromAPI(ctx, account, plan.Expiration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfaccount)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Account) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfAccount
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the account - if it no longer exists, remove it from the state
+	accounts, diags := api.Client().FindAccounts(ctx, api.AccountQueryParams{
+		AccountIds: []string{state.Id.ValueString()},
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(accounts) == 0 || accounts[0].State == "deleted" {
+		tflog.Debug(ctx, "Account no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	tfaccount, diags := tfAccountFromAPI(ctx, &accounts[0], state.Expiration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfaccount)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Account) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAccount
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAccount
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the account
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	account, diags := api.Client().UpdateAccount(ctx, state.Id.ValueString(), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated account to the state
+	tfaccount, diags := tfAccountFromAPI(ctx, account, plan.Expiration)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfaccount)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Account) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfAccount
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// accounts cannot be deleted so expire the account instead
+	resp.Diagnostics.Append(api.Client().ExpireAccount(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Expired account", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing account into the Terraform state using its ID.
+func (r *Account) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	resource.ImportStatePassthroughID(ctx, tfpath.Root("id"), req, resp)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating an account.
+func (r *Account) bodyFromPlan(ctx context.Context, plan tfAccount) (api.AccountBody, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body := api.AccountBody{}
+
+	if !plan.AccountType.IsNull() && !plan.AccountType.IsUnknown() {
+		value := plan.AccountType.ValueString()
+		body.AccountType = &value
+	}
+
+	unlimitedExpiration := true
+	if !plan.Expiration.IsNull() && !plan.Expiration.IsUnknown() {
+		value := plan.Expiration.ValueString()
+		body.Expiration = &value
+		unlimitedExpiration = false
+	}
+	body.UnlimitedExpiration = &unlimitedExpiration
+
+	if !plan.ExternalId.IsNull() && !plan.ExternalId.IsUnknown() {
+		value := plan.ExternalId.ValueString()
+		body.ExternalId = &value
+	}
+
+	if !plan.Modules.IsNull() && !plan.Modules.IsUnknown() {
+		body.Modules = []string{}
+		diags.Append(plan.Modules.ElementsAs(ctx, &body.Modules, false)...)
+		if diags.HasError() {
+			return body, diags
+		}
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if !plan.SalesforceId.IsNull() && !plan.SalesforceId.IsUnknown() {
+		value := plan.SalesforceId.ValueString()
+		body.SalesforceId = &value
+	}
+
+	if !plan.SKU.IsNull() && !plan.SKU.IsUnknown() {
+		value := plan.SKU.ValueString()
+		body.SKU = &value
+	}
+
+	if !plan.TotalLicenses.IsNull() && !plan.TotalLicenses.IsUnknown() {
+		value := plan.TotalLicenses.ValueInt64()
+		body.TotalLicenses = &value
+	}
+
+	if !plan.UnlimitedLicenses.IsNull() && !plan.UnlimitedLicenses.IsUnknown() {
+		value := plan.UnlimitedLicenses.ValueBool()
+		body.UnlimitedLicenses = &value
+	}
+	return body, diags
+}
+
+// tfAccountFromAPI converts an API account into a Terraform account.
+//
+// The API may return the expiration timestamp in a different format than the one that was configured, so the
+// configured expiration is kept when it refers to the same point in time.
+func tfAccountFromAPI(ctx context.Context, account *api.Account, expiration types.String) (tfAccount,
+	diag.Diagnostics) {
+
+	tfaccount := tfAccount{
+		AccountType:         types.StringValue(account.AccountType),
+		ActiveAgents:        types.Int64Value(int64(account.ActiveAgents)),
+		BillingMode:         types.StringValue(account.BillingMode),
+		CreatedAt:           types.StringValue(account.CreatedAt),
+		Creator:             types.StringValue(account.Creator),
+		CreatorId:           types.StringValue(account.CreatorId),
+		Expiration:          types.StringNull(),
+		ExternalId:          types.StringValue(account.ExternalId),
+		Id:                  types.StringValue(account.Id),
+		IsDefault:           types.BoolValue(account.IsDefault),
+		Name:                types.StringValue(account.Name),
+		NumberOfSites:       types.Int64Value(int64(account.NumberOfSites)),
+		RegistrationToken:   types.StringValue(account.RegistrationToken),
+		SalesforceId:        types.StringValue(account.SalesforceId),
+		SKU:                 types.StringNull(),
+		State:               types.StringValue(account.State),
+		TotalLicenses:       types.Int64Value(int64(account.TotalLicenses)),
+		UnlimitedExpiration: types.BoolValue(account.UnlimitedExpiration),
+		UnlimitedLicenses:   types.BoolValue(account.UnlimitedLicenses),
+		UpdatedAt:           types.StringValue(account.UpdatedAt),
+	}
+	if !account.UnlimitedExpiration && account.Expiration != "" {
+		tfaccount.Expiration = types.StringValue(account.Expiration)
+		if !expiration.IsNull() && !expiration.IsUnknown() &&
+			sameTimestamp(expiration.ValueString(), account.Expiration) {
+			tfaccount.Expiration = expiration
+		}
+	}
+	if len(account.Licenses.Bundles) > 0 {
+		tfaccount.SKU = types.StringValue(account.Licenses.Bundles[0].Name)
+	}
+	modules := []string{}
+	for _, module := range account.Licenses.Modules {
+		modules = append(modules, module.Name)
+	}
+	var diags diag.Diagnostics
+	tfaccount.Modules, diags = types.SetValueFrom(ctx, types.StringType, modules)
+	if diags.HasError() {
+		return tfaccount, diags
+	}
+	ctx = plugin.MaskSecrets(ctx, account.RegistrationToken)
+	tflog.Debug(ctx, fmt.Sprintf("converted API account to TF account: %+v", tfaccount), map[string]interface{}{
+		"api_account": account,
+	})
+	return tfaccount, diags
+}
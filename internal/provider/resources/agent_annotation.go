@@ -0,0 +1,246 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &AgentAnnotation{}
+	_ resource.ResourceWithConfigure = &AgentAnnotation{}
+)
+
+// tfAgentAnnotation defines the Terraform model for an agent annotation.
+type tfAgentAnnotation struct {
+	AgentIds   types.List     `tfsdk:"agent_ids"`
+	Annotation types.String   `tfsdk:"annotation"`
+	Filter     *tfAgentFilter `tfsdk:"filter"`
+}
+
+// NewAgentAnnotation creates a new AgentAnnotation object.
+func NewAgentAnnotation() resource.Resource {
+	return &AgentAnnotation{}
+}
+
+// AgentAnnotation is a resource used to set a free-text annotation on agents matching a filter.
+type AgentAnnotation struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AgentAnnotation) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_agent_annotation"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AgentAnnotation) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for setting an annotation (eg: owning team or change ticket) on all " +
+			"agents matching a filter.",
+		MarkdownDescription: `This resource is used for setting an annotation (eg: owning team or change ticket) on all
+			agents matching a filter.
+
+		The annotation is stored in the agent's **External ID** field, which is visible in the console. At least one
+		filter must be given. When the resource is destroyed, the annotation is cleared from the agents which were
+		annotated. If an annotated agent is changed outside of Terraform, the annotation will be re-applied.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agent_ids": schema.ListAttribute{
+				Description:         "IDs of the agents which have been annotated.",
+				MarkdownDescription: "IDs of the agents which have been annotated.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"annotation": schema.StringAttribute{
+				Description:         "The annotation to set on the matching agents.",
+				MarkdownDescription: "The annotation to set on the matching agents.",
+				Required:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("to annotate"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AgentAnnotation) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_ANNOTATION_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AgentAnnotation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAgentAnnotation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// never annotate every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents to annotate."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_ANNOTATION_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Annotation Creation Error", msg)
+		return
+	}
+
+	// annotate the agents
+	resp.Diagnostics.Append(r.annotate(ctx, &plan, queryParams)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *AgentAnnotation) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfAgentAnnotation
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	queryParams := agentFilterQueryParams(state.Filter)
+
+	// find the agents currently matching the filter
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// if any matching agent has a different annotation, surface it so the annotation is re-applied
+	agentIds := []string{}
+	for _, agent := range agents {
+		if agent.ExternalId != state.Annotation.ValueString() {
+			tflog.Debug(ctx, "Agent annotation has drifted.", map[string]interface{}{
+				"agent_id":   agent.Id,
+				"annotation": agent.ExternalId,
+			})
+			state.Annotation = types.StringValue(agent.ExternalId)
+			continue
+		}
+		agentIds = append(agentIds, agent.Id)
+	}
+	state.AgentIds, diags = types.ListValueFrom(ctx, types.StringType, agentIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *AgentAnnotation) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfAgentAnnotation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// re-apply the annotation - filter changes force replacement so the filter is the same as in the state
+	queryParams := agentFilterQueryParams(plan.Filter)
+	resp.Diagnostics.Append(r.annotate(ctx, &plan, queryParams)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *AgentAnnotation) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfAgentAnnotation
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only clear the annotation from agents which were annotated by this resource
+	var agentIds []string
+	resp.Diagnostics.Append(state.AgentIds.ElementsAs(ctx, &agentIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(agentIds) == 0 {
+		return
+	}
+	affected, diags := api.Client().SetAgentExternalId(ctx, api.AgentQueryParams{AgentIds: agentIds}, "")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Cleared agent annotation", map[string]interface{}{
+		"agents_affected": affected,
+	})
+}
+
+// annotate sets the annotation on all agents matching the given query parameters and updates the list of agents
+// which have been annotated in the given model.
+func (r *AgentAnnotation) annotate(ctx context.Context, model *tfAgentAnnotation,
+	queryParams api.AgentQueryParams) diag.Diagnostics {
+
+	affected, diags := api.Client().SetAgentExternalId(ctx, queryParams, model.Annotation.ValueString())
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Set agent annotation", map[string]interface{}{
+		"agents_affected": affected,
+	})
+
+	// keep track of which agents were annotated
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	if diags.HasError() {
+		return diags
+	}
+	agentIds := []string{}
+	for _, agent := range agents {
+		agentIds = append(agentIds, agent.Id)
+	}
+	model.AgentIds, diags = types.ListValueFrom(ctx, types.StringType, agentIds)
+	return diags
+}
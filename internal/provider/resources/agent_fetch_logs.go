@@ -0,0 +1,460 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api/waiter"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+const (
+	// agentFetchLogsClockSkew is how far before the logs are requested to start looking for upload activities, to
+	// allow for differences between the local clock and the console's clock.
+	agentFetchLogsClockSkew = time.Minute
+
+	// agentFetchLogsStateCompleted is the waiter state used once every agent has uploaded its log bundle.
+	agentFetchLogsStateCompleted = "completed"
+
+	// agentFetchLogsStatePending is the waiter state used while any agent has not yet uploaded its log bundle.
+	agentFetchLogsStatePending = "pending"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &AgentFetchLogs{}
+	_ resource.ResourceWithConfigure = &AgentFetchLogs{}
+)
+
+// tfAgentFetchLogs defines the Terraform model for an agent fetch logs request.
+type tfAgentFetchLogs struct {
+	Agents         types.List     `tfsdk:"agents"`
+	CompletedCount types.Int64    `tfsdk:"completed_count"`
+	DirectoryMode  types.String   `tfsdk:"directory_mode"`
+	FileMode       types.String   `tfsdk:"file_mode"`
+	Filter         *tfAgentFilter `tfsdk:"filter"`
+	LocalFolder    types.String   `tfsdk:"local_folder"`
+	PlatformLogs   types.Bool     `tfsdk:"platform_logs"`
+	PollInterval   types.String   `tfsdk:"poll_interval"`
+	Triggers       types.Map      `tfsdk:"triggers"`
+	Wait           types.Bool     `tfsdk:"wait"`
+	WaitTimeout    types.String   `tfsdk:"wait_timeout"`
+}
+
+// tfAgentFetchLogsResult defines the Terraform model for the log bundle of a single agent.
+type tfAgentFetchLogsResult struct {
+	ActivityId   types.String `tfsdk:"activity_id"`
+	AgentId      types.String `tfsdk:"agent_id"`
+	ComputerName types.String `tfsdk:"computer_name"`
+	DownloadUrl  types.String `tfsdk:"download_url"`
+	LocalFile    types.String `tfsdk:"local_file"`
+}
+
+// tfAgentFetchLogsResultAttrTypes defines the attribute types of a tfAgentFetchLogsResult object.
+var tfAgentFetchLogsResultAttrTypes = map[string]attr.Type{
+	"activity_id":   types.StringType,
+	"agent_id":      types.StringType,
+	"computer_name": types.StringType,
+	"download_url":  types.StringType,
+	"local_file":    types.StringType,
+}
+
+// NewAgentFetchLogs creates a new AgentFetchLogs object.
+func NewAgentFetchLogs() resource.Resource {
+	return &AgentFetchLogs{}
+}
+
+// AgentFetchLogs is a resource used to request log bundles from agents matching a filter.
+type AgentFetchLogs struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AgentFetchLogs) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_fetch_logs"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AgentFetchLogs) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for fetching log bundles from all agents matching a filter.",
+		MarkdownDescription: `This resource is used for fetching log bundles from all agents matching a filter.
+
+		At least one filter must be given. The logs are requested from the agents matching the filter when the
+		resource is created. If ` + "`wait`" + ` is set, the resource waits until every agent has uploaded its log
+		bundle and records the URL from which each bundle can be downloaded in ` + "`agents`" + `. If
+		` + "`local_folder`" + ` is also set, each bundle is saved to that folder as
+		` + "`<agent_id>-<activity_id>.zip`" + `. Destroying the resource does not remove any saved bundles. To fetch
+		the logs again, change any value in ` + "`triggers`" + `, which causes the resource to be replaced.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agents": schema.ListNestedAttribute{
+				Description:         "Log bundle uploaded by each agent matching the filter.",
+				MarkdownDescription: "Log bundle uploaded by each agent matching the filter.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"activity_id": schema.StringAttribute{
+							Description:         "ID of the activity logged when the agent uploaded its log bundle.",
+							MarkdownDescription: "ID of the activity logged when the agent uploaded its log bundle.",
+							Computed:            true,
+						},
+						"agent_id": schema.StringAttribute{
+							Description:         "ID of the agent.",
+							MarkdownDescription: "ID of the agent.",
+							Computed:            true,
+						},
+						"computer_name": schema.StringAttribute{
+							Description:         "Computer name of the agent.",
+							MarkdownDescription: "Computer name of the agent.",
+							Computed:            true,
+						},
+						"download_url": schema.StringAttribute{
+							Description: "URL from which the log bundle can be downloaded using an API token. Not set " +
+								"if the agent did not upload its bundle while waiting.",
+							MarkdownDescription: "URL from which the log bundle can be downloaded using an API token. " +
+								"Not set if the agent did not upload its bundle while waiting.",
+							Computed: true,
+						},
+						"local_file": schema.StringAttribute{
+							Description:         "Full path to the saved log bundle. Only set when local_folder is set.",
+							MarkdownDescription: "Full path to the saved log bundle. Only set when `local_folder` is set.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"completed_count": schema.Int64Attribute{
+				Description:         "Number of agents which uploaded their log bundle.",
+				MarkdownDescription: "Number of agents which uploaded their log bundle.",
+				Computed:            true,
+			},
+			"directory_mode": schema.StringAttribute{
+				Description: "The permissions to set on any folders created when saving the bundles. " +
+					"Changing this value has no effect on existing folders. Ignored on Windows. [Default: 0755]",
+				MarkdownDescription: "The permissions to set on any folders created when saving the bundles. " +
+					"Changing this value has no effect on existing folders. Ignored on Windows. [Default: `0755`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("0755"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.FileModeIsValid(),
+				},
+			},
+			"file_mode": schema.StringAttribute{
+				Description:         "The permissions to set on the bundle files. Ignored on Windows. [Default: 0640]",
+				MarkdownDescription: "The permissions to set on the bundle files. Ignored on Windows. [Default: `0640`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("0640"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.FileModeIsValid(),
+				},
+			},
+			"local_folder": schema.StringAttribute{
+				Description: "The full path to the folder in which to save the bundles. Relative paths will be based " +
+					"on the working directory when the Terraform plan is applied. Requires wait to be true. " +
+					"[Default: none - the bundles are not saved]",
+				MarkdownDescription: "The full path to the folder in which to save the bundles. Relative paths will be " +
+					"based on the working directory when the Terraform plan is applied. Requires `wait` to be `true`. " +
+					"[Default: none - the bundles are not saved]",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"platform_logs": schema.BoolAttribute{
+				Description:         "Whether or not to include operating system logs in the bundles. [Default: false]",
+				MarkdownDescription: "Whether or not to include operating system logs in the bundles. [Default: `false`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: "How often to check whether the agents have uploaded their log bundles (eg: 10s, 1m). " +
+					"[Default: 30s]",
+				MarkdownDescription: "How often to check whether the agents have uploaded their log bundles (eg: " +
+					"`10s`, `1m`). [Default: `30s`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("30s"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which, when changed, cause the resource to be replaced and the logs " +
+					"to be fetched again.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"logs to be fetched again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Whether or not to wait until every agent has uploaded its log bundle before the " +
+					"resource is created. [Default: true]",
+				MarkdownDescription: "Whether or not to wait until every agent has uploaded its log bundle before " +
+					"the resource is created. [Default: `true`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description:         "Maximum time to wait for the log bundles to be uploaded (eg: 5m, 1h). [Default: 30m]",
+				MarkdownDescription: "Maximum time to wait for the log bundles to be uploaded (eg: `5m`, `1h`). [Default: `30m`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30m"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("from which to fetch logs"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AgentFetchLogs) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_FETCH_LOGS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AgentFetchLogs) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAgentFetchLogs
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	interval, diags := plugin.ParseRelativeDuration(ctx, plan.PollInterval.ValueString())
+	resp.Diagnostics.Append(diags...)
+	timeout, diags := plugin.ParseRelativeDuration(ctx, plan.WaitTimeout.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the bundles can only be saved once they have been uploaded
+	if !plan.LocalFolder.IsNull() && !plan.Wait.ValueBool() {
+		msg := "The log bundles can only be saved to local_folder when wait is set to true."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_FETCH_LOGS_CREATE,
+		})
+		resp.Diagnostics.AddAttributeError(tfpath.Root("local_folder"), "Agent Fetch Logs Creation Error", msg)
+		return
+	}
+
+	// never fetch logs from every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents from which to fetch logs."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_FETCH_LOGS_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Fetch Logs Creation Error", msg)
+		return
+	}
+
+	// find the agents first so that exactly the same agents are asked for logs and waited on
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agentIds := []string{}
+	for _, agent := range agents {
+		agentIds = append(agentIds, agent.Id)
+	}
+
+	// request the logs
+	requestedAt := time.Now().UTC().Add(-agentFetchLogsClockSkew).Format(time.RFC3339)
+	if len(agentIds) > 0 {
+		affected, diags := api.Client().FetchAgentLogs(ctx, api.AgentQueryParams{AgentIds: agentIds},
+			plan.PlatformLogs.ValueBool())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, "Requested log bundles from agents", map[string]interface{}{
+			"agents_matched":  len(agentIds),
+			"agents_affected": affected,
+		})
+	}
+
+	// wait for every agent to upload its log bundle - the latest upload of each agent is used
+	uploads := map[string]api.Activity{}
+	if plan.Wait.ValueBool() && len(agentIds) > 0 {
+		_, diags = waiter.Wait(ctx, waiter.Config{
+			Description: "agent log fetch",
+			Interval:    interval,
+			Pending:     []string{agentFetchLogsStatePending},
+			Target:      []string{agentFetchLogsStateCompleted},
+			Timeout:     timeout,
+		}, func(ctx context.Context) ([]api.Activity, string, diag.Diagnostics) {
+			sortBy := "createdAt"
+			sortOrder := "asc"
+			activities, diags := api.Client().FindActivities(ctx, api.ActivityQueryParams{
+				ActivityTypes: []int{api.ACTIVITY_TYPE_AGENT_LOGS_UPLOADED},
+				AgentIds:      agentIds,
+				CreatedAfter:  &requestedAt,
+				SortBy:        &sortBy,
+				SortOrder:     &sortOrder,
+			})
+			if diags.HasError() {
+				return nil, "", diags
+			}
+			for _, activity := range activities {
+				uploads[activity.AgentId] = activity
+			}
+			if len(uploads) < len(agentIds) {
+				return activities, agentFetchLogsStatePending, diags
+			}
+			return activities, agentFetchLogsStateCompleted, diags
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// save the bundles locally
+	localFiles := map[string]string{}
+	if !plan.LocalFolder.IsNull() {
+		for agentId, activity := range uploads {
+			path := filepath.Join(plan.LocalFolder.ValueString(), fmt.Sprintf("%s-%s.zip", agentId, activity.Id))
+			absPath, size, diags := api.Client().DownloadAgentUpload(ctx, agentId, activity.Id, path,
+				plan.DirectoryMode.ValueString(), plan.FileMode.ValueString(), true)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			tflog.Debug(ctx, "Saved agent log bundle", map[string]interface{}{
+				"agent_id": agentId,
+				"file":     absPath,
+				"size":     size,
+			})
+			localFiles[agentId] = absPath
+		}
+	}
+
+	// save the results to the state
+	results := []tfAgentFetchLogsResult{}
+	for _, agent := range agents {
+		result := tfAgentFetchLogsResult{
+			ActivityId:   types.StringNull(),
+			AgentId:      types.StringValue(agent.Id),
+			ComputerName: types.StringValue(agent.ComputerName),
+			DownloadUrl:  types.StringNull(),
+			LocalFile:    types.StringNull(),
+		}
+		if activity, ok := uploads[agent.Id]; ok {
+			result.ActivityId = types.StringValue(activity.Id)
+			result.DownloadUrl = types.StringValue(api.Client().URL(api.AgentUploadURI(agent.Id, activity.Id)))
+		}
+		if localFile, ok := localFiles[agent.Id]; ok {
+			result.LocalFile = types.StringValue(localFile)
+		}
+		results = append(results, result)
+	}
+	plan.Agents, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfAgentFetchLogsResultAttrTypes},
+		results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.CompletedCount = types.Int64Value(int64(len(uploads)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// Fetching logs is a one-off action so there is nothing to refresh.
+func (r *AgentFetchLogs) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the wait settings can be updated in place and they only apply when the logs are fetched, so there is nothing
+// to do other than saving them.
+func (r *AgentFetchLogs) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAgentFetchLogs
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAgentFetchLogs
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.Agents = state.Agents
+	plan.CompletedCount = state.CompletedCount
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// Any saved bundles are left in place so the resource is simply removed from the state.
+func (r *AgentFetchLogs) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,188 @@
+package resources
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// tfAgentFilter defines the Terraform model for the filter used to select the agents on which a resource acts.
+type tfAgentFilter struct {
+	AccountIds           []types.String `tfsdk:"account_ids"`
+	AgentIds             []types.String `tfsdk:"agent_ids"`
+	ComputerName         types.String   `tfsdk:"computer_name"`
+	ComputerNameContains []types.String `tfsdk:"computer_name_contains"`
+	GroupIds             []types.String `tfsdk:"group_ids"`
+	IsActive             types.Bool     `tfsdk:"is_active"`
+	OSTypes              []types.String `tfsdk:"os_types"`
+	Query                types.String   `tfsdk:"query"`
+	SiteIds              []types.String `tfsdk:"site_ids"`
+}
+
+// agentFilterBlock returns the schema for the filter block used to select the agents on which a resource acts.
+//
+// The purpose completes the description of the block (eg: "to scan" gives "Defines the query filters used to select
+// the agents to scan."). Changing the filter replaces the resource.
+func agentFilterBlock(purpose string) schema.SingleNestedBlock {
+	description := "Defines the query filters used to select the agents " + purpose + "."
+	return schema.SingleNestedBlock{
+		Description:         description,
+		MarkdownDescription: description,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplace(),
+		},
+		Attributes: map[string]schema.Attribute{
+			"account_ids": schema.ListAttribute{
+				Description:         "List of account IDs to filter by.",
+				MarkdownDescription: "List of account IDs to filter by.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"agent_ids": schema.ListAttribute{
+				Description:         "List of agent IDs to filter by.",
+				MarkdownDescription: "List of agent IDs to filter by.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"computer_name": schema.StringAttribute{
+				Description:         "Computer name of the agent.",
+				MarkdownDescription: "Computer name of the agent.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"computer_name_contains": schema.ListAttribute{
+				Description:         "List of partial computer names to filter by.",
+				MarkdownDescription: "List of partial computer names to filter by.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_ids": schema.ListAttribute{
+				Description:         "List of group IDs to filter by.",
+				MarkdownDescription: "List of group IDs to filter by.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_active": schema.BoolAttribute{
+				Description:         "Whether or not the agent is active.",
+				MarkdownDescription: "Whether or not the agent is active.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"os_types": schema.ListAttribute{
+				Description:         "List of OS types to filter by (valid values: linux, macos, windows).",
+				MarkdownDescription: "List of OS types to filter by (valid values: `linux`, `macos`, `windows`).",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, "linux", "macos", "windows"),
+				},
+			},
+			"query": schema.StringAttribute{
+				Description:         "A free-text search term, will match applicable attributes.",
+				MarkdownDescription: "A free-text search term, will match applicable attributes.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"site_ids": schema.ListAttribute{
+				Description:         "List of site IDs to filter by.",
+				MarkdownDescription: "List of site IDs to filter by.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// agentFilterQueryParams converts the TF filter block into API query parameters.
+//
+// A nil filter (ie: the block was not given) results in empty query parameters.
+func agentFilterQueryParams(filter *tfAgentFilter) api.AgentQueryParams {
+	queryParams := api.AgentQueryParams{}
+	if filter == nil {
+		return queryParams
+	}
+
+	if len(filter.AccountIds) > 0 {
+		queryParams.AccountIds = agentFilterStrings(filter.AccountIds)
+	}
+
+	if len(filter.AgentIds) > 0 {
+		queryParams.AgentIds = agentFilterStrings(filter.AgentIds)
+	}
+
+	if !filter.ComputerName.IsNull() && !filter.ComputerName.IsUnknown() {
+		value := filter.ComputerName.ValueString()
+		queryParams.ComputerName = &value
+	}
+
+	if len(filter.ComputerNameContains) > 0 {
+		queryParams.ComputerNameContains = agentFilterStrings(filter.ComputerNameContains)
+	}
+
+	if len(filter.GroupIds) > 0 {
+		queryParams.GroupIds = agentFilterStrings(filter.GroupIds)
+	}
+
+	if !filter.IsActive.IsNull() && !filter.IsActive.IsUnknown() {
+		value := filter.IsActive.ValueBool()
+		queryParams.IsActive = &value
+	}
+
+	if len(filter.OSTypes) > 0 {
+		queryParams.OSTypes = agentFilterStrings(filter.OSTypes)
+	}
+
+	if !filter.Query.IsNull() && !filter.Query.IsUnknown() {
+		value := filter.Query.ValueString()
+		queryParams.Query = &value
+	}
+
+	if len(filter.SiteIds) > 0 {
+		queryParams.SiteIds = agentFilterStrings(filter.SiteIds)
+	}
+	return queryParams
+}
+
+// agentFilterStrings converts a list of TF strings from the filter block into a list of strings, skipping any null
+// or unknown values.
+func agentFilterStrings(values []types.String) []string {
+	result := []string{}
+	for _, e := range values {
+		if !e.IsNull() && !e.IsUnknown() {
+			result = append(result, e.ValueString())
+		}
+	}
+	return result
+}
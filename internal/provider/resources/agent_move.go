@@ -0,0 +1,429 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api/waiter"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+const (
+	// agentMoveStateCompleted is the waiter state used once the console shows every agent in its target.
+	agentMoveStateCompleted = "completed"
+
+	// agentMoveStatePending is the waiter state used while the console still shows any agent in its old location.
+	agentMoveStatePending = "pending"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &AgentMove{}
+	_ resource.ResourceWithConfigure = &AgentMove{}
+)
+
+// tfAgentMove defines the Terraform model for an agent move.
+type tfAgentMove struct {
+	Agents        types.List     `tfsdk:"agents"`
+	Filter        *tfAgentFilter `tfsdk:"filter"`
+	MovedCount    types.Int64    `tfsdk:"moved_count"`
+	PollInterval  types.String   `tfsdk:"poll_interval"`
+	TargetGroupId types.String   `tfsdk:"target_group_id"`
+	TargetSiteId  types.String   `tfsdk:"target_site_id"`
+	Triggers      types.Map      `tfsdk:"triggers"`
+	Wait          types.Bool     `tfsdk:"wait"`
+	WaitTimeout   types.String   `tfsdk:"wait_timeout"`
+}
+
+// tfAgentMoveResult defines the Terraform model for a single agent selected to be moved.
+type tfAgentMoveResult struct {
+	AgentId         types.String `tfsdk:"agent_id"`
+	ComputerName    types.String `tfsdk:"computer_name"`
+	PreviousGroupId types.String `tfsdk:"previous_group_id"`
+	PreviousSiteId  types.String `tfsdk:"previous_site_id"`
+}
+
+// tfAgentMoveResultAttrTypes defines the attribute types of a tfAgentMoveResult object.
+var tfAgentMoveResultAttrTypes = map[string]attr.Type{
+	"agent_id":          types.StringType,
+	"computer_name":     types.StringType,
+	"previous_group_id": types.StringType,
+	"previous_site_id":  types.StringType,
+}
+
+// NewAgentMove creates a new AgentMove object.
+func NewAgentMove() resource.Resource {
+	return &AgentMove{}
+}
+
+// AgentMove is a resource used to move agents matching a filter to another site and/or group.
+type AgentMove struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AgentMove) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_move"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AgentMove) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for moving all agents matching a filter to another site and/or group.",
+		MarkdownDescription: `This resource is used for moving all agents matching a filter to another site and/or
+			group.
+
+		At least one filter and a target site or group must be given. If only a group is given, the agents are moved
+		to the group's site first when needed. Before moving any agents, the target site is checked to make sure it
+		has enough free licenses for the agents which are not already in it. The agents are moved when the resource is
+		created and the agents selected are recorded in ` + "`agents`" + `. Destroying the resource does not move the
+		agents back. To move the agents matching the filter again, change any value in ` + "`triggers`" + `, which
+		causes the resource to be replaced.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agents": schema.ListNestedAttribute{
+				Description:         "Agents matching the filter along with their location before they were moved.",
+				MarkdownDescription: "Agents matching the filter along with their location before they were moved.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"agent_id": schema.StringAttribute{
+							Description:         "ID of the agent.",
+							MarkdownDescription: "ID of the agent.",
+							Computed:            true,
+						},
+						"computer_name": schema.StringAttribute{
+							Description:         "Computer name of the agent.",
+							MarkdownDescription: "Computer name of the agent.",
+							Computed:            true,
+						},
+						"previous_group_id": schema.StringAttribute{
+							Description:         "ID of the group to which the agent belonged before it was moved.",
+							MarkdownDescription: "ID of the group to which the agent belonged before it was moved.",
+							Computed:            true,
+						},
+						"previous_site_id": schema.StringAttribute{
+							Description:         "ID of the site to which the agent belonged before it was moved.",
+							MarkdownDescription: "ID of the site to which the agent belonged before it was moved.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"moved_count": schema.Int64Attribute{
+				Description:         "Number of agents the console reported as moved.",
+				MarkdownDescription: "Number of agents the console reported as moved.",
+				Computed:            true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description:         "How often to check whether the move has completed (eg: 10s, 1m). [Default: 10s]",
+				MarkdownDescription: "How often to check whether the move has completed (eg: `10s`, `1m`). [Default: `10s`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10s"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"target_group_id": schema.StringAttribute{
+				Description:         "ID of the group to which the agents are moved. [Default: none]",
+				MarkdownDescription: "ID of the group to which the agents are moved. [Default: none]",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"target_site_id": schema.StringAttribute{
+				Description: "ID of the site to which the agents are moved. If a target group is also given, it must " +
+					"belong to this site. [Default: the target group's site]",
+				MarkdownDescription: "ID of the site to which the agents are moved. If a target group is also given, it " +
+					"must belong to this site. [Default: the target group's site]",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which, when changed, cause the resource to be replaced and the agents " +
+					"to be moved again.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"agents to be moved again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Whether or not to wait until the console shows every agent in its target before the " +
+					"resource is created. [Default: false]",
+				MarkdownDescription: "Whether or not to wait until the console shows every agent in its target before " +
+					"the resource is created. [Default: `false`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description:         "Maximum time to wait for the move to complete (eg: 5m, 1h). [Default: 10m]",
+				MarkdownDescription: "Maximum time to wait for the move to complete (eg: `5m`, `1h`). [Default: `10m`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10m"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("to move"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AgentMove) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_MOVE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AgentMove) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAgentMove
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	interval, diags := plugin.ParseRelativeDuration(ctx, plan.PollInterval.ValueString())
+	resp.Diagnostics.Append(diags...)
+	timeout, diags := plugin.ParseRelativeDuration(ctx, plan.WaitTimeout.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// never move every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents to move."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_MOVE_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Move Creation Error", msg)
+		return
+	}
+
+	// work out the target site, which is the group's site if only a group is given
+	targetSiteId := plan.TargetSiteId.ValueString()
+	targetGroupId := plan.TargetGroupId.ValueString()
+	if targetSiteId == "" && targetGroupId == "" {
+		msg := "A target site or group must be given."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_MOVE_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Move Creation Error", msg)
+		return
+	}
+	if targetGroupId != "" {
+		groups, diags := api.Client().FindGroups(ctx, api.GroupQueryParams{GroupIds: []string{targetGroupId}})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(groups) == 0 {
+			msg := fmt.Sprintf("The target group does not exist.\n\nGroup ID: %s", targetGroupId)
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"internal_error_code": plugin.ERR_RESOURCE_AGENT_MOVE_CREATE,
+			})
+			resp.Diagnostics.AddError("Agent Move Creation Error", msg)
+			return
+		}
+		if targetSiteId != "" && groups[0].SiteId != targetSiteId {
+			msg := fmt.Sprintf("The target group does not belong to the target site.\n\nGroup ID: %s\n"+
+				"Group Site ID: %s\nTarget Site ID: %s", targetGroupId, groups[0].SiteId, targetSiteId)
+			tflog.Error(ctx, msg, map[string]interface{}{
+				"internal_error_code": plugin.ERR_RESOURCE_AGENT_MOVE_CREATE,
+			})
+			resp.Diagnostics.AddError("Agent Move Creation Error", msg)
+			return
+		}
+		targetSiteId = groups[0].SiteId
+	}
+
+	// find the agents first so that exactly the same agents are checked, moved and waited on
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agentIds := []string{}
+	incomingIds := []string{}
+	results := []tfAgentMoveResult{}
+	for _, agent := range agents {
+		agentIds = append(agentIds, agent.Id)
+		if agent.SiteId != targetSiteId {
+			incomingIds = append(incomingIds, agent.Id)
+		}
+		results = append(results, tfAgentMoveResult{
+			AgentId:         types.StringValue(agent.Id),
+			ComputerName:    types.StringValue(agent.ComputerName),
+			PreviousGroupId: types.StringValue(agent.GroupId),
+			PreviousSiteId:  types.StringValue(agent.SiteId),
+		})
+	}
+
+	// make sure the target site has a license for every agent moving into it
+	sites, diags := api.Client().FindSites(ctx, api.SiteQueryParams{SiteIds: []string{targetSiteId}})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(sites) == 0 {
+		msg := fmt.Sprintf("The target site does not exist.\n\nSite ID: %s", targetSiteId)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_MOVE_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Move Creation Error", msg)
+		return
+	}
+	site := sites[0]
+	if free := site.TotalLicenses - site.ActiveLicenses; !site.UnlimitedLicenses && len(incomingIds) > free {
+		msg := fmt.Sprintf("The target site does not have enough free licenses for the agents being moved into "+
+			"it.\n\nSite ID: %s\nAgents Moving: %d\nFree Licenses: %d", site.Id, len(incomingIds), free)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_MOVE_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Move Creation Error", msg)
+		return
+	}
+
+	// move the agents to the site and then to the group
+	var moved int
+	if len(incomingIds) > 0 {
+		moved, diags = api.Client().MoveAgentsToSite(ctx, api.AgentQueryParams{AgentIds: incomingIds}, targetSiteId)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if targetGroupId != "" && len(agentIds) > 0 {
+		moved, diags = api.Client().MoveAgentsToGroup(ctx, api.AgentQueryParams{AgentIds: agentIds}, targetGroupId)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	tflog.Info(ctx, "Moved agents", map[string]interface{}{
+		"agents_matched":  len(agentIds),
+		"agents_moved":    moved,
+		"target_group_id": targetGroupId,
+		"target_site_id":  targetSiteId,
+	})
+
+	// wait for the console to show every agent in its target
+	if plan.Wait.ValueBool() && len(agentIds) > 0 {
+		_, diags = waiter.Wait(ctx, waiter.Config{
+			Description: "agent move",
+			Interval:    interval,
+			Pending:     []string{agentMoveStatePending},
+			Target:      []string{agentMoveStateCompleted},
+			Timeout:     timeout,
+		}, func(ctx context.Context) ([]api.Agent, string, diag.Diagnostics) {
+			agents, diags := api.Client().FindAgents(ctx, api.AgentQueryParams{AgentIds: agentIds})
+			if diags.HasError() {
+				return nil, "", diags
+			}
+			for _, agent := range agents {
+				if agent.SiteId != targetSiteId || (targetGroupId != "" && agent.GroupId != targetGroupId) {
+					return agents, agentMoveStatePending, diags
+				}
+			}
+			return agents, agentMoveStateCompleted, diags
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// save the results to the state
+	plan.Agents, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfAgentMoveResultAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.MovedCount = types.Int64Value(int64(moved))
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// A move is a one-off action so there is nothing to refresh.
+func (r *AgentMove) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the wait settings can be updated in place and they only apply when the agents are moved, so there is nothing
+// to do other than saving them.
+func (r *AgentMove) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAgentMove
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAgentMove
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.Agents = state.Agents
+	plan.MovedCount = state.MovedCount
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The agents are not moved back so the resource is simply removed from the state.
+func (r *AgentMove) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
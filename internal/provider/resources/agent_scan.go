@@ -0,0 +1,267 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &AgentScan{}
+	_ resource.ResourceWithConfigure = &AgentScan{}
+)
+
+// tfAgentScan defines the Terraform model for an agent scan.
+type tfAgentScan struct {
+	Agents         types.List     `tfsdk:"agents"`
+	Filter         *tfAgentFilter `tfsdk:"filter"`
+	InitiatedCount types.Int64    `tfsdk:"initiated_count"`
+	Parallelism    types.Int64    `tfsdk:"parallelism"`
+	Triggers       types.Map      `tfsdk:"triggers"`
+}
+
+// tfAgentScanResult defines the Terraform model for the result of initiating a scan on a single agent.
+type tfAgentScanResult struct {
+	AgentId      types.String `tfsdk:"agent_id"`
+	ComputerName types.String `tfsdk:"computer_name"`
+	Initiated    types.Bool   `tfsdk:"initiated"`
+}
+
+// tfAgentScanResultAttrTypes defines the attribute types of a tfAgentScanResult object.
+var tfAgentScanResultAttrTypes = map[string]attr.Type{
+	"agent_id":      types.StringType,
+	"computer_name": types.StringType,
+	"initiated":     types.BoolType,
+}
+
+// NewAgentScan creates a new AgentScan object.
+func NewAgentScan() resource.Resource {
+	return &AgentScan{}
+}
+
+// AgentScan is a resource used to initiate a full disk scan on agents matching a filter.
+type AgentScan struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AgentScan) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_scan"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AgentScan) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for initiating a full disk scan on all agents matching a filter.",
+		MarkdownDescription: `This resource is used for initiating a full disk scan on all agents matching a filter.
+
+		At least one filter must be given. The scan is initiated on the agents matching the filter when the resource
+		is created and the result for each agent is recorded in ` + "`agents`" + `. Destroying the resource does not
+		abort any scan in progress. To scan the agents again, change any value in ` + "`triggers`" + `, which causes
+		the resource to be replaced.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agents": schema.ListNestedAttribute{
+				Description:         "Result of initiating the scan on each agent matching the filter.",
+				MarkdownDescription: "Result of initiating the scan on each agent matching the filter.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"agent_id": schema.StringAttribute{
+							Description:         "ID of the agent.",
+							MarkdownDescription: "ID of the agent.",
+							Computed:            true,
+						},
+						"computer_name": schema.StringAttribute{
+							Description:         "Computer name of the agent.",
+							MarkdownDescription: "Computer name of the agent.",
+							Computed:            true,
+						},
+						"initiated": schema.BoolAttribute{
+							Description:         "Whether or not the scan was initiated on the agent.",
+							MarkdownDescription: "Whether or not the scan was initiated on the agent.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"initiated_count": schema.Int64Attribute{
+				Description:         "Number of agents on which the scan was initiated.",
+				MarkdownDescription: "Number of agents on which the scan was initiated.",
+				Computed:            true,
+			},
+			"parallelism": schema.Int64Attribute{
+				Description:         "Maximum number of concurrent API calls to make when initiating scans. [Default: 5]",
+				MarkdownDescription: "Maximum number of concurrent API calls to make when initiating scans. [Default: `5`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(5),
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which, when changed, cause the resource to be replaced and the agents " +
+					"to be scanned again.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"agents to be scanned again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("to scan"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AgentScan) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_SCAN_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AgentScan) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAgentScan
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// never scan every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents to scan."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_SCAN_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Scan Creation Error", msg)
+		return
+	}
+
+	// find the agents first so that the scan can be initiated and reported on for each agent individually
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agentIds := []string{}
+	computerNames := map[string]string{}
+	for _, agent := range agents {
+		agentIds = append(agentIds, agent.Id)
+		computerNames[agent.Id] = agent.ComputerName
+	}
+
+	// initiate the scans - failures are recorded for each agent and reported once all scans have been initiated
+	var mutex sync.Mutex
+	initiated := map[string]bool{}
+	scanDiags := forEachParallel(plan.Parallelism.ValueInt64(), agentIds, "scan initiations",
+		func(agentId string) diag.Diagnostics {
+			affected, diags := api.Client().InitiateScan(ctx, api.AgentQueryParams{AgentIds: []string{agentId}})
+			mutex.Lock()
+			initiated[agentId] = !diags.HasError() && affected > 0
+			mutex.Unlock()
+			return diags
+		})
+
+	// record the result for each agent
+	results := []tfAgentScanResult{}
+	var count int64
+	for _, agentId := range agentIds {
+		results = append(results, tfAgentScanResult{
+			AgentId:      types.StringValue(agentId),
+			ComputerName: types.StringValue(computerNames[agentId]),
+			Initiated:    types.BoolValue(initiated[agentId]),
+		})
+		if initiated[agentId] {
+			count++
+		}
+	}
+	tflog.Info(ctx, "Initiated full disk scan on agents", map[string]interface{}{
+		"agents_matched":   len(agentIds),
+		"agents_initiated": count,
+	})
+	plan.Agents, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfAgentScanResultAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.InitiatedCount = types.Int64Value(count)
+
+	// save the plan to the state even if some scans failed so the results are available - the resource is marked
+	// as tainted in that case so the scan is initiated again on the next apply
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(scanDiags...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// A scan cannot be undone once initiated so there is nothing to refresh.
+func (r *AgentScan) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the parallelism can be updated in place so there is nothing to do other than saving it.
+func (r *AgentScan) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAgentScan
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAgentScan
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.Agents = state.Agents
+	plan.InitiatedCount = state.InitiatedCount
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// Scans which are still running are not aborted so the resource is simply removed from the state.
+func (r *AgentScan) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
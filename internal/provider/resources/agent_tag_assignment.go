@@ -0,0 +1,323 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &AgentTagAssignment{}
+	_ resource.ResourceWithConfigure = &AgentTagAssignment{}
+)
+
+// tfAgentTagAssignment defines the Terraform model for an agent tag assignment.
+type tfAgentTagAssignment struct {
+	AgentIds types.List     `tfsdk:"agent_ids"`
+	Filter   *tfAgentFilter `tfsdk:"filter"`
+	TagIds   types.Set      `tfsdk:"tag_ids"`
+}
+
+// NewAgentTagAssignment creates a new AgentTagAssignment object.
+func NewAgentTagAssignment() resource.Resource {
+	return &AgentTagAssignment{}
+}
+
+// AgentTagAssignment is a resource used to assign tags to agents matching a filter.
+type AgentTagAssignment struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AgentTagAssignment) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_agent_tag_assignment"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AgentTagAssignment) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for assigning a set of tags to all agents matching a filter.",
+		MarkdownDescription: `This resource is used for assigning a set of tags to all agents matching a filter.
+
+		At least one filter must be given; use the ` + "`agent_ids`" + ` filter to select agents by ID. If any of
+		the tags is removed from a matching agent outside of Terraform, or a new agent matches the filter, the tags
+		are re-assigned on the next apply. When the resource is destroyed, the tags are removed from the agents to
+		which they were assigned. Tags are typically created with the ` + "`singularity_tag`" + ` resource.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agent_ids": schema.ListAttribute{
+				Description:         "IDs of the agents to which the tags have been assigned.",
+				MarkdownDescription: "IDs of the agents to which the tags have been assigned.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"tag_ids": schema.SetAttribute{
+				Description:         "IDs of the tags to assign to the matching agents.",
+				MarkdownDescription: "IDs of the tags to assign to the matching agents.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("to which the tags are assigned"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AgentTagAssignment) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_TAG_ASSIGNMENT_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AgentTagAssignment) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAgentTagAssignment
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// never tag every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents to which the tags are assigned."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_TAG_ASSIGNMENT_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Tag Assignment Creation Error", msg)
+		return
+	}
+
+	// assign the tags
+	resp.Diagnostics.Append(r.assign(ctx, &plan, queryParams)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *AgentTagAssignment) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfAgentTagAssignment
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var tagIds []string
+	resp.Diagnostics.Append(state.TagIds.ElementsAs(ctx, &tagIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	queryParams := agentFilterQueryParams(state.Filter)
+
+	// find the agents currently matching the filter
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// any tag missing from a matching agent is dropped from the state so that it is re-assigned
+	missing := map[string]bool{}
+	agentIds := []string{}
+	for _, agent := range agents {
+		tagged := true
+		for _, id := range tagIds {
+			if !agentHasTagId(agent.Tags.SentinelOne, id) {
+				tflog.Debug(ctx, "Agent tag assignment has drifted.", map[string]interface{}{
+					"agent_id": agent.Id,
+					"tag_id":   id,
+				})
+				missing[id] = true
+				tagged = false
+			}
+		}
+		if tagged {
+			agentIds = append(agentIds, agent.Id)
+		}
+	}
+	assigned := []string{}
+	for _, id := range tagIds {
+		if !missing[id] {
+			assigned = append(assigned, id)
+		}
+	}
+	state.TagIds, diags = types.SetValueFrom(ctx, types.StringType, assigned)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.AgentIds, diags = types.ListValueFrom(ctx, types.StringType, agentIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *AgentTagAssignment) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAgentTagAssignment
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAgentTagAssignment
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// remove tags which are no longer assigned from the agents which were tagged
+	var stateTagIds, planTagIds, agentIds []string
+	resp.Diagnostics.Append(state.TagIds.ElementsAs(ctx, &stateTagIds, false)...)
+	resp.Diagnostics.Append(plan.TagIds.ElementsAs(ctx, &planTagIds, false)...)
+	resp.Diagnostics.Append(state.AgentIds.ElementsAs(ctx, &agentIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	assigned := map[string]bool{}
+	for _, id := range planTagIds {
+		assigned[id] = true
+	}
+	removed := []string{}
+	for _, id := range stateTagIds {
+		if !assigned[id] {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) > 0 && len(agentIds) > 0 {
+		affected, diags := api.Client().ManageAgentTags(ctx, api.AgentQueryParams{AgentIds: agentIds}, nil, removed)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Debug(ctx, "Removed agent tags", map[string]interface{}{
+			"agents_affected": affected,
+			"tag_ids":         removed,
+		})
+	}
+
+	// re-assign the tags - filter changes force replacement so the filter is the same as in the state
+	queryParams := agentFilterQueryParams(plan.Filter)
+	resp.Diagnostics.Append(r.assign(ctx, &plan, queryParams)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *AgentTagAssignment) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfAgentTagAssignment
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only remove the tags from agents which were tagged by this resource
+	var agentIds, tagIds []string
+	resp.Diagnostics.Append(state.AgentIds.ElementsAs(ctx, &agentIds, false)...)
+	resp.Diagnostics.Append(state.TagIds.ElementsAs(ctx, &tagIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(agentIds) == 0 || len(tagIds) == 0 {
+		return
+	}
+	affected, diags := api.Client().ManageAgentTags(ctx, api.AgentQueryParams{AgentIds: agentIds}, nil, tagIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed agent tags", map[string]interface{}{
+		"agents_affected": affected,
+		"tag_ids":         tagIds,
+	})
+}
+
+// assign adds the tags to all agents matching the given query parameters and updates the list of agents which have
+// been tagged in the given model.
+func (r *AgentTagAssignment) assign(ctx context.Context, model *tfAgentTagAssignment,
+	queryParams api.AgentQueryParams) diag.Diagnostics {
+
+	var tagIds []string
+	diags := model.TagIds.ElementsAs(ctx, &tagIds, false)
+	if diags.HasError() {
+		return diags
+	}
+	affected, diags := api.Client().ManageAgentTags(ctx, queryParams, tagIds, nil)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Assigned agent tags", map[string]interface{}{
+		"agents_affected": affected,
+		"tag_ids":         tagIds,
+	})
+
+	// keep track of which agents were tagged
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	if diags.HasError() {
+		return diags
+	}
+	agentIds := []string{}
+	for _, agent := range agents {
+		agentIds = append(agentIds, agent.Id)
+	}
+	model.AgentIds, diags = types.ListValueFrom(ctx, types.StringType, agentIds)
+	return diags
+}
+
+// agentHasTagId returns whether or not the tag with the given ID is in the given list of agent tags.
+func agentHasTagId(tags []api.AgentTag, id string) bool {
+	for _, t := range tags {
+		if t.Id == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,406 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api/waiter"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+const (
+	// agentUninstallStateCompleted is the waiter state used once every agent reports that it was uninstalled.
+	agentUninstallStateCompleted = "completed"
+
+	// agentUninstallStatePending is the waiter state used while any agent has not yet reported that it was
+	// uninstalled.
+	agentUninstallStatePending = "pending"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &AgentUninstall{}
+	_ resource.ResourceWithConfigure = &AgentUninstall{}
+)
+
+// tfAgentUninstall defines the Terraform model for an agent uninstall.
+type tfAgentUninstall struct {
+	Agents             types.List     `tfsdk:"agents"`
+	Confirm            types.Bool     `tfsdk:"confirm"`
+	Filter             *tfAgentFilter `tfsdk:"filter"`
+	IncludePassphrases types.Bool     `tfsdk:"include_passphrases"`
+	PollInterval       types.String   `tfsdk:"poll_interval"`
+	Triggers           types.Map      `tfsdk:"triggers"`
+	UninstalledCount   types.Int64    `tfsdk:"uninstalled_count"`
+	Wait               types.Bool     `tfsdk:"wait"`
+	WaitTimeout        types.String   `tfsdk:"wait_timeout"`
+}
+
+// tfAgentUninstallResult defines the Terraform model for a single agent selected to be uninstalled.
+type tfAgentUninstallResult struct {
+	AgentId      types.String `tfsdk:"agent_id"`
+	ComputerName types.String `tfsdk:"computer_name"`
+	Passphrase   types.String `tfsdk:"passphrase"`
+	Uninstalled  types.Bool   `tfsdk:"uninstalled"`
+}
+
+// tfAgentUninstallResultAttrTypes defines the attribute types of a tfAgentUninstallResult object.
+var tfAgentUninstallResultAttrTypes = map[string]attr.Type{
+	"agent_id":      types.StringType,
+	"computer_name": types.StringType,
+	"passphrase":    types.StringType,
+	"uninstalled":   types.BoolType,
+}
+
+// NewAgentUninstall creates a new AgentUninstall object.
+func NewAgentUninstall() resource.Resource {
+	return &AgentUninstall{}
+}
+
+// AgentUninstall is a resource used to uninstall agents matching a filter.
+type AgentUninstall struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AgentUninstall) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_uninstall"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AgentUninstall) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for uninstalling all agents matching a filter.",
+		MarkdownDescription: `This resource is used for uninstalling all agents matching a filter.
+
+		At least one filter must be given and, since uninstalling agents removes their protection, ` + "`confirm`" + `
+		must be set to ` + "`true`" + `. The uninstall command is sent to the agents matching the filter when the
+		resource is created and the agents selected are recorded in ` + "`agents`" + `. If
+		` + "`include_passphrases`" + ` is set, the passphrase of each agent is retrieved before the command is sent so
+		that agents which are offline or fail to uninstall can still be removed locally. Destroying the resource does
+		not reinstall the agents. To uninstall the agents matching the filter again, change any value in
+		` + "`triggers`" + `, which causes the resource to be replaced.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agents": schema.ListNestedAttribute{
+				Description:         "Agents matching the filter to which the uninstall command was sent.",
+				MarkdownDescription: "Agents matching the filter to which the uninstall command was sent.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"agent_id": schema.StringAttribute{
+							Description:         "ID of the agent.",
+							MarkdownDescription: "ID of the agent.",
+							Computed:            true,
+						},
+						"computer_name": schema.StringAttribute{
+							Description:         "Computer name of the agent.",
+							MarkdownDescription: "Computer name of the agent.",
+							Computed:            true,
+						},
+						"passphrase": schema.StringAttribute{
+							Description: "Passphrase used to uninstall the agent locally. Only set when " +
+								"include_passphrases is true.",
+							MarkdownDescription: "Passphrase used to uninstall the agent locally. Only set when " +
+								"`include_passphrases` is `true`.",
+							Computed:  true,
+							Sensitive: true,
+						},
+						"uninstalled": schema.BoolAttribute{
+							Description: "Whether or not the agent reported that it was uninstalled before the " +
+								"resource was created. Always false when not waiting.",
+							MarkdownDescription: "Whether or not the agent reported that it was uninstalled before the " +
+								"resource was created. Always `false` when not waiting.",
+							Computed: true,
+						},
+					},
+				},
+			},
+			"confirm": schema.BoolAttribute{
+				Description:         "Must be set to true to confirm that the agents should be uninstalled.",
+				MarkdownDescription: "Must be set to `true` to confirm that the agents should be uninstalled.",
+				Required:            true,
+			},
+			"include_passphrases": schema.BoolAttribute{
+				Description: "Whether or not to retrieve the passphrase of each agent before it is uninstalled. " +
+					"[Default: false]",
+				MarkdownDescription: "Whether or not to retrieve the passphrase of each agent before it is " +
+					"uninstalled. [Default: `false`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				Description: "How often to check whether the agents have been uninstalled (eg: 10s, 1m). " +
+					"[Default: 30s]",
+				MarkdownDescription: "How often to check whether the agents have been uninstalled (eg: `10s`, `1m`). " +
+					"[Default: `30s`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("30s"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which, when changed, cause the resource to be replaced and the agents " +
+					"to be uninstalled again.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"agents to be uninstalled again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"uninstalled_count": schema.Int64Attribute{
+				Description:         "Number of agents which reported that they were uninstalled.",
+				MarkdownDescription: "Number of agents which reported that they were uninstalled.",
+				Computed:            true,
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Whether or not to wait until every agent reports that it was uninstalled before the " +
+					"resource is created. [Default: true]",
+				MarkdownDescription: "Whether or not to wait until every agent reports that it was uninstalled before " +
+					"the resource is created. [Default: `true`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description:         "Maximum time to wait for the agents to be uninstalled (eg: 5m, 1h). [Default: 30m]",
+				MarkdownDescription: "Maximum time to wait for the agents to be uninstalled (eg: `5m`, `1h`). [Default: `30m`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30m"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("to uninstall"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AgentUninstall) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_UNINSTALL_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AgentUninstall) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAgentUninstall
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	interval, diags := plugin.ParseRelativeDuration(ctx, plan.PollInterval.ValueString())
+	resp.Diagnostics.Append(diags...)
+	timeout, diags := plugin.ParseRelativeDuration(ctx, plan.WaitTimeout.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// uninstalling agents removes their protection so it must be explicitly confirmed
+	if !plan.Confirm.ValueBool() {
+		msg := "The agents are only uninstalled when confirm is set to true."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_UNINSTALL_CREATE,
+		})
+		resp.Diagnostics.AddAttributeError(tfpath.Root("confirm"), "Agent Uninstall Creation Error", msg)
+		return
+	}
+
+	// never uninstall every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents to uninstall."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_UNINSTALL_CREATE,
+		})
+		resp.Diagnostics.AddError("Agent Uninstall Creation Error", msg)
+		return
+	}
+
+	// find the agents first so that exactly the same agents are uninstalled and waited on
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agentIds := []string{}
+	for _, agent := range agents {
+		agentIds = append(agentIds, agent.Id)
+	}
+
+	// retrieve the passphrases before the agents are uninstalled since they are no longer available afterwards
+	passphrases := map[string]string{}
+	if plan.IncludePassphrases.ValueBool() && len(agentIds) > 0 {
+		found, diags := api.Client().FindAgentPassphrases(ctx, api.AgentQueryParams{AgentIds: agentIds})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, passphrase := range found {
+			passphrases[passphrase.Id] = passphrase.Passphrase
+			ctx = plugin.MaskSecrets(ctx, passphrase.Passphrase)
+		}
+	}
+
+	// send the uninstall command
+	if len(agentIds) > 0 {
+		affected, diags := api.Client().UninstallAgents(ctx, api.AgentQueryParams{AgentIds: agentIds})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, "Sent uninstall command to agents", map[string]interface{}{
+			"agents_matched":  len(agentIds),
+			"agents_affected": affected,
+		})
+	}
+
+	// wait for every agent to report that it was uninstalled - agents which have since been removed from the
+	// console are treated as uninstalled
+	uninstalled := map[string]bool{}
+	if plan.Wait.ValueBool() && len(agentIds) > 0 {
+		_, diags = waiter.Wait(ctx, waiter.Config{
+			Description: "agent uninstall",
+			Interval:    interval,
+			Pending:     []string{agentUninstallStatePending},
+			Target:      []string{agentUninstallStateCompleted},
+			Timeout:     timeout,
+		}, func(ctx context.Context) ([]api.Agent, string, diag.Diagnostics) {
+			found, diags := api.Client().FindAgents(ctx, api.AgentQueryParams{AgentIds: agentIds})
+			if diags.HasError() {
+				return nil, "", diags
+			}
+			remaining := map[string]bool{}
+			for _, agent := range found {
+				if !agent.IsUninstalled {
+					remaining[agent.Id] = true
+				}
+			}
+			for _, agentId := range agentIds {
+				uninstalled[agentId] = !remaining[agentId]
+			}
+			if len(remaining) > 0 {
+				return found, agentUninstallStatePending, diags
+			}
+			return found, agentUninstallStateCompleted, diags
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// save the results to the state
+	results := []tfAgentUninstallResult{}
+	var count int64
+	for _, agent := range agents {
+		result := tfAgentUninstallResult{
+			AgentId:      types.StringValue(agent.Id),
+			ComputerName: types.StringValue(agent.ComputerName),
+			Passphrase:   types.StringNull(),
+			Uninstalled:  types.BoolValue(uninstalled[agent.Id]),
+		}
+		if passphrase, ok := passphrases[agent.Id]; ok {
+			result.Passphrase = types.StringValue(passphrase)
+		}
+		if uninstalled[agent.Id] {
+			count++
+		}
+		results = append(results, result)
+	}
+	plan.Agents, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfAgentUninstallResultAttrTypes},
+		results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.UninstalledCount = types.Int64Value(count)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// An uninstall is a one-off action so there is nothing to refresh.
+func (r *AgentUninstall) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the confirmation and wait settings can be updated in place and they only apply when the agents are
+// uninstalled, so there is nothing to do other than saving them.
+func (r *AgentUninstall) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAgentUninstall
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAgentUninstall
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.Agents = state.Agents
+	plan.UninstalledCount = state.UninstalledCount
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The agents cannot be reinstalled so the resource is simply removed from the state.
+func (r *AgentUninstall) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
@@ -0,0 +1,330 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &AgentUpgradePolicy{}
+	_ resource.ResourceWithConfigure   = &AgentUpgradePolicy{}
+	_ resource.ResourceWithImportState = &AgentUpgradePolicy{}
+)
+
+// tfAgentUpgradePolicy defines the Terraform model for an agent upgrade policy.
+type tfAgentUpgradePolicy struct {
+	Id                        types.String      `tfsdk:"id"`
+	InheritedFrom             types.String      `tfsdk:"inherited_from"`
+	LocalUpgradeAuthorization types.Bool        `tfsdk:"local_upgrade_authorization"`
+	PinnedVersions            []tfPinnedVersion `tfsdk:"pinned_version"`
+	ScopeId                   types.String      `tfsdk:"scope_id"`
+	ScopeType                 types.String      `tfsdk:"scope_type"`
+}
+
+// tfPinnedVersion defines the Terraform model for the agent version to which agents of an OS are pinned.
+type tfPinnedVersion struct {
+	OsType  types.String `tfsdk:"os_type"`
+	Version types.String `tfsdk:"version"`
+}
+
+// NewAgentUpgradePolicy creates a new AgentUpgradePolicy object.
+func NewAgentUpgradePolicy() resource.Resource {
+	return &AgentUpgradePolicy{}
+}
+
+// AgentUpgradePolicy is a resource used to manage the agent upgrade policy of an account, site or group.
+type AgentUpgradePolicy struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AgentUpgradePolicy) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_agent_upgrade_policy"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AgentUpgradePolicy) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the agent upgrade policy of an account, site or group.",
+		MarkdownDescription: `This resource is used for managing the agent upgrade policy of an account, site or group.
+
+		The upgrade policy controls whether agents require authorization from the console before they can be upgraded
+		or downgraded locally on the endpoint and which agent version, if any, agents of each OS are pinned to. The
+		pinned versions configured replace those of the scope's effective policy, so omitting the ` +
+			"`pinned_version`" + ` block removes any pins.
+
+		Destroying the resource reverts the scope to the upgrade policy inherited from its parent. Existing upgrade
+		policies can be imported using an ID in the format ` + "`<scope_type>/<scope_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "ID of the policy (in the format <scope_type>/<scope_id>).",
+				MarkdownDescription: "ID of the policy (in the format `<scope_type>/<scope_id>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inherited_from": schema.StringAttribute{
+				Description:         "Scope from which the policy was inherited, if any.",
+				MarkdownDescription: "Scope from which the policy was inherited, if any.",
+				Computed:            true,
+			},
+			"local_upgrade_authorization": schema.BoolAttribute{
+				Description: "Whether or not agents require authorization from the console before they can be " +
+					"upgraded or downgraded locally.",
+				MarkdownDescription: "Whether or not agents require authorization from the console before they can be " +
+					"upgraded or downgraded locally.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group whose upgrade policy is managed.",
+				MarkdownDescription: "ID of the account, site or group whose upgrade policy is managed.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description: "Level of the scope whose upgrade policy is managed (valid values: account, site, group).",
+				MarkdownDescription: "Level of the scope whose upgrade policy is managed (valid values: `account`, " +
+					"`site`, `group`).",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"pinned_version": schema.ListNestedBlock{
+				Description:         "Pins agents of an OS to a specific agent version.",
+				MarkdownDescription: "Pins agents of an OS to a specific agent version.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"os_type": schema.StringAttribute{
+							Description:         "OS of the agents (valid values: linux, macos, windows).",
+							MarkdownDescription: "OS of the agents (valid values: `linux`, `macos`, `windows`).",
+							Required:            true,
+							Validators: []validator.String{
+								validators.EnumStringValueOneOf(false, "linux", "macos", "windows"),
+							},
+						},
+						"version": schema.StringAttribute{
+							Description:         "Agent version to which the agents are pinned (eg: 23.1.2.400).",
+							MarkdownDescription: "Agent version to which the agents are pinned (eg: `23.1.2.400`).",
+							Required:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AgentUpgradePolicy) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_UPGRADE_POLICY_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AgentUpgradePolicy) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+
+	// retrieve values from plan
+	var plan tfAgentUpgradePolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the scope always has an upgrade policy so creating one simply overrides the configured settings
+	policy, diags := api.Client().UpdateUpgradePolicy(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the policy to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfAgentUpgradePolicyFromAPI(ctx, policy, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *AgentUpgradePolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfAgentUpgradePolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the effective upgrade policy of the scope
+	policy, diags := api.Client().GetUpgradePolicy(ctx, scopeFromModel(state.ScopeType, state.ScopeId))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfAgentUpgradePolicyFromAPI(ctx, policy, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *AgentUpgradePolicy) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+
+	// retrieve values from plan
+	var plan tfAgentUpgradePolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the policy
+	policy, diags := api.Client().UpdateUpgradePolicy(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated policy to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfAgentUpgradePolicyFromAPI(ctx, policy, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *AgentUpgradePolicy) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+
+	// get the current state
+	var state tfAgentUpgradePolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// revert the scope to its inherited upgrade policy
+	resp.Diagnostics.Append(api.Client().RevertUpgradePolicy(ctx, scopeFromModel(state.ScopeType,
+		state.ScopeId))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reverted agent upgrade policy", map[string]interface{}{
+		"scope_id":   state.ScopeId.ValueString(),
+		"scope_type": state.ScopeType.ValueString(),
+	})
+}
+
+// ImportState imports the upgrade policy of an existing scope into the Terraform state using an ID in the format
+// <scope_type>/<scope_id>.
+func (r *AgentUpgradePolicy) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE &&
+		parts[0] != api.SCOPE_GROUP) || parts[1] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id> where the scope type is one "+
+			"of: %s, %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_AGENT_UPGRADE_POLICY_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating an upgrade policy.
+func (r *AgentUpgradePolicy) bodyFromPlan(plan tfAgentUpgradePolicy) api.UpgradePolicyBody {
+	body := api.UpgradePolicyBody{}
+
+	if !plan.LocalUpgradeAuthorization.IsNull() && !plan.LocalUpgradeAuthorization.IsUnknown() {
+		value := plan.LocalUpgradeAuthorization.ValueBool()
+		body.LocalUpgradeAuthorization = &value
+	}
+
+	for _, pin := range plan.PinnedVersions {
+		body.PinnedVersions = append(body.PinnedVersions, api.PinnedVersion{
+			OsType:  pin.OsType.ValueString(),
+			Version: pin.Version.ValueString(),
+		})
+	}
+	return body
+}
+
+// tfAgentUpgradePolicyFromAPI converts an API upgrade policy into a Terraform agent upgrade policy.
+//
+// The scope is copied from the given model since it is always known from the configuration or import ID.
+func tfAgentUpgradePolicyFromAPI(ctx context.Context, policy *api.UpgradePolicy,
+	model tfAgentUpgradePolicy) tfAgentUpgradePolicy {
+
+	tfpolicy := tfAgentUpgradePolicy{
+		Id: types.StringValue(fmt.Sprintf("%s/%s", model.ScopeType.ValueString(),
+			model.ScopeId.ValueString())),
+		InheritedFrom:             types.StringValue(policy.InheritedFrom),
+		LocalUpgradeAuthorization: types.BoolValue(policy.LocalUpgradeAuthorization),
+		ScopeId:                   model.ScopeId,
+		ScopeType:                 model.ScopeType,
+	}
+	for _, pin := range policy.PinnedVersions {
+		tfpolicy.PinnedVersions = append(tfpolicy.PinnedVersions, tfPinnedVersion{
+			OsType:  types.StringValue(pin.OsType),
+			Version: types.StringValue(pin.Version),
+		})
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API upgrade policy to TF agent upgrade policy: %+v", tfpolicy),
+		map[string]interface{}{
+			"api_upgrade_policy": policy,
+		})
+	return tfpolicy
+}
@@ -0,0 +1,587 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+const (
+	// agentsUpgradeStatusFailed is the status of an agent whose upgrade command could not be sent.
+	agentsUpgradeStatusFailed = "failed"
+
+	// agentsUpgradeStatusSkipped is the status of an agent which was not upgraded because it already runs the
+	// package's version, runs a different OS or its batch was not sent before the schedule window closed.
+	agentsUpgradeStatusSkipped = "skipped"
+
+	// agentsUpgradeStatusSucceeded is the status of an agent to which the upgrade command was sent.
+	agentsUpgradeStatusSucceeded = "succeeded"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource               = &AgentsUpgrade{}
+	_ resource.ResourceWithConfigure  = &AgentsUpgrade{}
+	_ resource.ResourceWithModifyPlan = &AgentsUpgrade{}
+)
+
+// tfAgentsUpgrade defines the Terraform model for an agents upgrade.
+type tfAgentsUpgrade struct {
+	Agents              types.List     `tfsdk:"agents"`
+	BatchSize           types.Int64    `tfsdk:"batch_size"`
+	FailedCount         types.Int64    `tfsdk:"failed_count"`
+	Filter              *tfAgentFilter `tfsdk:"filter"`
+	PackageId           types.String   `tfsdk:"package_id"`
+	Parallelism         types.Int64    `tfsdk:"parallelism"`
+	ResolvedWindowEnd   types.String   `tfsdk:"resolved_window_end"`
+	ResolvedWindowStart types.String   `tfsdk:"resolved_window_start"`
+	SkippedCount        types.Int64    `tfsdk:"skipped_count"`
+	SucceededCount      types.Int64    `tfsdk:"succeeded_count"`
+	Triggers            types.Map      `tfsdk:"triggers"`
+	WindowEnd           types.String   `tfsdk:"window_end"`
+	WindowStart         types.String   `tfsdk:"window_start"`
+}
+
+// tfAgentsUpgradeResult defines the Terraform model for the result of upgrading a single agent.
+type tfAgentsUpgradeResult struct {
+	AgentId      types.String `tfsdk:"agent_id"`
+	AgentVersion types.String `tfsdk:"agent_version"`
+	ComputerName types.String `tfsdk:"computer_name"`
+	Status       types.String `tfsdk:"status"`
+	StatusReason types.String `tfsdk:"status_reason"`
+}
+
+// tfAgentsUpgradeResultAttrTypes defines the attribute types of a tfAgentsUpgradeResult object.
+var tfAgentsUpgradeResultAttrTypes = map[string]attr.Type{
+	"agent_id":      types.StringType,
+	"agent_version": types.StringType,
+	"computer_name": types.StringType,
+	"status":        types.StringType,
+	"status_reason": types.StringType,
+}
+
+// NewAgentsUpgrade creates a new AgentsUpgrade object.
+func NewAgentsUpgrade() resource.Resource {
+	return &AgentsUpgrade{}
+}
+
+// AgentsUpgrade is a resource used to upgrade agents matching a filter to a specific package.
+type AgentsUpgrade struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AgentsUpgrade) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agents_upgrade"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AgentsUpgrade) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for upgrading all agents matching a filter to a specific package.",
+		MarkdownDescription: `This resource is used for upgrading all agents matching a filter to a specific package.
+
+		At least one filter must be given. When the resource is created, the agents matching the filter are split into
+		batches of ` + "`batch_size`" + ` agents and the upgrade command is sent for up to ` + "`parallelism`" + `
+		batches at a time. Agents which already run the package's version or which run a different OS than the
+		package are skipped. If ` + "`window_start`" + ` is in the future, the resource waits until then before
+		sending the first batch and any batch which has not been sent by ` + "`window_end`" + ` is skipped. The result
+		for each agent is recorded in ` + "`agents`" + ` and summarized in ` + "`succeeded_count`" + `,
+		` + "`failed_count`" + ` and ` + "`skipped_count`" + `. Destroying the resource does not downgrade the
+		agents. To upgrade the agents matching the filter again, change any value in ` + "`triggers`" + `, which
+		causes the resource to be replaced.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agents": schema.ListNestedAttribute{
+				Description:         "Result of upgrading each agent matching the filter.",
+				MarkdownDescription: "Result of upgrading each agent matching the filter.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"agent_id": schema.StringAttribute{
+							Description:         "ID of the agent.",
+							MarkdownDescription: "ID of the agent.",
+							Computed:            true,
+						},
+						"agent_version": schema.StringAttribute{
+							Description:         "Version of the agent before it was upgraded.",
+							MarkdownDescription: "Version of the agent before it was upgraded.",
+							Computed:            true,
+						},
+						"computer_name": schema.StringAttribute{
+							Description:         "Computer name of the agent.",
+							MarkdownDescription: "Computer name of the agent.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "Result of the upgrade (succeeded, failed, skipped).",
+							MarkdownDescription: "Result of the upgrade (`succeeded`, `failed`, `skipped`).",
+							Computed:            true,
+						},
+						"status_reason": schema.StringAttribute{
+							Description:         "Reason the agent was skipped or failed, if any.",
+							MarkdownDescription: "Reason the agent was skipped or failed, if any.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"batch_size": schema.Int64Attribute{
+				Description:         "Maximum number of agents to include in each upgrade command. [Default: 100]",
+				MarkdownDescription: "Maximum number of agents to include in each upgrade command. [Default: `100`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(100),
+			},
+			"failed_count": schema.Int64Attribute{
+				Description:         "Number of agents for which the upgrade command could not be sent.",
+				MarkdownDescription: "Number of agents for which the upgrade command could not be sent.",
+				Computed:            true,
+			},
+			"package_id": schema.StringAttribute{
+				Description:         "ID of the package to which the agents are upgraded.",
+				MarkdownDescription: "ID of the package to which the agents are upgraded.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Description:         "Maximum number of batches to send concurrently. [Default: 5]",
+				MarkdownDescription: "Maximum number of batches to send concurrently. [Default: `5`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(5),
+			},
+			"resolved_window_end": schema.StringAttribute{
+				Description: "Timestamp to which window_end was resolved when the resource was planned. Relative " +
+					"timestamps are only resolved when the resource is created or window_end changes.",
+				MarkdownDescription: "Timestamp to which `window_end` was resolved when the resource was planned. " +
+					"Relative timestamps are only resolved when the resource is created or `window_end` changes.",
+				Computed: true,
+			},
+			"resolved_window_start": schema.StringAttribute{
+				Description: "Timestamp to which window_start was resolved when the resource was planned. Relative " +
+					"timestamps are only resolved when the resource is created or window_start changes.",
+				MarkdownDescription: "Timestamp to which `window_start` was resolved when the resource was planned. " +
+					"Relative timestamps are only resolved when the resource is created or `window_start` changes.",
+				Computed: true,
+			},
+			"skipped_count": schema.Int64Attribute{
+				Description:         "Number of agents which were not upgraded.",
+				MarkdownDescription: "Number of agents which were not upgraded.",
+				Computed:            true,
+			},
+			"succeeded_count": schema.Int64Attribute{
+				Description:         "Number of agents to which the upgrade command was sent.",
+				MarkdownDescription: "Number of agents to which the upgrade command was sent.",
+				Computed:            true,
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which, when changed, cause the resource to be replaced and the agents " +
+					"to be upgraded again.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"agents to be upgraded again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"window_end": schema.StringAttribute{
+				Description: "Batches which have not been sent by the given timestamp (eg: 2023-01-01T00:00:00Z) or " +
+					"relative timestamp (eg: +2h) are skipped.",
+				MarkdownDescription: "Batches which have not been sent by the given timestamp (eg: " +
+					"`2023-01-01T00:00:00Z`) or relative timestamp (eg: `+2h`) are skipped.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.TimestampIsValid(),
+				},
+			},
+			"window_start": schema.StringAttribute{
+				Description: "Wait until the given timestamp (eg: 2023-01-01T00:00:00Z) or relative timestamp " +
+					"(eg: +1h) before sending the first batch.",
+				MarkdownDescription: "Wait until the given timestamp (eg: `2023-01-01T00:00:00Z`) or relative " +
+					"timestamp (eg: `+1h`) before sending the first batch.",
+				Optional: true,
+				Validators: []validator.String{
+					validators.TimestampIsValid(),
+					validators.TimestampIsBefore(false, "window_end"),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("to upgrade"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AgentsUpgrade) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENTS_UPGRADE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// ModifyPlan is called to modify the Terraform plan.
+//
+// Relative schedule window timestamps are resolved while planning and kept in the state so that the window does not
+// move between the plan and the apply, which run in separate provider processes.
+func (r *AgentsUpgrade) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+
+	// nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// retrieve values from plan and state
+	var plan tfAgentsUpgrade
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resolvedStart := types.StringUnknown()
+	resolvedEnd := types.StringUnknown()
+	if !req.State.Raw.IsNull() {
+		var state tfAgentsUpgrade
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.WindowStart.Equal(state.WindowStart) {
+			resolvedStart = state.ResolvedWindowStart
+		}
+		if plan.WindowEnd.Equal(state.WindowEnd) {
+			resolvedEnd = state.ResolvedWindowEnd
+		}
+	}
+
+	// resolve any window timestamps which are new or have changed
+	resolvedStart, diags := r.resolveWindowTimestamp(ctx, plan.WindowStart, resolvedStart)
+	resp.Diagnostics.Append(diags...)
+	resolvedEnd, diags = r.resolveWindowTimestamp(ctx, plan.WindowEnd, resolvedEnd)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("resolved_window_start"), resolvedStart)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("resolved_window_end"), resolvedEnd)...)
+}
+
+// Create is used to create the Terraform resource.
+func (r *AgentsUpgrade) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAgentsUpgrade
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// window timestamps which were still unknown while planning are resolved now
+	var diags diag.Diagnostics
+	plan.ResolvedWindowStart, diags = r.resolveWindowTimestamp(ctx, plan.WindowStart, plan.ResolvedWindowStart)
+	resp.Diagnostics.Append(diags...)
+	plan.ResolvedWindowEnd, diags = r.resolveWindowTimestamp(ctx, plan.WindowEnd, plan.ResolvedWindowEnd)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	windowStart, diags := r.parseWindowTimestamp(ctx, plan.ResolvedWindowStart)
+	resp.Diagnostics.Append(diags...)
+	windowEnd, diags := r.parseWindowTimestamp(ctx, plan.ResolvedWindowEnd)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// never upgrade every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents to upgrade."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AGENTS_UPGRADE_CREATE,
+		})
+		resp.Diagnostics.AddError("Agents Upgrade Creation Error", msg)
+		return
+	}
+
+	// the package determines which agents actually need to be upgraded
+	pkg, diags := api.Client().GetPackage(ctx, plan.PackageId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// skip any agent which the package does not apply to and split the rest into batches
+	status := map[string]string{}
+	reasons := map[string]string{}
+	batches := map[string][]string{}
+	batchNames := []string{}
+	batchSize := int(plan.BatchSize.ValueInt64())
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	for _, agent := range agents {
+		if pkg.OSType != "" && agent.OSType != pkg.OSType {
+			status[agent.Id] = agentsUpgradeStatusSkipped
+			reasons[agent.Id] = fmt.Sprintf("agent runs %s but the package is for %s", agent.OSType, pkg.OSType)
+			continue
+		}
+		if agent.AgentVersion == pkg.Version {
+			status[agent.Id] = agentsUpgradeStatusSkipped
+			reasons[agent.Id] = "agent already runs the package's version"
+			continue
+		}
+		name := strconv.Itoa(len(batchNames))
+		if len(batchNames) == 0 || len(batches[batchNames[len(batchNames)-1]]) >= batchSize {
+			batchNames = append(batchNames, name)
+		} else {
+			name = batchNames[len(batchNames)-1]
+		}
+		batches[name] = append(batches[name], agent.Id)
+	}
+
+	// wait for the schedule window to open
+	if !windowStart.IsZero() && len(batchNames) > 0 {
+		resp.Diagnostics.Append(r.waitForWindow(ctx, windowStart)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// send the upgrade command for each batch - failures are recorded for each agent and reported once every batch
+	// has been sent
+	var mutex sync.Mutex
+	upgradeDiags := forEachParallel(plan.Parallelism.ValueInt64(), batchNames, "upgrade batches",
+		func(name string) diag.Diagnostics {
+			batch := batches[name]
+			if !windowEnd.IsZero() && !time.Now().Before(windowEnd) {
+				mutex.Lock()
+				for _, agentId := range batch {
+					status[agentId] = agentsUpgradeStatusSkipped
+					reasons[agentId] = "schedule window closed before the upgrade command was sent"
+				}
+				mutex.Unlock()
+				return nil
+			}
+			affected, diags := api.Client().UpgradeAgents(ctx, api.AgentQueryParams{AgentIds: batch},
+				plan.PackageId.ValueString(), false)
+			mutex.Lock()
+			for _, agentId := range batch {
+				if diags.HasError() {
+					status[agentId] = agentsUpgradeStatusFailed
+					reasons[agentId] = diags[0].Summary()
+				} else {
+					status[agentId] = agentsUpgradeStatusSucceeded
+				}
+			}
+			mutex.Unlock()
+			if !diags.HasError() && affected < len(batch) {
+				tflog.Warn(ctx, "Upgrade command affected fewer agents than were sent", map[string]interface{}{
+					"agents_sent":     len(batch),
+					"agents_affected": affected,
+				})
+			}
+			return diags
+		})
+
+	// record the result for each agent
+	results := []tfAgentsUpgradeResult{}
+	counts := map[string]int64{}
+	for _, agent := range agents {
+		result := tfAgentsUpgradeResult{
+			AgentId:      types.StringValue(agent.Id),
+			AgentVersion: types.StringValue(agent.AgentVersion),
+			ComputerName: types.StringValue(agent.ComputerName),
+			Status:       types.StringValue(status[agent.Id]),
+			StatusReason: types.StringNull(),
+		}
+		if reason, ok := reasons[agent.Id]; ok {
+			result.StatusReason = types.StringValue(reason)
+		}
+		counts[status[agent.Id]]++
+		results = append(results, result)
+	}
+	tflog.Info(ctx, "Sent upgrade command to agents", map[string]interface{}{
+		"agents_matched":   len(agents),
+		"agents_succeeded": counts[agentsUpgradeStatusSucceeded],
+		"agents_failed":    counts[agentsUpgradeStatusFailed],
+		"agents_skipped":   counts[agentsUpgradeStatusSkipped],
+	})
+	plan.Agents, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfAgentsUpgradeResultAttrTypes},
+		results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.FailedCount = types.Int64Value(counts[agentsUpgradeStatusFailed])
+	plan.SkippedCount = types.Int64Value(counts[agentsUpgradeStatusSkipped])
+	plan.SucceededCount = types.Int64Value(counts[agentsUpgradeStatusSucceeded])
+
+	// save the plan to the state even if some batches failed so the results are available - the resource is marked
+	// as tainted in that case so the upgrade is sent again on the next apply
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(upgradeDiags...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// An upgrade is a one-off action so there is nothing to refresh.
+func (r *AgentsUpgrade) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the batching and schedule window settings can be updated in place and they only apply when the agents are
+// upgraded, so there is nothing to do other than saving them.
+func (r *AgentsUpgrade) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAgentsUpgrade
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAgentsUpgrade
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// window timestamps which were still unknown while planning are resolved now
+	var diags diag.Diagnostics
+	plan.ResolvedWindowStart, diags = r.resolveWindowTimestamp(ctx, plan.WindowStart, plan.ResolvedWindowStart)
+	resp.Diagnostics.Append(diags...)
+	plan.ResolvedWindowEnd, diags = r.resolveWindowTimestamp(ctx, plan.WindowEnd, plan.ResolvedWindowEnd)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.Agents = state.Agents
+	plan.FailedCount = state.FailedCount
+	plan.SkippedCount = state.SkippedCount
+	plan.SucceededCount = state.SucceededCount
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The agents cannot be downgraded so the resource is simply removed from the state.
+func (r *AgentsUpgrade) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// resolveWindowTimestamp resolves the given schedule window timestamp against the current time unless it has already
+// been resolved.
+func (r *AgentsUpgrade) resolveWindowTimestamp(ctx context.Context, value, resolved types.String) (types.String,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	if value.IsNull() {
+		return types.StringNull(), diags
+	}
+	if value.IsUnknown() || (!resolved.IsNull() && !resolved.IsUnknown()) {
+		return resolved, diags
+	}
+	timestamp, diags := plugin.ResolveTimestamp(ctx, value.ValueString(), time.Now())
+	if diags.HasError() {
+		return types.StringUnknown(), diags
+	}
+	return types.StringValue(timestamp), diags
+}
+
+// parseWindowTimestamp parses the given resolved schedule window timestamp. A zero time is returned if the timestamp
+// is not set.
+func (r *AgentsUpgrade) parseWindowTimestamp(ctx context.Context, value types.String) (time.Time,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return time.Time{}, diags
+	}
+	t, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		msg := fmt.Sprintf("The schedule window timestamp is not a valid RFC3339 timestamp.\n\nError: %s\n"+
+			"Timestamp: %s", err.Error(), value.ValueString())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_RESOURCE_AGENTS_UPGRADE_CREATE,
+		})
+		diags.AddError("Agents Upgrade Creation Error", msg)
+		return time.Time{}, diags
+	}
+	return t, diags
+}
+
+// waitForWindow blocks until the given time is reached or the context is cancelled.
+func (r *AgentsUpgrade) waitForWindow(ctx context.Context, start time.Time) diag.Diagnostics {
+	var diags diag.Diagnostics
+	delay := time.Until(start)
+	if delay <= 0 {
+		return diags
+	}
+	tflog.Info(ctx, "Waiting for the schedule window to open", map[string]interface{}{
+		"window_start": start.Format(time.RFC3339),
+	})
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		msg := fmt.Sprintf("Stopped waiting for the schedule window to open.\n\nError: %s\nWindow Start: %s",
+			ctx.Err().Error(), start.Format(time.RFC3339))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               ctx.Err().Error(),
+			"internal_error_code": plugin.ERR_RESOURCE_AGENTS_UPGRADE_CREATE,
+		})
+		diags.AddError("Agents Upgrade Creation Error", msg)
+	}
+	return diags
+}
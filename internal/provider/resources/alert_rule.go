@@ -0,0 +1,412 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &AlertRule{}
+	_ resource.ResourceWithConfigure   = &AlertRule{}
+	_ resource.ResourceWithImportState = &AlertRule{}
+)
+
+// tfAlertRule defines the Terraform model for a custom alert rule.
+type tfAlertRule struct {
+	CreatedAt           types.String   `tfsdk:"created_at"`
+	Description         types.String   `tfsdk:"description"`
+	Enabled             types.Bool     `tfsdk:"enabled"`
+	Id                  types.String   `tfsdk:"id"`
+	Name                types.String   `tfsdk:"name"`
+	NotificationTargets []types.String `tfsdk:"notification_targets"`
+	Query               types.String   `tfsdk:"query"`
+	ScopeId             types.String   `tfsdk:"scope_id"`
+	ScopeType           types.String   `tfsdk:"scope_type"`
+	Severity            types.String   `tfsdk:"severity"`
+	ThrottleWindow      types.String   `tfsdk:"throttle_window"`
+	UpdatedAt           types.String   `tfsdk:"updated_at"`
+}
+
+// NewAlertRule creates a new AlertRule object.
+func NewAlertRule() resource.Resource {
+	return &AlertRule{}
+}
+
+// AlertRule is a resource used to manage a custom alert rule.
+type AlertRule struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AlertRule) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_alert_rule"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AlertRule) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a custom alert rule within an account or site.",
+		MarkdownDescription: `This resource is used for creating and managing a custom alert rule within an account
+			or site.
+
+		A custom alert rule raises an alert and notifies its targets whenever its query matches. Unlike STAR rules, it
+		does not take any response action on the matching endpoints. Existing custom alert rules can be imported using
+		an ID in the format ` + "`<scope_type>/<scope_id>/<alert_rule_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the rule was created.",
+				MarkdownDescription: "Timestamp of when the rule was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the rule. [Default: none]",
+				MarkdownDescription: "Description of the rule. [Default: none]",
+				Optional:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not the rule is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not the rule is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the rule.",
+				MarkdownDescription: "ID of the rule.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the rule.",
+				MarkdownDescription: "Name of the rule.",
+				Required:            true,
+			},
+			"notification_targets": schema.ListAttribute{
+				Description:         "Email addresses or webhook IDs which are notified when the rule raises an alert.",
+				MarkdownDescription: "Email addresses or webhook IDs which are notified when the rule raises an alert.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"query": schema.StringAttribute{
+				Description:         "Power Query which raises an alert when it matches.",
+				MarkdownDescription: "Power Query which raises an alert when it matches.",
+				Required:            true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the rule applies.",
+				MarkdownDescription: "ID of the account or site to which the rule applies.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the rule is created (valid values: account, site).",
+				MarkdownDescription: "Level at which the rule is created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"severity": schema.StringAttribute{
+				Description:         "Severity of the alerts raised (valid values: Low, Medium, High, Critical).",
+				MarkdownDescription: "Severity of the alerts raised (valid values: `Low`, `Medium`, `High`, `Critical`).",
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "Low", "Medium", "High", "Critical"),
+				},
+			},
+			"throttle_window": schema.StringAttribute{
+				Description: "Minimum time between alerts raised by the rule (eg: 15m, 1h). Matches within the " +
+					"window do not raise another alert. [Default: 1h]",
+				MarkdownDescription: "Minimum time between alerts raised by the rule (eg: `15m`, `1h`). Matches " +
+					"within the window do not raise another alert. [Default: `1h`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("1h"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the rule was last updated.",
+				MarkdownDescription: "Timestamp of when the rule was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AlertRule) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_ALERT_RULE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AlertRule) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAlertRule
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the rule
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	rule, diags := api.Client().CreateAlertRule(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the rule to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfAlertRuleFromAPI(ctx, rule, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *AlertRule) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfAlertRule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the rule - if it no longer exists, remove it from the state
+	queryParams := api.AlertRuleQueryParams{
+		AlertRuleIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	rules, diags := api.Client().FindAlertRules(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(rules) == 0 {
+		tflog.Debug(ctx, "Custom alert rule no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfAlertRuleFromAPI(ctx, &rules[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *AlertRule) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAlertRule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAlertRule
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the rule
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	rule, diags := api.Client().UpdateAlertRule(ctx, state.Id.ValueString(), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated rule to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfAlertRuleFromAPI(ctx, rule, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *AlertRule) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfAlertRule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the rule
+	resp.Diagnostics.Append(api.Client().DeleteAlertRule(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted custom alert rule", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing custom alert rule into the Terraform state.
+//
+// The API can only find a custom alert rule within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<alert_rule_id>.
+func (r *AlertRule) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<alert_rule_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_ALERT_RULE_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a custom alert rule.
+func (r *AlertRule) bodyFromPlan(ctx context.Context, plan tfAlertRule) (api.AlertRuleBody, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body := api.AlertRuleBody{}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := plan.Enabled.ValueBool()
+		body.Enabled = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if plan.NotificationTargets != nil {
+		body.NotificationTargets = []string{}
+		for _, target := range plan.NotificationTargets {
+			body.NotificationTargets = append(body.NotificationTargets, target.ValueString())
+		}
+	}
+
+	if !plan.Query.IsNull() && !plan.Query.IsUnknown() {
+		value := plan.Query.ValueString()
+		body.Query = &value
+	}
+
+	if !plan.Severity.IsNull() && !plan.Severity.IsUnknown() {
+		value := plan.Severity.ValueString()
+		body.Severity = &value
+	}
+
+	if !plan.ThrottleWindow.IsNull() && !plan.ThrottleWindow.IsUnknown() {
+		window, diags := plugin.ParseRelativeDuration(ctx, plan.ThrottleWindow.ValueString())
+		if diags.HasError() {
+			return body, diags
+		}
+		value := int(window.Seconds())
+		body.ThrottleWindowSeconds = &value
+	}
+	return body, diags
+}
+
+// tfAlertRuleFromAPI converts an API custom alert rule into a Terraform custom alert rule.
+//
+// The API does not return the scope of the rule so it is copied from the given model. The API returns the throttle
+// window in seconds so the configured window is kept as long as it still refers to the same duration.
+func tfAlertRuleFromAPI(ctx context.Context, rule *api.AlertRule, model tfAlertRule) tfAlertRule {
+	tfrule := tfAlertRule{
+		CreatedAt:           types.StringValue(rule.CreatedAt),
+		Description:         types.StringNull(),
+		Enabled:             types.BoolValue(rule.Enabled),
+		Id:                  types.StringValue(rule.Id),
+		Name:                types.StringValue(rule.Name),
+		NotificationTargets: []types.String{},
+		Query:               types.StringValue(rule.Query),
+		ScopeId:             model.ScopeId,
+		ScopeType:           model.ScopeType,
+		Severity:            types.StringValue(rule.Severity),
+		ThrottleWindow:      types.StringValue(fmt.Sprintf("%ds", rule.ThrottleWindowSeconds)),
+		UpdatedAt:           types.StringValue(rule.UpdatedAt),
+	}
+	if rule.Description != "" || !model.Description.IsNull() {
+		tfrule.Description = types.StringValue(rule.Description)
+	}
+	for _, target := range rule.NotificationTargets {
+		tfrule.NotificationTargets = append(tfrule.NotificationTargets, types.StringValue(target))
+	}
+	if !model.ThrottleWindow.IsNull() && !model.ThrottleWindow.IsUnknown() {
+		window, diags := plugin.ParseRelativeDuration(ctx, model.ThrottleWindow.ValueString())
+		if !diags.HasError() && int(window.Seconds()) == rule.ThrottleWindowSeconds {
+			tfrule.ThrottleWindow = model.ThrottleWindow
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API custom alert rule to TF custom alert rule: %+v", tfrule),
+		map[string]interface{}{
+			"api_alert_rule": rule,
+		})
+	return tfrule
+}
@@ -0,0 +1,354 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// API_TOKEN_USER_TYPE_USER and API_TOKEN_USER_TYPE_SERVICE_USER are the types of users for which API tokens can be
+// generated.
+const (
+	API_TOKEN_USER_TYPE_USER         = "user"
+	API_TOKEN_USER_TYPE_SERVICE_USER = "service_user"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource               = &ApiToken{}
+	_ resource.ResourceWithConfigure  = &ApiToken{}
+	_ resource.ResourceWithModifyPlan = &ApiToken{}
+)
+
+// tfApiToken defines the Terraform model for an API token.
+type tfApiToken struct {
+	ExpirationDays types.Int64  `tfsdk:"expiration_days"`
+	ExpiresAt      types.String `tfsdk:"expires_at"`
+	GeneratedAt    types.String `tfsdk:"generated_at"`
+	Id             types.String `tfsdk:"id"`
+	RotationDays   types.Int64  `tfsdk:"rotation_days"`
+	Token          types.String `tfsdk:"token"`
+	UserId         types.String `tfsdk:"user_id"`
+	UserType       types.String `tfsdk:"user_type"`
+}
+
+// NewApiToken creates a new ApiToken object.
+func NewApiToken() resource.Resource {
+	return &ApiToken{}
+}
+
+// ApiToken is a resource used to generate and rotate the API token of a user or service user.
+type ApiToken struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ApiToken) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ApiToken) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for generating and rotating the API token of a user or service user.",
+		MarkdownDescription: `This resource is used for generating and rotating the API token of a user or service user.
+
+		The token is stored in the Terraform state as the sensitive ` + "`token`" + ` attribute so that it can be
+		passed to other providers. A new token is generated in place, revoking the previous one, whenever
+		` + "`expiration_days`" + ` changes or, if ` + "`rotation_days`" + ` is set, on the first apply after the
+		token has reached that age. Destroying the resource revokes the token.
+
+		A user can only have one API token at a time, so each user should be managed by at most one of these
+		resources and service users created by ` + "`singularity_service_user`" + ` should use the token it
+		generates instead.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"expiration_days": schema.Int64Attribute{
+				Description: "Number of days after it has been generated that the token expires. Changing this " +
+					"value generates a new token. [Default: 90]",
+				MarkdownDescription: "Number of days after it has been generated that the token expires. Changing this " +
+					"value generates a new token. [Default: `90`]",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(90),
+			},
+			"expires_at": schema.StringAttribute{
+				Description:         "Timestamp of when the token expires.",
+				MarkdownDescription: "Timestamp of when the token expires.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"generated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the token was generated.",
+				MarkdownDescription: "Timestamp of when the token was generated.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the token (in the format <user_type>/<user_id>).",
+				MarkdownDescription: "ID of the token (in the format `<user_type>/<user_id>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotation_days": schema.Int64Attribute{
+				Description: "Number of days after which a new token is generated on the next apply. This should " +
+					"be less than the expiration days. [Default: none]",
+				MarkdownDescription: "Number of days after which a new token is generated on the next apply. This " +
+					"should be less than the expiration days. [Default: none]",
+				Optional: true,
+			},
+			"token": schema.StringAttribute{
+				Description:         "The API token.",
+				MarkdownDescription: "The API token.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Description:         "ID of the user or service user for which the token is generated.",
+				MarkdownDescription: "ID of the user or service user for which the token is generated.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_type": schema.StringAttribute{
+				Description:         "Type of user for which the token is generated (valid values: user, service_user).",
+				MarkdownDescription: "Type of user for which the token is generated (valid values: `user`, `service_user`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, API_TOKEN_USER_TYPE_USER, API_TOKEN_USER_TYPE_SERVICE_USER),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ApiToken) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_API_TOKEN_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// ModifyPlan is called to modify the Terraform plan.
+func (r *ApiToken) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+
+	// nothing to do when the resource is being created or destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// retrieve values from plan and state
+	var plan, state tfApiToken
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a new token is generated when the expiration changes or when the token is due to be rotated
+	rotate := !plan.ExpirationDays.Equal(state.ExpirationDays)
+	if !rotate && !plan.RotationDays.IsNull() && !plan.RotationDays.IsUnknown() {
+		generatedAt, err := time.Parse(time.RFC3339, state.GeneratedAt.ValueString())
+		if err != nil {
+			msg := fmt.Sprintf("The time at which the token was generated could not be parsed so the token will be "+
+				"rotated.\n\nError: %s\nGenerated At: %s", err.Error(), state.GeneratedAt.ValueString())
+			tflog.Warn(ctx, msg, map[string]interface{}{
+				"error":               err.Error(),
+				"internal_error_code": plugin.ERR_RESOURCE_API_TOKEN_MODIFY_PLAN,
+			})
+			resp.Diagnostics.AddWarning("Invalid Generation Time", msg)
+			rotate = true
+		} else {
+			rotateAt := generatedAt.Add(time.Duration(plan.RotationDays.ValueInt64()) * 24 * time.Hour)
+			rotate = !time.Now().Before(rotateAt)
+		}
+	}
+	if rotate {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("expires_at"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("generated_at"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("token"), types.StringUnknown())...)
+	}
+}
+
+// Create is used to create the Terraform resource.
+func (r *ApiToken) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfApiToken
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// generate the token
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", plan.UserType.ValueString(), plan.UserId.ValueString()))
+	resp.Diagnostics.Append(r.generate(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the token to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *ApiToken) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfApiToken
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, state.Token.ValueString())
+
+	// the token itself cannot be read back so only check that the user still exists
+	userId := state.UserId.ValueString()
+	found := false
+	if state.UserType.ValueString() == API_TOKEN_USER_TYPE_SERVICE_USER {
+		users, diags := api.Client().FindServiceUsers(ctx, api.ServiceUserQueryParams{
+			ServiceUserIds: []string{userId},
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		found = len(users) > 0
+	} else {
+		users, diags := api.Client().FindUsers(ctx, api.UserQueryParams{UserIds: []string{userId}})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		found = len(users) > 0
+	}
+	if !found {
+		tflog.Debug(ctx, "User for API token no longer exists.", map[string]interface{}{
+			"user_id":   userId,
+			"user_type": state.UserType.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *ApiToken) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfApiToken
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.Token.ValueString())
+
+	// generate a new token if it is being rotated (see ModifyPlan)
+	if plan.Token.IsUnknown() {
+		resp.Diagnostics.Append(r.generate(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, "Rotated API token", map[string]interface{}{
+			"id": plan.Id.ValueString(),
+		})
+	}
+
+	// save the plan to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *ApiToken) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfApiToken
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// revoke the token
+	if state.UserType.ValueString() == API_TOKEN_USER_TYPE_SERVICE_USER {
+		resp.Diagnostics.Append(api.Client().RevokeServiceUserApiToken(ctx, state.UserId.ValueString())...)
+	} else {
+		resp.Diagnostics.Append(api.Client().RevokeUserApiToken(ctx, state.UserId.ValueString())...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Revoked API token", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// generate generates a new token for the user in the given model and updates the model with the new token.
+func (r *ApiToken) generate(ctx context.Context, model *tfApiToken) diag.Diagnostics {
+	generatedAt := time.Now().UTC()
+	expiresAt := generatedAt.Add(time.Duration(model.ExpirationDays.ValueInt64()) * 24 * time.Hour)
+	expirationDate := expiresAt.Format(time.RFC3339)
+
+	var token string
+	var diags diag.Diagnostics
+	if model.UserType.ValueString() == API_TOKEN_USER_TYPE_SERVICE_USER {
+		token, diags = api.Client().GenerateServiceUserApiToken(ctx, model.UserId.ValueString(), expirationDate)
+	} else {
+		token, diags = api.Client().GenerateUserApiToken(ctx, model.UserId.ValueString(), expirationDate)
+	}
+	if diags.HasError() {
+		return diags
+	}
+	model.ExpiresAt = types.StringValue(expirationDate)
+	model.GeneratedAt = types.StringValue(generatedAt.Format(time.RFC3339))
+	model.Token = types.StringValue(token)
+	return diags
+}
@@ -0,0 +1,463 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &AzureCloudConnector{}
+	_ resource.ResourceWithConfigure   = &AzureCloudConnector{}
+	_ resource.ResourceWithImportState = &AzureCloudConnector{}
+)
+
+// tfAzureCloudConnector defines the Terraform model for a connection between Azure and Singularity Cloud.
+type tfAzureCloudConnector struct {
+	ClientId        types.String `tfsdk:"client_id"`
+	ClientSecret    types.String `tfsdk:"client_secret"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	Id              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	ScopeId         types.String `tfsdk:"scope_id"`
+	ScopeType       types.String `tfsdk:"scope_type"`
+	Services        types.List   `tfsdk:"services"`
+	Status          types.String `tfsdk:"status"`
+	StatusMessage   types.String `tfsdk:"status_message"`
+	SubscriptionIds types.Set    `tfsdk:"subscription_ids"`
+	TenantId        types.String `tfsdk:"tenant_id"`
+	UpdatedAt       types.String `tfsdk:"updated_at"`
+	WaitTimeout     types.String `tfsdk:"wait_timeout"`
+}
+
+// NewAzureCloudConnector creates a new AzureCloudConnector object.
+func NewAzureCloudConnector() resource.Resource {
+	return &AzureCloudConnector{}
+}
+
+// AzureCloudConnector is a resource used to connect Azure subscriptions to Singularity Cloud.
+type AzureCloudConnector struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *AzureCloudConnector) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_azure_cloud_connector"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *AzureCloudConnector) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for connecting Azure subscriptions to Singularity Cloud within an " +
+			"account or site.",
+		MarkdownDescription: `This resource is used for connecting Azure subscriptions to Singularity Cloud within an
+			account or site.
+
+		The console authenticates to the Azure tenant with the credentials of the given app registration. Once the
+		connector is created or updated, the console verifies the connection and an error is returned if it fails. If
+		the connection later fails (eg: the client secret expires), a warning is shown when the resource is refreshed.
+		Existing connectors can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<connector_id>`" + `;
+		since the API never returns the client secret, it is applied on the next update.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the connector was created.",
+				MarkdownDescription: "Timestamp of when the connector was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				Description:         "Application (client) ID of the app registration used by the console.",
+				MarkdownDescription: "Application (client) ID of the app registration used by the console.",
+				Required:            true,
+			},
+			"client_secret": schema.StringAttribute{
+				Description:         "Client secret of the app registration used by the console.",
+				MarkdownDescription: "Client secret of the app registration used by the console.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the connector.",
+				MarkdownDescription: "ID of the connector.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the connector.",
+				MarkdownDescription: "Name of the connector.",
+				Required:            true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the connector belongs.",
+				MarkdownDescription: "ID of the account or site to which the connector belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the connector is created (valid values: account, site).",
+				MarkdownDescription: "Level at which the connector is created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"services": schema.ListAttribute{
+				Description: "Singularity Cloud services enabled for the connected subscriptions (valid values: cspm, " +
+					"cdr, cwpp).",
+				MarkdownDescription: "Singularity Cloud services enabled for the connected subscriptions (valid values: " +
+					"`cspm`, `cdr`, `cwpp`).",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, "cspm", "cdr", "cwpp"),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description:         "Status of the connection (eg: connected, error).",
+				MarkdownDescription: "Status of the connection (eg: `connected`, `error`).",
+				Computed:            true,
+			},
+			"status_message": schema.StringAttribute{
+				Description:         "Details of the status of the connection, such as the reason it failed.",
+				MarkdownDescription: "Details of the status of the connection, such as the reason it failed.",
+				Computed:            true,
+			},
+			"subscription_ids": schema.SetAttribute{
+				Description: "IDs of the Azure subscriptions to connect. If not set, every subscription in the " +
+					"tenant which the app registration can access is connected.",
+				MarkdownDescription: "IDs of the Azure subscriptions to connect. If not set, every subscription in the " +
+					"tenant which the app registration can access is connected.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description:         "ID of the Azure tenant (directory) to which the subscriptions belong.",
+				MarkdownDescription: "ID of the Azure tenant (directory) to which the subscriptions belong.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the connector was last updated.",
+				MarkdownDescription: "Timestamp of when the connector was last updated.",
+				Computed:            true,
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description:         "How long to wait for the console to verify the connection. [Default: 5m]",
+				MarkdownDescription: "How long to wait for the console to verify the connection. [Default: `5m`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("5m"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *AzureCloudConnector) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_AZURE_CLOUD_CONNECTOR_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *AzureCloudConnector) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfAzureCloudConnector
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.ClientSecret.ValueString())
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the connector
+	connector, diags := api.Client().CreateCloudConnector(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the connector to the state before verifying it so that a failed connector is not left unmanaged
+	tfconnector, diags := tfAzureCloudConnectorFromAPI(ctx, connector, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfconnector)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.verify(ctx, connector, plan, &resp.State)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *AzureCloudConnector) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfAzureCloudConnector
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, state.ClientSecret.ValueString())
+
+	// find the connector - if it no longer exists, remove it from the state
+	connector, diags := findCloudConnector(ctx, state.ScopeType, state.ScopeId, state.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if connector == nil {
+		tflog.Debug(ctx, "Cloud connector no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if connector.Status == api.CLOUD_CONNECTOR_STATUS_ERROR {
+		resp.Diagnostics.Append(cloudConnectorStatusDiags(ctx, connector, diag.SeverityWarning)...)
+	}
+
+	// save refreshed state
+	tfconnector, diags := tfAzureCloudConnectorFromAPI(ctx, connector, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfconnector)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *AzureCloudConnector) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfAzureCloudConnector
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfAzureCloudConnector
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.ClientSecret.ValueString(), state.ClientSecret.ValueString())
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the connector
+	connector, diags := api.Client().UpdateCloudConnector(ctx, state.Id.ValueString(), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated connector to the state and verify it
+	tfconnector, diags := tfAzureCloudConnectorFromAPI(ctx, connector, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfconnector)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.verify(ctx, connector, plan, &resp.State)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *AzureCloudConnector) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfAzureCloudConnector
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the connector
+	resp.Diagnostics.Append(api.Client().DeleteCloudConnector(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted cloud connector", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing connector into the Terraform state.
+//
+// The API can only find a connector within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<connector_id>.
+func (r *AzureCloudConnector) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<connector_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_AZURE_CLOUD_CONNECTOR_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("wait_timeout"), "5m")...)
+}
+
+// verify waits for the console to verify the connection of the given connector and saves the verified connector to
+// the state.
+func (r *AzureCloudConnector) verify(ctx context.Context, connector *api.CloudConnector, plan tfAzureCloudConnector,
+	state *tfsdk.State) diag.Diagnostics {
+
+	verified, diags := waitForCloudConnector(ctx, connector, plan.ScopeType, plan.ScopeId,
+		plan.WaitTimeout.ValueString())
+	if verified == nil {
+		return diags
+	}
+	tfconnector, d := tfAzureCloudConnectorFromAPI(ctx, verified, plan)
+	diags.Append(d...)
+	if d.HasError() {
+		return diags
+	}
+	diags.Append(state.Set(ctx, tfconnector)...)
+	return diags
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a connector.
+func (r *AzureCloudConnector) bodyFromPlan(ctx context.Context, plan tfAzureCloudConnector) (api.CloudConnectorBody,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	name := plan.Name.ValueString()
+	body := api.CloudConnectorBody{
+		Azure: &api.AzureCloudConnectorConfig{
+			ClientId:        plan.ClientId.ValueString(),
+			ClientSecret:    plan.ClientSecret.ValueString(),
+			SubscriptionIds: []string{},
+			TenantId:        plan.TenantId.ValueString(),
+		},
+		Name:     &name,
+		Services: []string{},
+	}
+	if !plan.SubscriptionIds.IsNull() && !plan.SubscriptionIds.IsUnknown() {
+		diags.Append(plan.SubscriptionIds.ElementsAs(ctx, &body.Azure.SubscriptionIds, false)...)
+	}
+	diags.Append(plan.Services.ElementsAs(ctx, &body.Services, false)...)
+	return body, diags
+}
+
+// tfAzureCloudConnectorFromAPI converts an API cloud connector into a Terraform Azure cloud connector.
+//
+// The API does not return the scope of the connector or its client secret so they are copied from the given model
+// along with the other attributes which only affect the provider.
+func tfAzureCloudConnectorFromAPI(ctx context.Context, connector *api.CloudConnector, model tfAzureCloudConnector) (
+	tfAzureCloudConnector, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	config := connector.Azure
+	if config == nil {
+		config = &api.AzureCloudConnectorConfig{}
+	}
+	tfconnector := tfAzureCloudConnector{
+		ClientId:        types.StringValue(config.ClientId),
+		ClientSecret:    model.ClientSecret,
+		CreatedAt:       types.StringValue(connector.CreatedAt),
+		Id:              types.StringValue(connector.Id),
+		Name:            types.StringValue(connector.Name),
+		ScopeId:         model.ScopeId,
+		ScopeType:       model.ScopeType,
+		Status:          types.StringValue(connector.Status),
+		StatusMessage:   types.StringValue(connector.StatusMessage),
+		SubscriptionIds: types.SetNull(types.StringType),
+		TenantId:        types.StringValue(config.TenantId),
+		UpdatedAt:       types.StringValue(connector.UpdatedAt),
+		WaitTimeout:     model.WaitTimeout,
+	}
+	if len(config.SubscriptionIds) > 0 {
+		tfconnector.SubscriptionIds, diags = types.SetValueFrom(ctx, types.StringType, config.SubscriptionIds)
+		if diags.HasError() {
+			return tfconnector, diags
+		}
+	}
+	services := connector.Services
+	if services == nil {
+		services = []string{}
+	}
+	tfconnector.Services, diags = types.ListValueFrom(ctx, types.StringType, services)
+	if diags.HasError() {
+		return tfconnector, diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API cloud connector to TF Azure cloud connector: %+v", tfconnector),
+		map[string]interface{}{
+			"api_cloud_connector": connector,
+		})
+	return tfconnector, diags
+}
@@ -0,0 +1,337 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &BlocklistHash{}
+	_ resource.ResourceWithConfigure = &BlocklistHash{}
+)
+
+// tfBlocklistHash defines the Terraform model for a blocklisted hash.
+type tfBlocklistHash struct {
+	CreatedAt   types.String `tfsdk:"created_at"`
+	Description types.String `tfsdk:"description"`
+	Id          types.String `tfsdk:"id"`
+	OSType      types.String `tfsdk:"os_type"`
+	ScopeId     types.String `tfsdk:"scope_id"`
+	ScopeName   types.String `tfsdk:"scope_name"`
+	ScopeType   types.String `tfsdk:"scope_type"`
+	SHA1        types.String `tfsdk:"sha1"`
+	Source      types.String `tfsdk:"source"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+	UserName    types.String `tfsdk:"user_name"`
+}
+
+// NewBlocklistHash creates a new BlocklistHash object.
+func NewBlocklistHash() resource.Resource {
+	return &BlocklistHash{}
+}
+
+// BlocklistHash is a resource used to manage the lifecycle of a blocklisted hash.
+type BlocklistHash struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *BlocklistHash) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blocklist_hash"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *BlocklistHash) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for adding a SHA1 hash to the blocklist of an account, site or group.",
+		MarkdownDescription: `This resource is used for adding a SHA1 hash to the blocklist of an account, site or
+			group.
+
+		Agents within the scope will block any file whose SHA1 hash matches. Destroying the resource removes the hash
+		from the blocklist.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the hash was added to the blocklist.",
+				MarkdownDescription: "Timestamp of when the hash was added to the blocklist.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "User-defined description of the blocklisted hash. [Default: none]",
+				MarkdownDescription: "User-defined description of the blocklisted hash. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the blocklist entry.",
+				MarkdownDescription: "ID of the blocklist entry.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"os_type": schema.StringAttribute{
+				Description:         "Operating system to which the entry applies (valid values: linux, macos, windows).",
+				MarkdownDescription: "Operating system to which the entry applies (valid values: `linux`, `macos`, `windows`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "linux", "macos", "windows"),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the entry applies.",
+				MarkdownDescription: "ID of the account, site or group to which the entry applies.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_name": schema.StringAttribute{
+				Description:         "Name of the account, site or group to which the entry applies.",
+				MarkdownDescription: "Name of the account, site or group to which the entry applies.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the entry applies (valid values: account, site, group).",
+				MarkdownDescription: "Level at which the entry applies (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"sha1": schema.StringAttribute{
+				Description:         "SHA1 hash of the file to block.",
+				MarkdownDescription: "SHA1 hash of the file to block.",
+				Required:            true,
+			},
+			"source": schema.StringAttribute{
+				Description:         "Source from which the entry was created.",
+				MarkdownDescription: "Source from which the entry was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the entry was last updated.",
+				MarkdownDescription: "Timestamp of when the entry was last updated.",
+				Computed:            true,
+			},
+			"user_name": schema.StringAttribute{
+				Description:         "Name of the user who last modified the entry.",
+				MarkdownDescription: "Name of the user who last modified the entry.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *BlocklistHash) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_BLOCKLIST_HASH_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *BlocklistHash) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfBlocklistHash
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// add the hash to the blocklist
+	restriction, diags := api.Client().CreateRestriction(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the entry to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfBlocklistHashFromAPI(ctx, restriction, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *BlocklistHash) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfBlocklistHash
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the entry - if it no longer exists, remove it from the state
+	restrictionType := api.RESTRICTION_TYPE_BLOCKLIST_HASH
+	queryParams := api.RestrictionQueryParams{
+		RestrictionIds: []string{state.Id.ValueString()},
+		Type:           &restrictionType,
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{state.ScopeId.ValueString()}
+	}
+	restrictions, diags := api.Client().FindRestrictions(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(restrictions) == 0 {
+		tflog.Debug(ctx, "Blocklisted hash no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfBlocklistHashFromAPI(ctx, &restrictions[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *BlocklistHash) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfBlocklistHash
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfBlocklistHash
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the entry
+	restriction, diags := api.Client().UpdateRestriction(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated entry to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfBlocklistHashFromAPI(ctx, restriction, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *BlocklistHash) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfBlocklistHash
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// remove the hash from the blocklist
+	resp.Diagnostics.Append(api.Client().DeleteRestriction(ctx, state.Id.ValueString(),
+		api.RESTRICTION_TYPE_BLOCKLIST_HASH)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed blocklisted hash", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a blocklist entry.
+func (r *BlocklistHash) bodyFromPlan(plan tfBlocklistHash) api.RestrictionBody {
+	restrictionType := api.RESTRICTION_TYPE_BLOCKLIST_HASH
+	body := api.RestrictionBody{
+		Type: &restrictionType,
+	}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.OSType.IsNull() && !plan.OSType.IsUnknown() {
+		value := plan.OSType.ValueString()
+		body.OSType = &value
+	}
+
+	if !plan.SHA1.IsNull() && !plan.SHA1.IsUnknown() {
+		value := plan.SHA1.ValueString()
+		body.Value = &value
+	}
+	return body
+}
+
+// tfBlocklistHashFromAPI converts an API restriction into a Terraform blocklisted hash.
+//
+// The API does not return the scope to which the entry was assigned so it is copied from the given model.
+func tfBlocklistHashFromAPI(ctx context.Context, restriction *api.Restriction, model tfBlocklistHash) tfBlocklistHash {
+	tfhash := tfBlocklistHash{
+		CreatedAt:   types.StringValue(restriction.CreatedAt),
+		Description: types.StringValue(restriction.Description),
+		Id:          types.StringValue(restriction.Id),
+		OSType:      types.StringValue(restriction.OSType),
+		ScopeId:     model.ScopeId,
+		ScopeName:   types.StringValue(restriction.ScopeName),
+		ScopeType:   model.ScopeType,
+		SHA1:        types.StringValue(restriction.Value),
+		Source:      types.StringValue(restriction.Source),
+		UpdatedAt:   types.StringValue(restriction.UpdatedAt),
+		UserName:    types.StringValue(restriction.UserName),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API restriction to TF blocklisted hash: %+v", tfhash),
+		map[string]interface{}{
+			"api_restriction": restriction,
+		})
+	return tfhash
+}
@@ -0,0 +1,100 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api/waiter"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// findCloudConnector returns the cloud connector with the given ID within the given scope or nil if it does not
+// exist.
+func findCloudConnector(ctx context.Context, scopeType, scopeId types.String, id string) (*api.CloudConnector,
+	diag.Diagnostics) {
+
+	queryParams := api.CloudConnectorQueryParams{
+		CloudConnectorIds: []string{id},
+	}
+	if scopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{scopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{scopeId.ValueString()}
+	}
+	connectors, diags := api.Client().FindCloudConnectors(ctx, queryParams)
+	if diags.HasError() || len(connectors) == 0 {
+		return nil, diags
+	}
+	return &connectors[0], diags
+}
+
+// waitForCloudConnector waits for the console to finish verifying the connection of the given cloud connector and
+// returns the verified connector.
+//
+// An error is returned if the connection could not be established.
+func waitForCloudConnector(ctx context.Context, connector *api.CloudConnector, scopeType, scopeId types.String,
+	timeout string) (*api.CloudConnector, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	if connector.Status == api.CLOUD_CONNECTOR_STATUS_PENDING {
+		duration, diags := plugin.ParseRelativeDuration(ctx, timeout)
+		if diags.HasError() {
+			return nil, diags
+		}
+		connector, diags = waiter.Wait(ctx, waiter.Config{
+			Description: "cloud connector verification",
+			Pending:     []string{api.CLOUD_CONNECTOR_STATUS_PENDING},
+			Target:      []string{api.CLOUD_CONNECTOR_STATUS_CONNECTED, api.CLOUD_CONNECTOR_STATUS_ERROR},
+			Timeout:     duration,
+		}, func(ctx context.Context) (*api.CloudConnector, string, diag.Diagnostics) {
+			found, diags := findCloudConnector(ctx, scopeType, scopeId, connector.Id)
+			if diags.HasError() {
+				return nil, "", diags
+			}
+			if found == nil {
+				return nil, "deleted", diags
+			}
+			return found, found.Status, diags
+		})
+		if diags.HasError() {
+			return nil, diags
+		}
+	}
+
+	if connector.Status == api.CLOUD_CONNECTOR_STATUS_ERROR {
+		diags.Append(cloudConnectorStatusDiags(ctx, connector, diag.SeverityError)...)
+	}
+	return connector, diags
+}
+
+// cloudConnectorStatusDiags returns a diagnostic with the given severity describing why the connection of the given
+// cloud connector failed.
+func cloudConnectorStatusDiags(ctx context.Context, connector *api.CloudConnector,
+	severity diag.Severity) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+	msg := fmt.Sprintf("The console could not connect to the cloud provider using the connector's configuration. "+
+		"Check the credentials and permissions granted to Singularity Cloud.\n\nConnector: %s\nStatus: %s\n"+
+		"Message: %s", connector.Id, connector.Status, connector.StatusMessage)
+	if severity == diag.SeverityError {
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"connector_id":        connector.Id,
+			"status_message":      connector.StatusMessage,
+			"internal_error_code": plugin.ERR_RESOURCE_CLOUD_CONNECTOR_STATUS,
+		})
+		diags.AddError("Cloud Connector Error", msg)
+	} else {
+		tflog.Warn(ctx, msg, map[string]interface{}{
+			"connector_id":   connector.Id,
+			"status_message": connector.StatusMessage,
+		})
+		diags.AddWarning("Cloud Connector Error", msg)
+	}
+	return diags
+}
@@ -0,0 +1,461 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &CnsPolicy{}
+	_ resource.ResourceWithConfigure   = &CnsPolicy{}
+	_ resource.ResourceWithImportState = &CnsPolicy{}
+)
+
+// tfCnsPolicy defines the Terraform model for a Cloud Native Security policy.
+type tfCnsPolicy struct {
+	CreatedAt   types.String           `tfsdk:"created_at"`
+	Description types.String           `tfsdk:"description"`
+	Enabled     types.Bool             `tfsdk:"enabled"`
+	Exemptions  []tfCnsPolicyExemption `tfsdk:"exemption"`
+	Id          types.String           `tfsdk:"id"`
+	Name        types.String           `tfsdk:"name"`
+	RuleSets    types.Set              `tfsdk:"rule_sets"`
+	ScopeId     types.String           `tfsdk:"scope_id"`
+	ScopeType   types.String           `tfsdk:"scope_type"`
+	Severities  types.List             `tfsdk:"severities"`
+	UpdatedAt   types.String           `tfsdk:"updated_at"`
+}
+
+// tfCnsPolicyExemption defines the Terraform model for a cloud resource exempted from a rule of a policy.
+type tfCnsPolicyExemption struct {
+	ExpiresAt  types.String `tfsdk:"expires_at"`
+	Reason     types.String `tfsdk:"reason"`
+	ResourceId types.String `tfsdk:"resource_id"`
+	RuleId     types.String `tfsdk:"rule_id"`
+}
+
+// NewCnsPolicy creates a new CnsPolicy object.
+func NewCnsPolicy() resource.Resource {
+	return &CnsPolicy{}
+}
+
+// CnsPolicy is a resource used to manage a Cloud Native Security posture policy.
+type CnsPolicy struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *CnsPolicy) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cns_policy"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *CnsPolicy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	severities := []string{api.CNS_SEVERITY_CRITICAL, api.CNS_SEVERITY_HIGH, api.CNS_SEVERITY_MEDIUM,
+		api.CNS_SEVERITY_LOW}
+
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing a Cloud Native Security (CNS) posture policy within an " +
+			"account or site.",
+		MarkdownDescription: `This resource is used for managing a Cloud Native Security (CNS) posture policy within an
+			account or site.
+
+		A policy selects the rule sets (eg: compliance frameworks) which are evaluated against the connected cloud
+		accounts and the severities of the misconfigurations which are reported. Individual cloud resources can be
+		exempted from a rule using ` + "`exemption`" + ` blocks. Existing policies can be imported using an ID in the
+		format ` + "`<scope_type>/<scope_id>/<policy_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the policy was created.",
+				MarkdownDescription: "Timestamp of when the policy was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the policy.",
+				MarkdownDescription: "Description of the policy.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not the policy is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not the policy is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the policy.",
+				MarkdownDescription: "ID of the policy.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the policy.",
+				MarkdownDescription: "Name of the policy.",
+				Required:            true,
+			},
+			"rule_sets": schema.SetAttribute{
+				Description: "IDs of the rule sets evaluated by the policy (eg: cis-aws-foundations, " +
+					"pci-dss).",
+				MarkdownDescription: "IDs of the rule sets evaluated by the policy (eg: `cis-aws-foundations`, " +
+					"`pci-dss`).",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the policy belongs.",
+				MarkdownDescription: "ID of the account or site to which the policy belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level to which the policy belongs (valid values: account, site).",
+				MarkdownDescription: "Level to which the policy belongs (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"severities": schema.ListAttribute{
+				Description: fmt.Sprintf("Severities of the findings reported by the policy (valid values: %s).",
+					strings.Join(severities, ", ")),
+				MarkdownDescription: fmt.Sprintf("Severities of the findings reported by the policy (valid values: "+
+					"`%s`).", strings.Join(severities, "`, `")),
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, severities...),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the policy was last updated.",
+				MarkdownDescription: "Timestamp of when the policy was last updated.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"exemption": schema.ListNestedBlock{
+				Description:         "Exempts a cloud resource from a rule of the policy.",
+				MarkdownDescription: "Exempts a cloud resource from a rule of the policy.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"expires_at": schema.StringAttribute{
+							Description: "Timestamp at which the exemption expires in RFC3339 format. If not " +
+								"given, the exemption never expires.",
+							MarkdownDescription: "Timestamp at which the exemption expires in RFC3339 format. If not " +
+								"given, the exemption never expires.",
+							Optional: true,
+						},
+						"reason": schema.StringAttribute{
+							Description:         "Reason for exempting the resource.",
+							MarkdownDescription: "Reason for exempting the resource.",
+							Optional:            true,
+						},
+						"resource_id": schema.StringAttribute{
+							Description:         "ID of the cloud resource (eg: an AWS ARN).",
+							MarkdownDescription: "ID of the cloud resource (eg: an AWS ARN).",
+							Required:            true,
+						},
+						"rule_id": schema.StringAttribute{
+							Description:         "ID of the rule from which the resource is exempted.",
+							MarkdownDescription: "ID of the rule from which the resource is exempted.",
+							Required:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *CnsPolicy) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_CNS_POLICY_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *CnsPolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfCnsPolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the policy
+	policy, diags := api.Client().CreateCnsPolicy(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the policy to the state
+	tfpolicy, diags := tfCnsPolicyFromAPI(ctx, policy, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *CnsPolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfCnsPolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the policy - if it no longer exists, remove it from the state
+	queryParams := api.CnsPolicyQueryParams{
+		CnsPolicyIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	policies, diags := api.Client().FindCnsPolicies(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(policies) == 0 {
+		tflog.Debug(ctx, "CNS policy no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	tfpolicy, diags := tfCnsPolicyFromAPI(ctx, &policies[0], state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *CnsPolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfCnsPolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfCnsPolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the policy
+	policy, diags := api.Client().UpdateCnsPolicy(ctx, state.Id.ValueString(), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated policy to the state
+	tfpolicy, diags := tfCnsPolicyFromAPI(ctx, policy, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *CnsPolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfCnsPolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the policy
+	resp.Diagnostics.Append(api.Client().DeleteCnsPolicy(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted CNS policy", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing CNS policy into the Terraform state.
+//
+// The API can only find a policy within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<policy_id>.
+func (r *CnsPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<policy_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_CNS_POLICY_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a policy.
+func (r *CnsPolicy) bodyFromPlan(ctx context.Context, plan tfCnsPolicy) (api.CnsPolicyBody, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	description := plan.Description.ValueString()
+	enabled := plan.Enabled.ValueBool()
+	name := plan.Name.ValueString()
+	body := api.CnsPolicyBody{
+		Description: &description,
+		Enabled:     &enabled,
+		Exemptions:  []api.CnsPolicyExemption{},
+		Name:        &name,
+		RuleSets:    []string{},
+		Severities:  []string{},
+	}
+	diags.Append(plan.RuleSets.ElementsAs(ctx, &body.RuleSets, false)...)
+	diags.Append(plan.Severities.ElementsAs(ctx, &body.Severities, false)...)
+	for _, exemption := range plan.Exemptions {
+		body.Exemptions = append(body.Exemptions, api.CnsPolicyExemption{
+			ExpiresAt:  exemption.ExpiresAt.ValueString(),
+			Reason:     exemption.Reason.ValueString(),
+			ResourceId: exemption.ResourceId.ValueString(),
+			RuleId:     exemption.RuleId.ValueString(),
+		})
+	}
+	return body, diags
+}
+
+// tfCnsPolicyFromAPI converts an API CNS policy into a Terraform CNS policy.
+//
+// The API does not return the scope of the policy so it is copied from the given model.
+func tfCnsPolicyFromAPI(ctx context.Context, policy *api.CnsPolicy, model tfCnsPolicy) (tfCnsPolicy,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	tfpolicy := tfCnsPolicy{
+		CreatedAt:   types.StringValue(policy.CreatedAt),
+		Description: types.StringValue(policy.Description),
+		Enabled:     types.BoolValue(policy.Enabled),
+		Id:          types.StringValue(policy.Id),
+		Name:        types.StringValue(policy.Name),
+		ScopeId:     model.ScopeId,
+		ScopeType:   model.ScopeType,
+		UpdatedAt:   types.StringValue(policy.UpdatedAt),
+	}
+	for _, exemption := range policy.Exemptions {
+		tfexemption := tfCnsPolicyExemption{
+			ExpiresAt:  types.StringNull(),
+			Reason:     types.StringNull(),
+			ResourceId: types.StringValue(exemption.ResourceId),
+			RuleId:     types.StringValue(exemption.RuleId),
+		}
+		if exemption.ExpiresAt != "" {
+			tfexemption.ExpiresAt = types.StringValue(exemption.ExpiresAt)
+		}
+		if exemption.Reason != "" {
+			tfexemption.Reason = types.StringValue(exemption.Reason)
+		}
+		tfpolicy.Exemptions = append(tfpolicy.Exemptions, tfexemption)
+	}
+
+	ruleSets := policy.RuleSets
+	if ruleSets == nil {
+		ruleSets = []string{}
+	}
+	tfpolicy.RuleSets, diags = types.SetValueFrom(ctx, types.StringType, ruleSets)
+	if diags.HasError() {
+		return tfpolicy, diags
+	}
+	severities := policy.Severities
+	if severities == nil {
+		severities = []string{}
+	}
+	tfpolicy.Severities, diags = types.ListValueFrom(ctx, types.StringType, severities)
+	if diags.HasError() {
+		return tfpolicy, diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API CNS policy to TF CNS policy: %+v", tfpolicy),
+		map[string]interface{}{
+			"api_cns_policy": policy,
+		})
+	return tfpolicy, diags
+}
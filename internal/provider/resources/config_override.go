@@ -0,0 +1,392 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &ConfigOverride{}
+	_ resource.ResourceWithConfigure   = &ConfigOverride{}
+	_ resource.ResourceWithImportState = &ConfigOverride{}
+)
+
+// tfConfigOverride defines the Terraform model for an agent configuration override.
+type tfConfigOverride struct {
+	AgentVersion types.String `tfsdk:"agent_version"`
+	Config       types.String `tfsdk:"config"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+	Description  types.String `tfsdk:"description"`
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	OsType       types.String `tfsdk:"os_type"`
+	ScopeId      types.String `tfsdk:"scope_id"`
+	ScopeType    types.String `tfsdk:"scope_type"`
+	UpdatedAt    types.String `tfsdk:"updated_at"`
+}
+
+// NewConfigOverride creates a new ConfigOverride object.
+func NewConfigOverride() resource.Resource {
+	return &ConfigOverride{}
+}
+
+// ConfigOverride is a resource used to manage the lifecycle of an agent configuration override.
+type ConfigOverride struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ConfigOverride) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_config_override"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ConfigOverride) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing an agent configuration override within an " +
+			"account, site or group.",
+		MarkdownDescription: `This resource is used for creating and managing an agent configuration override within an
+			account, site or group.
+
+		Configuration overrides change advanced agent settings which are not exposed by policies. The override
+		applies to every agent running the given operating system within the scope, or only to agents running
+		` + "`agent_version`" + ` if it is given. Overrides can prevent agents from working correctly so they
+		should only be created as directed by SentinelOne support.
+
+		Existing overrides can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<override_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agent_version": schema.StringAttribute{
+				Description: "Agent version to which the override applies (eg: 23.1.2.400). [Default: none - the " +
+					"override applies to every agent version]",
+				MarkdownDescription: "Agent version to which the override applies (eg: `23.1.2.400`). [Default: none " +
+					"- the override applies to every agent version]",
+				Optional: true,
+			},
+			"config": schema.StringAttribute{
+				Description: "JSON encoded object holding the agent configuration to override (eg: " +
+					"jsonencode({...})).",
+				MarkdownDescription: "JSON encoded object holding the agent configuration to override (eg: " +
+					"`jsonencode({...})`).",
+				Required: true,
+				Validators: []validator.String{
+					validators.JSONObjectIsValid(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the override was created.",
+				MarkdownDescription: "Timestamp of when the override was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the override. [Default: none]",
+				MarkdownDescription: "Description of the override. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the override.",
+				MarkdownDescription: "ID of the override.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the override.",
+				MarkdownDescription: "Name of the override.",
+				Required:            true,
+			},
+			"os_type": schema.StringAttribute{
+				Description: "Operating system to which the override applies (valid values: linux, macos, " +
+					"windows).",
+				MarkdownDescription: "Operating system to which the override applies (valid values: `linux`, " +
+					"`macos`, `windows`).",
+				Required: true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "linux", "macos", "windows"),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the override belongs.",
+				MarkdownDescription: "ID of the account, site or group to which the override belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the override is created (valid values: account, site, group).",
+				MarkdownDescription: "Level at which the override is created (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the override was last updated.",
+				MarkdownDescription: "Timestamp of when the override was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ConfigOverride) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_CONFIG_OVERRIDE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *ConfigOverride) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfConfigOverride
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the override
+	override, diags := api.Client().CreateConfigOverride(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the override to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfConfigOverrideFromAPI(ctx, override, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *ConfigOverride) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfConfigOverride
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the override - if it no longer exists, remove it from the state
+	queryParams := api.ConfigOverrideQueryParams{
+		ConfigOverrideIds: []string{state.Id.ValueString()},
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{state.ScopeId.ValueString()}
+	}
+	overrides, diags := api.Client().FindConfigOverrides(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(overrides) == 0 {
+		tflog.Debug(ctx, "Configuration override no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfConfigOverrideFromAPI(ctx, &overrides[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *ConfigOverride) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfConfigOverride
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfConfigOverride
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the override
+	override, diags := api.Client().UpdateConfigOverride(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated override to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfConfigOverrideFromAPI(ctx, override, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *ConfigOverride) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfConfigOverride
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the override
+	resp.Diagnostics.Append(api.Client().DeleteConfigOverride(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed configuration override", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing configuration override into the Terraform state.
+//
+// The API can only find an override within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<override_id>.
+func (r *ConfigOverride) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE &&
+		parts[0] != api.SCOPE_GROUP) || parts[1] == "" || parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<override_id> where the "+
+			"scope type is one of: %s, %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP,
+			req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_CONFIG_OVERRIDE_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating an override.
+func (r *ConfigOverride) bodyFromPlan(plan tfConfigOverride) api.ConfigOverrideBody {
+	body := api.ConfigOverrideBody{}
+
+	if !plan.AgentVersion.IsNull() && !plan.AgentVersion.IsUnknown() {
+		value := plan.AgentVersion.ValueString()
+		body.AgentVersion = &value
+	}
+
+	if !plan.Config.IsNull() && !plan.Config.IsUnknown() {
+		body.Config = json.RawMessage(plan.Config.ValueString())
+	}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if !plan.OsType.IsNull() && !plan.OsType.IsUnknown() {
+		value := plan.OsType.ValueString()
+		body.OsType = &value
+	}
+	return body
+}
+
+// tfConfigOverrideFromAPI converts an API configuration override into a Terraform configuration override.
+//
+// The scope is copied from the given model since it is always known from the configuration or import ID. The
+// configuration in the model is kept if it is equivalent to the one returned by the API so that differences in
+// formatting or key order do not cause a perpetual diff.
+func tfConfigOverrideFromAPI(ctx context.Context, override *api.ConfigOverride,
+	model tfConfigOverride) tfConfigOverride {
+
+	tfoverride := tfConfigOverride{
+		AgentVersion: types.StringNull(),
+		Config:       types.StringValue(string(override.Config)),
+		CreatedAt:    types.StringValue(override.CreatedAt),
+		Description:  types.StringValue(override.Description),
+		Id:           types.StringValue(override.Id),
+		Name:         types.StringValue(override.Name),
+		OsType:       types.StringValue(override.OsType),
+		ScopeId:      model.ScopeId,
+		ScopeType:    model.ScopeType,
+		UpdatedAt:    types.StringValue(override.UpdatedAt),
+	}
+	if override.VersionOption == api.CONFIG_OVERRIDE_VERSION_SPECIFIC {
+		tfoverride.AgentVersion = types.StringValue(override.AgentVersion)
+	}
+	if !model.Config.IsNull() && !model.Config.IsUnknown() && jsonEquivalent(model.Config.ValueString(),
+		string(override.Config)) {
+		tfoverride.Config = model.Config
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API configuration override to TF configuration override: %+v",
+		tfoverride), map[string]interface{}{
+		"api_config_override": override,
+	})
+	return tfoverride
+}
+
+// jsonEquivalent determines whether or not the given JSON documents hold the same value regardless of formatting
+// or key order.
+func jsonEquivalent(a, b string) bool {
+	var va, vb interface{}
+	if err := json.Unmarshal([]byte(a), &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,318 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Console2FASettings{}
+	_ resource.ResourceWithConfigure   = &Console2FASettings{}
+	_ resource.ResourceWithImportState = &Console2FASettings{}
+)
+
+// tfConsole2FASettings defines the Terraform model for the console two-factor authentication settings.
+type tfConsole2FASettings struct {
+	AccountId       types.String `tfsdk:"account_id"`
+	AllowedMethods  types.Set    `tfsdk:"allowed_methods"`
+	Enforce2FA      types.Bool   `tfsdk:"enforce_2fa"`
+	GracePeriodDays types.Int64  `tfsdk:"grace_period_days"`
+	Id              types.String `tfsdk:"id"`
+}
+
+// NewConsole2FASettings creates a new Console2FASettings object.
+func NewConsole2FASettings() resource.Resource {
+	return &Console2FASettings{}
+}
+
+// Console2FASettings is a resource used to manage the two-factor authentication settings for console users of an
+// account.
+type Console2FASettings struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Console2FASettings) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_console_2fa_settings"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Console2FASettings) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the two-factor authentication settings for console users " +
+			"of an account.",
+		MarkdownDescription: `This resource is used for managing the two-factor authentication settings for console
+			users of an account.
+
+		Every account always has two-factor authentication settings, so creating the resource overrides the existing
+		settings and destroying it stops enforcing two-factor authentication. Existing settings can be imported using
+		the account ID.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description:         "ID of the account to which the settings belong.",
+				MarkdownDescription: "ID of the account to which the settings belong.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"allowed_methods": schema.SetAttribute{
+				Description: "Second factors which users may use (eg: totp, email, sms). If not set, the server " +
+					"will allow its default methods.",
+				MarkdownDescription: "Second factors which users may use (eg: `totp`, `email`, `sms`). If not set, the " +
+					"server will allow its default methods.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"enforce_2fa": schema.BoolAttribute{
+				Description:         "Whether or not every user must log in with a second factor. [Default: true]",
+				MarkdownDescription: "Whether or not every user must log in with a second factor. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"grace_period_days": schema.Int64Attribute{
+				Description: "Number of days users may continue to log in without a second factor after it is " +
+					"enforced. [Default: 0]",
+				MarkdownDescription: "Number of days users may continue to log in without a second factor after it is " +
+					"enforced. [Default: `0`]",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(0),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the settings (the account ID).",
+				MarkdownDescription: "ID of the settings (the account ID).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Console2FASettings) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_CONSOLE_2FA_SETTINGS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Console2FASettings) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfConsole2FASettings
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the account always has settings so creating them simply overrides them
+	settings, diags := api.Client().UpdateTwoFASettings(ctx, console2FAScope(plan.AccountId.ValueString()), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the settings to the state
+	tfsettings, diags := tfConsole2FASettingsFromAPI(ctx, settings, plan.AccountId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsettings)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Console2FASettings) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfConsole2FASettings
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the current settings of the account
+	settings, diags := api.Client().GetTwoFASettings(ctx, console2FAScope(state.AccountId.ValueString()))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	tfsettings, diags := tfConsole2FASettingsFromAPI(ctx, settings, state.AccountId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsettings)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Console2FASettings) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfConsole2FASettings
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the settings
+	settings, diags := api.Client().UpdateTwoFASettings(ctx, console2FAScope(plan.AccountId.ValueString()), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated settings to the state
+	tfsettings, diags := tfConsole2FASettingsFromAPI(ctx, settings, plan.AccountId.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsettings)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Console2FASettings) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfConsole2FASettings
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// stop enforcing two-factor authentication for the account
+	enforced := false
+	_, diags := api.Client().UpdateTwoFASettings(ctx, console2FAScope(state.AccountId.ValueString()),
+		api.TwoFASettingsBody{Enforced: &enforced})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Stopped enforcing two-factor authentication", map[string]interface{}{
+		"account_id": state.AccountId.ValueString(),
+	})
+}
+
+// ImportState imports the two-factor authentication settings of an existing account into the Terraform state using
+// the account ID.
+func (r *Console2FASettings) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, tfpath.Root("account_id"), req, resp)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating the two-factor authentication
+// settings.
+func (r *Console2FASettings) bodyFromPlan(ctx context.Context, plan tfConsole2FASettings) (api.TwoFASettingsBody,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	body := api.TwoFASettingsBody{}
+
+	if !plan.AllowedMethods.IsNull() && !plan.AllowedMethods.IsUnknown() {
+		body.AllowedMethods = []string{}
+		diags.Append(plan.AllowedMethods.ElementsAs(ctx, &body.AllowedMethods, false)...)
+		if diags.HasError() {
+			return body, diags
+		}
+	}
+
+	if !plan.Enforce2FA.IsNull() && !plan.Enforce2FA.IsUnknown() {
+		value := plan.Enforce2FA.ValueBool()
+		body.Enforced = &value
+	}
+
+	if !plan.GracePeriodDays.IsNull() && !plan.GracePeriodDays.IsUnknown() {
+		value := int(plan.GracePeriodDays.ValueInt64())
+		body.GracePeriodDays = &value
+	}
+	return body, diags
+}
+
+// console2FAScope returns the scope of the two-factor authentication settings of the account with the given ID.
+func console2FAScope(accountId string) api.Scope {
+	return api.Scope{
+		Id:   accountId,
+		Type: api.SCOPE_ACCOUNT,
+	}
+}
+
+// tfConsole2FASettingsFromAPI converts API two-factor authentication settings into Terraform two-factor
+// authentication settings.
+func tfConsole2FASettingsFromAPI(ctx context.Context, settings *api.TwoFASettings, accountId string) (
+	tfConsole2FASettings, diag.Diagnostics) {
+
+	tfsettings := tfConsole2FASettings{
+		AccountId:       types.StringValue(accountId),
+		Enforce2FA:      types.BoolValue(settings.Enforced),
+		GracePeriodDays: types.Int64Value(int64(settings.GracePeriodDays)),
+		Id:              types.StringValue(accountId),
+	}
+	methods := settings.AllowedMethods
+	if methods == nil {
+		methods = []string{}
+	}
+	var diags diag.Diagnostics
+	tfsettings.AllowedMethods, diags = types.SetValueFrom(ctx, types.StringType, methods)
+	if diags.HasError() {
+		return tfsettings, diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API 2FA settings to TF 2FA settings: %+v", tfsettings),
+		map[string]interface{}{
+			"api_two_fa_settings": settings,
+		})
+	return tfsettings, diags
+}
@@ -0,0 +1,474 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &DeviceControlRule{}
+	_ resource.ResourceWithConfigure   = &DeviceControlRule{}
+	_ resource.ResourceWithImportState = &DeviceControlRule{}
+)
+
+// tfDeviceControlRule defines the Terraform model for a device control rule.
+type tfDeviceControlRule struct {
+	AccessPermission types.String `tfsdk:"access_permission"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	DeviceClass      types.String `tfsdk:"device_class"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	Id               types.String `tfsdk:"id"`
+	Interface        types.String `tfsdk:"interface"`
+	Name             types.String `tfsdk:"name"`
+	OSType           types.String `tfsdk:"os_type"`
+	ProductId        types.String `tfsdk:"product_id"`
+	RuleType         types.String `tfsdk:"rule_type"`
+	ScopeId          types.String `tfsdk:"scope_id"`
+	ScopeType        types.String `tfsdk:"scope_type"`
+	Serial           types.String `tfsdk:"serial"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+	VendorId         types.String `tfsdk:"vendor_id"`
+}
+
+// NewDeviceControlRule creates a new DeviceControlRule object.
+func NewDeviceControlRule() resource.Resource {
+	return &DeviceControlRule{}
+}
+
+// DeviceControlRule is a resource used to manage the lifecycle of a device control rule.
+type DeviceControlRule struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *DeviceControlRule) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_control_rule"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *DeviceControlRule) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a device control rule for USB, Bluetooth, " +
+			"Thunderbolt or SD card devices within an account, site or group.",
+		MarkdownDescription: `This resource is used for creating and managing a device control rule for USB, Bluetooth,
+			Thunderbolt or SD card devices within an account, site or group.
+
+		The ` + "`rule_type`" + ` determines which of the device attributes is matched:
+
+		* ` + "`class`" + ` - matches the ` + "`device_class`" + `
+		* ` + "`vendorId`" + ` - matches the ` + "`vendor_id`" + `
+		* ` + "`productId`" + ` - matches the ` + "`vendor_id`" + ` and ` + "`product_id`" + `
+		* ` + "`deviceId`" + ` - matches the ` + "`vendor_id`" + `, ` + "`product_id`" + ` and ` + "`serial`" + `
+
+		When the scope is an account or site, it is checked at plan time to ensure that it is licensed for Device
+		Control.
+
+		Existing rules can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<rule_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"access_permission": schema.StringAttribute{
+				Description:         "Access granted to matching devices (valid values: allow, read_only, block).",
+				MarkdownDescription: "Access granted to matching devices (valid values: `allow`, `read_only`, `block`).",
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "allow", "read_only", "block"),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the rule was created.",
+				MarkdownDescription: "Timestamp of when the rule was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_class": schema.StringAttribute{
+				Description:         "Hexadecimal code of the device class to match (eg: 08 for mass storage). [Default: none]",
+				MarkdownDescription: "Hexadecimal code of the device class to match (eg: `08` for mass storage). [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not the rule is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not the rule is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the rule.",
+				MarkdownDescription: "ID of the rule.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"interface": schema.StringAttribute{
+				Description:         "Interface to which the rule applies (valid values: USB, Bluetooth, Thunderbolt, SDCard).",
+				MarkdownDescription: "Interface to which the rule applies (valid values: `USB`, `Bluetooth`, `Thunderbolt`, `SDCard`).",
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "USB", "Bluetooth", "Thunderbolt", "SDCard"),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the rule.",
+				MarkdownDescription: "Name of the rule.",
+				Required:            true,
+			},
+			"os_type": schema.StringAttribute{
+				Description:         "Operating system to which the rule applies (valid values: macos, windows).",
+				MarkdownDescription: "Operating system to which the rule applies (valid values: `macos`, `windows`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "macos", "windows"),
+				},
+			},
+			"product_id": schema.StringAttribute{
+				Description:         "Hexadecimal product ID of the device to match. [Default: none]",
+				MarkdownDescription: "Hexadecimal product ID of the device to match. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"rule_type": schema.StringAttribute{
+				Description:         "Device attribute matched by the rule (valid values: class, vendorId, productId, deviceId).",
+				MarkdownDescription: "Device attribute matched by the rule (valid values: `class`, `vendorId`, `productId`, `deviceId`).",
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "class", "vendorId", "productId", "deviceId"),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the rule applies.",
+				MarkdownDescription: "ID of the account, site or group to which the rule applies.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ScopeIsLicensedFor("Device Control", "control", "complete"),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the rule applies (valid values: account, site, group).",
+				MarkdownDescription: "Level at which the rule applies (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"serial": schema.StringAttribute{
+				Description:         "Serial number of the device to match. [Default: none]",
+				MarkdownDescription: "Serial number of the device to match. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the rule was last updated.",
+				MarkdownDescription: "Timestamp of when the rule was last updated.",
+				Computed:            true,
+			},
+			"vendor_id": schema.StringAttribute{
+				Description:         "Hexadecimal vendor ID of the device to match. [Default: none]",
+				MarkdownDescription: "Hexadecimal vendor ID of the device to match. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *DeviceControlRule) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_DEVICE_CONTROL_RULE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *DeviceControlRule) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfDeviceControlRule
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the rule
+	body := r.bodyFromPlan(plan)
+	osType := plan.OSType.ValueString() // always required so no need to check
+	body.OSType = &osType
+	rule, diags := api.Client().CreateDeviceControlRule(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the rule to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfDeviceControlRuleFromAPI(ctx, rule, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *DeviceControlRule) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfDeviceControlRule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the rule - if it no longer exists, remove it from the state
+	queryParams := api.DeviceControlRuleQueryParams{
+		RuleIds: []string{state.Id.ValueString()},
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{state.ScopeId.ValueString()}
+	}
+	rules, diags := api.Client().FindDeviceControlRules(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(rules) == 0 {
+		tflog.Debug(ctx, "Device control rule no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfDeviceControlRuleFromAPI(ctx, &rules[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *DeviceControlRule) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfDeviceControlRule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfDeviceControlRule
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the rule
+	rule, diags := api.Client().UpdateDeviceControlRule(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated rule to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfDeviceControlRuleFromAPI(ctx, rule, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *DeviceControlRule) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfDeviceControlRule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the rule
+	resp.Diagnostics.Append(api.Client().DeleteDeviceControlRule(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed device control rule", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing device control rule into the Terraform state.
+//
+// The API can only find a rule within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<rule_id>.
+func (r *DeviceControlRule) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" ||
+		(parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE && parts[0] != api.SCOPE_GROUP) {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<rule_id> where the scope "+
+			"type is one of: %s, %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_DEVICE_CONTROL_RULE_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a device control rule.
+//
+// Note that the OS type is not included as it cannot be changed once the rule has been created.
+func (r *DeviceControlRule) bodyFromPlan(plan tfDeviceControlRule) api.DeviceControlRuleBody {
+	body := api.DeviceControlRuleBody{}
+
+	if !plan.AccessPermission.IsNull() && !plan.AccessPermission.IsUnknown() {
+		action, accessPermission := deviceControlAccessToAPI(plan.AccessPermission.ValueString())
+		body.Action = &action
+		body.AccessPermission = &accessPermission
+	}
+
+	if !plan.DeviceClass.IsNull() && !plan.DeviceClass.IsUnknown() {
+		value := plan.DeviceClass.ValueString()
+		body.DeviceClass = &value
+	}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := "Disabled"
+		if plan.Enabled.ValueBool() {
+			value = "Enabled"
+		}
+		body.Status = &value
+	}
+
+	if !plan.Interface.IsNull() && !plan.Interface.IsUnknown() {
+		value := plan.Interface.ValueString()
+		body.Interface = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.RuleName = &value
+	}
+
+	if !plan.ProductId.IsNull() && !plan.ProductId.IsUnknown() {
+		value := plan.ProductId.ValueString()
+		body.ProductId = &value
+	}
+
+	if !plan.RuleType.IsNull() && !plan.RuleType.IsUnknown() {
+		value := plan.RuleType.ValueString()
+		body.RuleType = &value
+	}
+
+	if !plan.Serial.IsNull() && !plan.Serial.IsUnknown() {
+		value := plan.Serial.ValueString()
+		body.DeviceId = &value
+	}
+
+	if !plan.VendorId.IsNull() && !plan.VendorId.IsUnknown() {
+		value := plan.VendorId.ValueString()
+		body.VendorId = &value
+	}
+	return body
+}
+
+// deviceControlAccessToAPI converts a Terraform access permission into the API action and access permission.
+func deviceControlAccessToAPI(access string) (string, string) {
+	switch access {
+	case "allow":
+		return "Allow", "Read-Write"
+	case "read_only":
+		return "Allow", "Read-Only"
+	default:
+		return "Block", "N/A"
+	}
+}
+
+// deviceControlAccessFromAPI converts the API action and access permission into a Terraform access permission.
+func deviceControlAccessFromAPI(action, accessPermission string) string {
+	if strings.EqualFold(action, "Block") {
+		return "block"
+	}
+	if strings.EqualFold(accessPermission, "Read-Only") {
+		return "read_only"
+	}
+	return "allow"
+}
+
+// tfDeviceControlRuleFromAPI converts an API device control rule into a Terraform device control rule.
+//
+// The scope is copied from the given model since it is always known from the configuration or import ID.
+func tfDeviceControlRuleFromAPI(ctx context.Context, rule *api.DeviceControlRule,
+	model tfDeviceControlRule) tfDeviceControlRule {
+
+	tfrule := tfDeviceControlRule{
+		AccessPermission: types.StringValue(deviceControlAccessFromAPI(rule.Action, rule.AccessPermission)),
+		CreatedAt:        types.StringValue(rule.CreatedAt),
+		DeviceClass:      types.StringValue(rule.DeviceClass),
+		Enabled:          types.BoolValue(strings.EqualFold(rule.Status, "Enabled")),
+		Id:               types.StringValue(rule.Id),
+		Interface:        types.StringValue(rule.Interface),
+		Name:             types.StringValue(rule.RuleName),
+		OSType:           types.StringValue(rule.OSType),
+		ProductId:        types.StringValue(rule.ProductId),
+		RuleType:         types.StringValue(rule.RuleType),
+		ScopeId:          model.ScopeId,
+		ScopeType:        model.ScopeType,
+		Serial:           types.StringValue(rule.DeviceId),
+		UpdatedAt:        types.StringValue(rule.UpdatedAt),
+		VendorId:         types.StringValue(rule.VendorId),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API device control rule to TF device control rule: %+v", tfrule),
+		map[string]interface{}{
+			"api_device_control_rule": rule,
+		})
+	return tfrule
+}
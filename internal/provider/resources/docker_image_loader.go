@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/docker/docker/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -72,6 +73,16 @@ type tfK8sAgentPackageLoaderImage struct {
 	Size         types.Int64  `tfsdk:"size"`
 }
 
+// tfK8sAgentPackageLoaderImageAttrTypes holds the attribute types of a tfK8sAgentPackageLoaderImage object.
+var tfK8sAgentPackageLoaderImageAttrTypes = map[string]attr.Type{
+	"id":           types.StringType,
+	"repo_tags":    types.ListType{ElemType: types.StringType},
+	"purpose":      types.StringType,
+	"architecture": types.StringType,
+	"variant":      types.StringType,
+	"size":         types.Int64Type,
+}
+
 // NewK8sAgentPackageLoader creates a new K8sAgentPackageLoader object.
 func NewK8sAgentPackageLoader() resource.Resource {
 	return &K8sAgentPackageLoader{}
@@ -343,12 +354,26 @@ func (r *K8sAgentPackageLoader) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, diags = r.dockerLoad(ctx, dockerClient, absPath)
+	images, diags := r.dockerLoad(ctx, dockerClient, absPath)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// computed values must all be known once the resource has been created
+	plan.Images, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfK8sAgentPackageLoaderImageAttrTypes},
+		images)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.DockerAPIVersion.IsUnknown() {
+		plan.DockerAPIVersion = types.StringNull()
+	}
+	if plan.DockerCertPath.IsUnknown() {
+		plan.DockerCertPath = types.StringNull()
+	}
+
 	// save the the plan to the state
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
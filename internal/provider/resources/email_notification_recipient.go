@@ -0,0 +1,315 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &EmailNotificationRecipient{}
+	_ resource.ResourceWithConfigure   = &EmailNotificationRecipient{}
+	_ resource.ResourceWithImportState = &EmailNotificationRecipient{}
+)
+
+// tfEmailNotificationRecipient defines the Terraform model for a recipient.
+type tfEmailNotificationRecipient struct {
+	CreatedAt types.String `tfsdk:"created_at"`
+	Email     types.String `tfsdk:"email"`
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	ScopeId   types.String `tfsdk:"scope_id"`
+	ScopeType types.String `tfsdk:"scope_type"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+// NewEmailNotificationRecipient creates a new EmailNotificationRecipient object.
+func NewEmailNotificationRecipient() resource.Resource {
+	return &EmailNotificationRecipient{}
+}
+
+// EmailNotificationRecipient is a resource used to manage an email recipient of notifications and scheduled reports.
+type EmailNotificationRecipient struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *EmailNotificationRecipient) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_email_notification_recipient"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *EmailNotificationRecipient) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing an email recipient of notifications and " +
+			"scheduled reports within an account or site.",
+		MarkdownDescription: `This resource is used for creating and managing an email recipient of notifications and
+			scheduled reports within an account or site.
+
+		Recipients are used by the email channel of notification rules and by scheduled reports. Existing recipients
+		can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<recipient_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the recipient was created.",
+				MarkdownDescription: "Timestamp of when the recipient was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				Description:         "Email address to which notifications and reports are sent.",
+				MarkdownDescription: "Email address to which notifications and reports are sent.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the recipient.",
+				MarkdownDescription: "ID of the recipient.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Display name of the recipient.",
+				MarkdownDescription: "Display name of the recipient.",
+				Required:            true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the recipient belongs.",
+				MarkdownDescription: "ID of the account or site to which the recipient belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the recipient is created (valid values: account, site).",
+				MarkdownDescription: "Level at which the recipient is created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the recipient was last updated.",
+				MarkdownDescription: "Timestamp of when the recipient was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *EmailNotificationRecipient) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_EMAIL_NOTIFICATION_RECIPIENT_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *EmailNotificationRecipient) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfEmailNotificationRecipient
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the recipient
+	recipient, diags := api.Client().CreateNotificationRecipient(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the recipient to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfEmailNotificationRecipientFromAPI(ctx, recipient, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *EmailNotificationRecipient) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	// get the current state
+	var state tfEmailNotificationRecipient
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the recipient - if it no longer exists, remove it from the state
+	queryParams := api.NotificationRecipientQueryParams{
+		NotificationRecipientIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	recipients, diags := api.Client().FindNotificationRecipients(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(recipients) == 0 {
+		tflog.Debug(ctx, "Notification recipient no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfEmailNotificationRecipientFromAPI(ctx, &recipients[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *EmailNotificationRecipient) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfEmailNotificationRecipient
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfEmailNotificationRecipient
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the recipient
+	recipient, diags := api.Client().UpdateNotificationRecipient(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated recipient to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfEmailNotificationRecipientFromAPI(ctx, recipient, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *EmailNotificationRecipient) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfEmailNotificationRecipient
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the recipient
+	resp.Diagnostics.Append(api.Client().DeleteNotificationRecipient(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted notification recipient", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing recipient into the Terraform state.
+//
+// The API can only find a recipient within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<recipient_id>.
+func (r *EmailNotificationRecipient) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<recipient_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_EMAIL_NOTIFICATION_RECIPIENT_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a recipient.
+func (r *EmailNotificationRecipient) bodyFromPlan(plan tfEmailNotificationRecipient) api.NotificationRecipientBody {
+	body := api.NotificationRecipientBody{}
+
+	if !plan.Email.IsNull() && !plan.Email.IsUnknown() {
+		value := plan.Email.ValueString()
+		body.Email = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+	return body
+}
+
+// tfEmailNotificationRecipientFromAPI converts an API recipient into a Terraform recipient.
+//
+// The API does not return the scope of the recipient so it is copied from the given model.
+func tfEmailNotificationRecipientFromAPI(ctx context.Context, recipient *api.NotificationRecipient,
+	model tfEmailNotificationRecipient) tfEmailNotificationRecipient {
+
+	tfrecipient := tfEmailNotificationRecipient{
+		CreatedAt: types.StringValue(recipient.CreatedAt),
+		Email:     types.StringValue(recipient.Email),
+		Id:        types.StringValue(recipient.Id),
+		Name:      types.StringValue(recipient.Name),
+		ScopeId:   model.ScopeId,
+		ScopeType: model.ScopeType,
+		UpdatedAt: types.StringValue(recipient.UpdatedAt),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API recipient to TF recipient: %+v", tfrecipient),
+		map[string]interface{}{
+			"api_notification_recipient": recipient,
+		})
+	return tfrecipient
+}
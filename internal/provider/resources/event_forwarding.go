@@ -0,0 +1,365 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &EventForwarding{}
+	_ resource.ResourceWithConfigure   = &EventForwarding{}
+	_ resource.ResourceWithImportState = &EventForwarding{}
+)
+
+// tfEventForwarding defines the Terraform model for an event forwarding configuration.
+type tfEventForwarding struct {
+	AccountId       types.String   `tfsdk:"account_id"`
+	CredentialsRef  types.String   `tfsdk:"credentials_ref"`
+	Destination     types.String   `tfsdk:"destination"`
+	DestinationType types.String   `tfsdk:"destination_type"`
+	Enabled         types.Bool     `tfsdk:"enabled"`
+	EventTypes      []types.String `tfsdk:"event_types"`
+	Id              types.String   `tfsdk:"id"`
+	Region          types.String   `tfsdk:"region"`
+	Topic           types.String   `tfsdk:"topic"`
+	UpdatedAt       types.String   `tfsdk:"updated_at"`
+}
+
+// NewEventForwarding creates a new EventForwarding object for the singularity_event_forwarding resource.
+func NewEventForwarding() resource.Resource {
+	return &EventForwarding{
+		typeSuffix: "_event_forwarding",
+	}
+}
+
+// NewCloudFunnel creates a new EventForwarding object for the singularity_cloud_funnel resource.
+func NewCloudFunnel() resource.Resource {
+	return &EventForwarding{
+		typeSuffix: "_cloud_funnel",
+	}
+}
+
+// EventForwarding is a resource used to manage the event forwarding (Cloud Funnel) configuration of an account.
+type EventForwarding struct {
+	data *data.SingularityProvider
+
+	// typeSuffix is the suffix of the resource type name.
+	typeSuffix string
+}
+
+// Metadata returns metadata about the resource.
+func (r *EventForwarding) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + r.typeSuffix
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *EventForwarding) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the forwarding of agent telemetry events (Cloud Funnel) " +
+			"from an account to an S3 bucket or Kafka topic.",
+		MarkdownDescription: `This resource is used for managing the forwarding of agent telemetry events (Cloud Funnel)
+			from an account to an S3 bucket or Kafka topic.
+
+		Credentials are never stored in the configuration. Instead, ` + "`credentials_ref`" + ` references
+		credentials which have already been registered with the console (eg: the ARN of the IAM role to assume for
+		S3). Destroying the resource disables event forwarding for the account. Existing configurations can be
+		imported using the account ID.
+
+		The ` + "`singularity_cloud_funnel`" + ` and ` + "`singularity_event_forwarding`" + ` resources both
+		manage these settings and can be used interchangeably, but only one of them should manage any given account.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description:         "ID of the account whose events are forwarded.",
+				MarkdownDescription: "ID of the account whose events are forwarded.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"credentials_ref": schema.StringAttribute{
+				Description:         "Reference to the credentials used to write to the destination.",
+				MarkdownDescription: "Reference to the credentials used to write to the destination.",
+				Required:            true,
+			},
+			"destination": schema.StringAttribute{
+				Description: "Destination of the events (the bucket name for s3 or a comma-separated list of " +
+					"bootstrap servers for kafka).",
+				MarkdownDescription: "Destination of the events (the bucket name for `s3` or a comma-separated list of " +
+					"bootstrap servers for `kafka`).",
+				Required: true,
+			},
+			"destination_type": schema.StringAttribute{
+				Description:         "Type of destination (valid values: s3, kafka).",
+				MarkdownDescription: "Type of destination (valid values: `s3`, `kafka`).",
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "s3", "kafka"),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not events are forwarded. [Default: true]",
+				MarkdownDescription: "Whether or not events are forwarded. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"event_types": schema.ListAttribute{
+				Description: "Types of events which are forwarded (valid values: dns, file, indicators, login, " +
+					"network, process, registry, scheduled_task, threats, url).",
+				MarkdownDescription: "Types of events which are forwarded (valid values: `dns`, `file`, " +
+					"`indicators`, `login`, `network`, `process`, `registry`, `scheduled_task`, `threats`, `url`).",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false,
+						"dns", "file", "indicators", "login", "network", "process", "registry", "scheduled_task",
+						"threats", "url",
+					),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the configuration (the same as the account ID).",
+				MarkdownDescription: "ID of the configuration (the same as the account ID).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				Description:         "AWS region of the S3 bucket (s3 only). [Default: none]",
+				MarkdownDescription: "AWS region of the S3 bucket (`s3` only). [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"topic": schema.StringAttribute{
+				Description:         "Topic to which events are written (kafka only). [Default: none]",
+				MarkdownDescription: "Topic to which events are written (`kafka` only). [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the configuration was last updated.",
+				MarkdownDescription: "Timestamp of when the configuration was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *EventForwarding) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_EVENT_FORWARDING_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *EventForwarding) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfEventForwarding
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the account always has a configuration so creating one simply overrides it
+	accountId := plan.AccountId.ValueString() // always required so no need to check
+	forwarding, diags := api.Client().UpdateEventForwarding(ctx, eventForwardingScope(accountId), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the configuration to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfEventForwardingFromAPI(ctx, forwarding, accountId))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *EventForwarding) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfEventForwarding
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the current configuration of the account
+	accountId := state.AccountId.ValueString()
+	forwarding, diags := api.Client().GetEventForwarding(ctx, eventForwardingScope(accountId))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfEventForwardingFromAPI(ctx, forwarding, accountId))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *EventForwarding) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfEventForwarding
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the configuration
+	accountId := plan.AccountId.ValueString()
+	forwarding, diags := api.Client().UpdateEventForwarding(ctx, eventForwardingScope(accountId), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated configuration to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfEventForwardingFromAPI(ctx, forwarding, accountId))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *EventForwarding) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfEventForwarding
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// disable forwarding for the account
+	enabled := false
+	_, diags := api.Client().UpdateEventForwarding(ctx, eventForwardingScope(state.AccountId.ValueString()),
+		api.EventForwardingBody{Enabled: &enabled})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Disabled event forwarding", map[string]interface{}{
+		"account_id": state.AccountId.ValueString(),
+	})
+}
+
+// ImportState imports the event forwarding configuration of an existing account into the Terraform state using the
+// account ID.
+func (r *EventForwarding) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	resource.ImportStatePassthroughID(ctx, tfpath.Root("account_id"), req, resp)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating an event forwarding configuration.
+func (r *EventForwarding) bodyFromPlan(plan tfEventForwarding) api.EventForwardingBody {
+	body := api.EventForwardingBody{}
+
+	if !plan.CredentialsRef.IsNull() && !plan.CredentialsRef.IsUnknown() {
+		value := plan.CredentialsRef.ValueString()
+		body.CredentialsRef = &value
+	}
+
+	if !plan.Destination.IsNull() && !plan.Destination.IsUnknown() {
+		value := plan.Destination.ValueString()
+		body.Destination = &value
+	}
+
+	if !plan.DestinationType.IsNull() && !plan.DestinationType.IsUnknown() {
+		value := plan.DestinationType.ValueString()
+		body.DestinationType = &value
+	}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := plan.Enabled.ValueBool()
+		body.Enabled = &value
+	}
+
+	if plan.EventTypes != nil {
+		body.EventTypes = []string{}
+		for _, e := range plan.EventTypes {
+			body.EventTypes = append(body.EventTypes, e.ValueString())
+		}
+	}
+
+	if !plan.Region.IsNull() && !plan.Region.IsUnknown() {
+		value := plan.Region.ValueString()
+		body.Region = &value
+	}
+
+	if !plan.Topic.IsNull() && !plan.Topic.IsUnknown() {
+		value := plan.Topic.ValueString()
+		body.Topic = &value
+	}
+	return body
+}
+
+// eventForwardingScope returns the scope of the event forwarding configuration for the given account.
+func eventForwardingScope(accountId string) api.Scope {
+	return api.Scope{
+		Id:   accountId,
+		Type: api.SCOPE_ACCOUNT,
+	}
+}
+
+// tfEventForwardingFromAPI converts an API event forwarding configuration into a Terraform event forwarding
+// configuration.
+func tfEventForwardingFromAPI(ctx context.Context, forwarding *api.EventForwarding,
+	accountId string) tfEventForwarding {
+
+	tfforwarding := tfEventForwarding{
+		AccountId:       types.StringValue(accountId),
+		CredentialsRef:  types.StringValue(forwarding.CredentialsRef),
+		Destination:     types.StringValue(forwarding.Destination),
+		DestinationType: types.StringValue(forwarding.DestinationType),
+		Enabled:         types.BoolValue(forwarding.Enabled),
+		EventTypes:      []types.String{},
+		Id:              types.StringValue(accountId),
+		Region:          types.StringValue(forwarding.Region),
+		Topic:           types.StringValue(forwarding.Topic),
+		UpdatedAt:       types.StringValue(forwarding.UpdatedAt),
+	}
+	for _, e := range forwarding.EventTypes {
+		tfforwarding.EventTypes = append(tfforwarding.EventTypes, types.StringValue(e))
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API event forwarding to TF event forwarding: %+v", tfforwarding),
+		map[string]interface{}{
+			"api_event_forwarding": forwarding,
+		})
+	return tfforwarding
+}
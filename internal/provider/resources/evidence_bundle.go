@@ -0,0 +1,508 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// EVIDENCE_ARTIFACT_* are the artifacts which can be included in an evidence bundle.
+const (
+	EVIDENCE_ARTIFACT_EXCLUSIONS    = "exclusions"
+	EVIDENCE_ARTIFACT_NOTIFICATIONS = "notifications"
+	EVIDENCE_ARTIFACT_POLICIES      = "policies"
+	EVIDENCE_ARTIFACT_USERS         = "users"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &EvidenceBundle{}
+	_ resource.ResourceWithConfigure = &EvidenceBundle{}
+)
+
+// tfEvidenceBundle defines the Terraform model for an evidence bundle.
+type tfEvidenceBundle struct {
+	Artifacts     types.List   `tfsdk:"artifacts"`
+	DirectoryMode types.String `tfsdk:"directory_mode"`
+	FileMode      types.String `tfsdk:"file_mode"`
+	GeneratedAt   types.String `tfsdk:"generated_at"`
+	Id            types.String `tfsdk:"id"`
+	LocalFolder   types.String `tfsdk:"local_folder"`
+	OutputFile    types.String `tfsdk:"output_file"`
+	ScopeId       types.String `tfsdk:"scope_id"`
+	ScopeType     types.String `tfsdk:"scope_type"`
+	SHA256        types.String `tfsdk:"sha256"`
+	Triggers      types.Map    `tfsdk:"triggers"`
+}
+
+// evidenceBundle defines the content of an evidence bundle file.
+type evidenceBundle struct {
+	Artifacts       map[string]evidenceArtifact `json:"artifacts"`
+	GeneratedAt     string                      `json:"generated_at"`
+	ProviderVersion string                      `json:"provider_version"`
+	ScopeId         string                      `json:"scope_id"`
+	ScopeType       string                      `json:"scope_type"`
+}
+
+// evidenceArtifact defines a single artifact within an evidence bundle file.
+type evidenceArtifact struct {
+	Data   json.RawMessage `json:"data"`
+	SHA256 string          `json:"sha256"`
+}
+
+// evidenceUser defines the details of a user included in an evidence bundle.
+type evidenceUser struct {
+	Email              string `json:"email"`
+	FullName           string `json:"full_name"`
+	Id                 string `json:"id"`
+	LastLogin          string `json:"last_login"`
+	LowestRole         string `json:"lowest_role"`
+	PrimaryTwoFaMethod string `json:"primary_two_fa_method"`
+	Source             string `json:"source"`
+	TwoFaEnabled       bool   `json:"two_fa_enabled"`
+}
+
+// NewEvidenceBundle creates a new EvidenceBundle object.
+func NewEvidenceBundle() resource.Resource {
+	return &EvidenceBundle{}
+}
+
+// EvidenceBundle is a resource used to generate a local bundle of compliance evidence for a scope.
+type EvidenceBundle struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *EvidenceBundle) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_evidence_bundle"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *EvidenceBundle) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	allArtifacts := []attr.Value{
+		types.StringValue(EVIDENCE_ARTIFACT_EXCLUSIONS),
+		types.StringValue(EVIDENCE_ARTIFACT_NOTIFICATIONS),
+		types.StringValue(EVIDENCE_ARTIFACT_POLICIES),
+		types.StringValue(EVIDENCE_ARTIFACT_USERS),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for generating a bundle of compliance evidence (eg: for SOC2 or ISO " +
+			"27001 audits) for an account, site or group and saving it locally.",
+		MarkdownDescription: `This resource is used for generating a bundle of compliance evidence (eg: for SOC2 or ISO
+			27001 audits) for an account, site or group and saving it locally.
+
+		The bundle is a JSON file named after the scope and the time at which it was generated. Each artifact in the
+		bundle is stored along with the SHA256 checksum of its data and the checksum of the whole file is saved in
+		the state. If the file is removed or modified, a new bundle is generated on the next apply. Changing any
+		attribute, including ` + "`triggers`" + `, also generates a new bundle. Bundles are never deleted by the
+		provider so that previously generated evidence is retained.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"artifacts": schema.ListAttribute{
+				Description: "Artifacts to include in the bundle (valid values: exclusions, notifications, policies, " +
+					"users). [Default: all artifacts]",
+				MarkdownDescription: "Artifacts to include in the bundle (valid values: `exclusions`, `notifications`, " +
+					"`policies`, `users`). [Default: all artifacts]",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, allArtifacts)),
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, EVIDENCE_ARTIFACT_EXCLUSIONS,
+						EVIDENCE_ARTIFACT_NOTIFICATIONS, EVIDENCE_ARTIFACT_POLICIES, EVIDENCE_ARTIFACT_USERS),
+				},
+			},
+			"directory_mode": schema.StringAttribute{
+				Description: "The permissions to set on any folders created when saving the bundle. " +
+					"Changing this value has no effect on existing folders. Ignored on Windows. [Default: 0755]",
+				MarkdownDescription: "The permissions to set on any folders created when saving the bundle. " +
+					"Changing this value has no effect on existing folders. Ignored on Windows. [Default: `0755`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("0755"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.FileModeIsValid(),
+				},
+			},
+			"file_mode": schema.StringAttribute{
+				Description:         "The permissions to set on the bundle file. Ignored on Windows. [Default: 0640]",
+				MarkdownDescription: "The permissions to set on the bundle file. Ignored on Windows. [Default: `0640`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("0640"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.FileModeIsValid(),
+				},
+			},
+			"generated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the bundle was generated.",
+				MarkdownDescription: "Timestamp of when the bundle was generated.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the bundle.",
+				MarkdownDescription: "ID of the bundle.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_folder": schema.StringAttribute{
+				Description: "The full path to the folder in which to save the bundle. Relative paths will be based " +
+					"on the working directory when the Terraform plan is applied. [Default: the current working directory]",
+				MarkdownDescription: "The full path to the folder in which to save the bundle. Relative paths will be based " +
+					"on the working directory when the Terraform plan is applied. [Default: the current working directory]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(plugin.GetWorkDir()),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"output_file": schema.StringAttribute{
+				Description:         "The absolute path of the bundle file once it has been saved.",
+				MarkdownDescription: "The absolute path of the bundle file once it has been saved.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group for which to gather evidence.",
+				MarkdownDescription: "ID of the account, site or group for which to gather evidence.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which to gather evidence (valid values: account, site, group).",
+				MarkdownDescription: "Level at which to gather evidence (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"sha256": schema.StringAttribute{
+				Description:         "The SHA256 checksum of the bundle file.",
+				MarkdownDescription: "The SHA256 checksum of the bundle file.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description:         "Arbitrary values which, when changed, cause a new bundle to be generated.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause a new bundle to be generated.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *EvidenceBundle) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_EVIDENCE_BUNDLE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *EvidenceBundle) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfEvidenceBundle
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var artifacts []string
+	resp.Diagnostics.Append(plan.Artifacts.ElementsAs(ctx, &artifacts, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// gather the evidence
+	scope := scopeFromModel(plan.ScopeType, plan.ScopeId)
+	generatedAt := time.Now().UTC()
+	bundle := evidenceBundle{
+		Artifacts:       map[string]evidenceArtifact{},
+		GeneratedAt:     generatedAt.Format(time.RFC3339),
+		ProviderVersion: plugin.Version,
+		ScopeId:         scope.Id,
+		ScopeType:       scope.Type,
+	}
+	for _, artifact := range artifacts {
+		evidence, diags := r.gatherArtifact(ctx, scope, artifact)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		bundle.Artifacts[artifact] = evidenceArtifact{
+			Data:   evidence,
+			SHA256: fmt.Sprintf("%x", sha256.Sum256(evidence)),
+		}
+	}
+	content, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while encoding the evidence bundle.\n\nError: %s",
+			err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_RESOURCE_EVIDENCE_BUNDLE_CREATE,
+		})
+		resp.Diagnostics.AddError("Evidence Bundle Creation Error", msg)
+		return
+	}
+
+	// save the bundle to a timestamped file - existing bundles are never overwritten
+	id := fmt.Sprintf("%s-%s-%s", scope.Type, scope.Id, generatedAt.Format("20060102T150405Z"))
+	name := fmt.Sprintf("evidence-%s.json", id)
+	filename, diags := plugin.ToAbsolutePath(ctx, path.Join(plan.LocalFolder.ValueString(), name))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tempDir, diags := plugin.NewTempDir(ctx, "evidence-bundle", filepath.Dir(filename),
+		plan.DirectoryMode.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	defer tempDir.Cleanup(ctx)
+	if err := os.WriteFile(tempDir.Path(name), content, 0600); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while writing the evidence bundle.\n\nError: %s\nFile: %s",
+			err.Error(), tempDir.Path(name))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"file":                tempDir.Path(name),
+			"internal_error_code": plugin.ERR_RESOURCE_EVIDENCE_BUNDLE_CREATE,
+		})
+		resp.Diagnostics.AddError("Evidence Bundle Creation Error", msg)
+		return
+	}
+	resp.Diagnostics.Append(tempDir.Commit(ctx, name, filename, plan.DirectoryMode.ValueString(),
+		plan.FileMode.ValueString(), false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "Saved evidence bundle", map[string]interface{}{
+		"artifacts": artifacts,
+		"file":      filename,
+	})
+
+	// save the bundle details to the state
+	plan.GeneratedAt = types.StringValue(bundle.GeneratedAt)
+	plan.Id = types.StringValue(id)
+	plan.OutputFile = types.StringValue(filename)
+	plan.SHA256 = types.StringValue(fmt.Sprintf("%x", sha256.Sum256(content)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *EvidenceBundle) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfEvidenceBundle
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a bundle which has been removed must be generated again
+	absPath := state.OutputFile.ValueString()
+	exists, diags := plugin.PathExists(ctx, absPath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !exists {
+		tflog.Debug(ctx, "Evidence bundle no longer exists on the local system.", map[string]interface{}{
+			"file": absPath,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// a bundle which has been modified can no longer be trusted as evidence
+	sha, diags := plugin.GetFileSHA256(ctx, absPath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if sha != state.SHA256.ValueString() {
+		msg := fmt.Sprintf("The evidence bundle has been modified since it was generated and will be generated "+
+			"again.\n\nFile: %s\nExpected SHA256: %s\nActual SHA256: %s", absPath, state.SHA256.ValueString(), sha)
+		tflog.Warn(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_EVIDENCE_BUNDLE_READ,
+		})
+		resp.Diagnostics.AddWarning("Evidence Bundle Modified", msg)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Every configurable attribute requires a new bundle to be generated so the state is left as it is.
+func (r *EvidenceBundle) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state tfEvidenceBundle
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The bundle file is retained on the local system.
+func (r *EvidenceBundle) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state tfEvidenceBundle
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed evidence bundle from the state; the file has been retained", map[string]interface{}{
+		"file": state.OutputFile.ValueString(),
+	})
+}
+
+// gatherArtifact retrieves the data for the given artifact within the given scope from the API.
+func (r *EvidenceBundle) gatherArtifact(ctx context.Context, scope api.Scope, artifact string) (json.RawMessage,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	var evidence interface{}
+	switch artifact {
+	case EVIDENCE_ARTIFACT_EXCLUSIONS:
+		queryParams := api.ExclusionQueryParams{}
+		switch scope.Type {
+		case api.SCOPE_ACCOUNT:
+			queryParams.AccountIds = []string{scope.Id}
+		case api.SCOPE_SITE:
+			queryParams.SiteIds = []string{scope.Id}
+		case api.SCOPE_GROUP:
+			queryParams.GroupIds = []string{scope.Id}
+		}
+		exclusions, diags := api.Client().FindExclusions(ctx, queryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+		sort.SliceStable(exclusions, func(i, j int) bool {
+			return exclusions[i].Id < exclusions[j].Id
+		})
+		evidence = exclusions
+	case EVIDENCE_ARTIFACT_NOTIFICATIONS:
+		return api.Client().GetNotificationSettings(ctx, scope)
+	case EVIDENCE_ARTIFACT_POLICIES:
+		return api.Client().GetScopePolicy(ctx, scope)
+	case EVIDENCE_ARTIFACT_USERS:
+		queryParams := api.UserQueryParams{}
+		switch scope.Type {
+		case api.SCOPE_ACCOUNT:
+			queryParams.AccountIds = []string{scope.Id}
+		case api.SCOPE_SITE:
+			queryParams.SiteIds = []string{scope.Id}
+		case api.SCOPE_GROUP:
+			queryParams.GroupIds = []string{scope.Id}
+		}
+		users, diags := api.Client().FindUsers(ctx, queryParams)
+		if diags.HasError() {
+			return nil, diags
+		}
+		evidenceUsers := []evidenceUser{}
+		for _, user := range users {
+			evidenceUsers = append(evidenceUsers, evidenceUser{
+				Email:              user.Email,
+				FullName:           user.FullName,
+				Id:                 user.Id,
+				LastLogin:          user.LastLogin,
+				LowestRole:         user.LowestRole,
+				PrimaryTwoFaMethod: user.PrimaryTwoFaMethod,
+				Source:             user.Source,
+				TwoFaEnabled:       user.TwoFaEnabled,
+			})
+		}
+		sort.SliceStable(evidenceUsers, func(i, j int) bool {
+			return evidenceUsers[i].Id < evidenceUsers[j].Id
+		})
+		evidence = evidenceUsers
+	}
+
+	data, err := json.Marshal(evidence)
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while encoding the evidence for an artifact.\n\n"+
+			"Error: %s\nArtifact: %s", err.Error(), artifact)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"artifact":            artifact,
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_RESOURCE_EVIDENCE_BUNDLE_CREATE,
+		})
+		diags.AddError("Evidence Bundle Creation Error", msg)
+		return nil, diags
+	}
+	return data, diags
+}
@@ -0,0 +1,477 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Exclusion{}
+	_ resource.ResourceWithConfigure   = &Exclusion{}
+	_ resource.ResourceWithImportState = &Exclusion{}
+)
+
+// tfExclusion defines the Terraform model for an exclusion.
+type tfExclusion struct {
+	CreatedAt         types.String `tfsdk:"created_at"`
+	Description       types.String `tfsdk:"description"`
+	Id                types.String `tfsdk:"id"`
+	Mode              types.String `tfsdk:"mode"`
+	OSType            types.String `tfsdk:"os_type"`
+	PathExclusionType types.String `tfsdk:"path_exclusion_type"`
+	ScopeAccountId    types.String `tfsdk:"scope_account_id"`
+	ScopeId           types.String `tfsdk:"scope_id"`
+	ScopeName         types.String `tfsdk:"scope_name"`
+	ScopeType         types.String `tfsdk:"scope_type"`
+	Source            types.String `tfsdk:"source"`
+	Type              types.String `tfsdk:"type"`
+	UpdatedAt         types.String `tfsdk:"updated_at"`
+	UserName          types.String `tfsdk:"user_name"`
+	Value             types.String `tfsdk:"value"`
+}
+
+// NewExclusion creates a new Exclusion object.
+func NewExclusion() resource.Resource {
+	return &Exclusion{}
+}
+
+// Exclusion is a resource used to manage the lifecycle of an exclusion.
+type Exclusion struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Exclusion) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exclusion"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Exclusion) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a path, hash, certificate or browser exclusion " +
+			"within an account, site or group.",
+		MarkdownDescription: `This resource is used for creating and managing a path, hash, certificate or browser
+			exclusion within an account, site or group.
+
+		The ` + "`mode`" + ` determines how the agent treats matching processes:
+
+		* ` + "`suppress`" + ` - suppress alerts only
+		* ` + "`disable_in_process_monitor`" + ` / ` + "`disable_in_process_monitor_deep`" + ` - interoperability
+		  (and interoperability - extended)
+		* ` + "`disable_all_monitors`" + ` / ` + "`disable_all_monitors_deep`" + ` - performance focus (and
+		  performance focus - extended)
+
+		The mode and path exclusion type only apply to path exclusions.
+
+		Existing exclusions can be imported using an ID in the format
+		` + "`<scope_type>/<scope_id>/<type>/<exclusion_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the exclusion was created.",
+				MarkdownDescription: "Timestamp of when the exclusion was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "User-defined description of the exclusion. [Default: none]",
+				MarkdownDescription: "User-defined description of the exclusion. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the exclusion.",
+				MarkdownDescription: "ID of the exclusion.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"mode": schema.StringAttribute{
+				Description: "How the agent treats processes matching a path exclusion (valid values: suppress, " +
+					"disable_in_process_monitor, disable_in_process_monitor_deep, disable_all_monitors, " +
+					"disable_all_monitors_deep). If not set, the server will assign the mode.",
+				MarkdownDescription: "How the agent treats processes matching a path exclusion (valid values: " +
+					"`suppress`, `disable_in_process_monitor`, `disable_in_process_monitor_deep`, " +
+					"`disable_all_monitors`, `disable_all_monitors_deep`). If not set, the server will assign the mode.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false,
+						"suppress", "disable_in_process_monitor", "disable_in_process_monitor_deep",
+						"disable_all_monitors", "disable_all_monitors_deep",
+					),
+				},
+			},
+			"os_type": schema.StringAttribute{
+				Description:         "Operating system to which the exclusion applies (valid values: linux, macos, windows).",
+				MarkdownDescription: "Operating system to which the exclusion applies (valid values: `linux`, `macos`, `windows`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "linux", "macos", "windows"),
+				},
+			},
+			"path_exclusion_type": schema.StringAttribute{
+				Description: "Whether a path exclusion matches a single file, a folder or a folder and its subfolders " +
+					"(valid values: file, folder, subfolders). If not set, the server will assign the type.",
+				MarkdownDescription: "Whether a path exclusion matches a single file, a folder or a folder and its " +
+					"subfolders (valid values: `file`, `folder`, `subfolders`). If not set, the server will assign the type.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "file", "folder", "subfolders"),
+				},
+			},
+			"scope_account_id": scopeAccountIdAttribute("exclusion"),
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the exclusion applies.",
+				MarkdownDescription: "ID of the account, site or group to which the exclusion applies.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_name": schema.StringAttribute{
+				Description:         "Name of the account, site or group to which the exclusion applies.",
+				MarkdownDescription: "Name of the account, site or group to which the exclusion applies.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the exclusion applies (valid values: account, site, group).",
+				MarkdownDescription: "Level at which the exclusion applies (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description:         "Source from which the exclusion was created.",
+				MarkdownDescription: "Source from which the exclusion was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description:         "Type of exclusion (valid values: browser, certificate, path, white_hash).",
+				MarkdownDescription: "Type of exclusion (valid values: `browser`, `certificate`, `path`, `white_hash`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "browser", "certificate", "path", "white_hash"),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the exclusion was last updated.",
+				MarkdownDescription: "Timestamp of when the exclusion was last updated.",
+				Computed:            true,
+			},
+			"user_name": schema.StringAttribute{
+				Description:         "Name of the user who last modified the exclusion.",
+				MarkdownDescription: "Name of the user who last modified the exclusion.",
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				Description: "Value to exclude: a path, a SHA1 hash, a certificate signer identity or a browser name, " +
+					"depending on the type of exclusion.",
+				MarkdownDescription: "Value to exclude: a path, a SHA1 hash, a certificate signer identity or a browser " +
+					"name, depending on the type of exclusion.",
+				Required: true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Exclusion) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_EXCLUSION_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Exclusion) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfExclusion
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// create the exclusion
+	body := r.bodyFromPlan(plan)
+	osType := plan.OSType.ValueString() // always required so no need to check
+	body.OSType = &osType
+	exclusion, diags := api.Client().CreateExclusion(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the exclusion to the state
+	tfexclusion := tfExclusionFromAPI(ctx, exclusion, plan)
+	tfexclusion.ScopeAccountId = plan.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfexclusion)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Exclusion) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfExclusion
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// find the exclusion - if it no longer exists, remove it from the state
+	exclusionType := state.Type.ValueString()
+	queryParams := api.ExclusionQueryParams{
+		ExclusionIds: []string{state.Id.ValueString()},
+		Type:         &exclusionType,
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{state.ScopeId.ValueString()}
+	}
+	exclusions, diags := api.Client().FindExclusions(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(exclusions) == 0 {
+		tflog.Debug(ctx, "Exclusion no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	tfexclusion := tfExclusionFromAPI(ctx, &exclusions[0], state)
+	tfexclusion.ScopeAccountId = state.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfexclusion)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Exclusion) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfExclusion
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfExclusion
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// update the exclusion
+	exclusion, diags := api.Client().UpdateExclusion(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated exclusion to the state
+	tfexclusion := tfExclusionFromAPI(ctx, exclusion, plan)
+	tfexclusion.ScopeAccountId = plan.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfexclusion)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Exclusion) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfExclusion
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// delete the exclusion
+	resp.Diagnostics.Append(api.Client().DeleteExclusion(ctx, state.Id.ValueString(), state.Type.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed exclusion", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing exclusion into the Terraform state.
+//
+// The API can only find an exclusion within its scope and by its type so the import ID must be in the format
+// <scope_type>/<scope_id>/<type>/<exclusion_id>.
+func (r *Exclusion) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 4 || parts[1] == "" || parts[2] == "" || parts[3] == "" ||
+		(parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE && parts[0] != api.SCOPE_GROUP) {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<type>/<exclusion_id> where "+
+			"the scope type is one of: %s, %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE,
+			api.SCOPE_GROUP, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_EXCLUSION_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("type"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[3])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating an exclusion.
+//
+// Note that the OS type is not included as it cannot be changed once the exclusion has been created.
+func (r *Exclusion) bodyFromPlan(plan tfExclusion) api.ExclusionBody {
+	body := api.ExclusionBody{}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.Mode.IsNull() && !plan.Mode.IsUnknown() {
+		value := plan.Mode.ValueString()
+		body.Mode = &value
+	}
+
+	if !plan.PathExclusionType.IsNull() && !plan.PathExclusionType.IsUnknown() {
+		value := plan.PathExclusionType.ValueString()
+		body.PathExclusionType = &value
+	}
+
+	if !plan.Type.IsNull() && !plan.Type.IsUnknown() {
+		value := plan.Type.ValueString()
+		body.Type = &value
+	}
+
+	if !plan.Value.IsNull() && !plan.Value.IsUnknown() {
+		value := plan.Value.ValueString()
+		body.Value = &value
+	}
+	return body
+}
+
+// scopeFromModel converts the Terraform scope attributes into an API scope.
+func scopeFromModel(scopeType, scopeId types.String) api.Scope {
+	return api.Scope{
+		Id:   scopeId.ValueString(),
+		Type: scopeType.ValueString(),
+	}
+}
+
+// scopeAccountIdAttribute returns the schema for the attribute naming the child account on whose behalf a resource
+// is managed.
+//
+// The object belongs to the scope of the token which created it, so changing the account replaces the resource.
+// Resources which use this attribute must be listed in the description of the provider's account_api_tokens
+// attribute.
+func scopeAccountIdAttribute(name string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("ID of the child account on whose behalf the %s is managed. The matching token in "+
+			"the provider's account_api_tokens attribute is used instead of api_token. [Default: none - api_token is "+
+			"used]", name),
+		MarkdownDescription: fmt.Sprintf("ID of the child account on whose behalf the %s is managed. The matching "+
+			"token in the provider's `account_api_tokens` attribute is used instead of `api_token`. [Default: none - "+
+			"`api_token` is used]", name),
+		Optional: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplace(),
+		},
+	}
+}
+
+// tfExclusionFromAPI converts an API exclusion into a Terraform exclusion.
+//
+// The API does not return the scope to which the exclusion was assigned so it is copied from the given model.
+func tfExclusionFromAPI(ctx context.Context, exclusion *api.Exclusion, model tfExclusion) tfExclusion {
+	tfexclusion := tfExclusion{
+		CreatedAt:         types.StringValue(exclusion.CreatedAt),
+		Description:       types.StringValue(exclusion.Description),
+		Id:                types.StringValue(exclusion.Id),
+		Mode:              types.StringValue(exclusion.Mode),
+		OSType:            types.StringValue(exclusion.OSType),
+		PathExclusionType: types.StringValue(exclusion.PathExclusionType),
+		ScopeId:           model.ScopeId,
+		ScopeName:         types.StringValue(exclusion.ScopeName),
+		ScopeType:         model.ScopeType,
+		Source:            types.StringValue(exclusion.Source),
+		Type:              types.StringValue(exclusion.Type),
+		UpdatedAt:         types.StringValue(exclusion.UpdatedAt),
+		UserName:          types.StringValue(exclusion.UserName),
+		Value:             types.StringValue(exclusion.Value),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API exclusion to TF exclusion: %+v", tfexclusion), map[string]interface{}{
+		"api_exclusion": exclusion,
+	})
+	return tfexclusion
+}
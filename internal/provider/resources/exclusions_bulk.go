@@ -0,0 +1,767 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource               = &ExclusionsBulk{}
+	_ resource.ResourceWithConfigure  = &ExclusionsBulk{}
+	_ resource.ResourceWithModifyPlan = &ExclusionsBulk{}
+)
+
+// exclusionsBulkColumns holds the columns (CSV) or keys (JSON) which may be used in an exclusions file.
+var exclusionsBulkColumns = []string{"description", "mode", "os_type", "path_exclusion_type", "type", "value"}
+
+// tfExclusionsBulk defines the Terraform model for a bulk set of exclusions.
+type tfExclusionsBulk struct {
+	AdoptExisting  types.Bool   `tfsdk:"adopt_existing"`
+	BatchSize      types.Int64  `tfsdk:"batch_size"`
+	ExclusionCount types.Int64  `tfsdk:"exclusion_count"`
+	ExclusionIds   types.Map    `tfsdk:"exclusion_ids"`
+	FilePath       types.String `tfsdk:"file_path"`
+	FileSHA256     types.String `tfsdk:"file_sha256"`
+	Format         types.String `tfsdk:"format"`
+	Id             types.String `tfsdk:"id"`
+	Parallelism    types.Int64  `tfsdk:"parallelism"`
+	ScopeAccountId types.String `tfsdk:"scope_account_id"`
+	ScopeId        types.String `tfsdk:"scope_id"`
+	ScopeType      types.String `tfsdk:"scope_type"`
+}
+
+// exclusionsBulkEntry holds a single exclusion read from an exclusions file.
+type exclusionsBulkEntry struct {
+	Description       string `json:"description"`
+	Mode              string `json:"mode"`
+	OSType            string `json:"os_type"`
+	PathExclusionType string `json:"path_exclusion_type"`
+	Type              string `json:"type"`
+	Value             string `json:"value"`
+}
+
+// key returns the key used to identify the entry in the exclusion_ids map.
+func (e exclusionsBulkEntry) key() string {
+	return fmt.Sprintf("%s/%s/%s", e.Type, e.OSType, e.Value)
+}
+
+// NewExclusionsBulk creates a new ExclusionsBulk object.
+func NewExclusionsBulk() resource.Resource {
+	return &ExclusionsBulk{}
+}
+
+// ExclusionsBulk is a resource used to manage the lifecycle of a large set of exclusions loaded from a file.
+type ExclusionsBulk struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ExclusionsBulk) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_exclusions_bulk"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ExclusionsBulk) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing a large set of exclusions within an account, site or group " +
+			"from a CSV or JSON file.",
+		MarkdownDescription: `This resource is used for managing a large set of exclusions within an account, site or
+			group from a CSV or JSON file.
+
+		A CSV file must start with a header row and a JSON file must contain an array of objects. The columns (or keys)
+		` + "`type`" + `, ` + "`value`" + ` and ` + "`os_type`" + ` are required and ` + "`description`" + `,
+		` + "`mode`" + ` and ` + "`path_exclusion_type`" + ` are optional, with the same meaning as the attributes of
+		the ` + "`singularity_exclusion`" + ` resource. Each exclusion is identified by its type, OS type and value
+		so no two rows may share all three.
+
+		Whenever the file changes, or an exclusion managed by the resource is removed from the console, the file is
+		compared with the exclusions which exist in the scope: missing exclusions are created, changed exclusions are
+		updated and exclusions no longer in the file are deleted. Deletions are sent in batches of
+		` + "`batch_size`" + ` IDs while creations and updates are made up to ` + "`parallelism`" + ` at a time.
+		Exclusions which exist in the scope but were not created by this resource are never changed, and applying
+		fails if one of them has the same type, OS type and value as an exclusion in the file. Set
+		` + "`adopt_existing`" + ` to take those exclusions over instead; they are then managed like any other
+		exclusion in the file. Optional columns which are left empty are not compared or sent. Destroying the resource
+		deletes every exclusion it manages, including any which were taken over.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Whether or not to take over exclusions which already exist in the scope with the same " +
+					"type, OS type and value as an exclusion in the file. Exclusions which are taken over are deleted " +
+					"when the resource is destroyed. [Default: false]",
+				MarkdownDescription: "Whether or not to take over exclusions which already exist in the scope with the " +
+					"same type, OS type and value as an exclusion in the file. Exclusions which are taken over are " +
+					"deleted when the resource is destroyed. [Default: `false`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"batch_size": schema.Int64Attribute{
+				Description:         "Maximum number of exclusions to delete with a single API call. [Default: 100]",
+				MarkdownDescription: "Maximum number of exclusions to delete with a single API call. [Default: `100`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(100),
+			},
+			"exclusion_count": schema.Int64Attribute{
+				Description:         "Number of exclusions managed by the resource.",
+				MarkdownDescription: "Number of exclusions managed by the resource.",
+				Computed:            true,
+			},
+			"exclusion_ids": schema.MapAttribute{
+				Description: "Map of the key of each exclusion (<type>/<os_type>/<value>) to the ID of the exclusion.",
+				MarkdownDescription: "Map of the key of each exclusion (`<type>/<os_type>/<value>`) to the ID of the " +
+					"exclusion.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"file_path": schema.StringAttribute{
+				Description:         "Path to the local CSV or JSON file holding the exclusions.",
+				MarkdownDescription: "Path to the local CSV or JSON file holding the exclusions.",
+				Required:            true,
+			},
+			"file_sha256": schema.StringAttribute{
+				Description:         "SHA256 hash of the exclusions file which was last applied.",
+				MarkdownDescription: "SHA256 hash of the exclusions file which was last applied.",
+				Computed:            true,
+			},
+			"format": schema.StringAttribute{
+				Description: "Format of the exclusions file (valid values: csv, json). [Default: based on the file's " +
+					"extension]",
+				MarkdownDescription: "Format of the exclusions file (valid values: `csv`, `json`). [Default: based on " +
+					"the file's extension]",
+				Optional: true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(true, "csv", "json"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the set of exclusions.",
+				MarkdownDescription: "ID of the set of exclusions.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Description:         "Maximum number of concurrent API calls to make when changing exclusions. [Default: 5]",
+				MarkdownDescription: "Maximum number of concurrent API calls to make when changing exclusions. [Default: `5`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(5),
+			},
+			"scope_account_id": scopeAccountIdAttribute("set of exclusions"),
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the exclusions apply.",
+				MarkdownDescription: "ID of the account, site or group to which the exclusions apply.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the exclusions apply (valid values: account, site, group).",
+				MarkdownDescription: "Level at which the exclusions apply (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ExclusionsBulk) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_EXCLUSIONS_BULK_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// ModifyPlan is called to modify the Terraform plan.
+func (r *ExclusionsBulk) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+
+	// nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// we need to read the file here - otherwise if the file is changed, no changes will be detected
+	var plan tfExclusionsBulk
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.FilePath.IsNull() || plan.FilePath.IsUnknown() || plan.Format.IsUnknown() {
+		return
+	}
+	entries, sha256, diags := r.readFile(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("file_sha256"), types.StringValue(sha256))...)
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// the exclusions only need to be converged if the file changed or a managed exclusion was removed outside of
+	// Terraform - otherwise keep the exclusions from the state
+	var state tfExclusionsBulk
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stateIds := map[string]string{}
+	resp.Diagnostics.Append(state.ExclusionIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	changed := state.FileSHA256.ValueString() != sha256 || len(stateIds) != len(entries)
+	for key := range entries {
+		if _, ok := stateIds[key]; !ok {
+			changed = true
+			break
+		}
+	}
+	if changed {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("exclusion_count"), types.Int64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("exclusion_ids"),
+			types.MapUnknown(types.StringType))...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("exclusion_count"), state.ExclusionCount)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("exclusion_ids"), state.ExclusionIds)...)
+}
+
+// Create is used to create the Terraform resource.
+//
+// If any exclusion fails to be created, only the exclusions which were created successfully are saved to the state
+// so that they are cleaned up when the resource is replaced.
+func (r *ExclusionsBulk) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfExclusionsBulk
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+	plan.Id = types.StringValue(fmt.Sprintf("%s-%d", plan.ScopeId.ValueString(), time.Now().Unix()))
+
+	// create all of the exclusions
+	resp.Diagnostics.Append(r.converge(ctx, &plan, map[string]string{})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// Exclusions which no longer exist are removed from the state so that they are created again on the next apply.
+func (r *ExclusionsBulk) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfExclusionsBulk
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+	stateIds := map[string]string{}
+	resp.Diagnostics.Append(state.ExclusionIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find all of the exclusions within the scope with a single query
+	existing, diags := r.findExclusions(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for key, id := range stateIds {
+		if _, ok := existing[id]; !ok {
+			tflog.Debug(ctx, "Exclusion in bulk set no longer exists.", map[string]interface{}{
+				"id":  id,
+				"key": key,
+			})
+			delete(stateIds, key)
+		}
+	}
+
+	// save refreshed state
+	state.ExclusionIds, diags = types.MapValueFrom(ctx, types.StringType, stateIds)
+	resp.Diagnostics.Append(diags...)
+	state.ExclusionCount = types.Int64Value(int64(len(stateIds)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *ExclusionsBulk) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfExclusionsBulk
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfExclusionsBulk
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+	stateIds := map[string]string{}
+	resp.Diagnostics.Append(state.ExclusionIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// apply the changes, saving whatever succeeded even if something failed
+	plan.Id = state.Id
+	resp.Diagnostics.Append(r.converge(ctx, &plan, stateIds)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *ExclusionsBulk) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfExclusionsBulk
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+	stateIds := map[string]string{}
+	resp.Diagnostics.Append(state.ExclusionIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete all of the exclusions - if any fail, keep the remaining exclusions in the state
+	keys := []string{}
+	for key := range stateIds {
+		keys = append(keys, key)
+	}
+	resp.Diagnostics.Append(r.deleteExclusions(ctx, state, stateIds, keys)...)
+	if resp.Diagnostics.HasError() {
+		var diags diag.Diagnostics
+		state.ExclusionIds, diags = types.MapValueFrom(ctx, types.StringType, stateIds)
+		resp.Diagnostics.Append(diags...)
+		state.ExclusionCount = types.Int64Value(int64(len(stateIds)))
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
+
+// converge compares the exclusions in the file with the exclusions which exist within the scope and creates, updates
+// and deletes exclusions so that they match the file.
+//
+// The given map of keys to IDs holds the exclusions currently managed by the resource. It is updated as changes are
+// made and saved to the plan once finished, even if some of the changes failed. The hash of the file is only saved
+// if the file could be read.
+func (r *ExclusionsBulk) converge(ctx context.Context, plan *tfExclusionsBulk, ids map[string]string) (
+	diags diag.Diagnostics) {
+
+	// save the exclusions which are managed once finished to the plan
+	defer func() {
+		var d diag.Diagnostics
+		plan.ExclusionIds, d = types.MapValueFrom(ctx, types.StringType, ids)
+		diags.Append(d...)
+		plan.ExclusionCount = types.Int64Value(int64(len(ids)))
+		if plan.FileSHA256.IsUnknown() {
+			plan.FileSHA256 = types.StringValue("")
+		}
+	}()
+
+	// read the file and find the exclusions which currently exist
+	entries, sha256, d := r.readFile(ctx, *plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	existing, d := r.findExclusions(ctx, *plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// find the exclusions which exist in the scope but are not managed by the resource
+	managed := map[string]bool{}
+	for _, id := range ids {
+		managed[id] = true
+	}
+	unmanaged := map[string]string{}
+	for id, exclusion := range existing {
+		if !managed[id] {
+			unmanaged[fmt.Sprintf("%s/%s/%s", exclusion.Type, exclusion.OSType, exclusion.Value)] = id
+		}
+	}
+
+	// work out which exclusions need to be created, updated or deleted
+	toCreate := []string{}
+	toUpdate := []string{}
+	toDelete := []string{}
+	conflicts := []string{}
+	for key, entry := range entries {
+		id, ok := ids[key]
+		if ok {
+			if _, ok := existing[id]; !ok {
+				delete(ids, key)
+			}
+		}
+		if _, ok := ids[key]; !ok {
+			if id, ok := unmanaged[key]; ok {
+				if !plan.AdoptExisting.ValueBool() {
+					conflicts = append(conflicts, key)
+					continue
+				}
+				tflog.Debug(ctx, "Taking over existing exclusion.", map[string]interface{}{
+					"id":  id,
+					"key": key,
+				})
+				ids[key] = id
+			}
+		}
+		id, ok = ids[key]
+		if !ok {
+			toCreate = append(toCreate, key)
+		} else if !exclusionsBulkEntryMatches(entry, existing[id]) {
+			toUpdate = append(toUpdate, key)
+		}
+	}
+	for key := range ids {
+		if _, ok := entries[key]; !ok {
+			toDelete = append(toDelete, key)
+		}
+	}
+
+	// creating exclusions which already exist would leave duplicates in the scope
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		msg := fmt.Sprintf("The exclusions file contains %d exclusions which already exist in the scope but are not "+
+			"managed by this resource. Remove them from the file, or set adopt_existing to true to take them over. "+
+			"Exclusions which are taken over are deleted when the resource is destroyed.\n\nExclusions: %s",
+			len(conflicts), strings.Join(conflicts, ", "))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_EXCLUSIONS_BULK_CONVERGE,
+			"conflicts":           len(conflicts),
+		})
+		diags.AddAttributeError(tfpath.Root("adopt_existing"), "Exclusions Already Exist", msg)
+		return diags
+	}
+	sort.Strings(toCreate)
+	sort.Strings(toUpdate)
+	sort.Strings(toDelete)
+	tflog.Info(ctx, "Converging bulk exclusions", map[string]interface{}{
+		"create": len(toCreate),
+		"update": len(toUpdate),
+		"delete": len(toDelete),
+	})
+
+	// apply the changes
+	var mutex sync.Mutex
+	scope := scopeFromModel(plan.ScopeType, plan.ScopeId)
+	diags.Append(r.deleteExclusions(ctx, *plan, ids, toDelete)...)
+	diags.Append(forEachParallel(plan.Parallelism.ValueInt64(), toUpdate, "exclusion updates",
+		func(key string) diag.Diagnostics {
+			mutex.Lock()
+			id := ids[key]
+			mutex.Unlock()
+			body := exclusionsBulkBody(entries[key])
+			body.OSType = nil // cannot be changed once the exclusion has been created
+			_, diags := api.Client().UpdateExclusion(ctx, id, body)
+			return diags
+		})...)
+	diags.Append(forEachParallel(plan.Parallelism.ValueInt64(), toCreate, "exclusion creations",
+		func(key string) diag.Diagnostics {
+			exclusion, diags := api.Client().CreateExclusion(ctx, scope, exclusionsBulkBody(entries[key]))
+			if diags.HasError() {
+				return diags
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			ids[key] = exclusion.Id
+			return diags
+		})...)
+
+	plan.FileSHA256 = types.StringValue(sha256)
+	return diags
+}
+
+// deleteExclusions deletes the exclusions with the given keys in batches and removes them from the given map of keys
+// to IDs.
+//
+// The API can only delete exclusions of a single type at once so the exclusions are grouped by type first.
+func (r *ExclusionsBulk) deleteExclusions(ctx context.Context, model tfExclusionsBulk, ids map[string]string,
+	keys []string) diag.Diagnostics {
+
+	// split the exclusions into batches of the same type
+	batchSize := int(model.BatchSize.ValueInt64())
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	byType := map[string][]string{}
+	for _, key := range keys {
+		exclusionType := strings.SplitN(key, "/", 2)[0]
+		byType[exclusionType] = append(byType[exclusionType], key)
+	}
+	batches := map[string][]string{}
+	batchTypes := map[string]string{}
+	batchNames := []string{}
+	for exclusionType, typeKeys := range byType {
+		for start := 0; start < len(typeKeys); start += batchSize {
+			end := start + batchSize
+			if end > len(typeKeys) {
+				end = len(typeKeys)
+			}
+			name := fmt.Sprintf("%s-%d", exclusionType, start/batchSize)
+			batches[name] = typeKeys[start:end]
+			batchTypes[name] = exclusionType
+			batchNames = append(batchNames, name)
+		}
+	}
+	sort.Strings(batchNames)
+
+	// delete each batch
+	var mutex sync.Mutex
+	return forEachParallel(model.Parallelism.ValueInt64(), batchNames, "exclusion deletion batches",
+		func(name string) diag.Diagnostics {
+			mutex.Lock()
+			batchIds := []string{}
+			for _, key := range batches[name] {
+				batchIds = append(batchIds, ids[key])
+			}
+			mutex.Unlock()
+
+			diags := api.Client().DeleteExclusions(ctx, batchIds, batchTypes[name])
+			if diags.HasError() {
+				return diags
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, key := range batches[name] {
+				delete(ids, key)
+			}
+			return diags
+		})
+}
+
+// findExclusions returns all of the exclusions within the scope of the resource keyed by ID.
+func (r *ExclusionsBulk) findExclusions(ctx context.Context, model tfExclusionsBulk) (map[string]api.Exclusion,
+	diag.Diagnostics) {
+
+	queryParams := api.ExclusionQueryParams{}
+	switch model.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{model.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{model.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{model.ScopeId.ValueString()}
+	}
+	exclusions, diags := api.Client().FindExclusions(ctx, queryParams)
+	if diags.HasError() {
+		return nil, diags
+	}
+	existing := map[string]api.Exclusion{}
+	for _, exclusion := range exclusions {
+		existing[exclusion.Id] = exclusion
+	}
+	return existing, diags
+}
+
+// readFile reads and parses the exclusions file and returns its entries keyed by the key of each entry along with
+// the SHA256 hash of the file.
+func (r *ExclusionsBulk) readFile(ctx context.Context, model tfExclusionsBulk) (map[string]exclusionsBulkEntry,
+	string, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	// convert the path to an absolute path
+	absPath, diags := plugin.ToAbsolutePath(ctx, model.FilePath.ValueString())
+	if diags.HasError() {
+		return nil, "", diags
+	}
+	ctx = tflog.SetField(ctx, "file", absPath)
+	addError := func(msg string, err error) (map[string]exclusionsBulkEntry, string, diag.Diagnostics) {
+		if err != nil {
+			msg = fmt.Sprintf("%s\n\nError: %s\nFile: %s", msg, err.Error(), absPath)
+		} else {
+			msg = fmt.Sprintf("%s\n\nFile: %s", msg, absPath)
+		}
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_EXCLUSIONS_BULK_PARSE_FILE,
+		})
+		diags.AddAttributeError(tfpath.Root("file_path"), "Invalid Exclusions File", msg)
+		return nil, "", diags
+	}
+
+	// read the file
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		return addError("An unexpected error occurred while reading the exclusions file.", err)
+	}
+	format := strings.ToLower(model.Format.ValueString())
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(absPath)), ".")
+	}
+
+	// parse the entries
+	var entries []exclusionsBulkEntry
+	switch format {
+	case "csv":
+		reader := csv.NewReader(strings.NewReader(string(content)))
+		reader.TrimLeadingSpace = true
+		header, err := reader.Read()
+		if err != nil {
+			return addError("The header row of the exclusions file could not be read.", err)
+		}
+		columns := map[int]string{}
+		for i, column := range header {
+			column = strings.ToLower(strings.TrimSpace(column))
+			if !exclusionsBulkColumn(column) {
+				return addError(fmt.Sprintf("The exclusions file contains an unknown column: %s (valid columns: %s).",
+					column, strings.Join(exclusionsBulkColumns, ", ")), nil)
+			}
+			columns[i] = column
+		}
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return addError("A row of the exclusions file could not be read.", err)
+			}
+			values := map[string]string{}
+			for i, value := range record {
+				values[columns[i]] = strings.TrimSpace(value)
+			}
+			entries = append(entries, exclusionsBulkEntry{
+				Description:       values["description"],
+				Mode:              values["mode"],
+				OSType:            values["os_type"],
+				PathExclusionType: values["path_exclusion_type"],
+				Type:              values["type"],
+				Value:             values["value"],
+			})
+		}
+	case "json":
+		decoder := json.NewDecoder(strings.NewReader(string(content)))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entries); err != nil {
+			return addError("The exclusions file is not a JSON array of exclusion objects.", err)
+		}
+	default:
+		return addError("The format of the exclusions file could not be determined from its extension. Set the "+
+			"format attribute to csv or json.", nil)
+	}
+
+	// make sure every entry is complete and unique
+	keyed := map[string]exclusionsBulkEntry{}
+	for i, entry := range entries {
+		if entry.Type == "" || entry.Value == "" || entry.OSType == "" {
+			return addError(fmt.Sprintf("Exclusion #%d in the exclusions file is missing its type, value or "+
+				"os_type.", i+1), nil)
+		}
+		if _, ok := keyed[entry.key()]; ok {
+			return addError(fmt.Sprintf("Exclusion #%d in the exclusions file has the same type, OS type and value "+
+				"as an earlier exclusion: %s", i+1, entry.key()), nil)
+		}
+		keyed[entry.key()] = entry
+	}
+	return keyed, fmt.Sprintf("%x", sha256.Sum256(content)), diags
+}
+
+// exclusionsBulkBody converts an entry from an exclusions file into the API request body for creating or updating an
+// exclusion.
+func exclusionsBulkBody(entry exclusionsBulkEntry) api.ExclusionBody {
+	body := api.ExclusionBody{
+		OSType: &entry.OSType,
+		Type:   &entry.Type,
+		Value:  &entry.Value,
+	}
+	if entry.Description != "" {
+		body.Description = &entry.Description
+	}
+	if entry.Mode != "" {
+		body.Mode = &entry.Mode
+	}
+	if entry.PathExclusionType != "" {
+		body.PathExclusionType = &entry.PathExclusionType
+	}
+	return body
+}
+
+// exclusionsBulkColumn determines whether or not the given column may be used in an exclusions file.
+func exclusionsBulkColumn(column string) bool {
+	for _, c := range exclusionsBulkColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// exclusionsBulkEntryMatches determines whether or not the exclusion in the console matches the entry from the
+// exclusions file. Optional values which are not set in the file are not compared.
+func exclusionsBulkEntryMatches(entry exclusionsBulkEntry, exclusion api.Exclusion) bool {
+	if entry.Description != "" && entry.Description != exclusion.Description {
+		return false
+	}
+	if entry.Mode != "" && entry.Mode != exclusion.Mode {
+		return false
+	}
+	if entry.PathExclusionType != "" && entry.PathExclusionType != exclusion.PathExclusionType {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,343 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Filter{}
+	_ resource.ResourceWithConfigure   = &Filter{}
+	_ resource.ResourceWithImportState = &Filter{}
+)
+
+// tfFilter defines the Terraform model for a saved filter.
+type tfFilter struct {
+	AgentVersions []types.String `tfsdk:"agent_versions"`
+	CreatedAt     types.String   `tfsdk:"created_at"`
+	Id            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	OsTypes       []types.String `tfsdk:"os_types"`
+	ScopeId       types.String   `tfsdk:"scope_id"`
+	ScopeType     types.String   `tfsdk:"scope_type"`
+	TagIds        []types.String `tfsdk:"tag_ids"`
+	UpdatedAt     types.String   `tfsdk:"updated_at"`
+}
+
+// NewFilter creates a new Filter object.
+func NewFilter() resource.Resource {
+	return &Filter{}
+}
+
+// Filter is a resource used to manage the lifecycle of a saved filter.
+type Filter struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Filter) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_filter"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Filter) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a saved filter within an account or site.",
+		MarkdownDescription: `This resource is used for creating and managing a saved filter within an account or
+			site.
+
+		Saved filters are required by dynamic groups: pass the ` + "`id`" + ` of this resource as the
+		` + "`filter_id`" + ` of a ` + "`singularity_group`" + ` resource and agents matching the filter are
+		automatically added to the group. An agent matches the filter when it matches at least one of the values of
+		every query field which is set.
+
+		Existing filters can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<filter_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agent_versions": schema.ListAttribute{
+				Description:         "Agent versions to match. [Default: any]",
+				MarkdownDescription: "Agent versions to match. [Default: any]",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the filter was created.",
+				MarkdownDescription: "Timestamp of when the filter was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the filter.",
+				MarkdownDescription: "ID of the filter.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the filter.",
+				MarkdownDescription: "Name of the filter.",
+				Required:            true,
+			},
+			"os_types": schema.ListAttribute{
+				Description: "Operating system types to match (valid values: linux, macos, windows, " +
+					"windows_legacy). [Default: any]",
+				MarkdownDescription: "Operating system types to match (valid values: `linux`, `macos`, `windows`, " +
+					"`windows_legacy`). [Default: any]",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, "linux", "macos", "windows", "windows_legacy"),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the filter belongs.",
+				MarkdownDescription: "ID of the account or site to which the filter belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the filter is created (valid values: account, site).",
+				MarkdownDescription: "Level at which the filter is created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"tag_ids": schema.ListAttribute{
+				Description:         "IDs of the agent tags to match. [Default: any]",
+				MarkdownDescription: "IDs of the agent tags to match. [Default: any]",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the filter was last updated.",
+				MarkdownDescription: "Timestamp of when the filter was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Filter) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_FILTER_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Filter) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfFilter
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the filter
+	filter, diags := api.Client().CreateFilter(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the filter to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfFilterFromAPI(ctx, filter, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Filter) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfFilter
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the filter - if it no longer exists, remove it from the state
+	queryParams := api.FilterQueryParams{
+		FilterIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	filters, diags := api.Client().FindFilters(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(filters) == 0 {
+		tflog.Debug(ctx, "Filter no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfFilterFromAPI(ctx, &filters[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Filter) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfFilter
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfFilter
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the filter
+	filter, diags := api.Client().UpdateFilter(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated filter to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfFilterFromAPI(ctx, filter, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Filter) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfFilter
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the filter
+	resp.Diagnostics.Append(api.Client().DeleteFilter(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed filter", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing filter into the Terraform state.
+//
+// The API can only find a filter within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<filter_id>.
+func (r *Filter) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<filter_id> where the scope "+
+			"type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_FILTER_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a filter.
+func (r *Filter) bodyFromPlan(plan tfFilter) api.FilterBody {
+	body := api.FilterBody{}
+
+	for _, v := range plan.AgentVersions {
+		body.AgentVersions = append(body.AgentVersions, v.ValueString())
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	for _, o := range plan.OsTypes {
+		body.OsTypes = append(body.OsTypes, o.ValueString())
+	}
+
+	for _, t := range plan.TagIds {
+		body.TagIds = append(body.TagIds, t.ValueString())
+	}
+	return body
+}
+
+// tfFilterFromAPI converts an API filter into a Terraform filter.
+//
+// The scope is copied from the given model since it is always known from the configuration or import ID. Query
+// fields which are not set are left null to match an unset attribute in the configuration.
+func tfFilterFromAPI(ctx context.Context, filter *api.Filter, model tfFilter) tfFilter {
+	tffilter := tfFilter{
+		CreatedAt: types.StringValue(filter.CreatedAt),
+		Id:        types.StringValue(filter.Id),
+		Name:      types.StringValue(filter.Name),
+		ScopeId:   model.ScopeId,
+		ScopeType: model.ScopeType,
+		UpdatedAt: types.StringValue(filter.UpdatedAt),
+	}
+	for _, v := range filter.FilterFields.AgentVersions {
+		tffilter.AgentVersions = append(tffilter.AgentVersions, types.StringValue(v))
+	}
+	for _, o := range filter.FilterFields.OsTypes {
+		tffilter.OsTypes = append(tffilter.OsTypes, types.StringValue(o))
+	}
+	for _, t := range filter.FilterFields.TagIds {
+		tffilter.TagIds = append(tffilter.TagIds, types.StringValue(t))
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API filter to TF filter: %+v", tffilter), map[string]interface{}{
+		"api_filter": filter,
+	})
+	return tffilter
+}
@@ -0,0 +1,281 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &FirewallRuleOrder{}
+	_ resource.ResourceWithConfigure = &FirewallRuleOrder{}
+)
+
+// tfFirewallRuleOrder defines the Terraform model for the order of a set of firewall rules.
+type tfFirewallRuleOrder struct {
+	Id        types.String   `tfsdk:"id"`
+	RuleIds   []types.String `tfsdk:"rule_ids"`
+	ScopeId   types.String   `tfsdk:"scope_id"`
+	ScopeType types.String   `tfsdk:"scope_type"`
+}
+
+// NewFirewallRuleOrder creates a new FirewallRuleOrder object.
+func NewFirewallRuleOrder() resource.Resource {
+	return &FirewallRuleOrder{}
+}
+
+// FirewallRuleOrder is a resource used to manage the order in which a set of firewall rules is evaluated.
+type FirewallRuleOrder struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *FirewallRuleOrder) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_rule_order"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *FirewallRuleOrder) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the order in which a set of firewall rules within an " +
+			"account, site or group is evaluated.",
+		MarkdownDescription: `This resource is used for managing the order in which a set of firewall rules within an
+			account, site or group is evaluated.
+
+		The rules in ` + "`rule_ids`" + ` are evaluated in the order given. The positions currently held by those
+		rules are reassigned among them in a single API call, so rules which are not managed by this resource keep
+		their positions. Any change to the order made outside of Terraform is detected and reverted on the next
+		apply. Destroying the resource leaves the rules in their current order.
+
+		When the scope is an account or site, it is checked at plan time to ensure that it is licensed for Firewall
+		Control.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "ID of the rule order.",
+				MarkdownDescription: "ID of the rule order.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rule_ids": schema.ListAttribute{
+				Description:         "IDs of the firewall rules in the order in which they should be evaluated.",
+				MarkdownDescription: "IDs of the firewall rules in the order in which they should be evaluated.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the firewall rules belong.",
+				MarkdownDescription: "ID of the account, site or group to which the firewall rules belong.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.ScopeIsLicensedFor("Firewall Control", "control", "complete"),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level to which the firewall rules belong (valid values: account, site, group).",
+				MarkdownDescription: "Level to which the firewall rules belong (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *FirewallRuleOrder) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_FIREWALL_RULE_ORDER_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *FirewallRuleOrder) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfFirewallRuleOrder
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// apply the order
+	resp.Diagnostics.Append(r.applyOrder(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the order to the state
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", plan.ScopeType.ValueString(), plan.ScopeId.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *FirewallRuleOrder) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfFirewallRuleOrder
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the rules and sort them by their current position
+	rules, diags := r.findRules(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Order < rules[j].Order
+	})
+
+	// the state reflects the actual order so that any drift shows up as a change
+	state.RuleIds = []types.String{}
+	for _, rule := range rules {
+		state.RuleIds = append(state.RuleIds, types.StringValue(rule.Id))
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *FirewallRuleOrder) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfFirewallRuleOrder
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfFirewallRuleOrder
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// apply the order
+	resp.Diagnostics.Append(r.applyOrder(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated order to the state
+	plan.Id = state.Id
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The rules are left in their current order.
+func (r *FirewallRuleOrder) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Removed firewall rule order from the state; rules have been left in their current order")
+}
+
+// applyOrder reassigns the positions currently held by the rules in the plan so that the rules are evaluated in
+// the order given.
+func (r *FirewallRuleOrder) applyOrder(ctx context.Context, plan tfFirewallRuleOrder) diag.Diagnostics {
+	// find the current position of every rule
+	rules, diags := r.findRules(ctx, plan)
+	if diags.HasError() {
+		return diags
+	}
+	positions := map[string]int{}
+	for _, rule := range rules {
+		positions[rule.Id] = rule.Order
+	}
+	missing := []string{}
+	slots := []int{}
+	for _, id := range plan.RuleIds {
+		position, ok := positions[id.ValueString()]
+		if !ok {
+			missing = append(missing, id.ValueString())
+			continue
+		}
+		slots = append(slots, position)
+	}
+	if len(missing) > 0 {
+		msg := fmt.Sprintf("The following firewall rules were not found in the given scope and cannot be ordered: %s",
+			strings.Join(missing, ", "))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_FIREWALL_RULE_ORDER_APPLY,
+			"missing_rule_ids":    missing,
+		})
+		diags.AddError("Firewall Rule Not Found", msg)
+		return diags
+	}
+
+	// hand out the positions in ascending order following the order of the rules in the plan
+	sort.Ints(slots)
+	order := map[string]int{}
+	for i, id := range plan.RuleIds {
+		order[id.ValueString()] = slots[i]
+	}
+	return api.Client().ReorderFirewallRules(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), order)
+}
+
+// findRules finds the rules in the given model within its scope.
+func (r *FirewallRuleOrder) findRules(ctx context.Context, model tfFirewallRuleOrder) ([]api.FirewallRule,
+	diag.Diagnostics) {
+
+	queryParams := api.FirewallRuleQueryParams{
+		RuleIds: []string{},
+	}
+	for _, id := range model.RuleIds {
+		queryParams.RuleIds = append(queryParams.RuleIds, id.ValueString())
+	}
+	switch model.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{model.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{model.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{model.ScopeId.ValueString()}
+	}
+	return api.Client().FindFirewallRules(ctx, queryParams)
+}
@@ -0,0 +1,432 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Group{}
+	_ resource.ResourceWithConfigure   = &Group{}
+	_ resource.ResourceWithImportState = &Group{}
+)
+
+// tfGroup defines the Terraform model for a group.
+type tfGroup struct {
+	CreatedAt         types.String `tfsdk:"created_at"`
+	Creator           types.String `tfsdk:"creator"`
+	CreatorId         types.String `tfsdk:"creator_id"`
+	Description       types.String `tfsdk:"description"`
+	FilterId          types.String `tfsdk:"filter_id"`
+	FilterName        types.String `tfsdk:"filter_name"`
+	Id                types.String `tfsdk:"id"`
+	Inherits          types.Bool   `tfsdk:"inherits"`
+	IsDefault         types.Bool   `tfsdk:"is_default"`
+	Name              types.String `tfsdk:"name"`
+	Rank              types.Int64  `tfsdk:"rank"`
+	RegistrationToken types.String `tfsdk:"registration_token"`
+	ScopeAccountId    types.String `tfsdk:"scope_account_id"`
+	SiteId            types.String `tfsdk:"site_id"`
+	TotalAgents       types.Int64  `tfsdk:"total_agents"`
+	Type              types.String `tfsdk:"type"`
+	UpdatedAt         types.String `tfsdk:"updated_at"`
+}
+
+// NewGroup creates a new Group object.
+func NewGroup() resource.Resource {
+	return &Group{}
+}
+
+// Group is a resource used to manage the lifecycle of a group.
+type Group struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Group) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Group) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a static, dynamic or pinned group within a site.",
+		MarkdownDescription: `This resource is used for creating and managing a static, dynamic or pinned group within
+			a site.
+
+		A group is static unless a ` + "`filter_id`" + ` is given, in which case agents matching the filter are
+		automatically added to the group. Set ` + "`type`" + ` to create a pinned group. Existing groups can be
+		imported using their ID.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the group was created.",
+				MarkdownDescription: "Timestamp of when the group was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creator": schema.StringAttribute{
+				Description:         "Full name of the user who created the group.",
+				MarkdownDescription: "Full name of the user who created the group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creator_id": schema.StringAttribute{
+				Description:         "ID of the user who created the group.",
+				MarkdownDescription: "ID of the user who created the group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "User-defined description of the group. [Default: none]",
+				MarkdownDescription: "User-defined description of the group. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"filter_id": schema.StringAttribute{
+				Description: "ID of the filter used to dynamically associate agents with the group (eg: the ID of a " +
+					"singularity_filter resource). If not set, the group is a static group.",
+				MarkdownDescription: "ID of the filter used to dynamically associate agents with the group (eg: the ID " +
+					"of a `singularity_filter` resource). If not set, the group is a static group.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(groupFilterIdRequiresReplace,
+						"Adding or removing the filter changes the type of the group and requires the group to be "+
+							"replaced.",
+						"Adding or removing the filter changes the type of the group and requires the group to be "+
+							"replaced."),
+				},
+			},
+			"filter_name": schema.StringAttribute{
+				Description:         "If the group is dynamic, the name of the filter which is used to associate the agents.",
+				MarkdownDescription: "If the group is dynamic, the name of the filter which is used to associate the agents.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the group.",
+				MarkdownDescription: "ID of the group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inherits": schema.BoolAttribute{
+				Description:         "Whether or not the group inherits policies from its parent site. [Default: true]",
+				MarkdownDescription: "Whether or not the group inherits policies from its parent site. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"is_default": schema.BoolAttribute{
+				Description:         "Whether or not the group is the default group for the parent site.",
+				MarkdownDescription: "Whether or not the group is the default group for the parent site.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the group.",
+				MarkdownDescription: "Name of the group.",
+				Required:            true,
+			},
+			"rank": schema.Int64Attribute{
+				Description: "Rank sets the priority of a dynamic group over others. If not set, the server will " +
+					"assign the rank.",
+				MarkdownDescription: "Rank sets the priority of a dynamic group over others. If not set, the server " +
+					"will assign the rank.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"registration_token": schema.StringAttribute{
+				Description:         "Registration token for the group.",
+				MarkdownDescription: "Registration token for the group.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_account_id": scopeAccountIdAttribute("group"),
+			"site_id": schema.StringAttribute{
+				Description:         "ID of site to which the group belongs.",
+				MarkdownDescription: "ID of site to which the group belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"total_agents": schema.Int64Attribute{
+				Description:         "Total number of agents in the group.",
+				MarkdownDescription: "Total number of agents in the group.",
+				Computed:            true,
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of group (valid values: dynamic, pinned, static). Changing the type requires the " +
+					"group to be replaced. [Default: dynamic if filter_id is set, otherwise static]",
+				MarkdownDescription: "Type of group (valid values: `dynamic`, `pinned`, `static`). Changing the type " +
+					"requires the group to be replaced. [Default: `dynamic` if `filter_id` is set, otherwise `static`]",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "dynamic", "pinned", "static"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the group was last updated.",
+				MarkdownDescription: "Timestamp of when the group was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Group) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_GROUP_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Group) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfGroup
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// create the group
+	body := r.bodyFromPlan(plan)
+	siteId := plan.SiteId.ValueString() // always required so no need to check
+	body.SiteId = &siteId
+	if !plan.Type.IsNull() && !plan.Type.IsUnknown() {
+		groupType := plan.Type.ValueString()
+		body.Type = &groupType
+	}
+	group, diags := api.Client().CreateGroup(ctx, body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the group to the state
+	tfgroup := tfGroupFromAPI(ctx, group)
+	tfgroup.ScopeAccountId = plan.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfgroup)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Group) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfGroup
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// find the group - if it no longer exists, remove it from the state
+	groups, diags := api.Client().FindGroups(ctx, api.GroupQueryParams{
+		GroupIds: []string{state.Id.ValueString()},
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(groups) == 0 {
+		tflog.Debug(ctx, "Group no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	tfgroup := tfGroupFromAPI(ctx, &groups[0])
+	tfgroup.ScopeAccountId = state.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfgroup)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Group) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfGroup
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfGroup
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// update the group
+	group, diags := api.Client().UpdateGroup(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated group to the state
+	tfgroup := tfGroupFromAPI(ctx, group)
+	tfgroup.ScopeAccountId = plan.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfgroup)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Group) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfGroup
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// delete the group
+	resp.Diagnostics.Append(api.Client().DeleteGroup(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed group", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing group into the Terraform state using its ID.
+func (r *Group) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, tfpath.Root("id"), req, resp)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a group.
+//
+// Note that the site ID and type are not included as they cannot be changed once the group has been created.
+func (r *Group) bodyFromPlan(plan tfGroup) api.GroupBody {
+	body := api.GroupBody{}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.FilterId.IsNull() && !plan.FilterId.IsUnknown() {
+		value := plan.FilterId.ValueString()
+		body.FilterId = &value
+	}
+
+	if !plan.Inherits.IsNull() && !plan.Inherits.IsUnknown() {
+		value := plan.Inherits.ValueBool()
+		body.Inherits = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if !plan.Rank.IsNull() && !plan.Rank.IsUnknown() {
+		value := plan.Rank.ValueInt64()
+		body.Rank = &value
+	}
+	return body
+}
+
+// groupFilterIdRequiresReplace forces the group to be replaced when its filter is added or removed.
+//
+// The API does not support clearing the filter of a dynamic group or converting a static group into a dynamic one,
+// but the filter of a dynamic group can be changed in place.
+func groupFilterIdRequiresReplace(ctx context.Context, req planmodifier.StringRequest,
+	resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+
+	if req.PlanValue.IsUnknown() {
+		return
+	}
+	resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+}
+
+// tfGroupFromAPI converts an API group into a Terraform group.
+func tfGroupFromAPI(ctx context.Context, group *api.Group) tfGroup {
+	tfgroup := tfGroup{
+		CreatedAt:         types.StringValue(group.CreatedAt),
+		Creator:           types.StringValue(group.Creator),
+		CreatorId:         types.StringValue(group.CreatorId),
+		Description:       types.StringValue(group.Description),
+		FilterId:          types.StringNull(),
+		FilterName:        types.StringValue(group.FilterName),
+		Id:                types.StringValue(group.Id),
+		Inherits:          types.BoolValue(group.Inherits),
+		IsDefault:         types.BoolValue(group.IsDefault),
+		Name:              types.StringValue(group.Name),
+		Rank:              types.Int64Value(int64(group.Rank)),
+		RegistrationToken: types.StringValue(group.RegistrationToken),
+		SiteId:            types.StringValue(group.SiteId),
+		TotalAgents:       types.Int64Value(int64(group.TotalAgents)),
+		Type:              types.StringValue(group.Type),
+		UpdatedAt:         types.StringValue(group.UpdatedAt),
+	}
+	if group.FilterId != "" { // static groups have no filter
+		tfgroup.FilterId = types.StringValue(group.FilterId)
+	}
+	ctx = plugin.MaskSecrets(ctx, group.RegistrationToken)
+	tflog.Debug(ctx, fmt.Sprintf("converted API group to TF group: %+v", tfgroup), map[string]interface{}{
+		"api_group": group,
+	})
+	return tfgroup
+}
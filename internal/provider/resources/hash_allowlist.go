@@ -0,0 +1,372 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &HashAllowlist{}
+	_ resource.ResourceWithConfigure   = &HashAllowlist{}
+	_ resource.ResourceWithImportState = &HashAllowlist{}
+)
+
+// tfHashAllowlist defines the Terraform model for an allow-listed hash.
+type tfHashAllowlist struct {
+	CreatedAt      types.String `tfsdk:"created_at"`
+	Description    types.String `tfsdk:"description"`
+	Id             types.String `tfsdk:"id"`
+	OSType         types.String `tfsdk:"os_type"`
+	ScopeAccountId types.String `tfsdk:"scope_account_id"`
+	ScopeId        types.String `tfsdk:"scope_id"`
+	ScopeName      types.String `tfsdk:"scope_name"`
+	ScopeType      types.String `tfsdk:"scope_type"`
+	SHA1           types.String `tfsdk:"sha1"`
+	Source         types.String `tfsdk:"source"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+	UserName       types.String `tfsdk:"user_name"`
+}
+
+// NewHashAllowlist creates a new HashAllowlist object.
+func NewHashAllowlist() resource.Resource {
+	return &HashAllowlist{}
+}
+
+// HashAllowlist is a resource used to manage the lifecycle of an allow-listed hash.
+type HashAllowlist struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *HashAllowlist) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hash_allowlist"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *HashAllowlist) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for adding a SHA1 hash to the allowlist of an account, site or group.",
+		MarkdownDescription: `This resource is used for adding a SHA1 hash to the allowlist of an account, site or
+			group.
+
+		Agents within the scope will not detect or mitigate any file whose SHA1 hash matches. The hash is stored as a
+		` + "`white_hash`" + ` exclusion but is managed separately from the generic ` + "`singularity_exclusion`" + `
+		resource so that allow-listed hashes can be tracked on their own. Destroying the resource removes the hash
+		from the allowlist.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the hash was added to the allowlist.",
+				MarkdownDescription: "Timestamp of when the hash was added to the allowlist.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "User-defined description of the allow-listed hash. [Default: none]",
+				MarkdownDescription: "User-defined description of the allow-listed hash. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the allowlist entry.",
+				MarkdownDescription: "ID of the allowlist entry.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"os_type": schema.StringAttribute{
+				Description:         "Operating system to which the entry applies (valid values: linux, macos, windows).",
+				MarkdownDescription: "Operating system to which the entry applies (valid values: `linux`, `macos`, `windows`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "linux", "macos", "windows"),
+				},
+			},
+			"scope_account_id": scopeAccountIdAttribute("allowlist entry"),
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the entry applies.",
+				MarkdownDescription: "ID of the account, site or group to which the entry applies.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_name": schema.StringAttribute{
+				Description:         "Name of the account, site or group to which the entry applies.",
+				MarkdownDescription: "Name of the account, site or group to which the entry applies.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the entry applies (valid values: account, site, group).",
+				MarkdownDescription: "Level at which the entry applies (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"sha1": schema.StringAttribute{
+				Description:         "SHA1 hash of the file to allow.",
+				MarkdownDescription: "SHA1 hash of the file to allow.",
+				Required:            true,
+			},
+			"source": schema.StringAttribute{
+				Description:         "Source from which the entry was created.",
+				MarkdownDescription: "Source from which the entry was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the entry was last updated.",
+				MarkdownDescription: "Timestamp of when the entry was last updated.",
+				Computed:            true,
+			},
+			"user_name": schema.StringAttribute{
+				Description:         "Name of the user who last modified the entry.",
+				MarkdownDescription: "Name of the user who last modified the entry.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *HashAllowlist) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_HASH_ALLOWLIST_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *HashAllowlist) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfHashAllowlist
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// add the hash to the allowlist
+	body := r.bodyFromPlan(plan)
+	osType := plan.OSType.ValueString() // always required so no need to check
+	body.OSType = &osType
+	exclusion, diags := api.Client().CreateExclusion(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the entry to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfHashAllowlistFromAPI(ctx, exclusion, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *HashAllowlist) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfHashAllowlist
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// find the entry - if it no longer exists, remove it from the state
+	exclusionType := api.EXCLUSION_TYPE_ALLOWLIST_HASH
+	queryParams := api.ExclusionQueryParams{
+		ExclusionIds: []string{state.Id.ValueString()},
+		Type:         &exclusionType,
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{state.ScopeId.ValueString()}
+	}
+	exclusions, diags := api.Client().FindExclusions(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(exclusions) == 0 {
+		tflog.Debug(ctx, "Allow-listed hash no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfHashAllowlistFromAPI(ctx, &exclusions[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *HashAllowlist) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfHashAllowlist
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfHashAllowlist
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// update the entry
+	exclusion, diags := api.Client().UpdateExclusion(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated entry to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfHashAllowlistFromAPI(ctx, exclusion, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *HashAllowlist) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfHashAllowlist
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// remove the hash from the allowlist
+	resp.Diagnostics.Append(api.Client().DeleteExclusion(ctx, state.Id.ValueString(),
+		api.EXCLUSION_TYPE_ALLOWLIST_HASH)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed allow-listed hash", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing allow-listed hash into the Terraform state.
+//
+// The API can only find an exclusion within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<entry_id>.
+func (r *HashAllowlist) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" ||
+		(parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE && parts[0] != api.SCOPE_GROUP) {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<entry_id> where the scope "+
+			"type is one of: %s, %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_HASH_ALLOWLIST_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating an allowlist entry.
+//
+// Note that the OS type is not included as it cannot be changed once the entry has been created.
+func (r *HashAllowlist) bodyFromPlan(plan tfHashAllowlist) api.ExclusionBody {
+	exclusionType := api.EXCLUSION_TYPE_ALLOWLIST_HASH
+	body := api.ExclusionBody{
+		Type: &exclusionType,
+	}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.SHA1.IsNull() && !plan.SHA1.IsUnknown() {
+		value := plan.SHA1.ValueString()
+		body.Value = &value
+	}
+	return body
+}
+
+// tfHashAllowlistFromAPI converts an API exclusion into a Terraform allow-listed hash.
+//
+// The API does not return the scope to which the entry was assigned so it is copied from the given model.
+func tfHashAllowlistFromAPI(ctx context.Context, exclusion *api.Exclusion, model tfHashAllowlist) tfHashAllowlist {
+	tfhash := tfHashAllowlist{
+		CreatedAt:      types.StringValue(exclusion.CreatedAt),
+		Description:    types.StringValue(exclusion.Description),
+		Id:             types.StringValue(exclusion.Id),
+		OSType:         types.StringValue(exclusion.OSType),
+		ScopeAccountId: model.ScopeAccountId,
+		ScopeId:        model.ScopeId,
+		ScopeName:      types.StringValue(exclusion.ScopeName),
+		ScopeType:      model.ScopeType,
+		SHA1:           types.StringValue(exclusion.Value),
+		Source:         types.StringValue(exclusion.Source),
+		UpdatedAt:      types.StringValue(exclusion.UpdatedAt),
+		UserName:       types.StringValue(exclusion.UserName),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API exclusion to TF allow-listed hash: %+v", tfhash),
+		map[string]interface{}{
+			"api_exclusion": exclusion,
+		})
+	return tfhash
+}
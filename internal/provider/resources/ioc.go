@@ -0,0 +1,356 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &IOC{}
+	_ resource.ResourceWithConfigure   = &IOC{}
+	_ resource.ResourceWithImportState = &IOC{}
+)
+
+// tfIOC defines the Terraform model for an indicator of compromise.
+type tfIOC struct {
+	CreatedAt   types.String `tfsdk:"created_at"`
+	Description types.String `tfsdk:"description"`
+	Id          types.String `tfsdk:"id"`
+	ScopeId     types.String `tfsdk:"scope_id"`
+	ScopeType   types.String `tfsdk:"scope_type"`
+	Source      types.String `tfsdk:"source"`
+	Type        types.String `tfsdk:"type"`
+	ValidUntil  types.String `tfsdk:"valid_until"`
+	Value       types.String `tfsdk:"value"`
+}
+
+// NewIOC creates a new IOC object.
+func NewIOC() resource.Resource {
+	return &IOC{}
+}
+
+// IOC is a resource used to manage a threat intelligence indicator of compromise.
+type IOC struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *IOC) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ioc"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *IOC) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a threat intelligence indicator of " +
+			"compromise (IOC) within an account or site.",
+		MarkdownDescription: `This resource is used for creating and managing a threat intelligence indicator of
+			compromise (IOC) within an account or site.
+
+		The Threat Intelligence API does not support updating an IOC, so changing any argument replaces it. Once an IOC
+		passes its expiration time it is removed by the server, in which case it is removed from the state when it is
+		refreshed and created again on the next apply. Existing IOCs can be imported using an ID in the format
+		` + "`<scope_type>/<scope_id>/<ioc_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the IOC was created.",
+				MarkdownDescription: "Timestamp of when the IOC was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the IOC. [Default: none]",
+				MarkdownDescription: "Description of the IOC. [Default: none]",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "UUID of the IOC.",
+				MarkdownDescription: "UUID of the IOC.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the IOC belongs.",
+				MarkdownDescription: "ID of the account or site to which the IOC belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the IOC is created (valid values: account, site).",
+				MarkdownDescription: "Level at which the IOC is created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description:         "Name of the source of the IOC (eg: a threat feed). [Default: Terraform]",
+				MarkdownDescription: "Name of the source of the IOC (eg: a threat feed). [Default: `Terraform`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("Terraform"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Type of the IOC (valid values: DNS, IPV4, IPV6, MD5, SHA1, SHA256, URL).",
+				MarkdownDescription: "Type of the IOC (valid values: `DNS`, `IPV4`, `IPV6`, `MD5`, `SHA1`, `SHA256`, " +
+					"`URL`).",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "DNS", "IPV4", "IPV6", "MD5", "SHA1", "SHA256", "URL"),
+				},
+			},
+			"valid_until": schema.StringAttribute{
+				Description: "RFC3339 timestamp after which the IOC expires. If it is not set, the server's default " +
+					"expiration is used. [Default: none]",
+				MarkdownDescription: "RFC3339 timestamp after which the IOC expires. If it is not set, the server's " +
+					"default expiration is used. [Default: none]",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description:         "Value of the IOC (eg: the hash, IP address, domain or URL).",
+				MarkdownDescription: "Value of the IOC (eg: the hash, IP address, domain or URL).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *IOC) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_IOC_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *IOC) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfIOC
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the IOC
+	ioc, diags := api.Client().CreateIOC(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the IOC to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfIOCFromAPI(ctx, ioc, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// If the IOC no longer exists or has expired, it is removed from the state so that it is created again.
+func (r *IOC) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfIOC
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the IOC
+	queryParams := api.IOCQueryParams{
+		UUIDs: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	iocs, diags := api.Client().FindIOCs(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(iocs) == 0 {
+		tflog.Debug(ctx, "IOC no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// the server may not have purged an expired IOC yet but it is no longer in effect either way
+	if validUntil, err := time.Parse(time.RFC3339Nano, iocs[0].ValidUntil); err == nil &&
+		validUntil.Before(time.Now()) {
+		tflog.Debug(ctx, "IOC has expired.", map[string]interface{}{
+			"id":          state.Id.ValueString(),
+			"valid_until": iocs[0].ValidUntil,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfIOCFromAPI(ctx, &iocs[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Every argument requires the IOC to be replaced so this only copies the plan into the state.
+func (r *IOC) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan tfIOC
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *IOC) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfIOC
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the IOC
+	resp.Diagnostics.Append(api.Client().DeleteIOC(ctx, scopeFromModel(state.ScopeType, state.ScopeId),
+		state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted IOC", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing IOC into the Terraform state.
+//
+// The API can only find an IOC within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<ioc_id>.
+func (r *IOC) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<ioc_id> where the scope "+
+			"type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_IOC_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating an IOC.
+func (r *IOC) bodyFromPlan(plan tfIOC) api.IOCBody {
+	body := api.IOCBody{
+		Source: plan.Source.ValueString(),
+		Type:   plan.Type.ValueString(),
+		Value:  plan.Value.ValueString(),
+	}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.ValidUntil.IsNull() && !plan.ValidUntil.IsUnknown() {
+		value := plan.ValidUntil.ValueString()
+		body.ValidUntil = &value
+	}
+	return body
+}
+
+// tfIOCFromAPI converts an API IOC into a Terraform IOC.
+//
+// The API does not return the scope of the IOC so it is copied from the given model. The configured expiration is
+// kept if it refers to the same time as the one returned by the API, which may format it differently.
+func tfIOCFromAPI(ctx context.Context, ioc *api.IOC, model tfIOC) tfIOC {
+	tfioc := tfIOC{
+		CreatedAt:   types.StringValue(ioc.CreationTime),
+		Description: types.StringNull(),
+		Id:          types.StringValue(ioc.UUID),
+		ScopeId:     model.ScopeId,
+		ScopeType:   model.ScopeType,
+		Source:      types.StringValue(ioc.Source),
+		Type:        types.StringValue(ioc.Type),
+		ValidUntil:  types.StringValue(ioc.ValidUntil),
+		Value:       types.StringValue(ioc.Value),
+	}
+	if ioc.Description != "" || !model.Description.IsNull() {
+		tfioc.Description = types.StringValue(ioc.Description)
+	}
+	if !model.ValidUntil.IsNull() && !model.ValidUntil.IsUnknown() &&
+		sameTimestamp(model.ValidUntil.ValueString(), ioc.ValidUntil) {
+		tfioc.ValidUntil = model.ValidUntil
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API IOC to TF IOC: %+v", tfioc), map[string]interface{}{
+		"api_ioc": ioc,
+	})
+	return tfioc
+}
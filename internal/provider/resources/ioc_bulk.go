@@ -0,0 +1,914 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource               = &IOCBulk{}
+	_ resource.ResourceWithConfigure  = &IOCBulk{}
+	_ resource.ResourceWithModifyPlan = &IOCBulk{}
+)
+
+// iocBulkColumns holds the columns (CSV) or keys (JSON) which may be used in an indicators file.
+var iocBulkColumns = []string{"description", "source", "type", "valid_until", "value"}
+
+// iocBulkTypes holds the types of indicators which may be used in an indicators file.
+var iocBulkTypes = []string{"DNS", "IPV4", "IPV6", "MD5", "SHA1", "SHA256", "URL"}
+
+// iocBulkSTIXComparison matches a single equality comparison within a STIX pattern.
+var iocBulkSTIXComparison = regexp.MustCompile(`([a-z0-9-]+):([A-Za-z0-9_.'-]+)\s*=\s*'((?:[^'\\]|\\.)*)'`)
+
+// iocBulkSTIXObservables maps the object paths which may be used in a STIX pattern to the type of the indicator.
+//
+// Quotes and dashes are removed from the path and it is converted to upper case before it is looked up.
+var iocBulkSTIXObservables = map[string]string{
+	"domain-name:VALUE":  "DNS",
+	"file:HASHES.MD5":    "MD5",
+	"file:HASHES.SHA1":   "SHA1",
+	"file:HASHES.SHA256": "SHA256",
+	"ipv4-addr:VALUE":    "IPV4",
+	"ipv6-addr:VALUE":    "IPV6",
+	"url:VALUE":          "URL",
+}
+
+// tfIOCBulk defines the Terraform model for a bulk set of indicators of compromise.
+type tfIOCBulk struct {
+	ChunkSize   types.Int64  `tfsdk:"chunk_size"`
+	FailedCount types.Int64  `tfsdk:"failed_count"`
+	FilePath    types.String `tfsdk:"file_path"`
+	FileSHA256  types.String `tfsdk:"file_sha256"`
+	Format      types.String `tfsdk:"format"`
+	Id          types.String `tfsdk:"id"`
+	IOCCount    types.Int64  `tfsdk:"ioc_count"`
+	IOCIds      types.Map    `tfsdk:"ioc_ids"`
+	Parallelism types.Int64  `tfsdk:"parallelism"`
+	ScopeId     types.String `tfsdk:"scope_id"`
+	ScopeType   types.String `tfsdk:"scope_type"`
+	Source      types.String `tfsdk:"source"`
+}
+
+// iocBulkEntry holds a single indicator read from an indicators file.
+type iocBulkEntry struct {
+	Description string `json:"description"`
+	Source      string `json:"source"`
+	Type        string `json:"type"`
+	ValidUntil  string `json:"valid_until"`
+	Value       string `json:"value"`
+}
+
+// key returns the key used to identify the entry in the ioc_ids map.
+func (e iocBulkEntry) key() string {
+	return fmt.Sprintf("%s/%s", e.Type, e.Value)
+}
+
+// NewIOCBulk creates a new IOCBulk object.
+func NewIOCBulk() resource.Resource {
+	return &IOCBulk{}
+}
+
+// IOCBulk is a resource used to manage the lifecycle of a large set of indicators of compromise loaded from a file.
+type IOCBulk struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *IOCBulk) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ioc_bulk"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *IOCBulk) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing a large set of threat intelligence indicators of compromise " +
+			"(IOCs) within an account or site from a STIX, CSV or JSON file.",
+		MarkdownDescription: `This resource is used for managing a large set of threat intelligence indicators of
+			compromise (IOCs) within an account or site from a STIX, CSV or JSON file.
+
+		A CSV file must start with a header row and a JSON file must contain an array of objects. The columns (or keys)
+		` + "`type`" + ` and ` + "`value`" + ` are required and ` + "`description`" + `, ` + "`source`" + ` and
+		` + "`valid_until`" + ` are optional, with the same meaning as the attributes of the ` + "`singularity_ioc`" + `
+		resource. A STIX file must contain a STIX 2.x bundle: every equality comparison of a domain name, file hash,
+		IP address or URL in the pattern of an indicator object is managed as its own IOC and indicators without a
+		supported comparison are skipped with a warning. Each IOC is identified by its type and value so only the first
+		of any duplicates is used. IOCs which have already expired are skipped with a warning.
+
+		Whenever the file changes, or an IOC managed by the resource has expired or been removed from the console, the
+		file is compared with the IOCs which exist in the scope: missing IOCs are created, IOCs no longer in the file
+		are deleted and IOCs whose description, source or expiration changed are replaced, since IOCs cannot be
+		updated. IOCs are created and deleted in chunks of ` + "`chunk_size`" + ` indicators so that each request
+		stays within the API's size limit, with up to ` + "`parallelism`" + ` chunks sent at a time. A chunk which
+		fails is reported as a warning rather than failing the apply and is retried on the next apply. IOCs which
+		exist in the scope but were not created by this resource are never changed. Destroying the resource deletes
+		every IOC it manages.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"chunk_size": schema.Int64Attribute{
+				Description:         "Maximum number of IOCs to create or delete with a single API call. [Default: 500]",
+				MarkdownDescription: "Maximum number of IOCs to create or delete with a single API call. [Default: `500`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(500),
+			},
+			"failed_count": schema.Int64Attribute{
+				Description:         "Number of IOCs which could not be created or deleted during the last apply.",
+				MarkdownDescription: "Number of IOCs which could not be created or deleted during the last apply.",
+				Computed:            true,
+			},
+			"file_path": schema.StringAttribute{
+				Description:         "Path to the local STIX, CSV or JSON file holding the IOCs.",
+				MarkdownDescription: "Path to the local STIX, CSV or JSON file holding the IOCs.",
+				Required:            true,
+			},
+			"file_sha256": schema.StringAttribute{
+				Description:         "SHA256 hash of the IOCs file which was last applied.",
+				MarkdownDescription: "SHA256 hash of the IOCs file which was last applied.",
+				Computed:            true,
+			},
+			"format": schema.StringAttribute{
+				Description: "Format of the IOCs file (valid values: csv, json, stix). [Default: based on the file's " +
+					"extension, with a JSON file holding a STIX bundle detected automatically]",
+				MarkdownDescription: "Format of the IOCs file (valid values: `csv`, `json`, `stix`). [Default: based " +
+					"on the file's extension, with a JSON file holding a STIX bundle detected automatically]",
+				Optional: true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(true, "csv", "json", "stix"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the set of IOCs.",
+				MarkdownDescription: "ID of the set of IOCs.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ioc_count": schema.Int64Attribute{
+				Description:         "Number of IOCs managed by the resource.",
+				MarkdownDescription: "Number of IOCs managed by the resource.",
+				Computed:            true,
+			},
+			"ioc_ids": schema.MapAttribute{
+				Description:         "Map of the key of each IOC (<type>/<value>) to the UUID of the IOC.",
+				MarkdownDescription: "Map of the key of each IOC (`<type>/<value>`) to the UUID of the IOC.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"parallelism": schema.Int64Attribute{
+				Description:         "Maximum number of chunks to send to the API at once. [Default: 5]",
+				MarkdownDescription: "Maximum number of chunks to send to the API at once. [Default: `5`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(5),
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the IOCs belong.",
+				MarkdownDescription: "ID of the account or site to which the IOCs belong.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the IOCs are created (valid values: account, site).",
+				MarkdownDescription: "Level at which the IOCs are created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description: "Name of the source of any IOC which does not set its own source in the file. " +
+					"[Default: Terraform]",
+				MarkdownDescription: "Name of the source of any IOC which does not set its own source in the file. " +
+					"[Default: `Terraform`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("Terraform"),
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *IOCBulk) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_IOC_BULK_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// ModifyPlan is called to modify the Terraform plan.
+func (r *IOCBulk) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+
+	// nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// we need to read the file here - otherwise if the file is changed, no changes will be detected
+	var plan tfIOCBulk
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.FilePath.IsNull() || plan.FilePath.IsUnknown() || plan.Format.IsUnknown() {
+		return
+	}
+	entries, sha256, diags := r.readFile(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("file_sha256"), types.StringValue(sha256))...)
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// the IOCs only need to be converged if the file or default source changed, a managed IOC was removed outside of
+	// Terraform or a chunk failed during the last apply - otherwise keep the IOCs from the state
+	var state tfIOCBulk
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stateIds := map[string]string{}
+	resp.Diagnostics.Append(state.IOCIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	changed := state.FileSHA256.ValueString() != sha256 || len(stateIds) != len(entries) ||
+		state.FailedCount.ValueInt64() > 0 || !plan.Source.Equal(state.Source)
+	for key := range entries {
+		if _, ok := stateIds[key]; !ok {
+			changed = true
+			break
+		}
+	}
+	if changed {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("failed_count"), types.Int64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("ioc_count"), types.Int64Unknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("ioc_ids"),
+			types.MapUnknown(types.StringType))...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("failed_count"), state.FailedCount)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("ioc_count"), state.IOCCount)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("ioc_ids"), state.IOCIds)...)
+}
+
+// Create is used to create the Terraform resource.
+//
+// Only the IOCs which were created successfully are saved to the state so that the rest are retried on the next
+// apply.
+func (r *IOCBulk) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfIOCBulk
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Id = types.StringValue(fmt.Sprintf("%s-%d", plan.ScopeId.ValueString(), time.Now().Unix()))
+
+	// create all of the IOCs
+	resp.Diagnostics.Append(r.converge(ctx, &plan, map[string]string{})...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// IOCs which no longer exist or have expired are removed from the state so that they are created again on the next
+// apply.
+func (r *IOCBulk) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfIOCBulk
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stateIds := map[string]string{}
+	resp.Diagnostics.Append(state.IOCIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find all of the IOCs within the scope with a single query
+	existing, diags := r.findIOCs(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for key, id := range stateIds {
+		if _, ok := existing[id]; !ok {
+			tflog.Debug(ctx, "IOC in bulk set no longer exists or has expired.", map[string]interface{}{
+				"id":  id,
+				"key": key,
+			})
+			delete(stateIds, key)
+		}
+	}
+
+	// save refreshed state
+	state.IOCIds, diags = types.MapValueFrom(ctx, types.StringType, stateIds)
+	resp.Diagnostics.Append(diags...)
+	state.IOCCount = types.Int64Value(int64(len(stateIds)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// If the plan already holds the IOCs from the state, only the settings used for applying changes were modified so
+// there is nothing to converge.
+func (r *IOCBulk) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfIOCBulk
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfIOCBulk
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Id = state.Id
+	if !plan.IOCIds.IsUnknown() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+	stateIds := map[string]string{}
+	resp.Diagnostics.Append(state.IOCIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// apply the changes, saving whatever succeeded even if something failed
+	resp.Diagnostics.Append(r.converge(ctx, &plan, stateIds)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *IOCBulk) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfIOCBulk
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stateIds := map[string]string{}
+	resp.Diagnostics.Append(state.IOCIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete all of the IOCs - if any chunk fails, keep the remaining IOCs in the state
+	keys := []string{}
+	for key := range stateIds {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	chunks, names := iocBulkChunks(keys, state.ChunkSize.ValueInt64())
+	scope := scopeFromModel(state.ScopeType, state.ScopeId)
+	var mutex sync.Mutex
+	resp.Diagnostics.Append(forEachParallel(state.Parallelism.ValueInt64(), names, "IOC deletion chunks",
+		func(name string) diag.Diagnostics {
+			return r.deleteChunk(ctx, scope, stateIds, chunks[name], &mutex)
+		})...)
+	if resp.Diagnostics.HasError() {
+		var diags diag.Diagnostics
+		state.IOCIds, diags = types.MapValueFrom(ctx, types.StringType, stateIds)
+		resp.Diagnostics.Append(diags...)
+		state.IOCCount = types.Int64Value(int64(len(stateIds)))
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
+
+// converge compares the IOCs in the file with the IOCs which exist within the scope and creates, replaces and
+// deletes IOCs so that they match the file.
+//
+// The given map of keys to IDs holds the IOCs currently managed by the resource. It is updated as changes are made
+// and saved to the plan once finished, even if some of the changes failed. Chunks which fail are reported as
+// warnings and counted in failed_count so that they are retried on the next apply.
+func (r *IOCBulk) converge(ctx context.Context, plan *tfIOCBulk, ids map[string]string) (diags diag.Diagnostics) {
+	failedCount := 0
+
+	// save the IOCs which are managed once finished to the plan
+	defer func() {
+		var d diag.Diagnostics
+		plan.IOCIds, d = types.MapValueFrom(ctx, types.StringType, ids)
+		diags.Append(d...)
+		plan.IOCCount = types.Int64Value(int64(len(ids)))
+		plan.FailedCount = types.Int64Value(int64(failedCount))
+		if plan.FileSHA256.IsUnknown() {
+			plan.FileSHA256 = types.StringValue("")
+		}
+	}()
+
+	// read the file and find the IOCs which currently exist
+	entries, sha256, d := r.readFile(ctx, *plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	existing, d := r.findIOCs(ctx, *plan)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// work out which IOCs need to be created, replaced or deleted
+	toCreate := []string{}
+	toReplace := []string{}
+	toDelete := []string{}
+	for key, entry := range entries {
+		id, ok := ids[key]
+		if !ok {
+			toCreate = append(toCreate, key)
+			continue
+		}
+		ioc, ok := existing[id]
+		if !ok {
+			delete(ids, key)
+			toCreate = append(toCreate, key)
+		} else if !iocBulkEntryMatches(entry, ioc, plan.Source.ValueString()) {
+			toReplace = append(toReplace, key)
+		}
+	}
+	for key := range ids {
+		if _, ok := entries[key]; !ok {
+			toDelete = append(toDelete, key)
+		}
+	}
+	sort.Strings(toCreate)
+	sort.Strings(toReplace)
+	sort.Strings(toDelete)
+	tflog.Info(ctx, "Converging bulk IOCs", map[string]interface{}{
+		"create":  len(toCreate),
+		"replace": len(toReplace),
+		"delete":  len(toDelete),
+	})
+
+	// delete the IOCs which are no longer in the file or need to be replaced - replaced IOCs are only created again
+	// once the old IOC has been deleted
+	var mutex sync.Mutex
+	scope := scopeFromModel(plan.ScopeType, plan.ScopeId)
+	chunkSize := plan.ChunkSize.ValueInt64()
+	chunks, names := iocBulkChunks(append(toDelete, toReplace...), chunkSize)
+	diags.Append(iocBulkRetryWarnings(forEachParallel(plan.Parallelism.ValueInt64(), names, "IOC deletion chunks",
+		func(name string) diag.Diagnostics {
+			d := r.deleteChunk(ctx, scope, ids, chunks[name], &mutex)
+			if d.HasError() {
+				mutex.Lock()
+				defer mutex.Unlock()
+				failedCount += len(chunks[name])
+			}
+			return d
+		}))...)
+	for _, key := range toReplace {
+		if _, ok := ids[key]; !ok {
+			toCreate = append(toCreate, key)
+		}
+	}
+	sort.Strings(toCreate)
+
+	// create the missing IOCs
+	chunks, names = iocBulkChunks(toCreate, chunkSize)
+	diags.Append(iocBulkRetryWarnings(forEachParallel(plan.Parallelism.ValueInt64(), names, "IOC creation chunks",
+		func(name string) diag.Diagnostics {
+			keys := chunks[name]
+			bodies := []api.IOCBody{}
+			for _, key := range keys {
+				bodies = append(bodies, iocBulkBody(entries[key], plan.Source.ValueString()))
+			}
+			iocs, d := api.Client().CreateIOCs(ctx, scope, bodies)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if d.HasError() {
+				failedCount += len(keys)
+				return d
+			}
+
+			// match the IOCs which were returned to the entries in the chunk
+			created := map[string]string{}
+			for _, ioc := range iocs {
+				created[strings.ToLower(fmt.Sprintf("%s/%s", ioc.Type, ioc.Value))] = ioc.UUID
+			}
+			missing := 0
+			for _, key := range keys {
+				if uuid, ok := created[strings.ToLower(key)]; ok {
+					ids[key] = uuid
+				} else {
+					missing++
+				}
+			}
+			if missing > 0 {
+				failedCount += missing
+				msg := fmt.Sprintf("The API did not return %d of the %d IOCs sent in the chunk. They will be "+
+					"created again on the next apply.\n\nChunk: %s", missing, len(keys), name)
+				tflog.Warn(ctx, msg, map[string]interface{}{
+					"chunk":   name,
+					"missing": missing,
+				})
+				d.AddWarning("IOC Chunk Incomplete", msg)
+			}
+			return d
+		}))...)
+
+	plan.FileSHA256 = types.StringValue(sha256)
+	return diags
+}
+
+// deleteChunk deletes the IOCs with the given keys with a single API call and removes them from the given map of
+// keys to IDs, which is guarded by the given mutex.
+func (r *IOCBulk) deleteChunk(ctx context.Context, scope api.Scope, ids map[string]string, keys []string,
+	mutex *sync.Mutex) diag.Diagnostics {
+
+	mutex.Lock()
+	uuids := []string{}
+	for _, key := range keys {
+		uuids = append(uuids, ids[key])
+	}
+	mutex.Unlock()
+
+	diags := api.Client().DeleteIOCs(ctx, scope, uuids)
+	if diags.HasError() {
+		return diags
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, key := range keys {
+		delete(ids, key)
+	}
+	return diags
+}
+
+// findIOCs returns all of the IOCs within the scope of the resource which have not expired keyed by UUID.
+func (r *IOCBulk) findIOCs(ctx context.Context, model tfIOCBulk) (map[string]api.IOC, diag.Diagnostics) {
+	queryParams := api.IOCQueryParams{}
+	if model.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{model.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{model.ScopeId.ValueString()}
+	}
+	iocs, diags := api.Client().FindIOCs(ctx, queryParams)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	// the server may not have purged an expired IOC yet but it is no longer in effect either way
+	now := time.Now()
+	existing := map[string]api.IOC{}
+	for _, ioc := range iocs {
+		if validUntil, err := time.Parse(time.RFC3339Nano, ioc.ValidUntil); err == nil && validUntil.Before(now) {
+			continue
+		}
+		existing[ioc.UUID] = ioc
+	}
+	return existing, diags
+}
+
+// readFile reads and parses the IOCs file and returns its entries keyed by the key of each entry along with the
+// SHA256 hash of the file.
+func (r *IOCBulk) readFile(ctx context.Context, model tfIOCBulk) (map[string]iocBulkEntry, string,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	// convert the path to an absolute path
+	absPath, diags := plugin.ToAbsolutePath(ctx, model.FilePath.ValueString())
+	if diags.HasError() {
+		return nil, "", diags
+	}
+	ctx = tflog.SetField(ctx, "file", absPath)
+	addError := func(msg string, err error) (map[string]iocBulkEntry, string, diag.Diagnostics) {
+		if err != nil {
+			msg = fmt.Sprintf("%s\n\nError: %s\nFile: %s", msg, err.Error(), absPath)
+		} else {
+			msg = fmt.Sprintf("%s\n\nFile: %s", msg, absPath)
+		}
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_IOC_BULK_PARSE_FILE,
+		})
+		diags.AddAttributeError(tfpath.Root("file_path"), "Invalid IOCs File", msg)
+		return nil, "", diags
+	}
+
+	// read the file
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		return addError("An unexpected error occurred while reading the IOCs file.", err)
+	}
+	format := strings.ToLower(model.Format.ValueString())
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(absPath)), ".")
+		if format == "json" && bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")) {
+			format = "stix"
+		}
+	}
+
+	// parse the entries
+	var entries []iocBulkEntry
+	switch format {
+	case "csv":
+		reader := csv.NewReader(bytes.NewReader(content))
+		reader.TrimLeadingSpace = true
+		header, err := reader.Read()
+		if err != nil {
+			return addError("The header row of the IOCs file could not be read.", err)
+		}
+		columns := map[int]string{}
+		for i, column := range header {
+			column = strings.ToLower(strings.TrimSpace(column))
+			if !iocBulkColumn(column) {
+				return addError(fmt.Sprintf("The IOCs file contains an unknown column: %s (valid columns: %s).",
+					column, strings.Join(iocBulkColumns, ", ")), nil)
+			}
+			columns[i] = column
+		}
+		for {
+			record, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return addError("A row of the IOCs file could not be read.", err)
+			}
+			values := map[string]string{}
+			for i, value := range record {
+				values[columns[i]] = strings.TrimSpace(value)
+			}
+			entries = append(entries, iocBulkEntry{
+				Description: values["description"],
+				Source:      values["source"],
+				Type:        values["type"],
+				ValidUntil:  values["valid_until"],
+				Value:       values["value"],
+			})
+		}
+	case "json":
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entries); err != nil {
+			return addError("The IOCs file is not a JSON array of IOC objects.", err)
+		}
+	case "stix":
+		var unsupported int
+		entries, unsupported, err = iocBulkEntriesFromSTIX(content)
+		if err != nil {
+			return addError("The IOCs file is not a STIX 2.x bundle.", err)
+		}
+		if unsupported > 0 {
+			msg := fmt.Sprintf("%d indicators in the STIX bundle do not contain a supported pattern and were "+
+				"skipped.\n\nFile: %s", unsupported, absPath)
+			tflog.Warn(ctx, msg, map[string]interface{}{
+				"unsupported": unsupported,
+			})
+			diags.AddAttributeWarning(tfpath.Root("file_path"), "Unsupported STIX Indicators", msg)
+		}
+	default:
+		return addError("The format of the IOCs file could not be determined from its extension. Set the format "+
+			"attribute to csv, json or stix.", nil)
+	}
+
+	// make sure every entry is complete, skipping duplicates and expired entries
+	now := time.Now()
+	expired := 0
+	keyed := map[string]iocBulkEntry{}
+	for i, entry := range entries {
+		entry.Type = strings.ToUpper(entry.Type)
+		if entry.Type == "" || entry.Value == "" {
+			return addError(fmt.Sprintf("IOC #%d in the IOCs file is missing its type or value.", i+1), nil)
+		}
+		if !iocBulkType(entry.Type) {
+			return addError(fmt.Sprintf("IOC #%d in the IOCs file has an unknown type: %s (valid types: %s).", i+1,
+				entry.Type, strings.Join(iocBulkTypes, ", ")), nil)
+		}
+		if entry.ValidUntil != "" {
+			validUntil, err := time.Parse(time.RFC3339Nano, entry.ValidUntil)
+			if err != nil {
+				return addError(fmt.Sprintf("IOC #%d in the IOCs file has an invalid valid_until timestamp.", i+1),
+					err)
+			}
+			if validUntil.Before(now) {
+				expired++
+				continue
+			}
+		}
+		if _, ok := keyed[entry.key()]; ok {
+			tflog.Debug(ctx, "Skipping duplicate IOC in IOCs file.", map[string]interface{}{
+				"index": i + 1,
+				"key":   entry.key(),
+			})
+			continue
+		}
+		keyed[entry.key()] = entry
+	}
+	if expired > 0 {
+		msg := fmt.Sprintf("%d IOCs in the IOCs file have already expired and were skipped.\n\nFile: %s", expired,
+			absPath)
+		tflog.Warn(ctx, msg, map[string]interface{}{
+			"expired": expired,
+		})
+		diags.AddAttributeWarning(tfpath.Root("file_path"), "Expired IOCs", msg)
+	}
+	return keyed, fmt.Sprintf("%x", sha256.Sum256(content)), diags
+}
+
+// iocBulkBody converts an entry from an IOCs file into the API request body for creating an IOC, using the given
+// source if the entry does not have its own.
+func iocBulkBody(entry iocBulkEntry, source string) api.IOCBody {
+	body := api.IOCBody{
+		Source: source,
+		Type:   entry.Type,
+		Value:  entry.Value,
+	}
+	if entry.Source != "" {
+		body.Source = entry.Source
+	}
+	if entry.Description != "" {
+		body.Description = &entry.Description
+	}
+	if entry.ValidUntil != "" {
+		body.ValidUntil = &entry.ValidUntil
+	}
+	return body
+}
+
+// iocBulkChunks splits the given keys into chunks of at most the given size and returns the chunks keyed by name
+// along with the names of the chunks in order.
+func iocBulkChunks(keys []string, size int64) (map[string][]string, []string) {
+	if size < 1 {
+		size = 1
+	}
+	chunks := map[string][]string{}
+	names := []string{}
+	for start := 0; start < len(keys); start += int(size) {
+		end := start + int(size)
+		if end > len(keys) {
+			end = len(keys)
+		}
+		name := fmt.Sprintf("%d-%d", start+1, end)
+		chunks[name] = keys[start:end]
+		names = append(names, name)
+	}
+	return chunks, names
+}
+
+// iocBulkRetryWarnings converts the errors returned while applying chunks of IOCs into warnings so that the changes
+// which succeeded are still saved to the state and the IOCs which failed are retried on the next apply.
+func iocBulkRetryWarnings(diags diag.Diagnostics) diag.Diagnostics {
+	var warnings diag.Diagnostics
+	for _, d := range diags {
+		if d.Severity() != diag.SeverityError {
+			warnings.Append(d)
+			continue
+		}
+		warnings.AddWarning(d.Summary(), fmt.Sprintf("%s\n\nThe affected IOCs will be retried on the next apply.",
+			d.Detail()))
+	}
+	return warnings
+}
+
+// iocBulkColumn determines whether or not the given column may be used in an IOCs file.
+func iocBulkColumn(column string) bool {
+	for _, c := range iocBulkColumns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// iocBulkEntriesFromSTIX parses a STIX 2.x bundle and returns an entry for every supported comparison in the
+// patterns of its indicators along with the number of indicators which did not contain a supported comparison.
+func iocBulkEntriesFromSTIX(content []byte) ([]iocBulkEntry, int, error) {
+	var bundle struct {
+		Objects []struct {
+			Description string `json:"description"`
+			Pattern     string `json:"pattern"`
+			PatternType string `json:"pattern_type"`
+			Type        string `json:"type"`
+			ValidUntil  string `json:"valid_until"`
+		} `json:"objects"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(content, &bundle); err != nil {
+		return nil, 0, err
+	}
+	if bundle.Type != "bundle" {
+		return nil, 0, fmt.Errorf("expected an object of type bundle but found: %s", bundle.Type)
+	}
+
+	entries := []iocBulkEntry{}
+	unsupported := 0
+	unescape := strings.NewReplacer(`\'`, `'`, `\\`, `\`)
+	normalize := strings.NewReplacer("'", "", "-", "")
+	for _, object := range bundle.Objects {
+		if object.Type != "indicator" {
+			continue
+		}
+		found := false
+		if object.PatternType == "" || object.PatternType == "stix" {
+			for _, match := range iocBulkSTIXComparison.FindAllStringSubmatch(object.Pattern, -1) {
+				iocType, ok := iocBulkSTIXObservables[match[1]+":"+strings.ToUpper(normalize.Replace(match[2]))]
+				if !ok {
+					continue
+				}
+				found = true
+				entries = append(entries, iocBulkEntry{
+					Description: object.Description,
+					Type:        iocType,
+					ValidUntil:  object.ValidUntil,
+					Value:       unescape.Replace(match[3]),
+				})
+			}
+		}
+		if !found {
+			unsupported++
+		}
+	}
+	return entries, unsupported, nil
+}
+
+// iocBulkEntryMatches determines whether or not the IOC in the console matches the entry from the IOCs file using
+// the given source if the entry does not have its own. The expiration is only compared if it is set in the file.
+func iocBulkEntryMatches(entry iocBulkEntry, ioc api.IOC, source string) bool {
+	if entry.Source != "" {
+		source = entry.Source
+	}
+	if entry.Description != ioc.Description || source != ioc.Source {
+		return false
+	}
+	if entry.ValidUntil != "" {
+		expected, err := time.Parse(time.RFC3339Nano, entry.ValidUntil)
+		if err != nil {
+			return false
+		}
+		actual, err := time.Parse(time.RFC3339Nano, ioc.ValidUntil)
+		if err != nil || !expected.Equal(actual) {
+			return false
+		}
+	}
+	return true
+}
+
+// iocBulkType determines whether or not the given type may be used in an IOCs file.
+func iocBulkType(iocType string) bool {
+	for _, t := range iocBulkTypes {
+		if t == iocType {
+			return true
+		}
+	}
+	return false
+}
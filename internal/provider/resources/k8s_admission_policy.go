@@ -0,0 +1,474 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &K8sAdmissionPolicy{}
+	_ resource.ResourceWithConfigure   = &K8sAdmissionPolicy{}
+	_ resource.ResourceWithImportState = &K8sAdmissionPolicy{}
+)
+
+// tfK8sAdmissionPolicy defines the Terraform model for a Kubernetes admission policy.
+type tfK8sAdmissionPolicy struct {
+	Action             types.String `tfsdk:"action"`
+	AllowedImages      types.Set    `tfsdk:"allowed_images"`
+	BlockedVerbs       types.List   `tfsdk:"blocked_verbs"`
+	ClusterNames       types.Set    `tfsdk:"cluster_names"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	Description        types.String `tfsdk:"description"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	ExcludedNamespaces types.Set    `tfsdk:"excluded_namespaces"`
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Namespaces         types.Set    `tfsdk:"namespaces"`
+	ScopeId            types.String `tfsdk:"scope_id"`
+	ScopeType          types.String `tfsdk:"scope_type"`
+	UpdatedAt          types.String `tfsdk:"updated_at"`
+}
+
+// NewK8sAdmissionPolicy creates a new K8sAdmissionPolicy object.
+func NewK8sAdmissionPolicy() resource.Resource {
+	return &K8sAdmissionPolicy{}
+}
+
+// K8sAdmissionPolicy is a resource used to manage a Kubernetes admission and runtime policy.
+type K8sAdmissionPolicy struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *K8sAdmissionPolicy) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_admission_policy"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *K8sAdmissionPolicy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	verbs := []string{"attach", "create", "delete", "exec", "patch", "port-forward", "proxy", "update"}
+
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing a Kubernetes admission and runtime policy enforced by the " +
+			"CWPP agent within an account or site.",
+		MarkdownDescription: `This resource is used for managing a Kubernetes admission and runtime policy enforced by
+			the CWPP agent within an account or site.
+
+		A policy restricts the requests made to the Kubernetes API of the protected clusters: requests using any of
+		the blocked verbs are denied (or alerted on) and, if any allowed images are given, workloads using an image
+		which does not match one of them are rejected. The policy applies to the given clusters and namespaces, or to
+		every cluster and namespace within its scope if none are given. Existing policies can be imported using an ID
+		in the format ` + "`<scope_type>/<scope_id>/<policy_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"action": schema.StringAttribute{
+				Description: "Action taken when a request violates the policy (valid values: alert, block). " +
+					"[Default: block]",
+				MarkdownDescription: "Action taken when a request violates the policy (valid values: `alert`, " +
+					"`block`). [Default: `block`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(api.K8S_ADMISSION_ACTION_BLOCK),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.K8S_ADMISSION_ACTION_ALERT,
+						api.K8S_ADMISSION_ACTION_BLOCK),
+				},
+			},
+			"allowed_images": schema.SetAttribute{
+				Description: "Images which workloads are allowed to use. Wildcards are supported (eg: " +
+					"registry.example.com/*). If not given, any image is allowed.",
+				MarkdownDescription: "Images which workloads are allowed to use. Wildcards are supported (eg: " +
+					"`registry.example.com/*`). If not given, any image is allowed.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"blocked_verbs": schema.ListAttribute{
+				Description: fmt.Sprintf("Kubernetes API verbs which are blocked (valid values: %s).",
+					strings.Join(verbs, ", ")),
+				MarkdownDescription: fmt.Sprintf("Kubernetes API verbs which are blocked (valid values: `%s`).",
+					strings.Join(verbs, "`, `")),
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, verbs...),
+				},
+			},
+			"cluster_names": schema.SetAttribute{
+				Description:         "Names of the clusters to which the policy applies. If not given, it applies to all.",
+				MarkdownDescription: "Names of the clusters to which the policy applies. If not given, it applies to all.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the policy was created.",
+				MarkdownDescription: "Timestamp of when the policy was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the policy.",
+				MarkdownDescription: "Description of the policy.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not the policy is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not the policy is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"excluded_namespaces": schema.SetAttribute{
+				Description:         "Namespaces to which the policy does not apply (eg: kube-system).",
+				MarkdownDescription: "Namespaces to which the policy does not apply (eg: `kube-system`).",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the policy.",
+				MarkdownDescription: "ID of the policy.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the policy.",
+				MarkdownDescription: "Name of the policy.",
+				Required:            true,
+			},
+			"namespaces": schema.SetAttribute{
+				Description: "Namespaces to which the policy applies. If not given, it applies to all namespaces " +
+					"except the excluded ones.",
+				MarkdownDescription: "Namespaces to which the policy applies. If not given, it applies to all " +
+					"namespaces except the excluded ones.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the policy belongs.",
+				MarkdownDescription: "ID of the account or site to which the policy belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level to which the policy belongs (valid values: account, site).",
+				MarkdownDescription: "Level to which the policy belongs (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the policy was last updated.",
+				MarkdownDescription: "Timestamp of when the policy was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *K8sAdmissionPolicy) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_ADMISSION_POLICY_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *K8sAdmissionPolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfK8sAdmissionPolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the policy
+	policy, diags := api.Client().CreateK8sAdmissionPolicy(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the policy to the state
+	tfpolicy, diags := tfK8sAdmissionPolicyFromAPI(ctx, policy, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *K8sAdmissionPolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfK8sAdmissionPolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the policy - if it no longer exists, remove it from the state
+	queryParams := api.K8sAdmissionPolicyQueryParams{
+		PolicyIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	policies, diags := api.Client().FindK8sAdmissionPolicies(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(policies) == 0 {
+		tflog.Debug(ctx, "Kubernetes admission policy no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	tfpolicy, diags := tfK8sAdmissionPolicyFromAPI(ctx, &policies[0], state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *K8sAdmissionPolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfK8sAdmissionPolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfK8sAdmissionPolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the policy
+	policy, diags := api.Client().UpdateK8sAdmissionPolicy(ctx, state.Id.ValueString(), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated policy to the state
+	tfpolicy, diags := tfK8sAdmissionPolicyFromAPI(ctx, policy, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *K8sAdmissionPolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfK8sAdmissionPolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the policy
+	resp.Diagnostics.Append(api.Client().DeleteK8sAdmissionPolicy(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted Kubernetes admission policy", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing Kubernetes admission policy into the Terraform state.
+//
+// The API can only find a policy within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<policy_id>.
+func (r *K8sAdmissionPolicy) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<policy_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_ADMISSION_POLICY_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a policy.
+func (r *K8sAdmissionPolicy) bodyFromPlan(ctx context.Context, plan tfK8sAdmissionPolicy) (
+	api.K8sAdmissionPolicyBody, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	action := plan.Action.ValueString()
+	description := plan.Description.ValueString()
+	enabled := plan.Enabled.ValueBool()
+	name := plan.Name.ValueString()
+	body := api.K8sAdmissionPolicyBody{
+		Action:             &action,
+		AllowedImages:      []string{},
+		BlockedVerbs:       []string{},
+		ClusterNames:       []string{},
+		Description:        &description,
+		Enabled:            &enabled,
+		ExcludedNamespaces: []string{},
+		Name:               &name,
+		Namespaces:         []string{},
+	}
+	if !plan.AllowedImages.IsNull() && !plan.AllowedImages.IsUnknown() {
+		diags.Append(plan.AllowedImages.ElementsAs(ctx, &body.AllowedImages, false)...)
+	}
+	if !plan.BlockedVerbs.IsNull() && !plan.BlockedVerbs.IsUnknown() {
+		diags.Append(plan.BlockedVerbs.ElementsAs(ctx, &body.BlockedVerbs, false)...)
+	}
+	if !plan.ClusterNames.IsNull() && !plan.ClusterNames.IsUnknown() {
+		diags.Append(plan.ClusterNames.ElementsAs(ctx, &body.ClusterNames, false)...)
+	}
+	if !plan.ExcludedNamespaces.IsNull() && !plan.ExcludedNamespaces.IsUnknown() {
+		diags.Append(plan.ExcludedNamespaces.ElementsAs(ctx, &body.ExcludedNamespaces, false)...)
+	}
+	if !plan.Namespaces.IsNull() && !plan.Namespaces.IsUnknown() {
+		diags.Append(plan.Namespaces.ElementsAs(ctx, &body.Namespaces, false)...)
+	}
+	return body, diags
+}
+
+// tfK8sAdmissionPolicyFromAPI converts an API Kubernetes admission policy into a Terraform Kubernetes admission
+// policy.
+//
+// The API does not return the scope of the policy so it is copied from the given model. Empty lists returned by the
+// API are converted to null values unless they were explicitly set to empty lists in the model.
+func tfK8sAdmissionPolicyFromAPI(ctx context.Context, policy *api.K8sAdmissionPolicy, model tfK8sAdmissionPolicy) (
+	tfK8sAdmissionPolicy, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	tfpolicy := tfK8sAdmissionPolicy{
+		Action:       types.StringValue(policy.Action),
+		BlockedVerbs: types.ListNull(types.StringType),
+		CreatedAt:    types.StringValue(policy.CreatedAt),
+		Description:  types.StringValue(policy.Description),
+		Enabled:      types.BoolValue(policy.Enabled),
+		Id:           types.StringValue(policy.Id),
+		Name:         types.StringValue(policy.Name),
+		ScopeId:      model.ScopeId,
+		ScopeType:    model.ScopeType,
+		UpdatedAt:    types.StringValue(policy.UpdatedAt),
+	}
+	if len(policy.BlockedVerbs) > 0 || (!model.BlockedVerbs.IsNull() && !model.BlockedVerbs.IsUnknown()) {
+		verbs := policy.BlockedVerbs
+		if verbs == nil {
+			verbs = []string{}
+		}
+		tfpolicy.BlockedVerbs, diags = types.ListValueFrom(ctx, types.StringType, verbs)
+		if diags.HasError() {
+			return tfpolicy, diags
+		}
+	}
+	for _, set := range []struct {
+		values []string
+		model  types.Set
+		target *types.Set
+	}{
+		{policy.AllowedImages, model.AllowedImages, &tfpolicy.AllowedImages},
+		{policy.ClusterNames, model.ClusterNames, &tfpolicy.ClusterNames},
+		{policy.ExcludedNamespaces, model.ExcludedNamespaces, &tfpolicy.ExcludedNamespaces},
+		{policy.Namespaces, model.Namespaces, &tfpolicy.Namespaces},
+	} {
+		*set.target = types.SetNull(types.StringType)
+		if len(set.values) == 0 && (set.model.IsNull() || set.model.IsUnknown()) {
+			continue
+		}
+		values := set.values
+		if values == nil {
+			values = []string{}
+		}
+		*set.target, diags = types.SetValueFrom(ctx, types.StringType, values)
+		if diags.HasError() {
+			return tfpolicy, diags
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API Kubernetes admission policy to TF Kubernetes admission policy: %+v",
+		tfpolicy), map[string]interface{}{
+		"api_k8s_admission_policy": policy,
+	})
+	return tfpolicy, diags
+}
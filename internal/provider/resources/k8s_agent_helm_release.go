@@ -0,0 +1,642 @@
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &K8sAgentHelmRelease{}
+	_ resource.ResourceWithConfigure = &K8sAgentHelmRelease{}
+)
+
+// tfK8sAgentHelmRelease defines the Terraform model for a Helm release of the Singularity agent for Kubernetes.
+type tfK8sAgentHelmRelease struct {
+	AgentImage      types.String `tfsdk:"agent_image"`
+	AppVersion      types.String `tfsdk:"app_version"`
+	Chart           types.String `tfsdk:"chart"`
+	ChartRepository types.String `tfsdk:"chart_repository"`
+	ChartVersion    types.String `tfsdk:"chart_version"`
+	CreateNamespace types.Bool   `tfsdk:"create_namespace"`
+	HelmBinary      types.String `tfsdk:"helm_binary"`
+	HelperImage     types.String `tfsdk:"helper_image"`
+	Id              types.String `tfsdk:"id"`
+	ImagePullSecret types.String `tfsdk:"image_pull_secret"`
+	KubeConfig      types.String `tfsdk:"kubeconfig"`
+	KubeContext     types.String `tfsdk:"kube_context"`
+	Name            types.String `tfsdk:"name"`
+	Namespace       types.String `tfsdk:"namespace"`
+	Proxy           types.String `tfsdk:"proxy"`
+	Revision        types.Int64  `tfsdk:"revision"`
+	SiteToken       types.String `tfsdk:"site_token"`
+	Status          types.String `tfsdk:"status"`
+	Timeout         types.String `tfsdk:"timeout"`
+	Values          types.Map    `tfsdk:"values"`
+	ValuesYAML      types.String `tfsdk:"values_yaml"`
+	Wait            types.Bool   `tfsdk:"wait"`
+}
+
+// helmRelease holds the details of a release returned by the Helm CLI.
+type helmRelease struct {
+	Chart struct {
+		Metadata struct {
+			AppVersion string `json:"appVersion"`
+			Version    string `json:"version"`
+		} `json:"metadata"`
+	} `json:"chart"`
+	Info struct {
+		Status string `json:"status"`
+	} `json:"info"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int64  `json:"version"`
+}
+
+// NewK8sAgentHelmRelease creates a new K8sAgentHelmRelease object.
+func NewK8sAgentHelmRelease() resource.Resource {
+	return &K8sAgentHelmRelease{}
+}
+
+// K8sAgentHelmRelease is a resource used to deploy the Singularity agent for Kubernetes to a cluster using its Helm
+// chart.
+type K8sAgentHelmRelease struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *K8sAgentHelmRelease) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_agent_helm_release"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *K8sAgentHelmRelease) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for deploying the Singularity Agent for Kubernetes to a cluster by " +
+			"installing or upgrading a release of its Helm chart.",
+		MarkdownDescription: `This resource is used for deploying the Singularity Agent for Kubernetes to a cluster by
+			installing or upgrading a release of its Helm chart.
+
+		The release is managed with the Helm CLI, which must be installed on the machine running Terraform. The agent
+		and helper images are typically wired from the ` + "`repo_tags`" + ` of the images loaded by the
+		` + "`singularity_k8s_agent_package_loader`" + ` resource once they have been pushed to the cluster's
+		registry, so that downloading, loading, pushing and deploying the agent happens within a single Terraform
+		graph. Destroying the resource uninstalls the release.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agent_image": schema.StringAttribute{
+				Description: "Full reference of the agent image to deploy (eg: " +
+					"ghcr.io/example/cwpp_agent/s1agent:23.3.2). If not set, the chart's default is used.",
+				MarkdownDescription: "Full reference of the agent image to deploy (eg: " +
+					"`ghcr.io/example/cwpp_agent/s1agent:23.3.2`). If not set, the chart's default is used.",
+				Optional: true,
+			},
+			"app_version": schema.StringAttribute{
+				Description:         "Version of the agent deployed by the release.",
+				MarkdownDescription: "Version of the agent deployed by the release.",
+				Computed:            true,
+			},
+			"chart": schema.StringAttribute{
+				Description:         "Name of the chart to deploy. [Default: s1-agent]",
+				MarkdownDescription: "Name of the chart to deploy. [Default: `s1-agent`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("s1-agent"),
+			},
+			"chart_repository": schema.StringAttribute{
+				Description: "URL of the repository from which the chart is installed. [Default: " +
+					"https://charts.sentinelone.com]",
+				MarkdownDescription: "URL of the repository from which the chart is installed. [Default: " +
+					"`https://charts.sentinelone.com`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("https://charts.sentinelone.com"),
+			},
+			"chart_version": schema.StringAttribute{
+				Description: "Version of the chart to deploy. If not set, the latest version is deployed when the " +
+					"release is installed or upgraded.",
+				MarkdownDescription: "Version of the chart to deploy. If not set, the latest version is deployed when " +
+					"the release is installed or upgraded.",
+				Optional: true,
+				Computed: true,
+			},
+			"create_namespace": schema.BoolAttribute{
+				Description:         "Whether or not to create the namespace if it does not exist. [Default: true]",
+				MarkdownDescription: "Whether or not to create the namespace if it does not exist. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"helm_binary": schema.StringAttribute{
+				Description:         "Name or path of the Helm CLI binary. [Default: helm]",
+				MarkdownDescription: "Name or path of the Helm CLI binary. [Default: `helm`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("helm"),
+			},
+			"helper_image": schema.StringAttribute{
+				Description: "Full reference of the helper image to deploy (eg: " +
+					"ghcr.io/example/cwpp_agent/s1helper:23.3.2). If not set, the chart's default is used.",
+				MarkdownDescription: "Full reference of the helper image to deploy (eg: " +
+					"`ghcr.io/example/cwpp_agent/s1helper:23.3.2`). If not set, the chart's default is used.",
+				Optional: true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the release (in the format <namespace>/<name>).",
+				MarkdownDescription: "ID of the release (in the format `<namespace>/<name>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"image_pull_secret": schema.StringAttribute{
+				Description:         "Name of the Kubernetes secret used to pull the images from a private registry.",
+				MarkdownDescription: "Name of the Kubernetes secret used to pull the images from a private registry.",
+				Optional:            true,
+			},
+			"kubeconfig": schema.StringAttribute{
+				Description: "Path to the kubeconfig file used to connect to the cluster. If not set, Helm's default " +
+					"is used.",
+				MarkdownDescription: "Path to the kubeconfig file used to connect to the cluster. If not set, Helm's " +
+					"default is used.",
+				Optional: true,
+			},
+			"kube_context": schema.StringAttribute{
+				Description: "Name of the kubeconfig context used to connect to the cluster. If not set, the current " +
+					"context is used.",
+				MarkdownDescription: "Name of the kubeconfig context used to connect to the cluster. If not set, the " +
+					"current context is used.",
+				Optional: true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the release. [Default: s1-agent]",
+				MarkdownDescription: "Name of the release. [Default: `s1-agent`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("s1-agent"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Description:         "Namespace in which the release is installed. [Default: sentinelone]",
+				MarkdownDescription: "Namespace in which the release is installed. [Default: `sentinelone`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("sentinelone"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"proxy": schema.StringAttribute{
+				Description:         "URL of the proxy through which the agents connect to the management console.",
+				MarkdownDescription: "URL of the proxy through which the agents connect to the management console.",
+				Optional:            true,
+			},
+			"revision": schema.Int64Attribute{
+				Description:         "Current revision of the release.",
+				MarkdownDescription: "Current revision of the release.",
+				Computed:            true,
+			},
+			"site_token": schema.StringAttribute{
+				Description:         "Registration token of the site to which the agents belong.",
+				MarkdownDescription: "Registration token of the site to which the agents belong.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"status": schema.StringAttribute{
+				Description:         "Current status of the release (eg: deployed).",
+				MarkdownDescription: "Current status of the release (eg: `deployed`).",
+				Computed:            true,
+			},
+			"timeout": schema.StringAttribute{
+				Description:         "How long to wait for each Helm operation to complete. [Default: 10m]",
+				MarkdownDescription: "How long to wait for each Helm operation to complete. [Default: `10m`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10m"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"values": schema.MapAttribute{
+				Description: "Individual chart values to override using their dotted path (eg: " +
+					"configuration.cluster.name). These take precedence over every other value.",
+				MarkdownDescription: "Individual chart values to override using their dotted path (eg: " +
+					"`configuration.cluster.name`). These take precedence over every other value.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"values_yaml": schema.StringAttribute{
+				Description: "Contents of a YAML values file to apply to the chart. Values set by the other " +
+					"attributes take precedence over it.",
+				MarkdownDescription: "Contents of a YAML values file to apply to the chart. Values set by the other " +
+					"attributes take precedence over it.",
+				Optional: true,
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Whether or not to wait for every agent pod to be ready before the release is " +
+					"considered deployed. [Default: true]",
+				MarkdownDescription: "Whether or not to wait for every agent pod to be ready before the release is " +
+					"considered deployed. [Default: `true`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *K8sAgentHelmRelease) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *K8sAgentHelmRelease) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfK8sAgentHelmRelease
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// install the release and save it to the state
+	resp.Diagnostics.Append(r.upgrade(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *K8sAgentHelmRelease) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfK8sAgentHelmRelease
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, state.SiteToken.ValueString())
+
+	// get the status of the release - if it no longer exists, remove it from the state
+	output, stderr, err := r.runHelm(ctx, state, "status", state.Name.ValueString(), "--namespace",
+		state.Namespace.ValueString(), "--output", "json")
+	if err != nil && strings.Contains(stderr, "release: not found") {
+		tflog.Debug(ctx, "Helm release no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(helmErrorDiags(ctx, "status", err, stderr)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(applyHelmRelease(ctx, output, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *K8sAgentHelmRelease) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfK8sAgentHelmRelease
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// upgrade the release and save it to the state
+	resp.Diagnostics.Append(r.upgrade(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *K8sAgentHelmRelease) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfK8sAgentHelmRelease
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, state.SiteToken.ValueString())
+
+	// uninstall the release - one which no longer exists is already removed
+	args := []string{"uninstall", state.Name.ValueString(), "--namespace", state.Namespace.ValueString()}
+	waitArgs, diags := helmWaitArgs(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	_, stderr, err := r.runHelm(ctx, state, append(args, waitArgs...)...)
+	if err != nil && strings.Contains(stderr, "not found") {
+		return
+	}
+	resp.Diagnostics.Append(helmErrorDiags(ctx, "uninstall", err, stderr)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Uninstalled Helm release", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// upgrade installs or upgrades the release in the given model and refreshes the model's computed attributes.
+func (r *K8sAgentHelmRelease) upgrade(ctx context.Context, model *tfK8sAgentHelmRelease) diag.Diagnostics {
+	ctx = plugin.MaskSecrets(ctx, model.SiteToken.ValueString())
+
+	tempDir, diags := plugin.NewTempDir(ctx, "helm-values", "", "")
+	if diags.HasError() {
+		return diags
+	}
+	defer tempDir.Cleanup(ctx)
+
+	args := []string{"upgrade", model.Name.ValueString(), model.Chart.ValueString(), "--install",
+		"--repo", model.ChartRepository.ValueString(), "--namespace", model.Namespace.ValueString(),
+		"--output", "json"}
+	if model.CreateNamespace.ValueBool() {
+		args = append(args, "--create-namespace")
+	}
+	if !model.ChartVersion.IsNull() && !model.ChartVersion.IsUnknown() && model.ChartVersion.ValueString() != "" {
+		args = append(args, "--version", model.ChartVersion.ValueString())
+	}
+	waitArgs, diags := helmWaitArgs(ctx, *model)
+	if diags.HasError() {
+		return diags
+	}
+	args = append(args, waitArgs...)
+
+	valuesArgs, diags := helmValuesArgs(ctx, tempDir, model.ValuesYAML, helmValues(model.AgentImage,
+		model.HelperImage, model.ImagePullSecret, model.Proxy, model.SiteToken), model.Values)
+	if diags.HasError() {
+		return diags
+	}
+	args = append(args, valuesArgs...)
+
+	// install or upgrade the release
+	output, stderr, err := r.runHelm(ctx, *model, args...)
+	diags.Append(helmErrorDiags(ctx, "upgrade", err, stderr)...)
+	if diags.HasError() {
+		return diags
+	}
+	diags.Append(applyHelmRelease(ctx, output, model)...)
+	if diags.HasError() {
+		return diags
+	}
+	tflog.Debug(ctx, "Installed or upgraded Helm release", map[string]interface{}{
+		"id":       model.Id.ValueString(),
+		"revision": model.Revision.ValueInt64(),
+	})
+	return diags
+}
+
+// runHelm runs the Helm CLI with the given arguments along with the cluster connection arguments of the given model
+// and returns its standard output and error.
+func (r *K8sAgentHelmRelease) runHelm(ctx context.Context, model tfK8sAgentHelmRelease, args ...string) ([]byte,
+	string, error) {
+
+	if !model.KubeConfig.IsNull() && model.KubeConfig.ValueString() != "" {
+		args = append(args, "--kubeconfig", model.KubeConfig.ValueString())
+	}
+	if !model.KubeContext.IsNull() && model.KubeContext.ValueString() != "" {
+		args = append(args, "--kube-context", model.KubeContext.ValueString())
+	}
+	return runHelm(ctx, model.HelmBinary.ValueString(), args...)
+}
+
+// runHelm runs the given Helm CLI binary with the given arguments and returns its standard output and error.
+func runHelm(ctx context.Context, binary string, args ...string) ([]byte, string, error) {
+	tflog.Debug(ctx, "running Helm", map[string]interface{}{
+		"args": args,
+	})
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.Bytes(), strings.TrimSpace(stderr.String()), err
+}
+
+// applyHelmRelease parses the release returned by the Helm CLI and copies its details into the given model.
+func applyHelmRelease(ctx context.Context, output []byte, model *tfK8sAgentHelmRelease) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var release helmRelease
+	if err := json.Unmarshal(output, &release); err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while parsing the release returned by Helm.\n\n"+
+			"Error: %s\nOutput: %s", err.Error(), output)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"output":              string(output),
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_PARSE,
+		})
+		diags.AddError("Helm Output Error", msg)
+		return diags
+	}
+	model.AppVersion = types.StringValue(release.Chart.Metadata.AppVersion)
+	model.ChartVersion = types.StringValue(release.Chart.Metadata.Version)
+	model.Id = types.StringValue(fmt.Sprintf("%s/%s", release.Namespace, release.Name))
+	model.Revision = types.Int64Value(release.Version)
+	model.Status = types.StringValue(release.Info.Status)
+	tflog.Debug(ctx, fmt.Sprintf("converted Helm release to TF Helm release: %+v", release))
+	return diags
+}
+
+// helmErrorDiags returns an error diagnostic for the given Helm command if running it failed.
+func helmErrorDiags(ctx context.Context, command string, err error, stderr string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if err == nil {
+		return diags
+	}
+	msg := fmt.Sprintf("An unexpected error occurred while running the Helm %s command.\n\nError: %s\nOutput: %s",
+		command, err.Error(), stderr)
+	tflog.Error(ctx, msg, map[string]interface{}{
+		"command":             command,
+		"error":               err.Error(),
+		"output":              stderr,
+		"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_HELM,
+	})
+	diags.AddError("Helm Error", msg)
+	return diags
+}
+
+// helmWaitArgs returns the Helm arguments used to wait for an operation on the release in the given model.
+func helmWaitArgs(ctx context.Context, model tfK8sAgentHelmRelease) ([]string, diag.Diagnostics) {
+	timeout, diags := plugin.ParseRelativeDuration(ctx, model.Timeout.ValueString())
+	if diags.HasError() {
+		return nil, diags
+	}
+	args := []string{"--timeout", timeout.String()}
+	if model.Wait.ValueBool() {
+		args = append(args, "--wait")
+	}
+	return args, diags
+}
+
+// helmValues returns the chart values generated from the given agent settings.
+func helmValues(agentImage, helperImage, imagePullSecret, proxy, siteToken types.String) map[string]interface{} {
+	repositories := map[string]interface{}{}
+	tags := map[string]interface{}{}
+	for purpose, image := range map[string]types.String{"agent": agentImage, "helper": helperImage} {
+		if image.IsNull() || image.IsUnknown() || image.ValueString() == "" {
+			continue
+		}
+		repositories[purpose], tags[purpose] = splitImageReference(image.ValueString())
+	}
+	configuration := map[string]interface{}{}
+	if len(repositories) > 0 {
+		configuration["repositories"] = repositories
+		configuration["tag"] = tags
+	}
+	if !proxy.IsNull() && !proxy.IsUnknown() && proxy.ValueString() != "" {
+		configuration["proxy"] = proxy.ValueString()
+	}
+	secrets := map[string]interface{}{
+		"site_key": map[string]interface{}{
+			"value": siteToken.ValueString(),
+		},
+	}
+	if !imagePullSecret.IsNull() && !imagePullSecret.IsUnknown() && imagePullSecret.ValueString() != "" {
+		secrets["imagePullSecret"] = imagePullSecret.ValueString()
+	}
+	return map[string]interface{}{
+		"configuration": configuration,
+		"secrets":       secrets,
+	}
+}
+
+// helmValuesArgs writes the given values to files within the temporary folder and returns the Helm arguments used
+// to apply them to the chart.
+//
+// Values are applied in order of increasing precedence: the YAML values file, then the generated values and finally
+// the individual values. The generated values, including the site token, are passed in a file rather than on the
+// command line so that they are never visible in the list of processes.
+func helmValuesArgs(ctx context.Context, tempDir *plugin.TempDir, valuesYAML types.String,
+	generated map[string]interface{}, values types.Map) ([]string, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	args := []string{}
+
+	if !valuesYAML.IsNull() && !valuesYAML.IsUnknown() && valuesYAML.ValueString() != "" {
+		diags = writeHelmValuesFile(ctx, tempDir, "values.yaml", []byte(valuesYAML.ValueString()))
+		if diags.HasError() {
+			return nil, diags
+		}
+		args = append(args, "--values", tempDir.Path("values.yaml"))
+	}
+
+	content, err := json.Marshal(generated)
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while generating the chart values.\n\nError: %s",
+			err.Error())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_VALUES,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return nil, diags
+	}
+	diags = writeHelmValuesFile(ctx, tempDir, "generated.json", content)
+	if diags.HasError() {
+		return nil, diags
+	}
+	args = append(args, "--values", tempDir.Path("generated.json"))
+
+	if !values.IsNull() && !values.IsUnknown() {
+		individual := map[string]string{}
+		diags.Append(values.ElementsAs(ctx, &individual, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		keys := make([]string, 0, len(individual))
+		for key := range individual {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			args = append(args, "--set-string", fmt.Sprintf("%s=%s", key, individual[key]))
+		}
+	}
+	return args, diags
+}
+
+// splitImageReference splits a container image reference into its repository and tag.
+//
+// References without a tag use the "latest" tag.
+func splitImageReference(reference string) (string, string) {
+	index := strings.LastIndex(reference, ":")
+	if index <= strings.LastIndex(reference, "/") {
+		return reference, "latest"
+	}
+	return reference[:index], reference[index+1:]
+}
+
+// writeHelmValuesFile writes the given values to a file with the given name within the temporary folder.
+func writeHelmValuesFile(ctx context.Context, tempDir *plugin.TempDir, name string, values []byte) diag.Diagnostics {
+	if err := os.WriteFile(tempDir.Path(name), values, 0600); err != nil {
+		var diags diag.Diagnostics
+		msg := fmt.Sprintf("An unexpected error occurred while writing the chart values file.\n\nError: %s\n"+
+			"File: %s", err.Error(), tempDir.Path(name))
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_HELM_RELEASE_VALUES,
+		})
+		diags.AddError("Unexpected Internal Error", msg)
+		return diags
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &K8sAgentManifest{}
+	_ resource.ResourceWithConfigure = &K8sAgentManifest{}
+)
+
+// tfK8sAgentManifest defines the Terraform model for the rendered manifest of the Singularity agent for Kubernetes.
+type tfK8sAgentManifest struct {
+	AgentImage      types.String `tfsdk:"agent_image"`
+	Chart           types.String `tfsdk:"chart"`
+	ChartRepository types.String `tfsdk:"chart_repository"`
+	ChartVersion    types.String `tfsdk:"chart_version"`
+	HelmBinary      types.String `tfsdk:"helm_binary"`
+	HelperImage     types.String `tfsdk:"helper_image"`
+	Id              types.String `tfsdk:"id"`
+	ImagePullSecret types.String `tfsdk:"image_pull_secret"`
+	Manifest        types.String `tfsdk:"manifest"`
+	Name            types.String `tfsdk:"name"`
+	Namespace       types.String `tfsdk:"namespace"`
+	Proxy           types.String `tfsdk:"proxy"`
+	SiteToken       types.String `tfsdk:"site_token"`
+	Values          types.Map    `tfsdk:"values"`
+	ValuesYAML      types.String `tfsdk:"values_yaml"`
+}
+
+// NewK8sAgentManifest creates a new K8sAgentManifest object.
+func NewK8sAgentManifest() resource.Resource {
+	return &K8sAgentManifest{}
+}
+
+// K8sAgentManifest is a resource used to render the Kubernetes manifest which deploys the Singularity agent for
+// Kubernetes without deploying it.
+type K8sAgentManifest struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *K8sAgentManifest) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_agent_manifest"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *K8sAgentManifest) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for rendering the complete Kubernetes manifest which deploys the " +
+			"Singularity Agent for Kubernetes without connecting to a cluster.",
+		MarkdownDescription: `This resource is used for rendering the complete Kubernetes manifest which deploys the
+			Singularity Agent for Kubernetes without connecting to a cluster.
+
+		The manifest is rendered from the agent's Helm chart with the Helm CLI, which must be installed on the machine
+		running Terraform, and contains every object (eg: the DaemonSet and Deployment) with the images, site token
+		and proxy settings injected. It is meant to be applied by another provider (eg: kubernetes or kubectl) for
+		users who do not want this provider to change their cluster. Since the manifest contains the site token, it
+		is marked as sensitive.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agent_image": schema.StringAttribute{
+				Description: "Full reference of the agent image to deploy (eg: " +
+					"ghcr.io/example/cwpp_agent/s1agent:23.3.2). If not set, the chart's default is used.",
+				MarkdownDescription: "Full reference of the agent image to deploy (eg: " +
+					"`ghcr.io/example/cwpp_agent/s1agent:23.3.2`). If not set, the chart's default is used.",
+				Optional: true,
+			},
+			"chart": schema.StringAttribute{
+				Description:         "Name of the chart to render. [Default: s1-agent]",
+				MarkdownDescription: "Name of the chart to render. [Default: `s1-agent`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("s1-agent"),
+			},
+			"chart_repository": schema.StringAttribute{
+				Description: "URL of the repository from which the chart is retrieved. [Default: " +
+					"https://charts.sentinelone.com]",
+				MarkdownDescription: "URL of the repository from which the chart is retrieved. [Default: " +
+					"`https://charts.sentinelone.com`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("https://charts.sentinelone.com"),
+			},
+			"chart_version": schema.StringAttribute{
+				Description: "Version of the chart to render. If not set, the latest version is rendered each time " +
+					"the manifest is rendered.",
+				MarkdownDescription: "Version of the chart to render. If not set, the latest version is rendered each " +
+					"time the manifest is rendered.",
+				Optional: true,
+			},
+			"helm_binary": schema.StringAttribute{
+				Description:         "Name or path of the Helm CLI binary. [Default: helm]",
+				MarkdownDescription: "Name or path of the Helm CLI binary. [Default: `helm`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("helm"),
+			},
+			"helper_image": schema.StringAttribute{
+				Description: "Full reference of the helper image to deploy (eg: " +
+					"ghcr.io/example/cwpp_agent/s1helper:23.3.2). If not set, the chart's default is used.",
+				MarkdownDescription: "Full reference of the helper image to deploy (eg: " +
+					"`ghcr.io/example/cwpp_agent/s1helper:23.3.2`). If not set, the chart's default is used.",
+				Optional: true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the manifest (in the format <namespace>/<name>).",
+				MarkdownDescription: "ID of the manifest (in the format `<namespace>/<name>`).",
+				Computed:            true,
+			},
+			"image_pull_secret": schema.StringAttribute{
+				Description:         "Name of the Kubernetes secret used to pull the images from a private registry.",
+				MarkdownDescription: "Name of the Kubernetes secret used to pull the images from a private registry.",
+				Optional:            true,
+			},
+			"manifest": schema.StringAttribute{
+				Description:         "Rendered multi-document YAML manifest.",
+				MarkdownDescription: "Rendered multi-document YAML manifest.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the release used to name the rendered objects. [Default: s1-agent]",
+				MarkdownDescription: "Name of the release used to name the rendered objects. [Default: `s1-agent`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("s1-agent"),
+			},
+			"namespace": schema.StringAttribute{
+				Description:         "Namespace of the rendered objects. [Default: sentinelone]",
+				MarkdownDescription: "Namespace of the rendered objects. [Default: `sentinelone`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("sentinelone"),
+			},
+			"proxy": schema.StringAttribute{
+				Description:         "URL of the proxy through which the agents connect to the management console.",
+				MarkdownDescription: "URL of the proxy through which the agents connect to the management console.",
+				Optional:            true,
+			},
+			"site_token": schema.StringAttribute{
+				Description:         "Registration token of the site to which the agents belong.",
+				MarkdownDescription: "Registration token of the site to which the agents belong.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			"values": schema.MapAttribute{
+				Description: "Individual chart values to override using their dotted path (eg: " +
+					"configuration.cluster.name). These take precedence over every other value.",
+				MarkdownDescription: "Individual chart values to override using their dotted path (eg: " +
+					"`configuration.cluster.name`). These take precedence over every other value.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"values_yaml": schema.StringAttribute{
+				Description: "Contents of a YAML values file to apply to the chart. Values set by the other " +
+					"attributes take precedence over it.",
+				MarkdownDescription: "Contents of a YAML values file to apply to the chart. Values set by the other " +
+					"attributes take precedence over it.",
+				Optional: true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *K8sAgentManifest) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_MANIFEST_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *K8sAgentManifest) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfK8sAgentManifest
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// render the manifest and save it to the state
+	resp.Diagnostics.Append(r.render(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// The manifest only exists in the state so there is nothing to refresh.
+func (r *K8sAgentManifest) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *K8sAgentManifest) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfK8sAgentManifest
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// render the manifest again and save it to the state
+	resp.Diagnostics.Append(r.render(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The manifest only exists in the state so there is nothing to remove.
+func (r *K8sAgentManifest) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// render renders the manifest of the given model using the Helm CLI and saves it in the model.
+func (r *K8sAgentManifest) render(ctx context.Context, model *tfK8sAgentManifest) diag.Diagnostics {
+	ctx = plugin.MaskSecrets(ctx, model.SiteToken.ValueString())
+
+	tempDir, diags := plugin.NewTempDir(ctx, "helm-values", "", "")
+	if diags.HasError() {
+		return diags
+	}
+	defer tempDir.Cleanup(ctx)
+
+	args := []string{"template", model.Name.ValueString(), model.Chart.ValueString(), "--repo",
+		model.ChartRepository.ValueString(), "--namespace", model.Namespace.ValueString()}
+	if !model.ChartVersion.IsNull() && !model.ChartVersion.IsUnknown() && model.ChartVersion.ValueString() != "" {
+		args = append(args, "--version", model.ChartVersion.ValueString())
+	}
+	valuesArgs, diags := helmValuesArgs(ctx, tempDir, model.ValuesYAML, helmValues(model.AgentImage,
+		model.HelperImage, model.ImagePullSecret, model.Proxy, model.SiteToken), model.Values)
+	if diags.HasError() {
+		return diags
+	}
+	args = append(args, valuesArgs...)
+
+	// render the manifest
+	output, stderr, err := runHelm(ctx, model.HelmBinary.ValueString(), args...)
+	diags.Append(helmErrorDiags(ctx, "template", err, stderr)...)
+	if diags.HasError() {
+		return diags
+	}
+	model.Id = types.StringValue(fmt.Sprintf("%s/%s", model.Namespace.ValueString(), model.Name.ValueString()))
+	model.Manifest = types.StringValue(string(output))
+	tflog.Debug(ctx, "Rendered Kubernetes agent manifest", map[string]interface{}{
+		"id":   model.Id.ValueString(),
+		"size": len(output),
+	})
+	return diags
+}
@@ -0,0 +1,555 @@
+package resources
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &K8sAgentRegistryCopy{}
+	_ resource.ResourceWithConfigure = &K8sAgentRegistryCopy{}
+)
+
+// tfK8sAgentRegistryCopy defines the Terraform model for copying the k8s agent images to a remote registry.
+type tfK8sAgentRegistryCopy struct {
+	Id           types.String `tfsdk:"id"`
+	Images       types.List   `tfsdk:"images"`
+	ImageTag     types.String `tfsdk:"image_tag"`
+	Insecure     types.Bool   `tfsdk:"insecure"`
+	PackageFile  types.String `tfsdk:"package_file"`
+	Password     types.String `tfsdk:"password"`
+	PushedImages types.List   `tfsdk:"pushed_images"`
+	Registry     types.String `tfsdk:"registry"`
+	RepoPath     types.String `tfsdk:"repo_path"`
+	Username     types.String `tfsdk:"username"`
+}
+
+// tfK8sAgentRegistryCopyImage contains details on an image copied to the remote registry.
+type tfK8sAgentRegistryCopyImage struct {
+	Digest    types.String `tfsdk:"digest"`
+	Purpose   types.String `tfsdk:"purpose"`
+	Reference types.String `tfsdk:"reference"`
+	Source    types.String `tfsdk:"source"`
+}
+
+// tfK8sAgentRegistryCopyImageAttrTypes holds the attribute types of a tfK8sAgentRegistryCopyImage object.
+var tfK8sAgentRegistryCopyImageAttrTypes = map[string]attr.Type{
+	"digest":    types.StringType,
+	"purpose":   types.StringType,
+	"reference": types.StringType,
+	"source":    types.StringType,
+}
+
+// NewK8sAgentRegistryCopy creates a new K8sAgentRegistryCopy object.
+func NewK8sAgentRegistryCopy() resource.Resource {
+	return &K8sAgentRegistryCopy{}
+}
+
+// K8sAgentRegistryCopy is a resource used to copy the k8s agent images from a downloaded package straight into a
+// remote registry without a Docker daemon.
+type K8sAgentRegistryCopy struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *K8sAgentRegistryCopy) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k8s_agent_registry_copy"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *K8sAgentRegistryCopy) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for copying the agent and helper images of a downloaded Singularity " +
+			"Agent for Kubernetes package straight into a remote registry without a Docker daemon.",
+		MarkdownDescription: `This resource is used for copying the agent and helper images of a downloaded Singularity
+			Agent for Kubernetes package straight into a remote registry without a Docker daemon.
+
+		Unlike the ` + "`singularity_k8s_agent_package_loader`" + ` resource, the images are read directly from the
+		package archive and pushed to the registry, so it can be used on CI runners which have no Docker socket. Each
+		image is pushed to ` + "`<registry>/<repo_path>/<image>:<tag>`" + ` and the resulting references can be used
+		as the images of the ` + "`singularity_k8s_agent_helm_release`" + ` resource. If an image is removed from the
+		registry, it is copied again. Destroying the resource leaves the images in the registry.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "ID of the copy (in the format <registry>/<repo_path>).",
+				MarkdownDescription: "ID of the copy (in the format `<registry>/<repo_path>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"images": schema.ListAttribute{
+				Description: "The image(s) to copy to the remote registry (valid values: agent, helper) " +
+					"[Default: [agent, helper] ].",
+				MarkdownDescription: "The image(s) to copy to the remote registry (valid values: `agent`, `helper`) " +
+					"[Default: `[agent, helper]`].",
+				Optional: true,
+				Computed: true,
+				Default: listdefault.StaticValue(types.ListValueMust(
+					types.StringType, []attr.Value{
+						types.StringValue("agent"),
+						types.StringValue("helper"),
+					},
+				)),
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, "agent", "helper"),
+				},
+			},
+			"image_tag": schema.StringAttribute{
+				Description:         "Tag to give the copied images. If not set, the tag in the package is kept.",
+				MarkdownDescription: "Tag to give the copied images. If not set, the tag in the package is kept.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"insecure": schema.BoolAttribute{
+				Description:         "Whether or not to allow connecting to the registry over plain HTTP. [Default: false]",
+				MarkdownDescription: "Whether or not to allow connecting to the registry over plain HTTP. [Default: `false`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"package_file": schema.StringAttribute{
+				Description:         "The path to the downloaded Singularity Agent for Kubernetes package file.",
+				MarkdownDescription: "The path to the downloaded Singularity Agent for Kubernetes package file.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "Password used to authenticate with the registry. If no username is set, the " +
+					"credentials from the Docker configuration file (including credential helpers) are used instead.",
+				MarkdownDescription: "Password used to authenticate with the registry. If no username is set, the " +
+					"credentials from the Docker configuration file (including credential helpers) are used instead.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"pushed_images": schema.ListNestedAttribute{
+				Description:         "Images which were copied to the remote registry.",
+				MarkdownDescription: "Images which were copied to the remote registry.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"digest": schema.StringAttribute{
+							Description:         "Digest of the image in the remote registry.",
+							MarkdownDescription: "Digest of the image in the remote registry.",
+							Computed:            true,
+						},
+						"purpose": schema.StringAttribute{
+							Description:         "Purpose of the image (agent or helper).",
+							MarkdownDescription: "Purpose of the image (`agent` or `helper`).",
+							Computed:            true,
+						},
+						"reference": schema.StringAttribute{
+							Description:         "Full reference of the image in the remote registry.",
+							MarkdownDescription: "Full reference of the image in the remote registry.",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							Description:         "Name of the image within the package.",
+							MarkdownDescription: "Name of the image within the package.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"registry": schema.StringAttribute{
+				Description:         "The hostname of the remote registry (eg: ghcr.io).",
+				MarkdownDescription: "The hostname of the remote registry (eg: `ghcr.io`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_path": schema.StringAttribute{
+				Description: "The repository path within the remote registry in which to store the images " +
+					"(eg: joshhogle-at-s1/cwpp-k8s-agent).",
+				MarkdownDescription: "The repository path within the remote registry in which to store the images " +
+					"(eg: `joshhogle-at-s1/cwpp-k8s-agent`).",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description:         "Username used to authenticate with the registry.",
+				MarkdownDescription: "Username used to authenticate with the registry.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *K8sAgentRegistryCopy) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *K8sAgentRegistryCopy) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfK8sAgentRegistryCopy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.Password.ValueString())
+
+	// make sure the package file exists
+	absPath, diags := plugin.ToAbsolutePath(ctx, plan.PackageFile.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	exists, diags := plugin.PathExists(ctx, absPath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !exists {
+		msg := fmt.Sprintf("The package file specified does not exist.\n\nFile: %s", absPath)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"package_file":        absPath,
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_PACKAGE,
+		})
+		resp.Diagnostics.AddError("K8s Agent Registry Copy Creation Error", msg)
+		return
+	}
+
+	// copy the images
+	var purposes []string
+	resp.Diagnostics.Append(plan.Images.ElementsAs(ctx, &purposes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	images, diags := r.copyImages(ctx, plan, absPath, purposes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the copied images to the state
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", plan.Registry.ValueString(), plan.RepoPath.ValueString()))
+	plan.PushedImages, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfK8sAgentRegistryCopyImageAttrTypes},
+		images)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// If any of the copied images no longer exists in the registry or its digest has changed, the resource is removed
+// from the state so that the images are copied again.
+func (r *K8sAgentRegistryCopy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfK8sAgentRegistryCopy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, state.Password.ValueString())
+
+	var images []tfK8sAgentRegistryCopyImage
+	resp.Diagnostics.Append(state.PushedImages.ElementsAs(ctx, &images, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, image := range images {
+		ref, err := name.ParseReference(image.Reference.ValueString(), registryNameOptions(state)...)
+		if err == nil {
+			var descriptor *remote.Descriptor
+			descriptor, err = remote.Get(ref, registryRemoteOptions(ctx, state)...)
+			if err == nil {
+				if descriptor.Digest.String() == image.Digest.ValueString() {
+					continue
+				}
+				tflog.Debug(ctx, "Copied image has changed in the registry.", map[string]interface{}{
+					"reference": image.Reference.ValueString(),
+					"digest":    descriptor.Digest.String(),
+				})
+				resp.State.RemoveResource(ctx)
+				return
+			}
+		}
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			tflog.Debug(ctx, "Copied image no longer exists in the registry.", map[string]interface{}{
+				"reference": image.Reference.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		msg := fmt.Sprintf("An unexpected error occurred while retrieving the copied image from the registry.\n\n"+
+			"Error: %s\nImage: %s", err.Error(), image.Reference.ValueString())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"image":               image.Reference.ValueString(),
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_READ,
+		})
+		resp.Diagnostics.AddError("Registry Error", msg)
+		return
+	}
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the credentials used to connect to the registry can be changed without copying the images again.
+func (r *K8sAgentRegistryCopy) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfK8sAgentRegistryCopy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The images are left in the registry since they may be in use by a cluster.
+func (r *K8sAgentRegistryCopy) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+}
+
+// copyImages pushes the images of the package archive with the given purposes to the remote registry.
+func (r *K8sAgentRegistryCopy) copyImages(ctx context.Context, plan tfK8sAgentRegistryCopy, packageFile string,
+	purposes []string) ([]tfK8sAgentRegistryCopyImage, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	opener := packageOpener(packageFile)
+
+	// find the images within the package
+	manifest, err := tarball.LoadManifest(opener)
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while reading the images in the package file.\n\n"+
+			"Error: %s\nFile: %s", err.Error(), packageFile)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"package_file":        packageFile,
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_PACKAGE,
+		})
+		diags.AddError("Package Read Error", msg)
+		return nil, diags
+	}
+	imageFormat := regexp.MustCompile(fmt.Sprintf(`^(?:.*/)?%s\/(%s|%s):([a-zA-Z0-9\-_].*)$`,
+		DOCKER_IMAGE_BASE_REPOSITORY, DOCKER_IMAGE_S1_AGENT, DOCKER_IMAGE_S1_HELPER))
+	wanted := map[string]bool{}
+	for _, purpose := range purposes {
+		wanted[purpose] = true
+	}
+
+	images := []tfK8sAgentRegistryCopyImage{}
+	for _, descriptor := range manifest {
+		for _, repoTag := range descriptor.RepoTags {
+			matches := imageFormat.FindStringSubmatch(repoTag)
+			if matches == nil {
+				tflog.Warn(ctx, fmt.Sprintf("image in package is not a known agent image: ignoring\n\nImage: %s",
+					repoTag))
+				continue
+			}
+			purpose := "agent"
+			if matches[1] == DOCKER_IMAGE_S1_HELPER {
+				purpose = "helper"
+			}
+			if !wanted[purpose] {
+				continue
+			}
+			tag := matches[2]
+			if !plan.ImageTag.IsNull() && plan.ImageTag.ValueString() != "" {
+				tag = plan.ImageTag.ValueString()
+			}
+			destination := fmt.Sprintf("%s/%s/%s:%s", plan.Registry.ValueString(), plan.RepoPath.ValueString(),
+				matches[1], tag)
+			digest, diags := r.pushImage(ctx, plan, opener, repoTag, destination)
+			if diags.HasError() {
+				return nil, diags
+			}
+			images = append(images, tfK8sAgentRegistryCopyImage{
+				Digest:    types.StringValue(digest),
+				Purpose:   types.StringValue(purpose),
+				Reference: types.StringValue(destination),
+				Source:    types.StringValue(repoTag),
+			})
+		}
+	}
+	if len(images) == 0 {
+		msg := fmt.Sprintf("The package file does not contain any of the requested images.\n\nFile: %s\n"+
+			"Images: %v", packageFile, purposes)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"package_file":        packageFile,
+			"images":              purposes,
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_PACKAGE,
+		})
+		diags.AddError("Package Read Error", msg)
+		return nil, diags
+	}
+	return images, diags
+}
+
+// pushImage pushes the image with the given tag in the package archive to the given destination and returns its
+// digest.
+func (r *K8sAgentRegistryCopy) pushImage(ctx context.Context, plan tfK8sAgentRegistryCopy, opener tarball.Opener,
+	source, destination string) (string, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	addError := func(err error) {
+		msg := fmt.Sprintf("An unexpected error occurred while copying the image to the registry.\n\n"+
+			"Error: %s\nSource: %s\nDestination: %s", err.Error(), source, destination)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"source":              source,
+			"destination":         destination,
+			"internal_error_code": plugin.ERR_RESOURCE_K8S_AGENT_REGISTRY_COPY_PUSH,
+		})
+		diags.AddError("Registry Push Error", msg)
+	}
+
+	sourceTag, err := name.NewTag(source)
+	if err != nil {
+		addError(err)
+		return "", diags
+	}
+	image, err := tarball.Image(opener, &sourceTag)
+	if err != nil {
+		addError(err)
+		return "", diags
+	}
+	ref, err := name.ParseReference(destination, registryNameOptions(plan)...)
+	if err != nil {
+		addError(err)
+		return "", diags
+	}
+	if err := remote.Write(ref, image, registryRemoteOptions(ctx, plan)...); err != nil {
+		addError(err)
+		return "", diags
+	}
+	digest, err := image.Digest()
+	if err != nil {
+		addError(err)
+		return "", diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("copied image to registry: %s", destination), map[string]interface{}{
+		"source":      source,
+		"destination": destination,
+		"digest":      digest.String(),
+	})
+	return digest.String(), diags
+}
+
+// packageOpener returns an opener for the package archive which transparently decompresses it if it is gzipped.
+func packageOpener(path string) tarball.Opener {
+	return func() (io.ReadCloser, error) {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		reader := bufio.NewReader(file)
+		magic, err := reader.Peek(2)
+		if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+			return struct {
+				io.Reader
+				io.Closer
+			}{reader, file}, nil
+		}
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return struct {
+			io.Reader
+			io.Closer
+		}{gzipReader, file}, nil
+	}
+}
+
+// registryNameOptions returns the options used to parse image references in the remote registry.
+func registryNameOptions(model tfK8sAgentRegistryCopy) []name.Option {
+	if model.Insecure.ValueBool() {
+		return []name.Option{name.Insecure}
+	}
+	return nil
+}
+
+// registryRemoteOptions returns the options used to connect to the remote registry.
+func registryRemoteOptions(ctx context.Context, model tfK8sAgentRegistryCopy) []remote.Option {
+	options := []remote.Option{remote.WithContext(ctx)}
+	if !model.Username.IsNull() && model.Username.ValueString() != "" {
+		options = append(options, remote.WithAuth(&authn.Basic{
+			Username: model.Username.ValueString(),
+			Password: model.Password.ValueString(),
+		}))
+	} else {
+		options = append(options, remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	}
+	return options
+}
@@ -0,0 +1,401 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &MarketplaceApp{}
+	_ resource.ResourceWithConfigure   = &MarketplaceApp{}
+	_ resource.ResourceWithImportState = &MarketplaceApp{}
+)
+
+// tfMarketplaceApp defines the Terraform model for an application.
+type tfMarketplaceApp struct {
+	ApplicationId types.String `tfsdk:"application_id"`
+	Configuration types.Map    `tfsdk:"configuration"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	ScopeId       types.String `tfsdk:"scope_id"`
+	ScopeType     types.String `tfsdk:"scope_type"`
+	UpdatedAt     types.String `tfsdk:"updated_at"`
+}
+
+// NewMarketplaceApp creates a new MarketplaceApp object.
+func NewMarketplaceApp() resource.Resource {
+	return &MarketplaceApp{}
+}
+
+// MarketplaceApp is a resource used to manage an application installed from the Singularity Marketplace.
+type MarketplaceApp struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *MarketplaceApp) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_marketplace_app"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *MarketplaceApp) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for installing and configuring a Singularity Marketplace application " +
+			"within an account or site.",
+		MarkdownDescription: `This resource is used for installing and configuring a Singularity Marketplace application
+			within an account or site.
+
+		This allows integrations (eg: Okta or Splunk applications) to be provisioned along with the rest of the
+		console. Since the API masks secret parameters, changes made to the configuration outside of Terraform are not
+		detected. Existing applications can be imported using an ID in the format
+		` + "`<scope_type>/<scope_id>/<app_id>`" + `; the configuration must then be set in the Terraform configuration
+		and is applied on the next update.
+
+		The configuration is sensitive, so it is never shown in plan output, but it is still stored in the state.
+		Parameters whose names contain ` + "`token`" + `, ` + "`secret`" + `, ` + "`password`" + ` or ` + "`key`" + `
+		are masked in debug logs.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"application_id": schema.StringAttribute{
+				Description:         "ID of the application in the marketplace catalog.",
+				MarkdownDescription: "ID of the application in the marketplace catalog.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"configuration": schema.MapAttribute{
+				Description: "Connection parameters of the application (eg: the URL and API token of the " +
+					"integrated service). The parameters required depend on the application.",
+				MarkdownDescription: "Connection parameters of the application (eg: the URL and API token of the " +
+					"integrated service). The parameters required depend on the application.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the application was installed.",
+				MarkdownDescription: "Timestamp of when the application was installed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not the application is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not the application is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the installed application.",
+				MarkdownDescription: "ID of the installed application.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the application.",
+				MarkdownDescription: "Name of the application.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site in which the application is installed.",
+				MarkdownDescription: "ID of the account or site in which the application is installed.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the application is installed (valid values: account, site).",
+				MarkdownDescription: "Level at which the application is installed (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the application was last updated.",
+				MarkdownDescription: "Timestamp of when the application was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *MarketplaceApp) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_MARKETPLACE_APP_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *MarketplaceApp) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfMarketplaceApp
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, marketplaceAppSecrets(ctx, plan)...)
+
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// install the application
+	app, diags := api.Client().InstallMarketplaceApp(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the application to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfMarketplaceAppFromAPI(ctx, app, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *MarketplaceApp) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfMarketplaceApp
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, marketplaceAppSecrets(ctx, state)...)
+
+	// find the application - if it no longer exists, remove it from the state
+	queryParams := api.MarketplaceAppQueryParams{
+		MarketplaceAppIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	apps, diags := api.Client().FindMarketplaceApps(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(apps) == 0 {
+		tflog.Debug(ctx, "Marketplace application no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfMarketplaceAppFromAPI(ctx, &apps[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *MarketplaceApp) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfMarketplaceApp
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfMarketplaceApp
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, append(marketplaceAppSecrets(ctx, plan), marketplaceAppSecrets(ctx, state)...)...)
+
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the application
+	app, diags := api.Client().UpdateMarketplaceApp(ctx, state.Id.ValueString(), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated application to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfMarketplaceAppFromAPI(ctx, app, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *MarketplaceApp) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfMarketplaceApp
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// uninstall the application
+	resp.Diagnostics.Append(api.Client().UninstallMarketplaceApp(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Uninstalled marketplace application", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing marketplace application into the Terraform state.
+//
+// The API can only find an application within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<app_id>.
+func (r *MarketplaceApp) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<app_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_MARKETPLACE_APP_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for installing or updating an application.
+func (r *MarketplaceApp) bodyFromPlan(ctx context.Context, plan tfMarketplaceApp) (api.MarketplaceAppBody,
+	diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	body := api.MarketplaceAppBody{}
+
+	if !plan.ApplicationId.IsNull() && !plan.ApplicationId.IsUnknown() {
+		value := plan.ApplicationId.ValueString()
+		body.ApplicationId = &value
+	}
+
+	if !plan.Configuration.IsNull() && !plan.Configuration.IsUnknown() {
+		body.Config = map[string]string{}
+		diags.Append(plan.Configuration.ElementsAs(ctx, &body.Config, false)...)
+		if diags.HasError() {
+			return body, diags
+		}
+	}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := plan.Enabled.ValueBool()
+		body.Enabled = &value
+	}
+	return body, diags
+}
+
+// tfMarketplaceAppFromAPI converts an API marketplace application into a Terraform marketplace application.
+//
+// The API does not return the scope of the application and masks its secret parameters so both are copied from the
+// given model.
+func tfMarketplaceAppFromAPI(ctx context.Context, app *api.MarketplaceApp, model tfMarketplaceApp) tfMarketplaceApp {
+	tfapp := tfMarketplaceApp{
+		ApplicationId: types.StringValue(app.ApplicationId),
+		Configuration: model.Configuration,
+		CreatedAt:     types.StringValue(app.CreatedAt),
+		Enabled:       types.BoolValue(app.Enabled),
+		Id:            types.StringValue(app.Id),
+		Name:          types.StringValue(app.Name),
+		ScopeId:       model.ScopeId,
+		ScopeType:     model.ScopeType,
+		UpdatedAt:     types.StringValue(app.UpdatedAt),
+	}
+	if tfapp.Configuration.IsUnknown() {
+		tfapp.Configuration = types.MapNull(types.StringType)
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API marketplace app to TF marketplace app: %+v", tfapp),
+		map[string]interface{}{
+			"api_marketplace_app": app,
+		})
+	return tfapp
+}
+
+// marketplaceAppSecretKeys are the substrings of the configuration parameter names which are treated as secrets.
+var marketplaceAppSecretKeys = []string{"token", "secret", "password", "key"}
+
+// marketplaceAppSecrets returns the secret configuration parameters of the given model so that they can be masked in
+// logs.
+//
+// The API does not say which parameters are secret so only parameters whose names look sensitive are masked. Masking
+// every value would also mask short values such as "true" or region names wherever they appear in the logs.
+func marketplaceAppSecrets(ctx context.Context, model tfMarketplaceApp) []string {
+	if model.Configuration.IsNull() || model.Configuration.IsUnknown() {
+		return nil
+	}
+	configuration := map[string]string{}
+	if diags := model.Configuration.ElementsAs(ctx, &configuration, false); diags.HasError() {
+		return nil
+	}
+	secrets := []string{}
+	for name, value := range configuration {
+		name = strings.ToLower(name)
+		for _, key := range marketplaceAppSecretKeys {
+			if strings.Contains(name, key) {
+				secrets = append(secrets, value)
+				break
+			}
+		}
+	}
+	return secrets
+}
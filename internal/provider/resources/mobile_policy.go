@@ -0,0 +1,463 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &MobilePolicy{}
+	_ resource.ResourceWithConfigure   = &MobilePolicy{}
+	_ resource.ResourceWithImportState = &MobilePolicy{}
+)
+
+// tfMobilePolicy defines the Terraform model for a Singularity Mobile policy.
+type tfMobilePolicy struct {
+	Android       *tfMobileOsPolicy `tfsdk:"android"`
+	Id            types.String      `tfsdk:"id"`
+	InheritedFrom types.String      `tfsdk:"inherited_from"`
+	Ios           *tfMobileOsPolicy `tfsdk:"ios"`
+	Mdm           *tfMobileMdm      `tfsdk:"mdm"`
+	ScopeId       types.String      `tfsdk:"scope_id"`
+	ScopeType     types.String      `tfsdk:"scope_type"`
+}
+
+// tfMobileOsPolicy defines the Terraform model for the threat policy settings applied to devices running an OS.
+type tfMobileOsPolicy struct {
+	CompromisedDeviceAction types.String `tfsdk:"compromised_device_action"`
+	Enabled                 types.Bool   `tfsdk:"enabled"`
+	MalwareAction           types.String `tfsdk:"malware_action"`
+	MinimumOsVersion        types.String `tfsdk:"minimum_os_version"`
+	NetworkThreatAction     types.String `tfsdk:"network_threat_action"`
+	PhishingProtection      types.Bool   `tfsdk:"phishing_protection"`
+}
+
+// tfMobileMdm defines the Terraform model for the mobile device management integration.
+type tfMobileMdm struct {
+	ApiKey              types.String `tfsdk:"api_key"`
+	ApiUrl              types.String `tfsdk:"api_url"`
+	Enabled             types.Bool   `tfsdk:"enabled"`
+	Provider            types.String `tfsdk:"provider"`
+	SyncIntervalMinutes types.Int64  `tfsdk:"sync_interval_minutes"`
+	TenantId            types.String `tfsdk:"tenant_id"`
+}
+
+// NewMobilePolicy creates a new MobilePolicy object.
+func NewMobilePolicy() resource.Resource {
+	return &MobilePolicy{}
+}
+
+// MobilePolicy is a resource used to manage the Singularity Mobile policy of an account or site.
+type MobilePolicy struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *MobilePolicy) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mobile_policy"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *MobilePolicy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	actions := []string{api.MOBILE_THREAT_ACTION_ALERT, api.MOBILE_THREAT_ACTION_BLOCK,
+		api.MOBILE_THREAT_ACTION_IGNORE}
+	actionDescription := fmt.Sprintf("(valid values: %s).", strings.Join(actions, ", "))
+	actionMarkdownDescription := fmt.Sprintf("(valid values: `%s`).", strings.Join(actions, "`, `"))
+	osAttributes := func(os string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"compromised_device_action": schema.StringAttribute{
+				Description: fmt.Sprintf("Action taken when a %s device is jailbroken or rooted %s", os,
+					actionDescription),
+				MarkdownDescription: fmt.Sprintf("Action taken when a %s device is jailbroken or rooted %s", os,
+					actionMarkdownDescription),
+				Required: true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, actions...),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         fmt.Sprintf("Whether or not %s devices are protected.", os),
+				MarkdownDescription: fmt.Sprintf("Whether or not %s devices are protected.", os),
+				Required:            true,
+			},
+			"malware_action": schema.StringAttribute{
+				Description:         "Action taken when a malicious application is detected " + actionDescription,
+				MarkdownDescription: "Action taken when a malicious application is detected " + actionMarkdownDescription,
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, actions...),
+				},
+			},
+			"minimum_os_version": schema.StringAttribute{
+				Description: fmt.Sprintf("Minimum %s version allowed. Devices running an older version are "+
+					"reported as vulnerable.", os),
+				MarkdownDescription: fmt.Sprintf("Minimum %s version allowed. Devices running an older version are "+
+					"reported as vulnerable.", os),
+				Optional: true,
+			},
+			"network_threat_action": schema.StringAttribute{
+				Description: "Action taken when a network threat (eg: a man-in-the-middle attack) is detected " +
+					actionDescription,
+				MarkdownDescription: "Action taken when a network threat (eg: a man-in-the-middle attack) is " +
+					"detected " + actionMarkdownDescription,
+				Required: true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, actions...),
+				},
+			},
+			"phishing_protection": schema.BoolAttribute{
+				Description:         "Whether or not links to phishing sites are blocked.",
+				MarkdownDescription: "Whether or not links to phishing sites are blocked.",
+				Required:            true,
+			},
+		}
+	}
+	providers := []string{api.MOBILE_MDM_PROVIDER_INTUNE, api.MOBILE_MDM_PROVIDER_JAMF,
+		api.MOBILE_MDM_PROVIDER_MOBILEIRON, api.MOBILE_MDM_PROVIDER_WORKSPACE_ONE}
+
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the Singularity Mobile policy of an account or site.",
+		MarkdownDescription: `This resource is used for managing the Singularity Mobile policy of an account or site.
+
+		The policy defines how threats on iOS and Android devices are handled and how Singularity Mobile integrates
+		with the mobile device management (MDM) product used to enroll the devices. Any of the ` + "`android`" + `,
+		` + "`ios`" + ` and ` + "`mdm`" + ` blocks which are omitted are left unchanged in the console. Destroying the
+		resource reverts the scope to the policy inherited from its parent. Existing policies can be imported using
+		an ID in the format ` + "`<scope_type>/<scope_id>`" + `; since the API never returns the MDM API key, it is
+		applied on the next update.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "ID of the account or site (same as scope_id).",
+				MarkdownDescription: "ID of the account or site (same as `scope_id`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inherited_from": schema.StringAttribute{
+				Description:         "Scope from which the policy was inherited before it was managed.",
+				MarkdownDescription: "Scope from which the policy was inherited before it was managed.",
+				Computed:            true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site whose policy is managed.",
+				MarkdownDescription: "ID of the account or site whose policy is managed.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level of the policy (valid values: account, site).",
+				MarkdownDescription: "Level of the policy (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"android": schema.SingleNestedBlock{
+				Description:         "Threat policy settings applied to Android devices.",
+				MarkdownDescription: "Threat policy settings applied to Android devices.",
+				Attributes:          osAttributes("Android"),
+			},
+			"ios": schema.SingleNestedBlock{
+				Description:         "Threat policy settings applied to iOS devices.",
+				MarkdownDescription: "Threat policy settings applied to iOS devices.",
+				Attributes:          osAttributes("iOS"),
+			},
+			"mdm": schema.SingleNestedBlock{
+				Description:         "Settings of the integration with the MDM product used to enroll devices.",
+				MarkdownDescription: "Settings of the integration with the MDM product used to enroll devices.",
+				Attributes: map[string]schema.Attribute{
+					"api_key": schema.StringAttribute{
+						Description:         "API key used to access the MDM product.",
+						MarkdownDescription: "API key used to access the MDM product.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"api_url": schema.StringAttribute{
+						Description:         "URL of the API of the MDM product.",
+						MarkdownDescription: "URL of the API of the MDM product.",
+						Required:            true,
+					},
+					"enabled": schema.BoolAttribute{
+						Description:         "Whether or not the integration is enabled.",
+						MarkdownDescription: "Whether or not the integration is enabled.",
+						Required:            true,
+					},
+					"provider": schema.StringAttribute{
+						Description: fmt.Sprintf("MDM product (valid values: %s).",
+							strings.Join(providers, ", ")),
+						MarkdownDescription: fmt.Sprintf("MDM product (valid values: `%s`).",
+							strings.Join(providers, "`, `")),
+						Required: true,
+						Validators: []validator.String{
+							validators.EnumStringValueOneOf(false, providers...),
+						},
+					},
+					"sync_interval_minutes": schema.Int64Attribute{
+						Description:         "Number of minutes between synchronizations of the enrolled devices.",
+						MarkdownDescription: "Number of minutes between synchronizations of the enrolled devices.",
+						Required:            true,
+					},
+					"tenant_id": schema.StringAttribute{
+						Description:         "ID of the tenant within the MDM product.",
+						MarkdownDescription: "ID of the tenant within the MDM product.",
+						Optional:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *MobilePolicy) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_MOBILE_POLICY_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *MobilePolicy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfMobilePolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, mobilePolicySecrets(plan)...)
+
+	// the scope always has a policy so creating it simply overrides the settings given
+	policy, diags := api.Client().UpdateMobilePolicy(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the policy to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfMobilePolicyFromAPI(ctx, policy, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *MobilePolicy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfMobilePolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, mobilePolicySecrets(state)...)
+
+	// get the current policy of the scope
+	policy, diags := api.Client().GetMobilePolicy(ctx, scopeFromModel(state.ScopeType, state.ScopeId))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfMobilePolicyFromAPI(ctx, policy, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *MobilePolicy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfMobilePolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, mobilePolicySecrets(plan)...)
+
+	// update the policy
+	policy, diags := api.Client().UpdateMobilePolicy(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated policy to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfMobilePolicyFromAPI(ctx, policy, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *MobilePolicy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfMobilePolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// revert the scope to its inherited policy
+	resp.Diagnostics.Append(api.Client().RevertMobilePolicy(ctx, scopeFromModel(state.ScopeType,
+		state.ScopeId))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reverted mobile policy", map[string]interface{}{
+		"scope_type": state.ScopeType.ValueString(),
+		"scope_id":   state.ScopeId.ValueString(),
+	})
+}
+
+// ImportState imports the Singularity Mobile policy of an existing account or site into the Terraform state.
+//
+// The import ID must be in the format <scope_type>/<scope_id>.
+func (r *MobilePolicy) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id> where the scope type is one "+
+			"of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_MOBILE_POLICY_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[1])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating a policy.
+func (r *MobilePolicy) bodyFromPlan(plan tfMobilePolicy) api.MobilePolicyBody {
+	body := api.MobilePolicyBody{
+		Android: mobileOsPolicyFromModel(plan.Android),
+		Ios:     mobileOsPolicyFromModel(plan.Ios),
+	}
+	if plan.Mdm != nil {
+		body.Mdm = &api.MobileMdm{
+			ApiKey:              plan.Mdm.ApiKey.ValueString(),
+			ApiUrl:              plan.Mdm.ApiUrl.ValueString(),
+			Enabled:             plan.Mdm.Enabled.ValueBool(),
+			Provider:            plan.Mdm.Provider.ValueString(),
+			SyncIntervalMinutes: plan.Mdm.SyncIntervalMinutes.ValueInt64(),
+			TenantId:            plan.Mdm.TenantId.ValueString(),
+		}
+	}
+	return body
+}
+
+// mobileOsPolicyFromModel converts the Terraform OS policy settings into API OS policy settings.
+func mobileOsPolicyFromModel(model *tfMobileOsPolicy) *api.MobileOsPolicy {
+	if model == nil {
+		return nil
+	}
+	return &api.MobileOsPolicy{
+		CompromisedDeviceAction: model.CompromisedDeviceAction.ValueString(),
+		Enabled:                 model.Enabled.ValueBool(),
+		MalwareAction:           model.MalwareAction.ValueString(),
+		MinimumOsVersion:        model.MinimumOsVersion.ValueString(),
+		NetworkThreatAction:     model.NetworkThreatAction.ValueString(),
+		PhishingProtection:      model.PhishingProtection.ValueBool(),
+	}
+}
+
+// tfMobilePolicyFromAPI converts an API Singularity Mobile policy into a Terraform Singularity Mobile policy.
+//
+// Only the sections of the policy which are managed in the given model are converted since the others are left
+// unchanged. The API never returns the MDM API key so it is copied from the model.
+func tfMobilePolicyFromAPI(ctx context.Context, policy *api.MobilePolicy, model tfMobilePolicy) tfMobilePolicy {
+	tfpolicy := tfMobilePolicy{
+		Id:            model.ScopeId,
+		InheritedFrom: types.StringValue(policy.InheritedFrom),
+		ScopeId:       model.ScopeId,
+		ScopeType:     model.ScopeType,
+	}
+	if model.Android != nil && policy.Android != nil {
+		tfpolicy.Android = tfMobileOsPolicyFromAPI(policy.Android)
+	}
+	if model.Ios != nil && policy.Ios != nil {
+		tfpolicy.Ios = tfMobileOsPolicyFromAPI(policy.Ios)
+	}
+	if model.Mdm != nil && policy.Mdm != nil {
+		tfpolicy.Mdm = &tfMobileMdm{
+			ApiKey:              model.Mdm.ApiKey,
+			ApiUrl:              types.StringValue(policy.Mdm.ApiUrl),
+			Enabled:             types.BoolValue(policy.Mdm.Enabled),
+			Provider:            types.StringValue(policy.Mdm.Provider),
+			SyncIntervalMinutes: types.Int64Value(policy.Mdm.SyncIntervalMinutes),
+			TenantId:            types.StringNull(),
+		}
+		if policy.Mdm.TenantId != "" {
+			tfpolicy.Mdm.TenantId = types.StringValue(policy.Mdm.TenantId)
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API mobile policy to TF mobile policy: %+v", tfpolicy),
+		map[string]interface{}{
+			"api_mobile_policy": policy,
+		})
+	return tfpolicy
+}
+
+// tfMobileOsPolicyFromAPI converts API OS policy settings into Terraform OS policy settings.
+func tfMobileOsPolicyFromAPI(policy *api.MobileOsPolicy) *tfMobileOsPolicy {
+	tfpolicy := &tfMobileOsPolicy{
+		CompromisedDeviceAction: types.StringValue(policy.CompromisedDeviceAction),
+		Enabled:                 types.BoolValue(policy.Enabled),
+		MalwareAction:           types.StringValue(policy.MalwareAction),
+		MinimumOsVersion:        types.StringNull(),
+		NetworkThreatAction:     types.StringValue(policy.NetworkThreatAction),
+		PhishingProtection:      types.BoolValue(policy.PhishingProtection),
+	}
+	if policy.MinimumOsVersion != "" {
+		tfpolicy.MinimumOsVersion = types.StringValue(policy.MinimumOsVersion)
+	}
+	return tfpolicy
+}
+
+// mobilePolicySecrets returns the secret values held by the given model so that they can be masked in logs.
+func mobilePolicySecrets(model tfMobilePolicy) []string {
+	if model.Mdm == nil {
+		return nil
+	}
+	return []string{model.Mdm.ApiKey.ValueString()}
+}
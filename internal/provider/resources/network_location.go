@@ -0,0 +1,451 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &NetworkLocation{}
+	_ resource.ResourceWithConfigure   = &NetworkLocation{}
+	_ resource.ResourceWithImportState = &NetworkLocation{}
+)
+
+// tfNetworkLocation defines the Terraform model for a network location.
+type tfNetworkLocation struct {
+	CreatedAt         types.String                 `tfsdk:"created_at"`
+	Description       types.String                 `tfsdk:"description"`
+	DNSLookup         []tfNetworkLocationDNSLookup `tfsdk:"dns_lookup"`
+	DNSServers        []types.String               `tfsdk:"dns_servers"`
+	GatewayIPs        []types.String               `tfsdk:"gateway_ips"`
+	Id                types.String                 `tfsdk:"id"`
+	IPAddresses       []types.String               `tfsdk:"ip_addresses"`
+	Name              types.String                 `tfsdk:"name"`
+	NetworkInterfaces []types.String               `tfsdk:"network_interfaces"`
+	Operator          types.String                 `tfsdk:"operator"`
+	ScopeId           types.String                 `tfsdk:"scope_id"`
+	ScopeType         types.String                 `tfsdk:"scope_type"`
+	UpdatedAt         types.String                 `tfsdk:"updated_at"`
+}
+
+// tfNetworkLocationDNSLookup defines the Terraform model for a host which must resolve to a given IP address.
+type tfNetworkLocationDNSLookup struct {
+	Host types.String `tfsdk:"host"`
+	IP   types.String `tfsdk:"ip"`
+}
+
+// NewNetworkLocation creates a new NetworkLocation object.
+func NewNetworkLocation() resource.Resource {
+	return &NetworkLocation{}
+}
+
+// NetworkLocation is a resource used to manage the lifecycle of a network location.
+type NetworkLocation struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *NetworkLocation) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_network_location"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *NetworkLocation) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a network location within an account, site " +
+			"or group.",
+		MarkdownDescription: `This resource is used for creating and managing a network location within an account, site
+			or group.
+
+		Locations identify the network to which an agent is connected so that location-aware firewall policies can
+		apply different rules on different networks. An agent is in the location when all (or any, depending on the
+		` + "`operator`" + `) of the configured criteria match. Criteria which are not configured are disabled, so at
+		least one must be given.
+
+		Existing locations can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<location_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the location was created.",
+				MarkdownDescription: "Timestamp of when the location was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the location. [Default: none]",
+				MarkdownDescription: "Description of the location. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"dns_servers": schema.ListAttribute{
+				Description:         "DNS server IP addresses, one of which the agent must be using. [Default: none]",
+				MarkdownDescription: "DNS server IP addresses, one of which the agent must be using. [Default: none]",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"gateway_ips": schema.ListAttribute{
+				Description: "Default gateway IP addresses, one of which the agent must be using. [Default: " +
+					"none]",
+				MarkdownDescription: "Default gateway IP addresses, one of which the agent must be using. " +
+					"[Default: none]",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the location.",
+				MarkdownDescription: "ID of the location.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_addresses": schema.ListAttribute{
+				Description: "IP addresses or ranges (eg: 10.0.0.0/8, 10.0.0.1-10.0.0.50), one of which must be " +
+					"assigned to the agent. [Default: none]",
+				MarkdownDescription: "IP addresses or ranges (eg: `10.0.0.0/8`, `10.0.0.1-10.0.0.50`), one of which " +
+					"must be assigned to the agent. [Default: none]",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the location.",
+				MarkdownDescription: "Name of the location.",
+				Required:            true,
+			},
+			"network_interfaces": schema.ListAttribute{
+				Description: "Types of network interface, one of which the agent must be connected through (valid " +
+					"values: wired, wireless). [Default: none]",
+				MarkdownDescription: "Types of network interface, one of which the agent must be connected through " +
+					"(valid values: `wired`, `wireless`). [Default: none]",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, "wired", "wireless"),
+				},
+			},
+			"operator": schema.StringAttribute{
+				Description: "Whether all or any of the criteria must match (valid values: all, any). [Default: all]",
+				MarkdownDescription: "Whether all or any of the criteria must match (valid values: `all`, `any`). " +
+					"[Default: `all`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("all"),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "all", "any"),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the location belongs.",
+				MarkdownDescription: "ID of the account, site or group to which the location belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the location is created (valid values: account, site, group).",
+				MarkdownDescription: "Level at which the location is created (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the location was last updated.",
+				MarkdownDescription: "Timestamp of when the location was last updated.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"dns_lookup": schema.ListNestedBlock{
+				Description:         "Defines a host which must resolve to the given IP address.",
+				MarkdownDescription: "Defines a host which must resolve to the given IP address.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"host": schema.StringAttribute{
+							Description:         "Hostname to resolve.",
+							MarkdownDescription: "Hostname to resolve.",
+							Required:            true,
+						},
+						"ip": schema.StringAttribute{
+							Description:         "IP address to which the hostname must resolve.",
+							MarkdownDescription: "IP address to which the hostname must resolve.",
+							Required:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *NetworkLocation) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_NETWORK_LOCATION_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *NetworkLocation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfNetworkLocation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the location
+	location, diags := api.Client().CreateNetworkLocation(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the location to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfNetworkLocationFromAPI(ctx, location, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *NetworkLocation) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfNetworkLocation
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the location - if it no longer exists, remove it from the state
+	queryParams := api.NetworkLocationQueryParams{
+		LocationIds: []string{state.Id.ValueString()},
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{state.ScopeId.ValueString()}
+	}
+	locations, diags := api.Client().FindNetworkLocations(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(locations) == 0 {
+		tflog.Debug(ctx, "Network location no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfNetworkLocationFromAPI(ctx, &locations[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *NetworkLocation) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfNetworkLocation
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfNetworkLocation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the location
+	location, diags := api.Client().UpdateNetworkLocation(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated location to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfNetworkLocationFromAPI(ctx, location, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *NetworkLocation) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfNetworkLocation
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the location
+	resp.Diagnostics.Append(api.Client().DeleteNetworkLocation(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed network location", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing network location into the Terraform state.
+//
+// The API can only find a location within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<location_id>.
+func (r *NetworkLocation) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE &&
+		parts[0] != api.SCOPE_GROUP) || parts[1] == "" || parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<location_id> where the "+
+			"scope type is one of: %s, %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP,
+			req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_NETWORK_LOCATION_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a location.
+func (r *NetworkLocation) bodyFromPlan(plan tfNetworkLocation) api.NetworkLocationBody {
+	body := api.NetworkLocationBody{}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	for _, lookup := range plan.DNSLookup {
+		body.DNSLookup = append(body.DNSLookup, api.NetworkLocationDNSLookup{
+			Host: lookup.Host.ValueString(),
+			IP:   lookup.IP.ValueString(),
+		})
+	}
+
+	for _, v := range plan.DNSServers {
+		body.DNSServers = append(body.DNSServers, v.ValueString())
+	}
+
+	for _, v := range plan.GatewayIPs {
+		body.GatewayIPs = append(body.GatewayIPs, v.ValueString())
+	}
+
+	for _, v := range plan.IPAddresses {
+		body.IPAddresses = append(body.IPAddresses, v.ValueString())
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	for _, v := range plan.NetworkInterfaces {
+		body.NetworkInterfaces = append(body.NetworkInterfaces, v.ValueString())
+	}
+
+	if !plan.Operator.IsNull() && !plan.Operator.IsUnknown() {
+		value := plan.Operator.ValueString()
+		body.Operator = &value
+	}
+	return body
+}
+
+// tfNetworkLocationFromAPI converts an API network location into a Terraform network location.
+//
+// The scope is copied from the given model since it is always known from the configuration or import ID. Criteria
+// which are disabled or have no values are left unset so that they match a configuration which omits them.
+func tfNetworkLocationFromAPI(ctx context.Context, location *api.NetworkLocation,
+	model tfNetworkLocation) tfNetworkLocation {
+
+	values := func(criterion api.NetworkLocationValues) []types.String {
+		if !criterion.Enabled || len(criterion.Values) == 0 {
+			return nil
+		}
+		list := []types.String{}
+		for _, v := range criterion.Values {
+			list = append(list, types.StringValue(v))
+		}
+		return list
+	}
+
+	tflocation := tfNetworkLocation{
+		CreatedAt:         types.StringValue(location.CreatedAt),
+		Description:       types.StringValue(location.Description),
+		DNSServers:        values(location.DNSServers),
+		GatewayIPs:        values(location.GatewayIPs),
+		Id:                types.StringValue(location.Id),
+		IPAddresses:       values(location.IPAddresses),
+		Name:              types.StringValue(location.Name),
+		NetworkInterfaces: values(location.NetworkInterfaces),
+		Operator:          types.StringValue(location.Operator),
+		ScopeId:           model.ScopeId,
+		ScopeType:         model.ScopeType,
+		UpdatedAt:         types.StringValue(location.UpdatedAt),
+	}
+	if location.DNSLookup.Enabled {
+		for _, lookup := range location.DNSLookup.Values {
+			tflocation.DNSLookup = append(tflocation.DNSLookup, tfNetworkLocationDNSLookup{
+				Host: types.StringValue(lookup.Host),
+				IP:   types.StringValue(lookup.IP),
+			})
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API network location to TF network location: %+v", tflocation),
+		map[string]interface{}{
+			"api_network_location": location,
+		})
+	return tflocation
+}
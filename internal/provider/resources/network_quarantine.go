@@ -0,0 +1,252 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &NetworkQuarantine{}
+	_ resource.ResourceWithConfigure = &NetworkQuarantine{}
+)
+
+// tfNetworkQuarantine defines the Terraform model for a network quarantine.
+type tfNetworkQuarantine struct {
+	AgentIds types.List     `tfsdk:"agent_ids"`
+	Filter   *tfAgentFilter `tfsdk:"filter"`
+	Reason   types.String   `tfsdk:"reason"`
+}
+
+// NewNetworkQuarantine creates a new NetworkQuarantine object.
+func NewNetworkQuarantine() resource.Resource {
+	return &NetworkQuarantine{}
+}
+
+// NetworkQuarantine is a resource used to disconnect agents matching a filter from the network.
+type NetworkQuarantine struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *NetworkQuarantine) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_network_quarantine"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *NetworkQuarantine) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for disconnecting all agents matching a filter from the network " +
+			"(network quarantine) in order to contain an incident.",
+		MarkdownDescription: `This resource is used for disconnecting all agents matching a filter from the network
+			(network quarantine) in order to contain an incident.
+
+		At least one filter must be given. The agents matching the filter when the resource is created are
+		disconnected and recorded in ` + "`agent_ids`" + `. When the resource is destroyed, only those agents are
+		reconnected to the network. If any of the agents is reconnected outside of Terraform, the resource is
+		recreated on the next apply so that the agents are disconnected again.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agent_ids": schema.ListAttribute{
+				Description:         "IDs of the agents which have been disconnected from the network.",
+				MarkdownDescription: "IDs of the agents which have been disconnected from the network.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"reason": schema.StringAttribute{
+				Description: "Reason for the quarantine (eg: incident or change ticket number). This is only " +
+					"recorded in the Terraform state for auditing purposes.",
+				MarkdownDescription: "Reason for the quarantine (eg: incident or change ticket number). This is only " +
+					"recorded in the Terraform state for auditing purposes.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("to disconnect from the network"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *NetworkQuarantine) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_NETWORK_QUARANTINE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *NetworkQuarantine) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfNetworkQuarantine
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// never disconnect every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents to disconnect from the network."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_NETWORK_QUARANTINE_CREATE,
+		})
+		resp.Diagnostics.AddError("Network Quarantine Creation Error", msg)
+		return
+	}
+
+	// find the agents first so that exactly the agents which are disconnected are reconnected later
+	agents, diags := api.Client().FindAgents(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agentIds := []string{}
+	for _, agent := range agents {
+		agentIds = append(agentIds, agent.Id)
+	}
+	if len(agentIds) > 0 {
+		affected, diags := api.Client().DisconnectAgents(ctx, api.AgentQueryParams{AgentIds: agentIds})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, "Disconnected agents from the network", map[string]interface{}{
+			"agent_ids":       agentIds,
+			"agents_affected": affected,
+			"reason":          plan.Reason.ValueString(),
+		})
+	}
+	plan.AgentIds, diags = types.ListValueFrom(ctx, types.StringType, agentIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *NetworkQuarantine) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfNetworkQuarantine
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var agentIds []string
+	resp.Diagnostics.Append(state.AgentIds.ElementsAs(ctx, &agentIds, false)...)
+	if resp.Diagnostics.HasError() || len(agentIds) == 0 {
+		return
+	}
+
+	// find the quarantined agents - agents which no longer exist are dropped
+	agents, diags := api.Client().FindAgents(ctx, api.AgentQueryParams{AgentIds: agentIds})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// if any agent has been reconnected, recreate the resource so the agents are disconnected again
+	agentIds = []string{}
+	for _, agent := range agents {
+		if agent.NetworkStatus == "connected" || agent.NetworkStatus == "connecting" {
+			tflog.Debug(ctx, "Quarantined agent has been reconnected to the network.", map[string]interface{}{
+				"agent_id":       agent.Id,
+				"network_status": agent.NetworkStatus,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		agentIds = append(agentIds, agent.Id)
+	}
+	state.AgentIds, diags = types.ListValueFrom(ctx, types.StringType, agentIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the reason can be updated in place so there is nothing to do other than saving it.
+func (r *NetworkQuarantine) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfNetworkQuarantine
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfNetworkQuarantine
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.AgentIds = state.AgentIds
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *NetworkQuarantine) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfNetworkQuarantine
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only reconnect agents which were disconnected by this resource
+	var agentIds []string
+	resp.Diagnostics.Append(state.AgentIds.ElementsAs(ctx, &agentIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(agentIds) == 0 {
+		return
+	}
+	affected, diags := api.Client().ConnectAgents(ctx, api.AgentQueryParams{AgentIds: agentIds})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Info(ctx, "Reconnected agents to the network", map[string]interface{}{
+		"agent_ids":       agentIds,
+		"agents_affected": affected,
+	})
+}
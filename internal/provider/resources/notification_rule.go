@@ -0,0 +1,343 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &NotificationRule{}
+	_ resource.ResourceWithConfigure   = &NotificationRule{}
+	_ resource.ResourceWithImportState = &NotificationRule{}
+)
+
+// tfNotificationRule defines the Terraform model for a notification rule.
+type tfNotificationRule struct {
+	Email     types.Bool   `tfsdk:"email"`
+	EventType types.String `tfsdk:"event_type"`
+	Id        types.String `tfsdk:"id"`
+	ScopeId   types.String `tfsdk:"scope_id"`
+	ScopeType types.String `tfsdk:"scope_type"`
+	Syslog    types.Bool   `tfsdk:"syslog"`
+	Webhook   types.Bool   `tfsdk:"webhook"`
+}
+
+// NewNotificationRule creates a new NotificationRule object.
+func NewNotificationRule() resource.Resource {
+	return &NotificationRule{}
+}
+
+// NotificationRule is a resource used to manage the channels through which notifications for a type of event are
+// sent.
+type NotificationRule struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *NotificationRule) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_notification_rule"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *NotificationRule) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing which channels (email, syslog, webhook) are notified " +
+			"when a type of activity or threat event occurs within the whole console, an account or a site.",
+		MarkdownDescription: `This resource is used for managing which channels (email, syslog, webhook) are notified
+			when a type of activity or threat event occurs within the whole console, an account or a site.
+
+		Every scope always has a rule for every type of event, so creating the resource simply overrides the
+		current rule and destroying it disables every channel for the event type. The recipients of each channel
+		(eg: the syslog server or webhook) are configured separately.
+
+		Existing rules can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<event_type>`" + ` or
+		` + "`tenant/<event_type>`" + ` for rules which apply to the whole console.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"email": schema.BoolAttribute{
+				Description:         "Whether or not to send email notifications. [Default: false]",
+				MarkdownDescription: "Whether or not to send email notifications. [Default: `false`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"event_type": schema.StringAttribute{
+				Description:         "Type of activity or threat event to which the rule applies (eg: newThreat).",
+				MarkdownDescription: "Type of activity or threat event to which the rule applies (eg: `newThreat`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the rule in the same format as the import ID.",
+				MarkdownDescription: "ID of the rule in the same format as the import ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description: "ID of the account or site to which the rule belongs. This is required unless the " +
+					"scope type is tenant.",
+				MarkdownDescription: "ID of the account or site to which the rule belongs. This is required unless the " +
+					"scope type is `tenant`.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the rule applies (valid values: tenant, account, site).",
+				MarkdownDescription: "Level at which the rule applies (valid values: `tenant`, `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_TENANT, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"syslog": schema.BoolAttribute{
+				Description:         "Whether or not to send syslog notifications. [Default: false]",
+				MarkdownDescription: "Whether or not to send syslog notifications. [Default: `false`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"webhook": schema.BoolAttribute{
+				Description:         "Whether or not to send webhook notifications. [Default: false]",
+				MarkdownDescription: "Whether or not to send webhook notifications. [Default: `false`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *NotificationRule) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_NOTIFICATION_RULE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *NotificationRule) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfNotificationRule
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// account and site rules must be given the ID of the account or site
+	if plan.ScopeType.ValueString() != api.SCOPE_TENANT && (plan.ScopeId.IsNull() || plan.ScopeId.ValueString() == "") {
+		msg := fmt.Sprintf("A scope ID must be given when the scope type is %s.", plan.ScopeType.ValueString())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"scope_type":          plan.ScopeType.ValueString(),
+			"internal_error_code": plugin.ERR_RESOURCE_NOTIFICATION_RULE_CREATE,
+		})
+		resp.Diagnostics.AddAttributeError(tfpath.Root("scope_id"), "Missing Scope ID", msg)
+		return
+	}
+
+	// the scope always has a rule so creating one simply overrides it
+	rule, diags := api.Client().UpdateNotificationRule(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		plan.EventType.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the rule to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfNotificationRuleFromAPI(ctx, rule, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *NotificationRule) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfNotificationRule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the current rule
+	rule, diags := api.Client().GetNotificationRule(ctx, scopeFromModel(state.ScopeType, state.ScopeId),
+		state.EventType.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfNotificationRuleFromAPI(ctx, rule, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *NotificationRule) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfNotificationRule
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the rule
+	rule, diags := api.Client().UpdateNotificationRule(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		plan.EventType.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated rule to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfNotificationRuleFromAPI(ctx, rule, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *NotificationRule) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfNotificationRule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// disable every channel for the event type
+	disabled := false
+	_, diags := api.Client().UpdateNotificationRule(ctx, scopeFromModel(state.ScopeType, state.ScopeId),
+		state.EventType.ValueString(), api.NotificationRuleBody{
+			Email:   &disabled,
+			Syslog:  &disabled,
+			Webhook: &disabled,
+		})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Disabled notification rule", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing notification rule into the Terraform state.
+//
+// The import ID must be in the format <scope_type>/<scope_id>/<event_type> or tenant/<event_type>.
+func (r *NotificationRule) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	valid := false
+	switch {
+	case len(parts) == 2:
+		valid = parts[0] == api.SCOPE_TENANT && parts[1] != ""
+	case len(parts) == 3:
+		valid = (parts[0] == api.SCOPE_ACCOUNT || parts[0] == api.SCOPE_SITE) && parts[1] != "" && parts[2] != ""
+	}
+	if !valid {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<event_type> where the scope "+
+			"type is one of: %s, %s or %s/<event_type> for rules which apply to the whole console.\n\nImport ID: %s",
+			api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_TENANT, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_NOTIFICATION_RULE_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("event_type"), parts[1])...)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("event_type"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating a notification rule.
+func (r *NotificationRule) bodyFromPlan(plan tfNotificationRule) api.NotificationRuleBody {
+	body := api.NotificationRuleBody{}
+
+	if !plan.Email.IsNull() && !plan.Email.IsUnknown() {
+		value := plan.Email.ValueBool()
+		body.Email = &value
+	}
+
+	if !plan.Syslog.IsNull() && !plan.Syslog.IsUnknown() {
+		value := plan.Syslog.ValueBool()
+		body.Syslog = &value
+	}
+
+	if !plan.Webhook.IsNull() && !plan.Webhook.IsUnknown() {
+		value := plan.Webhook.ValueBool()
+		body.Webhook = &value
+	}
+	return body
+}
+
+// tfNotificationRuleFromAPI converts an API notification rule into a Terraform notification rule.
+//
+// The API does not return the scope or event type of the rule so they are copied from the given model.
+func tfNotificationRuleFromAPI(ctx context.Context, rule *api.NotificationRule,
+	model tfNotificationRule) tfNotificationRule {
+
+	id := fmt.Sprintf("%s/%s", model.ScopeType.ValueString(), model.EventType.ValueString())
+	if model.ScopeType.ValueString() != api.SCOPE_TENANT {
+		id = fmt.Sprintf("%s/%s/%s", model.ScopeType.ValueString(), model.ScopeId.ValueString(),
+			model.EventType.ValueString())
+	}
+	tfrule := tfNotificationRule{
+		Email:     types.BoolValue(rule.Email),
+		EventType: model.EventType,
+		Id:        types.StringValue(id),
+		ScopeId:   model.ScopeId,
+		ScopeType: model.ScopeType,
+		Syslog:    types.BoolValue(rule.Syslog),
+		Webhook:   types.BoolValue(rule.Webhook),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API notification rule to TF notification rule: %+v", tfrule),
+		map[string]interface{}{
+			"api_notification_rule": rule,
+		})
+	return tfrule
+}
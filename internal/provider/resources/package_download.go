@@ -34,6 +34,7 @@ var (
 
 // tfPackageDownload defines the Terrform model for a package download.
 type tfPackageDownload struct {
+	DefenderExclusion     types.Bool   `tfsdk:"defender_exclusion"`
 	DirectoryMode         types.String `tfsdk:"directory_mode"`
 	FileMode              types.String `tfsdk:"file_mode"`
 	FileSize              types.Int64  `tfsdk:"file_size"`
@@ -73,6 +74,19 @@ func (r *PackageDownload) Schema(ctx context.Context, req resource.SchemaRequest
 		TODO: add more of a description on how to use this data source...
 		`,
 		Attributes: map[string]schema.Attribute{
+			"defender_exclusion": schema.BoolAttribute{
+				Description: "Whether or not to add the local folder to the Microsoft Defender exclusions before " +
+					"downloading the package so that the installer is not quarantined. Requires administrative " +
+					"privileges. The exclusion is not removed when the resource is destroyed. Ignored on systems other " +
+					"than Windows. [Default: false]",
+				MarkdownDescription: "Whether or not to add the local folder to the Microsoft Defender exclusions before " +
+					"downloading the package so that the installer is not quarantined. Requires administrative " +
+					"privileges. The exclusion is not removed when the resource is destroyed. Ignored on systems other " +
+					"than Windows. [Default: `false`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"directory_mode": schema.StringAttribute{
 				Description: "The permissions to set on any folders created when saving the file. " +
 					"Changing this value has no effect on existing folders. Ignored on Windows. [Default: 0755]",
@@ -249,6 +263,14 @@ func (r *PackageDownload) Create(ctx context.Context, req resource.CreateRequest
 	plan.FileSize = types.Int64Value(pkg.FileSize)
 	plan.SHA1 = types.StringValue(pkg.SHA1)
 
+	// keep the local anti-virus from quarantining the package while it is being used
+	if plan.DefenderExclusion.ValueBool() {
+		resp.Diagnostics.Append(plugin.AddDefenderExclusion(ctx, plan.LocalFolder.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// download the package file
 	outputFile, fileSize, sha1, version, diags := api.Client().DownloadPackage(ctx, packageId, siteId,
 		path.Join(plan.LocalFolder.ValueString(), plan.LocalFilename.ValueString()),
@@ -264,7 +286,8 @@ func (r *PackageDownload) Create(ctx context.Context, req resource.CreateRequest
 	// compare the downloaded file size and SHA1 to make sure they match what are expected
 	if fileSize != pkg.FileSize {
 		msg := fmt.Sprintf("The downloaded package size (%d) does not match the expected package size (%d). "+
-			"This may be a transient error. Please try again in a few minutes.", fileSize, pkg.FileSize)
+			"This may be a transient error. Please try again in a few minutes.%s", fileSize, pkg.FileSize,
+			plugin.QuarantineHint(outputFile))
 		tflog.Error(ctx, msg, map[string]interface{}{
 			"downloaded_package_size": fileSize,
 			"expected_package_size":   pkg.FileSize,
@@ -275,7 +298,8 @@ func (r *PackageDownload) Create(ctx context.Context, req resource.CreateRequest
 	}
 	if sha1 != pkg.SHA1 {
 		msg := fmt.Sprintf("The downloaded package SHA1 (%s) does not match the expected package SHA1 (%s). "+
-			"This may be a transient error. Please try again in a few minutes.", sha1, pkg.SHA1)
+			"This may be a transient error. Please try again in a few minutes.%s", sha1, pkg.SHA1,
+			plugin.QuarantineHint(outputFile))
 		tflog.Error(ctx, msg, map[string]interface{}{
 			"downloaded_package_sha1": sha1,
 			"expected_package_sha1":   pkg.SHA1,
@@ -317,6 +341,10 @@ func (r *PackageDownload) Read(ctx context.Context, req resource.ReadRequest, re
 		tflog.Debug(ctx, "Package file no longer exists on the local system.", map[string]interface{}{
 			"file": absPath,
 		})
+		if hint := plugin.QuarantineHint(absPath); hint != "" {
+			resp.Diagnostics.AddWarning("Package File Missing", fmt.Sprintf("The package file no longer exists "+
+				"and will be downloaded again.\n\nFile: %s%s", absPath, hint))
+		}
 		resp.State.RemoveResource(ctx)
 		return
 	} else if err != nil {
@@ -402,6 +430,17 @@ func (r *PackageDownload) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	// exclude the new folder from scanning before the file is moved into it
+	if !plan.DefenderExclusion.IsNull() && !plan.DefenderExclusion.IsUnknown() {
+		state.DefenderExclusion = plan.DefenderExclusion
+	}
+	if state.DefenderExclusion.ValueBool() && srcPath != destPath {
+		resp.Diagnostics.Append(plugin.AddDefenderExclusion(ctx, folder)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// if filename or folder has changed, move the file
 	if srcPath != destPath {
 		state.OutputFile = types.StringValue(destPath)
@@ -414,16 +453,8 @@ func (r *PackageDownload) Update(ctx context.Context, req resource.UpdateRequest
 		}
 
 		// move the file
-		if err := os.Rename(srcPath, destPath); err != nil {
-			msg := fmt.Sprintf("An unexpected error occurred while moving the package file.\n\n"+
-				"Error: %s\nSource: %s\nDestination: %s", err.Error(), srcPath, destPath)
-			tflog.Error(ctx, msg, map[string]interface{}{
-				"error":               err.Error(),
-				"internal_error_code": plugin.ERR_RESOURCE_PACKAGE_DOWNLOAD_UPDATE,
-				"src_path":            srcPath,
-				"dest_path":           destPath,
-			})
-			resp.Diagnostics.AddError("Download Package Update Error", msg)
+		resp.Diagnostics.Append(plugin.MoveFile(ctx, srcPath, destPath, true)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
 		tflog.Debug(ctx, "Moved package file", map[string]interface{}{
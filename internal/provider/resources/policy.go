@@ -0,0 +1,371 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Policy{}
+	_ resource.ResourceWithConfigure   = &Policy{}
+	_ resource.ResourceWithImportState = &Policy{}
+)
+
+// tfPolicy defines the Terraform model for a site policy.
+type tfPolicy struct {
+	AgentNotification        types.Bool   `tfsdk:"agent_notification"`
+	AgentUiOn                types.Bool   `tfsdk:"agent_ui_on"`
+	AutoMitigationAction     types.String `tfsdk:"auto_mitigation_action"`
+	DeepVisibilityOn         types.Bool   `tfsdk:"deep_visibility_on"`
+	Id                       types.String `tfsdk:"id"`
+	InheritedFrom            types.String `tfsdk:"inherited_from"`
+	MitigationMode           types.String `tfsdk:"mitigation_mode"`
+	MitigationModeSuspicious types.String `tfsdk:"mitigation_mode_suspicious"`
+	ScanNewAgents            types.Bool   `tfsdk:"scan_new_agents"`
+	ScopeAccountId           types.String `tfsdk:"scope_account_id"`
+	SiteId                   types.String `tfsdk:"site_id"`
+	SnapshotsOn              types.Bool   `tfsdk:"snapshots_on"`
+}
+
+// NewPolicy creates a new Policy object.
+func NewPolicy() resource.Resource {
+	return &Policy{}
+}
+
+// Policy is a resource used to manage the protection policy of a site.
+type Policy struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Policy) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Policy) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the protection policy of a site.",
+		MarkdownDescription: `This resource is used for managing the protection policy of a site.
+
+		Any setting which is not configured keeps the value of the site's effective policy. Destroying the resource
+		reverts the site to the policy inherited from its parent account. Existing site policies can be imported
+		using the site ID.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agent_notification": schema.BoolAttribute{
+				Description:         "Whether or not agents show a notification to the user when a threat is detected.",
+				MarkdownDescription: "Whether or not agents show a notification to the user when a threat is detected.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"agent_ui_on": schema.BoolAttribute{
+				Description:         "Whether or not the agent UI is shown to the user.",
+				MarkdownDescription: "Whether or not the agent UI is shown to the user.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"auto_mitigation_action": schema.StringAttribute{
+				Description: "Action taken when a threat is mitigated in protect mode (valid values: mitigation.none, " +
+					"mitigation.killThreat, mitigation.quarantineThreat, mitigation.remediateThreat).",
+				MarkdownDescription: "Action taken when a threat is mitigated in protect mode (valid values: " +
+					"`mitigation.none`, `mitigation.killThreat`, `mitigation.quarantineThreat`, " +
+					"`mitigation.remediateThreat`).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false,
+						"mitigation.none", "mitigation.killThreat", "mitigation.quarantineThreat",
+						"mitigation.remediateThreat",
+					),
+				},
+			},
+			"deep_visibility_on": schema.BoolAttribute{
+				Description:         "Whether or not Deep Visibility data is collected from agents.",
+				MarkdownDescription: "Whether or not Deep Visibility data is collected from agents.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the policy (the same as the site ID).",
+				MarkdownDescription: "ID of the policy (the same as the site ID).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inherited_from": schema.StringAttribute{
+				Description:         "Scope from which the policy was inherited, if any.",
+				MarkdownDescription: "Scope from which the policy was inherited, if any.",
+				Computed:            true,
+			},
+			"mitigation_mode": schema.StringAttribute{
+				Description:         "How agents respond to malicious threats (valid values: detect, protect).",
+				MarkdownDescription: "How agents respond to malicious threats (valid values: `detect`, `protect`).",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "detect", "protect"),
+				},
+			},
+			"mitigation_mode_suspicious": schema.StringAttribute{
+				Description:         "How agents respond to suspicious activity (valid values: detect, protect).",
+				MarkdownDescription: "How agents respond to suspicious activity (valid values: `detect`, `protect`).",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "detect", "protect"),
+				},
+			},
+			"scan_new_agents": schema.BoolAttribute{
+				Description:         "Whether or not a full disk scan is run when a new agent is installed.",
+				MarkdownDescription: "Whether or not a full disk scan is run when a new agent is installed.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_account_id": scopeAccountIdAttribute("policy"),
+			"site_id": schema.StringAttribute{
+				Description:         "ID of the site whose policy is managed.",
+				MarkdownDescription: "ID of the site whose policy is managed.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"snapshots_on": schema.BoolAttribute{
+				Description:         "Whether or not Windows VSS snapshots are taken for use in rollback remediation.",
+				MarkdownDescription: "Whether or not Windows VSS snapshots are taken for use in rollback remediation.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Policy) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_POLICY_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Policy) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfPolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// the site always has a policy so creating one simply overrides the configured settings
+	siteId := plan.SiteId.ValueString() // always required so no need to check
+	policy, diags := api.Client().UpdateSitePolicy(ctx, siteId, r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the policy to the state
+	tfpolicy := tfPolicyFromAPI(ctx, policy, siteId)
+	tfpolicy.ScopeAccountId = plan.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Policy) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfPolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// get the effective policy of the site
+	siteId := state.SiteId.ValueString()
+	policy, diags := api.Client().GetSitePolicy(ctx, siteId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	tfpolicy := tfPolicyFromAPI(ctx, policy, siteId)
+	tfpolicy.ScopeAccountId = state.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Policy) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfPolicy
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// update the policy
+	siteId := plan.SiteId.ValueString()
+	policy, diags := api.Client().UpdateSitePolicy(ctx, siteId, r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated policy to the state
+	tfpolicy := tfPolicyFromAPI(ctx, policy, siteId)
+	tfpolicy.ScopeAccountId = plan.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfpolicy)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Policy) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfPolicy
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// revert the site to its inherited policy
+	resp.Diagnostics.Append(api.Client().RevertSitePolicy(ctx, state.SiteId.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Reverted site policy", map[string]interface{}{
+		"site_id": state.SiteId.ValueString(),
+	})
+}
+
+// ImportState imports the policy of an existing site into the Terraform state using the site ID.
+func (r *Policy) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, tfpath.Root("site_id"), req, resp)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating a policy.
+func (r *Policy) bodyFromPlan(plan tfPolicy) api.PolicyBody {
+	body := api.PolicyBody{}
+
+	if !plan.AgentNotification.IsNull() && !plan.AgentNotification.IsUnknown() {
+		value := plan.AgentNotification.ValueBool()
+		body.AgentNotification = &value
+	}
+
+	if !plan.AgentUiOn.IsNull() && !plan.AgentUiOn.IsUnknown() {
+		value := plan.AgentUiOn.ValueBool()
+		body.AgentUiOn = &value
+	}
+
+	if !plan.AutoMitigationAction.IsNull() && !plan.AutoMitigationAction.IsUnknown() {
+		value := plan.AutoMitigationAction.ValueString()
+		body.AutoMitigationAction = &value
+	}
+
+	if !plan.DeepVisibilityOn.IsNull() && !plan.DeepVisibilityOn.IsUnknown() {
+		value := plan.DeepVisibilityOn.ValueBool()
+		body.Ioc = &value
+	}
+
+	if !plan.MitigationMode.IsNull() && !plan.MitigationMode.IsUnknown() {
+		value := plan.MitigationMode.ValueString()
+		body.MitigationMode = &value
+	}
+
+	if !plan.MitigationModeSuspicious.IsNull() && !plan.MitigationModeSuspicious.IsUnknown() {
+		value := plan.MitigationModeSuspicious.ValueString()
+		body.MitigationModeSuspicious = &value
+	}
+
+	if !plan.ScanNewAgents.IsNull() && !plan.ScanNewAgents.IsUnknown() {
+		value := plan.ScanNewAgents.ValueBool()
+		body.ScanNewAgents = &value
+	}
+
+	if !plan.SnapshotsOn.IsNull() && !plan.SnapshotsOn.IsUnknown() {
+		value := plan.SnapshotsOn.ValueBool()
+		body.SnapshotsOn = &value
+	}
+	return body
+}
+
+// tfPolicyFromAPI converts an API policy into a Terraform policy.
+func tfPolicyFromAPI(ctx context.Context, policy *api.Policy, siteId string) tfPolicy {
+	tfpolicy := tfPolicy{
+		AgentNotification:        types.BoolValue(policy.AgentNotification),
+		AgentUiOn:                types.BoolValue(policy.AgentUi.AgentUiOn),
+		AutoMitigationAction:     types.StringValue(policy.AutoMitigationAction),
+		DeepVisibilityOn:         types.BoolValue(policy.Ioc),
+		Id:                       types.StringValue(siteId),
+		InheritedFrom:            types.StringValue(policy.InheritedFrom),
+		MitigationMode:           types.StringValue(policy.MitigationMode),
+		MitigationModeSuspicious: types.StringValue(policy.MitigationModeSuspicious),
+		ScanNewAgents:            types.BoolValue(policy.ScanNewAgents),
+		SiteId:                   types.StringValue(siteId),
+		SnapshotsOn:              types.BoolValue(policy.SnapshotsOn),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API policy to TF policy: %+v", tfpolicy), map[string]interface{}{
+		"api_policy": policy,
+	})
+	return tfpolicy
+}
@@ -0,0 +1,272 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &PolicyInheritance{}
+	_ resource.ResourceWithConfigure   = &PolicyInheritance{}
+	_ resource.ResourceWithImportState = &PolicyInheritance{}
+)
+
+// tfPolicyInheritance defines the Terraform model for the policy inheritance of a scope.
+type tfPolicyInheritance struct {
+	Id            types.String `tfsdk:"id"`
+	Inherit       types.Bool   `tfsdk:"inherit"`
+	InheritedFrom types.String `tfsdk:"inherited_from"`
+	ScopeId       types.String `tfsdk:"scope_id"`
+	ScopeType     types.String `tfsdk:"scope_type"`
+}
+
+// NewPolicyInheritance creates a new PolicyInheritance object.
+func NewPolicyInheritance() resource.Resource {
+	return &PolicyInheritance{}
+}
+
+// PolicyInheritance is a resource used to manage whether a site or group inherits the policy of its parent.
+type PolicyInheritance struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *PolicyInheritance) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_inheritance"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *PolicyInheritance) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing whether a site or group inherits the policy of its parent.",
+		MarkdownDescription: `This resource is used for managing whether a site or group inherits the policy of its
+			parent.
+
+		A site inherits the policy of its account and a group inherits the policy of its site until its own policy is
+		changed. This resource makes breaking or restoring the inheritance explicit so that it is tracked in the state,
+		including when it is broken by a policy change made elsewhere. When inheritance is broken, the scope keeps its
+		current effective policy. Destroying the resource leaves the inheritance as it is. Existing scopes can be
+		imported using an ID in the format ` + "`<scope_type>/<scope_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "ID of the policy inheritance (in the format <scope_type>/<scope_id>).",
+				MarkdownDescription: "ID of the policy inheritance (in the format `<scope_type>/<scope_id>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"inherit": schema.BoolAttribute{
+				Description:         "Whether or not the scope inherits the policy of its parent.",
+				MarkdownDescription: "Whether or not the scope inherits the policy of its parent.",
+				Required:            true,
+			},
+			"inherited_from": schema.StringAttribute{
+				Description:         "Scope from which the policy is inherited, if any.",
+				MarkdownDescription: "Scope from which the policy is inherited, if any.",
+				Computed:            true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the site or group.",
+				MarkdownDescription: "ID of the site or group.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Type of the scope (valid values: site, group).",
+				MarkdownDescription: "Type of the scope (valid values: `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *PolicyInheritance) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_POLICY_INHERITANCE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *PolicyInheritance) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfPolicyInheritance
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// apply the inheritance and save it to the state
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *PolicyInheritance) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfPolicyInheritance
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the effective policy of the scope
+	policy, diags := api.Client().GetPolicy(ctx, scopeFromModel(state.ScopeType, state.ScopeId))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfPolicyInheritanceFromAPI(ctx, policy, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *PolicyInheritance) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfPolicyInheritance
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// apply the inheritance and save it to the state
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The inheritance is left as it is since there is no way to know what it was before the resource was created.
+func (r *PolicyInheritance) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState imports the policy inheritance of an existing site or group into the Terraform state.
+//
+// The import ID must be in the format <scope_type>/<scope_id>.
+func (r *PolicyInheritance) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || (parts[0] != api.SCOPE_SITE && parts[0] != api.SCOPE_GROUP) || parts[1] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id> where the scope type is one "+
+			"of: %s, %s.\n\nImport ID: %s", api.SCOPE_SITE, api.SCOPE_GROUP, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_POLICY_INHERITANCE_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), req.ID)...)
+}
+
+// apply breaks or restores the policy inheritance of the scope in the given model and refreshes the model's computed
+// attributes.
+//
+// Nothing is changed if the scope already matches the model.
+func (r *PolicyInheritance) apply(ctx context.Context, model *tfPolicyInheritance) diag.Diagnostics {
+	scope := scopeFromModel(model.ScopeType, model.ScopeId)
+	policy, diags := api.Client().GetPolicy(ctx, scope)
+	if diags.HasError() {
+		return diags
+	}
+	inherit := model.Inherit.ValueBool()
+	if inherit != (policy.InheritedFrom != "") {
+		if inherit {
+			diags = api.Client().RevertPolicy(ctx, scope)
+		} else {
+			diags = api.Client().BreakPolicyInheritance(ctx, scope)
+		}
+		if diags.HasError() {
+			return diags
+		}
+		if policy, diags = api.Client().GetPolicy(ctx, scope); diags.HasError() {
+			return diags
+		}
+		tflog.Debug(ctx, "Changed policy inheritance", map[string]interface{}{
+			"inherit":    inherit,
+			"scope_id":   scope.Id,
+			"scope_type": scope.Type,
+		})
+	}
+	// the planned value is kept since Terraform requires it to match after the apply
+	*model = tfPolicyInheritanceFromAPI(ctx, policy, *model)
+	model.Inherit = types.BoolValue(inherit)
+	return diags
+}
+
+// tfPolicyInheritanceFromAPI converts the inheritance of an API policy into a Terraform policy inheritance.
+//
+// The API does not return the scope of the policy so it is copied from the given model.
+func tfPolicyInheritanceFromAPI(ctx context.Context, policy *api.Policy,
+	model tfPolicyInheritance) tfPolicyInheritance {
+
+	tfinheritance := tfPolicyInheritance{
+		Id:            types.StringValue(fmt.Sprintf("%s/%s", model.ScopeType.ValueString(), model.ScopeId.ValueString())),
+		Inherit:       types.BoolValue(policy.InheritedFrom != ""),
+		InheritedFrom: types.StringValue(policy.InheritedFrom),
+		ScopeId:       model.ScopeId,
+		ScopeType:     model.ScopeType,
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API policy to TF policy inheritance: %+v", tfinheritance),
+		map[string]interface{}{
+			"api_policy": policy,
+		})
+	return tfinheritance
+}
@@ -0,0 +1,323 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &ProtectedActions{}
+	_ resource.ResourceWithConfigure   = &ProtectedActions{}
+	_ resource.ResourceWithImportState = &ProtectedActions{}
+)
+
+// tfProtectedActions defines the Terraform model for the protected actions settings.
+type tfProtectedActions struct {
+	Actions   types.Set    `tfsdk:"actions"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	Id        types.String `tfsdk:"id"`
+	ScopeId   types.String `tfsdk:"scope_id"`
+	ScopeType types.String `tfsdk:"scope_type"`
+}
+
+// NewProtectedActions creates a new ProtectedActions object.
+func NewProtectedActions() resource.Resource {
+	return &ProtectedActions{}
+}
+
+// ProtectedActions is a resource used to manage which sensitive operations require two-factor authentication within
+// an account or site.
+type ProtectedActions struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ProtectedActions) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_protected_actions"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ProtectedActions) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing which sensitive operations require two-factor " +
+			"authentication within an account or site.",
+		MarkdownDescription: `This resource is used for managing which sensitive operations require two-factor
+			authentication within an account or site.
+
+		Once protected actions are enabled, console users must approve each of the given actions with their second
+		factor before it is performed. Every account and site always has protected actions settings, so creating the
+		resource overrides the existing settings and destroying it disables protected actions. Existing settings can be
+		imported using an ID in the format ` + "`<scope_type>/<scope_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"actions": schema.SetAttribute{
+				Description: "Actions which require two-factor authentication (eg: uninstall, unquarantine, " +
+					"disable_agent, decommission, disconnect_from_network).",
+				MarkdownDescription: "Actions which require two-factor authentication (eg: `uninstall`, " +
+					"`unquarantine`, `disable_agent`, `decommission`, `disconnect_from_network`).",
+				ElementType: types.StringType,
+				Required:    true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not protected actions are enabled. [Default: true]",
+				MarkdownDescription: "Whether or not protected actions are enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the settings (in the format <scope_type>/<scope_id>).",
+				MarkdownDescription: "ID of the settings (in the format `<scope_type>/<scope_id>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the settings belong.",
+				MarkdownDescription: "ID of the account or site to which the settings belong.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the settings apply (valid values: account, site).",
+				MarkdownDescription: "Level at which the settings apply (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ProtectedActions) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_PROTECTED_ACTIONS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *ProtectedActions) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfProtectedActions
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the scope always has settings so creating them simply overrides them
+	settings, diags := api.Client().UpdateProtectedActionsSettings(ctx, scopeFromModel(plan.ScopeType,
+		plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the settings to the state
+	tfsettings, diags := tfProtectedActionsFromAPI(ctx, settings, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsettings)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *ProtectedActions) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfProtectedActions
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the current settings of the scope
+	settings, diags := api.Client().GetProtectedActionsSettings(ctx, scopeFromModel(state.ScopeType, state.ScopeId))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	tfsettings, diags := tfProtectedActionsFromAPI(ctx, settings, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsettings)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *ProtectedActions) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfProtectedActions
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the settings
+	settings, diags := api.Client().UpdateProtectedActionsSettings(ctx, scopeFromModel(plan.ScopeType,
+		plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated settings to the state
+	tfsettings, diags := tfProtectedActionsFromAPI(ctx, settings, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsettings)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *ProtectedActions) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfProtectedActions
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// disable protected actions for the scope
+	enabled := false
+	_, diags := api.Client().UpdateProtectedActionsSettings(ctx, scopeFromModel(state.ScopeType, state.ScopeId),
+		api.ProtectedActionsSettingsBody{Enabled: &enabled})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Disabled protected actions", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports the protected actions settings of an existing account or site into the Terraform state.
+//
+// The import ID must be in the format <scope_type>/<scope_id>.
+func (r *ProtectedActions) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id> where the scope type is one "+
+			"of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_PROTECTED_ACTIONS_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), req.ID)...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating the protected actions settings.
+func (r *ProtectedActions) bodyFromPlan(ctx context.Context, plan tfProtectedActions) (
+	api.ProtectedActionsSettingsBody, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	body := api.ProtectedActionsSettingsBody{}
+
+	if !plan.Actions.IsNull() && !plan.Actions.IsUnknown() {
+		body.Actions = []string{}
+		diags.Append(plan.Actions.ElementsAs(ctx, &body.Actions, false)...)
+		if diags.HasError() {
+			return body, diags
+		}
+	}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := plan.Enabled.ValueBool()
+		body.Enabled = &value
+	}
+	return body, diags
+}
+
+// tfProtectedActionsFromAPI converts API protected actions settings into Terraform protected actions settings.
+//
+// The API does not return the scope of the settings so it is copied from the given model.
+func tfProtectedActionsFromAPI(ctx context.Context, settings *api.ProtectedActionsSettings,
+	model tfProtectedActions) (tfProtectedActions, diag.Diagnostics) {
+
+	id := fmt.Sprintf("%s/%s", model.ScopeType.ValueString(), model.ScopeId.ValueString())
+	tfsettings := tfProtectedActions{
+		Enabled:   types.BoolValue(settings.Enabled),
+		Id:        types.StringValue(id),
+		ScopeId:   model.ScopeId,
+		ScopeType: model.ScopeType,
+	}
+	actions := settings.Actions
+	if actions == nil {
+		actions = []string{}
+	}
+	var diags diag.Diagnostics
+	tfsettings.Actions, diags = types.SetValueFrom(ctx, types.StringType, actions)
+	if diags.HasError() {
+		return tfsettings, diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API protected actions settings to TF protected actions settings: %+v",
+		tfsettings), map[string]interface{}{
+		"api_protected_actions_settings": settings,
+	})
+	return tfsettings, diags
+}
@@ -0,0 +1,413 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api/waiter"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+const (
+	// rangerDeployStateCompleted is the waiter state used once the deployment is no longer running on any device.
+	rangerDeployStateCompleted = "completed"
+
+	// rangerDeployStatePending is the waiter state used while the deployment is running on any device.
+	rangerDeployStatePending = "pending"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &RangerDeployJob{}
+	_ resource.ResourceWithConfigure = &RangerDeployJob{}
+)
+
+// tfRangerDeployJob defines the Terraform model for a Ranger agent deployment job.
+type tfRangerDeployJob struct {
+	AffectedCount types.Int64    `tfsdk:"affected_count"`
+	CredentialId  types.String   `tfsdk:"credential_id"`
+	DeviceIds     []types.String `tfsdk:"device_ids"`
+	JobId         types.String   `tfsdk:"job_id"`
+	OSTypes       []types.String `tfsdk:"os_types"`
+	PackageId     types.String   `tfsdk:"package_id"`
+	PollInterval  types.String   `tfsdk:"poll_interval"`
+	SiteIds       []types.String `tfsdk:"site_ids"`
+	Targets       types.List     `tfsdk:"targets"`
+	Triggers      types.Map      `tfsdk:"triggers"`
+	WaitTimeout   types.String   `tfsdk:"wait_timeout"`
+}
+
+// tfRangerDeployTarget defines the Terraform model for the result of a deployment on a single device.
+type tfRangerDeployTarget struct {
+	DeviceId          types.String `tfsdk:"device_id"`
+	Hostname          types.String `tfsdk:"hostname"`
+	IPAddress         types.String `tfsdk:"ip_address"`
+	Status            types.String `tfsdk:"status"`
+	StatusDescription types.String `tfsdk:"status_description"`
+}
+
+// tfRangerDeployTargetAttrTypes defines the attribute types of a tfRangerDeployTarget object.
+var tfRangerDeployTargetAttrTypes = map[string]attr.Type{
+	"device_id":          types.StringType,
+	"hostname":           types.StringType,
+	"ip_address":         types.StringType,
+	"status":             types.StringType,
+	"status_description": types.StringType,
+}
+
+// NewRangerDeployJob creates a new RangerDeployJob object.
+func NewRangerDeployJob() resource.Resource {
+	return &RangerDeployJob{}
+}
+
+// RangerDeployJob is a resource used to deploy agents to unmanaged devices discovered by Ranger.
+type RangerDeployJob struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *RangerDeployJob) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_ranger_deploy_job"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *RangerDeployJob) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for deploying agents to unmanaged devices discovered by Ranger.",
+		MarkdownDescription: `This resource is used for deploying agents to unmanaged devices discovered by Ranger.
+
+		The deployment job is started when the resource is created using the Ranger credentials with the given ID
+		and the progress on each target device is polled every ` + "`poll_interval`" + ` until it is no longer
+		running on any device, failing if that does not happen within ` + "`wait_timeout`" + `. The result for each
+		device is recorded in ` + "`targets`" + `. Devices on which the deployment failed are reported as a warning
+		rather than an error so that the deployment is not started again on the next apply.
+
+		Destroying the resource does not uninstall the agents. To deploy again, change any value in
+		` + "`triggers`" + `, which causes the resource to be replaced.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"affected_count": schema.Int64Attribute{
+				Description:         "Number of devices targeted by the deployment.",
+				MarkdownDescription: "Number of devices targeted by the deployment.",
+				Computed:            true,
+			},
+			"credential_id": schema.StringAttribute{
+				Description: "ID of the Ranger credentials used to connect to the devices, as configured in the " +
+					"console.",
+				MarkdownDescription: "ID of the Ranger credentials used to connect to the devices, as configured in " +
+					"the console.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"device_ids": schema.ListAttribute{
+				Description:         "IDs of the unmanaged devices to which agents are deployed.",
+				MarkdownDescription: "IDs of the unmanaged devices to which agents are deployed.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"job_id": schema.StringAttribute{
+				Description:         "ID of the deployment job.",
+				MarkdownDescription: "ID of the deployment job.",
+				Computed:            true,
+			},
+			"os_types": schema.ListAttribute{
+				Description:         "List of OS types to which the deployment is limited (eg: linux, macos, windows).",
+				MarkdownDescription: "List of OS types to which the deployment is limited (eg: `linux`, `macos`, `windows`).",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"package_id": schema.StringAttribute{
+				Description: "ID of the agent package to deploy. If not given, the console chooses the package " +
+					"based on the OS of each device.",
+				MarkdownDescription: "ID of the agent package to deploy. If not given, the console chooses the " +
+					"package based on the OS of each device.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				Description:         "How often to poll the deployment progress (eg: 10s, 1m). [Default: 30s]",
+				MarkdownDescription: "How often to poll the deployment progress (eg: `10s`, `1m`). [Default: `30s`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30s"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"site_ids": schema.ListAttribute{
+				Description:         "IDs of the sites whose unmanaged devices are targeted.",
+				MarkdownDescription: "IDs of the sites whose unmanaged devices are targeted.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"targets": schema.ListNestedAttribute{
+				Description:         "Result of the deployment on each device.",
+				MarkdownDescription: "Result of the deployment on each device.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"device_id": schema.StringAttribute{
+							Description:         "ID of the device.",
+							MarkdownDescription: "ID of the device.",
+							Computed:            true,
+						},
+						"hostname": schema.StringAttribute{
+							Description:         "Hostname of the device.",
+							MarkdownDescription: "Hostname of the device.",
+							Computed:            true,
+						},
+						"ip_address": schema.StringAttribute{
+							Description:         "IP address of the device.",
+							MarkdownDescription: "IP address of the device.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "Status of the deployment on the device.",
+							MarkdownDescription: "Status of the deployment on the device.",
+							Computed:            true,
+						},
+						"status_description": schema.StringAttribute{
+							Description:         "Detailed description of the status (eg: the reason for a failure).",
+							MarkdownDescription: "Detailed description of the status (eg: the reason for a failure).",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"deployment to be started again.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"deployment to be started again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for the deployment to finish on every device (eg: 30m, 2h). " +
+					"[Default: 1h]",
+				MarkdownDescription: "Maximum time to wait for the deployment to finish on every device (eg: `30m`, " +
+					"`2h`). [Default: `1h`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("1h"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *RangerDeployJob) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_RANGER_DEPLOY_JOB_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *RangerDeployJob) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfRangerDeployJob
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	interval, diags := plugin.ParseRelativeDuration(ctx, plan.PollInterval.ValueString())
+	resp.Diagnostics.Append(diags...)
+	timeout, diags := plugin.ParseRelativeDuration(ctx, plan.WaitTimeout.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the deployment must be limited to specific devices or sites to avoid deploying to every unmanaged device
+	body := r.bodyFromPlan(plan)
+	if len(body.DeviceIds) == 0 && len(body.SiteIds) == 0 {
+		msg := "At least one device ID or site ID must be given in order to select the devices to which agents " +
+			"are deployed."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_RANGER_DEPLOY_JOB_CREATE,
+		})
+		resp.Diagnostics.AddError("Ranger Deploy Job Creation Error", msg)
+		return
+	}
+
+	// start the deployment
+	job, diags := api.Client().CreateRangerDeployJob(ctx, body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.AffectedCount = types.Int64Value(int64(job.Affected))
+	plan.JobId = types.StringValue(job.Id)
+	tflog.Info(ctx, "Started Ranger deployment job", map[string]interface{}{
+		"job_id":           job.Id,
+		"devices_affected": job.Affected,
+	})
+
+	// wait for the deployment to finish on every device
+	targets, waitDiags := waiter.Wait(ctx, waiter.Config{
+		Description: "Ranger deployment",
+		Interval:    interval,
+		Pending:     []string{rangerDeployStatePending},
+		Target:      []string{rangerDeployStateCompleted},
+		Timeout:     timeout,
+	}, func(ctx context.Context) ([]api.RangerDeployTarget, string, diag.Diagnostics) {
+		targets, diags := api.Client().FindRangerDeployTargets(ctx, job.Id)
+		if diags.HasError() {
+			return nil, "", diags
+		}
+		for _, target := range targets {
+			if target.Status == "pending" || target.Status == "in_progress" {
+				return targets, rangerDeployStatePending, diags
+			}
+		}
+		return targets, rangerDeployStateCompleted, diags
+	})
+
+	// record the result for each device
+	tftargets := []tfRangerDeployTarget{}
+	failed := 0
+	for _, target := range targets {
+		if target.Status == "failed" {
+			failed++
+		}
+		tftargets = append(tftargets, tfRangerDeployTarget{
+			DeviceId:          types.StringValue(target.DeviceId),
+			Hostname:          types.StringValue(target.Hostname),
+			IPAddress:         types.StringValue(target.IPAddress),
+			Status:            types.StringValue(target.Status),
+			StatusDescription: types.StringValue(target.StatusDescription),
+		})
+	}
+	plan.Targets, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfRangerDeployTargetAttrTypes},
+		tftargets)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if failed > 0 {
+		resp.Diagnostics.AddWarning("Ranger Deployment Failed On Some Devices", fmt.Sprintf("The deployment "+
+			"failed on %d devices. See the targets attribute for the result on each device.", failed))
+	}
+
+	// save the plan to the state even if waiting failed so the job is tracked - the resource is marked as tainted
+	// in that case
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(waitDiags...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// A deployment job cannot be changed once it has been started so there is nothing to refresh.
+func (r *RangerDeployJob) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the polling settings can be updated in place so there is nothing to do other than saving them.
+func (r *RangerDeployJob) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfRangerDeployJob
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfRangerDeployJob
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.AffectedCount = state.AffectedCount
+	plan.JobId = state.JobId
+	plan.Targets = state.Targets
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// Deployed agents are not uninstalled so the resource is simply removed from the state.
+func (r *RangerDeployJob) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for starting a deployment job.
+func (r *RangerDeployJob) bodyFromPlan(plan tfRangerDeployJob) api.RangerDeployBody {
+	body := api.RangerDeployBody{
+		CredentialId: plan.CredentialId.ValueString(),
+	}
+
+	for _, v := range plan.DeviceIds {
+		body.DeviceIds = append(body.DeviceIds, v.ValueString())
+	}
+
+	for _, v := range plan.OSTypes {
+		body.OSTypes = append(body.OSTypes, v.ValueString())
+	}
+
+	if !plan.PackageId.IsNull() && !plan.PackageId.IsUnknown() {
+		value := plan.PackageId.ValueString()
+		body.PackageId = &value
+	}
+
+	for _, v := range plan.SiteIds {
+		body.SiteIds = append(body.SiteIds, v.ValueString())
+	}
+	return body
+}
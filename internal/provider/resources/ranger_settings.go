@@ -0,0 +1,318 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &RangerSettings{}
+	_ resource.ResourceWithConfigure   = &RangerSettings{}
+	_ resource.ResourceWithImportState = &RangerSettings{}
+)
+
+// tfRangerSettings defines the Terraform model for the Ranger settings of a site.
+type tfRangerSettings struct {
+	DeviceDecommissionDays types.Int64    `tfsdk:"device_decommission_days"`
+	DeviceReviewEnabled    types.Bool     `tfsdk:"device_review_enabled"`
+	Enabled                types.Bool     `tfsdk:"enabled"`
+	ExcludedSubnets        []types.String `tfsdk:"excluded_subnets"`
+	Id                     types.String   `tfsdk:"id"`
+	IncludedSubnets        []types.String `tfsdk:"included_subnets"`
+	ScanIntervalMinutes    types.Int64    `tfsdk:"scan_interval_minutes"`
+	SiteId                 types.String   `tfsdk:"site_id"`
+}
+
+// NewRangerSettings creates a new RangerSettings object.
+func NewRangerSettings() resource.Resource {
+	return &RangerSettings{}
+}
+
+// RangerSettings is a resource used to manage the Ranger network discovery settings of a site.
+type RangerSettings struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *RangerSettings) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_ranger_settings"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *RangerSettings) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the Ranger network discovery settings of a site.",
+		MarkdownDescription: `This resource is used for managing the Ranger network discovery settings of a site.
+
+		Any setting which is not configured keeps the site's current value, except for the subnet lists which are
+		always replaced by the configured lists. Destroying the resource disables Ranger for the site. Existing
+		settings can be imported using the site ID.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"device_decommission_days": schema.Int64Attribute{
+				Description: "Number of days after which a device which has not been seen is decommissioned (0 " +
+					"never decommissions devices).",
+				MarkdownDescription: "Number of days after which a device which has not been seen is decommissioned " +
+					"(`0` never decommissions devices).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"device_review_enabled": schema.BoolAttribute{
+				Description:         "Whether or not newly discovered devices must be reviewed in the console.",
+				MarkdownDescription: "Whether or not newly discovered devices must be reviewed in the console.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not Ranger network discovery is enabled for the site.",
+				MarkdownDescription: "Whether or not Ranger network discovery is enabled for the site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"excluded_subnets": schema.ListAttribute{
+				Description:         "Subnets (in CIDR notation) which are never scanned. [Default: none]",
+				MarkdownDescription: "Subnets (in CIDR notation) which are never scanned. [Default: none]",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the settings (the same as the site ID).",
+				MarkdownDescription: "ID of the settings (the same as the site ID).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"included_subnets": schema.ListAttribute{
+				Description: "Subnets (in CIDR notation) to scan in addition to those local to the agents. " +
+					"[Default: none]",
+				MarkdownDescription: "Subnets (in CIDR notation) to scan in addition to those local to the agents. " +
+					"[Default: none]",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"scan_interval_minutes": schema.Int64Attribute{
+				Description:         "Number of minutes between periodic network scans.",
+				MarkdownDescription: "Number of minutes between periodic network scans.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"site_id": schema.StringAttribute{
+				Description:         "ID of the site whose Ranger settings are managed.",
+				MarkdownDescription: "ID of the site whose Ranger settings are managed.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *RangerSettings) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_RANGER_SETTINGS_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *RangerSettings) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfRangerSettings
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the site always has Ranger settings so creating them simply overrides the configured settings
+	siteId := plan.SiteId.ValueString() // always required so no need to check
+	settings, diags := api.Client().UpdateRangerSettings(ctx, siteId, r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the settings to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfRangerSettingsFromAPI(ctx, settings, siteId))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *RangerSettings) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfRangerSettings
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the Ranger settings of the site
+	siteId := state.SiteId.ValueString()
+	settings, diags := api.Client().GetRangerSettings(ctx, siteId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfRangerSettingsFromAPI(ctx, settings, siteId))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *RangerSettings) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfRangerSettings
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the settings
+	siteId := plan.SiteId.ValueString()
+	settings, diags := api.Client().UpdateRangerSettings(ctx, siteId, r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated settings to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfRangerSettingsFromAPI(ctx, settings, siteId))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *RangerSettings) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfRangerSettings
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// disable Ranger for the site
+	enabled := false
+	_, diags := api.Client().UpdateRangerSettings(ctx, state.SiteId.ValueString(),
+		api.RangerSettingsBody{Enabled: &enabled})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Disabled Ranger", map[string]interface{}{
+		"site_id": state.SiteId.ValueString(),
+	})
+}
+
+// ImportState imports the Ranger settings of an existing site into the Terraform state using the site ID.
+func (r *RangerSettings) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	resource.ImportStatePassthroughID(ctx, tfpath.Root("site_id"), req, resp)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating the Ranger settings.
+func (r *RangerSettings) bodyFromPlan(plan tfRangerSettings) api.RangerSettingsBody {
+	body := api.RangerSettingsBody{
+		ExcludedSubnets: []string{},
+		IncludedSubnets: []string{},
+	}
+
+	if !plan.DeviceDecommissionDays.IsNull() && !plan.DeviceDecommissionDays.IsUnknown() {
+		value := int(plan.DeviceDecommissionDays.ValueInt64())
+		body.DeviceDecommissionDays = &value
+	}
+
+	if !plan.DeviceReviewEnabled.IsNull() && !plan.DeviceReviewEnabled.IsUnknown() {
+		value := plan.DeviceReviewEnabled.ValueBool()
+		body.DeviceReviewEnabled = &value
+	}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := plan.Enabled.ValueBool()
+		body.Enabled = &value
+	}
+
+	for _, v := range plan.ExcludedSubnets {
+		body.ExcludedSubnets = append(body.ExcludedSubnets, v.ValueString())
+	}
+
+	for _, v := range plan.IncludedSubnets {
+		body.IncludedSubnets = append(body.IncludedSubnets, v.ValueString())
+	}
+
+	if !plan.ScanIntervalMinutes.IsNull() && !plan.ScanIntervalMinutes.IsUnknown() {
+		value := int(plan.ScanIntervalMinutes.ValueInt64())
+		body.ScanIntervalMinutes = &value
+	}
+	return body
+}
+
+// tfRangerSettingsFromAPI converts API Ranger settings into Terraform Ranger settings.
+//
+// Empty subnet lists are left unset so that they match a configuration which omits them.
+func tfRangerSettingsFromAPI(ctx context.Context, settings *api.RangerSettings, siteId string) tfRangerSettings {
+	tfsettings := tfRangerSettings{
+		DeviceDecommissionDays: types.Int64Value(int64(settings.DeviceDecommissionDays)),
+		DeviceReviewEnabled:    types.BoolValue(settings.DeviceReviewEnabled),
+		Enabled:                types.BoolValue(settings.Enabled),
+		Id:                     types.StringValue(siteId),
+		ScanIntervalMinutes:    types.Int64Value(int64(settings.ScanIntervalMinutes)),
+		SiteId:                 types.StringValue(siteId),
+	}
+	for _, v := range settings.ExcludedSubnets {
+		tfsettings.ExcludedSubnets = append(tfsettings.ExcludedSubnets, types.StringValue(v))
+	}
+	for _, v := range settings.IncludedSubnets {
+		tfsettings.IncludedSubnets = append(tfsettings.IncludedSubnets, types.StringValue(v))
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API Ranger settings to TF Ranger settings: %+v", tfsettings),
+		map[string]interface{}{
+			"api_ranger_settings": settings,
+		})
+	return tfsettings
+}
@@ -0,0 +1,380 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &ReportSchedule{}
+	_ resource.ResourceWithConfigure   = &ReportSchedule{}
+	_ resource.ResourceWithImportState = &ReportSchedule{}
+)
+
+// tfReportSchedule defines the Terraform model for a scheduled report.
+type tfReportSchedule struct {
+	CreatedAt  types.String   `tfsdk:"created_at"`
+	Day        types.Int64    `tfsdk:"day"`
+	Format     types.String   `tfsdk:"format"`
+	Frequency  types.String   `tfsdk:"frequency"`
+	Id         types.String   `tfsdk:"id"`
+	Name       types.String   `tfsdk:"name"`
+	Recipients []types.String `tfsdk:"recipients"`
+	ReportType types.String   `tfsdk:"report_type"`
+	ScopeId    types.String   `tfsdk:"scope_id"`
+	ScopeType  types.String   `tfsdk:"scope_type"`
+	UpdatedAt  types.String   `tfsdk:"updated_at"`
+}
+
+// NewReportSchedule creates a new ReportSchedule object.
+func NewReportSchedule() resource.Resource {
+	return &ReportSchedule{}
+}
+
+// ReportSchedule is a resource used to manage a recurring report.
+type ReportSchedule struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ReportSchedule) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_report_schedule"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ReportSchedule) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a recurring report within an account or site.",
+		MarkdownDescription: `This resource is used for creating and managing a recurring report within an account or
+			site.
+
+		The report is generated on the given schedule and emailed to each of the recipients. Existing scheduled reports
+		can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<report_schedule_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the scheduled report was created.",
+				MarkdownDescription: "Timestamp of when the scheduled report was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"day": schema.Int64Attribute{
+				Description: "Day on which the report is generated: the day of the week (1 = Monday to 7 = Sunday) " +
+					"for weekly reports or the day of the month (1 to 28) for monthly reports. It is ignored for " +
+					"daily reports. [Default: none]",
+				MarkdownDescription: "Day on which the report is generated: the day of the week (`1` = Monday to `7` = " +
+					"Sunday) for weekly reports or the day of the month (`1` to `28`) for monthly reports. It is " +
+					"ignored for daily reports. [Default: none]",
+				Optional: true,
+			},
+			"format": schema.StringAttribute{
+				Description:         "Format of the report (valid values: pdf, html). [Default: pdf]",
+				MarkdownDescription: "Format of the report (valid values: `pdf`, `html`). [Default: `pdf`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("pdf"),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "pdf", "html"),
+				},
+			},
+			"frequency": schema.StringAttribute{
+				Description:         "How often the report is generated (valid values: daily, weekly, monthly).",
+				MarkdownDescription: "How often the report is generated (valid values: `daily`, `weekly`, `monthly`).",
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "daily", "weekly", "monthly"),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the scheduled report.",
+				MarkdownDescription: "ID of the scheduled report.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the scheduled report.",
+				MarkdownDescription: "Name of the scheduled report.",
+				Required:            true,
+			},
+			"recipients": schema.ListAttribute{
+				Description:         "Email addresses to which the report is sent.",
+				MarkdownDescription: "Email addresses to which the report is sent.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"report_type": schema.StringAttribute{
+				Description:         "Type of report which is generated (eg: executive_summary).",
+				MarkdownDescription: "Type of report which is generated (eg: `executive_summary`).",
+				Required:            true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site covered by the report.",
+				MarkdownDescription: "ID of the account or site covered by the report.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the report is scheduled (valid values: account, site).",
+				MarkdownDescription: "Level at which the report is scheduled (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the scheduled report was last updated.",
+				MarkdownDescription: "Timestamp of when the scheduled report was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ReportSchedule) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_REPORT_SCHEDULE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *ReportSchedule) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfReportSchedule
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the scheduled report
+	schedule, diags := api.Client().CreateReportSchedule(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the scheduled report to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfReportScheduleFromAPI(ctx, schedule, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *ReportSchedule) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfReportSchedule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the scheduled report - if it no longer exists, remove it from the state
+	queryParams := api.ReportScheduleQueryParams{
+		ReportScheduleIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	schedules, diags := api.Client().FindReportSchedules(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(schedules) == 0 {
+		tflog.Debug(ctx, "Scheduled report no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfReportScheduleFromAPI(ctx, &schedules[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *ReportSchedule) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfReportSchedule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfReportSchedule
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the scheduled report
+	schedule, diags := api.Client().UpdateReportSchedule(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated scheduled report to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfReportScheduleFromAPI(ctx, schedule, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *ReportSchedule) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfReportSchedule
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the scheduled report
+	resp.Diagnostics.Append(api.Client().DeleteReportSchedule(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted scheduled report", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing scheduled report into the Terraform state.
+//
+// The API can only find a scheduled report within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<report_schedule_id>.
+func (r *ReportSchedule) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<report_schedule_id> where "+
+			"the scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_REPORT_SCHEDULE_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a scheduled report.
+func (r *ReportSchedule) bodyFromPlan(plan tfReportSchedule) api.ReportScheduleBody {
+	body := api.ReportScheduleBody{}
+
+	if !plan.Day.IsNull() && !plan.Day.IsUnknown() {
+		value := int(plan.Day.ValueInt64())
+		body.Day = &value
+	}
+
+	if !plan.Format.IsNull() && !plan.Format.IsUnknown() {
+		value := plan.Format.ValueString()
+		body.Format = &value
+	}
+
+	if !plan.Frequency.IsNull() && !plan.Frequency.IsUnknown() {
+		value := plan.Frequency.ValueString()
+		body.Frequency = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if plan.Recipients != nil {
+		body.Recipients = []string{}
+		for _, recipient := range plan.Recipients {
+			body.Recipients = append(body.Recipients, recipient.ValueString())
+		}
+	}
+
+	if !plan.ReportType.IsNull() && !plan.ReportType.IsUnknown() {
+		value := plan.ReportType.ValueString()
+		body.ReportType = &value
+	}
+	return body
+}
+
+// tfReportScheduleFromAPI converts an API scheduled report into a Terraform scheduled report.
+//
+// The API does not return the scope of the scheduled report so it is copied from the given model. The day is only
+// refreshed if it is configured since the API may fill in a default for it.
+func tfReportScheduleFromAPI(ctx context.Context, schedule *api.ReportSchedule,
+	model tfReportSchedule) tfReportSchedule {
+
+	tfschedule := tfReportSchedule{
+		CreatedAt:  types.StringValue(schedule.CreatedAt),
+		Day:        model.Day,
+		Format:     types.StringValue(schedule.Format),
+		Frequency:  types.StringValue(schedule.Frequency),
+		Id:         types.StringValue(schedule.Id),
+		Name:       types.StringValue(schedule.Name),
+		Recipients: []types.String{},
+		ReportType: types.StringValue(schedule.ReportType),
+		ScopeId:    model.ScopeId,
+		ScopeType:  model.ScopeType,
+		UpdatedAt:  types.StringValue(schedule.UpdatedAt),
+	}
+	if schedule.Day != nil && !model.Day.IsNull() {
+		tfschedule.Day = types.Int64Value(int64(*schedule.Day))
+	}
+	for _, recipient := range schedule.Recipients {
+		tfschedule.Recipients = append(tfschedule.Recipients, types.StringValue(recipient))
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API scheduled report to TF scheduled report: %+v", tfschedule),
+		map[string]interface{}{
+			"api_report_schedule": schedule,
+		})
+	return tfschedule
+}
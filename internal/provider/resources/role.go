@@ -0,0 +1,394 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Role{}
+	_ resource.ResourceWithConfigure   = &Role{}
+	_ resource.ResourceWithImportState = &Role{}
+)
+
+// tfRole defines the Terraform model for a custom role.
+type tfRole struct {
+	CreatedAt     types.String `tfsdk:"created_at"`
+	Description   types.String `tfsdk:"description"`
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	PermissionIds types.Set    `tfsdk:"permission_ids"`
+	ScopeId       types.String `tfsdk:"scope_id"`
+	ScopeType     types.String `tfsdk:"scope_type"`
+	UpdatedAt     types.String `tfsdk:"updated_at"`
+	UsersInRole   types.Int64  `tfsdk:"users_in_role"`
+}
+
+// NewRole creates a new Role object.
+func NewRole() resource.Resource {
+	return &Role{}
+}
+
+// Role is a resource used to manage the lifecycle of a custom RBAC role.
+type Role struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Role) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Role) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a custom RBAC role for the whole console, " +
+			"an account or a site.",
+		MarkdownDescription: `This resource is used for creating and managing a custom RBAC role for the whole console,
+			an account or a site.
+
+		A role is a named set of permissions (eg: endpoint actions, policy editing, threat response). A role created
+		with a ` + "`scope_type`" + ` of ` + "`tenant`" + ` can be assigned to users in every account, while
+		account and site roles are only available within that account or site.
+
+		Existing roles can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<role_id>`" + ` or
+		` + "`tenant/<role_id>`" + ` for roles created for the whole console.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the role was created.",
+				MarkdownDescription: "Timestamp of when the role was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the role. [Default: none]",
+				MarkdownDescription: "Description of the role. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the role.",
+				MarkdownDescription: "ID of the role.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the role.",
+				MarkdownDescription: "Name of the role.",
+				Required:            true,
+			},
+			"permission_ids": schema.SetAttribute{
+				Description:         "IDs of the permissions granted by the role.",
+				MarkdownDescription: "IDs of the permissions granted by the role.",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"scope_id": schema.StringAttribute{
+				Description: "ID of the account or site to which the role belongs. This is required unless the " +
+					"scope type is tenant.",
+				MarkdownDescription: "ID of the account or site to which the role belongs. This is required unless the " +
+					"scope type is `tenant`.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the role is created (valid values: tenant, account, site).",
+				MarkdownDescription: "Level at which the role is created (valid values: `tenant`, `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_TENANT, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the role was last updated.",
+				MarkdownDescription: "Timestamp of when the role was last updated.",
+				Computed:            true,
+			},
+			"users_in_role": schema.Int64Attribute{
+				Description:         "Number of users to which the role is assigned.",
+				MarkdownDescription: "Number of users to which the role is assigned.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Role) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_ROLE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Role) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfRole
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// account and site roles must be given the ID of the account or site
+	if plan.ScopeType.ValueString() != api.SCOPE_TENANT && (plan.ScopeId.IsNull() || plan.ScopeId.ValueString() == "") {
+		msg := fmt.Sprintf("A scope ID must be given when the scope type is %s.", plan.ScopeType.ValueString())
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"scope_type":          plan.ScopeType.ValueString(),
+			"internal_error_code": plugin.ERR_RESOURCE_ROLE_CREATE,
+		})
+		resp.Diagnostics.AddAttributeError(tfpath.Root("scope_id"), "Missing Scope ID", msg)
+		return
+	}
+
+	// create the role
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	role, diags := api.Client().CreateRole(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the role to the state
+	tfrole, diags := tfRoleFromAPI(ctx, role, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfrole)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Role) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfRole
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the role - if it no longer exists, remove it from the state
+	queryParams := api.RoleQueryParams{
+		RoleIds: []string{state.Id.ValueString()},
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	default:
+		tenant := true
+		queryParams.Tenant = &tenant
+	}
+	roles, diags := api.Client().FindRoles(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(roles) == 0 {
+		tflog.Debug(ctx, "Role no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	tfrole, diags := tfRoleFromAPI(ctx, &roles[0], state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfrole)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Role) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfRole
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfRole
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the role
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	role, diags := api.Client().UpdateRole(ctx, state.Id.ValueString(), scopeFromModel(state.ScopeType, state.ScopeId),
+		body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated role to the state
+	tfrole, diags := tfRoleFromAPI(ctx, role, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfrole)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Role) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfRole
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the role
+	resp.Diagnostics.Append(api.Client().DeleteRole(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed role", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing role into the Terraform state.
+//
+// The API can only find a role within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<role_id> or tenant/<role_id>.
+func (r *Role) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	valid := false
+	switch {
+	case len(parts) == 2:
+		valid = parts[0] == api.SCOPE_TENANT && parts[1] != ""
+	case len(parts) == 3:
+		valid = (parts[0] == api.SCOPE_ACCOUNT || parts[0] == api.SCOPE_SITE) && parts[1] != "" && parts[2] != ""
+	}
+	if !valid {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<role_id> where the scope "+
+			"type is one of: %s, %s or %s/<role_id> for roles created for the whole console.\n\nImport ID: %s",
+			api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_TENANT, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_ROLE_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[1])...)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a role.
+func (r *Role) bodyFromPlan(ctx context.Context, plan tfRole) (api.RoleBody, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body := api.RoleBody{}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if !plan.PermissionIds.IsNull() && !plan.PermissionIds.IsUnknown() {
+		body.PermissionIds = []string{}
+		diags.Append(plan.PermissionIds.ElementsAs(ctx, &body.PermissionIds, false)...)
+		if diags.HasError() {
+			return body, diags
+		}
+	}
+	return body, diags
+}
+
+// tfRoleFromAPI converts an API role into a Terraform role.
+//
+// The scope is copied from the given model since it is always known from the configuration or import ID.
+func tfRoleFromAPI(ctx context.Context, role *api.Role, model tfRole) (tfRole, diag.Diagnostics) {
+	tfrole := tfRole{
+		CreatedAt:   types.StringValue(role.CreatedAt),
+		Description: types.StringValue(role.Description),
+		Id:          types.StringValue(role.Id),
+		Name:        types.StringValue(role.Name),
+		ScopeId:     model.ScopeId,
+		ScopeType:   model.ScopeType,
+		UpdatedAt:   types.StringValue(role.UpdatedAt),
+		UsersInRole: types.Int64Value(int64(role.UsersInRoles)),
+	}
+	permissionIds := []string{}
+	permissionIds = append(permissionIds, role.PermissionIds...)
+	var diags diag.Diagnostics
+	tfrole.PermissionIds, diags = types.SetValueFrom(ctx, types.StringType, permissionIds)
+	if diags.HasError() {
+		return tfrole, diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API role to TF role: %+v", tfrole), map[string]interface{}{
+		"api_role": role,
+	})
+	return tfrole, diags
+}
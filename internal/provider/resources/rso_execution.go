@@ -0,0 +1,438 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api/waiter"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+const (
+	// rsoExecutionStateCompleted is the waiter state used once the script has finished running on every agent.
+	rsoExecutionStateCompleted = "completed"
+
+	// rsoExecutionStatePending is the waiter state used while the script is still running on any agent.
+	rsoExecutionStatePending = "pending"
+)
+
+// rsoTaskPendingStatuses holds the statuses of a task which has not finished running on an agent.
+var rsoTaskPendingStatuses = map[string]bool{
+	"created":             true,
+	"in_progress":         true,
+	"pending":             true,
+	"pending_user_action": true,
+	"scheduled":           true,
+}
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &RSOExecution{}
+	_ resource.ResourceWithConfigure = &RSOExecution{}
+)
+
+// tfRSOExecution defines the Terraform model for the execution of a Remote Script Orchestration (RSO) script.
+type tfRSOExecution struct {
+	Agents          types.List     `tfsdk:"agents"`
+	Filter          *tfAgentFilter `tfsdk:"filter"`
+	InputParams     types.String   `tfsdk:"input_params"`
+	ParentTaskId    types.String   `tfsdk:"parent_task_id"`
+	PollInterval    types.String   `tfsdk:"poll_interval"`
+	ScriptId        types.String   `tfsdk:"script_id"`
+	TaskDescription types.String   `tfsdk:"task_description"`
+	TimeoutSeconds  types.Int64    `tfsdk:"timeout_seconds"`
+	Triggers        types.Map      `tfsdk:"triggers"`
+	WaitTimeout     types.String   `tfsdk:"wait_timeout"`
+}
+
+// tfRSOExecutionTask defines the Terraform model for the execution of a script on a single agent.
+type tfRSOExecutionTask struct {
+	AgentId        types.String `tfsdk:"agent_id"`
+	ComputerName   types.String `tfsdk:"computer_name"`
+	DetailedStatus types.String `tfsdk:"detailed_status"`
+	DownloadURL    types.String `tfsdk:"download_url"`
+	Status         types.String `tfsdk:"status"`
+	TaskId         types.String `tfsdk:"task_id"`
+}
+
+// tfRSOExecutionTaskAttrTypes defines the attribute types of a tfRSOExecutionTask object.
+var tfRSOExecutionTaskAttrTypes = map[string]attr.Type{
+	"agent_id":        types.StringType,
+	"computer_name":   types.StringType,
+	"detailed_status": types.StringType,
+	"download_url":    types.StringType,
+	"status":          types.StringType,
+	"task_id":         types.StringType,
+}
+
+// NewRSOExecution creates a new RSOExecution object.
+func NewRSOExecution() resource.Resource {
+	return &RSOExecution{}
+}
+
+// RSOExecution is a resource used to execute a Remote Script Orchestration (RSO) script on agents matching a
+// filter.
+type RSOExecution struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *RSOExecution) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_rso_execution"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *RSOExecution) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for executing a Remote Script Orchestration (RSO) script on all agents " +
+			"matching a filter.",
+		MarkdownDescription: `This resource is used for executing a Remote Script Orchestration (RSO) script on all
+			agents matching a filter.
+
+		At least one filter must be given. The script is executed when the resource is created and the status of the
+		execution is polled every ` + "`poll_interval`" + ` until the script has finished running on every agent,
+		failing if that does not happen within ` + "`wait_timeout`" + `. The status of each agent and a link to
+		download its output are recorded in ` + "`agents`" + `. Agents on which the script failed are reported as a
+		warning rather than an error so that the script is not executed again on the next apply.
+
+		Destroying the resource does not undo anything done by the script. To execute the script again, change any
+		value in ` + "`triggers`" + `, which causes the resource to be replaced.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"agents": schema.ListNestedAttribute{
+				Description:         "Status of the execution of the script on each agent matching the filter.",
+				MarkdownDescription: "Status of the execution of the script on each agent matching the filter.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"agent_id": schema.StringAttribute{
+							Description:         "ID of the agent.",
+							MarkdownDescription: "ID of the agent.",
+							Computed:            true,
+						},
+						"computer_name": schema.StringAttribute{
+							Description:         "Computer name of the agent.",
+							MarkdownDescription: "Computer name of the agent.",
+							Computed:            true,
+						},
+						"detailed_status": schema.StringAttribute{
+							Description:         "Detailed status of the execution on the agent.",
+							MarkdownDescription: "Detailed status of the execution on the agent.",
+							Computed:            true,
+						},
+						"download_url": schema.StringAttribute{
+							Description: "Link to download the output of the script from the agent. Only set once " +
+								"the script has completed.",
+							MarkdownDescription: "Link to download the output of the script from the agent. Only set " +
+								"once the script has completed.",
+							Computed:  true,
+							Sensitive: true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "Status of the execution on the agent (eg: completed, failed).",
+							MarkdownDescription: "Status of the execution on the agent (eg: `completed`, `failed`).",
+							Computed:            true,
+						},
+						"task_id": schema.StringAttribute{
+							Description:         "ID of the task executing the script on the agent.",
+							MarkdownDescription: "ID of the task executing the script on the agent.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"input_params": schema.StringAttribute{
+				Description:         "Input parameters passed to the script. [Default: none]",
+				MarkdownDescription: "Input parameters passed to the script. [Default: none]",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"parent_task_id": schema.StringAttribute{
+				Description:         "ID of the parent task of the tasks executing the script on each agent.",
+				MarkdownDescription: "ID of the parent task of the tasks executing the script on each agent.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				Description:         "How often to poll the status of the execution (eg: 10s, 1m). [Default: 10s]",
+				MarkdownDescription: "How often to poll the status of the execution (eg: `10s`, `1m`). [Default: `10s`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10s"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"script_id": schema.StringAttribute{
+				Description:         "ID of the script to execute (eg: the id of a singularity_rso_script resource).",
+				MarkdownDescription: "ID of the script to execute (eg: the `id` of a `singularity_rso_script` resource).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"task_description": schema.StringAttribute{
+				Description:         "Description of the task shown in the console. [Default: none]",
+				MarkdownDescription: "Description of the task shown in the console. [Default: none]",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description: "Maximum number of seconds the script is allowed to run on each agent. [Default: the " +
+					"timeout configured for the script]",
+				MarkdownDescription: "Maximum number of seconds the script is allowed to run on each agent. " +
+					"[Default: the timeout configured for the script]",
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which, when changed, cause the resource to be replaced and the script " +
+					"to be executed again.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"script to be executed again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for the script to finish running on every agent (eg: 30m, 2h). " +
+					"[Default: 30m]",
+				MarkdownDescription: "Maximum time to wait for the script to finish running on every agent (eg: " +
+					"`30m`, `2h`). [Default: `30m`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("30m"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"filter": agentFilterBlock("on which to execute the script"),
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *RSOExecution) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_RSO_EXECUTION_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *RSOExecution) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfRSOExecution
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.InputParams.ValueString())
+	interval, diags := plugin.ParseRelativeDuration(ctx, plan.PollInterval.ValueString())
+	resp.Diagnostics.Append(diags...)
+	timeout, diags := plugin.ParseRelativeDuration(ctx, plan.WaitTimeout.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// never execute the script on every agent in the console by accident
+	queryParams := agentFilterQueryParams(plan.Filter)
+	if queryParams.IsEmpty() {
+		msg := "At least one filter must be given in order to select the agents on which to execute the script."
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_RSO_EXECUTION_CREATE,
+		})
+		resp.Diagnostics.AddError("RSO Execution Creation Error", msg)
+		return
+	}
+
+	// execute the script
+	execution, diags := api.Client().ExecuteRemoteScript(ctx, queryParams, r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ParentTaskId = types.StringValue(execution.ParentTaskId)
+	tflog.Info(ctx, "Executed RSO script on agents", map[string]interface{}{
+		"parent_task_id": execution.ParentTaskId,
+		"script_id":      plan.ScriptId.ValueString(),
+	})
+
+	// wait for the script to finish running on every agent
+	tasks, waitDiags := waiter.Wait(ctx, waiter.Config{
+		Description: "RSO script execution",
+		Interval:    interval,
+		Pending:     []string{rsoExecutionStatePending},
+		Target:      []string{rsoExecutionStateCompleted},
+		Timeout:     timeout,
+	}, func(ctx context.Context) ([]api.RemoteScriptTask, string, diag.Diagnostics) {
+		tasks, diags := api.Client().GetRemoteScriptTasks(ctx, execution.ParentTaskId)
+		if diags.HasError() {
+			return nil, "", diags
+		}
+		for _, t := range tasks {
+			if rsoTaskPendingStatuses[t.Status] {
+				return tasks, rsoExecutionStatePending, diags
+			}
+		}
+		return tasks, rsoExecutionStateCompleted, diags
+	})
+
+	// get the links to download the output of every agent on which the script completed
+	completedTaskIds := []string{}
+	for _, t := range tasks {
+		if t.Status == "completed" {
+			completedTaskIds = append(completedTaskIds, t.Id)
+		}
+	}
+	downloadURLs := map[string]string{}
+	if len(completedTaskIds) > 0 {
+		files, diags := api.Client().FetchRemoteScriptFiles(ctx, completedTaskIds)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, f := range files {
+			downloadURLs[f.TaskId] = f.DownloadURL
+		}
+	}
+
+	// record the status of each agent
+	results := []tfRSOExecutionTask{}
+	failed := 0
+	for _, t := range tasks {
+		results = append(results, tfRSOExecutionTask{
+			AgentId:        types.StringValue(t.AgentId),
+			ComputerName:   types.StringValue(t.AgentComputerName),
+			DetailedStatus: types.StringValue(t.DetailedStatus),
+			DownloadURL:    types.StringValue(downloadURLs[t.Id]),
+			Status:         types.StringValue(t.Status),
+			TaskId:         types.StringValue(t.Id),
+		})
+		if t.Status != "completed" && !rsoTaskPendingStatuses[t.Status] {
+			failed++
+		}
+	}
+	plan.Agents, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfRSOExecutionTaskAttrTypes}, results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if failed > 0 {
+		resp.Diagnostics.AddWarning("RSO Script Failed On Some Agents", fmt.Sprintf("The script did not complete "+
+			"on %d of %d agents. See the agents attribute for the status of each agent.", failed, len(tasks)))
+	}
+
+	// save the plan to the state even if waiting failed so the execution is tracked - the resource is marked as
+	// tainted in that case
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(waitDiags...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// An execution cannot be undone once it has finished so there is nothing to refresh.
+func (r *RSOExecution) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the polling settings can be updated in place so there is nothing to do other than saving them.
+func (r *RSOExecution) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfRSOExecution
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfRSOExecution
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.Agents = state.Agents
+	plan.ParentTaskId = state.ParentTaskId
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// Anything done by the script cannot be undone so the resource is simply removed from the state.
+func (r *RSOExecution) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for executing a script.
+func (r *RSOExecution) bodyFromPlan(plan tfRSOExecution) api.RemoteScriptExecutionBody {
+	body := api.RemoteScriptExecutionBody{
+		ScriptId: plan.ScriptId.ValueString(),
+	}
+
+	if !plan.InputParams.IsNull() && !plan.InputParams.IsUnknown() {
+		value := plan.InputParams.ValueString()
+		body.InputParams = &value
+	}
+
+	if !plan.TaskDescription.IsNull() && !plan.TaskDescription.IsUnknown() {
+		value := plan.TaskDescription.ValueString()
+		body.TaskDescription = &value
+	}
+
+	if !plan.TimeoutSeconds.IsNull() && !plan.TimeoutSeconds.IsUnknown() {
+		value := plan.TimeoutSeconds.ValueInt64()
+		body.TimeoutSeconds = &value
+	}
+	return body
+}
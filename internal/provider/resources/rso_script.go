@@ -0,0 +1,507 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &RSOScript{}
+	_ resource.ResourceWithConfigure   = &RSOScript{}
+	_ resource.ResourceWithImportState = &RSOScript{}
+	_ resource.ResourceWithModifyPlan  = &RSOScript{}
+)
+
+// tfRSOScript defines the Terraform model for a Remote Script Orchestration (RSO) script.
+type tfRSOScript struct {
+	CreatedAt         types.String   `tfsdk:"created_at"`
+	Description       types.String   `tfsdk:"description"`
+	FilePath          types.String   `tfsdk:"file_path"`
+	FileSHA256        types.String   `tfsdk:"file_sha256"`
+	Id                types.String   `tfsdk:"id"`
+	InputExample      types.String   `tfsdk:"input_example"`
+	InputInstructions types.String   `tfsdk:"input_instructions"`
+	InputRequired     types.Bool     `tfsdk:"input_required"`
+	Name              types.String   `tfsdk:"name"`
+	OSTypes           []types.String `tfsdk:"os_types"`
+	ScopeId           types.String   `tfsdk:"scope_id"`
+	ScopeType         types.String   `tfsdk:"scope_type"`
+	ScriptType        types.String   `tfsdk:"script_type"`
+	TimeoutSeconds    types.Int64    `tfsdk:"timeout_seconds"`
+	UpdatedAt         types.String   `tfsdk:"updated_at"`
+	Version           types.String   `tfsdk:"version"`
+}
+
+// NewRSOScript creates a new RSOScript object.
+func NewRSOScript() resource.Resource {
+	return &RSOScript{}
+}
+
+// RSOScript is a resource used to upload and manage a Remote Script Orchestration (RSO) script.
+type RSOScript struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *RSOScript) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rso_script"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *RSOScript) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for uploading and managing a Remote Script Orchestration (RSO) script " +
+			"within an account, site or group.",
+		MarkdownDescription: `This resource is used for uploading and managing a Remote Script Orchestration (RSO)
+			script within an account, site or group.
+
+		The SHA256 hash of the script file is checked on every plan. If the file changes, a new version of the script
+		is uploaded and ` + "`version`" + ` is updated, so resources which execute the script can depend on it.
+
+		Existing scripts can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<script_id>`" + `.
+		The API does not return the script file, so the script is uploaded again on the next apply after an import.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the script was created.",
+				MarkdownDescription: "Timestamp of when the script was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "User-defined description of the script. [Default: none]",
+				MarkdownDescription: "User-defined description of the script. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"file_path": schema.StringAttribute{
+				Description:         "Path to the local script file to upload.",
+				MarkdownDescription: "Path to the local script file to upload.",
+				Required:            true,
+			},
+			"file_sha256": schema.StringAttribute{
+				Description:         "SHA256 hash of the script file which was uploaded.",
+				MarkdownDescription: "SHA256 hash of the script file which was uploaded.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the script.",
+				MarkdownDescription: "ID of the script.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"input_example": schema.StringAttribute{
+				Description:         "Example of the input parameters accepted by the script. [Default: none]",
+				MarkdownDescription: "Example of the input parameters accepted by the script. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"input_instructions": schema.StringAttribute{
+				Description:         "Instructions describing the input parameters accepted by the script. [Default: none]",
+				MarkdownDescription: "Instructions describing the input parameters accepted by the script. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"input_required": schema.BoolAttribute{
+				Description:         "Whether or not input parameters must be given when executing the script. [Default: false]",
+				MarkdownDescription: "Whether or not input parameters must be given when executing the script. [Default: `false`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the script.",
+				MarkdownDescription: "Name of the script.",
+				Required:            true,
+			},
+			"os_types": schema.ListAttribute{
+				Description:         "Operating systems on which the script can be executed (eg: linux, macos, windows).",
+				MarkdownDescription: "Operating systems on which the script can be executed (eg: `linux`, `macos`, `windows`).",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					validators.EnumStringListValuesAre(false, "linux", "macos", "windows"),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account, site or group to which the script belongs.",
+				MarkdownDescription: "ID of the account, site or group to which the script belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the script is created (valid values: account, site, group).",
+				MarkdownDescription: "Level at which the script is created (valid values: `account`, `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+			"script_type": schema.StringAttribute{
+				Description:         "Type of the script (valid values: action, dataCollection). [Default: action]",
+				MarkdownDescription: "Type of the script (valid values: `action`, `dataCollection`). [Default: `action`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("action"),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "action", "dataCollection"),
+				},
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description:         "Maximum number of seconds the script is allowed to run. [Default: 3600]",
+				MarkdownDescription: "Maximum number of seconds the script is allowed to run. [Default: `3600`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(3600),
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the script was last updated.",
+				MarkdownDescription: "Timestamp of when the script was last updated.",
+				Computed:            true,
+			},
+			"version": schema.StringAttribute{
+				Description:         "Version of the script, which changes every time a new version is uploaded.",
+				MarkdownDescription: "Version of the script, which changes every time a new version is uploaded.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *RSOScript) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_RSO_SCRIPT_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// ModifyPlan is called to modify the Terraform plan.
+func (r *RSOScript) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+
+	// nothing to do when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// we need to hash the script file here - otherwise if the file is changed, no changes will be detected
+	var filePath types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, tfpath.Root("file_path"), &filePath)...)
+	if resp.Diagnostics.HasError() || filePath.IsNull() || filePath.IsUnknown() {
+		return
+	}
+	sha256, diags := plugin.GetFileSHA256(ctx, filePath.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("file_sha256"), types.StringValue(sha256))...)
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	// a new version is uploaded if only the file changed so the values which change with it are no longer known
+	var stateSHA256 types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, tfpath.Root("file_sha256"), &stateSHA256)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if stateSHA256.ValueString() != sha256 {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("updated_at"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("version"), types.StringUnknown())...)
+	}
+}
+
+// Create is used to create the Terraform resource.
+func (r *RSOScript) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfRSOScript
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// upload the script
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	script, diags := api.Client().CreateRemoteScript(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the script to the state
+	plan.FileSHA256 = types.StringValue(fmt.Sprintf("%x", sha256.Sum256(body.FileContent)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfRSOScriptFromAPI(ctx, script, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *RSOScript) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfRSOScript
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the script - if it no longer exists, remove it from the state
+	queryParams := api.RemoteScriptQueryParams{
+		ScriptIds: []string{state.Id.ValueString()},
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_GROUP:
+		queryParams.GroupIds = []string{state.ScopeId.ValueString()}
+	}
+	scripts, diags := api.Client().FindRemoteScripts(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(scripts) == 0 {
+		tflog.Debug(ctx, "RSO script no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfRSOScriptFromAPI(ctx, &scripts[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *RSOScript) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfRSOScript
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfRSOScript
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// upload a new version of the script
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	script, diags := api.Client().UpdateRemoteScript(ctx, state.Id.ValueString(), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated script to the state
+	plan.FileSHA256 = types.StringValue(fmt.Sprintf("%x", sha256.Sum256(body.FileContent)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfRSOScriptFromAPI(ctx, script, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *RSOScript) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfRSOScript
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the script
+	resp.Diagnostics.Append(api.Client().DeleteRemoteScript(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted RSO script", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing script into the Terraform state.
+//
+// The API can only find a script within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<script_id>.
+func (r *RSOScript) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" ||
+		(parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE && parts[0] != api.SCOPE_GROUP) {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<script_id> where the "+
+			"scope type is one of: %s, %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, api.SCOPE_GROUP,
+			req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_RSO_SCRIPT_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for uploading a script, reading the script
+// file in the process.
+func (r *RSOScript) bodyFromPlan(ctx context.Context, plan tfRSOScript) (api.RemoteScriptBody, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	body := api.RemoteScriptBody{}
+
+	// read the script file
+	absPath, diags := plugin.ToAbsolutePath(ctx, plan.FilePath.ValueString())
+	if diags.HasError() {
+		return body, diags
+	}
+	content, err := os.ReadFile(absPath)
+	if err != nil {
+		msg := fmt.Sprintf("An unexpected error occurred while reading the script file.\n\nError: %s\nFile: %s",
+			err.Error(), absPath)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"error":               err.Error(),
+			"file_path":           absPath,
+			"internal_error_code": plugin.ERR_RESOURCE_RSO_SCRIPT_READ_FILE,
+		})
+		diags.AddError("RSO Script File Error", msg)
+		return body, diags
+	}
+	body.FileContent = content
+	body.FileName = filepath.Base(absPath)
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.ScriptDescription = &value
+	}
+
+	if !plan.InputExample.IsNull() && !plan.InputExample.IsUnknown() {
+		value := plan.InputExample.ValueString()
+		body.InputExample = &value
+	}
+
+	if !plan.InputInstructions.IsNull() && !plan.InputInstructions.IsUnknown() {
+		value := plan.InputInstructions.ValueString()
+		body.InputInstructions = &value
+	}
+
+	if !plan.InputRequired.IsNull() && !plan.InputRequired.IsUnknown() {
+		value := plan.InputRequired.ValueBool()
+		body.InputRequired = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.ScriptName = &value
+	}
+
+	if plan.OSTypes != nil {
+		body.OSTypes = []string{}
+		for _, o := range plan.OSTypes {
+			body.OSTypes = append(body.OSTypes, o.ValueString())
+		}
+	}
+
+	if !plan.ScriptType.IsNull() && !plan.ScriptType.IsUnknown() {
+		value := plan.ScriptType.ValueString()
+		body.ScriptType = &value
+	}
+
+	if !plan.TimeoutSeconds.IsNull() && !plan.TimeoutSeconds.IsUnknown() {
+		value := plan.TimeoutSeconds.ValueInt64()
+		body.TimeoutSeconds = &value
+	}
+	return body, diags
+}
+
+// tfRSOScriptFromAPI converts an API script into a Terraform script.
+//
+// The API does not return the scope or the script file so they are copied from the given model.
+func tfRSOScriptFromAPI(ctx context.Context, script *api.RemoteScript, model tfRSOScript) tfRSOScript {
+	tfscript := tfRSOScript{
+		CreatedAt:         types.StringValue(script.CreatedAt),
+		Description:       types.StringValue(script.ScriptDescription),
+		FilePath:          model.FilePath,
+		FileSHA256:        model.FileSHA256,
+		Id:                types.StringValue(script.Id),
+		InputExample:      types.StringValue(script.InputExample),
+		InputInstructions: types.StringValue(script.InputInstructions),
+		InputRequired:     types.BoolValue(script.InputRequired),
+		Name:              types.StringValue(script.ScriptName),
+		OSTypes:           []types.String{},
+		ScopeId:           model.ScopeId,
+		ScopeType:         model.ScopeType,
+		ScriptType:        types.StringValue(script.ScriptType),
+		TimeoutSeconds:    types.Int64Value(script.TimeoutSeconds),
+		UpdatedAt:         types.StringValue(script.UpdatedAt),
+		Version:           types.StringValue(script.Version),
+	}
+	for _, o := range script.OSTypes {
+		tfscript.OSTypes = append(tfscript.OSTypes, types.StringValue(o))
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API RSO script to TF RSO script: %+v", tfscript), map[string]interface{}{
+		"api_script": script,
+	})
+	return tfscript
+}
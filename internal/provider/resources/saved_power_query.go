@@ -0,0 +1,350 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &SavedPowerQuery{}
+	_ resource.ResourceWithConfigure   = &SavedPowerQuery{}
+	_ resource.ResourceWithImportState = &SavedPowerQuery{}
+)
+
+// tfSavedPowerQuery defines the Terraform model for a saved query.
+type tfSavedPowerQuery struct {
+	CreatedAt     types.String `tfsdk:"created_at"`
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Query         types.String `tfsdk:"query"`
+	QueryLanguage types.String `tfsdk:"query_language"`
+	ScopeId       types.String `tfsdk:"scope_id"`
+	ScopeType     types.String `tfsdk:"scope_type"`
+	Shared        types.Bool   `tfsdk:"shared"`
+	UpdatedAt     types.String `tfsdk:"updated_at"`
+}
+
+// NewSavedPowerQuery creates a new SavedPowerQuery object.
+func NewSavedPowerQuery() resource.Resource {
+	return &SavedPowerQuery{}
+}
+
+// SavedPowerQuery is a resource used to manage a saved Deep Visibility or Power Query query.
+type SavedPowerQuery struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *SavedPowerQuery) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_saved_power_query"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *SavedPowerQuery) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a saved Deep Visibility or Power Query " +
+			"query within an account or site.",
+		MarkdownDescription: `This resource is used for creating and managing a saved Deep Visibility or Power Query
+			query within an account or site.
+
+		Keeping hunting queries in Terraform allows them to be versioned and promoted between consoles. Existing saved
+		queries can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<saved_query_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the query was saved.",
+				MarkdownDescription: "Timestamp of when the query was saved.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the saved query.",
+				MarkdownDescription: "ID of the saved query.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the saved query.",
+				MarkdownDescription: "Name of the saved query.",
+				Required:            true,
+			},
+			"query": schema.StringAttribute{
+				Description:         "Text of the query.",
+				MarkdownDescription: "Text of the query.",
+				Required:            true,
+			},
+			"query_language": schema.StringAttribute{
+				Description: "Language in which the query is written (valid values: s1ql for Deep Visibility, pq for " +
+					"Power Query). [Default: pq]",
+				MarkdownDescription: "Language in which the query is written (valid values: `s1ql` for Deep " +
+					"Visibility, `pq` for Power Query). [Default: `pq`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("pq"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "s1ql", "pq"),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site in which the query is saved.",
+				MarkdownDescription: "ID of the account or site in which the query is saved.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the query is saved (valid values: account, site).",
+				MarkdownDescription: "Level at which the query is saved (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"shared": schema.BoolAttribute{
+				Description: "Whether or not the query is shared with the other users in its scope. " +
+					"[Default: false]",
+				MarkdownDescription: "Whether or not the query is shared with the other users in its scope. " +
+					"[Default: `false`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the saved query was last updated.",
+				MarkdownDescription: "Timestamp of when the saved query was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *SavedPowerQuery) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_SAVED_POWER_QUERY_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *SavedPowerQuery) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfSavedPowerQuery
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the query
+	query, diags := api.Client().CreateSavedPowerQuery(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the query to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSavedPowerQueryFromAPI(ctx, query, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *SavedPowerQuery) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfSavedPowerQuery
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the saved query - if it no longer exists, remove it from the state
+	queryParams := api.SavedPowerQueryQueryParams{
+		SavedPowerQueryIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	queries, diags := api.Client().FindSavedPowerQueries(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(queries) == 0 {
+		tflog.Debug(ctx, "Saved query no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSavedPowerQueryFromAPI(ctx, &queries[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *SavedPowerQuery) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfSavedPowerQuery
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfSavedPowerQuery
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the saved query
+	query, diags := api.Client().UpdateSavedPowerQuery(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated query to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSavedPowerQueryFromAPI(ctx, query, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *SavedPowerQuery) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfSavedPowerQuery
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the saved query
+	resp.Diagnostics.Append(api.Client().DeleteSavedPowerQuery(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted saved query", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing saved query into the Terraform state.
+//
+// The API can only find a saved query within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<saved_query_id>.
+func (r *SavedPowerQuery) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<saved_query_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_SAVED_POWER_QUERY_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a saved query.
+func (r *SavedPowerQuery) bodyFromPlan(plan tfSavedPowerQuery) api.SavedPowerQueryBody {
+	body := api.SavedPowerQueryBody{}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if !plan.Query.IsNull() && !plan.Query.IsUnknown() {
+		value := plan.Query.ValueString()
+		body.Query = &value
+	}
+
+	if !plan.QueryLanguage.IsNull() && !plan.QueryLanguage.IsUnknown() {
+		value := plan.QueryLanguage.ValueString()
+		body.QueryLanguage = &value
+	}
+
+	if !plan.Shared.IsNull() && !plan.Shared.IsUnknown() {
+		value := plan.Shared.ValueBool()
+		body.Shared = &value
+	}
+	return body
+}
+
+// tfSavedPowerQueryFromAPI converts an API saved query into a Terraform saved query.
+//
+// The API does not return the scope of the saved query so it is copied from the given model.
+func tfSavedPowerQueryFromAPI(ctx context.Context, query *api.SavedPowerQuery,
+	model tfSavedPowerQuery) tfSavedPowerQuery {
+
+	tfquery := tfSavedPowerQuery{
+		CreatedAt:     types.StringValue(query.CreatedAt),
+		Id:            types.StringValue(query.Id),
+		Name:          types.StringValue(query.Name),
+		Query:         types.StringValue(query.Query),
+		QueryLanguage: types.StringValue(query.QueryLanguage),
+		ScopeId:       model.ScopeId,
+		ScopeType:     model.ScopeType,
+		Shared:        types.BoolValue(query.Shared),
+		UpdatedAt:     types.StringValue(query.UpdatedAt),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API saved query to TF saved query: %+v", tfquery),
+		map[string]interface{}{
+			"api_saved_power_query": query,
+		})
+	return tfquery
+}
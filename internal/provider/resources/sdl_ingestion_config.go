@@ -0,0 +1,427 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &SdlIngestionConfig{}
+	_ resource.ResourceWithConfigure   = &SdlIngestionConfig{}
+	_ resource.ResourceWithImportState = &SdlIngestionConfig{}
+)
+
+// tfSdlIngestionConfig defines the Terraform model for the ingestion configuration of a Data Lake log source.
+type tfSdlIngestionConfig struct {
+	CreatedAt        types.String `tfsdk:"created_at"`
+	Description      types.String `tfsdk:"description"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	Id               types.String `tfsdk:"id"`
+	IngestUrl        types.String `tfsdk:"ingest_url"`
+	Name             types.String `tfsdk:"name"`
+	ParserDefinition types.String `tfsdk:"parser_definition"`
+	ParserName       types.String `tfsdk:"parser_name"`
+	RetentionTier    types.String `tfsdk:"retention_tier"`
+	ScopeId          types.String `tfsdk:"scope_id"`
+	ScopeType        types.String `tfsdk:"scope_type"`
+	SourceType       types.String `tfsdk:"source_type"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+}
+
+// NewSdlIngestionConfig creates a new SdlIngestionConfig object.
+func NewSdlIngestionConfig() resource.Resource {
+	return &SdlIngestionConfig{}
+}
+
+// SdlIngestionConfig is a resource used to manage the ingestion configuration of a Data Lake log source.
+type SdlIngestionConfig struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *SdlIngestionConfig) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sdl_ingestion_config"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *SdlIngestionConfig) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the ingestion configuration of a custom Singularity Data " +
+			"Lake log source within an account or site.",
+		MarkdownDescription: `This resource is used for managing the ingestion configuration of a custom Singularity Data
+			Lake log source within an account or site.
+
+		Each log source defines how logs are sent to the Data Lake, the parser used to extract fields from them and
+		the retention tier in which they are stored. Logs are sent to the computed ` + "`ingest_url`" + `. Either a
+		built-in parser can be referenced by name or a custom parser can be defined using ` + "`parser_definition`" + `,
+		in which case the parser is created (or updated) along with the log source. Existing log sources can be
+		imported using an ID in the format ` + "`<scope_type>/<scope_id>/<config_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the configuration was created.",
+				MarkdownDescription: "Timestamp of when the configuration was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the log source.",
+				MarkdownDescription: "Description of the log source.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not ingestion from the log source is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not ingestion from the log source is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the ingestion configuration.",
+				MarkdownDescription: "ID of the ingestion configuration.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ingest_url": schema.StringAttribute{
+				Description:         "URL to which the logs of the source are sent.",
+				MarkdownDescription: "URL to which the logs of the source are sent.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the log source.",
+				MarkdownDescription: "Name of the log source.",
+				Required:            true,
+			},
+			"parser_definition": schema.StringAttribute{
+				Description: "Definition of a custom parser in the Data Lake parser format. If not given, " +
+					"parser_name must reference a built-in parser.",
+				MarkdownDescription: "Definition of a custom parser in the Data Lake parser format. If not given, " +
+					"`parser_name` must reference a built-in parser.",
+				Optional: true,
+			},
+			"parser_name": schema.StringAttribute{
+				Description: "Name of the parser used to extract fields from the logs (eg: json). If " +
+					"parser_definition is given, this is the name of the custom parser.",
+				MarkdownDescription: "Name of the parser used to extract fields from the logs (eg: `json`). If " +
+					"`parser_definition` is given, this is the name of the custom parser.",
+				Required: true,
+			},
+			"retention_tier": schema.StringAttribute{
+				Description: "Retention tier in which the logs are stored (valid values: archive, extended, " +
+					"standard). [Default: standard]",
+				MarkdownDescription: "Retention tier in which the logs are stored (valid values: `archive`, " +
+					"`extended`, `standard`). [Default: `standard`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(api.SDL_RETENTION_TIER_STANDARD),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SDL_RETENTION_TIER_ARCHIVE,
+						api.SDL_RETENTION_TIER_EXTENDED, api.SDL_RETENTION_TIER_STANDARD),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the log source belongs.",
+				MarkdownDescription: "ID of the account or site to which the log source belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level to which the log source belongs (valid values: account, site).",
+				MarkdownDescription: "Level to which the log source belongs (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"source_type": schema.StringAttribute{
+				Description:         "How logs are sent to the Data Lake (valid values: hec, s3, syslog).",
+				MarkdownDescription: "How logs are sent to the Data Lake (valid values: `hec`, `s3`, `syslog`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SDL_SOURCE_TYPE_HEC, api.SDL_SOURCE_TYPE_S3,
+						api.SDL_SOURCE_TYPE_SYSLOG),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the configuration was last updated.",
+				MarkdownDescription: "Timestamp of when the configuration was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *SdlIngestionConfig) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_SDL_INGESTION_CONFIG_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *SdlIngestionConfig) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfSdlIngestionConfig
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the configuration
+	config, diags := api.Client().CreateSdlIngestionConfig(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the configuration to the state
+	tfconfig, diags := tfSdlIngestionConfigFromAPI(ctx, config, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfconfig)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *SdlIngestionConfig) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfSdlIngestionConfig
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the configuration - if it no longer exists, remove it from the state
+	queryParams := api.SdlIngestionConfigQueryParams{
+		ConfigIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	configs, diags := api.Client().FindSdlIngestionConfigs(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(configs) == 0 {
+		tflog.Debug(ctx, "SDL ingestion config no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	tfconfig, diags := tfSdlIngestionConfigFromAPI(ctx, &configs[0], state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfconfig)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *SdlIngestionConfig) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfSdlIngestionConfig
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfSdlIngestionConfig
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := r.bodyFromPlan(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the configuration
+	config, diags := api.Client().UpdateSdlIngestionConfig(ctx, state.Id.ValueString(), body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated configuration to the state
+	tfconfig, diags := tfSdlIngestionConfigFromAPI(ctx, config, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfconfig)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *SdlIngestionConfig) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfSdlIngestionConfig
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the configuration
+	resp.Diagnostics.Append(api.Client().DeleteSdlIngestionConfig(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted SDL ingestion config", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing SDL ingestion config into the Terraform state.
+//
+// The API can only find a configuration within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<config_id>.
+func (r *SdlIngestionConfig) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<config_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_SDL_INGESTION_CONFIG_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a configuration.
+func (r *SdlIngestionConfig) bodyFromPlan(ctx context.Context, plan tfSdlIngestionConfig) (
+	api.SdlIngestionConfigBody, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+
+	description := plan.Description.ValueString()
+	enabled := plan.Enabled.ValueBool()
+	name := plan.Name.ValueString()
+	parserName := plan.ParserName.ValueString()
+	retentionTier := plan.RetentionTier.ValueString()
+	sourceType := plan.SourceType.ValueString()
+	body := api.SdlIngestionConfigBody{
+		Description:   &description,
+		Enabled:       &enabled,
+		Name:          &name,
+		ParserName:    &parserName,
+		RetentionTier: &retentionTier,
+		SourceType:    &sourceType,
+	}
+	if !plan.ParserDefinition.IsNull() && !plan.ParserDefinition.IsUnknown() {
+		value := plan.ParserDefinition.ValueString()
+		body.ParserDefinition = &value
+	}
+	return body, diags
+}
+
+// tfSdlIngestionConfigFromAPI converts an API Data Lake ingestion configuration into a Terraform Data Lake ingestion
+// configuration.
+//
+// The API does not return the scope of the configuration so it is copied from the given model. The API returns the
+// definition of built-in parsers too so the definition is only stored when the model has one.
+func tfSdlIngestionConfigFromAPI(ctx context.Context, config *api.SdlIngestionConfig, model tfSdlIngestionConfig) (
+	tfSdlIngestionConfig, diag.Diagnostics) {
+
+	var diags diag.Diagnostics
+	tfconfig := tfSdlIngestionConfig{
+		CreatedAt:        types.StringValue(config.CreatedAt),
+		Description:      types.StringValue(config.Description),
+		Enabled:          types.BoolValue(config.Enabled),
+		Id:               types.StringValue(config.Id),
+		IngestUrl:        types.StringValue(config.IngestUrl),
+		Name:             types.StringValue(config.Name),
+		ParserDefinition: types.StringNull(),
+		ParserName:       types.StringValue(config.ParserName),
+		RetentionTier:    types.StringValue(config.RetentionTier),
+		ScopeId:          model.ScopeId,
+		ScopeType:        model.ScopeType,
+		SourceType:       types.StringValue(config.SourceType),
+		UpdatedAt:        types.StringValue(config.UpdatedAt),
+	}
+	if !model.ParserDefinition.IsNull() {
+		tfconfig.ParserDefinition = types.StringValue(config.ParserDefinition)
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API SDL ingestion config to TF SDL ingestion config: %+v", tfconfig),
+		map[string]interface{}{
+			"api_sdl_ingestion_config": config,
+		})
+	return tfconfig, diags
+}
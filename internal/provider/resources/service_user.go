@@ -0,0 +1,407 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource               = &ServiceUser{}
+	_ resource.ResourceWithConfigure  = &ServiceUser{}
+	_ resource.ResourceWithModifyPlan = &ServiceUser{}
+)
+
+// tfServiceUser defines the Terraform model for a service user.
+type tfServiceUser struct {
+	ApiToken         types.String `tfsdk:"api_token"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+	Description      types.String `tfsdk:"description"`
+	ExpirationDate   types.String `tfsdk:"expiration_date"`
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	RoleId           types.String `tfsdk:"role_id"`
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"`
+	ScopeId          types.String `tfsdk:"scope_id"`
+	ScopeType        types.String `tfsdk:"scope_type"`
+	UpdatedAt        types.String `tfsdk:"updated_at"`
+}
+
+// NewServiceUser creates a new ServiceUser object.
+func NewServiceUser() resource.Resource {
+	return &ServiceUser{}
+}
+
+// ServiceUser is a resource used to manage the lifecycle of a service user and its API token.
+type ServiceUser struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ServiceUser) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_user"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ServiceUser) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a service user within an account or site " +
+			"along with its API token.",
+		MarkdownDescription: `This resource is used for creating and managing a service user within an account or site
+			along with its API token.
+
+		The API token is generated when the service user is created and is stored in the Terraform state as the
+		sensitive ` + "`api_token`" + ` attribute so that it can be passed to other automation (eg: a SIEM collector).
+		A new token is generated, revoking the previous one, whenever ` + "`expiration_date`" + ` or
+		` + "`rotation_triggers`" + ` changes. Since the token cannot be retrieved again once it has been generated,
+		existing service users cannot be imported.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"api_token": schema.StringAttribute{
+				Description:         "API token generated for the service user.",
+				MarkdownDescription: "API token generated for the service user.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the service user was created.",
+				MarkdownDescription: "Timestamp of when the service user was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the service user. [Default: none]",
+				MarkdownDescription: "Description of the service user. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"expiration_date": schema.StringAttribute{
+				Description: "Timestamp of when the service user's API token expires (eg: 2024-01-01T00:00:00Z). " +
+					"Changing this value generates a new API token.",
+				MarkdownDescription: "Timestamp of when the service user's API token expires " +
+					"(eg: `2024-01-01T00:00:00Z`). Changing this value generates a new API token.",
+				Required: true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the service user.",
+				MarkdownDescription: "ID of the service user.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the service user.",
+				MarkdownDescription: "Name of the service user.",
+				Required:            true,
+			},
+			"role_id": schema.StringAttribute{
+				Description:         "ID of the role granted to the service user within its account or site.",
+				MarkdownDescription: "ID of the role granted to the service user within its account or site.",
+				Required:            true,
+			},
+			"rotation_triggers": schema.MapAttribute{
+				Description: "Arbitrary map of values which, when changed, generates a new API token for the " +
+					"service user. [Default: none]",
+				MarkdownDescription: "Arbitrary map of values which, when changed, generates a new API token for the " +
+					"service user. [Default: none]",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the service user belongs.",
+				MarkdownDescription: "ID of the account or site to which the service user belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the service user is created (valid values: account, site).",
+				MarkdownDescription: "Level at which the service user is created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the service user was last updated.",
+				MarkdownDescription: "Timestamp of when the service user was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ServiceUser) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_SERVICE_USER_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// ModifyPlan is called to modify the Terraform plan.
+func (r *ServiceUser) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+
+	// nothing to do when the resource is being created or destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// retrieve values from plan and state
+	var plan, state tfServiceUser
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a new token is generated whenever the expiration date or rotation triggers change
+	if !plan.ExpirationDate.Equal(state.ExpirationDate) || !plan.RotationTriggers.Equal(state.RotationTriggers) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("api_token"), types.StringUnknown())...)
+	}
+}
+
+// Create is used to create the Terraform resource.
+func (r *ServiceUser) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfServiceUser
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the service user
+	body := r.bodyFromPlan(plan)
+	scope := plan.ScopeType.ValueString() // always required so no need to check
+	body.Scope = &scope
+	user, diags := api.Client().CreateServiceUser(ctx, body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state := tfServiceUserFromAPI(ctx, user, plan)
+
+	// generate the API token - if this fails, the service user is saved without a token so that Terraform marks it
+	// as tainted and replaces it on the next apply
+	token, diags := api.Client().GenerateServiceUserApiToken(ctx, user.Id, plan.ExpirationDate.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		state.ApiToken = types.StringValue("")
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+		return
+	}
+	state.ApiToken = types.StringValue(token)
+
+	// save the service user to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *ServiceUser) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfServiceUser
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the service user - if it no longer exists, remove it from the state
+	queryParams := api.ServiceUserQueryParams{
+		ServiceUserIds: []string{state.Id.ValueString()},
+	}
+	switch state.ScopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	case api.SCOPE_SITE:
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	}
+	users, diags := api.Client().FindServiceUsers(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(users) == 0 {
+		tflog.Debug(ctx, "Service user no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfServiceUserFromAPI(ctx, &users[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *ServiceUser) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfServiceUser
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfServiceUser
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the service user
+	user, diags := api.Client().UpdateServiceUser(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updated := tfServiceUserFromAPI(ctx, user, plan)
+
+	// rotate the API token if required (see ModifyPlan)
+	updated.ApiToken = state.ApiToken
+	if plan.ApiToken.IsUnknown() {
+		token, diags := api.Client().GenerateServiceUserApiToken(ctx, state.Id.ValueString(),
+			plan.ExpirationDate.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		updated.ApiToken = types.StringValue(token)
+		tflog.Info(ctx, "Rotated service user API token", map[string]interface{}{
+			"id":              state.Id.ValueString(),
+			"expiration_date": plan.ExpirationDate.ValueString(),
+		})
+	}
+
+	// save the updated service user to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, updated)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *ServiceUser) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfServiceUser
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the service user, which also revokes its API token
+	resp.Diagnostics.Append(api.Client().DeleteServiceUser(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed service user", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a service user.
+//
+// Note that the scope type is not included as it cannot be changed once the service user has been created.
+func (r *ServiceUser) bodyFromPlan(plan tfServiceUser) api.ServiceUserBody {
+	body := api.ServiceUserBody{}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.ExpirationDate.IsNull() && !plan.ExpirationDate.IsUnknown() {
+		value := plan.ExpirationDate.ValueString()
+		body.ExpirationDate = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if !plan.RoleId.IsNull() && !plan.RoleId.IsUnknown() {
+		body.ScopeRoles = []api.ServiceUserScopeRole{
+			{
+				Id:     plan.ScopeId.ValueString(),
+				RoleId: plan.RoleId.ValueString(),
+			},
+		}
+	}
+	return body
+}
+
+// tfServiceUserFromAPI converts an API service user into a Terraform service user.
+//
+// The API token, expiration date, rotation triggers and scope are copied from the given model since the API never
+// returns the token and the remaining values are always known from the configuration.
+func tfServiceUserFromAPI(ctx context.Context, user *api.ServiceUser, model tfServiceUser) tfServiceUser {
+	tfuser := tfServiceUser{
+		CreatedAt:        types.StringValue(user.CreatedAt),
+		Description:      types.StringValue(user.Description),
+		ExpirationDate:   model.ExpirationDate,
+		Id:               types.StringValue(user.Id),
+		Name:             types.StringValue(user.Name),
+		RoleId:           model.RoleId,
+		RotationTriggers: model.RotationTriggers,
+		ScopeId:          model.ScopeId,
+		ScopeType:        model.ScopeType,
+		UpdatedAt:        types.StringValue(user.UpdatedAt),
+	}
+	for _, scopeRole := range user.ScopeRoles {
+		if scopeRole.Id == model.ScopeId.ValueString() {
+			tfuser.RoleId = types.StringValue(scopeRole.RoleId)
+		}
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API service user to TF service user: %+v", tfuser),
+		map[string]interface{}{
+			"api_service_user": user,
+		})
+
+	// the token is added after logging so that it never appears in the logs
+	tfuser.ApiToken = model.ApiToken
+	return tfuser
+}
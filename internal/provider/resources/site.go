@@ -0,0 +1,458 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Site{}
+	_ resource.ResourceWithConfigure   = &Site{}
+	_ resource.ResourceWithImportState = &Site{}
+)
+
+// tfSite defines the Terraform model for a site.
+type tfSite struct {
+	AccountId           types.String `tfsdk:"account_id"`
+	AccountName         types.String `tfsdk:"account_name"`
+	ActiveLicenses      types.Int64  `tfsdk:"active_licenses"`
+	CreatedAt           types.String `tfsdk:"created_at"`
+	Creator             types.String `tfsdk:"creator"`
+	CreatorId           types.String `tfsdk:"creator_id"`
+	Description         types.String `tfsdk:"description"`
+	Expiration          types.String `tfsdk:"expiration"`
+	ExternalId          types.String `tfsdk:"external_id"`
+	Id                  types.String `tfsdk:"id"`
+	IsDefault           types.Bool   `tfsdk:"is_default"`
+	Name                types.String `tfsdk:"name"`
+	RegistrationToken   types.String `tfsdk:"registration_token"`
+	ScopeAccountId      types.String `tfsdk:"scope_account_id"`
+	SiteType            types.String `tfsdk:"site_type"`
+	State               types.String `tfsdk:"state"`
+	TotalLicenses       types.Int64  `tfsdk:"total_licenses"`
+	UnlimitedExpiration types.Bool   `tfsdk:"unlimited_expiration"`
+	UnlimitedLicenses   types.Bool   `tfsdk:"unlimited_licenses"`
+	UpdatedAt           types.String `tfsdk:"updated_at"`
+}
+
+// NewSite creates a new Site object.
+func NewSite() resource.Resource {
+	return &Site{}
+}
+
+// Site is a resource used to manage the lifecycle of a site.
+type Site struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Site) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Site) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing a site within an account.",
+		MarkdownDescription: `This resource is used for creating and managing a site within an account.
+
+		If no ` + "`expiration`" + ` is given, the site never expires. Existing sites can be imported using their ID.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description:         "ID of the account to which the site belongs.",
+				MarkdownDescription: "ID of the account to which the site belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"account_name": schema.StringAttribute{
+				Description:         "Name of the account to which the site belongs.",
+				MarkdownDescription: "Name of the account to which the site belongs.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"active_licenses": schema.Int64Attribute{
+				Description:         "Number of active licenses in use.",
+				MarkdownDescription: "Number of active licenses in use.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the site was created.",
+				MarkdownDescription: "Timestamp of when the site was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creator": schema.StringAttribute{
+				Description:         "Full name of the user who created the site.",
+				MarkdownDescription: "Full name of the user who created the site.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creator_id": schema.StringAttribute{
+				Description:         "ID of the user who created the site.",
+				MarkdownDescription: "ID of the user who created the site.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "User-defined description of the site. [Default: none]",
+				MarkdownDescription: "User-defined description of the site. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"expiration": schema.StringAttribute{
+				Description: "Timestamp of when the site expires (eg: 2024-01-01T00:00:00Z). If not set, the site " +
+					"never expires.",
+				MarkdownDescription: "Timestamp of when the site expires (eg: `2024-01-01T00:00:00Z`). If not set, the " +
+					"site never expires.",
+				Optional: true,
+			},
+			"external_id": schema.StringAttribute{
+				Description:         "ID of the site in an external system such as a CRM. [Default: none]",
+				MarkdownDescription: "ID of the site in an external system such as a CRM. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the site.",
+				MarkdownDescription: "ID of the site.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_default": schema.BoolAttribute{
+				Description:         "Whether or not the site is the default site for the account.",
+				MarkdownDescription: "Whether or not the site is the default site for the account.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the site.",
+				MarkdownDescription: "Name of the site.",
+				Required:            true,
+			},
+			"registration_token": schema.StringAttribute{
+				Description:         "Registration token for the site.",
+				MarkdownDescription: "Registration token for the site.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_account_id": scopeAccountIdAttribute("site"),
+			"site_type": schema.StringAttribute{
+				Description:         "Type of site (valid values: Paid, Trial). [Default: Paid]",
+				MarkdownDescription: "Type of site (valid values: `Paid`, `Trial`). [Default: `Paid`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("Paid"),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "Paid", "Trial"),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description:         "State of the site.",
+				MarkdownDescription: "State of the site.",
+				Computed:            true,
+			},
+			"total_licenses": schema.Int64Attribute{
+				Description: "Number of licenses available to the site. Ignored when unlimited_licenses is true. If " +
+					"not set, the server will assign the number of licenses.",
+				MarkdownDescription: "Number of licenses available to the site. Ignored when `unlimited_licenses` is " +
+					"`true`. If not set, the server will assign the number of licenses.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"unlimited_expiration": schema.BoolAttribute{
+				Description:         "Whether or not the site never expires.",
+				MarkdownDescription: "Whether or not the site never expires.",
+				Computed:            true,
+			},
+			"unlimited_licenses": schema.BoolAttribute{
+				Description:         "Whether or not the site has unlimited licenses. [Default: true]",
+				MarkdownDescription: "Whether or not the site has unlimited licenses. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the site was last updated.",
+				MarkdownDescription: "Timestamp of when the site was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Site) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_SITE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Site) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfSite
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// create the site
+	body := r.bodyFromPlan(plan)
+	accountId := plan.AccountId.ValueString() // always required so no need to check
+	body.AccountId = &accountId
+	site, diags := api.Client().CreateSite(ctx, body)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the site to the state
+	tfsite := tfSiteFromAPI(ctx, site, plan.Expiration)
+	tfsite.ScopeAccountId = plan.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsite)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Site) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfSite
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// find the site - if it no longer exists, remove it from the state
+	sites, diags := api.Client().FindSites(ctx, api.SiteQueryParams{
+		SiteIds: []string{state.Id.ValueString()},
+	})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(sites) == 0 || sites[0].State == "deleted" {
+		tflog.Debug(ctx, "Site no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	tfsite := tfSiteFromAPI(ctx, &sites[0], state.Expiration)
+	tfsite.ScopeAccountId = state.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsite)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Site) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfSite
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfSite
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, plan.ScopeAccountId.ValueString())
+
+	// update the site
+	site, diags := api.Client().UpdateSite(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated site to the state
+	tfsite := tfSiteFromAPI(ctx, site, plan.Expiration)
+	tfsite.ScopeAccountId = plan.ScopeAccountId
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfsite)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Site) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfSite
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = api.WithScopeAccount(ctx, state.ScopeAccountId.ValueString())
+
+	// delete the site
+	resp.Diagnostics.Append(api.Client().DeleteSite(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed site", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing site into the Terraform state using its ID.
+func (r *Site) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, tfpath.Root("id"), req, resp)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a site.
+//
+// Note that the account ID is not included as it cannot be changed once the site has been created.
+func (r *Site) bodyFromPlan(plan tfSite) api.SiteBody {
+	body := api.SiteBody{}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	unlimitedExpiration := true
+	if !plan.Expiration.IsNull() && !plan.Expiration.IsUnknown() {
+		value := plan.Expiration.ValueString()
+		body.Expiration = &value
+		unlimitedExpiration = false
+	}
+	body.UnlimitedExpiration = &unlimitedExpiration
+
+	if !plan.ExternalId.IsNull() && !plan.ExternalId.IsUnknown() {
+		value := plan.ExternalId.ValueString()
+		body.ExternalId = &value
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if !plan.SiteType.IsNull() && !plan.SiteType.IsUnknown() {
+		value := plan.SiteType.ValueString()
+		body.SiteType = &value
+	}
+
+	if !plan.TotalLicenses.IsNull() && !plan.TotalLicenses.IsUnknown() {
+		value := plan.TotalLicenses.ValueInt64()
+		body.TotalLicenses = &value
+	}
+
+	if !plan.UnlimitedLicenses.IsNull() && !plan.UnlimitedLicenses.IsUnknown() {
+		value := plan.UnlimitedLicenses.ValueBool()
+		body.UnlimitedLicenses = &value
+	}
+	return body
+}
+
+// tfSiteFromAPI converts an API site into a Terraform site.
+//
+// The API may return the expiration timestamp in a different format than the one that was configured, so the
+// configured expiration is kept when it refers to the same point in time.
+func tfSiteFromAPI(ctx context.Context, site *api.Site, expiration types.String) tfSite {
+	tfsite := tfSite{
+		AccountId:           types.StringValue(site.AccountId),
+		AccountName:         types.StringValue(site.AccountName),
+		ActiveLicenses:      types.Int64Value(int64(site.ActiveLicenses)),
+		CreatedAt:           types.StringValue(site.CreatedAt),
+		Creator:             types.StringValue(site.Creator),
+		CreatorId:           types.StringValue(site.CreatorId),
+		Description:         types.StringValue(site.Description),
+		Expiration:          types.StringNull(),
+		ExternalId:          types.StringValue(site.ExternalId),
+		Id:                  types.StringValue(site.Id),
+		IsDefault:           types.BoolValue(site.IsDefault),
+		Name:                types.StringValue(site.Name),
+		RegistrationToken:   types.StringValue(site.RegistrationToken),
+		SiteType:            types.StringValue(site.SiteType),
+		State:               types.StringValue(site.State),
+		TotalLicenses:       types.Int64Value(int64(site.TotalLicenses)),
+		UnlimitedExpiration: types.BoolValue(site.UnlimitedExpiration),
+		UnlimitedLicenses:   types.BoolValue(site.UnlimitedLicenses),
+		UpdatedAt:           types.StringValue(site.UpdatedAt),
+	}
+	if !site.UnlimitedExpiration && site.Expiration != "" {
+		tfsite.Expiration = types.StringValue(site.Expiration)
+		if !expiration.IsNull() && !expiration.IsUnknown() && sameTimestamp(expiration.ValueString(), site.Expiration) {
+			tfsite.Expiration = expiration
+		}
+	}
+	ctx = plugin.MaskSecrets(ctx, site.RegistrationToken)
+	tflog.Debug(ctx, fmt.Sprintf("converted API site to TF site: %+v", tfsite), map[string]interface{}{
+		"api_site": site,
+	})
+	return tfsite
+}
+
+// sameTimestamp returns whether or not the two given RFC3339 timestamps refer to the same point in time.
+//
+// If either timestamp cannot be parsed, the raw strings are compared instead.
+func sameTimestamp(a, b string) bool {
+	ta, errA := time.Parse(time.RFC3339Nano, a)
+	tb, errB := time.Parse(time.RFC3339Nano, b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ta.Equal(tb)
+}
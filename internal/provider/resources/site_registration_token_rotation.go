@@ -0,0 +1,295 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource               = &SiteRegistrationTokenRotation{}
+	_ resource.ResourceWithConfigure  = &SiteRegistrationTokenRotation{}
+	_ resource.ResourceWithModifyPlan = &SiteRegistrationTokenRotation{}
+)
+
+// tfSiteRegistrationTokenRotation defines the Terraform model for a registration token rotation.
+type tfSiteRegistrationTokenRotation struct {
+	Id                types.String `tfsdk:"id"`
+	RegistrationToken types.String `tfsdk:"registration_token"`
+	RotateWhen        types.Map    `tfsdk:"rotate_when"`
+	RotatedAt         types.String `tfsdk:"rotated_at"`
+	ScopeId           types.String `tfsdk:"scope_id"`
+	ScopeType         types.String `tfsdk:"scope_type"`
+}
+
+// NewSiteRegistrationTokenRotation creates a new SiteRegistrationTokenRotation object.
+func NewSiteRegistrationTokenRotation() resource.Resource {
+	return &SiteRegistrationTokenRotation{}
+}
+
+// SiteRegistrationTokenRotation is a resource used to rotate the registration token of a site or group.
+type SiteRegistrationTokenRotation struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *SiteRegistrationTokenRotation) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site_registration_token_rotation"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *SiteRegistrationTokenRotation) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for rotating the registration token of a site or group.",
+		MarkdownDescription: `This resource is used for rotating the registration token of a site or group.
+
+		A new registration token is generated when the resource is created and again, in place, whenever any value
+		in ` + "`rotate_when`" + ` changes. The new token is stored in the Terraform state as the sensitive
+		` + "`registration_token`" + ` attribute so that it can be passed to agent installers.
+
+		The previous token stops working as soon as a new one is generated, so any installers still using it must be
+		updated. Destroying the resource only removes it from the Terraform state and leaves the current token in
+		place.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "ID of the rotation (in the format <scope_type>/<scope_id>).",
+				MarkdownDescription: "ID of the rotation (in the format `<scope_type>/<scope_id>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"registration_token": schema.StringAttribute{
+				Description:         "The registration token generated by the last rotation.",
+				MarkdownDescription: "The registration token generated by the last rotation.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotate_when": schema.MapAttribute{
+				Description: "Arbitrary map of values which, when changed, generate a new registration token. " +
+					"[Default: none]",
+				MarkdownDescription: "Arbitrary map of values which, when changed, generate a new registration " +
+					"token. [Default: none]",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"rotated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the registration token was last rotated.",
+				MarkdownDescription: "Timestamp of when the registration token was last rotated.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the site or group whose registration token is rotated.",
+				MarkdownDescription: "ID of the site or group whose registration token is rotated.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Type of scope whose registration token is rotated (valid values: site, group).",
+				MarkdownDescription: "Type of scope whose registration token is rotated (valid values: `site`, `group`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_SITE, api.SCOPE_GROUP),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *SiteRegistrationTokenRotation) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_SITE_REGISTRATION_TOKEN_ROTATION_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// ModifyPlan is called to modify the Terraform plan.
+func (r *SiteRegistrationTokenRotation) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse) {
+
+	// nothing to do when the resource is being created or destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// retrieve values from plan and state
+	var plan, state tfSiteRegistrationTokenRotation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// a new token is generated whenever the triggers change
+	if !plan.RotateWhen.Equal(state.RotateWhen) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("registration_token"),
+			types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tfpath.Root("rotated_at"), types.StringUnknown())...)
+	}
+}
+
+// Create is used to create the Terraform resource.
+func (r *SiteRegistrationTokenRotation) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfSiteRegistrationTokenRotation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// rotate the token
+	plan.Id = types.StringValue(fmt.Sprintf("%s/%s", plan.ScopeType.ValueString(), plan.ScopeId.ValueString()))
+	resp.Diagnostics.Append(r.rotate(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the token to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *SiteRegistrationTokenRotation) Read(ctx context.Context, req resource.ReadRequest,
+	resp *resource.ReadResponse) {
+	// get the current state
+	var state tfSiteRegistrationTokenRotation
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the token is not compared with the current one so that it is not rotated again if it was changed elsewhere -
+	// only check that the site or group still exists
+	scopeId := state.ScopeId.ValueString()
+	found := false
+	if state.ScopeType.ValueString() == api.SCOPE_GROUP {
+		groups, diags := api.Client().FindGroups(ctx, api.GroupQueryParams{GroupIds: []string{scopeId}})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		found = len(groups) > 0
+	} else {
+		sites, diags := api.Client().FindSites(ctx, api.SiteQueryParams{SiteIds: []string{scopeId}})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		found = len(sites) > 0
+	}
+	if !found {
+		tflog.Debug(ctx, "Scope for registration token rotation no longer exists.", map[string]interface{}{
+			"scope_id":   scopeId,
+			"scope_type": state.ScopeType.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *SiteRegistrationTokenRotation) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfSiteRegistrationTokenRotation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// rotate the token if the triggers changed (see ModifyPlan)
+	if plan.RegistrationToken.IsUnknown() {
+		resp.Diagnostics.Append(r.rotate(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		tflog.Info(ctx, "Rotated registration token", map[string]interface{}{
+			"id": plan.Id.ValueString(),
+		})
+	}
+
+	// save the plan to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// The registration token is left as it is since rotating it again would only break installers using it.
+func (r *SiteRegistrationTokenRotation) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+}
+
+// rotate generates a new registration token for the scope in the given model and updates the model with the new
+// token.
+func (r *SiteRegistrationTokenRotation) rotate(ctx context.Context,
+	model *tfSiteRegistrationTokenRotation) diag.Diagnostics {
+
+	rotatedAt := time.Now().UTC()
+	token, diags := api.Client().RegenerateRegistrationToken(ctx, scopeFromModel(model.ScopeType, model.ScopeId))
+	if diags.HasError() {
+		return diags
+	}
+	ctx = plugin.MaskSecrets(ctx, token)
+	tflog.Debug(ctx, "generated registration token", map[string]interface{}{
+		"id":                 model.Id.ValueString(),
+		"registration_token": token,
+	})
+	model.RegistrationToken = types.StringValue(token)
+	model.RotatedAt = types.StringValue(rotatedAt.Format(time.RFC3339))
+	return diags
+}
@@ -0,0 +1,505 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &SiteSet{}
+	_ resource.ResourceWithConfigure = &SiteSet{}
+)
+
+// tfSiteSet defines the Terraform model for a set of sites.
+type tfSiteSet struct {
+	AccountId          types.String                 `tfsdk:"account_id"`
+	Id                 types.String                 `tfsdk:"id"`
+	Parallelism        types.Int64                  `tfsdk:"parallelism"`
+	RegistrationTokens map[string]types.String      `tfsdk:"registration_tokens"`
+	SiteIds            map[string]types.String      `tfsdk:"site_ids"`
+	Sites              map[string]tfSiteSetSettings `tfsdk:"sites"`
+}
+
+// tfSiteSetSettings defines the Terraform model for the settings of a single site within a set of sites.
+type tfSiteSetSettings struct {
+	Description       types.String `tfsdk:"description"`
+	ExternalId        types.String `tfsdk:"external_id"`
+	SiteType          types.String `tfsdk:"site_type"`
+	TotalLicenses     types.Int64  `tfsdk:"total_licenses"`
+	UnlimitedLicenses types.Bool   `tfsdk:"unlimited_licenses"`
+}
+
+// NewSiteSet creates a new SiteSet object.
+func NewSiteSet() resource.Resource {
+	return &SiteSet{}
+}
+
+// SiteSet is a resource used to manage the lifecycle of many sites within an account at once.
+type SiteSet struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *SiteSet) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site_set"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *SiteSet) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing many sites within an account as a single " +
+			"resource.",
+		MarkdownDescription: `This resource is used for creating and managing many sites within an account as a single
+			resource.
+
+		Sites are keyed by name in the ` + "`sites`" + ` map. Adding or removing a key creates or deletes the
+		corresponding site and changing a key (ie: renaming a site) replaces it. API calls for each site are made in
+		parallel, up to ` + "`parallelism`" + ` at a time. The ID and registration token of every site is available in
+		the ` + "`site_ids`" + ` and ` + "`registration_tokens`" + ` maps.
+
+		All sites in the set never expire. Use the ` + "`singularity_site`" + ` resource for sites that need an
+		expiration.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description:         "ID of the account in which to create the sites.",
+				MarkdownDescription: "ID of the account in which to create the sites.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the site set.",
+				MarkdownDescription: "ID of the site set.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"parallelism": schema.Int64Attribute{
+				Description:         "Maximum number of concurrent API calls to make when changing sites. [Default: 5]",
+				MarkdownDescription: "Maximum number of concurrent API calls to make when changing sites. [Default: `5`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(5),
+			},
+			"registration_tokens": schema.MapAttribute{
+				Description:         "Map of site names to the registration token of each site.",
+				MarkdownDescription: "Map of site names to the registration token of each site.",
+				Computed:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+			},
+			"site_ids": schema.MapAttribute{
+				Description:         "Map of site names to the ID of each site.",
+				MarkdownDescription: "Map of site names to the ID of each site.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"sites": schema.MapNestedAttribute{
+				Description:         "Map of site names to the settings for each site.",
+				MarkdownDescription: "Map of site names to the settings for each site.",
+				Required:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"description": schema.StringAttribute{
+							Description:         "User-defined description of the site.",
+							MarkdownDescription: "User-defined description of the site.",
+							Optional:            true,
+						},
+						"external_id": schema.StringAttribute{
+							Description:         "External ID of the site (eg: a CRM identifier).",
+							MarkdownDescription: "External ID of the site (eg: a CRM identifier).",
+							Optional:            true,
+						},
+						"site_type": schema.StringAttribute{
+							Description:         "Type of site (valid values: Paid, Trial). [Default: Paid]",
+							MarkdownDescription: "Type of site (valid values: `Paid`, `Trial`). [Default: `Paid`]",
+							Optional:            true,
+							Validators: []validator.String{
+								validators.EnumStringValueOneOf(false, "Paid", "Trial"),
+							},
+						},
+						"total_licenses": schema.Int64Attribute{
+							Description:         "Total number of licenses allocated to the site.",
+							MarkdownDescription: "Total number of licenses allocated to the site.",
+							Optional:            true,
+						},
+						"unlimited_licenses": schema.BoolAttribute{
+							Description: "Whether or not the site has unlimited licenses. [Default: true unless " +
+								"total_licenses is set]",
+							MarkdownDescription: "Whether or not the site has unlimited licenses. [Default: `true` unless " +
+								"`total_licenses` is set]",
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *SiteSet) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_SITE_SET_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+//
+// If any site fails to be created, only the sites which were created successfully are saved to the state so that
+// they are cleaned up when the resource is replaced.
+func (r *SiteSet) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfSiteSet
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create all of the sites
+	state := tfSiteSet{
+		AccountId:          plan.AccountId,
+		Id:                 types.StringValue(fmt.Sprintf("%s-%d", plan.AccountId.ValueString(), time.Now().Unix())),
+		Parallelism:        plan.Parallelism,
+		RegistrationTokens: map[string]types.String{},
+		SiteIds:            map[string]types.String{},
+		Sites:              map[string]tfSiteSetSettings{},
+	}
+	resp.Diagnostics.Append(r.createSites(ctx, plan, &state, siteSetNames(plan.Sites))...)
+
+	// save the sites to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *SiteSet) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfSiteSet
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, siteSetSecrets(state)...)
+
+	// find all of the sites with a single query
+	siteIds := []string{}
+	for _, id := range state.SiteIds {
+		siteIds = append(siteIds, id.ValueString())
+	}
+	found := map[string]api.Site{}
+	if len(siteIds) > 0 {
+		sites, diags := api.Client().FindSites(ctx, api.SiteQueryParams{
+			SiteIds: siteIds,
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, site := range sites {
+			found[site.Id] = site
+		}
+	}
+
+	// remove any sites which no longer exist so they are recreated and refresh the rest
+	for name, id := range state.SiteIds {
+		site, ok := found[id.ValueString()]
+		if !ok || site.State == "deleted" {
+			tflog.Debug(ctx, "Site in site set no longer exists.", map[string]interface{}{
+				"id":   id.ValueString(),
+				"name": name,
+			})
+			delete(state.Sites, name)
+			delete(state.SiteIds, name)
+			delete(state.RegistrationTokens, name)
+			continue
+		}
+		state.RegistrationTokens[name] = types.StringValue(site.RegistrationToken)
+		state.Sites[name] = tfSiteSetSettingsFromAPI(&site, state.Sites[name])
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *SiteSet) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfSiteSet
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfSiteSet
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, siteSetSecrets(state)...)
+	state.Parallelism = plan.Parallelism
+
+	// work out which sites need to be created, updated or deleted
+	toCreate := []string{}
+	toUpdate := []string{}
+	toDelete := []string{}
+	for _, name := range siteSetNames(plan.Sites) {
+		current, ok := state.Sites[name]
+		if !ok {
+			toCreate = append(toCreate, name)
+		} else if !reflect.DeepEqual(current, plan.Sites[name]) {
+			toUpdate = append(toUpdate, name)
+		}
+	}
+	for _, name := range siteSetNames(state.Sites) {
+		if _, ok := plan.Sites[name]; !ok {
+			toDelete = append(toDelete, name)
+		}
+	}
+
+	// apply the changes, saving whatever succeeded even if something failed
+	resp.Diagnostics.Append(r.deleteSites(ctx, &state, toDelete)...)
+	resp.Diagnostics.Append(r.updateSites(ctx, plan, &state, toUpdate)...)
+	resp.Diagnostics.Append(r.createSites(ctx, plan, &state, toCreate)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+}
+
+// Delete removes the Terraform resource.
+func (r *SiteSet) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfSiteSet
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, siteSetSecrets(state)...)
+
+	// delete all of the sites - if any fail, keep the remaining sites in the state
+	resp.Diagnostics.Append(r.deleteSites(ctx, &state, siteSetNames(state.Sites))...)
+	if resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+	}
+}
+
+// createSites creates the sites with the given names using the settings from the plan and records them in the
+// state.
+func (r *SiteSet) createSites(ctx context.Context, plan tfSiteSet, state *tfSiteSet, names []string) diag.Diagnostics {
+	var mutex sync.Mutex
+	accountId := plan.AccountId.ValueString()
+	return forEachParallel(plan.Parallelism.ValueInt64(), names, "site creations",
+		func(name string) diag.Diagnostics {
+			body := siteSetBody(name, plan.Sites[name])
+			body.AccountId = &accountId
+			site, diags := api.Client().CreateSite(ctx, body)
+			if diags.HasError() {
+				return diags
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			state.Sites[name] = plan.Sites[name]
+			state.SiteIds[name] = types.StringValue(site.Id)
+			state.RegistrationTokens[name] = types.StringValue(site.RegistrationToken)
+			return diags
+		})
+}
+
+// deleteSites deletes the sites with the given names and removes them from the state.
+func (r *SiteSet) deleteSites(ctx context.Context, state *tfSiteSet, names []string) diag.Diagnostics {
+	var mutex sync.Mutex
+	return forEachParallel(state.Parallelism.ValueInt64(), names, "site deletions",
+		func(name string) diag.Diagnostics {
+			mutex.Lock()
+			id := state.SiteIds[name].ValueString()
+			mutex.Unlock()
+
+			diags := api.Client().DeleteSite(ctx, id)
+			if diags.HasError() {
+				return diags
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			delete(state.Sites, name)
+			delete(state.SiteIds, name)
+			delete(state.RegistrationTokens, name)
+			return diags
+		})
+}
+
+// updateSites updates the sites with the given names using the settings from the plan and records them in the
+// state.
+func (r *SiteSet) updateSites(ctx context.Context, plan tfSiteSet, state *tfSiteSet, names []string) diag.Diagnostics {
+	var mutex sync.Mutex
+	return forEachParallel(plan.Parallelism.ValueInt64(), names, "site updates",
+		func(name string) diag.Diagnostics {
+			mutex.Lock()
+			id := state.SiteIds[name].ValueString()
+			mutex.Unlock()
+
+			site, diags := api.Client().UpdateSite(ctx, id, siteSetBody(name, plan.Sites[name]))
+			if diags.HasError() {
+				return diags
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			state.Sites[name] = plan.Sites[name]
+			state.RegistrationTokens[name] = types.StringValue(site.RegistrationToken)
+			return diags
+		})
+}
+
+// forEachParallel calls the given function for each of the given names, running at most parallelism calls at once,
+// and returns the combined diagnostics of all calls.
+//
+// Repeated diagnostics are grouped and a summary of how many of the items failed is added at the end, using items
+// to describe what was being done to them (eg: site creations).
+func forEachParallel(parallelism int64, names []string, items string,
+	fn func(name string) diag.Diagnostics) diag.Diagnostics {
+
+	var diags diag.Diagnostics
+	var mutex sync.Mutex
+	failed := 0
+	var wg sync.WaitGroup
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	semaphore := make(chan struct{}, parallelism)
+	for _, name := range names {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(name string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			d := fn(name)
+			mutex.Lock()
+			diags = append(diags, d...)
+			if d.HasError() {
+				failed++
+			}
+			mutex.Unlock()
+		}(name)
+	}
+	wg.Wait()
+	return plugin.AggregateDiagnostics(diags, failed, len(names), items)
+}
+
+// siteSetBody converts the settings for a single site into the API request body for creating or updating the site.
+func siteSetBody(name string, settings tfSiteSetSettings) api.SiteBody {
+	siteType := "Paid"
+	unlimitedExpiration := true
+	unlimitedLicenses := true
+	body := api.SiteBody{
+		Name:                &name,
+		SiteType:            &siteType,
+		UnlimitedExpiration: &unlimitedExpiration,
+		UnlimitedLicenses:   &unlimitedLicenses,
+	}
+
+	if !settings.Description.IsNull() && !settings.Description.IsUnknown() {
+		value := settings.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !settings.ExternalId.IsNull() && !settings.ExternalId.IsUnknown() {
+		value := settings.ExternalId.ValueString()
+		body.ExternalId = &value
+	}
+
+	if !settings.SiteType.IsNull() && !settings.SiteType.IsUnknown() {
+		value := settings.SiteType.ValueString()
+		body.SiteType = &value
+	}
+
+	if !settings.TotalLicenses.IsNull() && !settings.TotalLicenses.IsUnknown() {
+		value := settings.TotalLicenses.ValueInt64()
+		body.TotalLicenses = &value
+		unlimitedLicenses = false
+	}
+
+	if !settings.UnlimitedLicenses.IsNull() && !settings.UnlimitedLicenses.IsUnknown() {
+		unlimitedLicenses = settings.UnlimitedLicenses.ValueBool()
+	}
+	return body
+}
+
+// siteSetNames returns the names of the sites in the given map in sorted order.
+func siteSetNames(sites map[string]tfSiteSetSettings) []string {
+	names := []string{}
+	for name := range sites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// tfSiteSetSettingsFromAPI refreshes the given site settings using an API site.
+//
+// Only settings which were configured are refreshed so that unset settings do not show up as changes.
+func tfSiteSetSettingsFromAPI(site *api.Site, settings tfSiteSetSettings) tfSiteSetSettings {
+	if !settings.Description.IsNull() {
+		settings.Description = types.StringValue(site.Description)
+	}
+	if !settings.ExternalId.IsNull() {
+		settings.ExternalId = types.StringValue(site.ExternalId)
+	}
+	if !settings.SiteType.IsNull() {
+		settings.SiteType = types.StringValue(site.SiteType)
+	}
+	if !settings.TotalLicenses.IsNull() {
+		settings.TotalLicenses = types.Int64Value(int64(site.TotalLicenses))
+	}
+	if !settings.UnlimitedLicenses.IsNull() {
+		settings.UnlimitedLicenses = types.BoolValue(site.UnlimitedLicenses)
+	}
+	return settings
+}
+
+// siteSetSecrets returns the registration tokens held by the given model so that they can be masked in logs.
+func siteSetSecrets(model tfSiteSet) []string {
+	secrets := []string{}
+	for _, token := range model.RegistrationTokens {
+		secrets = append(secrets, token.ValueString())
+	}
+	return secrets
+}
@@ -0,0 +1,387 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &SSOSAML{}
+	_ resource.ResourceWithConfigure   = &SSOSAML{}
+	_ resource.ResourceWithImportState = &SSOSAML{}
+)
+
+// tfSSOSAML defines the Terraform model for the SAML single sign-on settings.
+type tfSSOSAML struct {
+	AttributeMappings map[string]types.String `tfsdk:"attribute_mappings"`
+	DefaultRoleId     types.String            `tfsdk:"default_role_id"`
+	Enabled           types.Bool              `tfsdk:"enabled"`
+	Id                types.String            `tfsdk:"id"`
+	IdpMetadataUrl    types.String            `tfsdk:"idp_metadata_url"`
+	IdpMetadataXml    types.String            `tfsdk:"idp_metadata_xml"`
+	JitProvisioning   types.Bool              `tfsdk:"jit_provisioning"`
+	ScopeId           types.String            `tfsdk:"scope_id"`
+	ScopeType         types.String            `tfsdk:"scope_type"`
+	SpAcsUrl          types.String            `tfsdk:"sp_acs_url"`
+	SpEntityId        types.String            `tfsdk:"sp_entity_id"`
+}
+
+// NewSSOSAML creates a new SSOSAML object.
+func NewSSOSAML() resource.Resource {
+	return &SSOSAML{}
+}
+
+// SSOSAML is a resource used to manage the SAML single sign-on settings of an account or site.
+type SSOSAML struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *SSOSAML) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sso_saml"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *SSOSAML) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the SAML single sign-on settings used to log into the " +
+			"console for an account or site.",
+		MarkdownDescription: `This resource is used for managing the SAML single sign-on settings used to log into the
+			console for an account or site.
+
+		Exactly one of ` + "`idp_metadata_url`" + ` or ` + "`idp_metadata_xml`" + ` must be given. The service
+		provider entity ID and ACS URL needed to configure the identity provider are available in
+		` + "`sp_entity_id`" + ` and ` + "`sp_acs_url`" + `.
+
+		Every account and site always has SSO settings, so creating the resource overrides the existing settings and
+		destroying it disables SSO. The identity provider metadata is not returned in full by the API, so changes
+		made to it outside of Terraform cannot be detected. Existing settings can be imported using an ID in the
+		format ` + "`<scope_type>/<scope_id>`" + `; the metadata is not imported and will be updated on the next
+		apply.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"attribute_mappings": schema.MapAttribute{
+				Description: "Map of console user fields (eg: email, first_name, last_name, role) to the names of the " +
+					"SAML attributes which hold them.",
+				MarkdownDescription: "Map of console user fields (eg: `email`, `first_name`, `last_name`, `role`) to " +
+					"the names of the SAML attributes which hold them.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"default_role_id": schema.StringAttribute{
+				Description:         "ID of the role given to users provisioned just-in-time. [Default: none]",
+				MarkdownDescription: "ID of the role given to users provisioned just-in-time. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not SSO is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not SSO is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the settings (in the format <scope_type>/<scope_id>).",
+				MarkdownDescription: "ID of the settings (in the format `<scope_type>/<scope_id>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"idp_metadata_url": schema.StringAttribute{
+				Description:         "URL from which the identity provider's SAML metadata is retrieved.",
+				MarkdownDescription: "URL from which the identity provider's SAML metadata is retrieved.",
+				Optional:            true,
+			},
+			"idp_metadata_xml": schema.StringAttribute{
+				Description:         "The identity provider's SAML metadata XML document.",
+				MarkdownDescription: "The identity provider's SAML metadata XML document.",
+				Optional:            true,
+			},
+			"jit_provisioning": schema.BoolAttribute{
+				Description: "Whether or not users who log in for the first time are created automatically. " +
+					"[Default: false]",
+				MarkdownDescription: "Whether or not users who log in for the first time are created automatically. " +
+					"[Default: `false`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the settings belong.",
+				MarkdownDescription: "ID of the account or site to which the settings belong.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the settings apply (valid values: account, site).",
+				MarkdownDescription: "Level at which the settings apply (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"sp_acs_url": schema.StringAttribute{
+				Description: "Assertion consumer service URL of the console to configure in the identity " +
+					"provider.",
+				MarkdownDescription: "Assertion consumer service URL of the console to configure in the identity " +
+					"provider.",
+				Computed: true,
+			},
+			"sp_entity_id": schema.StringAttribute{
+				Description:         "Entity ID of the console to configure in the identity provider.",
+				MarkdownDescription: "Entity ID of the console to configure in the identity provider.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *SSOSAML) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_SSO_SAML_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *SSOSAML) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfSSOSAML
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.checkMetadata(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the scope always has settings so creating them simply overrides them
+	settings, diags := api.Client().UpdateSSOSettings(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the settings to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSSOSAMLFromAPI(ctx, settings, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *SSOSAML) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfSSOSAML
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the current settings of the scope
+	settings, diags := api.Client().GetSSOSettings(ctx, scopeFromModel(state.ScopeType, state.ScopeId))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSSOSAMLFromAPI(ctx, settings, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *SSOSAML) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfSSOSAML
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.checkMetadata(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the settings
+	settings, diags := api.Client().UpdateSSOSettings(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated settings to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSSOSAMLFromAPI(ctx, settings, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *SSOSAML) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfSSOSAML
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// disable SSO for the scope
+	enabled := false
+	_, diags := api.Client().UpdateSSOSettings(ctx, scopeFromModel(state.ScopeType, state.ScopeId),
+		api.SSOSettingsBody{Enabled: &enabled})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Disabled SSO", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports the SAML single sign-on settings of an existing account or site into the Terraform state.
+//
+// The import ID must be in the format <scope_type>/<scope_id>.
+func (r *SSOSAML) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id> where the scope type is one "+
+			"of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_SSO_SAML_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), req.ID)...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating the SSO settings.
+func (r *SSOSAML) bodyFromPlan(plan tfSSOSAML) api.SSOSettingsBody {
+	body := api.SSOSettingsBody{}
+
+	if plan.AttributeMappings != nil {
+		body.AttributeMappings = map[string]string{}
+		for k, v := range plan.AttributeMappings {
+			body.AttributeMappings[k] = v.ValueString()
+		}
+	}
+
+	if !plan.DefaultRoleId.IsNull() && !plan.DefaultRoleId.IsUnknown() {
+		value := plan.DefaultRoleId.ValueString()
+		body.DefaultRoleId = &value
+	}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := plan.Enabled.ValueBool()
+		body.Enabled = &value
+	}
+
+	if !plan.IdpMetadataUrl.IsNull() && !plan.IdpMetadataUrl.IsUnknown() {
+		value := plan.IdpMetadataUrl.ValueString()
+		body.IdpMetadataUrl = &value
+	}
+
+	if !plan.IdpMetadataXml.IsNull() && !plan.IdpMetadataXml.IsUnknown() {
+		value := plan.IdpMetadataXml.ValueString()
+		body.IdpMetadataXml = &value
+	}
+
+	if !plan.JitProvisioning.IsNull() && !plan.JitProvisioning.IsUnknown() {
+		value := plan.JitProvisioning.ValueBool()
+		body.JitProvisioning = &value
+	}
+	return body
+}
+
+// checkMetadata ensures that exactly one source of identity provider metadata is given in the plan.
+func (r *SSOSAML) checkMetadata(ctx context.Context, plan tfSSOSAML) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	hasUrl := !plan.IdpMetadataUrl.IsNull() && plan.IdpMetadataUrl.ValueString() != ""
+	hasXml := !plan.IdpMetadataXml.IsNull() && plan.IdpMetadataXml.ValueString() != ""
+	if hasUrl != hasXml {
+		return diags
+	}
+	msg := "Exactly one of idp_metadata_url or idp_metadata_xml must be given."
+	tflog.Error(ctx, msg, map[string]interface{}{
+		"internal_error_code": plugin.ERR_RESOURCE_SSO_SAML_METADATA,
+	})
+	diags.AddAttributeError(tfpath.Root("idp_metadata_url"), "Invalid Identity Provider Metadata", msg)
+	return diags
+}
+
+// tfSSOSAMLFromAPI converts API SSO settings into Terraform SSO settings.
+//
+// The API does not return the scope or the metadata XML of the settings so they are copied from the given model,
+// along with the metadata URL so that it is only ever compared with the configured value.
+func tfSSOSAMLFromAPI(ctx context.Context, settings *api.SSOSettings, model tfSSOSAML) tfSSOSAML {
+	id := fmt.Sprintf("%s/%s", model.ScopeType.ValueString(), model.ScopeId.ValueString())
+	tfsettings := tfSSOSAML{
+		AttributeMappings: map[string]types.String{},
+		DefaultRoleId:     types.StringValue(settings.DefaultRoleId),
+		Enabled:           types.BoolValue(settings.Enabled),
+		Id:                types.StringValue(id),
+		IdpMetadataUrl:    model.IdpMetadataUrl,
+		IdpMetadataXml:    model.IdpMetadataXml,
+		JitProvisioning:   types.BoolValue(settings.JitProvisioning),
+		ScopeId:           model.ScopeId,
+		ScopeType:         model.ScopeType,
+		SpAcsUrl:          types.StringValue(settings.SpAcsUrl),
+		SpEntityId:        types.StringValue(settings.SpEntityId),
+	}
+	for k, v := range settings.AttributeMappings {
+		tfsettings.AttributeMappings[k] = types.StringValue(v)
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API SSO settings to TF SSO settings: %+v", tfsettings),
+		map[string]interface{}{
+			"api_sso_settings": settings,
+		})
+	return tfsettings
+}
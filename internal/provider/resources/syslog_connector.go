@@ -0,0 +1,360 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &SyslogConnector{}
+	_ resource.ResourceWithConfigure   = &SyslogConnector{}
+	_ resource.ResourceWithImportState = &SyslogConnector{}
+)
+
+// tfSyslogConnector defines the Terraform model for the syslog integration settings.
+type tfSyslogConnector struct {
+	Enabled   types.Bool   `tfsdk:"enabled"`
+	Facility  types.String `tfsdk:"facility"`
+	Format    types.String `tfsdk:"format"`
+	Host      types.String `tfsdk:"host"`
+	Id        types.String `tfsdk:"id"`
+	Port      types.Int64  `tfsdk:"port"`
+	Protocol  types.String `tfsdk:"protocol"`
+	ScopeId   types.String `tfsdk:"scope_id"`
+	ScopeType types.String `tfsdk:"scope_type"`
+	TLS       types.Bool   `tfsdk:"tls"`
+}
+
+// NewSyslogConnector creates a new SyslogConnector object.
+func NewSyslogConnector() resource.Resource {
+	return &SyslogConnector{}
+}
+
+// SyslogConnector is a resource used to manage the syslog integration settings of an account or site.
+type SyslogConnector struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *SyslogConnector) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_syslog_connector"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *SyslogConnector) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the syslog integration used to forward events from an " +
+			"account or site to a SIEM.",
+		MarkdownDescription: `This resource is used for managing the syslog integration used to forward events from an
+			account or site to a SIEM.
+
+		Every account and site always has syslog settings, so creating the resource overrides the existing settings
+		and destroying it disables the integration. Which events are sent over syslog is controlled separately by the
+		` + "`syslog`" + ` channel of each ` + "`singularity_notification_rule`" + ` resource.
+
+		Existing settings can be imported using an ID in the format ` + "`<scope_type>/<scope_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not the syslog integration is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not the syslog integration is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"facility": schema.StringAttribute{
+				Description:         "Syslog facility used for the messages (eg: local0, user). [Default: local0]",
+				MarkdownDescription: "Syslog facility used for the messages (eg: `local0`, `user`). [Default: `local0`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("local0"),
+			},
+			"format": schema.StringAttribute{
+				Description:         "Format of the messages (valid values: cef, cef2, syslog). [Default: cef]",
+				MarkdownDescription: "Format of the messages (valid values: `cef`, `cef2`, `syslog`). [Default: `cef`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("cef"),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "cef", "cef2", "syslog"),
+				},
+			},
+			"host": schema.StringAttribute{
+				Description:         "Hostname or IP address of the syslog server.",
+				MarkdownDescription: "Hostname or IP address of the syslog server.",
+				Required:            true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the settings (in the format <scope_type>/<scope_id>).",
+				MarkdownDescription: "ID of the settings (in the format `<scope_type>/<scope_id>`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"port": schema.Int64Attribute{
+				Description:         "Port on which the syslog server listens. [Default: 514]",
+				MarkdownDescription: "Port on which the syslog server listens. [Default: `514`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(514),
+			},
+			"protocol": schema.StringAttribute{
+				Description: "Transport protocol used to send the messages (valid values: tcp, udp). [Default: tcp]",
+				MarkdownDescription: "Transport protocol used to send the messages (valid values: `tcp`, `udp`). " +
+					"[Default: `tcp`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("tcp"),
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "tcp", "udp"),
+				},
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the settings belong.",
+				MarkdownDescription: "ID of the account or site to which the settings belong.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the settings apply (valid values: account, site).",
+				MarkdownDescription: "Level at which the settings apply (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"tls": schema.BoolAttribute{
+				Description: "Whether or not the connection to the syslog server uses TLS (tcp only). [Default: false]",
+				MarkdownDescription: "Whether or not the connection to the syslog server uses TLS (`tcp` only). " +
+					"[Default: `false`]",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *SyslogConnector) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_SYSLOG_CONNECTOR_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *SyslogConnector) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfSyslogConnector
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the scope always has settings so creating them simply overrides them
+	connector, diags := api.Client().UpdateSyslogConnector(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the settings to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSyslogConnectorFromAPI(ctx, connector, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *SyslogConnector) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfSyslogConnector
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// get the current settings of the scope
+	connector, diags := api.Client().GetSyslogConnector(ctx, scopeFromModel(state.ScopeType, state.ScopeId))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSyslogConnectorFromAPI(ctx, connector, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *SyslogConnector) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from plan
+	var plan tfSyslogConnector
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the settings
+	connector, diags := api.Client().UpdateSyslogConnector(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated settings to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfSyslogConnectorFromAPI(ctx, connector, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *SyslogConnector) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfSyslogConnector
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// disable the integration for the scope
+	enabled := false
+	_, diags := api.Client().UpdateSyslogConnector(ctx, scopeFromModel(state.ScopeType, state.ScopeId),
+		api.SyslogConnectorBody{Enabled: &enabled})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Disabled syslog integration", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports the syslog integration settings of an existing account or site into the Terraform state.
+//
+// The import ID must be in the format <scope_type>/<scope_id>.
+func (r *SyslogConnector) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id> where the scope type is one "+
+			"of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_SYSLOG_CONNECTOR_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), req.ID)...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for updating the syslog integration settings.
+func (r *SyslogConnector) bodyFromPlan(plan tfSyslogConnector) api.SyslogConnectorBody {
+	body := api.SyslogConnectorBody{}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := plan.Enabled.ValueBool()
+		body.Enabled = &value
+	}
+
+	if !plan.Facility.IsNull() && !plan.Facility.IsUnknown() {
+		value := plan.Facility.ValueString()
+		body.Facility = &value
+	}
+
+	if !plan.Format.IsNull() && !plan.Format.IsUnknown() {
+		value := strings.ToLower(plan.Format.ValueString())
+		body.Format = &value
+	}
+
+	if !plan.Host.IsNull() && !plan.Host.IsUnknown() {
+		value := plan.Host.ValueString()
+		body.Host = &value
+	}
+
+	if !plan.Port.IsNull() && !plan.Port.IsUnknown() {
+		value := plan.Port.ValueInt64()
+		body.Port = &value
+	}
+
+	if !plan.Protocol.IsNull() && !plan.Protocol.IsUnknown() {
+		value := strings.ToLower(plan.Protocol.ValueString())
+		body.Protocol = &value
+	}
+
+	if !plan.TLS.IsNull() && !plan.TLS.IsUnknown() {
+		value := plan.TLS.ValueBool()
+		body.TLS = &value
+	}
+	return body
+}
+
+// tfSyslogConnectorFromAPI converts API syslog integration settings into Terraform syslog integration settings.
+//
+// The API does not return the scope of the settings so it is copied from the given model.
+func tfSyslogConnectorFromAPI(ctx context.Context, connector *api.SyslogConnector,
+	model tfSyslogConnector) tfSyslogConnector {
+
+	tfconnector := tfSyslogConnector{
+		Enabled:   types.BoolValue(connector.Enabled),
+		Facility:  types.StringValue(connector.Facility),
+		Format:    types.StringValue(connector.Format),
+		Host:      types.StringValue(connector.Host),
+		Id:        types.StringValue(fmt.Sprintf("%s/%s", model.ScopeType.ValueString(), model.ScopeId.ValueString())),
+		Port:      types.Int64Value(connector.Port),
+		Protocol:  types.StringValue(connector.Protocol),
+		ScopeId:   model.ScopeId,
+		ScopeType: model.ScopeType,
+		TLS:       types.BoolValue(connector.TLS),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API syslog connector to TF syslog connector: %+v", tfconnector),
+		map[string]interface{}{
+			"api_syslog_connector": connector,
+		})
+	return tfconnector
+}
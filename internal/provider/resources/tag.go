@@ -0,0 +1,322 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Tag{}
+	_ resource.ResourceWithConfigure   = &Tag{}
+	_ resource.ResourceWithImportState = &Tag{}
+)
+
+// tfTag defines the Terraform model for an agent tag.
+type tfTag struct {
+	CreatedAt   types.String `tfsdk:"created_at"`
+	Description types.String `tfsdk:"description"`
+	Id          types.String `tfsdk:"id"`
+	Key         types.String `tfsdk:"key"`
+	ScopeId     types.String `tfsdk:"scope_id"`
+	ScopeType   types.String `tfsdk:"scope_type"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+	Value       types.String `tfsdk:"value"`
+}
+
+// NewTag creates a new Tag object.
+func NewTag() resource.Resource {
+	return &Tag{}
+}
+
+// Tag is a resource used to manage the lifecycle of an agent tag.
+type Tag struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Tag) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tag"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Tag) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing an agent tag within an account or site.",
+		MarkdownDescription: `This resource is used for creating and managing an agent tag within an account or site.
+
+		Tags are key/value pairs which can be assigned to agents and referenced by policies, firewall rules and
+		filters for dynamic groups (eg: the ` + "`tag_ids`" + ` of a ` + "`singularity_filter`" + ` resource).
+		Changing the key or value of a tag renames it in place, so it remains assigned to the same agents. Deleting
+		a tag removes it from every agent to which it is assigned. The tag manager does not support colors or any
+		other metadata beyond a description.
+
+		Existing tags can be imported using an ID in the format ` + "`<scope_type>/<scope_id>/<tag_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the tag was created.",
+				MarkdownDescription: "Timestamp of when the tag was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the tag. [Default: none]",
+				MarkdownDescription: "Description of the tag. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the tag.",
+				MarkdownDescription: "ID of the tag.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Description:         "Key of the tag.",
+				MarkdownDescription: "Key of the tag.",
+				Required:            true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the tag belongs.",
+				MarkdownDescription: "ID of the account or site to which the tag belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the tag is created (valid values: account, site).",
+				MarkdownDescription: "Level at which the tag is created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the tag was last updated.",
+				MarkdownDescription: "Timestamp of when the tag was last updated.",
+				Computed:            true,
+			},
+			"value": schema.StringAttribute{
+				Description:         "Value of the tag. [Default: none]",
+				MarkdownDescription: "Value of the tag. [Default: none]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Tag) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_TAG_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Tag) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfTag
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the tag
+	tag, diags := api.Client().CreateTag(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the tag to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfTagFromAPI(ctx, tag, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Tag) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfTag
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the tag - if it no longer exists, remove it from the state
+	queryParams := api.TagQueryParams{
+		TagIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	tags, diags := api.Client().FindTags(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(tags) == 0 {
+		tflog.Debug(ctx, "Tag no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfTagFromAPI(ctx, &tags[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Tag) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfTag
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfTag
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the tag
+	tag, diags := api.Client().UpdateTag(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated tag to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfTagFromAPI(ctx, tag, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Tag) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfTag
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the tag
+	resp.Diagnostics.Append(api.Client().DeleteTag(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed tag", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing tag into the Terraform state.
+//
+// The API can only find a tag within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<tag_id>.
+func (r *Tag) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<tag_id> where the scope "+
+			"type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_TAG_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a tag.
+func (r *Tag) bodyFromPlan(plan tfTag) api.TagBody {
+	body := api.TagBody{}
+
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		value := plan.Description.ValueString()
+		body.Description = &value
+	}
+
+	if !plan.Key.IsNull() && !plan.Key.IsUnknown() {
+		value := plan.Key.ValueString()
+		body.Key = &value
+	}
+
+	if !plan.Value.IsNull() && !plan.Value.IsUnknown() {
+		value := plan.Value.ValueString()
+		body.Value = &value
+	}
+	return body
+}
+
+// tfTagFromAPI converts an API tag into a Terraform tag.
+//
+// The scope is copied from the given model since it is always known from the configuration or import ID.
+func tfTagFromAPI(ctx context.Context, tag *api.Tag, model tfTag) tfTag {
+	tftag := tfTag{
+		CreatedAt:   types.StringValue(tag.CreatedAt),
+		Description: types.StringValue(tag.Description),
+		Id:          types.StringValue(tag.Id),
+		Key:         types.StringValue(tag.Key),
+		ScopeId:     model.ScopeId,
+		ScopeType:   model.ScopeType,
+		UpdatedAt:   types.StringValue(tag.UpdatedAt),
+		Value:       types.StringValue(tag.Value),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API tag to TF tag: %+v", tftag), map[string]interface{}{
+		"api_tag": tag,
+	})
+	return tftag
+}
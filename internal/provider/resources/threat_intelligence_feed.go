@@ -0,0 +1,418 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &ThreatIntelligenceFeed{}
+	_ resource.ResourceWithConfigure   = &ThreatIntelligenceFeed{}
+	_ resource.ResourceWithImportState = &ThreatIntelligenceFeed{}
+)
+
+// tfThreatIntelligenceFeed defines the Terraform model for a third-party threat intelligence feed.
+type tfThreatIntelligenceFeed struct {
+	ApiKey                 types.String `tfsdk:"api_key"`
+	CreatedAt              types.String `tfsdk:"created_at"`
+	Description            types.String `tfsdk:"description"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	FeedUrl                types.String `tfsdk:"feed_url"`
+	Format                 types.String `tfsdk:"format"`
+	Id                     types.String `tfsdk:"id"`
+	IndicatorCount         types.Int64  `tfsdk:"indicator_count"`
+	LastRefreshedAt        types.String `tfsdk:"last_refreshed_at"`
+	LastRefreshMessage     types.String `tfsdk:"last_refresh_message"`
+	LastRefreshStatus      types.String `tfsdk:"last_refresh_status"`
+	Name                   types.String `tfsdk:"name"`
+	RefreshIntervalMinutes types.Int64  `tfsdk:"refresh_interval_minutes"`
+	ScopeId                types.String `tfsdk:"scope_id"`
+	ScopeType              types.String `tfsdk:"scope_type"`
+	UpdatedAt              types.String `tfsdk:"updated_at"`
+}
+
+// NewThreatIntelligenceFeed creates a new ThreatIntelligenceFeed object.
+func NewThreatIntelligenceFeed() resource.Resource {
+	return &ThreatIntelligenceFeed{}
+}
+
+// ThreatIntelligenceFeed is a resource used to manage the recurring ingestion of a third-party threat intelligence
+// feed.
+type ThreatIntelligenceFeed struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ThreatIntelligenceFeed) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_threat_intelligence_feed"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ThreatIntelligenceFeed) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing the recurring ingestion of a third-party threat intelligence " +
+			"feed within an account or site.",
+		MarkdownDescription: `This resource is used for managing the recurring ingestion of a third-party threat
+			intelligence feed within an account or site.
+
+		The server downloads the feed from ` + "`feed_url`" + ` every ` + "`refresh_interval_minutes`" + ` minutes and
+		ingests the indicators it contains as IOCs. The status of the most recent refresh is updated whenever the
+		resource is read and a warning is shown if the last refresh of an enabled feed failed. The API never returns
+		the ` + "`api_key`" + ` so changes made to it outside of Terraform cannot be detected. Existing feeds can be
+		imported using an ID in the format ` + "`<scope_type>/<scope_id>/<feed_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"api_key": schema.StringAttribute{
+				Description:         "API key sent to the feed provider when downloading the feed. [Default: none]",
+				MarkdownDescription: "API key sent to the feed provider when downloading the feed. [Default: none]",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the feed was created.",
+				MarkdownDescription: "Timestamp of when the feed was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "Description of the feed. [Default: \"\"]",
+				MarkdownDescription: "Description of the feed. [Default: `\"\"`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not the feed is refreshed on its schedule. [Default: true]",
+				MarkdownDescription: "Whether or not the feed is refreshed on its schedule. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"feed_url": schema.StringAttribute{
+				Description:         "URL from which the feed is downloaded.",
+				MarkdownDescription: "URL from which the feed is downloaded.",
+				Required:            true,
+			},
+			"format": schema.StringAttribute{
+				Description:         "Format of the feed (valid values: csv, json, stix, taxii).",
+				MarkdownDescription: "Format of the feed (valid values: `csv`, `json`, `stix`, `taxii`).",
+				Required:            true,
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.THREAT_INTELLIGENCE_FEED_FORMAT_CSV,
+						api.THREAT_INTELLIGENCE_FEED_FORMAT_JSON, api.THREAT_INTELLIGENCE_FEED_FORMAT_STIX,
+						api.THREAT_INTELLIGENCE_FEED_FORMAT_TAXII),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the feed.",
+				MarkdownDescription: "ID of the feed.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"indicator_count": schema.Int64Attribute{
+				Description:         "Number of indicators ingested from the feed during the last refresh.",
+				MarkdownDescription: "Number of indicators ingested from the feed during the last refresh.",
+				Computed:            true,
+			},
+			"last_refreshed_at": schema.StringAttribute{
+				Description:         "Timestamp of when the feed was last refreshed.",
+				MarkdownDescription: "Timestamp of when the feed was last refreshed.",
+				Computed:            true,
+			},
+			"last_refresh_message": schema.StringAttribute{
+				Description:         "Details about the last refresh of the feed.",
+				MarkdownDescription: "Details about the last refresh of the feed.",
+				Computed:            true,
+			},
+			"last_refresh_status": schema.StringAttribute{
+				Description:         "Status of the last refresh of the feed (eg: pending, success, failed).",
+				MarkdownDescription: "Status of the last refresh of the feed (eg: `pending`, `success`, `failed`).",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the feed.",
+				MarkdownDescription: "Name of the feed.",
+				Required:            true,
+			},
+			"refresh_interval_minutes": schema.Int64Attribute{
+				Description:         "Number of minutes between refreshes of the feed. [Default: 60]",
+				MarkdownDescription: "Number of minutes between refreshes of the feed. [Default: `60`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(60),
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the feed belongs.",
+				MarkdownDescription: "ID of the account or site to which the feed belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level to which the feed belongs (valid values: account, site).",
+				MarkdownDescription: "Level to which the feed belongs (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the feed was last updated.",
+				MarkdownDescription: "Timestamp of when the feed was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ThreatIntelligenceFeed) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_THREAT_INTELLIGENCE_FEED_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *ThreatIntelligenceFeed) Create(ctx context.Context, req resource.CreateRequest,
+	resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfThreatIntelligenceFeed
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.ApiKey.ValueString())
+
+	// create the feed
+	feed, diags := api.Client().CreateThreatIntelligenceFeed(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the feed to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfThreatIntelligenceFeedFromAPI(ctx, feed, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *ThreatIntelligenceFeed) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfThreatIntelligenceFeed
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, state.ApiKey.ValueString())
+
+	// find the feed - if it no longer exists, remove it from the state
+	queryParams := api.ThreatIntelligenceFeedQueryParams{
+		FeedIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	feeds, diags := api.Client().FindThreatIntelligenceFeeds(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(feeds) == 0 {
+		tflog.Debug(ctx, "Threat intelligence feed no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	feed := &feeds[0]
+
+	// surface a failed refresh of the feed without failing the refresh of the resource
+	if feed.Enabled && feed.LastRefreshStatus == api.THREAT_INTELLIGENCE_FEED_STATUS_FAILED {
+		msg := fmt.Sprintf("The last refresh of the threat intelligence feed failed and its indicators may be out of "+
+			"date. Check the feed URL, format and API key.\n\nFeed: %s\nLast Refreshed: %s\nMessage: %s", feed.Id,
+			feed.LastRefreshedAt, feed.LastRefreshMessage)
+		tflog.Warn(ctx, msg, map[string]interface{}{
+			"feed_id":              feed.Id,
+			"last_refresh_message": feed.LastRefreshMessage,
+		})
+		resp.Diagnostics.AddWarning("Threat Intelligence Feed Refresh Failed", msg)
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfThreatIntelligenceFeedFromAPI(ctx, feed, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *ThreatIntelligenceFeed) Update(ctx context.Context, req resource.UpdateRequest,
+	resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfThreatIntelligenceFeed
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfThreatIntelligenceFeed
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.ApiKey.ValueString(), state.ApiKey.ValueString())
+
+	// update the feed
+	feed, diags := api.Client().UpdateThreatIntelligenceFeed(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated feed to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfThreatIntelligenceFeedFromAPI(ctx, feed, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *ThreatIntelligenceFeed) Delete(ctx context.Context, req resource.DeleteRequest,
+	resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfThreatIntelligenceFeed
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the feed
+	resp.Diagnostics.Append(api.Client().DeleteThreatIntelligenceFeed(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted threat intelligence feed", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing threat intelligence feed into the Terraform state.
+//
+// The API can only find a feed within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<feed_id>.
+func (r *ThreatIntelligenceFeed) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<feed_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_THREAT_INTELLIGENCE_FEED_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a feed.
+func (r *ThreatIntelligenceFeed) bodyFromPlan(plan tfThreatIntelligenceFeed) api.ThreatIntelligenceFeedBody {
+	description := plan.Description.ValueString()
+	enabled := plan.Enabled.ValueBool()
+	feedUrl := plan.FeedUrl.ValueString()
+	format := plan.Format.ValueString()
+	name := plan.Name.ValueString()
+	refreshInterval := plan.RefreshIntervalMinutes.ValueInt64()
+	body := api.ThreatIntelligenceFeedBody{
+		Description:            &description,
+		Enabled:                &enabled,
+		FeedUrl:                &feedUrl,
+		Format:                 &format,
+		Name:                   &name,
+		RefreshIntervalMinutes: &refreshInterval,
+	}
+	if !plan.ApiKey.IsNull() && !plan.ApiKey.IsUnknown() {
+		apiKey := plan.ApiKey.ValueString()
+		body.ApiKey = &apiKey
+	}
+	return body
+}
+
+// tfThreatIntelligenceFeedFromAPI converts an API threat intelligence feed into a Terraform threat intelligence feed.
+//
+// The API does not return the scope or the API key of the feed so they are copied from the given model.
+func tfThreatIntelligenceFeedFromAPI(ctx context.Context, feed *api.ThreatIntelligenceFeed,
+	model tfThreatIntelligenceFeed) tfThreatIntelligenceFeed {
+	tffeed := tfThreatIntelligenceFeed{
+		ApiKey:                 model.ApiKey,
+		CreatedAt:              types.StringValue(feed.CreatedAt),
+		Description:            types.StringValue(feed.Description),
+		Enabled:                types.BoolValue(feed.Enabled),
+		FeedUrl:                types.StringValue(feed.FeedUrl),
+		Format:                 types.StringValue(feed.Format),
+		Id:                     types.StringValue(feed.Id),
+		IndicatorCount:         types.Int64Value(feed.IndicatorCount),
+		LastRefreshedAt:        types.StringValue(feed.LastRefreshedAt),
+		LastRefreshMessage:     types.StringValue(feed.LastRefreshMessage),
+		LastRefreshStatus:      types.StringValue(feed.LastRefreshStatus),
+		Name:                   types.StringValue(feed.Name),
+		RefreshIntervalMinutes: types.Int64Value(feed.RefreshIntervalMinutes),
+		ScopeId:                model.ScopeId,
+		ScopeType:              model.ScopeType,
+		UpdatedAt:              types.StringValue(feed.UpdatedAt),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API threat intelligence feed to TF threat intelligence feed: %+v",
+		tffeed), map[string]interface{}{
+		"api_threat_intelligence_feed": feed,
+	})
+	return tffeed
+}
@@ -0,0 +1,385 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api/waiter"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+const (
+	// threatMitigationStateCompleted is the waiter state used once the action is no longer pending on any agent.
+	threatMitigationStateCompleted = "completed"
+
+	// threatMitigationStatePending is the waiter state used while the action is pending on any agent.
+	threatMitigationStatePending = "pending"
+)
+
+// threatMitigationActions maps the mitigation actions which can be configured to the actions used by the API.
+var threatMitigationActions = map[string]string{
+	"kill":         "kill",
+	"quarantine":   "quarantine",
+	"remediate":    "remediate",
+	"rollback":     "rollback-remediation",
+	"unquarantine": "un-quarantine",
+}
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource              = &ThreatMitigation{}
+	_ resource.ResourceWithConfigure = &ThreatMitigation{}
+)
+
+// tfThreatMitigation defines the Terraform model for a mitigation action applied to threats.
+type tfThreatMitigation struct {
+	Action        types.String   `tfsdk:"action"`
+	AffectedCount types.Int64    `tfsdk:"affected_count"`
+	PollInterval  types.String   `tfsdk:"poll_interval"`
+	ThreatIds     []types.String `tfsdk:"threat_ids"`
+	Threats       types.List     `tfsdk:"threats"`
+	Triggers      types.Map      `tfsdk:"triggers"`
+	WaitTimeout   types.String   `tfsdk:"wait_timeout"`
+}
+
+// tfThreatMitigationResult defines the Terraform model for the result of a mitigation action on a single threat.
+type tfThreatMitigationResult struct {
+	FailedCount  types.Int64  `tfsdk:"failed_count"`
+	PendingCount types.Int64  `tfsdk:"pending_count"`
+	Status       types.String `tfsdk:"status"`
+	SuccessCount types.Int64  `tfsdk:"success_count"`
+	ThreatId     types.String `tfsdk:"threat_id"`
+}
+
+// tfThreatMitigationResultAttrTypes defines the attribute types of a tfThreatMitigationResult object.
+var tfThreatMitigationResultAttrTypes = map[string]attr.Type{
+	"failed_count":  types.Int64Type,
+	"pending_count": types.Int64Type,
+	"status":        types.StringType,
+	"success_count": types.Int64Type,
+	"threat_id":     types.StringType,
+}
+
+// NewThreatMitigation creates a new ThreatMitigation object.
+func NewThreatMitigation() resource.Resource {
+	return &ThreatMitigation{}
+}
+
+// ThreatMitigation is a resource used to apply a mitigation action to one or more threats.
+type ThreatMitigation struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ThreatMitigation) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+
+	resp.TypeName = req.ProviderTypeName + "_threat_mitigation"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ThreatMitigation) Schema(ctx context.Context, req resource.SchemaRequest,
+	resp *resource.SchemaResponse) {
+
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for applying a mitigation action to one or more threats.",
+		MarkdownDescription: `This resource is used for applying a mitigation action to one or more threats.
+
+		The action is applied when the resource is created and the mitigation status of each threat is polled every
+		` + "`poll_interval`" + ` until the action is no longer pending on any agent, failing if that does not happen
+		within ` + "`wait_timeout`" + `. The result for each threat is recorded in ` + "`threats`" + ` for audit
+		purposes. Agents on which the action failed are reported as a warning rather than an error so that the
+		action is not applied again on the next apply.
+
+		Destroying the resource does not undo the action. To apply the action again, change any value in
+		` + "`triggers`" + `, which causes the resource to be replaced.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"action": schema.StringAttribute{
+				Description: "Mitigation action to apply (valid values: kill, quarantine, remediate, rollback, " +
+					"unquarantine).",
+				MarkdownDescription: "Mitigation action to apply (valid values: `kill`, `quarantine`, `remediate`, " +
+					"`rollback`, `unquarantine`).",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, "kill", "quarantine", "remediate", "rollback",
+						"unquarantine"),
+				},
+			},
+			"affected_count": schema.Int64Attribute{
+				Description:         "Number of threats to which the action was applied.",
+				MarkdownDescription: "Number of threats to which the action was applied.",
+				Computed:            true,
+			},
+			"poll_interval": schema.StringAttribute{
+				Description:         "How often to poll the mitigation status (eg: 10s, 1m). [Default: 10s]",
+				MarkdownDescription: "How often to poll the mitigation status (eg: `10s`, `1m`). [Default: `10s`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10s"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+			"threat_ids": schema.ListAttribute{
+				Description:         "IDs of the threats to which the action is applied.",
+				MarkdownDescription: "IDs of the threats to which the action is applied.",
+				Required:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"threats": schema.ListNestedAttribute{
+				Description:         "Result of the action on each threat.",
+				MarkdownDescription: "Result of the action on each threat.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"failed_count": schema.Int64Attribute{
+							Description:         "Number of agents on which the action failed.",
+							MarkdownDescription: "Number of agents on which the action failed.",
+							Computed:            true,
+						},
+						"pending_count": schema.Int64Attribute{
+							Description:         "Number of agents on which the action is still pending.",
+							MarkdownDescription: "Number of agents on which the action is still pending.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "Overall status of the action on the threat.",
+							MarkdownDescription: "Overall status of the action on the threat.",
+							Computed:            true,
+						},
+						"success_count": schema.Int64Attribute{
+							Description:         "Number of agents on which the action succeeded.",
+							MarkdownDescription: "Number of agents on which the action succeeded.",
+							Computed:            true,
+						},
+						"threat_id": schema.StringAttribute{
+							Description:         "ID of the threat.",
+							MarkdownDescription: "ID of the threat.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values which, when changed, cause the resource to be replaced and the action " +
+					"to be applied again.",
+				MarkdownDescription: "Arbitrary values which, when changed, cause the resource to be replaced and the " +
+					"action to be applied again.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "Maximum time to wait for the action to finish on every agent (eg: 15m, 1h). " +
+					"[Default: 15m]",
+				MarkdownDescription: "Maximum time to wait for the action to finish on every agent (eg: `15m`, " +
+					"`1h`). [Default: `15m`]",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("15m"),
+				Validators: []validator.String{
+					validators.DurationIsValid(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ThreatMitigation) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_THREAT_MITIGATION_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *ThreatMitigation) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfThreatMitigation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	interval, diags := plugin.ParseRelativeDuration(ctx, plan.PollInterval.ValueString())
+	resp.Diagnostics.Append(diags...)
+	timeout, diags := plugin.ParseRelativeDuration(ctx, plan.WaitTimeout.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	threatIds := []string{}
+	for _, id := range plan.ThreatIds {
+		threatIds = append(threatIds, id.ValueString())
+	}
+
+	// apply the action
+	action := threatMitigationActions[plan.Action.ValueString()]
+	affected, diags := api.Client().MitigateThreats(ctx, action, threatIds)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.AffectedCount = types.Int64Value(int64(affected))
+	tflog.Info(ctx, "Applied mitigation action to threats", map[string]interface{}{
+		"action":           action,
+		"threats_given":    len(threatIds),
+		"threats_affected": affected,
+	})
+
+	// wait for the action to finish on every agent
+	results, waitDiags := waiter.Wait(ctx, waiter.Config{
+		Description: "threat mitigation",
+		Interval:    interval,
+		Pending:     []string{threatMitigationStatePending},
+		Target:      []string{threatMitigationStateCompleted},
+		Timeout:     timeout,
+	}, func(ctx context.Context) ([]tfThreatMitigationResult, string, diag.Diagnostics) {
+		var diags diag.Diagnostics
+		results := []tfThreatMitigationResult{}
+		state := threatMitigationStateCompleted
+		for _, threatId := range threatIds {
+			actions, getDiags := api.Client().GetThreatMitigationStatus(ctx, threatId)
+			diags.Append(getDiags...)
+			if diags.HasError() {
+				return nil, "", diags
+			}
+			result := threatMitigationResultFromAPI(threatId, action, actions)
+			if result.PendingCount.ValueInt64() > 0 {
+				state = threatMitigationStatePending
+			}
+			results = append(results, result)
+		}
+		return results, state, diags
+	})
+
+	// record the result for each threat
+	var failed int64
+	for _, result := range results {
+		failed += result.FailedCount.ValueInt64()
+	}
+	if results == nil {
+		results = []tfThreatMitigationResult{}
+	}
+	plan.Threats, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: tfThreatMitigationResultAttrTypes},
+		results)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if failed > 0 {
+		resp.Diagnostics.AddWarning("Threat Mitigation Failed On Some Agents", fmt.Sprintf("The %s action failed "+
+			"on %d agents. See the threats attribute for the result on each threat.", plan.Action.ValueString(),
+			failed))
+	}
+
+	// save the plan to the state even if waiting failed so the action is tracked - the resource is marked as
+	// tainted in that case
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+	resp.Diagnostics.Append(waitDiags...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+//
+// A mitigation action cannot be undone once applied so there is nothing to refresh.
+func (r *ThreatMitigation) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+//
+// Only the polling settings can be updated in place so there is nothing to do other than saving them.
+func (r *ThreatMitigation) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfThreatMitigation
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfThreatMitigation
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the plan to the state
+	plan.AffectedCount = state.AffectedCount
+	plan.Threats = state.Threats
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete removes the Terraform resource.
+//
+// Mitigation actions cannot be undone so the resource is simply removed from the state.
+func (r *ThreatMitigation) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// threatMitigationResultFromAPI converts the mitigation status of a threat into the Terraform result for the
+// given API action.
+//
+// Only the status of the given action is included since the threat may have had other actions applied to it in
+// the past.
+func threatMitigationResultFromAPI(threatId, action string,
+	actions []api.ThreatMitigationAction) tfThreatMitigationResult {
+
+	result := tfThreatMitigationResult{
+		FailedCount:  types.Int64Value(0),
+		PendingCount: types.Int64Value(0),
+		Status:       types.StringValue(""),
+		SuccessCount: types.Int64Value(0),
+		ThreatId:     types.StringValue(threatId),
+	}
+	for _, a := range actions {
+		if a.Action != action {
+			continue
+		}
+		result.FailedCount = types.Int64Value(a.FailedCount)
+		result.PendingCount = types.Int64Value(a.PendingCount)
+		result.Status = types.StringValue(a.Status)
+		result.SuccessCount = types.Int64Value(a.SuccessCount)
+	}
+	return result
+}
@@ -0,0 +1,294 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &ThreatNote{}
+	_ resource.ResourceWithConfigure   = &ThreatNote{}
+	_ resource.ResourceWithImportState = &ThreatNote{}
+)
+
+// tfThreatNote defines the Terraform model for a threat note.
+type tfThreatNote struct {
+	CreatedAt types.String `tfsdk:"created_at"`
+	Creator   types.String `tfsdk:"creator"`
+	Id        types.String `tfsdk:"id"`
+	Text      types.String `tfsdk:"text"`
+	ThreatId  types.String `tfsdk:"threat_id"`
+	UpdatedAt types.String `tfsdk:"updated_at"`
+}
+
+// NewThreatNote creates a new ThreatNote object.
+func NewThreatNote() resource.Resource {
+	return &ThreatNote{}
+}
+
+// ThreatNote is a resource used to manage the lifecycle of an analyst note attached to a threat.
+type ThreatNote struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *ThreatNote) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_threat_note"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *ThreatNote) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for attaching an analyst note to a threat.",
+		MarkdownDescription: `This resource is used for attaching an analyst note to a threat.
+
+		Notes are shown alongside the threat in the console, which makes them useful for documenting automated
+		response actions taken against the threat. Changing the text of a note edits it in place. Deleting the
+		resource deletes the note from the threat.
+
+		Existing notes can be imported using an ID in the format ` + "`<threat_id>/<note_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the note was created.",
+				MarkdownDescription: "Timestamp of when the note was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"creator": schema.StringAttribute{
+				Description:         "Name of the user who created the note.",
+				MarkdownDescription: "Name of the user who created the note.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the note.",
+				MarkdownDescription: "ID of the note.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"text": schema.StringAttribute{
+				Description:         "Text of the note.",
+				MarkdownDescription: "Text of the note.",
+				Required:            true,
+			},
+			"threat_id": schema.StringAttribute{
+				Description:         "ID of the threat to which the note is attached.",
+				MarkdownDescription: "ID of the threat to which the note is attached.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the note was last updated.",
+				MarkdownDescription: "Timestamp of when the note was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *ThreatNote) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_THREAT_NOTE_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *ThreatNote) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfThreatNote
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// add the note
+	note, diags := api.Client().AddThreatNote(ctx, plan.ThreatId.ValueString(), plan.Text.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the note to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfThreatNoteFromAPI(ctx, note, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *ThreatNote) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfThreatNote
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the note - if it no longer exists, remove it from the state
+	note, diags := r.findNote(ctx, state.ThreatId.ValueString(), state.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if note == nil {
+		tflog.Debug(ctx, "Threat note no longer exists.", map[string]interface{}{
+			"id":        state.Id.ValueString(),
+			"threat_id": state.ThreatId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfThreatNoteFromAPI(ctx, note, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *ThreatNote) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfThreatNote
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfThreatNote
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the note and then find it again since the API does not return the updated note
+	resp.Diagnostics.Append(api.Client().UpdateThreatNote(ctx, state.ThreatId.ValueString(), state.Id.ValueString(),
+		plan.Text.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	note, diags := r.findNote(ctx, state.ThreatId.ValueString(), state.Id.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if note == nil {
+		resp.Diagnostics.AddError("Threat Note Not Found", fmt.Sprintf("The note was updated but could not be "+
+			"found afterwards.\n\nThreat ID: %s\nNote ID: %s", state.ThreatId.ValueString(), state.Id.ValueString()))
+		return
+	}
+
+	// save the updated note to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfThreatNoteFromAPI(ctx, note, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *ThreatNote) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfThreatNote
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the note
+	resp.Diagnostics.Append(api.Client().DeleteThreatNote(ctx, state.ThreatId.ValueString(),
+		state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Removed threat note", map[string]interface{}{
+		"id":        state.Id.ValueString(),
+		"threat_id": state.ThreatId.ValueString(),
+	})
+}
+
+// ImportState imports an existing threat note into the Terraform state.
+//
+// The API can only find a note through its threat so the import ID must be in the format <threat_id>/<note_id>.
+func (r *ThreatNote) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <threat_id>/<note_id>.\n\nImport ID: %s", req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_THREAT_NOTE_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("threat_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[1])...)
+}
+
+// findNote returns the note with the matching ID on the threat with the matching ID or nil if it does not exist.
+func (r *ThreatNote) findNote(ctx context.Context, threatId, noteId string) (*api.ThreatNote, diag.Diagnostics) {
+	notes, diags := api.Client().FindThreatNotes(ctx, threatId)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for i := range notes {
+		if notes[i].Id == noteId {
+			return &notes[i], diags
+		}
+	}
+	return nil, diags
+}
+
+// tfThreatNoteFromAPI converts an API threat note into a Terraform threat note.
+//
+// The threat ID is copied from the given model since it is always known from the configuration or import ID.
+func tfThreatNoteFromAPI(ctx context.Context, note *api.ThreatNote, model tfThreatNote) tfThreatNote {
+	tfnote := tfThreatNote{
+		CreatedAt: types.StringValue(note.CreatedAt),
+		Creator:   types.StringValue(note.Creator),
+		Id:        types.StringValue(note.Id),
+		Text:      types.StringValue(note.Text),
+		ThreatId:  model.ThreatId,
+		UpdatedAt: types.StringValue(note.UpdatedAt),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API threat note to TF threat note: %+v", tfnote), map[string]interface{}{
+		"api_threat_note": note,
+	})
+	return tfnote
+}
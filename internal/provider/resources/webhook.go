@@ -0,0 +1,373 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &Webhook{}
+	_ resource.ResourceWithConfigure   = &Webhook{}
+	_ resource.ResourceWithImportState = &Webhook{}
+)
+
+// tfWebhook defines the Terraform model for a webhook.
+type tfWebhook struct {
+	CreatedAt      types.String   `tfsdk:"created_at"`
+	DeliveryStatus types.String   `tfsdk:"delivery_status"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	EventTypes     []types.String `tfsdk:"event_types"`
+	Id             types.String   `tfsdk:"id"`
+	LastDeliveryAt types.String   `tfsdk:"last_delivery_at"`
+	Name           types.String   `tfsdk:"name"`
+	ScopeId        types.String   `tfsdk:"scope_id"`
+	ScopeType      types.String   `tfsdk:"scope_type"`
+	Secret         types.String   `tfsdk:"secret"`
+	UpdatedAt      types.String   `tfsdk:"updated_at"`
+	URL            types.String   `tfsdk:"url"`
+}
+
+// NewWebhook creates a new Webhook object.
+func NewWebhook() resource.Resource {
+	return &Webhook{}
+}
+
+// Webhook is a resource used to manage an outbound webhook integration.
+type Webhook struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *Webhook) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *Webhook) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for creating and managing an outbound webhook integration within an " +
+			"account or site.",
+		MarkdownDescription: `This resource is used for creating and managing an outbound webhook integration within an
+			account or site.
+
+		Webhooks deliver events to an external URL, such as a ChatOps channel or SOAR platform. The result of the most
+		recent delivery is refreshed every time the resource is read and is available in ` + "`delivery_status`" + `.
+
+		The secret used to sign deliveries is never returned by the API, so changes made to it outside of Terraform
+		cannot be detected. Existing webhooks can be imported using an ID in the format
+		` + "`<scope_type>/<scope_id>/<webhook_id>`" + `; the secret is not imported and will be updated on the next
+		apply if it is configured.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the webhook was created.",
+				MarkdownDescription: "Timestamp of when the webhook was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"delivery_status": schema.StringAttribute{
+				Description:         "Status of the most recent delivery to the webhook.",
+				MarkdownDescription: "Status of the most recent delivery to the webhook.",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not events are delivered to the webhook. [Default: true]",
+				MarkdownDescription: "Whether or not events are delivered to the webhook. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"event_types": schema.ListAttribute{
+				Description:         "Types of events which are delivered to the webhook (eg: threat_created).",
+				MarkdownDescription: "Types of events which are delivered to the webhook (eg: `threat_created`).",
+				Required:            true,
+				ElementType:         types.StringType,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the webhook.",
+				MarkdownDescription: "ID of the webhook.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_delivery_at": schema.StringAttribute{
+				Description:         "Timestamp of the most recent delivery to the webhook.",
+				MarkdownDescription: "Timestamp of the most recent delivery to the webhook.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the webhook.",
+				MarkdownDescription: "Name of the webhook.",
+				Required:            true,
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the webhook belongs.",
+				MarkdownDescription: "ID of the account or site to which the webhook belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level at which the webhook is created (valid values: account, site).",
+				MarkdownDescription: "Level at which the webhook is created (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"secret": schema.StringAttribute{
+				Description:         "Secret used to sign the deliveries to the webhook. [Default: none]",
+				MarkdownDescription: "Secret used to sign the deliveries to the webhook. [Default: none]",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the webhook was last updated.",
+				MarkdownDescription: "Timestamp of when the webhook was last updated.",
+				Computed:            true,
+			},
+			"url": schema.StringAttribute{
+				Description:         "URL to which events are delivered.",
+				MarkdownDescription: "URL to which events are delivered.",
+				Required:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *Webhook) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_WEBHOOK_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *Webhook) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfWebhook
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.Secret.ValueString())
+
+	// create the webhook
+	webhook, diags := api.Client().CreateWebhook(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the webhook to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfWebhookFromAPI(ctx, webhook, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *Webhook) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfWebhook
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, state.Secret.ValueString())
+
+	// find the webhook - if it no longer exists, remove it from the state
+	queryParams := api.WebhookQueryParams{
+		WebhookIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	webhooks, diags := api.Client().FindWebhooks(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(webhooks) == 0 {
+		tflog.Debug(ctx, "Webhook no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfWebhookFromAPI(ctx, &webhooks[0], state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *Webhook) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfWebhook
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfWebhook
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx = plugin.MaskSecrets(ctx, plan.Secret.ValueString())
+
+	// update the webhook
+	webhook, diags := api.Client().UpdateWebhook(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated webhook to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfWebhookFromAPI(ctx, webhook, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *Webhook) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfWebhook
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the webhook
+	resp.Diagnostics.Append(api.Client().DeleteWebhook(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted webhook", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing webhook into the Terraform state.
+//
+// The API can only find a webhook within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<webhook_id>.
+func (r *Webhook) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<webhook_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_WEBHOOK_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a webhook.
+func (r *Webhook) bodyFromPlan(plan tfWebhook) api.WebhookBody {
+	body := api.WebhookBody{}
+
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		value := plan.Enabled.ValueBool()
+		body.Enabled = &value
+	}
+
+	if plan.EventTypes != nil {
+		body.EventTypes = []string{}
+		for _, e := range plan.EventTypes {
+			body.EventTypes = append(body.EventTypes, e.ValueString())
+		}
+	}
+
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		value := plan.Name.ValueString()
+		body.Name = &value
+	}
+
+	if !plan.Secret.IsNull() && !plan.Secret.IsUnknown() {
+		value := plan.Secret.ValueString()
+		body.Secret = &value
+	}
+
+	if !plan.URL.IsNull() && !plan.URL.IsUnknown() {
+		value := plan.URL.ValueString()
+		body.URL = &value
+	}
+	return body
+}
+
+// tfWebhookFromAPI converts an API webhook into a Terraform webhook.
+//
+// The API does not return the scope or secret of the webhook so they are copied from the given model.
+func tfWebhookFromAPI(ctx context.Context, webhook *api.Webhook, model tfWebhook) tfWebhook {
+	tfwebhook := tfWebhook{
+		CreatedAt:      types.StringValue(webhook.CreatedAt),
+		DeliveryStatus: types.StringValue(webhook.LastDeliveryStatus),
+		Enabled:        types.BoolValue(webhook.Enabled),
+		EventTypes:     []types.String{},
+		Id:             types.StringValue(webhook.Id),
+		LastDeliveryAt: types.StringValue(webhook.LastDeliveryAt),
+		Name:           types.StringValue(webhook.Name),
+		ScopeId:        model.ScopeId,
+		ScopeType:      model.ScopeType,
+		Secret:         model.Secret,
+		UpdatedAt:      types.StringValue(webhook.UpdatedAt),
+		URL:            types.StringValue(webhook.URL),
+	}
+	for _, e := range webhook.EventTypes {
+		tfwebhook.EventTypes = append(tfwebhook.EventTypes, types.StringValue(e))
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API webhook to TF webhook: %+v", tfwebhook), map[string]interface{}{
+		"api_webhook": webhook,
+	})
+	return tfwebhook
+}
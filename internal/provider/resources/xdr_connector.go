@@ -0,0 +1,375 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/data"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/validators"
+)
+
+// ensure implementation satisfied expected interfaces
+var (
+	_ resource.Resource                = &XdrConnector{}
+	_ resource.ResourceWithConfigure   = &XdrConnector{}
+	_ resource.ResourceWithImportState = &XdrConnector{}
+)
+
+// tfXdrConnector defines the Terraform model for an XDR ingestion connector.
+type tfXdrConnector struct {
+	ConnectorType          types.String `tfsdk:"connector_type"`
+	CreatedAt              types.String `tfsdk:"created_at"`
+	CredentialId           types.String `tfsdk:"credential_id"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	Health                 types.String `tfsdk:"health"`
+	HealthMessage          types.String `tfsdk:"health_message"`
+	Id                     types.String `tfsdk:"id"`
+	LastPolledAt           types.String `tfsdk:"last_polled_at"`
+	Name                   types.String `tfsdk:"name"`
+	PollingIntervalMinutes types.Int64  `tfsdk:"polling_interval_minutes"`
+	ScopeId                types.String `tfsdk:"scope_id"`
+	ScopeType              types.String `tfsdk:"scope_type"`
+	UpdatedAt              types.String `tfsdk:"updated_at"`
+}
+
+// NewXdrConnector creates a new XdrConnector object.
+func NewXdrConnector() resource.Resource {
+	return &XdrConnector{}
+}
+
+// XdrConnector is a resource used to manage a connector which ingests data from a third-party product into XDR.
+type XdrConnector struct {
+	data *data.SingularityProvider
+}
+
+// Metadata returns metadata about the resource.
+func (r *XdrConnector) Metadata(ctx context.Context, req resource.MetadataRequest,
+	resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_xdr_connector"
+}
+
+// Schema defines the parameters for the resource's configuration.
+func (r *XdrConnector) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This resource is used for managing a connector which ingests data from a third-party product " +
+			"into XDR within an account or site.",
+		MarkdownDescription: `This resource is used for managing a connector which ingests data from a third-party
+			product into XDR within an account or site.
+
+		The connector polls the third-party product (eg: Okta, CrowdStrike or a firewall vendor) using the stored
+		credential referenced by ` + "`credential_id`" + ` so secrets never need to be part of the Terraform
+		configuration. The health of the connector is refreshed whenever the resource is read and a warning is shown
+		if an enabled connector is unhealthy. Existing connectors can be imported using an ID in the format
+		` + "`<scope_type>/<scope_id>/<connector_id>`" + `.
+		`,
+		Attributes: map[string]schema.Attribute{
+			"connector_type": schema.StringAttribute{
+				Description:         "Type of the connector (eg: okta, crowdstrike, paloalto_firewall).",
+				MarkdownDescription: "Type of the connector (eg: `okta`, `crowdstrike`, `paloalto_firewall`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description:         "Timestamp of when the connector was created.",
+				MarkdownDescription: "Timestamp of when the connector was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"credential_id": schema.StringAttribute{
+				Description:         "ID of the stored credential used to access the third-party product.",
+				MarkdownDescription: "ID of the stored credential used to access the third-party product.",
+				Required:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				Description:         "Whether or not the connector is enabled. [Default: true]",
+				MarkdownDescription: "Whether or not the connector is enabled. [Default: `true`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"health": schema.StringAttribute{
+				Description:         "Health of the connector (eg: healthy, pending, unhealthy).",
+				MarkdownDescription: "Health of the connector (eg: `healthy`, `pending`, `unhealthy`).",
+				Computed:            true,
+			},
+			"health_message": schema.StringAttribute{
+				Description:         "Details about the health of the connector.",
+				MarkdownDescription: "Details about the health of the connector.",
+				Computed:            true,
+			},
+			"id": schema.StringAttribute{
+				Description:         "ID of the connector.",
+				MarkdownDescription: "ID of the connector.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_polled_at": schema.StringAttribute{
+				Description:         "Timestamp of when the connector last polled the third-party product.",
+				MarkdownDescription: "Timestamp of when the connector last polled the third-party product.",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				Description:         "Name of the connector.",
+				MarkdownDescription: "Name of the connector.",
+				Required:            true,
+			},
+			"polling_interval_minutes": schema.Int64Attribute{
+				Description:         "Number of minutes between polls of the third-party product. [Default: 5]",
+				MarkdownDescription: "Number of minutes between polls of the third-party product. [Default: `5`]",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(5),
+			},
+			"scope_id": schema.StringAttribute{
+				Description:         "ID of the account or site to which the connector belongs.",
+				MarkdownDescription: "ID of the account or site to which the connector belongs.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope_type": schema.StringAttribute{
+				Description:         "Level to which the connector belongs (valid values: account, site).",
+				MarkdownDescription: "Level to which the connector belongs (valid values: `account`, `site`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					validators.EnumStringValueOneOf(false, api.SCOPE_ACCOUNT, api.SCOPE_SITE),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Description:         "Timestamp of when the connector was last updated.",
+				MarkdownDescription: "Timestamp of when the connector was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure initializes the configuration for the resource.
+func (r *XdrConnector) Configure(ctx context.Context, req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*data.SingularityProvider)
+	if !ok {
+		expectedType := reflect.TypeOf(&data.SingularityProvider{})
+		msg := fmt.Sprintf("The provider data sent in the request does not match the type expected. This is always an "+
+			"error with the provider and should be reported to the provider developers.\n\nExpected Type: %s\nData Type "+
+			"Received: %T", expectedType, req.ProviderData)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"internal_error_code": plugin.ERR_RESOURCE_XDR_CONNECTOR_CONFIGURE,
+			"expected_type":       fmt.Sprintf("%T", expectedType),
+			"received_type":       fmt.Sprintf("%T", req.ProviderData),
+		})
+		resp.Diagnostics.AddError("Unexpected Configuration Error", msg)
+		return
+	}
+	r.data = providerData
+}
+
+// Create is used to create the Terraform resource.
+func (r *XdrConnector) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// retrieve values from plan
+	var plan tfXdrConnector
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// create the connector
+	connector, diags := api.Client().CreateXdrConnector(ctx, scopeFromModel(plan.ScopeType, plan.ScopeId),
+		r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the connector to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfXdrConnectorFromAPI(ctx, connector, plan))...)
+}
+
+// Read refreshes the current state of the Terraform resource.
+func (r *XdrConnector) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// get the current state
+	var state tfXdrConnector
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// find the connector - if it no longer exists, remove it from the state
+	queryParams := api.XdrConnectorQueryParams{
+		ConnectorIds: []string{state.Id.ValueString()},
+	}
+	if state.ScopeType.ValueString() == api.SCOPE_SITE {
+		queryParams.SiteIds = []string{state.ScopeId.ValueString()}
+	} else {
+		queryParams.AccountIds = []string{state.ScopeId.ValueString()}
+	}
+	connectors, diags := api.Client().FindXdrConnectors(ctx, queryParams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(connectors) == 0 {
+		tflog.Debug(ctx, "XDR connector no longer exists.", map[string]interface{}{
+			"id": state.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	connector := &connectors[0]
+
+	// surface the health of the connector without failing the refresh
+	if connector.Enabled && connector.Health == api.XDR_CONNECTOR_HEALTH_UNHEALTHY {
+		msg := fmt.Sprintf("The XDR connector is unhealthy and may not be ingesting data. Check the stored "+
+			"credential and the connectivity to the third-party product.\n\nConnector: %s\nHealth: %s\nMessage: %s",
+			connector.Id, connector.Health, connector.HealthMessage)
+		tflog.Warn(ctx, msg, map[string]interface{}{
+			"connector_id":   connector.Id,
+			"health_message": connector.HealthMessage,
+		})
+		resp.Diagnostics.AddWarning("XDR Connector Unhealthy", msg)
+	}
+
+	// save refreshed state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfXdrConnectorFromAPI(ctx, connector, state))...)
+}
+
+// Update modifies the Terraform resource in place without destroying it.
+func (r *XdrConnector) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// retrieve values from state
+	var state tfXdrConnector
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// retrieve values from plan
+	var plan tfXdrConnector
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// update the connector
+	connector, diags := api.Client().UpdateXdrConnector(ctx, state.Id.ValueString(), r.bodyFromPlan(plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// save the updated connector to the state
+	resp.Diagnostics.Append(resp.State.Set(ctx, tfXdrConnectorFromAPI(ctx, connector, plan))...)
+}
+
+// Delete removes the Terraform resource.
+func (r *XdrConnector) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// get the current state
+	var state tfXdrConnector
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// delete the connector
+	resp.Diagnostics.Append(api.Client().DeleteXdrConnector(ctx, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, "Deleted XDR connector", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+}
+
+// ImportState imports an existing XDR connector into the Terraform state.
+//
+// The API can only find a connector within its scope so the import ID must be in the format
+// <scope_type>/<scope_id>/<connector_id>.
+func (r *XdrConnector) ImportState(ctx context.Context, req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse) {
+
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || (parts[0] != api.SCOPE_ACCOUNT && parts[0] != api.SCOPE_SITE) || parts[1] == "" ||
+		parts[2] == "" {
+		msg := fmt.Sprintf("The import ID must be in the format <scope_type>/<scope_id>/<connector_id> where the "+
+			"scope type is one of: %s, %s.\n\nImport ID: %s", api.SCOPE_ACCOUNT, api.SCOPE_SITE, req.ID)
+		tflog.Error(ctx, msg, map[string]interface{}{
+			"import_id":           req.ID,
+			"internal_error_code": plugin.ERR_RESOURCE_XDR_CONNECTOR_IMPORT,
+		})
+		resp.Diagnostics.AddError("Invalid Import ID", msg)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_type"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("scope_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tfpath.Root("id"), parts[2])...)
+}
+
+// bodyFromPlan converts the Terraform plan into the API request body for creating or updating a connector.
+func (r *XdrConnector) bodyFromPlan(plan tfXdrConnector) api.XdrConnectorBody {
+	connectorType := plan.ConnectorType.ValueString()
+	credentialId := plan.CredentialId.ValueString()
+	enabled := plan.Enabled.ValueBool()
+	name := plan.Name.ValueString()
+	pollingInterval := plan.PollingIntervalMinutes.ValueInt64()
+	return api.XdrConnectorBody{
+		ConnectorType:          &connectorType,
+		CredentialId:           &credentialId,
+		Enabled:                &enabled,
+		Name:                   &name,
+		PollingIntervalMinutes: &pollingInterval,
+	}
+}
+
+// tfXdrConnectorFromAPI converts an API XDR connector into a Terraform XDR connector.
+//
+// The API does not return the scope of the connector so it is copied from the given model.
+func tfXdrConnectorFromAPI(ctx context.Context, connector *api.XdrConnector, model tfXdrConnector) tfXdrConnector {
+	tfconnector := tfXdrConnector{
+		ConnectorType:          types.StringValue(connector.ConnectorType),
+		CreatedAt:              types.StringValue(connector.CreatedAt),
+		CredentialId:           types.StringValue(connector.CredentialId),
+		Enabled:                types.BoolValue(connector.Enabled),
+		Health:                 types.StringValue(connector.Health),
+		HealthMessage:          types.StringValue(connector.HealthMessage),
+		Id:                     types.StringValue(connector.Id),
+		LastPolledAt:           types.StringValue(connector.LastPolledAt),
+		Name:                   types.StringValue(connector.Name),
+		PollingIntervalMinutes: types.Int64Value(connector.PollingIntervalMinutes),
+		ScopeId:                model.ScopeId,
+		ScopeType:              model.ScopeType,
+		UpdatedAt:              types.StringValue(connector.UpdatedAt),
+	}
+	tflog.Debug(ctx, fmt.Sprintf("converted API XDR connector to TF XDR connector: %+v", tfconnector),
+		map[string]interface{}{
+			"api_xdr_connector": connector,
+		})
+	return tfconnector
+}
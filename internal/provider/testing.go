@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// NewTestProvider creates a new instance of the provider which always uses the given API endpoint and token,
+// ignoring any environment variables and provider configuration.
+//
+// The endpoint may include an "http://" prefix in order to point the provider at a local mock API server (eg: one
+// created with httptest.NewServer or the mock server in tools/mockapi). If httpClient is not nil, it is used for
+// all API queries.
+//
+// Configuring the provider initializes the global REST API client, so tests using it must not run in parallel.
+func NewTestProvider(apiEndpoint, apiToken string, httpClient *http.Client) func() provider.Provider {
+	return func() provider.Provider {
+		return &SingularityProvider{
+			testApiEndpoint: apiEndpoint,
+			testApiToken:    apiToken,
+			testHTTPClient:  httpClient,
+		}
+	}
+}
+
+// NewTestProviderFactories returns provider factories suitable for use as the ProtoV6ProviderFactories of an
+// acceptance test case, using a provider created by NewTestProvider.
+func NewTestProviderFactories(apiEndpoint, apiToken string,
+	httpClient *http.Client) map[string]func() (tfprotov6.ProviderServer, error) {
+
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		plugin.PROVIDER_NAME: providerserver.NewProtocol6WithError(NewTestProvider(apiEndpoint, apiToken,
+			httpClient)()),
+	}
+}
@@ -0,0 +1,54 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ensure implementation satisfied expected interfaces
+var _ validator.String = duration{}
+
+// DurationIsValid returns a validator which ensures that the value given is a valid relative duration
+// (eg: 30d, 2w, 12h).
+func DurationIsValid() validator.String {
+	return duration{}
+}
+
+// duration holds details about the duration validator.
+type duration struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to
+// understand its impact.
+func (v duration) Description(ctx context.Context) string {
+	return "checks that the value given is a valid relative duration"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a
+// practitioner to understand its impact.
+func (v duration) MarkdownDescription(ctx context.Context) string {
+	return "checks that the value given is a valid relative duration"
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and
+// updating `resp` with diagnostics.
+func (v duration) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	_, diags := plugin.ParseRelativeDuration(ctx, req.ConfigValue.ValueString())
+	if diags.HasError() {
+		tflog.Error(ctx, fmt.Sprintf("Attribute validation failed\n\nError: %s\nAttribute: %s",
+			diags[0].Detail(), req.Path.String()), map[string]interface{}{
+			"error":               diags[0].Detail(),
+			"attribute":           req.Path.String(),
+			"internal_error_code": plugin.ERR_VALIDATOR_DURATION,
+		})
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Value Used", diags[0].Detail())
+		return
+	}
+}
@@ -0,0 +1,57 @@
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ensure implementation satisfied expected interfaces
+var _ validator.String = jsonObject{}
+
+// JSONObjectIsValid returns a validator which ensures that the value given is a JSON encoded object
+// (eg: the output of jsonencode()).
+func JSONObjectIsValid() validator.String {
+	return jsonObject{}
+}
+
+// jsonObject holds details about the JSON object validator.
+type jsonObject struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to
+// understand its impact.
+func (v jsonObject) Description(ctx context.Context) string {
+	return "checks that the value given is a JSON encoded object"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a
+// practitioner to understand its impact.
+func (v jsonObject) MarkdownDescription(ctx context.Context) string {
+	return "checks that the value given is a JSON encoded object"
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and
+// updating `resp` with diagnostics.
+func (v jsonObject) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &object); err != nil {
+		msg := fmt.Sprintf("The value given must be a JSON encoded object (eg: the output of jsonencode()).\n\n"+
+			"Error: %s", err.Error())
+		tflog.Error(ctx, fmt.Sprintf("Attribute validation failed\n\n%s\nAttribute: %s", msg, req.Path.String()),
+			map[string]interface{}{
+				"error":               err.Error(),
+				"attribute":           req.Path.String(),
+				"internal_error_code": plugin.ERR_VALIDATOR_JSON_OBJECT,
+			})
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Value Used", msg)
+		return
+	}
+}
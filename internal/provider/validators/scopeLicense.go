@@ -0,0 +1,103 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ensure implementation satisfied expected interfaces
+var _ validator.String = scopeLicense{}
+
+// ScopeIsLicensedFor returns a validator which ensures that the account or site whose ID is given is licensed for
+// the given feature, which is the case when the scope has any of the given bundles or modules.
+//
+// The validator must be applied to a scope ID attribute with a sibling "scope_type" attribute. Groups are not
+// checked. Since the scope must be looked up using the API, nothing is checked until the provider has been
+// configured, which means the check happens at plan time rather than during "terraform validate".
+func ScopeIsLicensedFor(feature string, names ...string) validator.String {
+	return scopeLicense{
+		feature: feature,
+		names:   names,
+	}
+}
+
+// scopeLicense holds details about the scope license validator.
+type scopeLicense struct {
+	// feature is the name of the feature shown in error messages (eg: Firewall Control).
+	feature string
+
+	// names holds the list of bundles and modules, any of which grants the feature.
+	names []string
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to
+// understand its impact.
+func (v scopeLicense) Description(ctx context.Context) string {
+	return fmt.Sprintf("checks that the account or site is licensed for %s", v.feature)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a
+// practitioner to understand its impact.
+func (v scopeLicense) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("checks that the account or site is licensed for %s", v.feature)
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and
+// updating `resp` with diagnostics.
+func (v scopeLicense) ValidateString(ctx context.Context, req validator.StringRequest,
+	resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() || !api.Client().IsInitialized() {
+		return
+	}
+
+	// the scope type is held in a sibling attribute
+	var scopeType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("scope_type"), &scopeType)...)
+	if resp.Diagnostics.HasError() || scopeType.IsUnknown() || scopeType.IsNull() {
+		return
+	}
+
+	// look up the licenses of the scope - if the scope does not exist, leave it to the API to report the error
+	scopeId := req.ConfigValue.ValueString()
+	var licensed bool
+	switch scopeType.ValueString() {
+	case api.SCOPE_ACCOUNT:
+		accounts, diags := api.Client().FindAccounts(ctx, api.AccountQueryParams{AccountIds: []string{scopeId}})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() || len(accounts) == 0 {
+			return
+		}
+		licensed = accounts[0].Licenses.Includes(v.names...)
+	case api.SCOPE_SITE:
+		sites, diags := api.Client().FindSites(ctx, api.SiteQueryParams{SiteIds: []string{scopeId}})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() || len(sites) == 0 {
+			return
+		}
+		licensed = sites[0].Licenses.Includes(v.names...)
+	default:
+		return
+	}
+	if licensed {
+		return
+	}
+
+	msg := fmt.Sprintf("The %s with ID %s is not licensed for %s. The %s must have one of the following bundles or "+
+		"modules: %s", scopeType.ValueString(), scopeId, v.feature, scopeType.ValueString(), strings.Join(v.names, ", "))
+	tflog.Error(ctx, fmt.Sprintf("Attribute validation failed\n\nError: %s\nAttribute: %s",
+		msg, req.Path.String()), map[string]interface{}{
+		"error":               msg,
+		"attribute":           req.Path.String(),
+		"feature":             v.feature,
+		"internal_error_code": plugin.ERR_VALIDATOR_SCOPE_LICENSE,
+	})
+	resp.Diagnostics.AddAttributeError(req.Path, "Module Not Licensed", msg)
+}
@@ -0,0 +1,55 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ensure implementation satisfied expected interfaces
+var _ validator.String = timestamp{}
+
+// TimestampIsValid returns a validator which ensures that any relative timestamp given (eg: -24h, -7d) contains
+// a valid relative duration. Absolute timestamps are passed through to the API unchanged.
+func TimestampIsValid() validator.String {
+	return timestamp{}
+}
+
+// timestamp holds details about the timestamp validator.
+type timestamp struct{}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to
+// understand its impact.
+func (v timestamp) Description(ctx context.Context) string {
+	return "checks that the value given is a timestamp or a valid relative timestamp"
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a
+// practitioner to understand its impact.
+func (v timestamp) MarkdownDescription(ctx context.Context) string {
+	return "checks that the value given is a timestamp or a valid relative timestamp"
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and
+// updating `resp` with diagnostics.
+func (v timestamp) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	_, diags := plugin.ResolveTimestamp(ctx, req.ConfigValue.ValueString(), time.Now())
+	if diags.HasError() {
+		tflog.Error(ctx, fmt.Sprintf("Attribute validation failed\n\nError: %s\nAttribute: %s",
+			diags[0].Detail(), req.Path.String()), map[string]interface{}{
+			"error":               diags[0].Detail(),
+			"attribute":           req.Path.String(),
+			"internal_error_code": plugin.ERR_VALIDATOR_TIMESTAMP,
+		})
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Value Used", diags[0].Detail())
+		return
+	}
+}
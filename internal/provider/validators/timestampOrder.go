@@ -0,0 +1,119 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ensure implementation satisfied expected interfaces
+var _ validator.String = timestampOrder{}
+
+// TimestampIsBefore returns a validator which ensures that the timestamp or relative timestamp given is before the
+// timestamp held in each of the given sibling attributes. If allowEqual is true, the timestamps may also be equal.
+//
+// The validator is meant for the lower bound of a time range filter (eg: updated_after) so that a range which can
+// never match anything is reported at plan time rather than silently returning no results. Values which are not
+// RFC 3339 timestamps once resolved are not compared and are left for the API to check.
+func TimestampIsBefore(allowEqual bool, siblings ...string) validator.String {
+	return timestampOrder{
+		allowEqual: allowEqual,
+		siblings:   siblings,
+	}
+}
+
+// timestampOrder holds details about the timestamp order validator.
+type timestampOrder struct {
+	// allowEqual indicates whether or not the timestamps may be equal.
+	allowEqual bool
+
+	// siblings holds the names of the sibling attributes holding the upper bounds.
+	siblings []string
+}
+
+// Description returns a plain text description of the validator's behavior, suitable for a practitioner to
+// understand its impact.
+func (v timestampOrder) Description(ctx context.Context) string {
+	if v.allowEqual {
+		return fmt.Sprintf("checks that the timestamp is at or before the timestamp given in %v", v.siblings)
+	}
+	return fmt.Sprintf("checks that the timestamp is before the timestamp given in %v", v.siblings)
+}
+
+// MarkdownDescription returns a markdown formatted description of the validator's behavior, suitable for a
+// practitioner to understand its impact.
+func (v timestampOrder) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// Validate runs the main validation logic of the validator, reading configuration data out of `req` and
+// updating `resp` with diagnostics.
+func (v timestampOrder) ValidateString(ctx context.Context, req validator.StringRequest,
+	resp *validator.StringResponse) {
+
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+	// both timestamps are resolved against the same time so that relative timestamps can be compared
+	now := time.Now()
+	lower, ok := resolveTimestamp(ctx, req.ConfigValue.ValueString(), now)
+	if !ok {
+		return
+	}
+
+	for _, sibling := range v.siblings {
+		var value types.String
+		siblingPath := req.Path.ParentPath().AtName(sibling)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, siblingPath, &value)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if value.IsUnknown() || value.IsNull() {
+			continue
+		}
+		upper, ok := resolveTimestamp(ctx, value.ValueString(), now)
+		if !ok {
+			continue
+		}
+
+		if lower.Before(upper) || (v.allowEqual && lower.Equal(upper)) {
+			continue
+		}
+		order := "before"
+		if v.allowEqual {
+			order = "at or before"
+		}
+		msg := fmt.Sprintf("The timestamp given must be %s the timestamp given for %s, otherwise the filter "+
+			"can never match anything.\n\nValue: %s (%s)\n%s: %s (%s)", order, siblingPath.String(),
+			req.ConfigValue.ValueString(), lower.Format(time.RFC3339), sibling, value.ValueString(),
+			upper.Format(time.RFC3339))
+		tflog.Error(ctx, fmt.Sprintf("Attribute validation failed\n\n%s\nAttribute: %s", msg, req.Path.String()),
+			map[string]interface{}{
+				"attribute":           req.Path.String(),
+				"sibling":             siblingPath.String(),
+				"internal_error_code": plugin.ERR_VALIDATOR_TIMESTAMP_ORDER,
+			})
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Timestamp Range", msg)
+	}
+}
+
+// resolveTimestamp resolves the given timestamp or relative timestamp into a time, using the given reference time for
+// relative timestamps.
+//
+// If the timestamp cannot be resolved, false is returned. Invalid values are reported by TimestampIsValid.
+func resolveTimestamp(ctx context.Context, value string, reference time.Time) (time.Time, bool) {
+	resolved, diags := plugin.ResolveTimestamp(ctx, value, reference)
+	if diags.HasError() {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, resolved)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,3 @@
+// Package selftest verifies that a provider binary was built correctly and can talk to the API before it is rolled
+// out (eg: when mirroring the provider into an air-gapped environment).
+package selftest
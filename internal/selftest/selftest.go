@@ -0,0 +1,118 @@
+package selftest
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/mockapi"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+)
+
+// ENDPOINT_TIMEOUT is the maximum time to wait for the endpoint being checked to respond.
+const ENDPOINT_TIMEOUT = 15 * time.Second
+
+// fixtures holds the canned API responses served by the mock API server.
+//
+//go:embed fixtures
+var fixtures embed.FS
+
+// check is a single self-test check which returns a short description of what was verified or an error.
+type check struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+// Run executes every self-test check, writing the result of each one to the given writer, and returns whether or
+// not all of them passed.
+//
+// The endpoint is only checked for reachability if one is given. Any HTTP response counts as reachable since no
+// API token is used.
+func Run(ctx context.Context, w io.Writer, endpoint string) bool {
+	checks := []check{
+		{name: "build info", run: checkBuildInfo},
+		{name: "mock API", run: checkMockAPI},
+	}
+	if endpoint != "" {
+		checks = append(checks, check{
+			name: "endpoint",
+			run: func(ctx context.Context) (string, error) {
+				return checkEndpoint(ctx, endpoint)
+			},
+		})
+	}
+
+	passed := true
+	for _, c := range checks {
+		result, err := c.run(ctx)
+		if err != nil {
+			fmt.Fprintf(w, "FAIL  %s: %s\n", c.name, err.Error())
+			passed = false
+			continue
+		}
+		fmt.Fprintf(w, "PASS  %s: %s\n", c.name, result)
+	}
+	return passed
+}
+
+// checkBuildInfo verifies that the version and commit were embedded into the binary when it was built.
+func checkBuildInfo(ctx context.Context) (string, error) {
+	if plugin.Version == "" || plugin.Build == "" {
+		return "", fmt.Errorf("the binary was built without version information (version: %q, commit: %q)",
+			plugin.Version, plugin.Build)
+	}
+	return fmt.Sprintf("version %s (commit %s)", plugin.Version, plugin.Build), nil
+}
+
+// checkEndpoint verifies that the given console endpoint responds to HTTP requests.
+func checkEndpoint(ctx context.Context, endpoint string) (string, error) {
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		url = "https://" + url
+	}
+	url += api.API_BASE_URI + "/system/status"
+
+	ctx, cancel := context.WithTimeout(ctx, ENDPOINT_TIMEOUT)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("console is not reachable: %s", err.Error())
+	}
+	resp.Body.Close()
+	return fmt.Sprintf("%s responded with HTTP %d", url, resp.StatusCode), nil
+}
+
+// checkMockAPI exercises the API client end-to-end against an in-process mock API server.
+func checkMockAPI(ctx context.Context) (string, error) {
+	root, err := fs.Sub(fixtures, "fixtures")
+	if err != nil {
+		return "", err
+	}
+	server := httptest.NewServer(mockapi.NewHandler(root, nil))
+	defer server.Close()
+	api.Client().Init(server.URL, "self-test")
+	api.Client().SetHTTPClient(server.Client())
+
+	if diags := api.Client().GetSystemStatus(ctx); diags.HasError() {
+		return "", fmt.Errorf("%s: %s", diags[0].Summary(), diags[0].Detail())
+	}
+	info, diags := api.Client().GetSystemInfo(ctx)
+	if diags.HasError() {
+		return "", fmt.Errorf("%s: %s", diags[0].Summary(), diags[0].Detail())
+	}
+	if info.Build != "self-test" || info.Release != "0.0.0" {
+		return "", fmt.Errorf("unexpected system info returned by the mock API: %+v", *info)
+	}
+	return fmt.Sprintf("queried %s", server.URL), nil
+}
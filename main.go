@@ -3,23 +3,51 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/api"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/importer"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
 	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/selftest"
 )
 
 func main() {
-	var debug bool
+	var debug, generateImports, selfTest bool
+	var scopeType, scopeId, output, selfTestEndpoint string
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&generateImports, "generate-imports", false, "write import blocks and resource skeletons for the "+
+		"existing sites, groups and exclusions in a scope and exit (uses SINGULARITY_API_ENDPOINT and "+
+		"SINGULARITY_API_TOKEN)")
+	flag.StringVar(&scopeType, "scope-type", api.SCOPE_ACCOUNT, "level of the scope used with -generate-imports "+
+		"(account or site)")
+	flag.StringVar(&scopeId, "scope-id", "", "ID of the account or site used with -generate-imports")
+	flag.StringVar(&output, "output", "", "file to which -generate-imports writes the configuration (default: stdout)")
+	flag.BoolVar(&selfTest, "self-test", false, "verify the build information of the binary and exercise the API "+
+		"client against an in-process mock API server and exit")
+	flag.StringVar(&selfTestEndpoint, "self-test-endpoint", "", "console hostname or URL which -self-test also checks "+
+		"is reachable")
 	flag.Parse()
 
+	if generateImports {
+		os.Exit(runGenerateImports(api.Scope{Id: scopeId, Type: scopeType}, output))
+	}
+	if selfTest {
+		if !selftest.Run(context.Background(), os.Stdout, selfTestEndpoint) {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	opts := providerserver.ServeOpts{
 		Address:         plugin.PROVIDER_ADDRESS,
 		Debug:           debug,
-		ProtocolVersion: 6,
+		ProtocolVersion: plugin.PROTOCOL_VERSION,
 	}
 
 	err := providerserver.Serve(context.Background(), provider.New(), opts)
@@ -27,3 +55,36 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// runGenerateImports writes import blocks for the objects in the given scope to the output file, or standard output
+// if no file is given, and returns the exit code for the process.
+func runGenerateImports(scope api.Scope, output string) int {
+	apiEndpoint := os.Getenv("SINGULARITY_API_ENDPOINT")
+	apiToken := os.Getenv("SINGULARITY_API_TOKEN")
+	if apiEndpoint == "" || apiToken == "" || scope.Id == "" {
+		fmt.Fprintln(os.Stderr, "-generate-imports requires -scope-id and the SINGULARITY_API_ENDPOINT and "+
+			"SINGULARITY_API_TOKEN environment variables")
+		return 2
+	}
+	api.Client().Init(apiEndpoint, apiToken)
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to create output file: %s\n", err.Error())
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+
+	diags := importer.Generate(context.Background(), w, scope)
+	for _, d := range diags {
+		fmt.Fprintf(os.Stderr, "%s: %s\n\n%s\n", d.Severity(), d.Summary(), d.Detail())
+	}
+	if diags.HasError() {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,486 @@
+//go:build integration
+
+// Package k8sintegration holds the end-to-end test of the Singularity Agent for Kubernetes workflow in
+// examples/tests/k8s.
+//
+// The test downloads a package from the mock API server with singularity_package_download, loads its images into a
+// Docker daemon with singularity_k8s_agent_package_loader and pushes them to a registry with
+// singularity_k8s_agent_registry_copy. Nothing touches a real console or the local Docker image cache:
+//
+//   - a Docker-in-Docker daemon is started as a container on the local Docker host and used for every image
+//     operation
+//   - a registry container is started and used as the push destination
+//   - the package is generated by tagging a small image as the agent and helper images and saving them to an archive
+//     which is served by the mock API server along with a matching package fixture
+//
+// The provider is built from the current source and used through a Terraform CLI configuration file with a
+// development override, so "terraform" must be in the PATH (or given with -terraform) and the local Docker host must
+// allow privileged containers. The test is only built with the integration build tag:
+//
+//	go test -tags integration ./tools/k8sintegration
+//
+// Every container, network and temporary file is removed when the test finishes unless -keep is given (eg:
+// go test -tags integration ./tools/k8sintegration -args -keep).
+package k8sintegration
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/mockapi"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/plugin"
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/provider/resources"
+)
+
+// IDs of the site and package served by the mock API server.
+const (
+	SITE_ID    = "1000000000000000010"
+	PACKAGE_ID = "1000000000000001000"
+)
+
+// PACKAGE_VERSION is the version of the package served by the mock API server, which the tests check for.
+const PACKAGE_VERSION = "23.3.1.1"
+
+// IMAGE_TAG is the tag given to the agent and helper images in the generated package.
+const IMAGE_TAG = "test"
+
+// REPO_PATH is the repository path within the registry to which the provider pushes the images.
+const REPO_PATH = "integration/k8s-agent"
+
+// READY_TIMEOUT is the maximum time to wait for the Docker-in-Docker daemon and registry to start.
+const READY_TIMEOUT = 2 * time.Minute
+
+// command line options (given after -args)
+var (
+	baseImage = flag.String("base-image", "busybox:1.36", "image tagged as the agent and helper images in the "+
+		"generated package")
+	dindImage = flag.String("dind-image", "docker:23-dind", "Docker-in-Docker image used for the daemon into "+
+		"which the package is loaded")
+	keep          = flag.Bool("keep", false, "keep the containers, network and temporary files for debugging")
+	registryImage = flag.String("registry-image", "registry:2", "image used for the destination registry")
+	terraform     = flag.String("terraform", "terraform", "path to the Terraform CLI")
+)
+
+// TestK8sAgentPackageWorkflow downloads, loads and pushes a generated package with the provider.
+func TestK8sAgentPackageWorkflow(t *testing.T) {
+	ctx := context.Background()
+	e := &environment{t: t}
+	if *keep {
+		workDir, err := os.MkdirTemp("", "singularity-k8s-integration-")
+		if err != nil {
+			t.Fatalf("failed to create working directory: %s", err.Error())
+		}
+		e.workDir = workDir
+	} else {
+		e.workDir = t.TempDir()
+	}
+	t.Cleanup(func() { e.cleanup(ctx) })
+
+	// start the daemon and registry the test runs against
+	if err := e.startContainers(ctx); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// generate the package and serve it from the mock API server
+	if err := e.writeFixtures(ctx); err != nil {
+		t.Fatal(err.Error())
+	}
+	server := httptest.NewServer(mockapi.NewHandler(os.DirFS(filepath.Join(e.workDir, "fixtures")), nil))
+	t.Cleanup(server.Close)
+
+	// download, load and push the package with the provider
+	if err := e.terraformTest(ctx, server.URL); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// the registry must have received the images pushed by the provider
+	for _, image := range []string{resources.DOCKER_IMAGE_S1_AGENT, resources.DOCKER_IMAGE_S1_HELPER} {
+		if err := e.checkRegistry(fmt.Sprintf("%s/%s", REPO_PATH, image)); err != nil {
+			t.Error(err.Error())
+		}
+	}
+}
+
+// environment holds the resources created for the test.
+type environment struct {
+	// containerIds holds the IDs of the containers to remove when cleaning up.
+	containerIds []string
+
+	// dind is the client for the Docker-in-Docker daemon.
+	dind *client.Client
+
+	// dindHost is the URL of the Docker-in-Docker daemon on the local host.
+	dindHost string
+
+	// host is the client for the local Docker host.
+	host *client.Client
+
+	// networkId is the ID of the network shared by the containers.
+	networkId string
+
+	// registryHost is the address of the registry on the local host.
+	registryHost string
+
+	// t is the test using the environment.
+	t *testing.T
+
+	// workDir is the temporary directory holding the provider, fixtures and downloaded package.
+	workDir string
+}
+
+// startContainers starts the registry and Docker-in-Docker daemon on a new network and waits for both of them to be
+// ready.
+func (e *environment) startContainers(ctx context.Context) error {
+	var err error
+	e.host, err = client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to connect to the local Docker host: %w", err)
+	}
+
+	prefix := filepath.Base(e.workDir)
+	created, err := e.host.NetworkCreate(ctx, prefix, types.NetworkCreate{})
+	if err != nil {
+		return fmt.Errorf("failed to create network: %w", err)
+	}
+	e.networkId = created.ID
+
+	// the provider pushes to the registry using the published port
+	registryPort, err := e.startContainer(ctx, prefix, containerSpec{
+		alias: "registry",
+		image: *registryImage,
+		port:  "5000/tcp",
+	})
+	if err != nil {
+		return err
+	}
+	e.registryHost = fmt.Sprintf("127.0.0.1:%s", registryPort)
+
+	// TLS is disabled so that the daemon listens on plain TCP
+	dindPort, err := e.startContainer(ctx, prefix, containerSpec{
+		alias:      "dind",
+		env:        []string{"DOCKER_TLS_CERTDIR="},
+		image:      *dindImage,
+		port:       "2375/tcp",
+		privileged: true,
+	})
+	if err != nil {
+		return err
+	}
+	e.dindHost = fmt.Sprintf("tcp://127.0.0.1:%s", dindPort)
+	e.dind, err = client.NewClientWithOpts(client.WithHost(e.dindHost), client.WithAPIVersionNegotiation())
+	if err != nil {
+		return fmt.Errorf("failed to create client for the Docker-in-Docker daemon: %w", err)
+	}
+
+	// wait for both of them to respond
+	deadline := time.Now().Add(READY_TIMEOUT)
+	for {
+		_, pingErr := e.dind.Ping(ctx)
+		resp, httpErr := http.Get(fmt.Sprintf("http://%s/v2/", e.registryHost))
+		if httpErr == nil {
+			resp.Body.Close()
+		}
+		if pingErr == nil && httpErr == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Docker-in-Docker daemon or registry did not start within %s (daemon: %v, "+
+				"registry: %v)", READY_TIMEOUT, pingErr, httpErr)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+// containerSpec describes a container started for the test.
+type containerSpec struct {
+	// alias is the hostname of the container on the test network.
+	alias string
+
+	// env holds the environment variables set in the container.
+	env []string
+
+	// image is the image to run.
+	image string
+
+	// port is the container port to publish on the local host (eg: 5000/tcp).
+	port string
+
+	// privileged indicates whether or not the container runs in privileged mode.
+	privileged bool
+}
+
+// startContainer pulls the image for the given container, starts it on the test network and returns the local host
+// port to which its port is published.
+func (e *environment) startContainer(ctx context.Context, prefix string, spec containerSpec) (string, error) {
+	name := fmt.Sprintf("%s-%s", prefix, spec.alias)
+	e.t.Logf("starting %s (%s)", name, spec.image)
+	pull, err := e.host.ImagePull(ctx, spec.image, types.ImagePullOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to pull %s: %w", spec.image, err)
+	}
+	if err := drain(pull); err != nil {
+		return "", fmt.Errorf("failed to pull %s: %w", spec.image, err)
+	}
+
+	containerPort := nat.Port(spec.port)
+	created, err := e.host.ContainerCreate(ctx,
+		&container.Config{
+			Env:          spec.env,
+			ExposedPorts: nat.PortSet{containerPort: struct{}{}},
+			Image:        spec.image,
+		},
+		&container.HostConfig{
+			PortBindings: nat.PortMap{containerPort: []nat.PortBinding{{HostIP: "127.0.0.1"}}},
+			Privileged:   spec.privileged,
+		},
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				e.networkId: {Aliases: []string{spec.alias}},
+			},
+		}, nil, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	e.containerIds = append(e.containerIds, created.ID)
+	if err := e.host.ContainerStart(ctx, created.ID, types.ContainerStartOptions{}); err != nil {
+		return "", fmt.Errorf("failed to start %s: %w", name, err)
+	}
+
+	details, err := e.host.ContainerInspect(ctx, created.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect %s: %w", name, err)
+	}
+	bindings := details.NetworkSettings.Ports[containerPort]
+	if len(bindings) == 0 {
+		return "", fmt.Errorf("port %s of %s was not published", spec.port, name)
+	}
+	return bindings[0].HostPort, nil
+}
+
+// writeFixtures generates the package archive from the base image and writes it, along with the package details, as
+// fixtures for the mock API server.
+//
+// The images are untagged in the Docker-in-Docker daemon once they have been saved so that the loader has to load
+// them from the package.
+func (e *environment) writeFixtures(ctx context.Context) error {
+	e.t.Logf("generating package from %s", *baseImage)
+	pull, err := e.dind.ImagePull(ctx, *baseImage, types.ImagePullOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to pull %s: %w", *baseImage, err)
+	}
+	if err := drain(pull); err != nil {
+		return fmt.Errorf("failed to pull %s: %w", *baseImage, err)
+	}
+	images := packageImages()
+	for _, image := range images {
+		if err := e.dind.ImageTag(ctx, *baseImage, image); err != nil {
+			return fmt.Errorf("failed to tag %s: %w", image, err)
+		}
+	}
+
+	// save the images as the package download
+	packageFile := filepath.Join(e.workDir, "fixtures", "GET", "update", "agent", "download", SITE_ID, PACKAGE_ID)
+	if err := os.MkdirAll(filepath.Dir(packageFile), 0755); err != nil {
+		return fmt.Errorf("failed to create fixtures directory: %w", err)
+	}
+	archive, err := e.dind.ImageSave(ctx, images)
+	if err != nil {
+		return fmt.Errorf("failed to save package images: %w", err)
+	}
+	defer archive.Close()
+	file, err := os.Create(packageFile)
+	if err != nil {
+		return fmt.Errorf("failed to create package file: %w", err)
+	}
+	defer file.Close()
+	hash := sha1.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), archive)
+	if err != nil {
+		return fmt.Errorf("failed to write package file: %w", err)
+	}
+	for _, image := range images {
+		if _, err := e.dind.ImageRemove(ctx, image, types.ImageRemoveOptions{}); err != nil {
+			return fmt.Errorf("failed to untag %s: %w", image, err)
+		}
+	}
+
+	// the package details must match the generated archive for the download to be accepted
+	packages, err := json.MarshalIndent(map[string]interface{}{
+		"data": []map[string]interface{}{
+			{
+				"createdAt":     "2023-01-01T00:00:00.000000Z",
+				"fileExtension": ".tar",
+				"fileName":      "SentinelOne_K8s_Agent.tar",
+				"fileSize":      size,
+				"id":            PACKAGE_ID,
+				"majorVersion":  "23.3",
+				"minorVersion":  "GA",
+				"osArch":        "64 bit",
+				"osType":        "linux",
+				"packageType":   "AgentAndRanger",
+				"platformType":  "linux_k8s",
+				"scopeLevel":    "global",
+				"sha1":          hex.EncodeToString(hash.Sum(nil)),
+				"status":        "ga",
+				"updatedAt":     "2023-01-01T00:00:00.000000Z",
+				"version":       PACKAGE_VERSION,
+			},
+		},
+		"pagination": map[string]interface{}{
+			"nextCursor": nil,
+			"totalItems": 1,
+		},
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode package fixture: %w", err)
+	}
+	packagesFile := filepath.Join(e.workDir, "fixtures", "GET", "update", "agent", "packages.json")
+	if err := os.WriteFile(packagesFile, packages, 0644); err != nil {
+		return fmt.Errorf("failed to write package fixture: %w", err)
+	}
+	return nil
+}
+
+// terraformTest builds the provider and runs "terraform test" in examples/tests/k8s against the mock API server at
+// the given URL, the Docker-in-Docker daemon and the registry.
+func (e *environment) terraformTest(ctx context.Context, apiEndpoint string) error {
+	repoRoot, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		return fmt.Errorf("failed to find the root of the repository: %w", err)
+	}
+
+	// build the provider and point Terraform at it
+	binDir := filepath.Join(e.workDir, "bin")
+	build := exec.CommandContext(ctx, "go", "build", "-o", filepath.Join(binDir,
+		"terraform-provider-"+plugin.PROVIDER_NAME), ".")
+	build.Dir = repoRoot
+	if output, err := build.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to build provider: %w\n%s", err, output)
+	}
+	cliConfig := filepath.Join(e.workDir, "terraformrc")
+	source := strings.TrimPrefix(plugin.PROVIDER_ADDRESS, "registry.terraform.io/")
+	if err := os.WriteFile(cliConfig, []byte(fmt.Sprintf("provider_installation {\n  dev_overrides {\n"+
+		"    %q = %q\n  }\n  direct {}\n}\n", source, binDir)), 0644); err != nil {
+		return fmt.Errorf("failed to write Terraform CLI configuration: %w", err)
+	}
+
+	// run the tests
+	e.t.Log("running terraform test in examples/tests/k8s")
+	test := exec.CommandContext(ctx, *terraform, "test")
+	test.Dir = filepath.Join(repoRoot, "examples", "tests", "k8s")
+	test.Env = append(os.Environ(),
+		"TF_CLI_CONFIG_FILE="+cliConfig,
+		"SINGULARITY_API_ENDPOINT="+apiEndpoint,
+		"SINGULARITY_API_TOKEN=mock-api-token",
+		"TF_VAR_docker_host="+e.dindHost,
+		"TF_VAR_local_folder="+filepath.Join(e.workDir, "download"),
+		"TF_VAR_package_id="+PACKAGE_ID,
+		"TF_VAR_registry="+e.registryHost,
+		"TF_VAR_repo_path="+REPO_PATH,
+		"TF_VAR_site_id="+SITE_ID,
+	)
+	output, err := test.CombinedOutput()
+	e.t.Logf("terraform test output:\n%s", output)
+	if err != nil {
+		return fmt.Errorf("terraform test failed: %w", err)
+	}
+	return nil
+}
+
+// checkRegistry checks that the registry has the given repository with the tag of the package images.
+func (e *environment) checkRegistry(repo string) error {
+	resp, err := http.Get(fmt.Sprintf("http://%s/v2/%s/tags/list", e.registryHost, repo))
+	if err != nil {
+		return fmt.Errorf("failed to list tags of %s in the registry: %w", repo, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("registry does not have %s (HTTP %d)", repo, resp.StatusCode)
+	}
+	var tags struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tags); err != nil {
+		return fmt.Errorf("failed to parse tags of %s in the registry: %w", repo, err)
+	}
+	for _, tag := range tags.Tags {
+		if tag == IMAGE_TAG {
+			return nil
+		}
+	}
+	return fmt.Errorf("registry does not have %s:%s (tags: %v)", repo, IMAGE_TAG, tags.Tags)
+}
+
+// cleanup removes the containers and network created for the test. The temporary files are removed by the testing
+// package.
+func (e *environment) cleanup(ctx context.Context) {
+	if *keep {
+		e.t.Logf("keeping test environment (Docker-in-Docker daemon: %s, registry: %s, files: %s)", e.dindHost,
+			e.registryHost, e.workDir)
+		return
+	}
+	if e.host == nil {
+		return
+	}
+	for _, id := range e.containerIds {
+		if err := e.host.ContainerRemove(ctx, id, types.ContainerRemoveOptions{
+			Force:         true,
+			RemoveVolumes: true,
+		}); err != nil {
+			e.t.Logf("failed to remove container %s: %s", id, err.Error())
+		}
+	}
+	if e.networkId != "" {
+		if err := e.host.NetworkRemove(ctx, e.networkId); err != nil {
+			e.t.Logf("failed to remove network %s: %s", e.networkId, err.Error())
+		}
+	}
+}
+
+// packageImages returns the names of the agent and helper images in the generated package.
+func packageImages() []string {
+	return []string{
+		fmt.Sprintf("%s/%s:%s", resources.DOCKER_IMAGE_BASE_REPOSITORY, resources.DOCKER_IMAGE_S1_AGENT, IMAGE_TAG),
+		fmt.Sprintf("%s/%s:%s", resources.DOCKER_IMAGE_BASE_REPOSITORY, resources.DOCKER_IMAGE_S1_HELPER, IMAGE_TAG),
+	}
+}
+
+// drain reads the given stream of progress messages from the Docker API until it is closed, returning any error
+// reported in the stream.
+func drain(stream io.ReadCloser) error {
+	defer stream.Close()
+	decoder := json.NewDecoder(stream)
+	for {
+		var message struct {
+			Error string `json:"error"`
+		}
+		if err := decoder.Decode(&message); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if message.Error != "" {
+			return errors.New(message.Error)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+// Command mockapi serves canned SentinelOne Singularity API responses from a directory of JSON fixtures so that the
+// provider can be exercised by Terraform tests without access to a real console.
+//
+// Fixtures are looked up by HTTP method and URI relative to the API base URI. For example, a GET request for
+// /web/api/v2.1/sites is answered with the contents of <fixtures>/GET/sites.json and a PUT request for
+// /web/api/v2.1/sites/123/policy is answered with <fixtures>/PUT/sites/123/policy.json. Binary responses (eg: package
+// downloads) are served from a file named after the URI itself without the .json extension. Requests which have no
+// matching fixture receive a 404 response in the same format as the real API.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/joshhogle-at-s1/terraform-provider-sentinelone-singularity/internal/mockapi"
+)
+
+func main() {
+	var listen, fixtures string
+
+	flag.StringVar(&listen, "listen", "127.0.0.1:8080", "address on which to listen for requests")
+	flag.StringVar(&fixtures, "fixtures", "fixtures", "directory containing the JSON fixtures to serve")
+	flag.Parse()
+
+	log.Printf("serving fixtures from %s on http://%s", fixtures, listen)
+	handler := mockapi.NewHandler(os.DirFS(fixtures), log.Default())
+	if err := http.ListenAndServe(listen, handler); err != nil {
+		log.Fatal(err.Error())
+	}
+}